@@ -0,0 +1,111 @@
+package entities
+
+import "time"
+
+type (
+	// ApiClient is a partner allowed to call the API. Secret is only filled in
+	// when the client is created or its secret rotated. After a rotation the
+	// previous secret keeps working until PreviousSecretExpiresAt, so the
+	// client can switch over without downtime.
+	ApiClient struct {
+		Id                      int64      `json:"id"`
+		TenantId                int64      `json:"tenant_id"`
+		ClientKey               string     `json:"client_key"`
+		Name                    string     `json:"name"`
+		Scopes                  []ApiScope `json:"scopes"`
+		RateLimit               RateLimit  `json:"rate_limit"`
+		Secret                  string     `json:"secret,omitempty"`
+		PreviousSecret          string     `json:"-"`
+		PreviousSecretExpiresAt time.Time  `json:"previous_secret_expires_at,omitempty"`
+		IsActive                bool       `json:"is_active"`
+		CreatedAt               time.Time  `json:"created_at"`
+		UpdatedAt               time.Time  `json:"updated_at,omitempty"`
+	}
+
+	// ClientCredentials are the secrets a request of the client may be signed
+	// with right now and what the client may do
+	ClientCredentials struct {
+		ClientKey string
+		TenantId  int64
+		Secrets   []string
+		Scopes    []ApiScope
+		RateLimit RateLimit
+	}
+
+	// RateLimit is a token bucket per client and route: Burst requests at once,
+	// refilled at RequestsPerMinute. A zero limit falls back to the default of
+	// the route.
+	RateLimit struct {
+		RequestsPerMinute int `json:"requests_per_minute"`
+		Burst             int `json:"burst"`
+	}
+
+	// RateLimitResult is what taking a token from a bucket gave. RetryAfter is
+	// how long until the next token when none was left.
+	RateLimitResult struct {
+		Allowed    bool
+		Remaining  int
+		RetryAfter time.Duration
+	}
+
+	// ApiScope is what a client may do. ScopeAdmin allows everything.
+	ApiScope string
+)
+
+const (
+	ScopeRead     ApiScope = "read"
+	ScopeWrite    ApiScope = "write"
+	ScopePayments ApiScope = "payments"
+	ScopeAdmin    ApiScope = "admin"
+)
+
+var ApiScopes = []ApiScope{ScopeRead, ScopeWrite, ScopePayments, ScopeAdmin}
+
+func (s ApiScope) IsValid() bool {
+	for _, scope := range ApiScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (l RateLimit) IsZero() bool {
+	return l.RequestsPerMinute == 0 && l.Burst == 0
+}
+
+func HasScope(scopes []ApiScope, scope ApiScope) bool {
+	for _, s := range scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+type (
+	// AuthFailure is a request turned away by VerifySignatureMiddleware. A
+	// client key failing too often is blocked for a while.
+	AuthFailure struct {
+		Id        int64             `json:"id"`
+		ClientKey string            `json:"client_key"`
+		Method    string            `json:"method"`
+		Path      string            `json:"path"`
+		Reason    AuthFailureReason `json:"reason"`
+		SourceIp  string            `json:"source_ip"`
+		CreatedAt time.Time         `json:"created_at"`
+	}
+
+	AuthFailureReason string
+)
+
+const (
+	AuthFailureMissingCredentials AuthFailureReason = "missing_credentials"
+	AuthFailureInvalidTimestamp   AuthFailureReason = "invalid_timestamp"
+	AuthFailureInvalidNonce       AuthFailureReason = "invalid_nonce"
+	AuthFailureStaleRequest       AuthFailureReason = "stale_request"
+	AuthFailureInvalidClientKey   AuthFailureReason = "invalid_client_key"
+	AuthFailureInvalidSignature   AuthFailureReason = "invalid_signature"
+	AuthFailureReplayedRequest    AuthFailureReason = "replayed_request"
+	AuthFailureScopeDenied        AuthFailureReason = "scope_denied"
+)
@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+type (
+	// AuditEntry records a change of the state of a loan, of what is attached
+	// to it, of the settlement and reconciliation worked on by the operator or
+	// of the setup of a tenant. Entries are only appended, every one is chained to
+	// the previous one with Hash so an entry changed or removed afterwards
+	// breaks the chain, see VerifyAuditChain.
+	AuditEntry struct {
+		Id         int64          `json:"id"`
+		TenantId   int64          `json:"tenant_id"`
+		ActorType  AuditActorType `json:"actor_type"`
+		Actor      string         `json:"actor"`
+		Action     AuditAction    `json:"action"`
+		EntityType string         `json:"entity_type"`
+		EntityId   int64          `json:"entity_id"`
+		// the loan and the borrower the change is about, 0 for the setup of a
+		// tenant and for settlement and reconciliation
+		LoanId int64 `json:"loan_id,omitempty"`
+		UserId int64 `json:"user_id,omitempty"`
+		// the entity before and after the change as JSON, null when it was
+		// created or is not kept
+		Before       json.RawMessage `json:"before"`
+		After        json.RawMessage `json:"after"`
+		RequestId    string          `json:"request_id,omitempty"`
+		PreviousHash string          `json:"previous_hash"`
+		Hash         string          `json:"hash"`
+		CreatedAt    time.Time       `json:"created_at"`
+	}
+
+	// AuditChainVerification is the result of checking the hash chain of the
+	// audit log from its first entry. BrokenAt is the first entry that does
+	// not follow from the previous one.
+	AuditChainVerification struct {
+		Checked  int64  `json:"checked"`
+		Valid    bool   `json:"valid"`
+		BrokenAt int64  `json:"broken_at,omitempty"`
+		Reason   string `json:"reason,omitempty"`
+	}
+
+	// AuditActorType tells who made a change: a client through the API, an
+	// admin user through a command, or the engine itself in a scheduled job
+	AuditActorType string
+
+	AuditAction string
+)
+
+const (
+	AuditActorClient AuditActorType = "client"
+	AuditActorAdmin  AuditActorType = "admin"
+	AuditActorSystem AuditActorType = "system"
+
+	AuditLoanCreated                AuditAction = "loan.created"
+	AuditLoanImported               AuditAction = "loan.imported"
+	AuditLoanStatusUpdated          AuditAction = "loan.status_updated"
+	AuditLoanScheduleUpdated        AuditAction = "loan.schedule_updated"
+	AuditScheduleVersionCreated     AuditAction = "loan_schedule_version.created"
+	AuditScheduleVersionClosed      AuditAction = "loan_schedule_version.closed"
+	AuditDunningLevelUpdated        AuditAction = "loan_dunning_level.updated"
+	AuditRepaymentCreated           AuditAction = "repayment.created"
+	AuditRepaymentImported          AuditAction = "repayment.imported"
+	AuditRepaymentStatusUpdated     AuditAction = "repayment.status_updated"
+	AuditReceiptCancelled           AuditAction = "receipt.cancelled"
+	AuditWriteOffCreated            AuditAction = "write_off.created"
+	AuditRecoveryCreated            AuditAction = "recovery.created"
+	AuditPromiseCreated             AuditAction = "promise_to_pay.created"
+	AuditPromiseUpdated             AuditAction = "promise_to_pay.updated"
+	AuditMandateCreated             AuditAction = "debit_mandate.created"
+	AuditMandateStatusUpdated       AuditAction = "debit_mandate.status_updated"
+	AuditDebitInstructionCreated    AuditAction = "debit_instruction.created"
+	AuditDebitInstructionUpdated    AuditAction = "debit_instruction.updated"
+	AuditVirtualAccountCreated      AuditAction = "virtual_account.created"
+	AuditSettlementExceptionUpdated AuditAction = "settlement_exception.updated"
+	AuditBankStatementLineMatched   AuditAction = "bank_statement_line.matched"
+	AuditApiClientCreated           AuditAction = "api_client.created"
+	AuditApiClientStatusUpdated     AuditAction = "api_client.status_updated"
+	AuditApiClientRateLimitUpdated  AuditAction = "api_client.rate_limit_updated"
+	AuditApiClientSecretRotated     AuditAction = "api_client.secret_rotated"
+	AuditTenantCreated              AuditAction = "tenant.created"
+	AuditTenantSettingsUpdated      AuditAction = "tenant.settings_updated"
+)
+
+// ComputeHash hashes the entry with the hash of the previous entry. Every
+// field but Hash itself is covered, CreatedAt to the microsecond as stored.
+func (e AuditEntry) ComputeHash() string {
+	content, _ := json.Marshal(struct {
+		Id           int64           `json:"id"`
+		TenantId     int64           `json:"tenant_id"`
+		ActorType    AuditActorType  `json:"actor_type"`
+		Actor        string          `json:"actor"`
+		Action       AuditAction     `json:"action"`
+		EntityType   string          `json:"entity_type"`
+		EntityId     int64           `json:"entity_id"`
+		LoanId       int64           `json:"loan_id"`
+		UserId       int64           `json:"user_id"`
+		Before       json.RawMessage `json:"before"`
+		After        json.RawMessage `json:"after"`
+		RequestId    string          `json:"request_id"`
+		PreviousHash string          `json:"previous_hash"`
+		CreatedAt    string          `json:"created_at"`
+	}{
+		Id:           e.Id,
+		TenantId:     e.TenantId,
+		ActorType:    e.ActorType,
+		Actor:        e.Actor,
+		Action:       e.Action,
+		EntityType:   e.EntityType,
+		EntityId:     e.EntityId,
+		LoanId:       e.LoanId,
+		UserId:       e.UserId,
+		Before:       rawOrNull(e.Before),
+		After:        rawOrNull(e.After),
+		RequestId:    e.RequestId,
+		PreviousHash: e.PreviousHash,
+		CreatedAt:    e.CreatedAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+	})
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func rawOrNull(value json.RawMessage) json.RawMessage {
+	if len(value) == 0 {
+		return json.RawMessage("null")
+	}
+	return value
+}
@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+type (
+	DebitMandate struct {
+		Id            int64         `json:"id"`
+		LoanId        int64         `json:"loan_id"`
+		BankCode      string        `json:"bank_code"`
+		AccountNumber string        `json:"account_number"`
+		AccountName   string        `json:"account_name"`
+		Status        MandateStatus `json:"status"`
+		CreatedAt     time.Time     `json:"created_at"`
+		UpdatedAt     time.Time     `json:"updated_at,omitempty"`
+	}
+
+	DebitInstruction struct {
+		Id                int64                  `json:"id"`
+		MandateId         int64                  `json:"mandate_id"`
+		LoanId            int64                  `json:"loan_id"`
+		ReferenceId       string                 `json:"reference_id"`
+		InstallmentNumber int                    `json:"installment_number"`
+		Amount            int64                  `json:"amount"`
+		DueDate           time.Time              `json:"due_date"`
+		Status            DebitInstructionStatus `json:"status"`
+		Attempts          int                    `json:"attempts"`
+		ReasonCode        string                 `json:"reason_code,omitempty"`
+		NextAttemptAt     time.Time              `json:"next_attempt_at,omitempty"`
+		RepaymentId       int64                  `json:"repayment_id,omitempty"`
+		CreatedAt         time.Time              `json:"created_at"`
+		UpdatedAt         time.Time              `json:"updated_at,omitempty"`
+	}
+
+	// DebitResult is the answer of the bank to a debit instruction
+	DebitResult struct {
+		Success    bool   `json:"success"`
+		ReasonCode string `json:"reason_code,omitempty"`
+	}
+
+	// RetryPolicy decides whether and when a failed debit is attempted again
+	RetryPolicy struct {
+		MaxAttempts          int
+		Interval             time.Duration
+		RetryableReasonCodes []string
+	}
+
+	CollectionRunResult struct {
+		CollectionDate time.Time `json:"collection_date"`
+		Created        int       `json:"created"`
+		Attempted      int       `json:"attempted"`
+		Succeeded      int       `json:"succeeded"`
+		Retrying       int       `json:"retrying"`
+		Failed         int       `json:"failed"`
+		Cancelled      int       `json:"cancelled"`
+		Unposted       int       `json:"unposted"`
+	}
+
+	MandateStatus          string
+	DebitInstructionStatus string
+)
+
+const (
+	MandateActive  MandateStatus = "active"
+	MandateRevoked MandateStatus = "revoked"
+
+	// a debited instruction was collected by the bank but its repayment is
+	// not posted yet, it is only ever posted again, never debited again
+	DebitInstructionPending   DebitInstructionStatus = "pending"
+	DebitInstructionRetrying  DebitInstructionStatus = "retrying"
+	DebitInstructionDebited   DebitInstructionStatus = "debited"
+	DebitInstructionSucceeded DebitInstructionStatus = "succeeded"
+	DebitInstructionFailed    DebitInstructionStatus = "failed"
+	DebitInstructionCancelled DebitInstructionStatus = "cancelled"
+
+	DebitReasonInsufficientFunds = "INSUFFICIENT_FUNDS"
+	DebitReasonAccountClosed     = "ACCOUNT_CLOSED"
+	DebitReasonMandateRevoked    = "MANDATE_REVOKED"
+	DebitReasonTechnicalError    = "TECHNICAL_ERROR"
+	DebitReasonPaymentRejected   = "PAYMENT_REJECTED"
+	DebitReasonPostingFailed     = "POSTING_FAILED"
+	DebitReasonAlreadyPaid       = "ALREADY_PAID"
+	DebitReasonLoanNotActive     = "LOAN_NOT_ACTIVE"
+)
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:          3,
+	Interval:             24 * time.Hour,
+	RetryableReasonCodes: []string{DebitReasonInsufficientFunds, DebitReasonTechnicalError},
+}
+
+func (p RetryPolicy) ShouldRetry(reasonCode string, attempts int) bool {
+	if attempts >= p.MaxAttempts {
+		return false
+	}
+	for _, code := range p.RetryableReasonCodes {
+		if code == reasonCode {
+			return true
+		}
+	}
+	return false
+}
+
+// DebitInstructionReferenceId is also used as the repayment reference id, so
+// one instruction can never be collected twice. The sequence counts the
+// instructions of the installment, a new one is made once the repayment of
+// the previous one is reversed.
+func DebitInstructionReferenceId(loanId int64, installmentNumber, sequence int) string {
+	return fmt.Sprintf("DD-%d-%d-%d", loanId, installmentNumber, sequence)
+}
@@ -0,0 +1,114 @@
+package entities
+
+import "time"
+
+type (
+	// DunningRule moves a loan to Level once it is DaysPastDue days past due
+	DunningRule struct {
+		Level       int           `json:"level"`
+		DaysPastDue int           `json:"days_past_due"`
+		Action      DunningAction `json:"action"`
+	}
+
+	// DunningPolicy lists the rules by ascending days past due
+	DunningPolicy struct {
+		Rules []DunningRule `json:"rules"`
+	}
+
+	// LoanDunningLevel is the level a loan is at. Level 0 is a loan that is not
+	// being dunned. An overridden level is kept by the daily evaluation until
+	// the loan is no longer past due.
+	LoanDunningLevel struct {
+		Id              int64         `json:"id"`
+		LoanId          int64         `json:"loan_id"`
+		LoanReferenceId string        `json:"loan_reference_id"`
+		Level           int           `json:"level"`
+		Action          DunningAction `json:"action,omitempty"`
+		DaysPastDue     int           `json:"days_past_due"`
+		IsOverridden    bool          `json:"is_overridden"`
+		OverrideReason  string        `json:"override_reason,omitempty"`
+		BusinessDate    time.Time     `json:"business_date"`
+		CreatedAt       time.Time     `json:"created_at"`
+		UpdatedAt       time.Time     `json:"updated_at,omitempty"`
+	}
+
+	// DunningActionItem is the work a loan reaching a level asks for, picked
+	// up by the notification subsystem or a collector
+	DunningActionItem struct {
+		Id              int64               `json:"id"`
+		LoanId          int64               `json:"loan_id"`
+		LoanReferenceId string              `json:"loan_reference_id"`
+		Level           int                 `json:"level"`
+		Action          DunningAction       `json:"action"`
+		DaysPastDue     int                 `json:"days_past_due"`
+		BusinessDate    time.Time           `json:"business_date"`
+		Status          DunningActionStatus `json:"status"`
+		CreatedAt       time.Time           `json:"created_at"`
+		UpdatedAt       time.Time           `json:"updated_at,omitempty"`
+	}
+
+	DunningEventData struct {
+		LoanId          int64         `json:"loan_id"`
+		LoanReferenceId string        `json:"loan_reference_id"`
+		UserId          int64         `json:"user_id"`
+		Level           int           `json:"level"`
+		Action          DunningAction `json:"action"`
+		DaysPastDue     int           `json:"days_past_due"`
+	}
+
+	DunningRunResult struct {
+		BusinessDate time.Time `json:"business_date"`
+		Loans        int       `json:"loans"`
+		Escalated    int       `json:"escalated"`
+		Lowered      int       `json:"lowered"`
+		OnPromise    int       `json:"on_promise"`
+	}
+
+	DunningAction       string
+	DunningActionStatus string
+)
+
+const (
+	DunningReminder        DunningAction = "reminder"
+	DunningSecondNotice    DunningAction = "second_notice"
+	DunningCallQueue       DunningAction = "call_queue"
+	DunningFieldCollection DunningAction = "field_collection"
+
+	DunningActionPending DunningActionStatus = "pending"
+	DunningActionDone    DunningActionStatus = "done"
+)
+
+var DefaultDunningPolicy = DunningPolicy{
+	Rules: []DunningRule{
+		{Level: 1, DaysPastDue: 1, Action: DunningReminder},
+		{Level: 2, DaysPastDue: 7, Action: DunningSecondNotice},
+		{Level: 3, DaysPastDue: 15, Action: DunningCallQueue},
+		{Level: 4, DaysPastDue: 30, Action: DunningFieldCollection},
+	},
+}
+
+// RuleFor is the rule of the highest level a loan that many days past due has
+// reached, the zero rule when it has reached none
+func (p DunningPolicy) RuleFor(daysPastDue int) DunningRule {
+	var rule DunningRule
+	for _, r := range p.Rules {
+		if daysPastDue >= r.DaysPastDue && r.Level > rule.Level {
+			rule = r
+		}
+	}
+	return rule
+}
+
+// RuleOf is the rule of the given level, false when no rule has that level
+func (p DunningPolicy) RuleOf(level int) (DunningRule, bool) {
+	for _, r := range p.Rules {
+		if r.Level == level {
+			return r, true
+		}
+	}
+	return DunningRule{}, false
+}
+
+func (s DunningActionStatus) IsValid() bool {
+	return s == DunningActionPending || s == DunningActionDone
+}
@@ -0,0 +1,23 @@
+package entities
+
+type (
+	// EncryptedColumn is a column stored sealed with the encryption keyring
+	EncryptedColumn struct {
+		Table  string `json:"table"`
+		Column string `json:"column"`
+	}
+
+	// EncryptedValue is the still encrypted value of a column in a row
+	EncryptedValue struct {
+		Id    int64
+		Value []byte
+	}
+
+	// ReencryptResult counts, for a column, the values read and the ones
+	// sealed with an older key that were re-encrypted under the primary key
+	ReencryptResult struct {
+		Column      EncryptedColumn `json:"column"`
+		Scanned     int             `json:"scanned"`
+		Reencrypted int             `json:"reencrypted"`
+	}
+)
@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"io"
+	"strconv"
+)
+
+type (
+	// LoanImportRequest carries the loans CSV and the optional CSV of their
+	// historical repayments. A dry run validates every row without saving.
+	LoanImportRequest struct {
+		Loans      io.Reader
+		Repayments io.Reader
+		DryRun     bool
+	}
+
+	// ImportRowResult is the outcome of one row of the loans CSV, Row is the
+	// line number in the file
+	ImportRowResult struct {
+		Row             int             `json:"row"`
+		LoanReferenceId string          `json:"loan_reference_id"`
+		Status          ImportRowStatus `json:"status"`
+		LoanId          int64           `json:"loan_id,omitempty"`
+		Repayments      int             `json:"repayments"`
+		Error           string          `json:"error,omitempty"`
+	}
+
+	ImportResult struct {
+		DryRun    bool              `json:"dry_run"`
+		Total     int               `json:"total"`
+		Succeeded int               `json:"succeeded"`
+		Failed    int               `json:"failed"`
+		Rows      []ImportRowResult `json:"rows"`
+	}
+
+	ImportRowStatus string
+)
+
+const (
+	ImportRowCreated ImportRowStatus = "created"
+	ImportRowValid   ImportRowStatus = "valid"
+	ImportRowFailed  ImportRowStatus = "failed"
+)
+
+func (r *ImportResult) CSVHeader() []string {
+	return []string{"row", "loan_reference_id", "status", "loan_id", "repayments", "error"}
+}
+
+func (r *ImportResult) CSVRows() [][]string {
+	rows := make([][]string, len(r.Rows))
+	for i, row := range r.Rows {
+		rows[i] = []string{strconv.Itoa(row.Row), row.LoanReferenceId, string(row.Status),
+			strconv.FormatInt(row.LoanId, 10), strconv.Itoa(row.Repayments), row.Error}
+	}
+	return rows
+}
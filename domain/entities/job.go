@@ -0,0 +1,53 @@
+package entities
+
+import "time"
+
+type (
+	// JobRun is one execution of a scheduled job for a business date. There is
+	// at most one run per job and business date, a rerun updates the same row.
+	JobRun struct {
+		Id           int64        `json:"id"`
+		JobName      string       `json:"job_name"`
+		BusinessDate time.Time    `json:"business_date"`
+		Status       JobRunStatus `json:"status"`
+		Trigger      JobTrigger   `json:"trigger"`
+		Owner        string       `json:"owner"`
+		Attempts     int          `json:"attempts"`
+		Error        string       `json:"error,omitempty"`
+		StartedAt    time.Time    `json:"started_at"`
+		FinishedAt   time.Time    `json:"finished_at,omitempty"`
+		CreatedAt    time.Time    `json:"created_at"`
+		UpdatedAt    time.Time    `json:"updated_at,omitempty"`
+	}
+
+	JobInfo struct {
+		Name     string    `json:"name"`
+		Schedule string    `json:"schedule"`
+		NextRun  time.Time `json:"next_run"`
+	}
+
+	JobRunStatus string
+	JobTrigger   string
+)
+
+const (
+	JobRunRunning   JobRunStatus = "running"
+	JobRunSucceeded JobRunStatus = "succeeded"
+	JobRunFailed    JobRunStatus = "failed"
+
+	JobTriggerScheduled JobTrigger = "scheduled"
+	JobTriggerManual    JobTrigger = "manual"
+
+	JobCollectionRun         = "collection_run"
+	JobEndOfDay              = "end_of_day_snapshot"
+	JobWriteOff              = "write_off"
+	JobPromiseToPay          = "promise_to_pay"
+	JobDunning               = "dunning"
+	JobNotificationReminders = "notification_reminders"
+	JobRequestNoncePurge     = "request_nonce_purge"
+	JobAuthFailurePurge      = "auth_failure_purge"
+
+	// WebhookDispatchLease is the lease the instance sending the webhook
+	// deliveries holds, the dispatcher is not a scheduled job
+	WebhookDispatchLease = "webhook_dispatch"
+)
@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in the minor unit of its ISO 4217 currency, e.g. cents
+// for USD, so 1050 USD is USD 10.50, and whole rupiah for IDR. Every amount
+// the engine stores is in the minor unit of the currency of its loan.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// DefaultCurrency is the currency of everything created before loans had one
+const DefaultCurrency = "IDR"
+
+// currencyMinorUnits are the currencies a loan can be booked in and the
+// number of decimals of their minor unit, the exponent of ISO 4217. IDR is
+// the exception: its sen is no longer in use and every rupiah amount stored
+// before loans had a currency is in whole rupiah, so IDR is kept in whole
+// rupiah instead of the exponent of 2 ISO 4217 gives it.
+var currencyMinorUnits = map[string]int{
+	"AUD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"IDR": 0,
+	"JPY": 0,
+	"KRW": 0,
+	"MYR": 2,
+	"PHP": 2,
+	"SGD": 2,
+	"THB": 2,
+	"USD": 2,
+	"VND": 0,
+}
+
+// IsValidCurrency tells whether a loan can be booked in the currency
+func IsValidCurrency(currency string) bool {
+	_, ok := currencyMinorUnits[currency]
+	return ok
+}
+
+// NormalizeCurrency upper cases a currency code read from a request
+func NormalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
+
+// MinorUnits is the number of decimals of the minor unit of the currency
+func MinorUnits(currency string) int {
+	return currencyMinorUnits[currency]
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Decimal formats the amount in the major unit with the decimals of the
+// currency, e.g. 10.50 for 1050 USD and 150000 for 150000 JPY
+func (m Money) Decimal() string {
+	digits := MinorUnits(m.Currency)
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	value := strconv.FormatInt(amount, 10)
+	if digits == 0 {
+		return sign + value
+	}
+	if len(value) <= digits {
+		value = strings.Repeat("0", digits-len(value)+1) + value
+	}
+
+	return sign + value[:len(value)-digits] + "." + value[len(value)-digits:]
+}
+
+// String formats the amount the way it is shown to people, e.g. USD 10.50
+func (m Money) String() string {
+	return m.Currency + " " + m.Decimal()
+}
@@ -0,0 +1,197 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+type (
+	// NotificationTemplate is the text of one kind of message in one locale.
+	// Subject and Body are text/template sources rendered with NotificationData,
+	// the subject is only used by channels that have one.
+	NotificationTemplate struct {
+		Kind    NotificationKind `json:"kind"`
+		Locale  string           `json:"locale"`
+		Subject string           `json:"subject"`
+		Body    string           `json:"body"`
+	}
+
+	NotificationData struct {
+		LoanReferenceId string
+		Installment     int
+		Amount          Money
+		DueDate         string
+		DaysPastDue     int
+	}
+
+	// NotificationMessage is a rendered message on its way to a borrower
+	NotificationMessage struct {
+		UserId  int64               `json:"user_id"`
+		Channel NotificationChannel `json:"channel"`
+		Kind    NotificationKind    `json:"kind"`
+		Locale  string              `json:"locale"`
+		Subject string              `json:"subject,omitempty"`
+		Body    string              `json:"body"`
+	}
+
+	// NotificationPreference is what a borrower asked for. A borrower without
+	// one gets every channel in the default locale.
+	NotificationPreference struct {
+		UserId    int64                 `json:"user_id"`
+		Locale    string                `json:"locale"`
+		OptedOut  []NotificationChannel `json:"opted_out"`
+		CreatedAt time.Time             `json:"created_at"`
+		UpdatedAt time.Time             `json:"updated_at,omitempty"`
+	}
+
+	// NotificationDelivery is one message in the delivery log. Reference is
+	// the event or installment the message is about, a message is only sent
+	// once per kind and reference.
+	NotificationDelivery struct {
+		Id        int64                      `json:"id"`
+		UserId    int64                      `json:"user_id"`
+		LoanId    int64                      `json:"loan_id"`
+		Channel   NotificationChannel        `json:"channel"`
+		Kind      NotificationKind           `json:"kind"`
+		Reference string                     `json:"reference"`
+		Locale    string                     `json:"locale"`
+		Subject   string                     `json:"subject,omitempty"`
+		Body      string                     `json:"body"`
+		Status    NotificationDeliveryStatus `json:"status"`
+		Error     string                     `json:"error,omitempty"`
+		CreatedAt time.Time                  `json:"created_at"`
+	}
+
+	NotificationRunResult struct {
+		BusinessDate time.Time `json:"business_date"`
+		Loans        int       `json:"loans"`
+		DueReminders int       `json:"due_reminders"`
+		LateNotices  int       `json:"late_notices"`
+	}
+
+	NotificationChannel        string
+	NotificationKind           string
+	NotificationDeliveryStatus string
+)
+
+const (
+	NotificationEmail NotificationChannel = "email"
+	NotificationSMS   NotificationChannel = "sms"
+	NotificationPush  NotificationChannel = "push"
+
+	NotificationDueReminder     NotificationKind = "due_reminder"
+	NotificationPaymentReceived NotificationKind = "payment_received"
+	// NotificationLateNotice tells the borrower an installment is late
+	NotificationLateNotice    NotificationKind = "late_notice"
+	NotificationLoanCompleted NotificationKind = "loan_completed"
+	// the dunning notices sent when a late loan is escalated
+	NotificationDunningReminder     NotificationKind = "dunning_reminder"
+	NotificationDunningSecondNotice NotificationKind = "dunning_second_notice"
+
+	NotificationSent   NotificationDeliveryStatus = "sent"
+	NotificationFailed NotificationDeliveryStatus = "failed"
+
+	DefaultNotificationLocale = "en"
+)
+
+var NotificationChannels = []NotificationChannel{NotificationEmail, NotificationSMS, NotificationPush}
+
+// DunningNotificationKinds are the dunning actions sent to the borrower, the
+// other actions are worked by collectors from the dunning action queue
+var DunningNotificationKinds = map[DunningAction]NotificationKind{
+	DunningReminder:     NotificationDunningReminder,
+	DunningSecondNotice: NotificationDunningSecondNotice,
+}
+
+var DefaultNotificationTemplates = []NotificationTemplate{
+	{
+		Kind:    NotificationDueReminder,
+		Locale:  "en",
+		Subject: "Installment {{.Installment}} of loan {{.LoanReferenceId}} is due soon",
+		Body:    "Your installment {{.Installment}} of {{.Amount}} for loan {{.LoanReferenceId}} is due on {{.DueDate}}.",
+	},
+	{
+		Kind:    NotificationPaymentReceived,
+		Locale:  "en",
+		Subject: "Payment received for loan {{.LoanReferenceId}}",
+		Body:    "We received your payment of {{.Amount}} for loan {{.LoanReferenceId}}. Thank you.",
+	},
+	{
+		Kind:    NotificationLateNotice,
+		Locale:  "en",
+		Subject: "Installment {{.Installment}} of loan {{.LoanReferenceId}} is late",
+		Body:    "Your installment {{.Installment}} of {{.Amount}} for loan {{.LoanReferenceId}} was due on {{.DueDate}} and is now late. Please pay it as soon as possible.",
+	},
+	{
+		Kind:    NotificationLoanCompleted,
+		Locale:  "en",
+		Subject: "Loan {{.LoanReferenceId}} is paid off",
+		Body:    "Your loan {{.LoanReferenceId}} is fully paid. Thank you for borrowing with us.",
+	},
+	{
+		Kind:    NotificationDunningReminder,
+		Locale:  "en",
+		Subject: "Loan {{.LoanReferenceId}} is past due",
+		Body:    "Your loan {{.LoanReferenceId}} is {{.DaysPastDue}} days past due. Please pay the late installments as soon as possible.",
+	},
+	{
+		Kind:    NotificationDunningSecondNotice,
+		Locale:  "en",
+		Subject: "Second notice: loan {{.LoanReferenceId}} is past due",
+		Body:    "Your loan {{.LoanReferenceId}} is now {{.DaysPastDue}} days past due. Please pay the late installments now to avoid further collection action.",
+	},
+	{
+		Kind:    NotificationDueReminder,
+		Locale:  "id",
+		Subject: "Cicilan {{.Installment}} pinjaman {{.LoanReferenceId}} segera jatuh tempo",
+		Body:    "Cicilan {{.Installment}} sebesar {{.Amount}} untuk pinjaman {{.LoanReferenceId}} jatuh tempo pada {{.DueDate}}.",
+	},
+	{
+		Kind:    NotificationPaymentReceived,
+		Locale:  "id",
+		Subject: "Pembayaran pinjaman {{.LoanReferenceId}} diterima",
+		Body:    "Pembayaran Anda sebesar {{.Amount}} untuk pinjaman {{.LoanReferenceId}} telah kami terima. Terima kasih.",
+	},
+	{
+		Kind:    NotificationLateNotice,
+		Locale:  "id",
+		Subject: "Cicilan {{.Installment}} pinjaman {{.LoanReferenceId}} terlambat",
+		Body:    "Cicilan {{.Installment}} sebesar {{.Amount}} untuk pinjaman {{.LoanReferenceId}} jatuh tempo pada {{.DueDate}} dan kini terlambat. Mohon segera lakukan pembayaran.",
+	},
+	{
+		Kind:    NotificationLoanCompleted,
+		Locale:  "id",
+		Subject: "Pinjaman {{.LoanReferenceId}} telah lunas",
+		Body:    "Pinjaman {{.LoanReferenceId}} Anda telah lunas. Terima kasih.",
+	},
+	{
+		Kind:    NotificationDunningReminder,
+		Locale:  "id",
+		Subject: "Pinjaman {{.LoanReferenceId}} melewati jatuh tempo",
+		Body:    "Pinjaman {{.LoanReferenceId}} Anda telah {{.DaysPastDue}} hari melewati jatuh tempo. Mohon segera bayar cicilan yang terlambat.",
+	},
+	{
+		Kind:    NotificationDunningSecondNotice,
+		Locale:  "id",
+		Subject: "Pemberitahuan kedua: pinjaman {{.LoanReferenceId}} melewati jatuh tempo",
+		Body:    "Pinjaman {{.LoanReferenceId}} Anda kini {{.DaysPastDue}} hari melewati jatuh tempo. Mohon bayar cicilan yang terlambat sekarang untuk menghindari tindakan penagihan lebih lanjut.",
+	},
+}
+
+func (c NotificationChannel) IsValid() bool {
+	for _, channel := range NotificationChannels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+func (p NotificationPreference) IsOptedOutOf(channel NotificationChannel) bool {
+	for _, c := range p.OptedOut {
+		if strings.EqualFold(string(c), string(channel)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package entities
+
+import "time"
+
+type (
+	// PromiseToPay is an agreement a collector made with a borrower to pay at
+	// least Amount on the loan by the end of PromisedDate
+	PromiseToPay struct {
+		Id              int64         `json:"id"`
+		LoanId          int64         `json:"loan_id"`
+		LoanReferenceId string        `json:"loan_reference_id"`
+		Amount          int64         `json:"amount"`
+		PromisedDate    time.Time     `json:"promised_date"`
+		Agent           string        `json:"agent"`
+		Status          PromiseStatus `json:"status"`
+		PaidAmount      int64         `json:"paid_amount"`
+		ResolvedAt      time.Time     `json:"resolved_at,omitempty"`
+		CreatedAt       time.Time     `json:"created_at"`
+	}
+
+	// PromisePolicy bounds the promises a collector can take: the promised
+	// date is at most MaxDays days after the business date, and a loan whose
+	// last MaxBrokenInARow promises were all broken gets no new promise. Zero
+	// leaves the limit off.
+	PromisePolicy struct {
+		MaxDays         int
+		MaxBrokenInARow int
+	}
+
+	PromiseToPayRunResult struct {
+		BusinessDate time.Time `json:"business_date"`
+		Evaluated    int       `json:"evaluated"`
+		Kept         int       `json:"kept"`
+		Broken       int       `json:"broken"`
+	}
+
+	PromiseStatus string
+)
+
+const (
+	PromiseOpen   PromiseStatus = "open"
+	PromiseKept   PromiseStatus = "kept"
+	PromiseBroken PromiseStatus = "broken"
+)
+
+var DefaultPromisePolicy = PromisePolicy{MaxDays: 14, MaxBrokenInARow: 2}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+type (
+	// Receipt is issued for every repayment in the same transaction, so the
+	// receipt numbers of a month have no gaps.
+	Receipt struct {
+		Id                   int64         `json:"id"`
+		TenantId             int64         `json:"tenant_id"`
+		ReceiptNumber        string        `json:"receipt_number"`
+		RepaymentId          int64         `json:"repayment_id"`
+		RepaymentReferenceId string        `json:"repayment_reference_id"`
+		LoanId               int64         `json:"loan_id"`
+		LoanReferenceId      string        `json:"loan_reference_id"`
+		UserId               int64         `json:"user_id"`
+		InstallmentNumber    int           `json:"installment_number"`
+		Amount               int64         `json:"amount"`
+		Currency             string        `json:"currency"`
+		Principal            int64         `json:"principal"`
+		Interest             int64         `json:"interest"`
+		Fees                 int64         `json:"fees"`
+		Status               ReceiptStatus `json:"status"`
+		CancellationNote     string        `json:"cancellation_note,omitempty"`
+		IssuedAt             time.Time     `json:"issued_at"`
+		CancelledAt          time.Time     `json:"cancelled_at,omitempty"`
+		CreatedAt            time.Time     `json:"created_at"`
+		UpdatedAt            time.Time     `json:"updated_at,omitempty"`
+	}
+
+	ReceiptStatus string
+)
+
+const (
+	ReceiptIssued    ReceiptStatus = "issued"
+	ReceiptCancelled ReceiptStatus = "cancelled"
+)
+
+// ReceiptPeriod is the month a receipt number sequence runs for, e.g. 202401
+func ReceiptPeriod(issuedAt time.Time) string {
+	return issuedAt.Format("200601")
+}
+
+// FormatReceiptNumber formats the sequence of a period as RCP-202401-000001
+func FormatReceiptNumber(period string, sequence int64) string {
+	return fmt.Sprintf("RCP-%s-%06d", period, sequence)
+}
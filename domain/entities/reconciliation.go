@@ -0,0 +1,104 @@
+package entities
+
+import (
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+type (
+	// BankStatementLine is money received on a collection account according
+	// to the bank statement. Reconciliation matches it to the repayment it
+	// paid for, a line can only be matched to one repayment and back.
+	BankStatementLine struct {
+		Id            int64                `json:"id"`
+		BankCode      string               `json:"bank_code"`
+		TransactionId string               `json:"transaction_id"`
+		ValueDate     time.Time            `json:"value_date"`
+		Amount        int64                `json:"amount"`
+		Description   string               `json:"description"`
+		Status        ReconciliationStatus `json:"status"`
+		RepaymentId   int64                `json:"repayment_id,omitempty"`
+		MatchType     MatchType            `json:"match_type,omitempty"`
+		Reason        string               `json:"reason,omitempty"`
+		Note          string               `json:"note,omitempty"`
+		CreatedAt     time.Time            `json:"created_at"`
+		UpdatedAt     time.Time            `json:"updated_at,omitempty"`
+	}
+
+	// MatchTolerance is how far a statement line may be from its repayment
+	// and still be matched automatically
+	MatchTolerance struct {
+		Amount int64
+		Days   int
+	}
+
+	// BankStatementImportRequest carries a bank statement CSV with the
+	// transaction_id, value_date, amount and description columns
+	BankStatementImportRequest struct {
+		BankCode string
+		File     io.Reader
+	}
+
+	BankStatementImportResult struct {
+		Total      int                 `json:"total"`
+		Duplicates int                 `json:"duplicates"`
+		Matched    int                 `json:"matched"`
+		Mismatched int                 `json:"mismatched"`
+		Unmatched  int                 `json:"unmatched"`
+		Lines      []BankStatementLine `json:"lines"`
+	}
+
+	ReconciliationReport struct {
+		FromDate               time.Time           `json:"from_date"`
+		ToDate                 time.Time           `json:"to_date"`
+		MatchedAmount          int64               `json:"matched_amount"`
+		MismatchedAmount       int64               `json:"mismatched_amount"`
+		UnmatchedAmount        int64               `json:"unmatched_amount"`
+		UnreconciledAmount     int64               `json:"unreconciled_amount"`
+		Matched                []BankStatementLine `json:"matched"`
+		Mismatched             []BankStatementLine `json:"mismatched"`
+		Unmatched              []BankStatementLine `json:"unmatched"`
+		UnreconciledRepayments []Repayment         `json:"unreconciled_repayments"`
+	}
+
+	ReconciliationStatus string
+	MatchType            string
+)
+
+const (
+	ReconciliationUnmatched  ReconciliationStatus = "unmatched"
+	ReconciliationMatched    ReconciliationStatus = "matched"
+	ReconciliationMismatched ReconciliationStatus = "mismatched"
+
+	MatchAuto   MatchType = "auto"
+	MatchManual MatchType = "manual"
+)
+
+// DefaultMatchTolerance only matches exact amounts, settled up to three days
+// after the payment to cover weekends
+var DefaultMatchTolerance = MatchTolerance{Amount: 0, Days: 3}
+
+func (r *ReconciliationReport) CSVHeader() []string {
+	return []string{"item", "status", "id", "transaction_id", "value_date", "amount", "description", "repayment_id", "reason"}
+}
+
+// CSVRows lists the statement lines followed by the repayments no line paid for
+func (r *ReconciliationReport) CSVRows() [][]string {
+	var rows [][]string
+	for _, lines := range [][]BankStatementLine{r.Matched, r.Mismatched, r.Unmatched} {
+		for _, line := range lines {
+			rows = append(rows, []string{"statement_line", string(line.Status), strconv.FormatInt(line.Id, 10),
+				line.TransactionId, line.ValueDate.Format(helper.DateLayout), strconv.FormatInt(line.Amount, 10),
+				line.Description, strconv.FormatInt(line.RepaymentId, 10), line.Reason})
+		}
+	}
+	for _, repayment := range r.UnreconciledRepayments {
+		rows = append(rows, []string{"repayment", "unreconciled", strconv.FormatInt(repayment.Id, 10),
+			repayment.ReferenceId, repayment.CreatedAt.Format(helper.DateLayout), strconv.FormatInt(repayment.Amount, 10),
+			"", strconv.FormatInt(repayment.Id, 10), ""})
+	}
+	return rows
+}
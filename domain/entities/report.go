@@ -0,0 +1,197 @@
+package entities
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+type (
+	// ReportFilter narrows a report down. A zero RepaymentSchedule, Status or
+	// Restructured matches every loan. Snapshot based reports are taken as of ToDate.
+	ReportFilter struct {
+		FromDate          time.Time
+		ToDate            time.Time
+		RepaymentSchedule RepaymentScheduleType
+		Status            LoanStatus
+		Restructured      *bool
+	}
+
+	PortfolioMeasure struct {
+		Loans                int     `json:"loans"`
+		PrincipalOutstanding int64   `json:"principal_outstanding"`
+		RatioPercent         float64 `json:"ratio_percent"`
+	}
+
+	AgingBucketSummary struct {
+		Currency             string `json:"currency" db:"currency"`
+		AgingBucket          string `json:"aging_bucket" db:"aging_bucket"`
+		Loans                int    `json:"loans" db:"loans"`
+		PrincipalOutstanding int64  `json:"principal_outstanding" db:"principal_outstanding"`
+		InterestOutstanding  int64  `json:"interest_outstanding" db:"interest_outstanding"`
+	}
+
+	// PortfolioAtRiskReport has one portfolio per currency, amounts of
+	// different currencies are never added up
+	PortfolioAtRiskReport struct {
+		AsOf       time.Time         `json:"as_of"`
+		Portfolios []PortfolioAtRisk `json:"portfolios"`
+	}
+
+	// PortfolioAtRisk amounts are in the minor unit of the currency
+	PortfolioAtRisk struct {
+		Currency string               `json:"currency"`
+		Total    PortfolioMeasure     `json:"total"`
+		PAR1     PortfolioMeasure     `json:"par1"`
+		PAR30    PortfolioMeasure     `json:"par30"`
+		PAR90    PortfolioMeasure     `json:"par90"`
+		Aging    []AgingBucketSummary `json:"aging"`
+	}
+
+	ScheduleOutstanding struct {
+		Currency             string                `json:"currency" db:"currency"`
+		RepaymentSchedule    RepaymentScheduleType `json:"repayment_schedule" db:"repayment_schedule"`
+		Loans                int                   `json:"loans" db:"loans"`
+		PrincipalOutstanding int64                 `json:"principal_outstanding" db:"principal_outstanding"`
+		InterestOutstanding  int64                 `json:"interest_outstanding" db:"interest_outstanding"`
+	}
+
+	OutstandingByScheduleReport struct {
+		AsOf      time.Time             `json:"as_of"`
+		Schedules []ScheduleOutstanding `json:"schedules"`
+	}
+
+	DailyDisbursement struct {
+		Date   time.Time `json:"date"`
+		Loans  int       `json:"loans"`
+		Amount int64     `json:"amount"`
+	}
+
+	// DisbursementReport has the days of the period once per currency loans
+	// were booked in
+	DisbursementReport struct {
+		FromDate   time.Time              `json:"from_date"`
+		ToDate     time.Time              `json:"to_date"`
+		Currencies []CurrencyDisbursement `json:"currencies"`
+	}
+
+	// CurrencyDisbursement amounts are in the minor unit of the currency
+	CurrencyDisbursement struct {
+		Currency    string              `json:"currency"`
+		TotalLoans  int                 `json:"total_loans"`
+		TotalAmount int64               `json:"total_amount"`
+		Days        []DailyDisbursement `json:"days"`
+	}
+
+	// CollectionRateReport compares the installments due within the period
+	// with those of them paid by the end of the period, once per currency
+	CollectionRateReport struct {
+		FromDate   time.Time                `json:"from_date"`
+		ToDate     time.Time                `json:"to_date"`
+		Currencies []CurrencyCollectionRate `json:"currencies"`
+	}
+
+	// CurrencyCollectionRate amounts are in the minor unit of the currency
+	CurrencyCollectionRate struct {
+		Currency              string  `json:"currency"`
+		InstallmentsDue       int     `json:"installments_due"`
+		InstallmentsCollected int     `json:"installments_collected"`
+		AmountDue             int64   `json:"amount_due"`
+		AmountCollected       int64   `json:"amount_collected"`
+		CollectionRatePercent float64 `json:"collection_rate_percent"`
+	}
+)
+
+func (r *PortfolioAtRiskReport) CSVHeader() []string {
+	return []string{"as_of", "currency", "measure", "loans", "principal_outstanding", "ratio_percent"}
+}
+
+func (r *PortfolioAtRiskReport) CSVRows() [][]string {
+	asOf := r.AsOf.Format(helper.DateLayout)
+
+	var rows [][]string
+	for _, portfolio := range r.Portfolios {
+		measureRow := func(name string, m PortfolioMeasure) []string {
+			return []string{asOf, portfolio.Currency, name, strconv.Itoa(m.Loans),
+				NewMoney(m.PrincipalOutstanding, portfolio.Currency).Decimal(), formatPercent(m.RatioPercent)}
+		}
+
+		rows = append(rows,
+			measureRow("total", portfolio.Total),
+			measureRow("par1", portfolio.PAR1),
+			measureRow("par30", portfolio.PAR30),
+			measureRow("par90", portfolio.PAR90),
+		)
+		for _, bucket := range portfolio.Aging {
+			rows = append(rows, measureRow("aging "+bucket.AgingBucket, PortfolioMeasure{
+				Loans:                bucket.Loans,
+				PrincipalOutstanding: bucket.PrincipalOutstanding,
+				RatioPercent:         Percent(bucket.PrincipalOutstanding, portfolio.Total.PrincipalOutstanding),
+			}))
+		}
+	}
+	return rows
+}
+
+func (r *OutstandingByScheduleReport) CSVHeader() []string {
+	return []string{"as_of", "currency", "repayment_schedule", "loans", "principal_outstanding", "interest_outstanding"}
+}
+
+func (r *OutstandingByScheduleReport) CSVRows() [][]string {
+	rows := make([][]string, len(r.Schedules))
+	for i, s := range r.Schedules {
+		rows[i] = []string{r.AsOf.Format(helper.DateLayout), s.Currency, string(s.RepaymentSchedule), strconv.Itoa(s.Loans),
+			NewMoney(s.PrincipalOutstanding, s.Currency).Decimal(), NewMoney(s.InterestOutstanding, s.Currency).Decimal()}
+	}
+	return rows
+}
+
+func (r *DisbursementReport) CSVHeader() []string {
+	return []string{"date", "currency", "loans", "amount"}
+}
+
+func (r *DisbursementReport) CSVRows() [][]string {
+	var rows [][]string
+	for _, c := range r.Currencies {
+		for _, d := range c.Days {
+			rows = append(rows, []string{d.Date.Format(helper.DateLayout), c.Currency, strconv.Itoa(d.Loans),
+				NewMoney(d.Amount, c.Currency).Decimal()})
+		}
+	}
+	return rows
+}
+
+func (r *CollectionRateReport) CSVHeader() []string {
+	return []string{"from_date", "to_date", "currency", "installments_due", "installments_collected", "amount_due", "amount_collected", "collection_rate_percent"}
+}
+
+func (r *CollectionRateReport) CSVRows() [][]string {
+	rows := make([][]string, len(r.Currencies))
+	for i, c := range r.Currencies {
+		rows[i] = []string{
+			r.FromDate.Format(helper.DateLayout),
+			r.ToDate.Format(helper.DateLayout),
+			c.Currency,
+			strconv.Itoa(c.InstallmentsDue),
+			strconv.Itoa(c.InstallmentsCollected),
+			NewMoney(c.AmountDue, c.Currency).Decimal(),
+			NewMoney(c.AmountCollected, c.Currency).Decimal(),
+			formatPercent(c.CollectionRatePercent),
+		}
+	}
+	return rows
+}
+
+// Percent returns part as a percentage of total rounded to two decimals
+func Percent(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)*10000/float64(total)) / 100
+}
+
+func formatPercent(p float64) string {
+	return strconv.FormatFloat(p, 'f', 2, 64)
+}
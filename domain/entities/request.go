@@ -1,10 +1,17 @@
 package entities
 
+import "time"
+
 type (
 	RepaymentRequest struct {
 		LoanReferenceId      string `json:"loan_reference_id"`
 		RepaymentReferenceId string `json:"repayment_reference_id"`
 		Amount               int64  `json:"amount"`
+		// empty is the currency of the loan
+		Currency string `json:"currency,omitempty"`
+		// PaidAt is when a gateway received the money, zero is now. It is
+		// not read from clients, only payment callbacks set it.
+		PaidAt time.Time `json:"-"`
 	}
 
 	LoanRequest struct {
@@ -14,5 +21,166 @@ type (
 		RatePercentage    int                   `json:"rate_percentage"`
 		RepaymentSchedule RepaymentScheduleType `json:"repayment_schedule"`
 		Tenor             int                   `json:"tenor"`
+		// empty is the currency of the tenant
+		Currency string   `json:"currency,omitempty"`
+		Borrower Borrower `json:"borrower,omitempty"`
+	}
+
+	ApiClientRequest struct {
+		Name      string     `json:"name"`
+		Scopes    []ApiScope `json:"scopes"`
+		RateLimit RateLimit  `json:"rate_limit"`
+	}
+
+	ApiClientKeyRequest struct {
+		ClientKey string `json:"client_key"`
+	}
+
+	ApiClientRateLimitRequest struct {
+		ClientKey string    `json:"client_key"`
+		RateLimit RateLimit `json:"rate_limit"`
+	}
+
+	WebhookSubscriptionRequest struct {
+		Url        string   `json:"url"`
+		EventTypes []string `json:"event_types"`
+	}
+
+	WebhookSubscriptionIdRequest struct {
+		SubscriptionId int64 `json:"subscription_id"`
+	}
+
+	WebhookRedeliverRequest struct {
+		DeliveryId int64 `json:"delivery_id"`
+	}
+
+	DebitMandateRequest struct {
+		LoanReferenceId string `json:"loan_reference_id"`
+		BankCode        string `json:"bank_code"`
+		AccountNumber   string `json:"account_number"`
+		AccountName     string `json:"account_name"`
+	}
+
+	DebitMandateIdRequest struct {
+		MandateId int64 `json:"mandate_id"`
+	}
+
+	CollectionRunRequest struct {
+		CollectionDate string `json:"collection_date"`
+	}
+
+	// DunningOverrideRequest puts a loan on a dunning level by hand, level 0
+	// takes it out of dunning
+	DunningOverrideRequest struct {
+		LoanReferenceId string `json:"loan_reference_id"`
+		Level           int    `json:"level"`
+		Reason          string `json:"reason"`
+	}
+
+	DunningActionIdRequest struct {
+		ActionId int64 `json:"action_id"`
+	}
+
+	// PromiseToPayRequest records a promise, PromisedDate is formatted as 2006-01-02
+	PromiseToPayRequest struct {
+		LoanReferenceId string `json:"loan_reference_id"`
+		Amount          int64  `json:"amount"`
+		PromisedDate    string `json:"promised_date"`
+		Agent           string `json:"agent"`
+	}
+
+	NotificationPreferenceRequest struct {
+		UserId   int64                 `json:"user_id"`
+		Locale   string                `json:"locale"`
+		OptedOut []NotificationChannel `json:"opted_out"`
+	}
+
+	VirtualAccountRequest struct {
+		LoanReferenceId string `json:"loan_reference_id"`
+		BankCode        string `json:"bank_code"`
+	}
+
+	JobRunRequest struct {
+		JobName      string `json:"job_name"`
+		BusinessDate string `json:"business_date"`
+		Force        bool   `json:"force"`
+	}
+
+	PaymentReversalRequest struct {
+		RepaymentReferenceId string `json:"repayment_reference_id"`
+		Reason               string `json:"reason"`
+	}
+
+	StatementRequest struct {
+		UserId int64
+		Period string
+	}
+
+	ReportRequest struct {
+		FromDate          string
+		ToDate            string
+		RepaymentSchedule string
+		Status            string
+		Restructured      string
+	}
+
+	SnapshotBackfillRequest struct {
+		FromDate string `json:"from_date"`
+		ToDate   string `json:"to_date"`
+	}
+
+	// SettlementRepostRequest re-posts an open exception, the loan reference
+	// id replaces the one of the settlement line when it is set
+	SettlementRepostRequest struct {
+		ExceptionId     int64  `json:"exception_id"`
+		LoanReferenceId string `json:"loan_reference_id"`
+	}
+
+	SettlementDismissRequest struct {
+		ExceptionId int64  `json:"exception_id"`
+		Note        string `json:"note"`
+	}
+
+	ReconciliationRequest struct {
+		FromDate string `json:"from_date"`
+		ToDate   string `json:"to_date"`
+	}
+
+	ManualMatchRequest struct {
+		LineId               int64  `json:"line_id"`
+		RepaymentReferenceId string `json:"repayment_reference_id"`
+		Note                 string `json:"note"`
+	}
+
+	WriteOffRequest struct {
+		LoanReferenceId string `json:"loan_reference_id"`
+		Reason          string `json:"reason"`
+	}
+
+	// RestructureRequest replaces the remaining installments of a loan with
+	// Tenor new ones at RatePercentage. CapitalizeArrears adds the interest of
+	// the installments already overdue to the new principal, otherwise it is
+	// forgiven with the old schedule.
+	RestructureRequest struct {
+		LoanReferenceId   string `json:"loan_reference_id"`
+		Tenor             int    `json:"tenor"`
+		RatePercentage    int    `json:"rate_percentage"`
+		CapitalizeArrears bool   `json:"capitalize_arrears"`
+		Reason            string `json:"reason"`
+	}
+
+	// DeferralRequest postpones the next Installments unpaid installments of a
+	// loan, and every one after them, by as many periods
+	DeferralRequest struct {
+		LoanReferenceId string               `json:"loan_reference_id"`
+		Installments    int                  `json:"installments"`
+		Interest        DeferralInterestType `json:"interest"`
+		Reason          string               `json:"reason"`
+	}
+
+	RecoveryRequest struct {
+		LoanReferenceId     string `json:"loan_reference_id"`
+		RecoveryReferenceId string `json:"recovery_reference_id"`
+		Amount              int64  `json:"amount"`
 	}
 )
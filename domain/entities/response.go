@@ -10,6 +10,13 @@ type (
 		RepaymentNeeded []RepaymentNeeded `json:"repayment_needed,omitempty"`
 	}
 
+	// UserStatus tells whether a user is delinquent and, when they are, the
+	// open promises to pay on their loans
+	UserStatus struct {
+		IsDelinquent  bool           `json:"is_delinquent"`
+		PromisesToPay []PromiseToPay `json:"promises_to_pay,omitempty"`
+	}
+
 	LoanList struct {
 		UserId int64          `json:"user_id"`
 		Loans  []LoanResponse `json:"loans,omitempty"`
@@ -19,13 +26,95 @@ type (
 		Id                int64                 `json:"id" `
 		ReferenceId       string                `json:"reference_id" `
 		UserId            int64                 `json:"user_id,omitempty" `
-		Amount            int64                 `json:"amount" `
+		Amount            Money                 `json:"amount" `
 		RatePercentage    int                   `json:"rate_percentage" `
 		Status            string                `json:"status" `
 		RepaymentSchedule RepaymentScheduleType `json:"repayment_schedule" `
 		Tenor             int                   `json:"tenor" `
-		RepaymentAmount   int64                 `json:"repayment_amount" `
+		RepaymentAmount   Money                 `json:"repayment_amount" `
+		Restructured      bool                  `json:"restructured" `
 		CreatedAt         time.Time             `json:"created_at" `
 		UpdatedAt         time.Time             `json:"updated_at,omitempty" `
 	}
+
+	RepaymentResponse struct {
+		Id          int64           `json:"id"`
+		LoanId      int64           `json:"loan_id"`
+		ReferenceId string          `json:"reference_id"`
+		Amount      Money           `json:"amount"`
+		Status      RepaymentStatus `json:"status,omitempty"`
+		CreatedAt   time.Time       `json:"created_at"`
+		UpdatedAt   time.Time       `json:"updated_at,omitempty"`
+	}
+
+	ReceiptResponse struct {
+		Id                   int64         `json:"id"`
+		ReceiptNumber        string        `json:"receipt_number"`
+		RepaymentId          int64         `json:"repayment_id"`
+		RepaymentReferenceId string        `json:"repayment_reference_id"`
+		LoanId               int64         `json:"loan_id"`
+		LoanReferenceId      string        `json:"loan_reference_id"`
+		UserId               int64         `json:"user_id"`
+		InstallmentNumber    int           `json:"installment_number"`
+		Amount               Money         `json:"amount"`
+		Principal            Money         `json:"principal"`
+		Interest             Money         `json:"interest"`
+		Fees                 Money         `json:"fees"`
+		Status               ReceiptStatus `json:"status"`
+		CancellationNote     string        `json:"cancellation_note,omitempty"`
+		IssuedAt             time.Time     `json:"issued_at"`
+		CancelledAt          time.Time     `json:"cancelled_at,omitempty"`
+	}
 )
+
+// Response is the loan as shown to clients, its amounts in its currency
+func (l Loan) Response() LoanResponse {
+	return LoanResponse{
+		Id:                l.Id,
+		ReferenceId:       l.ReferenceId,
+		UserId:            l.UserId,
+		Amount:            l.Money(l.Amount),
+		RatePercentage:    l.RatePercentage,
+		Status:            l.Status.String(),
+		RepaymentSchedule: l.RepaymentSchedule,
+		Tenor:             l.Tenor,
+		RepaymentAmount:   l.Money(l.RepaymentAmount),
+		Restructured:      l.IsRestructured(),
+		CreatedAt:         l.CreatedAt,
+		UpdatedAt:         l.UpdatedAt,
+	}
+}
+
+func (r Repayment) Response() RepaymentResponse {
+	return RepaymentResponse{
+		Id:          r.Id,
+		LoanId:      r.LoanId,
+		ReferenceId: r.ReferenceId,
+		Amount:      NewMoney(r.Amount, r.Currency),
+		Status:      r.Status,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+func (r Receipt) Response() ReceiptResponse {
+	money := func(amount int64) Money { return NewMoney(amount, r.Currency) }
+	return ReceiptResponse{
+		Id:                   r.Id,
+		ReceiptNumber:        r.ReceiptNumber,
+		RepaymentId:          r.RepaymentId,
+		RepaymentReferenceId: r.RepaymentReferenceId,
+		LoanId:               r.LoanId,
+		LoanReferenceId:      r.LoanReferenceId,
+		UserId:               r.UserId,
+		InstallmentNumber:    r.InstallmentNumber,
+		Amount:               money(r.Amount),
+		Principal:            money(r.Principal),
+		Interest:             money(r.Interest),
+		Fees:                 money(r.Fees),
+		Status:               r.Status,
+		CancellationNote:     r.CancellationNote,
+		IssuedAt:             r.IssuedAt,
+		CancelledAt:          r.CancelledAt,
+	}
+}
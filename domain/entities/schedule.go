@@ -0,0 +1,88 @@
+package entities
+
+import "time"
+
+type (
+	// LoanScheduleVersion is one set of terms a loan was repaid under. A new
+	// version closes the previous one and covers the installments left after
+	// InstallmentsBefore of them were paid with PaidBefore in total. A deferral
+	// version postpones them by DeferredInstallments periods.
+	LoanScheduleVersion struct {
+		Id                   int64                 `json:"id"`
+		LoanId               int64                 `json:"loan_id"`
+		Version              int                   `json:"version"`
+		Type                 ScheduleChangeType    `json:"type"`
+		Principal            int64                 `json:"principal"`
+		RatePercentage       int                   `json:"rate_percentage"`
+		Tenor                int                   `json:"tenor"`
+		RepaymentAmount      int64                 `json:"repayment_amount"`
+		RepaymentSchedule    RepaymentScheduleType `json:"repayment_schedule"`
+		InstallmentsBefore   int                   `json:"installments_before"`
+		PaidBefore           int64                 `json:"paid_before"`
+		StartAt              time.Time             `json:"start_at"`
+		DeferredInstallments int                   `json:"deferred_installments,omitempty"`
+		DeferralInterest     DeferralInterestType  `json:"deferral_interest,omitempty"`
+		DeferredInterest     int64                 `json:"deferred_interest,omitempty"`
+		Reason               string                `json:"reason,omitempty"`
+		ClosedAt             time.Time             `json:"closed_at,omitempty"`
+		CreatedAt            time.Time             `json:"created_at"`
+	}
+
+	ScheduleChangeType string
+
+	// DeferralInterestType is what happens to the interest of the deferred periods
+	DeferralInterestType string
+)
+
+const (
+	ScheduleOriginal    ScheduleChangeType = "original"
+	ScheduleRestructure ScheduleChangeType = "restructure"
+	ScheduleDeferral    ScheduleChangeType = "deferral"
+
+	// DeferralInterestCapitalized adds the interest to the principal the
+	// remaining installments are calculated from
+	DeferralInterestCapitalized DeferralInterestType = "capitalized"
+	// DeferralInterestSpread adds the interest evenly to the remaining installments
+	DeferralInterestSpread DeferralInterestType = "spread"
+	// DeferralInterestWaived forgives the interest
+	DeferralInterestWaived DeferralInterestType = "waived"
+)
+
+func (t DeferralInterestType) IsValid() bool {
+	return t == DeferralInterestCapitalized || t == DeferralInterestSpread || t == DeferralInterestWaived
+}
+
+// OriginalScheduleVersion is the version a loan starts with. It is only
+// stored once the loan is rescheduled for the first time.
+func OriginalScheduleVersion(loan Loan) LoanScheduleVersion {
+	return LoanScheduleVersion{
+		LoanId:            loan.Id,
+		Version:           1,
+		Type:              ScheduleOriginal,
+		Principal:         loan.Amount,
+		RatePercentage:    loan.RatePercentage,
+		Tenor:             loan.Tenor,
+		RepaymentAmount:   loan.RepaymentAmount,
+		RepaymentSchedule: loan.RepaymentSchedule,
+		StartAt:           loan.CreatedAt,
+		CreatedAt:         loan.CreatedAt,
+	}
+}
+
+// RemainingPrincipal is the principal of the version not yet covered after
+// the given number of installments of the loan were paid
+func (v LoanScheduleVersion) RemainingPrincipal(installmentsPaid int) int64 {
+	paid := installmentsPaid - v.InstallmentsBefore
+	if paid >= v.Tenor {
+		return 0
+	}
+	if paid < 0 {
+		paid = 0
+	}
+	return v.Principal - v.Principal*int64(paid)/int64(v.Tenor)
+}
+
+// InstallmentInterest is the flat interest part of each installment of the version
+func (v LoanScheduleVersion) InstallmentInterest() int64 {
+	return v.Principal * int64(v.RatePercentage) / 100 / int64(v.Tenor)
+}
@@ -0,0 +1,123 @@
+package entities
+
+import (
+	"io"
+	"time"
+)
+
+type (
+	// SettlementLine is one payment of a bank settlement file. A line the
+	// parser could not read keeps what it could and the reason in Error.
+	SettlementLine struct {
+		LineNumber           int       `json:"line_number"`
+		TransactionId        string    `json:"transaction_id"`
+		LoanReferenceId      string    `json:"loan_reference_id,omitempty"`
+		VirtualAccountNumber string    `json:"virtual_account_number,omitempty"`
+		Amount               int64     `json:"amount"`
+		PaidAt               time.Time `json:"paid_at,omitempty"`
+		Error                string    `json:"error,omitempty"`
+	}
+
+	// SettlementUploadRequest carries a settlement file of a bank in one of
+	// the supported formats
+	SettlementUploadRequest struct {
+		BankCode string
+		Format   string
+		FileName string
+		File     io.Reader
+	}
+
+	// SettlementFile is an uploaded settlement file, the same content of a
+	// bank is only processed once
+	SettlementFile struct {
+		Id         int64                `json:"id"`
+		BankCode   string               `json:"bank_code"`
+		Format     string               `json:"format"`
+		FileName   string               `json:"file_name"`
+		Checksum   string               `json:"checksum"`
+		Status     SettlementFileStatus `json:"status"`
+		TotalLines int                  `json:"total_lines"`
+		Posted     int                  `json:"posted"`
+		Duplicates int                  `json:"duplicates"`
+		Exceptions int                  `json:"exceptions"`
+		CreatedAt  time.Time            `json:"created_at"`
+		UpdatedAt  time.Time            `json:"updated_at,omitempty"`
+	}
+
+	SettlementLineResult struct {
+		LineNumber    int                  `json:"line_number"`
+		TransactionId string               `json:"transaction_id"`
+		Status        SettlementLineStatus `json:"status"`
+		RepaymentId   int64                `json:"repayment_id,omitempty"`
+		ExceptionId   int64                `json:"exception_id,omitempty"`
+		Error         string               `json:"error,omitempty"`
+	}
+
+	SettlementResult struct {
+		File  SettlementFile         `json:"file"`
+		Lines []SettlementLineResult `json:"lines"`
+	}
+
+	// SettlementException is a settlement line that could not be posted,
+	// operations either fix and re-post it or dismiss it with a note
+	SettlementException struct {
+		Id                   int64                     `json:"id"`
+		FileId               int64                     `json:"file_id"`
+		LineNumber           int                       `json:"line_number"`
+		BankCode             string                    `json:"bank_code"`
+		TransactionId        string                    `json:"transaction_id"`
+		LoanReferenceId      string                    `json:"loan_reference_id"`
+		VirtualAccountNumber string                    `json:"virtual_account_number"`
+		Amount               int64                     `json:"amount"`
+		PaidAt               time.Time                 `json:"paid_at,omitempty"`
+		Reason               string                    `json:"reason"`
+		Status               SettlementExceptionStatus `json:"status"`
+		RepaymentId          int64                     `json:"repayment_id,omitempty"`
+		Note                 string                    `json:"note,omitempty"`
+		CreatedAt            time.Time                 `json:"created_at"`
+		UpdatedAt            time.Time                 `json:"updated_at,omitempty"`
+	}
+
+	SettlementFileStatus      string
+	SettlementLineStatus      string
+	SettlementExceptionStatus string
+)
+
+const (
+	SettlementFileProcessing SettlementFileStatus = "processing"
+	SettlementFileCompleted  SettlementFileStatus = "completed"
+
+	SettlementLinePosted    SettlementLineStatus = "posted"
+	SettlementLineDuplicate SettlementLineStatus = "duplicate"
+	SettlementLineException SettlementLineStatus = "exception"
+
+	SettlementExceptionOpen      SettlementExceptionStatus = "open"
+	SettlementExceptionPosted    SettlementExceptionStatus = "posted"
+	SettlementExceptionDismissed SettlementExceptionStatus = "dismissed"
+)
+
+func (s SettlementExceptionStatus) IsValid() bool {
+	return s == SettlementExceptionOpen || s == SettlementExceptionPosted || s == SettlementExceptionDismissed
+}
+
+// RepaymentReferenceId is the repayment reference id of a settled payment.
+// A payment on a virtual account uses the reference of its gateway callback,
+// so a payment that was already reported by the gateway is not posted twice.
+func (l SettlementLine) RepaymentReferenceId(bankCode string) string {
+	if l.VirtualAccountNumber != "" {
+		return PaymentCallback{TransactionId: l.TransactionId}.RepaymentReferenceId(bankCode)
+	}
+	return "STL-" + bankCode + "-" + l.TransactionId
+}
+
+// Line is the settlement line the exception was raised for
+func (e SettlementException) Line() SettlementLine {
+	return SettlementLine{
+		LineNumber:           e.LineNumber,
+		TransactionId:        e.TransactionId,
+		LoanReferenceId:      e.LoanReferenceId,
+		VirtualAccountNumber: e.VirtualAccountNumber,
+		Amount:               e.Amount,
+		PaidAt:               e.PaidAt,
+	}
+}
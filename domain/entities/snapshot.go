@@ -0,0 +1,52 @@
+package entities
+
+import "time"
+
+type (
+	// LoanDailySnapshot is the state of a loan at the end of a business date.
+	// There is one row per loan and business date.
+	LoanDailySnapshot struct {
+		Id                   int64      `json:"id"`
+		BusinessDate         time.Time  `json:"business_date"`
+		LoanId               int64      `json:"loan_id"`
+		LoanReferenceId      string     `json:"loan_reference_id"`
+		UserId               int64      `json:"user_id"`
+		Status               LoanStatus `json:"status"`
+		InstallmentsPaid     int        `json:"installments_paid"`
+		PrincipalOutstanding int64      `json:"principal_outstanding"`
+		InterestOutstanding  int64      `json:"interest_outstanding"`
+		PenaltyOutstanding   int64      `json:"penalty_outstanding"`
+		DaysPastDue          int        `json:"days_past_due"`
+		AgingBucket          string     `json:"aging_bucket"`
+		CreatedAt            time.Time  `json:"created_at"`
+		UpdatedAt            time.Time  `json:"updated_at,omitempty"`
+	}
+
+	SnapshotRunResult struct {
+		BusinessDate time.Time `json:"business_date"`
+		Loans        int       `json:"loans"`
+	}
+)
+
+const (
+	AgingCurrent = "current"
+	Aging1To30   = "1-30"
+	Aging31To60  = "31-60"
+	Aging61To90  = "61-90"
+	AgingOver90  = "90+"
+)
+
+// AgingBucketOf groups days past due the way the portfolio reports do
+func AgingBucketOf(daysPastDue int) string {
+	switch {
+	case daysPastDue <= 0:
+		return AgingCurrent
+	case daysPastDue <= 30:
+		return Aging1To30
+	case daysPastDue <= 60:
+		return Aging31To60
+	case daysPastDue <= 90:
+		return Aging61To90
+	}
+	return AgingOver90
+}
@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+type (
+	// Statement is the monthly statement of all loans of a user. It is stored
+	// when first generated so later requests return exactly the same figures.
+	// Loans of different currencies are totalled apart.
+	Statement struct {
+		Id          int64            `json:"id"`
+		UserId      int64            `json:"user_id"`
+		PeriodStart time.Time        `json:"period_start"`
+		PeriodEnd   time.Time        `json:"period_end"`
+		Totals      []StatementTotal `json:"totals"`
+		Loans       []StatementLoan  `json:"loans"`
+		CreatedAt   time.Time        `json:"created_at"`
+	}
+
+	// StatementTotal adds up the loans of the statement in the currency
+	StatementTotal struct {
+		Currency       string `json:"currency"`
+		OpeningBalance int64  `json:"opening_balance"`
+		AmountBooked   int64  `json:"amount_booked"`
+		AmountDue      int64  `json:"amount_due"`
+		PaymentsTotal  int64  `json:"payments_total"`
+		Fees           int64  `json:"fees"`
+		ClosingBalance int64  `json:"closing_balance"`
+	}
+
+	// StatementLoan balances are the total payable, principal and interest, not
+	// yet repaid. ClosingBalance = OpeningBalance + AmountBooked + Fees - PaymentsTotal
+	StatementLoan struct {
+		LoanReferenceId   string                `json:"loan_reference_id"`
+		Currency          string                `json:"currency"`
+		RepaymentSchedule RepaymentScheduleType `json:"repayment_schedule"`
+		OpeningBalance    int64                 `json:"opening_balance"`
+		AmountBooked      int64                 `json:"amount_booked"`
+		InstallmentsDue   int                   `json:"installments_due"`
+		AmountDue         int64                 `json:"amount_due"`
+		PaymentsTotal     int64                 `json:"payments_total"`
+		Fees              int64                 `json:"fees"`
+		ClosingBalance    int64                 `json:"closing_balance"`
+		Payments          []StatementPayment    `json:"payments"`
+	}
+
+	StatementPayment struct {
+		ReferenceId string    `json:"reference_id"`
+		Amount      int64     `json:"amount"`
+		PaidAt      time.Time `json:"paid_at"`
+	}
+)
+
+func (s *Statement) CSVHeader() []string {
+	return []string{"period_start", "period_end", "loan_reference_id", "currency", "opening_balance", "amount_booked",
+		"installments_due", "amount_due", "payments_received", "fees", "closing_balance"}
+}
+
+func (s *Statement) CSVRows() [][]string {
+	periodStart, periodEnd := s.PeriodStart.Format(helper.DateLayout), s.PeriodEnd.Format(helper.DateLayout)
+
+	rows := make([][]string, 0, len(s.Loans)+len(s.Totals))
+	for _, loan := range s.Loans {
+		rows = append(rows, []string{periodStart, periodEnd, loan.LoanReferenceId, loan.Currency,
+			strconv.FormatInt(loan.OpeningBalance, 10), strconv.FormatInt(loan.AmountBooked, 10),
+			strconv.Itoa(loan.InstallmentsDue), strconv.FormatInt(loan.AmountDue, 10),
+			strconv.FormatInt(loan.PaymentsTotal, 10), strconv.FormatInt(loan.Fees, 10),
+			strconv.FormatInt(loan.ClosingBalance, 10)})
+	}
+	for _, total := range s.Totals {
+		rows = append(rows, []string{periodStart, periodEnd, "total", total.Currency,
+			strconv.FormatInt(total.OpeningBalance, 10), strconv.FormatInt(total.AmountBooked, 10), "",
+			strconv.FormatInt(total.AmountDue, 10), strconv.FormatInt(total.PaymentsTotal, 10),
+			strconv.FormatInt(total.Fees, 10), strconv.FormatInt(total.ClosingBalance, 10)})
+	}
+	return rows
+}
+
+func (s *Statement) PDFLines() []string {
+	lines := []string{
+		"Loan Statement",
+		"",
+		fmt.Sprintf("User ID: %d", s.UserId),
+		fmt.Sprintf("Period: %s to %s", s.PeriodStart.Format(helper.DateLayout), s.PeriodEnd.Format(helper.DateLayout)),
+	}
+
+	for _, total := range s.Totals {
+		money := func(amount int64) Money { return NewMoney(amount, total.Currency) }
+		lines = append(lines,
+			"",
+			fmt.Sprintf("Total %s", total.Currency),
+			fmt.Sprintf("  Opening balance:   %s", money(total.OpeningBalance)),
+			fmt.Sprintf("  New loans:         %s", money(total.AmountBooked)),
+			fmt.Sprintf("  Installments due:  %s", money(total.AmountDue)),
+			fmt.Sprintf("  Payments received: %s", money(total.PaymentsTotal)),
+			fmt.Sprintf("  Fees:              %s", money(total.Fees)),
+			fmt.Sprintf("  Closing balance:   %s", money(total.ClosingBalance)),
+		)
+	}
+
+	for _, loan := range s.Loans {
+		money := func(amount int64) Money { return NewMoney(amount, loan.Currency) }
+		lines = append(lines,
+			"",
+			fmt.Sprintf("Loan %s (%s)", loan.LoanReferenceId, loan.RepaymentSchedule),
+			fmt.Sprintf("  Opening balance:   %s", money(loan.OpeningBalance)),
+			fmt.Sprintf("  New loan:          %s", money(loan.AmountBooked)),
+			fmt.Sprintf("  Installments due:  %s (%d)", money(loan.AmountDue), loan.InstallmentsDue),
+			fmt.Sprintf("  Payments received: %s", money(loan.PaymentsTotal)),
+			fmt.Sprintf("  Fees:              %s", money(loan.Fees)),
+			fmt.Sprintf("  Closing balance:   %s", money(loan.ClosingBalance)),
+		)
+		for _, payment := range loan.Payments {
+			lines = append(lines, fmt.Sprintf("    %s  %s  %s", payment.PaidAt.Format(helper.DateLayout), payment.ReferenceId,
+				money(payment.Amount)))
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,57 @@
+package entities
+
+import "time"
+
+type (
+	// Tenant is a lending partner the engine runs for. Its loans, repayments
+	// and API clients are only seen by the clients of the same tenant, and
+	// reference ids only need to be unique within it.
+	Tenant struct {
+		Id   int64  `json:"id"`
+		Code string `json:"code"`
+		Name string `json:"name"`
+		// due dates, days past due and the business dates of the jobs are
+		// counted in this timezone
+		Timezone string `json:"timezone"`
+		Currency string `json:"currency"`
+		// a borrower is delinquent once a loan missed this many installments
+		DelinquentAfterMissed int       `json:"delinquent_after_missed"`
+		CreatedAt             time.Time `json:"created_at"`
+		UpdatedAt             time.Time `json:"updated_at,omitempty"`
+	}
+
+	TenantSettingsRequest struct {
+		Name                  string `json:"name"`
+		Timezone              string `json:"timezone"`
+		Currency              string `json:"currency"`
+		DelinquentAfterMissed int    `json:"delinquent_after_missed"`
+	}
+)
+
+// DefaultTenantId is the tenant everything created before tenants existed
+// belongs to
+const DefaultTenantId int64 = 1
+
+// DefaultTenant holds the settings used when no tenant is known, such as in
+// a scheduled job running for every tenant
+var DefaultTenant = Tenant{
+	Id:                    DefaultTenantId,
+	Code:                  "default",
+	Name:                  "Default",
+	Timezone:              "Asia/Jakarta",
+	Currency:              "IDR",
+	DelinquentAfterMissed: 2,
+}
+
+// Location is the timezone the business dates of the tenant are in, the
+// local timezone when the tenant has none that can be loaded
+func (t Tenant) Location() *time.Location {
+	if t.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(t.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
@@ -14,6 +14,7 @@ type (
 		ReferenceId       string                `json:"reference_id" `
 		UserId            int64                 `json:"user_id" `
 		Amount            int64                 `json:"amount" `
+		Currency          string                `json:"currency" `
 		RatePercentage    int                   `json:"rate_percentage" `
 		Status            LoanStatus            `json:"status" `
 		RepaymentSchedule RepaymentScheduleType `json:"repayment_schedule" `
@@ -21,42 +22,69 @@ type (
 		RepaymentAmount   int64                 `json:"repayment_amount" `
 		CreatedAt         time.Time             `json:"created_at" `
 		UpdatedAt         time.Time             `json:"updated_at" `
+		// a rescheduled loan keeps its installments numbered from the first
+		// one, the current schedule starts after InstallmentsOffset of them
+		// were paid with PaidOffset in total
+		ScheduleVersion    int       `json:"schedule_version" `
+		ScheduleStartAt    time.Time `json:"schedule_start_at,omitempty" `
+		InstallmentsOffset int       `json:"installments_offset,omitempty" `
+		PaidOffset         int64     `json:"paid_offset,omitempty" `
+		TenantId           int64     `json:"tenant_id" `
+		Borrower           Borrower  `json:"borrower" `
+	}
+
+	// Borrower is the personal data of the borrower of a loan, it is stored
+	// encrypted and never logged
+	Borrower struct {
+		Name        string `json:"name,omitempty"`
+		NationalId  string `json:"national_id,omitempty"`
+		BankAccount string `json:"bank_account,omitempty"`
 	}
 
 	Repayment struct {
-		Id          int64     `json:"id"`
-		LoanId      int64     `json:"loan_id"`
-		ReferenceId string    `json:"reference_id"`
-		Amount      int64     `json:"amount"`
-		CreatedAt   time.Time `json:"created_at"`
-		UpdatedAt   time.Time `json:"updated_at,omitempty"`
+		Id          int64           `json:"id"`
+		LoanId      int64           `json:"loan_id"`
+		ReferenceId string          `json:"reference_id"`
+		Amount      int64           `json:"amount"`
+		Currency    string          `json:"currency"`
+		Status      RepaymentStatus `json:"status,omitempty"`
+		CreatedAt   time.Time       `json:"created_at"`
+		UpdatedAt   time.Time       `json:"updated_at,omitempty"`
+		TenantId    int64           `json:"tenant_id"`
 	}
 
 	LoanHistory struct {
-		Loan       Loan        `json:"loan"`
-		Repayments []Repayment `json:"repayments"`
+		Loan       LoanResponse        `json:"loan"`
+		Repayments []RepaymentResponse `json:"repayments"`
 	}
 
 	OutStanding struct {
 		LoanId            int64  `json:"loan_id"`
 		LoanReferenceId   string `json:"loan_reference_id"`
-		OutstandingAmount int64  `json:"outstanding_amount"`
+		OutstandingAmount Money  `json:"outstanding_amount"`
 	}
 
 	RepaymentNeeded struct {
-		Amount  int64     `json:"amount"`
+		Amount  Money     `json:"amount"`
 		DueDate time.Time `json:"due_date"`
 		IsLate  bool      `json:"is_late"`
 	}
 
 	LoanStatus            int
 	RepaymentScheduleType string
+	RepaymentStatus       string
 )
 
 const (
 	LoanStatusActive    LoanStatus = 1
 	LoanStatusRejected  LoanStatus = 2
 	LoanStatusCompleted LoanStatus = 3
+	// a written off loan is no longer collected, only recoveries are accepted
+	LoanStatusWrittenOff LoanStatus = 4
+
+	// a reversed repayment is kept for the record but no longer counts as paid
+	RepaymentPosted   RepaymentStatus = "posted"
+	RepaymentReversed RepaymentStatus = "reversed"
 
 	RepaymentMonthly RepaymentScheduleType = "monthly"
 	RepaymentWeekly  RepaymentScheduleType = "weekly"
@@ -80,10 +108,93 @@ func (e LoanStatus) String() string {
 		return "rejected"
 	case LoanStatusCompleted:
 		return "completed"
+	case LoanStatusWrittenOff:
+		return "written_off"
 	}
 	return "unknown status " + strconv.FormatInt(int64(e), 10)
 }
 
+// String keeps the personal data of the borrower out of the logs
+func (b Borrower) String() string {
+	if b == (Borrower{}) {
+		return "{}"
+	}
+	return "{redacted}"
+}
+
+// Money is an amount of the loan, in the currency of the loan
+func (l Loan) Money(amount int64) Money {
+	return NewMoney(amount, l.Currency)
+}
+
+// TotalInterest is the flat interest charged over the whole tenor
+func (l Loan) TotalInterest() int64 {
+	return l.Amount * int64(l.RatePercentage) / 100
+}
+
+// PrincipalPaid is the part of the principal covered by the given number of
+// installments. Every installment repays principal and interest pro rata.
+func (l Loan) PrincipalPaid(installmentsPaid int) int64 {
+	if installmentsPaid >= l.Tenor {
+		return l.Amount
+	}
+	return l.Amount * int64(installmentsPaid) / int64(l.Tenor)
+}
+
+// InterestPaid is the part of the interest covered by the given number of installments
+func (l Loan) InterestPaid(installmentsPaid int) int64 {
+	if installmentsPaid >= l.Tenor {
+		return l.TotalInterest()
+	}
+	return l.TotalInterest() * int64(installmentsPaid) / int64(l.Tenor)
+}
+
+// DueDate returns when the installment with the given number, starting at 1, is due.
+// Installments paid before the current schedule are dated by the original one.
+func (l Loan) DueDate(installmentNumber int) time.Time {
+	if installmentNumber <= l.InstallmentsOffset {
+		return AddTime(l.CreatedAt, installmentNumber, l.RepaymentSchedule)
+	}
+	return AddTime(l.ScheduleStart(), installmentNumber-l.InstallmentsOffset, l.RepaymentSchedule)
+}
+
+// ScheduleStart is the date the installments of the current schedule count from
+func (l Loan) ScheduleStart() time.Time {
+	if l.ScheduleStartAt.IsZero() {
+		return l.CreatedAt
+	}
+	return l.ScheduleStartAt
+}
+
+// TotalPayable is what the borrower pays over the life of the loan under the current schedule
+func (l Loan) TotalPayable() int64 {
+	return l.PaidOffset + l.RepaymentAmount*int64(l.Tenor-l.InstallmentsOffset)
+}
+
+// MissedInstallments counts the installments of the current schedule due by
+// now, the one of the running period included, that the repayments do not cover
+func (l Loan) MissedInstallments(now time.Time, repaymentCount int) int {
+	// a deferred schedule has nothing due before it starts
+	if now.Before(l.ScheduleStart()) {
+		return 0
+	}
+	return MissRepayment(l.ScheduleStart(), now, repaymentCount-l.InstallmentsOffset, l.RepaymentSchedule)
+}
+
+func (l Loan) IsRestructured() bool {
+	return l.ScheduleVersion > 1
+}
+
+// ParseLoanStatus is the reverse of LoanStatus.String
+func ParseLoanStatus(value string) (LoanStatus, bool) {
+	for _, status := range []LoanStatus{LoanStatusActive, LoanStatusRejected, LoanStatusCompleted, LoanStatusWrittenOff} {
+		if strings.EqualFold(value, status.String()) {
+			return status, true
+		}
+	}
+	return 0, false
+}
+
 func AddTime(time time.Time, addition int, param RepaymentScheduleType) time.Time {
 	switch param {
 	case RepaymentMonthly:
@@ -0,0 +1,40 @@
+package entities
+
+import "time"
+
+type (
+	VirtualAccount struct {
+		Id        int64                `json:"id"`
+		LoanId    int64                `json:"loan_id"`
+		BankCode  string               `json:"bank_code"`
+		Number    string               `json:"number"`
+		Status    VirtualAccountStatus `json:"status"`
+		CreatedAt time.Time            `json:"created_at"`
+		UpdatedAt time.Time            `json:"updated_at,omitempty"`
+	}
+
+	// PaymentCallback is the notification a payment gateway sends when money
+	// is received on a virtual account. A callback without a currency is in
+	// the currency of the loan.
+	PaymentCallback struct {
+		TransactionId        string    `json:"transaction_id"`
+		BankCode             string    `json:"bank_code"`
+		VirtualAccountNumber string    `json:"virtual_account_number"`
+		Amount               int64     `json:"amount"`
+		Currency             string    `json:"currency,omitempty"`
+		PaidAt               time.Time `json:"paid_at"`
+	}
+
+	VirtualAccountStatus string
+)
+
+const (
+	VirtualAccountActive   VirtualAccountStatus = "active"
+	VirtualAccountInactive VirtualAccountStatus = "inactive"
+)
+
+// RepaymentReferenceId is the repayment reference id of a gateway
+// payment, so a callback retried by the gateway is only recorded once.
+func (c PaymentCallback) RepaymentReferenceId(bankCode string) string {
+	return "VA-" + bankCode + "-" + c.TransactionId
+}
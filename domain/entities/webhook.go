@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+type (
+	WebhookSubscription struct {
+		Id         int64     `json:"id"`
+		ClientKey  string    `json:"client_key"`
+		Url        string    `json:"url"`
+		EventTypes []string  `json:"event_types"`
+		Secret     string    `json:"secret,omitempty"`
+		IsActive   bool      `json:"is_active"`
+		CreatedAt  time.Time `json:"created_at"`
+		UpdatedAt  time.Time `json:"updated_at,omitempty"`
+	}
+
+	WebhookDelivery struct {
+		Id               int64                 `json:"id"`
+		SubscriptionId   int64                 `json:"subscription_id"`
+		EventId          string                `json:"event_id"`
+		EventType        string                `json:"event_type"`
+		Payload          string                `json:"payload"`
+		Status           WebhookDeliveryStatus `json:"status"`
+		Attempts         int                   `json:"attempts"`
+		NextAttemptAt    time.Time             `json:"next_attempt_at,omitempty"`
+		LastResponseCode int                   `json:"last_response_code"`
+		LastError        string                `json:"last_error,omitempty"`
+		DeliveredAt      time.Time             `json:"delivered_at,omitempty"`
+		CreatedAt        time.Time             `json:"created_at"`
+		UpdatedAt        time.Time             `json:"updated_at,omitempty"`
+	}
+
+	WebhookDeliveryAttempt struct {
+		Id           int64     `json:"id"`
+		DeliveryId   int64     `json:"delivery_id"`
+		Attempt      int       `json:"attempt"`
+		ResponseCode int       `json:"response_code"`
+		Error        string    `json:"error,omitempty"`
+		CreatedAt    time.Time `json:"created_at"`
+	}
+
+	// Event is sent to the subscribers of the tenant owning the loan it is
+	// about, whoever made the change
+	Event struct {
+		Id         string      `json:"id"`
+		Type       string      `json:"type"`
+		OccurredAt time.Time   `json:"occurred_at"`
+		Data       interface{} `json:"data"`
+		TenantId   int64       `json:"-"`
+	}
+
+	LoanEventData struct {
+		LoanId          int64  `json:"loan_id"`
+		LoanReferenceId string `json:"loan_reference_id"`
+		UserId          int64  `json:"user_id"`
+		Amount          Money  `json:"amount"`
+		Status          string `json:"status"`
+	}
+
+	PaymentEventData struct {
+		LoanId               int64  `json:"loan_id"`
+		LoanReferenceId      string `json:"loan_reference_id"`
+		RepaymentId          int64  `json:"repayment_id"`
+		RepaymentReferenceId string `json:"repayment_reference_id"`
+		Amount               Money  `json:"amount"`
+	}
+
+	WebhookDeliveryStatus string
+)
+
+const (
+	EventLoanCreated      = "loan.created"
+	EventLoanCompleted    = "loan.completed"
+	EventPaymentReceived  = "payment.received"
+	EventPaymentReversed  = "payment.reversed"
+	EventDunningEscalated = "dunning.escalated"
+
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookEventTypes lists every event a client is allowed to subscribe to.
+var WebhookEventTypes = []string{
+	EventLoanCreated,
+	EventLoanCompleted,
+	EventPaymentReceived,
+	EventPaymentReversed,
+	EventDunningEscalated,
+}
+
+func IsValidWebhookEventType(eventType string) bool {
+	for _, e := range WebhookEventTypes {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func (s WebhookSubscription) IsSubscribedTo(eventType string) bool {
+	for _, e := range s.EventTypes {
+		if strings.EqualFold(e, eventType) {
+			return true
+		}
+	}
+	return false
+}
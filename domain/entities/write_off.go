@@ -0,0 +1,57 @@
+package entities
+
+import "time"
+
+type (
+	// WriteOff records the amount still owed when a loan was written off.
+	// A loan is written off at most once.
+	WriteOff struct {
+		Id              int64        `json:"id"`
+		LoanId          int64        `json:"loan_id"`
+		LoanReferenceId string       `json:"loan_reference_id"`
+		Amount          int64        `json:"amount"`
+		DaysPastDue     int          `json:"days_past_due"`
+		Type            WriteOffType `json:"type"`
+		Reason          string       `json:"reason"`
+		CreatedAt       time.Time    `json:"created_at"`
+	}
+
+	// Recovery is money collected on a written off loan. It is kept apart from
+	// the repayments so it never changes the installments of the loan.
+	Recovery struct {
+		Id          int64     `json:"id"`
+		LoanId      int64     `json:"loan_id"`
+		ReferenceId string    `json:"reference_id"`
+		Amount      int64     `json:"amount"`
+		CreatedAt   time.Time `json:"created_at"`
+	}
+
+	WriteOffDetail struct {
+		WriteOff        WriteOff   `json:"write_off"`
+		Recoveries      []Recovery `json:"recoveries"`
+		RecoveredAmount int64      `json:"recovered_amount"`
+		RemainingAmount int64      `json:"remaining_amount"`
+	}
+
+	// WriteOffPolicy writes off active loans automatically once they are
+	// DaysPastDue days late. Zero turns the automatic write-off off.
+	WriteOffPolicy struct {
+		DaysPastDue int
+	}
+
+	WriteOffRunResult struct {
+		BusinessDate time.Time `json:"business_date"`
+		Loans        int       `json:"loans"`
+		WrittenOff   int       `json:"written_off"`
+		OnPromise    int       `json:"on_promise"`
+	}
+
+	WriteOffType string
+)
+
+const (
+	WriteOffManual    WriteOffType = "manual"
+	WriteOffAutomatic WriteOffType = "automatic"
+)
+
+var DefaultWriteOffPolicy = WriteOffPolicy{DaysPastDue: 180}
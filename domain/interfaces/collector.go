@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/domain/payment_collector.go -package=mock_domain github.com/sirait-kevin/BillingEngine/domain/interfaces PaymentCollector
+type PaymentCollector interface {
+	Debit(ctx context.Context, mandate entities.DebitMandate, instruction entities.DebitInstruction) (entities.DebitResult, error)
+}
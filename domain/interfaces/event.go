@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/domain/event_publisher.go -package=mock_domain github.com/sirait-kevin/BillingEngine/domain/interfaces EventPublisher
+type EventPublisher interface {
+	Publish(ctx context.Context, event entities.Event) error
+}
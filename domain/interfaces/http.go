@@ -0,0 +1,8 @@
+package interfaces
+
+import "net/http"
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/domain/http_client.go -package=mock_domain github.com/sirait-kevin/BillingEngine/domain/interfaces HTTPClient
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// NonceStore remembers the nonces of signed requests until they expire.
+//
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/domain/nonce_store.go -package=mock_domain github.com/sirait-kevin/BillingEngine/domain/interfaces NonceStore
+type NonceStore interface {
+	// UseNonce reports false when the client already used the nonce and it
+	// has not expired yet
+	UseNonce(ctx context.Context, clientKey, nonce string, now, expiresAt time.Time) (bool, error)
+	PurgeExpiredNonces(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/domain/notifier.go -package=mock_domain github.com/sirait-kevin/BillingEngine/domain/interfaces Notifier
+type Notifier interface {
+	Channel() entities.NotificationChannel
+	Send(ctx context.Context, message entities.NotificationMessage) error
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// RateLimitStore keeps the token buckets of the rate limiter.
+//
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/domain/rate_limit_store.go -package=mock_domain github.com/sirait-kevin/BillingEngine/domain/interfaces RateLimitStore
+type RateLimitStore interface {
+	// TakeToken takes a token from the bucket, filling it up to the limit
+	// first when it is used for the first time
+	TakeToken(ctx context.Context, bucket string, limit entities.RateLimit, now time.Time) (*entities.RateLimitResult, error)
+}
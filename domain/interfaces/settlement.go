@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"io"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/domain/settlement_parser.go -package=mock_domain github.com/sirait-kevin/BillingEngine/domain/interfaces SettlementParser
+type SettlementParser interface {
+	Format() string
+	Parse(r io.Reader) ([]entities.SettlementLine, error)
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/domain/virtual_account_generator.go -package=mock_domain github.com/sirait-kevin/BillingEngine/domain/interfaces VirtualAccountGenerator
+type VirtualAccountGenerator interface {
+	BankCode() string
+	Generate(ctx context.Context, loan entities.Loan) (string, error)
+}
@@ -4,75 +4,404 @@ import (
 	"bytes"
 	"context"
 	"crypto/hmac"
+	"expvar"
 	"io/ioutil"
 	"log"
+	"math"
+	"net"
 	"net/http"
-	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
 	"github.com/sirait-kevin/BillingEngine/pkg/errs"
 	"github.com/sirait-kevin/BillingEngine/pkg/helper"
 	"github.com/sirait-kevin/BillingEngine/pkg/logger"
 )
 
-// Mocked client keys and secrets for simplicity
-var clientSecrets = map[string]string{
-	"client1": "secret1",
-	"client2": "secret2",
-	// Add more clients and their secrets here
+// ClientAuthenticator looks up what a Client-Key may sign requests with and do
+//
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/middleware/ClientAuthenticator.go -package=mock_middleware github.com/sirait-kevin/BillingEngine/handlers/middleware ClientAuthenticator
+type ClientAuthenticator interface {
+	GetClientCredentials(ctx context.Context, clientKey string) (*entities.ClientCredentials, error)
 }
 
-func VerifySignatureMiddleware(next http.Handler) http.Handler {
+// AuthAuditor records the requests turned away and tells when a Client-Key
+// failed too often to be let through for now
+//
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/middleware/AuthAuditor.go -package=mock_middleware github.com/sirait-kevin/BillingEngine/handlers/middleware AuthAuditor
+type AuthAuditor interface {
+	RecordAuthFailure(ctx context.Context, failure entities.AuthFailure) error
+	IsClientBlocked(ctx context.Context, clientKey, sourceIp string) (bool, error)
+}
+
+const (
+	defaultSignatureWindow = 5 * time.Minute
+	maxNonceLength         = 64
+	maxRequestIdLength     = 64
+)
+
+// ReplayProtection rejects the signed requests whose X-Timestamp, in unix
+// seconds, is more than Window away from now, and the ones reusing an X-Nonce
+// of the client while the request it was first used with is still in the window.
+type ReplayProtection struct {
+	Nonces interfaces.NonceStore
+	Clock  interfaces.Clock
+	Window time.Duration
+}
+
+// RateLimits configures RateLimitMiddleware, Routes is keyed like RouteScopes
+type RateLimits struct {
+	Store   interfaces.RateLimitStore
+	Clock   interfaces.Clock
+	Default entities.RateLimit
+	Routes  map[string]entities.RateLimit
+}
+
+// the usage of every client and route, served with the other expvar metrics
+var (
+	rateLimitAllowed  = expvar.NewMap("rate_limit_allowed")
+	rateLimitRejected = expvar.NewMap("rate_limit_rejected")
+)
+
+// RouteScopes maps "METHOD /path/template" to the scope the route requires.
+// Unlisted routes under /admin require the admin scope, other unlisted GET
+// routes the read scope and the rest the write scope.
+type RouteScopes map[string]entities.ApiScope
+
+func (s RouteScopes) ScopeOf(r *http.Request) entities.ApiScope {
+	path := routePath(r)
+	if scope, ok := s[r.Method+" "+path]; ok {
+		return scope
+	}
+	if path == "/admin" || strings.HasPrefix(path, "/admin/") {
+		return entities.ScopeAdmin
+	}
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return entities.ScopeRead
+	}
+	return entities.ScopeWrite
+}
+
+// routePath is the path template of the matched route, so every loan shares
+// the route of /loan/{id}
+func routePath(r *http.Request) string {
+	var path string
+	if route := mux.CurrentRoute(r); route != nil {
+		path, _ = route.GetPathTemplate()
+	}
+	if path == "" {
+		path = r.URL.Path
+	}
+	return path
+}
+
+// VerifySignatureMiddleware lets through the requests signed with a secret of
+// an active client whose scopes cover the route, see helper.SignedRequestData.
+// A stale request is answered with 408, a replayed one with 409 and a client
+// key blocked for the source ip after repeated wrong signatures with 429.
+// A request turned away is recorded with the auditor once its client key is
+// known, so requests with made up keys can not fill the audit. It must run
+// after LoggingMiddleware, the clients are looked up in the database.
+func VerifySignatureMiddleware(clients ClientAuthenticator, scopes RouteScopes, replay ReplayProtection, auditor AuthAuditor) func(http.Handler) http.Handler {
+	window := replay.Window
+	if window <= 0 {
+		window = defaultSignatureWindow
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			clientKey := r.Header.Get("Client-Key")
+			signature := r.Header.Get("X-Signature")
+			timestamp := r.Header.Get("X-Timestamp")
+			nonce := r.Header.Get("X-Nonce")
+			sourceIp := sourceIpOf(r)
+
+			var credentials *entities.ClientCredentials
+			reject := func(reason entities.AuthFailureReason, err error) {
+				if credentials != nil {
+					recordAuthFailure(ctx, auditor, r, clientKey, sourceIp, reason)
+				}
+				helper.JSON(w, ctx, nil, err)
+			}
+
+			if clientKey == "" || signature == "" || timestamp == "" || nonce == "" {
+				reject(entities.AuthFailureMissingCredentials,
+					errs.NewWithMessage(http.StatusBadRequest, "Missing Client-Key, signature, timestamp or nonce"))
+				return
+			}
+
+			isBlocked, err := auditor.IsClientBlocked(ctx, clientKey, sourceIp)
+			if err != nil {
+				helper.JSON(w, ctx, nil, err)
+				return
+			}
+			if isBlocked {
+				helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusTooManyRequests, "Too many failed authentications, try again later"))
+				return
+			}
+
+			credentials, err = clients.GetClientCredentials(ctx, clientKey)
+			if err != nil {
+				if errs.GetHTTPCode(err) == http.StatusUnauthorized {
+					reject(entities.AuthFailureInvalidClientKey, err)
+					return
+				}
+				helper.JSON(w, ctx, nil, err)
+				return
+			}
+			// a request without a tenant would see every tenant, see OperatorOnlyMiddleware
+			if credentials.TenantId <= 0 {
+				reject(entities.AuthFailureInvalidClientKey,
+					errs.NewWithMessage(http.StatusUnauthorized, "Client-Key does not belong to a tenant"))
+				return
+			}
+
+			if len(nonce) > maxNonceLength {
+				reject(entities.AuthFailureInvalidNonce,
+					errs.NewWithMessage(http.StatusBadRequest, "Nonce can not be longer than 64 characters"))
+				return
+			}
+			if !helper.IsValidNonce(nonce) {
+				reject(entities.AuthFailureInvalidNonce,
+					errs.NewWithMessage(http.StatusBadRequest, "Nonce can not contain a line break or a slash"))
+				return
+			}
+			unix, err := strconv.ParseInt(timestamp, 10, 64)
+			if err != nil {
+				reject(entities.AuthFailureInvalidTimestamp, errs.NewWithMessage(http.StatusBadRequest, "Invalid timestamp"))
+				return
+			}
+
+			now := replay.Clock.Now()
+			signedAt := time.Unix(unix, 0)
+			if signedAt.Before(now.Add(-window)) || signedAt.After(now.Add(window)) {
+				reject(entities.AuthFailureStaleRequest,
+					errs.NewWithMessage(http.StatusRequestTimeout, "Request timestamp is outside the allowed window"))
+				return
+			}
+
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusInternalServerError, "Error reading request body"))
+				return
+			}
+			r.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+
+			// during a rotation the previous secret is valid as well
+			var isValid bool
+			for _, secret := range credentials.Secrets {
+				expectedSignature := helper.GenerateSignature(secret, helper.SignedRequestData(timestamp, nonce, r.RequestURI, body))
+				if hmac.Equal([]byte(expectedSignature), []byte(signature)) {
+					isValid = true
+					break
+				}
+			}
+			if !isValid {
+				reject(entities.AuthFailureInvalidSignature, errs.NewWithMessage(http.StatusUnauthorized, "Invalid signature"))
+				return
+			}
+
+			// the nonce is only spent by correctly signed requests, it is remembered
+			// until a request signed at the same time would be stale anyway
+			isFresh, err := replay.Nonces.UseNonce(ctx, clientKey, nonce, now, signedAt.Add(window))
+			if err != nil {
+				helper.JSON(w, ctx, nil, err)
+				return
+			}
+			if !isFresh {
+				reject(entities.AuthFailureReplayedRequest,
+					errs.NewWithMessage(http.StatusConflict, "Request nonce has already been used"))
+				return
+			}
+
+			if !entities.HasScope(credentials.Scopes, scopes.ScopeOf(r)) {
+				reject(entities.AuthFailureScopeDenied,
+					errs.NewWithMessage(http.StatusForbidden, "Client-Key is not allowed to use this endpoint"))
+				return
+			}
+
+			ctx = context.WithValue(ctx, "client_key", clientKey)
+			ctx = context.WithValue(ctx, "client_scopes", credentials.Scopes)
+			ctx = helper.WithTenantId(ctx, credentials.TenantId)
+			ctx = context.WithValue(ctx, "client_rate_limit", credentials.RateLimit)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// recordAuthFailure only logs when the failure can not be recorded, the
+// request is turned away either way
+func recordAuthFailure(ctx context.Context, auditor AuthAuditor, r *http.Request, clientKey, sourceIp string, reason entities.AuthFailureReason) {
+	err := auditor.RecordAuthFailure(ctx, entities.AuthFailure{
+		ClientKey: clientKey,
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		Reason:    reason,
+		SourceIp:  sourceIp,
+	})
+	if err != nil {
+		if logger, ok := ctx.Value("logger").(*logrus.Entry); ok {
+			logger.Error("Error recording auth failure: ", err)
+		}
+	}
+}
+
+func sourceIpOf(r *http.Request) string {
+	sourceIp, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return sourceIp
+}
+
+// RateLimitMiddleware gives every client a token bucket per route, so one
+// partner can not flood the others out. The limit is the one of the client,
+// or else the one of the route in Routes, or else Default. It must run after
+// VerifySignatureMiddleware.
+func RateLimitMiddleware(limits RateLimits) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			clientKey := helper.GetClientKey(ctx)
+			route := r.Method + " " + routePath(r)
+
+			limit, _ := ctx.Value("client_rate_limit").(entities.RateLimit)
+			if limit.IsZero() {
+				limit = limits.Routes[route]
+			}
+			if limit.IsZero() {
+				limit = limits.Default
+			}
+			if clientKey == "" || limit.RequestsPerMinute <= 0 || limit.Burst <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// the requests are let through when the store is down rather than
+			// turning every client away
+			result, err := limits.Store.TakeToken(ctx, clientKey+" "+route, limit, limits.Clock.Now())
+			if err != nil {
+				if logger, ok := ctx.Value("logger").(*logrus.Entry); ok {
+					logger.Error("Error taking rate limit token: ", err)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit.Burst))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			if !result.Allowed {
+				rateLimitRejected.Add(clientKey+" "+route, 1)
+				retryAfter := int(math.Ceil(result.RetryAfter.Seconds()))
+				if retryAfter < 1 {
+					retryAfter = 1
+				}
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusTooManyRequests, "Rate limit exceeded, try again later"))
+				return
+			}
+
+			rateLimitAllowed.Add(clientKey+" "+route, 1)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// VerifyCallbackSignatureMiddleware checks notifications sent by the payment
+// gateway, which sign the raw body with the secret shared with us. The gateway
+// pays into the virtual accounts of every tenant, so the request is run as the
+// operator and the payment is booked under the tenant of the loan.
+func VerifyCallbackSignatureMiddleware(secret string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			signature := r.Header.Get("X-Callback-Signature")
+			if signature == "" {
+				helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Missing callback signature"))
+				return
+			}
+			if secret == "" {
+				helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusUnauthorized, "Callback secret is not configured"))
+				return
+			}
+
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusInternalServerError, "Error reading request body"))
+				return
+			}
+			r.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+
+			expectedSignature := helper.GenerateSignature(secret, string(body))
+			if !hmac.Equal([]byte(expectedSignature), []byte(signature)) {
+				helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusUnauthorized, "Invalid callback signature"))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(helper.WithOperator(ctx)))
+		})
+	}
+}
+
+// AdminOnlyMiddleware lets through only the clients with the admin scope. It
+// must run after VerifySignatureMiddleware.
+func AdminOnlyMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
-		clientKey := r.Header.Get("Client-Key")
-		signature := r.Header.Get("X-Signature")
-		if clientKey == "" || signature == "" {
-			helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Missing Client-Key or signature"))
-			return
-		}
+		scopes, _ := ctx.Value("client_scopes").([]entities.ApiScope)
 
-		secret, ok := clientSecrets[clientKey]
-		if !ok {
-			helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusUnauthorized, "Invalid Client-Key"))
+		if helper.GetClientKey(ctx) != "" && entities.HasScope(scopes, entities.ScopeAdmin) {
+			next.ServeHTTP(w, r)
 			return
 		}
 
-		body, err := ioutil.ReadAll(r.Body)
-		if err != nil {
-			helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusInternalServerError, "Error reading request body"))
-			return
-		}
-		r.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusForbidden, "Client-Key is not allowed to use admin endpoints"))
+	})
+}
 
-		expectedSignature := helper.GenerateSignature(secret, r.RequestURI+string(body))
+// OperatorOnlyMiddleware lets through only the admin clients of the default
+// tenant, the operator running the engine for every tenant. The settlement
+// files, the bank statements and the jobs are not any tenant's, so the
+// request is run as the operator and sees every tenant. It must run after
+// AdminOnlyMiddleware.
+func OperatorOnlyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
 
-		if !hmac.Equal([]byte(expectedSignature), []byte(signature)) {
-			if os.Getenv("DEBUG_MODE") == "true" {
-				helper.JSON(w, ctx, map[string]interface{}{
-					"expected_signature": expectedSignature,
-				}, errs.NewWithMessage(http.StatusUnauthorized, "Invalid signature"))
-			} else {
-				helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusUnauthorized, "Invalid signature"))
-			}
+		if helper.GetTenantId(ctx) != entities.DefaultTenantId {
+			helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusForbidden, "Client-Key is not allowed to use operator endpoints"))
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(helper.WithOperator(ctx)))
 	})
 }
 
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
+		// the X-Request-Id of the caller is kept so its logs and the audit log
+		// can be matched with ours, else one is given to the request
+		requestId := r.Header.Get("X-Request-Id")
+		if requestId == "" || len(requestId) > maxRequestIdLength {
+			requestId, _ = helper.GenerateRandomString(16)
+		}
+		w.Header().Set("X-Request-Id", requestId)
 		logger := logger.Log.WithFields(logrus.Fields{
-			"method": r.Method,
-			"url":    r.URL.String(),
+			"method":     r.Method,
+			"url":        r.URL.String(),
+			"request_id": requestId,
 		})
 
 		ctx := context.WithValue(r.Context(), "logger", logger)
+		ctx = helper.WithRequestId(ctx, requestId)
 		rw := &responseWriter{w, http.StatusOK}
 		next.ServeHTTP(rw, r.WithContext(ctx))
 
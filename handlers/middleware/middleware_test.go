@@ -0,0 +1,555 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_middleware "github.com/sirait-kevin/BillingEngine/mocks/middleware"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func TestVerifySignatureMiddleware(t *testing.T) {
+	now := time.Unix(1714557600, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	credentials := &entities.ClientCredentials{
+		ClientKey: "client1",
+		TenantId:  2,
+		Secrets:   []string{"new-secret", "old-secret"},
+		Scopes:    []entities.ApiScope{entities.ScopeRead},
+	}
+	// signedRequest is a request of client1 signed with the secret
+	signedRequest := func(method, target, body, timestamp, nonce, secret string) *http.Request {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Client-Key", "client1")
+		r.Header.Set("X-Timestamp", timestamp)
+		r.Header.Set("X-Nonce", nonce)
+		r.Header.Set("X-Signature", helper.GenerateSignature(secret, helper.SignedRequestData(timestamp, nonce, target, []byte(body))))
+		return r
+	}
+	authFailure := func(r *http.Request, reason entities.AuthFailureReason) entities.AuthFailure {
+		return entities.AuthFailure{
+			ClientKey: r.Header.Get("Client-Key"),
+			Method:    r.Method,
+			Path:      r.URL.Path,
+			Reason:    reason,
+			SourceIp:  "192.0.2.1",
+		}
+	}
+
+	type fields struct {
+		Clients *mock_middleware.MockClientAuthenticator
+		Auditor *mock_middleware.MockAuthAuditor
+		Nonces  *mock_domain.MockNonceStore
+		Clock   *mock_domain.MockClock
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+		wantNext bool
+	}{
+		{
+			name: "success signed with the previous secret during a rotation",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Clients: mock_middleware.NewMockClientAuthenticator(ctrl),
+					Auditor: mock_middleware.NewMockAuthAuditor(ctrl),
+					Nonces:  mock_domain.NewMockNonceStore(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: signedRequest("GET", "/payment/history?user_id=1", "", timestamp, "nonce1", "old-secret"),
+			},
+			mock: func(f fields, args args) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.Auditor.EXPECT().IsClientBlocked(gomock.Any(), "client1", "192.0.2.1").Return(false, nil)
+				f.Clients.EXPECT().GetClientCredentials(gomock.Any(), "client1").Return(credentials, nil)
+				f.Nonces.EXPECT().UseNonce(gomock.Any(), "client1", "nonce1", now, now.Add(5*time.Minute)).Return(true, nil)
+			},
+			wantCode: 200,
+			wantNext: true,
+		},
+		{
+			name: "error missing nonce is not recorded",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Clients: mock_middleware.NewMockClientAuthenticator(ctrl),
+					Auditor: mock_middleware.NewMockAuthAuditor(ctrl),
+					Nonces:  mock_domain.NewMockNonceStore(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: signedRequest("GET", "/payment/history?user_id=1", "", timestamp, "", "new-secret"),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error nonce holding a slash",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Clients: mock_middleware.NewMockClientAuthenticator(ctrl),
+					Auditor: mock_middleware.NewMockAuthAuditor(ctrl),
+					Nonces:  mock_domain.NewMockNonceStore(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: signedRequest("GET", "/history?user_id=1", "", timestamp, "nonce1/payment", "new-secret"),
+			},
+			mock: func(f fields, args args) {
+				f.Auditor.EXPECT().IsClientBlocked(gomock.Any(), "client1", "192.0.2.1").Return(false, nil)
+				f.Clients.EXPECT().GetClientCredentials(gomock.Any(), "client1").Return(credentials, nil)
+				f.Auditor.EXPECT().RecordAuthFailure(gomock.Any(), authFailure(args.r, entities.AuthFailureInvalidNonce)).Return(nil)
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error stale request",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Clients: mock_middleware.NewMockClientAuthenticator(ctrl),
+					Auditor: mock_middleware.NewMockAuthAuditor(ctrl),
+					Nonces:  mock_domain.NewMockNonceStore(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: signedRequest("GET", "/payment/history?user_id=1", "",
+					strconv.FormatInt(now.Add(-6*time.Minute).Unix(), 10), "nonce1", "new-secret"),
+			},
+			mock: func(f fields, args args) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.Auditor.EXPECT().IsClientBlocked(gomock.Any(), "client1", "192.0.2.1").Return(false, nil)
+				f.Clients.EXPECT().GetClientCredentials(gomock.Any(), "client1").Return(credentials, nil)
+				f.Auditor.EXPECT().RecordAuthFailure(gomock.Any(), authFailure(args.r, entities.AuthFailureStaleRequest)).Return(nil)
+			},
+			wantCode: 408,
+		},
+		{
+			name: "error unknown client key is not recorded",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Clients: mock_middleware.NewMockClientAuthenticator(ctrl),
+					Auditor: mock_middleware.NewMockAuthAuditor(ctrl),
+					Nonces:  mock_domain.NewMockNonceStore(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: signedRequest("GET", "/payment/history?user_id=1", "", timestamp, "nonce1", "new-secret"),
+			},
+			mock: func(f fields, args args) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.Auditor.EXPECT().IsClientBlocked(gomock.Any(), "client1", "192.0.2.1").Return(false, nil)
+				f.Clients.EXPECT().GetClientCredentials(gomock.Any(), "client1").
+					Return(nil, errs.NewWithMessage(http.StatusUnauthorized, "Invalid Client-Key"))
+			},
+			wantCode: 401,
+		},
+		{
+			name: "error client without a tenant",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Clients: mock_middleware.NewMockClientAuthenticator(ctrl),
+					Auditor: mock_middleware.NewMockAuthAuditor(ctrl),
+					Nonces:  mock_domain.NewMockNonceStore(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: signedRequest("GET", "/payment/history?user_id=1", "", timestamp, "nonce1", "new-secret"),
+			},
+			mock: func(f fields, args args) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.Auditor.EXPECT().IsClientBlocked(gomock.Any(), "client1", "192.0.2.1").Return(false, nil)
+				f.Clients.EXPECT().GetClientCredentials(gomock.Any(), "client1").Return(&entities.ClientCredentials{
+					ClientKey: "client1",
+					Secrets:   []string{"new-secret"},
+					Scopes:    []entities.ApiScope{entities.ScopeRead},
+				}, nil)
+				f.Auditor.EXPECT().RecordAuthFailure(gomock.Any(), authFailure(args.r, entities.AuthFailureInvalidClientKey)).Return(nil)
+			},
+			wantCode: 401,
+		},
+		{
+			name: "error invalid signature",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Clients: mock_middleware.NewMockClientAuthenticator(ctrl),
+					Auditor: mock_middleware.NewMockAuthAuditor(ctrl),
+					Nonces:  mock_domain.NewMockNonceStore(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: signedRequest("GET", "/payment/history?user_id=1", "", timestamp, "nonce1", "wrong-secret"),
+			},
+			mock: func(f fields, args args) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.Auditor.EXPECT().IsClientBlocked(gomock.Any(), "client1", "192.0.2.1").Return(false, nil)
+				f.Clients.EXPECT().GetClientCredentials(gomock.Any(), "client1").Return(credentials, nil)
+				f.Auditor.EXPECT().RecordAuthFailure(gomock.Any(), authFailure(args.r, entities.AuthFailureInvalidSignature)).Return(nil)
+			},
+			wantCode: 401,
+		},
+		{
+			name: "error signature moved to another nonce and uri",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Clients: mock_middleware.NewMockClientAuthenticator(ctrl),
+					Auditor: mock_middleware.NewMockAuthAuditor(ctrl),
+					Nonces:  mock_domain.NewMockNonceStore(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					// signed as nonce "abc1" and "/payment/history", replayed as nonce "abc" and "1/payment/history"
+					signed := signedRequest("GET", "/payment/history?user_id=1", "", timestamp, "abc1", "new-secret")
+					r := signedRequest("GET", "/payment/history?user_id=1", "", timestamp, "abc", "new-secret")
+					r.RequestURI = "1/payment/history?user_id=1"
+					r.Header.Set("X-Signature", signed.Header.Get("X-Signature"))
+					return r
+				}(),
+			},
+			mock: func(f fields, args args) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.Auditor.EXPECT().IsClientBlocked(gomock.Any(), "client1", "192.0.2.1").Return(false, nil)
+				f.Clients.EXPECT().GetClientCredentials(gomock.Any(), "client1").Return(credentials, nil)
+				f.Auditor.EXPECT().RecordAuthFailure(gomock.Any(), authFailure(args.r, entities.AuthFailureInvalidSignature)).Return(nil)
+			},
+			wantCode: 401,
+		},
+		{
+			name: "error replayed nonce",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Clients: mock_middleware.NewMockClientAuthenticator(ctrl),
+					Auditor: mock_middleware.NewMockAuthAuditor(ctrl),
+					Nonces:  mock_domain.NewMockNonceStore(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: signedRequest("GET", "/payment/history?user_id=1", "", timestamp, "nonce1", "new-secret"),
+			},
+			mock: func(f fields, args args) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.Auditor.EXPECT().IsClientBlocked(gomock.Any(), "client1", "192.0.2.1").Return(false, nil)
+				f.Clients.EXPECT().GetClientCredentials(gomock.Any(), "client1").Return(credentials, nil)
+				f.Nonces.EXPECT().UseNonce(gomock.Any(), "client1", "nonce1", now, now.Add(5*time.Minute)).Return(false, nil)
+				f.Auditor.EXPECT().RecordAuthFailure(gomock.Any(), authFailure(args.r, entities.AuthFailureReplayedRequest)).Return(nil)
+			},
+			wantCode: 409,
+		},
+		{
+			name: "error scope does not cover the route",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Clients: mock_middleware.NewMockClientAuthenticator(ctrl),
+					Auditor: mock_middleware.NewMockAuthAuditor(ctrl),
+					Nonces:  mock_domain.NewMockNonceStore(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: signedRequest("POST", "/payment", `{"amount":100}`, timestamp, "nonce1", "new-secret"),
+			},
+			mock: func(f fields, args args) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.Auditor.EXPECT().IsClientBlocked(gomock.Any(), "client1", "192.0.2.1").Return(false, nil)
+				f.Clients.EXPECT().GetClientCredentials(gomock.Any(), "client1").Return(credentials, nil)
+				f.Nonces.EXPECT().UseNonce(gomock.Any(), "client1", "nonce1", now, now.Add(5*time.Minute)).Return(true, nil)
+				f.Auditor.EXPECT().RecordAuthFailure(gomock.Any(), authFailure(args.r, entities.AuthFailureScopeDenied)).Return(nil)
+			},
+			wantCode: 403,
+		},
+		{
+			name: "error blocked client",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Clients: mock_middleware.NewMockClientAuthenticator(ctrl),
+					Auditor: mock_middleware.NewMockAuthAuditor(ctrl),
+					Nonces:  mock_domain.NewMockNonceStore(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: signedRequest("GET", "/payment/history?user_id=1", "", timestamp, "nonce1", "new-secret"),
+			},
+			mock: func(f fields, args args) {
+				f.Auditor.EXPECT().IsClientBlocked(gomock.Any(), "client1", "192.0.2.1").Return(true, nil)
+			},
+			wantCode: 429,
+		},
+		{
+			name: "error nonce store",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Clients: mock_middleware.NewMockClientAuthenticator(ctrl),
+					Auditor: mock_middleware.NewMockAuthAuditor(ctrl),
+					Nonces:  mock_domain.NewMockNonceStore(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: signedRequest("GET", "/payment/history?user_id=1", "", timestamp, "nonce1", "new-secret"),
+			},
+			mock: func(f fields, args args) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.Auditor.EXPECT().IsClientBlocked(gomock.Any(), "client1", "192.0.2.1").Return(false, nil)
+				f.Clients.EXPECT().GetClientCredentials(gomock.Any(), "client1").Return(credentials, nil)
+				f.Nonces.EXPECT().UseNonce(gomock.Any(), "client1", "nonce1", now, now.Add(5*time.Minute)).Return(false, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			tt.mock(f, tt.args)
+
+			var nextCalled bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+				assert.Equal(t, "client1", helper.GetClientKey(r.Context()))
+				assert.Equal(t, int64(2), helper.GetTenantId(r.Context()))
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := VerifySignatureMiddleware(f.Clients, RouteScopes{"POST /payment": entities.ScopePayments},
+				ReplayProtection{Nonces: f.Nonces, Clock: f.Clock}, f.Auditor)(next)
+
+			handler.ServeHTTP(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+			assert.Equal(t, tt.wantNext, nextCalled)
+		})
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	clientLimit := entities.RateLimit{RequestsPerMinute: 60, Burst: 10}
+	withClient := func(r *http.Request, limit entities.RateLimit) *http.Request {
+		ctx := helper.WithTenantId(r.Context(), 1)
+		ctx = context.WithValue(ctx, "client_key", "client1")
+		ctx = context.WithValue(ctx, "client_rate_limit", limit)
+		return r.WithContext(ctx)
+	}
+
+	type fields struct {
+		Store *mock_domain.MockRateLimitStore
+		Clock *mock_domain.MockClock
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name        string
+		fields      func(ctrl *gomock.Controller) fields
+		args        args
+		mock        func(f fields, args args)
+		wantCode    int
+		wantHeaders map[string]string
+	}{
+		{
+			name: "success within the limit of the client",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Store: mock_domain.NewMockRateLimitStore(ctrl),
+					Clock: mock_domain.NewMockClock(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: withClient(httptest.NewRequest("GET", "/payment/history", nil), clientLimit),
+			},
+			mock: func(f fields, args args) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.Store.EXPECT().TakeToken(gomock.Any(), "client1 GET /payment/history", clientLimit, now).
+					Return(&entities.RateLimitResult{Allowed: true, Remaining: 9}, nil)
+			},
+			wantCode:    200,
+			wantHeaders: map[string]string{"X-RateLimit-Limit": "10", "X-RateLimit-Remaining": "9"},
+		},
+		{
+			name: "success limit of the route when the client has none",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Store: mock_domain.NewMockRateLimitStore(ctrl),
+					Clock: mock_domain.NewMockClock(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: withClient(httptest.NewRequest("POST", "/payment", nil), entities.RateLimit{}),
+			},
+			mock: func(f fields, args args) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.Store.EXPECT().TakeToken(gomock.Any(), "client1 POST /payment", entities.RateLimit{RequestsPerMinute: 30, Burst: 5}, now).
+					Return(&entities.RateLimitResult{Allowed: true, Remaining: 4}, nil)
+			},
+			wantCode:    200,
+			wantHeaders: map[string]string{"X-RateLimit-Limit": "5", "X-RateLimit-Remaining": "4"},
+		},
+		{
+			name: "success store down lets the request through",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Store: mock_domain.NewMockRateLimitStore(ctrl),
+					Clock: mock_domain.NewMockClock(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: withClient(httptest.NewRequest("GET", "/payment/history", nil), clientLimit),
+			},
+			mock: func(f fields, args args) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.Store.EXPECT().TakeToken(gomock.Any(), gomock.Any(), clientLimit, now).Return(nil, errors.New("some error"))
+			},
+			wantCode: 200,
+		},
+		{
+			name: "success request without a client is not limited",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Store: mock_domain.NewMockRateLimitStore(ctrl),
+					Clock: mock_domain.NewMockClock(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "/payment/history", nil),
+			},
+			mock:     func(f fields, args args) {},
+			wantCode: 200,
+		},
+		{
+			name: "error limit exceeded",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Store: mock_domain.NewMockRateLimitStore(ctrl),
+					Clock: mock_domain.NewMockClock(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: withClient(httptest.NewRequest("GET", "/payment/history", nil), clientLimit),
+			},
+			mock: func(f fields, args args) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.Store.EXPECT().TakeToken(gomock.Any(), "client1 GET /payment/history", clientLimit, now).
+					Return(&entities.RateLimitResult{RetryAfter: 1500 * time.Millisecond}, nil)
+			},
+			wantCode:    429,
+			wantHeaders: map[string]string{"Retry-After": "2", "X-RateLimit-Limit": "10", "X-RateLimit-Remaining": "0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			tt.mock(f, tt.args)
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := RateLimitMiddleware(RateLimits{
+				Store:   f.Store,
+				Clock:   f.Clock,
+				Default: entities.RateLimit{RequestsPerMinute: 600, Burst: 100},
+				Routes:  map[string]entities.RateLimit{"POST /payment": {RequestsPerMinute: 30, Burst: 5}},
+			})(next)
+
+			handler.ServeHTTP(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+			for header, value := range tt.wantHeaders {
+				assert.Equal(t, value, tt.args.w.Header().Get(header), header)
+			}
+		})
+	}
+}
+
+func TestOperatorOnlyMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		tenantId   int64
+		wantCode   int
+		wantTenant int64
+	}{
+		{name: "success operator sees every tenant", tenantId: entities.DefaultTenantId, wantCode: 200, wantTenant: 0},
+		{name: "error admin of another tenant", tenantId: 2, wantCode: 403},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/admin/jobs", nil)
+			r = r.WithContext(helper.WithTenantId(r.Context(), tt.tenantId))
+			w := httptest.NewRecorder()
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.wantTenant, helper.GetTenantId(r.Context()))
+				assert.True(t, helper.IsOperator(r.Context()))
+				w.WriteHeader(http.StatusOK)
+			})
+			OperatorOnlyMiddleware(next).ServeHTTP(w, r)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+		})
+	}
+}
+
+func TestRouteScopes_ScopeOf(t *testing.T) {
+	scopes := RouteScopes{"POST /payment": entities.ScopePayments}
+	tests := []struct {
+		name string
+		r    *http.Request
+		want entities.ApiScope
+	}{
+		{name: "listed route", r: httptest.NewRequest("POST", "/payment", nil), want: entities.ScopePayments},
+		{name: "admin route", r: httptest.NewRequest("GET", "/admin/api-clients", nil), want: entities.ScopeAdmin},
+		{name: "unlisted read", r: httptest.NewRequest("GET", "/payment/history", nil), want: entities.ScopeRead},
+		{name: "unlisted write", r: httptest.NewRequest("POST", "/loan", nil), want: entities.ScopeWrite},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, scopes.ScopeOf(tt.r))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package restful
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func (h *ApiClientHandler) CreateClient(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var request entities.ApiClientRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	client, err := h.ApiClientUC.CreateClient(ctx, request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, client, nil)
+}
+
+func (h *ApiClientHandler) GetClients(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	clients, err := h.ApiClientUC.GetClientList(ctx)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, clients, nil)
+}
+
+func (h *ApiClientHandler) DisableClient(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var request entities.ApiClientKeyRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	err = h.ApiClientUC.DisableClient(ctx, request.ClientKey)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, map[string]string{
+		"client_key": request.ClientKey,
+	}, nil)
+}
+
+func (h *ApiClientHandler) RotateSecret(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var request entities.ApiClientKeyRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	client, err := h.ApiClientUC.RotateSecret(ctx, request.ClientKey)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, client, nil)
+}
+
+func (h *ApiClientHandler) SetRateLimit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var request entities.ApiClientRateLimitRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	err = h.ApiClientUC.SetRateLimit(ctx, request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, request, nil)
+}
@@ -0,0 +1,262 @@
+package restful
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+)
+
+func TestApiClientHandler_CreateClient(t *testing.T) {
+	type fields struct {
+		ApiClientUC *mock_handler.MockApiClientUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientUC: mock_handler.NewMockApiClientUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/api-client/create",
+					bytes.NewBufferString(`{"name":"partner","scopes":["read","payments"]}`)),
+			},
+			mock: func(f fields, args args) {
+				f.ApiClientUC.EXPECT().CreateClient(gomock.Any(), entities.ApiClientRequest{
+					Name:   "partner",
+					Scopes: []entities.ApiScope{entities.ScopeRead, entities.ScopePayments},
+				}).Return(&entities.ApiClient{Id: 3, ClientKey: "key", Secret: "secret", IsActive: true}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientUC: mock_handler.NewMockApiClientUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/api-client/create", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientUC: mock_handler.NewMockApiClientUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/api-client/create", bytes.NewBufferString(`{"name":"partner"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.ApiClientUC.EXPECT().CreateClient(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &ApiClientHandler{
+				ApiClientUC: f.ApiClientUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.CreateClient(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
+
+func TestApiClientHandler_RotateSecret(t *testing.T) {
+	type fields struct {
+		ApiClientUC *mock_handler.MockApiClientUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientUC: mock_handler.NewMockApiClientUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/api-client/rotate",
+					bytes.NewBufferString(`{"client_key":"client1"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.ApiClientUC.EXPECT().RotateSecret(gomock.Any(), "client1").
+					Return(&entities.ApiClient{Id: 3, ClientKey: "client1", Secret: "new secret", IsActive: true}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientUC: mock_handler.NewMockApiClientUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/api-client/rotate", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientUC: mock_handler.NewMockApiClientUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/api-client/rotate", bytes.NewBufferString(`{"client_key":"client1"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.ApiClientUC.EXPECT().RotateSecret(gomock.Any(), "client1").Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &ApiClientHandler{
+				ApiClientUC: f.ApiClientUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.RotateSecret(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
+
+func TestApiClientHandler_SetRateLimit(t *testing.T) {
+	type fields struct {
+		ApiClientUC *mock_handler.MockApiClientUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientUC: mock_handler.NewMockApiClientUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/api-client/rate-limit",
+					bytes.NewBufferString(`{"client_key":"client1","rate_limit":{"requests_per_minute":120,"burst":20}}`)),
+			},
+			mock: func(f fields, args args) {
+				f.ApiClientUC.EXPECT().SetRateLimit(gomock.Any(), entities.ApiClientRateLimitRequest{
+					ClientKey: "client1",
+					RateLimit: entities.RateLimit{RequestsPerMinute: 120, Burst: 20},
+				}).Return(nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientUC: mock_handler.NewMockApiClientUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/api-client/rate-limit", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientUC: mock_handler.NewMockApiClientUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/api-client/rate-limit", bytes.NewBufferString(`{"client_key":"client1"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.ApiClientUC.EXPECT().SetRateLimit(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &ApiClientHandler{
+				ApiClientUC: f.ApiClientUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.SetRateLimit(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package restful
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func (h *AuditHandler) GetLoanAuditTrail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	entries, err := h.AuditUC.GetLoanAuditTrail(ctx, r.FormValue("loan_reference_id"))
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, entries, nil)
+}
+
+func (h *AuditHandler) GetUserAuditTrail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, err := strconv.ParseInt(r.FormValue("user_id"), 10, 64)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid user ID"))
+		return
+	}
+
+	entries, err := h.AuditUC.GetUserAuditTrail(ctx, userId)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, entries, nil)
+}
+
+func (h *AuditHandler) VerifyAuditChain(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	verification, err := h.AuditUC.VerifyAuditChain(ctx)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, verification, nil)
+}
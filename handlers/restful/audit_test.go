@@ -0,0 +1,206 @@
+package restful
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+)
+
+func TestAuditHandler_GetLoanAuditTrail(t *testing.T) {
+	type fields struct {
+		AuditUC *mock_handler.MockAuditUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuditUC: mock_handler.NewMockAuditUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/audit/loan?loan_reference_id=loan-1", nil),
+			},
+			mock: func(f fields, args args) {
+				f.AuditUC.EXPECT().GetLoanAuditTrail(gomock.Any(), "loan-1").
+					Return(&[]entities.AuditEntry{{Id: 1, Action: entities.AuditLoanCreated, LoanId: 10}}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuditUC: mock_handler.NewMockAuditUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/audit/loan?loan_reference_id=loan-1", nil),
+			},
+			mock: func(f fields, args args) {
+				f.AuditUC.EXPECT().GetLoanAuditTrail(gomock.Any(), "loan-1").Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &AuditHandler{
+				AuditUC: f.AuditUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.GetLoanAuditTrail(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
+
+func TestAuditHandler_GetUserAuditTrail(t *testing.T) {
+	type fields struct {
+		AuditUC *mock_handler.MockAuditUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuditUC: mock_handler.NewMockAuditUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/audit/user?user_id=7", nil),
+			},
+			mock: func(f fields, args args) {
+				f.AuditUC.EXPECT().GetUserAuditTrail(gomock.Any(), int64(7)).
+					Return(&[]entities.AuditEntry{{Id: 1, Action: entities.AuditLoanCreated, UserId: 7}}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error invalid user id",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuditUC: mock_handler.NewMockAuditUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/audit/user?user_id=abc", nil),
+			},
+			mock:     func(f fields, args args) {},
+			wantCode: 400,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &AuditHandler{
+				AuditUC: f.AuditUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.GetUserAuditTrail(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
+
+func TestAuditHandler_VerifyAuditChain(t *testing.T) {
+	type fields struct {
+		AuditUC *mock_handler.MockAuditUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuditUC: mock_handler.NewMockAuditUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/audit/verify", nil),
+			},
+			mock: func(f fields, args args) {
+				f.AuditUC.EXPECT().VerifyAuditChain(gomock.Any()).Return(&entities.AuditChainVerification{Checked: 3, Valid: true}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuditUC: mock_handler.NewMockAuditUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/audit/verify", nil),
+			},
+			mock: func(f fields, args args) {
+				f.AuditUC.EXPECT().VerifyAuditChain(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &AuditHandler{
+				AuditUC: f.AuditUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.VerifyAuditChain(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package restful
+
+import (
+	"net/http"
+
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func (h *AuthAuditHandler) GetAuthFailures(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	failures, err := h.AuthAuditUC.GetAuthFailureList(ctx, r.FormValue("client_key"))
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, failures, nil)
+}
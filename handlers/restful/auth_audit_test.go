@@ -0,0 +1,79 @@
+package restful
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+)
+
+func TestAuthAuditHandler_GetAuthFailures(t *testing.T) {
+	type fields struct {
+		AuthAuditUC *mock_handler.MockAuthAuditUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuthAuditUC: mock_handler.NewMockAuthAuditUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/auth/failures?client_key=client1", nil),
+			},
+			mock: func(f fields, args args) {
+				f.AuthAuditUC.EXPECT().GetAuthFailureList(gomock.Any(), "client1").
+					Return(&[]entities.AuthFailure{{Id: 1, ClientKey: "client1", Reason: entities.AuthFailureInvalidSignature}}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuthAuditUC: mock_handler.NewMockAuthAuditUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/auth/failures", nil),
+			},
+			mock: func(f fields, args args) {
+				f.AuthAuditUC.EXPECT().GetAuthFailureList(gomock.Any(), "").Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &AuthAuditHandler{
+				AuthAuditUC: f.AuthAuditUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.GetAuthFailures(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
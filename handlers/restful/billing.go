@@ -64,6 +64,37 @@ func (h *BillingHandler) MakePayment(w http.ResponseWriter, r *http.Request) {
 
 }
 
+func (h *BillingHandler) GetReceipt(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	referenceId := r.FormValue("reference_id")
+
+	receipt, err := h.BillingUC.GetReceipt(ctx, referenceId)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, receipt.Response(), nil)
+}
+
+func (h *BillingHandler) ReversePayment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var reversalRequest entities.PaymentReversalRequest
+	err := json.NewDecoder(r.Body).Decode(&reversalRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	receipt, err := h.BillingUC.ReversePayment(ctx, reversalRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, receipt.Response(), nil)
+}
+
 func (h *BillingHandler) GetOutStandingAmount(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	referenceId := r.FormValue("reference_id")
@@ -89,9 +120,18 @@ func (h *BillingHandler) GetUserStatus(w http.ResponseWriter, r *http.Request) {
 		helper.JSON(w, ctx, nil, err)
 		return
 	}
-	helper.JSON(w, ctx, map[string]bool{
-		"is_delinquent": isDelinquent,
-	}, nil)
+
+	status := entities.UserStatus{IsDelinquent: isDelinquent}
+	if isDelinquent {
+		promises, err := h.BillingUC.GetOpenPromiseToPayListByUserId(ctx, userId)
+		if err != nil {
+			helper.JSON(w, ctx, nil, err)
+			return
+		}
+		status.PromisesToPay = *promises
+	}
+
+	helper.JSON(w, ctx, status, nil)
 }
 
 func (h *BillingHandler) GetPaymentInquiry(w http.ResponseWriter, r *http.Request) {
@@ -123,18 +163,7 @@ func (h *BillingHandler) GetLoanHistory(w http.ResponseWriter, r *http.Request)
 
 	loanResponses := make([]entities.LoanResponse, len(*loans))
 	for i, l := range *loans {
-		loanResponses[i] = entities.LoanResponse{
-			Id:                l.Id,
-			ReferenceId:       l.ReferenceId,
-			Amount:            l.Amount,
-			RatePercentage:    l.RatePercentage,
-			Status:            l.Status.String(),
-			RepaymentSchedule: l.RepaymentSchedule,
-			Tenor:             l.Tenor,
-			RepaymentAmount:   l.RepaymentAmount,
-			CreatedAt:         l.CreatedAt,
-			UpdatedAt:         l.UpdatedAt,
-		}
+		loanResponses[i] = l.Response()
 	}
 
 	helper.JSON(w, ctx, &entities.LoanList{
@@ -412,6 +412,31 @@ func TestBillingHandler_GetUserStatus(t *testing.T) {
 			},
 			mock: func(f fields, args args) {
 				f.BillingUC.EXPECT().GetUserStatusIsDelinquent(gomock.Any(), int64(1)).Return(true, nil)
+				f.BillingUC.EXPECT().GetOpenPromiseToPayListByUserId(gomock.Any(), int64(1)).Return(&[]entities.PromiseToPay{
+					{Id: 3, LoanId: 1, Amount: 500, Status: entities.PromiseOpen},
+				}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "success not delinquent",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					BillingUC: mock_handler.NewMockBillingUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest("GET", "localhost:8080/user/status", nil)
+					r.Form = url.Values{
+						"user_id": {"1"},
+					}
+					return r
+				}(),
+			},
+			mock: func(f fields, args args) {
+				f.BillingUC.EXPECT().GetUserStatusIsDelinquent(gomock.Any(), int64(1)).Return(false, nil)
 			},
 			wantCode: 200,
 		},
@@ -458,6 +483,29 @@ func TestBillingHandler_GetUserStatus(t *testing.T) {
 			},
 			wantCode: 500,
 		},
+		{
+			name: "error promise to pay list",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					BillingUC: mock_handler.NewMockBillingUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest("GET", "localhost:8080/user/status", nil)
+					r.Form = url.Values{
+						"user_id": {"1"},
+					}
+					return r
+				}(),
+			},
+			mock: func(f fields, args args) {
+				f.BillingUC.EXPECT().GetUserStatusIsDelinquent(gomock.Any(), int64(1)).Return(true, nil)
+				f.BillingUC.EXPECT().GetOpenPromiseToPayListByUserId(gomock.Any(), int64(1)).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -674,3 +722,85 @@ func TestBillingHandler_GetLoanHistory(t *testing.T) {
 		})
 	}
 }
+
+func TestBillingHandler_ReversePayment(t *testing.T) {
+	type fields struct {
+		BillingUC *mock_handler.MockBillingUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					BillingUC: mock_handler.NewMockBillingUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/payment/reverse", bytes.NewBufferString(`{"repayment_reference_id":"repay1","reason":"duplicate"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.BillingUC.EXPECT().ReversePayment(gomock.Any(), entities.PaymentReversalRequest{
+					RepaymentReferenceId: "repay1",
+					Reason:               "duplicate",
+				}).Return(&entities.Receipt{Status: entities.ReceiptCancelled}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					BillingUC: mock_handler.NewMockBillingUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/payment/reverse", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					BillingUC: mock_handler.NewMockBillingUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/payment/reverse", bytes.NewBufferString(`{}`)),
+			},
+			mock: func(f fields, args args) {
+				f.BillingUC.EXPECT().ReversePayment(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &BillingHandler{
+				BillingUC: f.BillingUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.ReversePayment(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package restful
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func (h *CollectionHandler) CreateMandate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var mandateRequest entities.DebitMandateRequest
+	err := json.NewDecoder(r.Body).Decode(&mandateRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	mandate, err := h.CollectionUC.CreateMandate(ctx, mandateRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, mandate, nil)
+}
+
+func (h *CollectionHandler) RevokeMandate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var mandateIdRequest entities.DebitMandateIdRequest
+	err := json.NewDecoder(r.Body).Decode(&mandateIdRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	err = h.CollectionUC.RevokeMandate(ctx, mandateIdRequest.MandateId)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, map[string]int64{
+		"mandate_id": mandateIdRequest.MandateId,
+	}, nil)
+}
+
+func (h *CollectionHandler) GetMandateList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	referenceId := r.FormValue("loan_reference_id")
+
+	mandates, err := h.CollectionUC.GetMandateListByLoanReferenceId(ctx, referenceId)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, mandates, nil)
+}
+
+func (h *CollectionHandler) GetDebitInstructionList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	referenceId := r.FormValue("loan_reference_id")
+
+	instructions, err := h.CollectionUC.GetDebitInstructionListByLoanReferenceId(ctx, referenceId)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, instructions, nil)
+}
+
+func (h *CollectionHandler) RunCollection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var runRequest entities.CollectionRunRequest
+	err := json.NewDecoder(r.Body).Decode(&runRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	var collectionDate time.Time
+	if runRequest.CollectionDate != "" {
+		collectionDate, err = helper.ParseDate(runRequest.CollectionDate, time.Local)
+		if err != nil {
+			helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "collection date must be formatted as "+helper.DateLayout))
+			return
+		}
+	}
+
+	result, err := h.CollectionUC.RunCollection(ctx, collectionDate)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, result, nil)
+}
+
+func (h *CollectionHandler) CreatePromiseToPay(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var promiseRequest entities.PromiseToPayRequest
+	err := json.NewDecoder(r.Body).Decode(&promiseRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	promise, err := h.CollectionUC.CreatePromiseToPay(ctx, promiseRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, promise, nil)
+}
+
+func (h *CollectionHandler) GetPromiseToPayList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	referenceId := r.FormValue("loan_reference_id")
+
+	promises, err := h.CollectionUC.GetPromiseToPayListByLoanReferenceId(ctx, referenceId)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, promises, nil)
+}
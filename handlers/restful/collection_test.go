@@ -0,0 +1,279 @@
+package restful
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+)
+
+func TestCollectionHandler_CreateMandate(t *testing.T) {
+	type fields struct {
+		CollectionUC *mock_handler.MockCollectionUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionUC: mock_handler.NewMockCollectionUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/mandate/create", bytes.NewBufferString(`{"loan_reference_id":"loan1","bank_code":"BCA","account_number":"1234567890"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.CollectionUC.EXPECT().CreateMandate(gomock.Any(), entities.DebitMandateRequest{
+					LoanReferenceId: "loan1",
+					BankCode:        "BCA",
+					AccountNumber:   "1234567890",
+				}).Return(&entities.DebitMandate{Id: 1}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionUC: mock_handler.NewMockCollectionUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/mandate/create", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionUC: mock_handler.NewMockCollectionUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/mandate/create", bytes.NewBufferString(`{"loan_reference_id":"loan1"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.CollectionUC.EXPECT().CreateMandate(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &CollectionHandler{
+				CollectionUC: f.CollectionUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.CreateMandate(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
+
+func TestCollectionHandler_RunCollection(t *testing.T) {
+	type fields struct {
+		CollectionUC *mock_handler.MockCollectionUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionUC: mock_handler.NewMockCollectionUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/collection/run", bytes.NewBufferString(`{"collection_date":"2000-02-01"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.CollectionUC.EXPECT().RunCollection(gomock.Any(), time.Date(2000, 2, 1, 0, 0, 0, 0, time.Local)).Return(&entities.CollectionRunResult{}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "success without collection date",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionUC: mock_handler.NewMockCollectionUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/collection/run", bytes.NewBufferString(`{}`)),
+			},
+			mock: func(f fields, args args) {
+				f.CollectionUC.EXPECT().RunCollection(gomock.Any(), time.Time{}).Return(&entities.CollectionRunResult{}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error collection date format",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionUC: mock_handler.NewMockCollectionUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/collection/run", bytes.NewBufferString(`{"collection_date":"01-02-2000"}`)),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionUC: mock_handler.NewMockCollectionUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/collection/run", bytes.NewBufferString(`{}`)),
+			},
+			mock: func(f fields, args args) {
+				f.CollectionUC.EXPECT().RunCollection(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &CollectionHandler{
+				CollectionUC: f.CollectionUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.RunCollection(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
+
+func TestCollectionHandler_CreatePromiseToPay(t *testing.T) {
+	type fields struct {
+		CollectionUC *mock_handler.MockCollectionUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionUC: mock_handler.NewMockCollectionUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/collection/promise",
+					bytes.NewBufferString(`{"loan_reference_id":"loan1","amount":500,"promised_date":"2024-05-10","agent":"agent1"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.CollectionUC.EXPECT().CreatePromiseToPay(gomock.Any(), entities.PromiseToPayRequest{
+					LoanReferenceId: "loan1",
+					Amount:          500,
+					PromisedDate:    "2024-05-10",
+					Agent:           "agent1",
+				}).Return(&entities.PromiseToPay{Id: 3, LoanId: 1, Status: entities.PromiseOpen}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionUC: mock_handler.NewMockCollectionUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/collection/promise", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionUC: mock_handler.NewMockCollectionUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/collection/promise", bytes.NewBufferString(`{"loan_reference_id":"loan1"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.CollectionUC.EXPECT().CreatePromiseToPay(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &CollectionHandler{
+				CollectionUC: f.CollectionUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.CreatePromiseToPay(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
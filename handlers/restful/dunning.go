@@ -0,0 +1,73 @@
+package restful
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func (h *DunningHandler) GetDunningLevel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	referenceId := r.FormValue("loan_reference_id")
+
+	level, err := h.DunningUC.GetDunningLevel(ctx, referenceId)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, level, nil)
+}
+
+func (h *DunningHandler) OverrideDunningLevel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var request entities.DunningOverrideRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	level, err := h.DunningUC.OverrideDunningLevel(ctx, request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, level, nil)
+}
+
+func (h *DunningHandler) GetDunningActions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	status := entities.DunningActionStatus(r.FormValue("status"))
+	action := entities.DunningAction(r.FormValue("action"))
+
+	actions, err := h.DunningUC.GetDunningActionList(ctx, status, action)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, actions, nil)
+}
+
+func (h *DunningHandler) CompleteDunningAction(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var request entities.DunningActionIdRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	action, err := h.DunningUC.CompleteDunningAction(ctx, request.ActionId)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, action, nil)
+}
@@ -0,0 +1,165 @@
+package restful
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+)
+
+func TestDunningHandler_OverrideDunningLevel(t *testing.T) {
+	type fields struct {
+		DunningUC *mock_handler.MockDunningUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DunningUC: mock_handler.NewMockDunningUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/dunning/level/override",
+					bytes.NewBufferString(`{"loan_reference_id":"loan1","level":3,"reason":"unreachable by phone"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.DunningUC.EXPECT().OverrideDunningLevel(gomock.Any(), entities.DunningOverrideRequest{
+					LoanReferenceId: "loan1",
+					Level:           3,
+					Reason:          "unreachable by phone",
+				}).Return(&entities.LoanDunningLevel{LoanId: 1, Level: 3, IsOverridden: true}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DunningUC: mock_handler.NewMockDunningUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/dunning/level/override", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DunningUC: mock_handler.NewMockDunningUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/dunning/level/override", bytes.NewBufferString(`{"loan_reference_id":"loan1"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.DunningUC.EXPECT().OverrideDunningLevel(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &DunningHandler{
+				DunningUC: f.DunningUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.OverrideDunningLevel(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
+
+func TestDunningHandler_GetDunningActions(t *testing.T) {
+	type fields struct {
+		DunningUC *mock_handler.MockDunningUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DunningUC: mock_handler.NewMockDunningUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/dunning/actions?status=pending&action=call_queue", nil),
+			},
+			mock: func(f fields, args args) {
+				f.DunningUC.EXPECT().GetDunningActionList(gomock.Any(), entities.DunningActionPending, entities.DunningCallQueue).
+					Return(&[]entities.DunningActionItem{{Id: 7, Action: entities.DunningCallQueue}}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DunningUC: mock_handler.NewMockDunningUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/dunning/actions", nil),
+			},
+			mock: func(f fields, args args) {
+				f.DunningUC.EXPECT().GetDunningActionList(gomock.Any(), entities.DunningActionStatus(""), entities.DunningAction("")).
+					Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &DunningHandler{
+				DunningUC: f.DunningUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.GetDunningActions(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package restful
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const maxImportUploadSize = 32 << 20
+
+// ImportLoans takes a multipart form with the loans file, an optional
+// repayments file and the dry_run flag
+func (h *ImportHandler) ImportLoans(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	err := r.ParseMultipartForm(maxImportUploadSize)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	var request entities.LoanImportRequest
+	if value := r.FormValue("dry_run"); value != "" {
+		request.DryRun, err = strconv.ParseBool(value)
+		if err != nil {
+			helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid dry run"))
+			return
+		}
+	}
+
+	loans, _, err := r.FormFile("loans")
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "loans file is required"))
+		return
+	}
+	defer loans.Close()
+	request.Loans = loans
+
+	repayments, _, err := r.FormFile("repayments")
+	if err == nil {
+		defer repayments.Close()
+		request.Repayments = repayments
+	}
+
+	result, err := h.ImportUC.ImportLoans(ctx, request)
+	if isCSV(r) {
+		helper.CSV(w, ctx, "loan_import_result.csv", result, err)
+		return
+	}
+
+	helper.JSON(w, ctx, result, err)
+}
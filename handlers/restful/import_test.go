@@ -0,0 +1,148 @@
+package restful
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+)
+
+func newImportRequest(url string, files map[string]string, dryRun string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, content := range files {
+		part, _ := writer.CreateFormFile(name, name+".csv")
+		part.Write([]byte(content))
+	}
+	if dryRun != "" {
+		writer.WriteField("dry_run", dryRun)
+	}
+	writer.Close()
+
+	r := httptest.NewRequest("POST", url, body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	return r
+}
+
+func TestImportHandler_ImportLoans(t *testing.T) {
+	type fields struct {
+		ImportUC *mock_handler.MockImportUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name            string
+		fields          func(ctrl *gomock.Controller) fields
+		args            args
+		mock            func(f fields, args args)
+		wantCode        int
+		wantContentType string
+	}{
+		{
+			name: "success csv result",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ImportUC: mock_handler.NewMockImportUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: newImportRequest("localhost:8080/admin/loan/import?format=csv", map[string]string{
+					"loans":      "reference_id\nloan1\n",
+					"repayments": "loan_reference_id\nloan1\n",
+				}, "true"),
+			},
+			mock: func(f fields, args args) {
+				f.ImportUC.EXPECT().ImportLoans(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, request entities.LoanImportRequest) (*entities.ImportResult, error) {
+						assert.True(t, request.DryRun)
+						assert.NotNil(t, request.Loans)
+						assert.NotNil(t, request.Repayments)
+						return &entities.ImportResult{DryRun: true}, nil
+					})
+			},
+			wantCode:        200,
+			wantContentType: "text/csv",
+		},
+		{
+			name: "error missing loans file",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ImportUC: mock_handler.NewMockImportUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: newImportRequest("localhost:8080/admin/loan/import", map[string]string{
+					"repayments": "loan_reference_id\nloan1\n",
+				}, ""),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode:        400,
+			wantContentType: "application/json",
+		},
+		{
+			name: "error invalid dry run",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ImportUC: mock_handler.NewMockImportUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: newImportRequest("localhost:8080/admin/loan/import", map[string]string{
+					"loans": "reference_id\nloan1\n",
+				}, "maybe"),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode:        400,
+			wantContentType: "application/json",
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ImportUC: mock_handler.NewMockImportUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: newImportRequest("localhost:8080/admin/loan/import", map[string]string{
+					"loans": "reference_id\nloan1\n",
+				}, ""),
+			},
+			mock: func(f fields, args args) {
+				f.ImportUC.EXPECT().ImportLoans(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode:        500,
+			wantContentType: "application/json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &ImportHandler{
+				ImportUC: f.ImportUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.ImportLoans(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+			assert.Equal(t, tt.wantContentType, tt.args.w.Header().Get("Content-Type"))
+		})
+	}
+}
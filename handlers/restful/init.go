@@ -2,6 +2,7 @@ package restful
 
 import (
 	"context"
+	"time"
 
 	"github.com/sirait-kevin/BillingEngine/domain/entities"
 )
@@ -12,11 +13,216 @@ type BillingUsecase interface {
 	GetPaymentHistoryByReferenceID(ctx context.Context, referenceId string) (*entities.LoanHistory, error)
 	GetOutStandingAmountByReferenceID(ctx context.Context, referenceId string) (*entities.OutStanding, error)
 	GetUserStatusIsDelinquent(ctx context.Context, userId int64) (bool, error)
+	GetOpenPromiseToPayListByUserId(ctx context.Context, userId int64) (*[]entities.PromiseToPay, error)
 	GetRepaymentInquiryByLoanReferenceId(ctx context.Context, referenceId string) (*entities.RepaymentInquiry, error)
 	MakePayment(ctx context.Context, repaymentRequest entities.RepaymentRequest) (int64, error)
 	GetLoanListByUserId(ctx context.Context, userId int64) (*[]entities.Loan, error)
+	GetReceipt(ctx context.Context, repaymentReferenceId string) (*entities.Receipt, error)
+	ReversePayment(ctx context.Context, request entities.PaymentReversalRequest) (*entities.Receipt, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/WebhookUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful WebhookUsecase
+type WebhookUsecase interface {
+	RegisterSubscription(ctx context.Context, clientKey string, request entities.WebhookSubscriptionRequest) (*entities.WebhookSubscription, error)
+	GetSubscriptionList(ctx context.Context, clientKey string) (*[]entities.WebhookSubscription, error)
+	DisableSubscription(ctx context.Context, clientKey string, subscriptionId int64) error
+	GetDeliveryList(ctx context.Context, clientKey string, subscriptionId int64) (*[]entities.WebhookDelivery, error)
+	Redeliver(ctx context.Context, clientKey string, deliveryId int64) (*entities.WebhookDelivery, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/VirtualAccountUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful VirtualAccountUsecase
+type VirtualAccountUsecase interface {
+	CreateVirtualAccount(ctx context.Context, request entities.VirtualAccountRequest) (*entities.VirtualAccount, error)
+	GetVirtualAccountListByLoanReferenceId(ctx context.Context, referenceId string) (*[]entities.VirtualAccount, error)
+	HandlePaymentCallback(ctx context.Context, callback entities.PaymentCallback) (int64, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/CollectionUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful CollectionUsecase
+type CollectionUsecase interface {
+	CreateMandate(ctx context.Context, request entities.DebitMandateRequest) (*entities.DebitMandate, error)
+	RevokeMandate(ctx context.Context, mandateId int64) error
+	GetMandateListByLoanReferenceId(ctx context.Context, referenceId string) (*[]entities.DebitMandate, error)
+	GetDebitInstructionListByLoanReferenceId(ctx context.Context, referenceId string) (*[]entities.DebitInstruction, error)
+	RunCollection(ctx context.Context, collectionDate time.Time) (*entities.CollectionRunResult, error)
+	CreatePromiseToPay(ctx context.Context, request entities.PromiseToPayRequest) (*entities.PromiseToPay, error)
+	GetPromiseToPayListByLoanReferenceId(ctx context.Context, referenceId string) (*[]entities.PromiseToPay, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/JobUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful JobUsecase
+type JobUsecase interface {
+	GetJobList(ctx context.Context) (*[]entities.JobInfo, error)
+	GetJobRunList(ctx context.Context, jobName string) (*[]entities.JobRun, error)
+	TriggerJob(ctx context.Context, request entities.JobRunRequest) (*entities.JobRun, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/SnapshotUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful SnapshotUsecase
+type SnapshotUsecase interface {
+	BackfillSnapshots(ctx context.Context, request entities.SnapshotBackfillRequest) (*[]entities.SnapshotRunResult, error)
+	GetSnapshotList(ctx context.Context, businessDate string) (*[]entities.LoanDailySnapshot, error)
 }
 
 type BillingHandler struct {
 	BillingUC BillingUsecase
 }
+
+type WebhookHandler struct {
+	WebhookUC WebhookUsecase
+}
+
+type VirtualAccountHandler struct {
+	VirtualAccountUC VirtualAccountUsecase
+}
+
+type CollectionHandler struct {
+	CollectionUC CollectionUsecase
+}
+
+type JobHandler struct {
+	JobUC JobUsecase
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/ImportUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful ImportUsecase
+type ImportUsecase interface {
+	ImportLoans(ctx context.Context, request entities.LoanImportRequest) (*entities.ImportResult, error)
+}
+
+type ImportHandler struct {
+	ImportUC ImportUsecase
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/StatementUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful StatementUsecase
+type StatementUsecase interface {
+	GetStatement(ctx context.Context, request entities.StatementRequest) (*entities.Statement, error)
+}
+
+type StatementHandler struct {
+	StatementUC StatementUsecase
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/ReportUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful ReportUsecase
+type ReportUsecase interface {
+	GetPortfolioAtRisk(ctx context.Context, request entities.ReportRequest) (*entities.PortfolioAtRiskReport, error)
+	GetOutstandingBySchedule(ctx context.Context, request entities.ReportRequest) (*entities.OutstandingByScheduleReport, error)
+	GetDisbursement(ctx context.Context, request entities.ReportRequest) (*entities.DisbursementReport, error)
+	GetCollectionRate(ctx context.Context, request entities.ReportRequest) (*entities.CollectionRateReport, error)
+}
+
+type ReportHandler struct {
+	ReportUC ReportUsecase
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/SettlementUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful SettlementUsecase
+type SettlementUsecase interface {
+	ProcessSettlementFile(ctx context.Context, request entities.SettlementUploadRequest) (*entities.SettlementResult, error)
+	GetSettlementExceptionList(ctx context.Context, status string) (*[]entities.SettlementException, error)
+	RepostSettlementException(ctx context.Context, request entities.SettlementRepostRequest) (*entities.SettlementException, error)
+	DismissSettlementException(ctx context.Context, request entities.SettlementDismissRequest) (*entities.SettlementException, error)
+}
+
+type SettlementHandler struct {
+	SettlementUC SettlementUsecase
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/ReconciliationUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful ReconciliationUsecase
+type ReconciliationUsecase interface {
+	ImportBankStatement(ctx context.Context, request entities.BankStatementImportRequest) (*entities.BankStatementImportResult, error)
+	RunReconciliation(ctx context.Context, request entities.ReconciliationRequest) (*entities.ReconciliationReport, error)
+	GetReconciliationReport(ctx context.Context, request entities.ReconciliationRequest) (*entities.ReconciliationReport, error)
+	MatchManually(ctx context.Context, request entities.ManualMatchRequest) (*entities.BankStatementLine, error)
+}
+
+type ReconciliationHandler struct {
+	ReconciliationUC ReconciliationUsecase
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/WriteOffUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful WriteOffUsecase
+type WriteOffUsecase interface {
+	WriteOffLoan(ctx context.Context, request entities.WriteOffRequest) (*entities.WriteOff, error)
+	GetWriteOff(ctx context.Context, loanReferenceId string) (*entities.WriteOffDetail, error)
+	RecordRecovery(ctx context.Context, request entities.RecoveryRequest) (int64, error)
+}
+
+type WriteOffHandler struct {
+	WriteOffUC WriteOffUsecase
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/ScheduleUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful ScheduleUsecase
+type ScheduleUsecase interface {
+	RestructureLoan(ctx context.Context, request entities.RestructureRequest) (*entities.LoanScheduleVersion, error)
+	DeferLoan(ctx context.Context, request entities.DeferralRequest) (*entities.LoanScheduleVersion, error)
+	GetScheduleVersionList(ctx context.Context, loanReferenceId string) (*[]entities.LoanScheduleVersion, error)
+}
+
+type ScheduleHandler struct {
+	ScheduleUC ScheduleUsecase
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/DunningUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful DunningUsecase
+type DunningUsecase interface {
+	GetDunningLevel(ctx context.Context, loanReferenceId string) (*entities.LoanDunningLevel, error)
+	OverrideDunningLevel(ctx context.Context, request entities.DunningOverrideRequest) (*entities.LoanDunningLevel, error)
+	GetDunningActionList(ctx context.Context, status entities.DunningActionStatus, action entities.DunningAction) (*[]entities.DunningActionItem, error)
+	CompleteDunningAction(ctx context.Context, actionId int64) (*entities.DunningActionItem, error)
+}
+
+type DunningHandler struct {
+	DunningUC DunningUsecase
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/NotificationUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful NotificationUsecase
+type NotificationUsecase interface {
+	SavePreference(ctx context.Context, request entities.NotificationPreferenceRequest) (*entities.NotificationPreference, error)
+	GetPreference(ctx context.Context, userId int64) (*entities.NotificationPreference, error)
+	GetDeliveryList(ctx context.Context, userId int64) (*[]entities.NotificationDelivery, error)
+}
+
+type NotificationHandler struct {
+	NotificationUC NotificationUsecase
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/ApiClientUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful ApiClientUsecase
+type ApiClientUsecase interface {
+	CreateClient(ctx context.Context, request entities.ApiClientRequest) (*entities.ApiClient, error)
+	GetClientList(ctx context.Context) (*[]entities.ApiClient, error)
+	DisableClient(ctx context.Context, clientKey string) error
+	SetRateLimit(ctx context.Context, request entities.ApiClientRateLimitRequest) error
+	RotateSecret(ctx context.Context, clientKey string) (*entities.ApiClient, error)
+}
+
+type ApiClientHandler struct {
+	ApiClientUC ApiClientUsecase
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/AuthAuditUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful AuthAuditUsecase
+type AuthAuditUsecase interface {
+	GetAuthFailureList(ctx context.Context, clientKey string) (*[]entities.AuthFailure, error)
+}
+
+type AuthAuditHandler struct {
+	AuthAuditUC AuthAuditUsecase
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/AuditUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful AuditUsecase
+type AuditUsecase interface {
+	GetLoanAuditTrail(ctx context.Context, loanReferenceId string) (*[]entities.AuditEntry, error)
+	GetUserAuditTrail(ctx context.Context, userId int64) (*[]entities.AuditEntry, error)
+	VerifyAuditChain(ctx context.Context) (*entities.AuditChainVerification, error)
+}
+
+type AuditHandler struct {
+	AuditUC AuditUsecase
+}
+
+type SnapshotHandler struct {
+	SnapshotUC SnapshotUsecase
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../../mocks/handler/TenantUsecase.go -package=mock_handler github.com/sirait-kevin/BillingEngine/handlers/restful TenantUsecase
+type TenantUsecase interface {
+	GetCurrentTenant(ctx context.Context) (*entities.Tenant, error)
+	UpdateSettings(ctx context.Context, settings entities.TenantSettingsRequest) (*entities.Tenant, error)
+}
+
+type TenantHandler struct {
+	TenantUC TenantUsecase
+}
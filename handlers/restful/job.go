@@ -0,0 +1,53 @@
+package restful
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func (h *JobHandler) GetJobs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	jobs, err := h.JobUC.GetJobList(ctx)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, jobs, nil)
+}
+
+func (h *JobHandler) GetJobRuns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	jobName := r.FormValue("job_name")
+
+	runs, err := h.JobUC.GetJobRunList(ctx, jobName)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, runs, nil)
+}
+
+func (h *JobHandler) RunJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var jobRunRequest entities.JobRunRequest
+	err := json.NewDecoder(r.Body).Decode(&jobRunRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	run, err := h.JobUC.TriggerJob(ctx, jobRunRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, run, nil)
+}
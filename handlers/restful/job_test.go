@@ -0,0 +1,97 @@
+package restful
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+)
+
+func TestJobHandler_RunJob(t *testing.T) {
+	type fields struct {
+		JobUC *mock_handler.MockJobUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobUC: mock_handler.NewMockJobUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/job/run", bytes.NewBufferString(`{"job_name":"collection_run","business_date":"2000-12-01"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.JobUC.EXPECT().TriggerJob(gomock.Any(), entities.JobRunRequest{
+					JobName:      "collection_run",
+					BusinessDate: "2000-12-01",
+				}).Return(&entities.JobRun{Id: 1}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobUC: mock_handler.NewMockJobUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/job/run", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobUC: mock_handler.NewMockJobUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/job/run", bytes.NewBufferString(`{"job_name":"collection_run"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.JobUC.EXPECT().TriggerJob(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &JobHandler{
+				JobUC: f.JobUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.RunJob(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package restful
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func (h *NotificationHandler) SavePreference(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var request entities.NotificationPreferenceRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	preference, err := h.NotificationUC.SavePreference(ctx, request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, preference, nil)
+}
+
+func (h *NotificationHandler) GetPreference(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, err := strconv.ParseInt(r.FormValue("user_id"), 10, 64)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid user ID"))
+		return
+	}
+
+	preference, err := h.NotificationUC.GetPreference(ctx, userId)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, preference, nil)
+}
+
+func (h *NotificationHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, err := strconv.ParseInt(r.FormValue("user_id"), 10, 64)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid user ID"))
+		return
+	}
+
+	deliveries, err := h.NotificationUC.GetDeliveryList(ctx, userId)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, deliveries, nil)
+}
@@ -0,0 +1,163 @@
+package restful
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+)
+
+func TestNotificationHandler_SavePreference(t *testing.T) {
+	type fields struct {
+		NotificationUC *mock_handler.MockNotificationUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					NotificationUC: mock_handler.NewMockNotificationUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/notification/preference",
+					bytes.NewBufferString(`{"user_id":10,"locale":"id","opted_out":["sms","push"]}`)),
+			},
+			mock: func(f fields, args args) {
+				f.NotificationUC.EXPECT().SavePreference(gomock.Any(), entities.NotificationPreferenceRequest{
+					UserId:   10,
+					Locale:   "id",
+					OptedOut: []entities.NotificationChannel{entities.NotificationSMS, entities.NotificationPush},
+				}).Return(&entities.NotificationPreference{UserId: 10, Locale: "id"}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					NotificationUC: mock_handler.NewMockNotificationUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/notification/preference", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					NotificationUC: mock_handler.NewMockNotificationUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/notification/preference", bytes.NewBufferString(`{"user_id":10}`)),
+			},
+			mock: func(f fields, args args) {
+				f.NotificationUC.EXPECT().SavePreference(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &NotificationHandler{
+				NotificationUC: f.NotificationUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.SavePreference(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
+
+func TestNotificationHandler_GetDeliveries(t *testing.T) {
+	type fields struct {
+		NotificationUC *mock_handler.MockNotificationUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					NotificationUC: mock_handler.NewMockNotificationUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/notification/deliveries?user_id=10", nil),
+			},
+			mock: func(f fields, args args) {
+				f.NotificationUC.EXPECT().GetDeliveryList(gomock.Any(), int64(10)).
+					Return(&[]entities.NotificationDelivery{{Id: 1, UserId: 10}}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error invalid user id",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					NotificationUC: mock_handler.NewMockNotificationUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/notification/deliveries?user_id=abc", nil),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &NotificationHandler{
+				NotificationUC: f.NotificationUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.GetDeliveries(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package restful
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+// ImportBankStatement takes a multipart form with the statement file and the
+// bank_code of the account it belongs to
+func (h *ReconciliationHandler) ImportBankStatement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	err := r.ParseMultipartForm(maxImportUploadSize)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "bank statement file is required"))
+		return
+	}
+	defer file.Close()
+
+	result, err := h.ReconciliationUC.ImportBankStatement(ctx, entities.BankStatementImportRequest{
+		BankCode: r.FormValue("bank_code"),
+		File:     file,
+	})
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, result, nil)
+}
+
+func (h *ReconciliationHandler) RunReconciliation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var request entities.ReconciliationRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	report, err := h.ReconciliationUC.RunReconciliation(ctx, request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, report, nil)
+}
+
+func (h *ReconciliationHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	report, err := h.ReconciliationUC.GetReconciliationReport(ctx, entities.ReconciliationRequest{
+		FromDate: r.FormValue("from_date"),
+		ToDate:   r.FormValue("to_date"),
+	})
+	if isCSV(r) {
+		helper.CSV(w, ctx, "reconciliation.csv", report, err)
+		return
+	}
+
+	helper.JSON(w, ctx, report, err)
+}
+
+func (h *ReconciliationHandler) MatchManually(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var request entities.ManualMatchRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	line, err := h.ReconciliationUC.MatchManually(ctx, request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, line, nil)
+}
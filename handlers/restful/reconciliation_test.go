@@ -0,0 +1,285 @@
+package restful
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+)
+
+func TestReconciliationHandler_ImportBankStatement(t *testing.T) {
+	type fields struct {
+		ReconciliationUC *mock_handler.MockReconciliationUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationUC: mock_handler.NewMockReconciliationUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: newSettlementRequest("localhost:8080/admin/reconciliation/statement/import",
+					"transaction_id,value_date,amount\ntrx1,2024-01-02,100\n", map[string]string{
+						"bank_code": "BCA",
+					}),
+			},
+			mock: func(f fields, args args) {
+				f.ReconciliationUC.EXPECT().ImportBankStatement(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, request entities.BankStatementImportRequest) (*entities.BankStatementImportResult, error) {
+						assert.Equal(t, "BCA", request.BankCode)
+						assert.NotNil(t, request.File)
+						return &entities.BankStatementImportResult{Total: 1, Matched: 1}, nil
+					})
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error missing file",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationUC: mock_handler.NewMockReconciliationUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: newSettlementRequest("localhost:8080/admin/reconciliation/statement/import", "", map[string]string{
+					"bank_code": "BCA",
+				}),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationUC: mock_handler.NewMockReconciliationUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: newSettlementRequest("localhost:8080/admin/reconciliation/statement/import", "transaction_id\ntrx1\n", nil),
+			},
+			mock: func(f fields, args args) {
+				f.ReconciliationUC.EXPECT().ImportBankStatement(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &ReconciliationHandler{
+				ReconciliationUC: f.ReconciliationUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.ImportBankStatement(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
+
+func TestReconciliationHandler_GetReport(t *testing.T) {
+	type fields struct {
+		ReconciliationUC *mock_handler.MockReconciliationUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	report := &entities.ReconciliationReport{
+		FromDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local),
+		ToDate:   time.Date(2024, 1, 31, 0, 0, 0, 0, time.Local),
+		Matched: []entities.BankStatementLine{
+			{Id: 11, TransactionId: "trx1", Amount: 100, Status: entities.ReconciliationMatched, RepaymentId: 1},
+		},
+		MatchedAmount: 100,
+	}
+	tests := []struct {
+		name            string
+		fields          func(ctrl *gomock.Controller) fields
+		args            args
+		mock            func(f fields, args args)
+		wantCode        int
+		wantContentType string
+	}{
+		{
+			name: "success json",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationUC: mock_handler.NewMockReconciliationUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/reconciliation/report?from_date=2024-01-01&to_date=2024-01-31", nil),
+			},
+			mock: func(f fields, args args) {
+				f.ReconciliationUC.EXPECT().GetReconciliationReport(gomock.Any(), entities.ReconciliationRequest{
+					FromDate: "2024-01-01",
+					ToDate:   "2024-01-31",
+				}).Return(report, nil)
+			},
+			wantCode:        200,
+			wantContentType: "application/json",
+		},
+		{
+			name: "success csv",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationUC: mock_handler.NewMockReconciliationUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/reconciliation/report?format=csv", nil),
+			},
+			mock: func(f fields, args args) {
+				f.ReconciliationUC.EXPECT().GetReconciliationReport(gomock.Any(), entities.ReconciliationRequest{}).Return(report, nil)
+			},
+			wantCode:        200,
+			wantContentType: "text/csv",
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationUC: mock_handler.NewMockReconciliationUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/reconciliation/report", nil),
+			},
+			mock: func(f fields, args args) {
+				f.ReconciliationUC.EXPECT().GetReconciliationReport(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode:        500,
+			wantContentType: "application/json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &ReconciliationHandler{
+				ReconciliationUC: f.ReconciliationUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.GetReport(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+			assert.Equal(t, tt.wantContentType, tt.args.w.Header().Get("Content-Type"))
+		})
+	}
+}
+
+func TestReconciliationHandler_MatchManually(t *testing.T) {
+	type fields struct {
+		ReconciliationUC *mock_handler.MockReconciliationUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationUC: mock_handler.NewMockReconciliationUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/reconciliation/match",
+					bytes.NewBufferString(`{"line_id":14,"repayment_reference_id":"repay4","note":"confirmed"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.ReconciliationUC.EXPECT().MatchManually(gomock.Any(), entities.ManualMatchRequest{
+					LineId:               14,
+					RepaymentReferenceId: "repay4",
+					Note:                 "confirmed",
+				}).Return(&entities.BankStatementLine{Id: 14, Status: entities.ReconciliationMatched, RepaymentId: 4}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationUC: mock_handler.NewMockReconciliationUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/reconciliation/match", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationUC: mock_handler.NewMockReconciliationUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/reconciliation/match", bytes.NewBufferString(`{"line_id":14}`)),
+			},
+			mock: func(f fields, args args) {
+				f.ReconciliationUC.EXPECT().MatchManually(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &ReconciliationHandler{
+				ReconciliationUC: f.ReconciliationUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.MatchManually(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package restful
+
+import (
+	"net/http"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func (h *ReportHandler) GetPortfolioAtRisk(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	report, err := h.ReportUC.GetPortfolioAtRisk(ctx, reportRequest(r))
+	if isCSV(r) {
+		helper.CSV(w, ctx, "portfolio_at_risk.csv", report, err)
+		return
+	}
+
+	helper.JSON(w, ctx, report, err)
+}
+
+func (h *ReportHandler) GetOutstanding(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	report, err := h.ReportUC.GetOutstandingBySchedule(ctx, reportRequest(r))
+	if isCSV(r) {
+		helper.CSV(w, ctx, "outstanding_by_schedule.csv", report, err)
+		return
+	}
+
+	helper.JSON(w, ctx, report, err)
+}
+
+func (h *ReportHandler) GetDisbursement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	report, err := h.ReportUC.GetDisbursement(ctx, reportRequest(r))
+	if isCSV(r) {
+		helper.CSV(w, ctx, "disbursement.csv", report, err)
+		return
+	}
+
+	helper.JSON(w, ctx, report, err)
+}
+
+func (h *ReportHandler) GetCollectionRate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	report, err := h.ReportUC.GetCollectionRate(ctx, reportRequest(r))
+	if isCSV(r) {
+		helper.CSV(w, ctx, "collection_rate.csv", report, err)
+		return
+	}
+
+	helper.JSON(w, ctx, report, err)
+}
+
+func reportRequest(r *http.Request) entities.ReportRequest {
+	return entities.ReportRequest{
+		FromDate:          r.FormValue("from_date"),
+		ToDate:            r.FormValue("to_date"),
+		RepaymentSchedule: r.FormValue("repayment_schedule"),
+		Status:            r.FormValue("status"),
+		Restructured:      r.FormValue("restructured"),
+	}
+}
+
+func isCSV(r *http.Request) bool {
+	return r.FormValue("format") == "csv"
+}
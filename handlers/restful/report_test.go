@@ -0,0 +1,111 @@
+package restful
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+)
+
+func TestReportHandler_GetPortfolioAtRisk(t *testing.T) {
+	type fields struct {
+		ReportUC *mock_handler.MockReportUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	report := &entities.PortfolioAtRiskReport{
+		AsOf: time.Date(2000, 3, 14, 0, 0, 0, 0, time.Local),
+		Portfolios: []entities.PortfolioAtRisk{{
+			Currency: "IDR",
+			Total:    entities.PortfolioMeasure{Loans: 1, PrincipalOutstanding: 1000, RatioPercent: 100},
+		}},
+	}
+	tests := []struct {
+		name            string
+		fields          func(ctrl *gomock.Controller) fields
+		args            args
+		mock            func(f fields, args args)
+		wantCode        int
+		wantContentType string
+	}{
+		{
+			name: "success json",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReportUC: mock_handler.NewMockReportUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/report/par?to_date=2000-03-14&repayment_schedule=weekly&status=active", nil),
+			},
+			mock: func(f fields, args args) {
+				f.ReportUC.EXPECT().GetPortfolioAtRisk(gomock.Any(), entities.ReportRequest{
+					ToDate:            "2000-03-14",
+					RepaymentSchedule: "weekly",
+					Status:            "active",
+				}).Return(report, nil)
+			},
+			wantCode:        200,
+			wantContentType: "application/json",
+		},
+		{
+			name: "success csv",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReportUC: mock_handler.NewMockReportUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/report/par?format=csv", nil),
+			},
+			mock: func(f fields, args args) {
+				f.ReportUC.EXPECT().GetPortfolioAtRisk(gomock.Any(), entities.ReportRequest{}).Return(report, nil)
+			},
+			wantCode:        200,
+			wantContentType: "text/csv",
+		},
+		{
+			name: "error usecase csv",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReportUC: mock_handler.NewMockReportUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/report/par?format=csv", nil),
+			},
+			mock: func(f fields, args args) {
+				f.ReportUC.EXPECT().GetPortfolioAtRisk(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode:        500,
+			wantContentType: "application/json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &ReportHandler{
+				ReportUC: f.ReportUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.GetPortfolioAtRisk(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+			assert.Equal(t, tt.wantContentType, tt.args.w.Header().Get("Content-Type"))
+		})
+	}
+}
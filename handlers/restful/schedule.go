@@ -0,0 +1,59 @@
+package restful
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func (h *ScheduleHandler) RestructureLoan(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var request entities.RestructureRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	version, err := h.ScheduleUC.RestructureLoan(ctx, request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, version, nil)
+}
+
+func (h *ScheduleHandler) DeferLoan(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var request entities.DeferralRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	version, err := h.ScheduleUC.DeferLoan(ctx, request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, version, nil)
+}
+
+func (h *ScheduleHandler) GetScheduleVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	referenceId := r.FormValue("loan_reference_id")
+
+	versions, err := h.ScheduleUC.GetScheduleVersionList(ctx, referenceId)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, versions, nil)
+}
@@ -0,0 +1,252 @@
+package restful
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+)
+
+func TestScheduleHandler_RestructureLoan(t *testing.T) {
+	type fields struct {
+		ScheduleUC *mock_handler.MockScheduleUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleUC: mock_handler.NewMockScheduleUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/loan/restructure",
+					bytes.NewBufferString(`{"loan_reference_id":"loan1","tenor":20,"rate_percentage":5,"capitalize_arrears":true,"reason":"job loss"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.ScheduleUC.EXPECT().RestructureLoan(gomock.Any(), entities.RestructureRequest{
+					LoanReferenceId:   "loan1",
+					Tenor:             20,
+					RatePercentage:    5,
+					CapitalizeArrears: true,
+					Reason:            "job loss",
+				}).Return(&entities.LoanScheduleVersion{LoanId: 1, Version: 2}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleUC: mock_handler.NewMockScheduleUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/loan/restructure", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleUC: mock_handler.NewMockScheduleUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/loan/restructure", bytes.NewBufferString(`{"loan_reference_id":"loan1"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.ScheduleUC.EXPECT().RestructureLoan(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &ScheduleHandler{
+				ScheduleUC: f.ScheduleUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.RestructureLoan(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
+
+func TestScheduleHandler_DeferLoan(t *testing.T) {
+	type fields struct {
+		ScheduleUC *mock_handler.MockScheduleUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleUC: mock_handler.NewMockScheduleUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/loan/deferral",
+					bytes.NewBufferString(`{"loan_reference_id":"loan1","installments":3,"interest":"spread","reason":"flood"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.ScheduleUC.EXPECT().DeferLoan(gomock.Any(), entities.DeferralRequest{
+					LoanReferenceId: "loan1",
+					Installments:    3,
+					Interest:        entities.DeferralInterestSpread,
+					Reason:          "flood",
+				}).Return(&entities.LoanScheduleVersion{LoanId: 1, Version: 2}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleUC: mock_handler.NewMockScheduleUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/loan/deferral", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleUC: mock_handler.NewMockScheduleUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/loan/deferral", bytes.NewBufferString(`{"loan_reference_id":"loan1"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.ScheduleUC.EXPECT().DeferLoan(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &ScheduleHandler{
+				ScheduleUC: f.ScheduleUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.DeferLoan(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
+
+func TestScheduleHandler_GetScheduleVersions(t *testing.T) {
+	type fields struct {
+		ScheduleUC *mock_handler.MockScheduleUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleUC: mock_handler.NewMockScheduleUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/loan/schedule/versions?loan_reference_id=loan1", nil),
+			},
+			mock: func(f fields, args args) {
+				f.ScheduleUC.EXPECT().GetScheduleVersionList(gomock.Any(), "loan1").Return(&[]entities.LoanScheduleVersion{
+					{LoanId: 1, Version: 1},
+				}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleUC: mock_handler.NewMockScheduleUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/loan/schedule/versions?loan_reference_id=loan1", nil),
+			},
+			mock: func(f fields, args args) {
+				f.ScheduleUC.EXPECT().GetScheduleVersionList(gomock.Any(), "loan1").Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &ScheduleHandler{
+				ScheduleUC: f.ScheduleUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.GetScheduleVersions(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package restful
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+// UploadSettlementFile takes a multipart form with the settlement file, the
+// bank_code of the bank that sent it and the format of the file
+func (h *SettlementHandler) UploadSettlementFile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	err := r.ParseMultipartForm(maxImportUploadSize)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "settlement file is required"))
+		return
+	}
+	defer file.Close()
+
+	result, err := h.SettlementUC.ProcessSettlementFile(ctx, entities.SettlementUploadRequest{
+		BankCode: r.FormValue("bank_code"),
+		Format:   r.FormValue("format"),
+		FileName: header.Filename,
+		File:     file,
+	})
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, result, nil)
+}
+
+func (h *SettlementHandler) GetExceptions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	exceptions, err := h.SettlementUC.GetSettlementExceptionList(ctx, r.FormValue("status"))
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, exceptions, nil)
+}
+
+func (h *SettlementHandler) RepostException(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var request entities.SettlementRepostRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	exception, err := h.SettlementUC.RepostSettlementException(ctx, request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, exception, nil)
+}
+
+func (h *SettlementHandler) DismissException(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var request entities.SettlementDismissRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	exception, err := h.SettlementUC.DismissSettlementException(ctx, request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, exception, nil)
+}
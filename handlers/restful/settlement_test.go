@@ -0,0 +1,207 @@
+package restful
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+)
+
+func newSettlementRequest(url string, content string, fields map[string]string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if content != "" {
+		part, _ := writer.CreateFormFile("file", "settlement.csv")
+		part.Write([]byte(content))
+	}
+	for name, value := range fields {
+		writer.WriteField(name, value)
+	}
+	writer.Close()
+
+	r := httptest.NewRequest("POST", url, body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	return r
+}
+
+func TestSettlementHandler_UploadSettlementFile(t *testing.T) {
+	type fields struct {
+		SettlementUC *mock_handler.MockSettlementUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementUC: mock_handler.NewMockSettlementUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: newSettlementRequest("localhost:8080/admin/settlement/upload", "transaction_id\ntrx1\n", map[string]string{
+					"bank_code": "BCA",
+					"format":    "csv",
+				}),
+			},
+			mock: func(f fields, args args) {
+				f.SettlementUC.EXPECT().ProcessSettlementFile(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, request entities.SettlementUploadRequest) (*entities.SettlementResult, error) {
+						assert.Equal(t, "BCA", request.BankCode)
+						assert.Equal(t, "csv", request.Format)
+						assert.Equal(t, "settlement.csv", request.FileName)
+						assert.NotNil(t, request.File)
+						return &entities.SettlementResult{}, nil
+					})
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error missing file",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementUC: mock_handler.NewMockSettlementUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: newSettlementRequest("localhost:8080/admin/settlement/upload", "", map[string]string{
+					"bank_code": "BCA",
+				}),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementUC: mock_handler.NewMockSettlementUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: newSettlementRequest("localhost:8080/admin/settlement/upload", "transaction_id\ntrx1\n", nil),
+			},
+			mock: func(f fields, args args) {
+				f.SettlementUC.EXPECT().ProcessSettlementFile(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &SettlementHandler{
+				SettlementUC: f.SettlementUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.UploadSettlementFile(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
+
+func TestSettlementHandler_RepostException(t *testing.T) {
+	type fields struct {
+		SettlementUC *mock_handler.MockSettlementUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementUC: mock_handler.NewMockSettlementUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/settlement/exception/repost",
+					bytes.NewBufferString(`{"exception_id":21,"loan_reference_id":"loan1"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.SettlementUC.EXPECT().RepostSettlementException(gomock.Any(), entities.SettlementRepostRequest{
+					ExceptionId:     21,
+					LoanReferenceId: "loan1",
+				}).Return(&entities.SettlementException{Id: 21, Status: entities.SettlementExceptionPosted}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementUC: mock_handler.NewMockSettlementUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/settlement/exception/repost", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementUC: mock_handler.NewMockSettlementUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/settlement/exception/repost", bytes.NewBufferString(`{"exception_id":21}`)),
+			},
+			mock: func(f fields, args args) {
+				f.SettlementUC.EXPECT().RepostSettlementException(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &SettlementHandler{
+				SettlementUC: f.SettlementUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.RepostException(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
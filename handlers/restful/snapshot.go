@@ -0,0 +1,41 @@
+package restful
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func (h *SnapshotHandler) Backfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var backfillRequest entities.SnapshotBackfillRequest
+	err := json.NewDecoder(r.Body).Decode(&backfillRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	results, err := h.SnapshotUC.BackfillSnapshots(ctx, backfillRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, results, nil)
+}
+
+func (h *SnapshotHandler) GetSnapshots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	businessDate := r.FormValue("business_date")
+
+	snapshots, err := h.SnapshotUC.GetSnapshotList(ctx, businessDate)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, snapshots, nil)
+}
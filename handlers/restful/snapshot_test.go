@@ -0,0 +1,97 @@
+package restful
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+)
+
+func TestSnapshotHandler_Backfill(t *testing.T) {
+	type fields struct {
+		SnapshotUC *mock_handler.MockSnapshotUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SnapshotUC: mock_handler.NewMockSnapshotUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/snapshot/backfill", bytes.NewBufferString(`{"from_date":"2000-03-01","to_date":"2000-03-02"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.SnapshotUC.EXPECT().BackfillSnapshots(gomock.Any(), entities.SnapshotBackfillRequest{
+					FromDate: "2000-03-01",
+					ToDate:   "2000-03-02",
+				}).Return(&[]entities.SnapshotRunResult{}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SnapshotUC: mock_handler.NewMockSnapshotUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/snapshot/backfill", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SnapshotUC: mock_handler.NewMockSnapshotUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/snapshot/backfill", bytes.NewBufferString(`{}`)),
+			},
+			mock: func(f fields, args args) {
+				f.SnapshotUC.EXPECT().BackfillSnapshots(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &SnapshotHandler{
+				SnapshotUC: f.SnapshotUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.Backfill(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package restful
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func (h *StatementHandler) GetStatement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, err := strconv.ParseInt(r.FormValue("user_id"), 10, 64)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid user ID"))
+		return
+	}
+	period := r.FormValue("period")
+
+	statement, err := h.StatementUC.GetStatement(ctx, entities.StatementRequest{
+		UserId: userId,
+		Period: period,
+	})
+
+	filename := "statement_" + strconv.FormatInt(userId, 10) + "_" + period
+	switch r.FormValue("format") {
+	case "csv":
+		helper.CSV(w, ctx, filename+".csv", statement, err)
+	case "pdf":
+		helper.PDF(w, ctx, filename+".pdf", statement, err)
+	default:
+		helper.JSON(w, ctx, statement, err)
+	}
+}
@@ -0,0 +1,140 @@
+package restful
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+)
+
+func TestStatementHandler_GetStatement(t *testing.T) {
+	type fields struct {
+		StatementUC *mock_handler.MockStatementUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	statement := &entities.Statement{
+		Id:          1,
+		UserId:      1,
+		PeriodStart: time.Date(2000, 3, 1, 0, 0, 0, 0, time.Local),
+		PeriodEnd:   time.Date(2000, 3, 31, 0, 0, 0, 0, time.Local),
+		Loans:       []entities.StatementLoan{{LoanReferenceId: "loan1", ClosingBalance: 1100}},
+	}
+	tests := []struct {
+		name            string
+		fields          func(ctrl *gomock.Controller) fields
+		args            args
+		mock            func(f fields, args args)
+		wantCode        int
+		wantContentType string
+	}{
+		{
+			name: "success json",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					StatementUC: mock_handler.NewMockStatementUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/statement?user_id=1&period=2000-03", nil),
+			},
+			mock: func(f fields, args args) {
+				f.StatementUC.EXPECT().GetStatement(gomock.Any(), entities.StatementRequest{UserId: 1, Period: "2000-03"}).Return(statement, nil)
+			},
+			wantCode:        200,
+			wantContentType: "application/json",
+		},
+		{
+			name: "success pdf",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					StatementUC: mock_handler.NewMockStatementUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/statement?user_id=1&period=2000-03&format=pdf", nil),
+			},
+			mock: func(f fields, args args) {
+				f.StatementUC.EXPECT().GetStatement(gomock.Any(), gomock.Any()).Return(statement, nil)
+			},
+			wantCode:        200,
+			wantContentType: "application/pdf",
+		},
+		{
+			name: "success csv",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					StatementUC: mock_handler.NewMockStatementUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/statement?user_id=1&period=2000-03&format=csv", nil),
+			},
+			mock: func(f fields, args args) {
+				f.StatementUC.EXPECT().GetStatement(gomock.Any(), gomock.Any()).Return(statement, nil)
+			},
+			wantCode:        200,
+			wantContentType: "text/csv",
+		},
+		{
+			name: "error invalid user id",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					StatementUC: mock_handler.NewMockStatementUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/statement?user_id=abc&period=2000-03", nil),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode:        400,
+			wantContentType: "application/json",
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					StatementUC: mock_handler.NewMockStatementUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/statement?user_id=1&period=2000-03&format=pdf", nil),
+			},
+			mock: func(f fields, args args) {
+				f.StatementUC.EXPECT().GetStatement(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode:        500,
+			wantContentType: "application/json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &StatementHandler{
+				StatementUC: f.StatementUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.GetStatement(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+			assert.Equal(t, tt.wantContentType, tt.args.w.Header().Get("Content-Type"))
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package restful
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+)
+
+func TestTenantHandler_GetTenant(t *testing.T) {
+	type fields struct {
+		TenantUC *mock_handler.MockTenantUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					TenantUC: mock_handler.NewMockTenantUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/tenant", nil),
+			},
+			mock: func(f fields, args args) {
+				f.TenantUC.EXPECT().GetCurrentTenant(gomock.Any()).Return(&entities.Tenant{Id: 2, Code: "partner"}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					TenantUC: mock_handler.NewMockTenantUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/admin/tenant", nil),
+			},
+			mock: func(f fields, args args) {
+				f.TenantUC.EXPECT().GetCurrentTenant(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &TenantHandler{
+				TenantUC: f.TenantUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.GetTenant(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
+
+func TestTenantHandler_UpdateSettings(t *testing.T) {
+	type fields struct {
+		TenantUC *mock_handler.MockTenantUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					TenantUC: mock_handler.NewMockTenantUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/tenant/settings",
+					bytes.NewBufferString(`{"name":"Partner","timezone":"Asia/Jakarta","currency":"IDR","delinquent_after_missed":3}`)),
+			},
+			mock: func(f fields, args args) {
+				f.TenantUC.EXPECT().UpdateSettings(gomock.Any(), entities.TenantSettingsRequest{
+					Name:                  "Partner",
+					Timezone:              "Asia/Jakarta",
+					Currency:              "IDR",
+					DelinquentAfterMissed: 3,
+				}).Return(&entities.Tenant{Id: 2}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					TenantUC: mock_handler.NewMockTenantUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/tenant/settings", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					TenantUC: mock_handler.NewMockTenantUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/tenant/settings", bytes.NewBufferString(`{"name":"Partner"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.TenantUC.EXPECT().UpdateSettings(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &TenantHandler{
+				TenantUC: f.TenantUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.UpdateSettings(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
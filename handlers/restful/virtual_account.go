@@ -0,0 +1,61 @@
+package restful
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func (h *VirtualAccountHandler) CreateVirtualAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var virtualAccountRequest entities.VirtualAccountRequest
+	err := json.NewDecoder(r.Body).Decode(&virtualAccountRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	virtualAccount, err := h.VirtualAccountUC.CreateVirtualAccount(ctx, virtualAccountRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, virtualAccount, nil)
+}
+
+func (h *VirtualAccountHandler) GetVirtualAccountList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	referenceId := r.FormValue("loan_reference_id")
+
+	virtualAccounts, err := h.VirtualAccountUC.GetVirtualAccountListByLoanReferenceId(ctx, referenceId)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, virtualAccounts, nil)
+}
+
+func (h *VirtualAccountHandler) PaymentCallback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var callback entities.PaymentCallback
+	err := json.NewDecoder(r.Body).Decode(&callback)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	paymentID, err := h.VirtualAccountUC.HandlePaymentCallback(ctx, callback)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, map[string]int64{
+		"payment_id": paymentID,
+	}, nil)
+}
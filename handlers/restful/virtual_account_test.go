@@ -0,0 +1,181 @@
+package restful
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/handlers/middleware"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+	"github.com/sirait-kevin/BillingEngine/pkg/fakegateway"
+)
+
+func TestVirtualAccountHandler_CreateVirtualAccount(t *testing.T) {
+	type fields struct {
+		VirtualAccountUC *mock_handler.MockVirtualAccountUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					VirtualAccountUC: mock_handler.NewMockVirtualAccountUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/virtual-account/create", bytes.NewBufferString(`{"loan_reference_id":"loan1","bank_code":"BCA"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.VirtualAccountUC.EXPECT().CreateVirtualAccount(gomock.Any(), entities.VirtualAccountRequest{
+					LoanReferenceId: "loan1",
+					BankCode:        "BCA",
+				}).Return(&entities.VirtualAccount{Id: 1}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					VirtualAccountUC: mock_handler.NewMockVirtualAccountUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/virtual-account/create", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					VirtualAccountUC: mock_handler.NewMockVirtualAccountUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/virtual-account/create", bytes.NewBufferString(`{"loan_reference_id":"loan1","bank_code":"BCA"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.VirtualAccountUC.EXPECT().CreateVirtualAccount(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &VirtualAccountHandler{
+				VirtualAccountUC: f.VirtualAccountUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.CreateVirtualAccount(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
+
+func TestVirtualAccountHandler_PaymentCallback(t *testing.T) {
+	callback := entities.PaymentCallback{
+		TransactionId:        "trx1",
+		BankCode:             "BCA",
+		VirtualAccountNumber: "880800000000013",
+		Amount:               1000,
+		PaidAt:               time.Date(2000, 12, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	type fields struct {
+		VirtualAccountUC *mock_handler.MockVirtualAccountUsecase
+	}
+	tests := []struct {
+		name          string
+		fields        func(ctrl *gomock.Controller) fields
+		gatewaySecret string
+		mock          func(f fields)
+		wantCode      int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					VirtualAccountUC: mock_handler.NewMockVirtualAccountUsecase(ctrl),
+				}
+			},
+			gatewaySecret: "gateway-secret",
+			mock: func(f fields) {
+				f.VirtualAccountUC.EXPECT().HandlePaymentCallback(gomock.Any(), callback).Return(int64(1), nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error invalid signature",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					VirtualAccountUC: mock_handler.NewMockVirtualAccountUsecase(ctrl),
+				}
+			},
+			gatewaySecret: "wrong-secret",
+			mock: func(f fields) {
+			},
+			wantCode: 401,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					VirtualAccountUC: mock_handler.NewMockVirtualAccountUsecase(ctrl),
+				}
+			},
+			gatewaySecret: "gateway-secret",
+			mock: func(f fields) {
+				f.VirtualAccountUC.EXPECT().HandlePaymentCallback(gomock.Any(), callback).Return(int64(0), errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &VirtualAccountHandler{
+				VirtualAccountUC: f.VirtualAccountUC,
+			}
+			tt.mock(f)
+
+			server := httptest.NewServer(middleware.VerifyCallbackSignatureMiddleware("gateway-secret")(http.HandlerFunc(h.PaymentCallback)))
+			defer server.Close()
+
+			gateway := &fakegateway.Gateway{CallbackURL: server.URL, Secret: tt.gatewaySecret}
+			resp, err := gateway.SendPayment(context.Background(), callback)
+			assert.Nil(t, err)
+			defer resp.Body.Close()
+			assert.EqualValues(t, tt.wantCode, resp.StatusCode)
+		})
+	}
+}
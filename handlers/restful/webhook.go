@@ -0,0 +1,96 @@
+package restful
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func (h *WebhookHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var subscriptionRequest entities.WebhookSubscriptionRequest
+	err := json.NewDecoder(r.Body).Decode(&subscriptionRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	subscription, err := h.WebhookUC.RegisterSubscription(ctx, helper.GetClientKey(ctx), subscriptionRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, subscription, nil)
+}
+
+func (h *WebhookHandler) GetSubscriptions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	subscriptions, err := h.WebhookUC.GetSubscriptionList(ctx, helper.GetClientKey(ctx))
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, subscriptions, nil)
+}
+
+func (h *WebhookHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var idRequest entities.WebhookSubscriptionIdRequest
+	err := json.NewDecoder(r.Body).Decode(&idRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	err = h.WebhookUC.DisableSubscription(ctx, helper.GetClientKey(ctx), idRequest.SubscriptionId)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, map[string]int64{
+		"subscription_id": idRequest.SubscriptionId,
+	}, nil)
+}
+
+func (h *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	subscriptionId, err := strconv.ParseInt(r.FormValue("subscription_id"), 10, 64)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid subscription ID"))
+		return
+	}
+
+	deliveries, err := h.WebhookUC.GetDeliveryList(ctx, helper.GetClientKey(ctx), subscriptionId)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, deliveries, nil)
+}
+
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var redeliverRequest entities.WebhookRedeliverRequest
+	err := json.NewDecoder(r.Body).Decode(&redeliverRequest)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	delivery, err := h.WebhookUC.Redeliver(ctx, helper.GetClientKey(ctx), redeliverRequest.DeliveryId)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, delivery, nil)
+}
@@ -0,0 +1,266 @@
+package restful
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+)
+
+func getSampleWebhookSubscriptionRequest() entities.WebhookSubscriptionRequest {
+	return entities.WebhookSubscriptionRequest{
+		Url:        "https://partner.example.com/hooks",
+		EventTypes: []string{entities.EventPaymentReceived},
+	}
+}
+
+func TestWebhookHandler_Subscribe(t *testing.T) {
+	type fields struct {
+		WebhookUC *mock_handler.MockWebhookUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookUC: mock_handler.NewMockWebhookUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					jsonB, _ := json.Marshal(getSampleWebhookSubscriptionRequest())
+					return httptest.NewRequest("POST", "localhost:8080/webhook/subscribe", bytes.NewBuffer(jsonB))
+				}(),
+			},
+			mock: func(f fields, args args) {
+				f.WebhookUC.EXPECT().RegisterSubscription(gomock.Any(), "", getSampleWebhookSubscriptionRequest()).Return(&entities.WebhookSubscription{Id: 1}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookUC: mock_handler.NewMockWebhookUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/webhook/subscribe", bytes.NewBuffer([]byte("error"))),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookUC: mock_handler.NewMockWebhookUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					jsonB, _ := json.Marshal(getSampleWebhookSubscriptionRequest())
+					return httptest.NewRequest("POST", "localhost:8080/webhook/subscribe", bytes.NewBuffer(jsonB))
+				}(),
+			},
+			mock: func(f fields, args args) {
+				f.WebhookUC.EXPECT().RegisterSubscription(gomock.Any(), "", getSampleWebhookSubscriptionRequest()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &WebhookHandler{
+				WebhookUC: f.WebhookUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.Subscribe(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
+
+func TestWebhookHandler_GetDeliveries(t *testing.T) {
+	type fields struct {
+		WebhookUC *mock_handler.MockWebhookUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookUC: mock_handler.NewMockWebhookUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/webhook/deliveries?subscription_id=1", nil),
+			},
+			mock: func(f fields, args args) {
+				f.WebhookUC.EXPECT().GetDeliveryList(gomock.Any(), "", int64(1)).Return(&[]entities.WebhookDelivery{}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error parameter",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookUC: mock_handler.NewMockWebhookUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/webhook/deliveries?subscription_id=a", nil),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookUC: mock_handler.NewMockWebhookUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "localhost:8080/webhook/deliveries?subscription_id=1", nil),
+			},
+			mock: func(f fields, args args) {
+				f.WebhookUC.EXPECT().GetDeliveryList(gomock.Any(), "", int64(1)).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &WebhookHandler{
+				WebhookUC: f.WebhookUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.GetDeliveries(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
+
+func TestWebhookHandler_Redeliver(t *testing.T) {
+	type fields struct {
+		WebhookUC *mock_handler.MockWebhookUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookUC: mock_handler.NewMockWebhookUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/webhook/redeliver", bytes.NewBufferString(`{"delivery_id":1}`)),
+			},
+			mock: func(f fields, args args) {
+				f.WebhookUC.EXPECT().Redeliver(gomock.Any(), "", int64(1)).Return(&entities.WebhookDelivery{Id: 1}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookUC: mock_handler.NewMockWebhookUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/webhook/redeliver", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookUC: mock_handler.NewMockWebhookUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/webhook/redeliver", bytes.NewBufferString(`{"delivery_id":1}`)),
+			},
+			mock: func(f fields, args args) {
+				f.WebhookUC.EXPECT().Redeliver(gomock.Any(), "", int64(1)).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &WebhookHandler{
+				WebhookUC: f.WebhookUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.Redeliver(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
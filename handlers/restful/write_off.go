@@ -0,0 +1,61 @@
+package restful
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func (h *WriteOffHandler) WriteOffLoan(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var request entities.WriteOffRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	writeOff, err := h.WriteOffUC.WriteOffLoan(ctx, request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, writeOff, nil)
+}
+
+func (h *WriteOffHandler) GetWriteOff(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	referenceId := r.FormValue("loan_reference_id")
+
+	detail, err := h.WriteOffUC.GetWriteOff(ctx, referenceId)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, detail, nil)
+}
+
+func (h *WriteOffHandler) RecordRecovery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var request entities.RecoveryRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, errs.NewWithMessage(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	recoveryId, err := h.WriteOffUC.RecordRecovery(ctx, request)
+	if err != nil {
+		helper.JSON(w, ctx, nil, err)
+		return
+	}
+
+	helper.JSON(w, ctx, map[string]int64{
+		"recovery_id": recoveryId,
+	}, nil)
+}
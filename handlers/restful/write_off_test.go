@@ -0,0 +1,182 @@
+package restful
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_handler "github.com/sirait-kevin/BillingEngine/mocks/handler"
+)
+
+func TestWriteOffHandler_WriteOffLoan(t *testing.T) {
+	type fields struct {
+		WriteOffUC *mock_handler.MockWriteOffUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffUC: mock_handler.NewMockWriteOffUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/loan/write-off",
+					bytes.NewBufferString(`{"loan_reference_id":"loan1","reason":"borrower deceased"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.WriteOffUC.EXPECT().WriteOffLoan(gomock.Any(), entities.WriteOffRequest{
+					LoanReferenceId: "loan1",
+					Reason:          "borrower deceased",
+				}).Return(&entities.WriteOff{Id: 5, LoanId: 1, Amount: 1100}, nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffUC: mock_handler.NewMockWriteOffUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/loan/write-off", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffUC: mock_handler.NewMockWriteOffUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/loan/write-off", bytes.NewBufferString(`{"loan_reference_id":"loan1"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.WriteOffUC.EXPECT().WriteOffLoan(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &WriteOffHandler{
+				WriteOffUC: f.WriteOffUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.WriteOffLoan(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
+
+func TestWriteOffHandler_RecordRecovery(t *testing.T) {
+	type fields struct {
+		WriteOffUC *mock_handler.MockWriteOffUsecase
+	}
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		args     args
+		mock     func(f fields, args args)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffUC: mock_handler.NewMockWriteOffUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/loan/recovery",
+					bytes.NewBufferString(`{"loan_reference_id":"loan1","recovery_reference_id":"rec1","amount":500}`)),
+			},
+			mock: func(f fields, args args) {
+				f.WriteOffUC.EXPECT().RecordRecovery(gomock.Any(), entities.RecoveryRequest{
+					LoanReferenceId:     "loan1",
+					RecoveryReferenceId: "rec1",
+					Amount:              500,
+				}).Return(int64(7), nil)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "error request decoding",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffUC: mock_handler.NewMockWriteOffUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/loan/recovery", bytes.NewBufferString("error")),
+			},
+			mock: func(f fields, args args) {
+			},
+			wantCode: 400,
+		},
+		{
+			name: "error usecase",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffUC: mock_handler.NewMockWriteOffUsecase(ctrl),
+				}
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("POST", "localhost:8080/admin/loan/recovery", bytes.NewBufferString(`{"loan_reference_id":"loan1"}`)),
+			},
+			mock: func(f fields, args args) {
+				f.WriteOffUC.EXPECT().RecordRecovery(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("some error"))
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			h := &WriteOffHandler{
+				WriteOffUC: f.WriteOffUC,
+			}
+			tt.mock(f, tt.args)
+
+			h.RecordRecovery(tt.args.w, tt.args.r)
+			assert.EqualValues(t, tt.wantCode, tt.args.w.Code)
+		})
+	}
+}
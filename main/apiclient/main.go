@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+	"github.com/sirait-kevin/BillingEngine/pkg/keyring"
+	"github.com/sirait-kevin/BillingEngine/pkg/logger"
+	"github.com/sirait-kevin/BillingEngine/repositories"
+	"github.com/sirait-kevin/BillingEngine/usecases"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+// apiclient creates an API client straight in the database, e.g. the first
+// admin client the others are created with, and prints its key and secret
+//
+//	go run main/apiclient/main.go -name operations -scopes admin -tenant 1
+func main() {
+	name := flag.String("name", "", "client name")
+	scopes := flag.String("scopes", "read", "comma separated scopes: read, write, payments, admin")
+	tenantId := flag.Int64("tenant", entities.DefaultTenantId, "id of the tenant the client belongs to")
+	actor := flag.String("actor", os.Getenv("USER"), "admin user the changes are audited as")
+	flag.Parse()
+
+	if *name == "" {
+		log.Fatal("-name is required")
+	}
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+	logger.InitLogger(true)
+
+	keys, err := keyring.Load()
+	if err != nil {
+		log.Fatalf("Failed to load the encryption keyring: %v", err)
+	}
+	helper.SetKeyring(keys)
+
+	db, err := sqlx.Connect("mysql", "BillingEngine:rootpassword@tcp(localhost:3306)/BillingEngine?parseTime=true")
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	request := entities.ApiClientRequest{Name: *name}
+	for _, scope := range strings.Split(*scopes, ",") {
+		request.Scopes = append(request.Scopes, entities.ApiScope(strings.TrimSpace(scope)))
+	}
+
+	apiClientUsecase := &usecases.ApiClientUseCase{
+		ApiClientRepo: &repositories.DBRepository{DB: db},
+		Clock:         helper.RealClock{},
+	}
+	ctx := context.WithValue(context.Background(), "logger", logger.Log.WithField("command", "apiclient"))
+	ctx = helper.WithAdminUser(ctx, *actor)
+	ctx = helper.WithTenantId(ctx, *tenantId)
+	client, err := apiClientUsecase.CreateClient(ctx, request)
+	if err != nil {
+		log.Fatalf("Failed to create api client: %v", err)
+	}
+
+	fmt.Printf("Client-Key: %s\nSecret:     %s\n", client.ClientKey, client.Secret)
+}
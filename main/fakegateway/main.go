@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/fakegateway"
+)
+
+// fakegateway sends a signed virtual account payment notification to a
+// running BillingEngine, e.g.
+//
+//	go run main/fakegateway/main.go -va 88080000000001 -bank BCA -amount 1050 -trx trx-1
+func main() {
+	callbackURL := flag.String("url", "http://localhost:8080/callback/payment", "callback endpoint")
+	secret := flag.String("secret", os.Getenv("PAYMENT_GATEWAY_SECRET"), "gateway signing secret")
+	bankCode := flag.String("bank", "", "bank code of the virtual account")
+	number := flag.String("va", "", "virtual account number")
+	amount := flag.Int64("amount", 0, "paid amount")
+	transactionId := flag.String("trx", "", "gateway transaction id")
+	flag.Parse()
+
+	gateway := &fakegateway.Gateway{CallbackURL: *callbackURL, Secret: *secret}
+	resp, err := gateway.SendPayment(context.Background(), entities.PaymentCallback{
+		TransactionId:        *transactionId,
+		BankCode:             *bankCode,
+		VirtualAccountNumber: *number,
+		Amount:               *amount,
+	})
+	if err != nil {
+		log.Fatalf("Failed to send callback: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	log.Printf("%s %s", resp.Status, body)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+	"github.com/sirait-kevin/BillingEngine/pkg/keyring"
+	"github.com/sirait-kevin/BillingEngine/pkg/logger"
+	"github.com/sirait-kevin/BillingEngine/repositories"
+	"github.com/sirait-kevin/BillingEngine/usecases"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+// importloans imports a partner's loan book straight into the database, under
+// the partner's tenant, and writes the result of every row to a CSV file, e.g.
+//
+//	go run main/importloans/main.go -tenant 2 -loans loans.csv -repayments repayments.csv -out result.csv -dry-run
+func main() {
+	loansPath := flag.String("loans", "", "loans CSV file")
+	repaymentsPath := flag.String("repayments", "", "optional repayments CSV file")
+	outPath := flag.String("out", "import_result.csv", "result CSV file")
+	dryRun := flag.Bool("dry-run", false, "validate the files without saving")
+	tenantId := flag.Int64("tenant", entities.DefaultTenantId, "id of the tenant the loans belong to")
+	actor := flag.String("actor", os.Getenv("USER"), "admin user the changes are audited as")
+	flag.Parse()
+
+	if *loansPath == "" {
+		log.Fatal("-loans is required")
+	}
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+	logger.InitLogger(true)
+
+	keys, err := keyring.Load()
+	if err != nil {
+		log.Fatalf("Failed to load the encryption keyring: %v", err)
+	}
+	helper.SetKeyring(keys)
+
+	db, err := sqlx.Connect("mysql", "BillingEngine:rootpassword@tcp(localhost:3306)/BillingEngine?parseTime=true")
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	request := entities.LoanImportRequest{DryRun: *dryRun}
+	loans, err := os.Open(*loansPath)
+	if err != nil {
+		log.Fatalf("Failed to open loans file: %v", err)
+	}
+	defer loans.Close()
+	request.Loans = loans
+
+	if *repaymentsPath != "" {
+		repayments, err := os.Open(*repaymentsPath)
+		if err != nil {
+			log.Fatalf("Failed to open repayments file: %v", err)
+		}
+		defer repayments.Close()
+		request.Repayments = repayments
+	}
+
+	dbRepository := &repositories.DBRepository{DB: db}
+	importUsecase := &usecases.ImportUseCase{
+		ImportRepo: dbRepository,
+		Clock:      helper.RealClock{},
+		Tenants:    &usecases.TenantUseCase{TenantRepo: dbRepository},
+	}
+	ctx := context.WithValue(context.Background(), "logger", logger.Log.WithField("command", "importloans"))
+	ctx = helper.WithAdminUser(ctx, *actor)
+	ctx = helper.WithTenantId(ctx, *tenantId)
+	result, err := importUsecase.ImportLoans(ctx, request)
+	if err != nil {
+		log.Fatalf("Failed to import loans: %v", err)
+	}
+
+	out, err := os.Create(*outPath)
+	if err != nil {
+		log.Fatalf("Failed to create result file: %v", err)
+	}
+	defer out.Close()
+
+	writer := csv.NewWriter(out)
+	writer.Write(result.CSVHeader())
+	writer.WriteAll(result.CSVRows())
+	if err = writer.Error(); err != nil {
+		log.Fatalf("Failed to write result file: %v", err)
+	}
+
+	log.Printf("%d rows, %d succeeded, %d failed, result written to %s", result.Total, result.Succeeded, result.Failed, *outPath)
+}
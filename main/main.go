@@ -1,18 +1,35 @@
 package main
 
 import (
+	"context"
+	"expvar"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/joho/godotenv"
 	"github.com/nsqio/go-nsq"
 
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
 	"github.com/sirait-kevin/BillingEngine/handlers/middleware"
 	"github.com/sirait-kevin/BillingEngine/handlers/mq"
 	"github.com/sirait-kevin/BillingEngine/handlers/restful"
+	"github.com/sirait-kevin/BillingEngine/pkg/collector"
+	"github.com/sirait-kevin/BillingEngine/pkg/cron"
+	"github.com/sirait-kevin/BillingEngine/pkg/event"
 	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+	"github.com/sirait-kevin/BillingEngine/pkg/keyring"
 	"github.com/sirait-kevin/BillingEngine/pkg/logger"
+	"github.com/sirait-kevin/BillingEngine/pkg/nonce"
+	"github.com/sirait-kevin/BillingEngine/pkg/notification"
+	"github.com/sirait-kevin/BillingEngine/pkg/ratelimit"
+	"github.com/sirait-kevin/BillingEngine/pkg/settlement"
+	"github.com/sirait-kevin/BillingEngine/pkg/virtualaccount"
 	"github.com/sirait-kevin/BillingEngine/repositories"
 	"github.com/sirait-kevin/BillingEngine/usecases"
 
@@ -30,6 +47,12 @@ func main() {
 	logger.InitLogger(true)
 	logger.Info("Starting BillingEngine...")
 
+	keys, err := keyring.Load()
+	if err != nil {
+		log.Fatalf("Failed to load the encryption keyring: %v", err)
+	}
+	helper.SetKeyring(keys)
+
 	db, err := sqlx.Connect("mysql", "BillingEngine:rootpassword@tcp(localhost:3306)/BillingEngine?parseTime=true")
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
@@ -37,17 +60,257 @@ func main() {
 	defer db.Close()
 
 	dbRepository := &repositories.DBRepository{DB: db}
+	nonceStore := newNonceStore(dbRepository)
+	authAuditUsecase := &usecases.AuthAuditUseCase{
+		AuthAuditRepo: dbRepository,
+		Clock:         helper.RealClock{},
+		MaxFailures:   10,
+		BlockWindow:   15 * time.Minute,
+		Retention:     30 * 24 * time.Hour,
+	}
+	apiClientUsecase := &usecases.ApiClientUseCase{
+		ApiClientRepo:       dbRepository,
+		Clock:               helper.RealClock{},
+		RotationGracePeriod: 24 * time.Hour,
+	}
+	webhookUsecase := &usecases.WebhookUseCase{
+		WebhookRepo: dbRepository,
+		HTTPClient:  newWebhookHTTPClient(),
+		Clock:       helper.RealClock{},
+	}
+	// the notifiers write to local files until the email, SMS and push providers are set up
+	notificationUsecase := &usecases.NotificationUseCase{
+		NotificationRepo: dbRepository,
+		Notifiers: notification.NewRegistry(
+			&notification.FileNotifier{Name: entities.NotificationEmail, Path: "notifications.email.jsonl"},
+			&notification.FileNotifier{Name: entities.NotificationSMS, Path: "notifications.sms.jsonl"},
+			&notification.FileNotifier{Name: entities.NotificationPush, Path: "notifications.push.jsonl"},
+		),
+		Templates:    entities.DefaultNotificationTemplates,
+		ReminderDays: 3,
+	}
+	tenantUsecase := &usecases.TenantUseCase{
+		TenantRepo: dbRepository,
+	}
+	auditUsecase := &usecases.AuditUseCase{
+		AuditRepo: dbRepository,
+		BatchSize: 1000,
+	}
 	billingUsecase := &usecases.BillingUseCase{
-		DBRepo: dbRepository,
-		Clock:  helper.RealClock{},
+		DBRepo:  dbRepository,
+		Clock:   helper.RealClock{},
+		Events:  event.FanOut{webhookUsecase, notificationUsecase},
+		Tenants: tenantUsecase,
+	}
+	virtualAccountUsecase := &usecases.VirtualAccountUseCase{
+		VirtualAccountRepo: dbRepository,
+		Generators: virtualaccount.NewRegistry(
+			virtualaccount.PrefixGenerator{Bank: "BCA", Prefix: "8808", Digits: 10},
+			virtualaccount.PrefixGenerator{Bank: "BNI", Prefix: "9881", Digits: 10},
+			virtualaccount.PrefixGenerator{Bank: "MANDIRI", Prefix: "8950", Digits: 10},
+		),
+		Payments: billingUsecase,
+		Clock:    helper.RealClock{},
+	}
+	collectionUsecase := &usecases.CollectionUseCase{
+		CollectionRepo: dbRepository,
+		Collector:      &collector.SimulatedCollector{},
+		Payments:       billingUsecase,
+		Clock:          helper.RealClock{},
+		RetryPolicy:    entities.DefaultRetryPolicy,
+		PromisePolicy:  entities.DefaultPromisePolicy,
+		Tenants:        tenantUsecase,
+	}
+	settlementUsecase := &usecases.SettlementUseCase{
+		SettlementRepo: dbRepository,
+		Parsers: settlement.NewRegistry(
+			settlement.CSVParser{},
+			// D<transaction id:20><virtual account:20><amount:15><paid at:14>
+			settlement.FixedWidthParser{
+				Name:                 "fixed",
+				DetailPrefix:         "D",
+				TransactionId:        settlement.Field{Start: 1, Length: 20},
+				VirtualAccountNumber: settlement.Field{Start: 21, Length: 20},
+				Amount:               settlement.Field{Start: 41, Length: 15},
+				PaidAt:               settlement.Field{Start: 56, Length: 14},
+				PaidAtLayout:         "20060102150405",
+			},
+		),
+		Payments: billingUsecase,
+	}
+	reconciliationUsecase := &usecases.ReconciliationUseCase{
+		ReconciliationRepo: dbRepository,
+		Clock:              helper.RealClock{},
+		Tolerance:          entities.DefaultMatchTolerance,
+	}
+	writeOffUsecase := &usecases.WriteOffUseCase{
+		WriteOffRepo: dbRepository,
+		Clock:        helper.RealClock{},
+		Policy:       entities.DefaultWriteOffPolicy,
+		Tenants:      tenantUsecase,
+	}
+	scheduleUsecase := &usecases.ScheduleUseCase{
+		ScheduleRepo: dbRepository,
+		Clock:        helper.RealClock{},
+	}
+	dunningUsecase := &usecases.DunningUseCase{
+		DunningRepo: dbRepository,
+		Clock:       helper.RealClock{},
+		Events:      event.FanOut{webhookUsecase, notificationUsecase},
+		Policy:      entities.DefaultDunningPolicy,
+		Tenants:     tenantUsecase,
+	}
+	snapshotUsecase := &usecases.SnapshotUseCase{
+		SnapshotRepo: dbRepository,
+		Clock:        helper.RealClock{},
+	}
+	importUsecase := &usecases.ImportUseCase{
+		ImportRepo: dbRepository,
+		Clock:      helper.RealClock{},
+		Tenants:    tenantUsecase,
+	}
+	statementUsecase := &usecases.StatementUseCase{
+		StatementRepo: dbRepository,
+		Clock:         helper.RealClock{},
+	}
+	reportUsecase := &usecases.ReportUseCase{
+		ReportRepo: dbRepository,
+		Clock:      helper.RealClock{},
+	}
+	clock := helper.RealClock{}
+	jobUsecase := &usecases.JobUseCase{
+		JobRepo:    dbRepository,
+		Tenants:    tenantUsecase,
+		Clock:      clock,
+		InstanceId: instanceId(),
+		Location:   entities.DefaultTenant.Location(),
+		Jobs: []usecases.Job{
+			{
+				Name:      entities.JobCollectionRun,
+				Schedule:  cron.MustParse("0 6 * * *"),
+				PerTenant: true,
+				Run: func(ctx context.Context, businessDate time.Time) error {
+					_, err := collectionUsecase.RunCollection(ctx, businessDate)
+					return err
+				},
+			},
+			{
+				// runs before the write-off so a broken promise no longer holds it
+				Name:      entities.JobPromiseToPay,
+				Schedule:  cron.MustParse("5 0 * * *"),
+				PerTenant: true,
+				Run: func(ctx context.Context, businessDate time.Time) error {
+					_, err := collectionUsecase.EvaluatePromisesToPay(ctx, businessDate.AddDate(0, 0, -1))
+					return err
+				},
+			},
+			{
+				// runs before the snapshot so the day ends with the loans already written off
+				Name:      entities.JobWriteOff,
+				Schedule:  cron.MustParse("15 0 * * *"),
+				PerTenant: true,
+				Run: func(ctx context.Context, businessDate time.Time) error {
+					_, err := writeOffUsecase.WriteOffDelinquentLoans(ctx, businessDate.AddDate(0, 0, -1))
+					return err
+				},
+			},
+			{
+				// runs after the collection run so the auto-debits of the day are counted
+				Name:      entities.JobDunning,
+				Schedule:  cron.MustParse("0 7 * * *"),
+				PerTenant: true,
+				Run: func(ctx context.Context, businessDate time.Time) error {
+					_, err := dunningUsecase.EvaluateDunning(ctx, businessDate)
+					return err
+				},
+			},
+			{
+				Name:      entities.JobNotificationReminders,
+				Schedule:  cron.MustParse("0 8 * * *"),
+				PerTenant: true,
+				Run: func(ctx context.Context, businessDate time.Time) error {
+					_, err := notificationUsecase.SendReminders(ctx, businessDate)
+					return err
+				},
+			},
+			{
+				Name:     entities.JobRequestNoncePurge,
+				Schedule: cron.MustParse("45 0 * * *"),
+				Run: func(ctx context.Context, businessDate time.Time) error {
+					_, err := nonceStore.PurgeExpiredNonces(ctx, clock.Now())
+					return err
+				},
+			},
+			{
+				Name:     entities.JobAuthFailurePurge,
+				Schedule: cron.MustParse("50 0 * * *"),
+				Run: func(ctx context.Context, businessDate time.Time) error {
+					_, err := authAuditUsecase.PurgeAuthFailures(ctx)
+					return err
+				},
+			},
+			{
+				// runs after midnight and snapshots the day that just ended
+				Name:      entities.JobEndOfDay,
+				Schedule:  cron.MustParse("30 0 * * *"),
+				PerTenant: true,
+				Run: func(ctx context.Context, businessDate time.Time) error {
+					_, err := snapshotUsecase.CreateSnapshot(ctx, businessDate.AddDate(0, 0, -1))
+					return err
+				},
+			},
+		},
 	}
 	billingHandler := &restful.BillingHandler{BillingUC: billingUsecase}
+	webhookHandler := &restful.WebhookHandler{WebhookUC: webhookUsecase}
+	virtualAccountHandler := &restful.VirtualAccountHandler{VirtualAccountUC: virtualAccountUsecase}
+	collectionHandler := &restful.CollectionHandler{CollectionUC: collectionUsecase}
+	jobHandler := &restful.JobHandler{JobUC: jobUsecase}
+	snapshotHandler := &restful.SnapshotHandler{SnapshotUC: snapshotUsecase}
+	reportHandler := &restful.ReportHandler{ReportUC: reportUsecase}
+	statementHandler := &restful.StatementHandler{StatementUC: statementUsecase}
+	importHandler := &restful.ImportHandler{ImportUC: importUsecase}
+	settlementHandler := &restful.SettlementHandler{SettlementUC: settlementUsecase}
+	reconciliationHandler := &restful.ReconciliationHandler{ReconciliationUC: reconciliationUsecase}
+	writeOffHandler := &restful.WriteOffHandler{WriteOffUC: writeOffUsecase}
+	scheduleHandler := &restful.ScheduleHandler{ScheduleUC: scheduleUsecase}
+	dunningHandler := &restful.DunningHandler{DunningUC: dunningUsecase}
+	apiClientHandler := &restful.ApiClientHandler{ApiClientUC: apiClientUsecase}
+	authAuditHandler := &restful.AuthAuditHandler{AuthAuditUC: authAuditUsecase}
+	tenantHandler := &restful.TenantHandler{TenantUC: tenantUsecase}
+	auditHandler := &restful.AuditHandler{AuditUC: auditUsecase}
+	notificationHandler := &restful.NotificationHandler{NotificationUC: notificationUsecase}
+
+	mainRouter := mux.NewRouter()
 
-	router := mux.NewRouter()
+	// payment gateway callbacks are signed with the gateway secret instead of a Client-Key
+	callbackRouter := mainRouter.PathPrefix("/callback").Subrouter()
+	callbackRouter.Use(middleware.LoggingMiddleware)
+	callbackRouter.Use(middleware.ErrorHandlingMiddleware)
+	callbackRouter.Use(middleware.VerifyCallbackSignatureMiddleware(os.Getenv("PAYMENT_GATEWAY_SECRET")))
+
+	callbackRouter.HandleFunc("/payment", virtualAccountHandler.PaymentCallback).Methods(http.MethodPost)
+
+	router := mainRouter.NewRoute().Subrouter()
 
-	router.Use(middleware.VerifySignatureMiddleware)
 	router.Use(middleware.LoggingMiddleware)
 	router.Use(middleware.ErrorHandlingMiddleware)
+	router.Use(middleware.VerifySignatureMiddleware(apiClientUsecase, middleware.RouteScopes{
+		"POST /make/payment": entities.ScopePayments,
+	}, middleware.ReplayProtection{
+		Nonces: nonceStore,
+		Clock:  helper.RealClock{},
+		Window: signatureWindow(),
+	}, authAuditUsecase))
+	router.Use(middleware.RateLimitMiddleware(middleware.RateLimits{
+		Store:   newRateLimitStore(dbRepository),
+		Clock:   helper.RealClock{},
+		Default: entities.RateLimit{RequestsPerMinute: 600, Burst: 100},
+		Routes: map[string]entities.RateLimit{
+			"POST /make/payment": {RequestsPerMinute: 60, Burst: 10},
+			"GET /user/status":   {RequestsPerMinute: 120, Burst: 20},
+		},
+	}))
 
 	router.HandleFunc("/create/loan", billingHandler.CreateLoan).Methods(http.MethodPost)
 	router.HandleFunc("/make/payment", billingHandler.MakePayment).Methods(http.MethodPost)
@@ -56,13 +319,178 @@ func main() {
 	router.HandleFunc("/outstanding/amount", billingHandler.GetOutStandingAmount).Methods(http.MethodGet)
 	router.HandleFunc("/user/status", billingHandler.GetUserStatus).Methods(http.MethodGet)
 	router.HandleFunc("/payment/inquiry", billingHandler.GetPaymentInquiry).Methods(http.MethodGet)
+	router.HandleFunc("/payment/receipt", billingHandler.GetReceipt).Methods(http.MethodGet)
 	router.HandleFunc("/loan/history", billingHandler.GetLoanHistory).Methods(http.MethodGet)
+	router.HandleFunc("/statement", statementHandler.GetStatement).Methods(http.MethodGet)
+
+	router.HandleFunc("/webhook/subscribe", webhookHandler.Subscribe).Methods(http.MethodPost)
+	router.HandleFunc("/webhook/unsubscribe", webhookHandler.Unsubscribe).Methods(http.MethodPost)
+	router.HandleFunc("/webhook/subscriptions", webhookHandler.GetSubscriptions).Methods(http.MethodGet)
+	router.HandleFunc("/webhook/deliveries", webhookHandler.GetDeliveries).Methods(http.MethodGet)
+	router.HandleFunc("/webhook/redeliver", webhookHandler.Redeliver).Methods(http.MethodPost)
+
+	router.HandleFunc("/virtual-account/create", virtualAccountHandler.CreateVirtualAccount).Methods(http.MethodPost)
+	router.HandleFunc("/virtual-account/list", virtualAccountHandler.GetVirtualAccountList).Methods(http.MethodGet)
+
+	router.HandleFunc("/mandate/create", collectionHandler.CreateMandate).Methods(http.MethodPost)
+	router.HandleFunc("/mandate/revoke", collectionHandler.RevokeMandate).Methods(http.MethodPost)
+	router.HandleFunc("/mandate/list", collectionHandler.GetMandateList).Methods(http.MethodGet)
+	router.HandleFunc("/collection/instructions", collectionHandler.GetDebitInstructionList).Methods(http.MethodGet)
+	router.HandleFunc("/collection/promise", collectionHandler.CreatePromiseToPay).Methods(http.MethodPost)
+	router.HandleFunc("/collection/promises", collectionHandler.GetPromiseToPayList).Methods(http.MethodGet)
+
+	router.HandleFunc("/notification/preference", notificationHandler.SavePreference).Methods(http.MethodPost)
+	router.HandleFunc("/notification/preference", notificationHandler.GetPreference).Methods(http.MethodGet)
+
+	adminRouter := router.PathPrefix("/admin").Subrouter()
+	adminRouter.Use(middleware.AdminOnlyMiddleware)
+
+	adminRouter.HandleFunc("/api-client/create", apiClientHandler.CreateClient).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/api-client/disable", apiClientHandler.DisableClient).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/api-client/rotate", apiClientHandler.RotateSecret).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/api-client/rate-limit", apiClientHandler.SetRateLimit).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/api-clients", apiClientHandler.GetClients).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/audit/loan", auditHandler.GetLoanAuditTrail).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/audit/user", auditHandler.GetUserAuditTrail).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/tenant", tenantHandler.GetTenant).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/tenant/settings", tenantHandler.UpdateSettings).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/loan/import", importHandler.ImportLoans).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/loan/restructure", scheduleHandler.RestructureLoan).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/loan/deferral", scheduleHandler.DeferLoan).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/loan/schedule/versions", scheduleHandler.GetScheduleVersions).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/loan/write-off", writeOffHandler.WriteOffLoan).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/loan/write-off", writeOffHandler.GetWriteOff).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/loan/recovery", writeOffHandler.RecordRecovery).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/dunning/level", dunningHandler.GetDunningLevel).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/dunning/level/override", dunningHandler.OverrideDunningLevel).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/dunning/actions", dunningHandler.GetDunningActions).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/dunning/action/complete", dunningHandler.CompleteDunningAction).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/notification/deliveries", notificationHandler.GetDeliveries).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/payment/reverse", billingHandler.ReversePayment).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/snapshot/backfill", snapshotHandler.Backfill).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/snapshot/list", snapshotHandler.GetSnapshots).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/report/par", reportHandler.GetPortfolioAtRisk).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/report/outstanding", reportHandler.GetOutstanding).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/report/disbursement", reportHandler.GetDisbursement).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/report/collection-rate", reportHandler.GetCollectionRate).Methods(http.MethodGet)
+
+	// the settlement files, bank statements, collection runs and jobs are the
+	// operator's, not any tenant's
+	operatorRouter := adminRouter.NewRoute().Subrouter()
+	operatorRouter.Use(middleware.OperatorOnlyMiddleware)
+
+	operatorRouter.HandleFunc("/auth/failures", authAuditHandler.GetAuthFailures).Methods(http.MethodGet)
+	operatorRouter.HandleFunc("/audit/verify", auditHandler.VerifyAuditChain).Methods(http.MethodGet)
+	operatorRouter.HandleFunc("/settlement/upload", settlementHandler.UploadSettlementFile).Methods(http.MethodPost)
+	operatorRouter.HandleFunc("/settlement/exceptions", settlementHandler.GetExceptions).Methods(http.MethodGet)
+	operatorRouter.HandleFunc("/settlement/exception/repost", settlementHandler.RepostException).Methods(http.MethodPost)
+	operatorRouter.HandleFunc("/settlement/exception/dismiss", settlementHandler.DismissException).Methods(http.MethodPost)
+	operatorRouter.HandleFunc("/collection/run", collectionHandler.RunCollection).Methods(http.MethodPost)
+	operatorRouter.HandleFunc("/reconciliation/statement/import", reconciliationHandler.ImportBankStatement).Methods(http.MethodPost)
+	operatorRouter.HandleFunc("/reconciliation/run", reconciliationHandler.RunReconciliation).Methods(http.MethodPost)
+	operatorRouter.HandleFunc("/reconciliation/report", reconciliationHandler.GetReport).Methods(http.MethodGet)
+	operatorRouter.HandleFunc("/reconciliation/match", reconciliationHandler.MatchManually).Methods(http.MethodPost)
+	operatorRouter.HandleFunc("/jobs", jobHandler.GetJobs).Methods(http.MethodGet)
+	operatorRouter.HandleFunc("/job/runs", jobHandler.GetJobRuns).Methods(http.MethodGet)
+	operatorRouter.HandleFunc("/job/run", jobHandler.RunJob).Methods(http.MethodPost)
+	operatorRouter.Handle("/metrics", expvar.Handler()).Methods(http.MethodGet)
+
+	go startWebhookDispatcher(jobUsecase, webhookUsecase)
+	go startJobScheduler(jobUsecase)
 
 	//nsqHandler := &mq.NSQHandler{BillingUseCase: useCase}
 	//startNSQConsumer(nsqHandler)
 
 	logger.Log.Info("Starting server on :8080")
-	log.Fatal(http.ListenAndServe(":8080", router))
+	log.Fatal(http.ListenAndServe(":8080", mainRouter))
+}
+
+func startWebhookDispatcher(jobUsecase *usecases.JobUseCase, webhookUsecase *usecases.WebhookUseCase) {
+	ctx := context.WithValue(context.Background(), "logger", logger.Log.WithField("worker", "webhook_dispatcher"))
+	ctx = helper.WithOperator(ctx)
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		// every instance ticks, the one holding the lease sends the deliveries
+		_, err := jobUsecase.RunLeased(ctx, entities.WebhookDispatchLease, webhookUsecase.DispatchPending)
+		if err != nil {
+			logger.Error("Error dispatching webhooks: %v", err)
+		}
+	}
+}
+
+// newWebhookHTTPClient only connects to public addresses, so a subscription
+// host resolving to the network of the engine is not called
+func newWebhookHTTPClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second, Control: helper.DialPublicOnly}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: transport,
+		// a redirect is not followed, it could point anywhere
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// startJobScheduler checks every minute for jobs scheduled since the last
+// check. Every instance runs it, the job leases decide who does the work.
+func startJobScheduler(jobUsecase *usecases.JobUseCase) {
+	ctx := context.WithValue(context.Background(), "logger", logger.Log.WithField("worker", "job_scheduler"))
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	lastCheck := time.Now()
+	for now := range ticker.C {
+		runs, err := jobUsecase.RunDue(ctx, lastCheck, now)
+		if err != nil {
+			logger.Error("Error running scheduled jobs: %v", err)
+		}
+		for _, run := range *runs {
+			logger.Info("Job %v for %v finished with status %v", run.JobName, run.BusinessDate.Format(helper.DateLayout), run.Status)
+		}
+		lastCheck = now
+	}
+}
+
+func instanceId() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%v-%d", hostname, os.Getpid())
+}
+
+// newNonceStore keeps the request nonces in MySQL so every instance sees them,
+// NONCE_STORE=memory keeps them in the process for a single instance
+func newNonceStore(dbRepository *repositories.DBRepository) interfaces.NonceStore {
+	if os.Getenv("NONCE_STORE") == "memory" {
+		return &nonce.MemoryStore{}
+	}
+	return dbRepository
+}
+
+// newRateLimitStore keeps the token buckets in the process, every instance
+// then limits on its own. RATE_LIMIT_STORE=mysql shares them between instances.
+func newRateLimitStore(dbRepository *repositories.DBRepository) interfaces.RateLimitStore {
+	if os.Getenv("RATE_LIMIT_STORE") == "mysql" {
+		return dbRepository
+	}
+	return &ratelimit.MemoryStore{}
+}
+
+// signatureWindow is how far X-Timestamp may be from now, SIGNATURE_WINDOW
+// takes a duration such as 5m
+func signatureWindow() time.Duration {
+	window, err := time.ParseDuration(os.Getenv("SIGNATURE_WINDOW"))
+	if err != nil || window <= 0 {
+		return 5 * time.Minute
+	}
+	return window
 }
 
 func startNSQConsumer(handler *mq.NSQHandler) {
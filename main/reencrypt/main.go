@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+	"github.com/sirait-kevin/BillingEngine/pkg/keyring"
+	"github.com/sirait-kevin/BillingEngine/pkg/logger"
+	"github.com/sirait-kevin/BillingEngine/repositories"
+	"github.com/sirait-kevin/BillingEngine/usecases"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+// reencrypt re-encrypts every encrypted column under the primary key of the
+// keyring. A key is rotated by adding the new key as the primary one, running
+// reencrypt, then removing the old key from the keyring.
+//
+//	ENCRYPTION_KEYS="2025-01:...,2024-10:..." go run main/reencrypt/main.go
+func main() {
+	batchSize := flag.Int("batch", 500, "rows read at a time")
+	actor := flag.String("actor", os.Getenv("USER"), "admin user the changes are audited as")
+	flag.Parse()
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+	logger.InitLogger(true)
+
+	keys, err := keyring.Load()
+	if err != nil {
+		log.Fatalf("Failed to load the encryption keyring: %v", err)
+	}
+	helper.SetKeyring(keys)
+
+	db, err := sqlx.Connect("mysql", "BillingEngine:rootpassword@tcp(localhost:3306)/BillingEngine?parseTime=true")
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	encryptionUsecase := &usecases.EncryptionUseCase{
+		EncryptionRepo: &repositories.DBRepository{DB: db},
+		BatchSize:      *batchSize,
+	}
+	ctx := context.WithValue(context.Background(), "logger", logger.Log.WithField("command", "reencrypt"))
+	ctx = helper.WithAdminUser(ctx, *actor)
+	results, err := encryptionUsecase.Reencrypt(ctx)
+	for _, result := range results {
+		fmt.Printf("%s.%s: %d read, %d re-encrypted under %s\n", result.Column.Table, result.Column.Column,
+			result.Scanned, result.Reencrypted, keys.Primary())
+	}
+	if err != nil {
+		log.Fatalf("Failed to re-encrypt: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+// signrequest prints the string a request is signed with, to debug a client
+// whose signatures are rejected. With -secret it prints the headers as well.
+// Nothing is sent and the secret never leaves the machine, e.g.
+//
+//	go run main/signrequest/main.go -uri /make/payment -body '{"loan_reference_id":"loan1"}' -timestamp 1717236000 -nonce n1
+func main() {
+	requestURI := flag.String("uri", "", "request URI with the query string, e.g. /payment/history?user_id=1")
+	body := flag.String("body", "", "raw request body")
+	bodyFile := flag.String("body-file", "", "file with the raw request body, instead of -body")
+	timestamp := flag.String("timestamp", "", "X-Timestamp in unix seconds, now when empty")
+	nonce := flag.String("nonce", "", "X-Nonce, random when empty")
+	clientKey := flag.String("client-key", "", "Client-Key")
+	secret := flag.String("secret", "", "client secret, the signature is only printed when it is set")
+	flag.Parse()
+
+	if *requestURI == "" {
+		log.Fatal("-uri is required")
+	}
+
+	data := []byte(*body)
+	if *bodyFile != "" {
+		var err error
+		data, err = os.ReadFile(*bodyFile)
+		if err != nil {
+			log.Fatalf("Failed to read body: %v", err)
+		}
+	}
+	if *timestamp == "" {
+		*timestamp = strconv.FormatInt(time.Now().Unix(), 10)
+	}
+	if *nonce == "" {
+		var err error
+		*nonce, err = helper.GenerateRandomString(16)
+		if err != nil {
+			log.Fatalf("Failed to generate nonce: %v", err)
+		}
+	}
+
+	if !helper.IsValidNonce(*nonce) {
+		log.Fatal("-nonce can not contain a line break or a slash")
+	}
+
+	stringToSign := helper.SignedRequestData(*timestamp, *nonce, *requestURI, data)
+	fmt.Printf("String to sign: %q\n", stringToSign)
+	if *secret == "" {
+		return
+	}
+
+	fmt.Printf("Client-Key: %s\n", *clientKey)
+	fmt.Printf("X-Timestamp: %s\n", *timestamp)
+	fmt.Printf("X-Nonce: %s\n", *nonce)
+	fmt.Printf("X-Signature: %s\n", helper.GenerateSignature(*secret, stringToSign))
+}
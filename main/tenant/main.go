@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+	"github.com/sirait-kevin/BillingEngine/pkg/logger"
+	"github.com/sirait-kevin/BillingEngine/repositories"
+	"github.com/sirait-kevin/BillingEngine/usecases"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+// tenant creates a tenant straight in the database and prints its id, the
+// first admin client of the tenant is then created with the apiclient command
+//
+//	go run main/tenant/main.go -code partner-a -name "Partner A" -timezone Asia/Jakarta -currency IDR
+func main() {
+	code := flag.String("code", "", "tenant code")
+	name := flag.String("name", "", "tenant name")
+	timezone := flag.String("timezone", entities.DefaultTenant.Timezone, "IANA timezone of the tenant")
+	currency := flag.String("currency", entities.DefaultTenant.Currency, "ISO 4217 currency of the tenant")
+	delinquentAfterMissed := flag.Int("delinquent-after-missed", entities.DefaultTenant.DelinquentAfterMissed,
+		"missed installments that make a borrower delinquent")
+	actor := flag.String("actor", os.Getenv("USER"), "admin user the changes are audited as")
+	flag.Parse()
+
+	if *code == "" || *name == "" {
+		log.Fatal("-code and -name are required")
+	}
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+	logger.InitLogger(true)
+
+	db, err := sqlx.Connect("mysql", "BillingEngine:rootpassword@tcp(localhost:3306)/BillingEngine?parseTime=true")
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	tenantUsecase := &usecases.TenantUseCase{
+		TenantRepo: &repositories.DBRepository{DB: db},
+	}
+	ctx := context.WithValue(context.Background(), "logger", logger.Log.WithField("command", "tenant"))
+	ctx = helper.WithAdminUser(ctx, *actor)
+	tenant, err := tenantUsecase.CreateTenant(ctx, *code, entities.TenantSettingsRequest{
+		Name:                  *name,
+		Timezone:              *timezone,
+		Currency:              *currency,
+		DelinquentAfterMissed: *delinquentAfterMissed,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create tenant: %v", err)
+	}
+
+	fmt.Printf("Tenant: %d (%s)\n", tenant.Id, tenant.Code)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/domain/interfaces (interfaces: PaymentCollector)
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockPaymentCollector is a mock of PaymentCollector interface.
+type MockPaymentCollector struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentCollectorMockRecorder
+}
+
+// MockPaymentCollectorMockRecorder is the mock recorder for MockPaymentCollector.
+type MockPaymentCollectorMockRecorder struct {
+	mock *MockPaymentCollector
+}
+
+// NewMockPaymentCollector creates a new mock instance.
+func NewMockPaymentCollector(ctrl *gomock.Controller) *MockPaymentCollector {
+	mock := &MockPaymentCollector{ctrl: ctrl}
+	mock.recorder = &MockPaymentCollectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentCollector) EXPECT() *MockPaymentCollectorMockRecorder {
+	return m.recorder
+}
+
+// Debit mocks base method.
+func (m *MockPaymentCollector) Debit(arg0 context.Context, arg1 entities.DebitMandate, arg2 entities.DebitInstruction) (entities.DebitResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Debit", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entities.DebitResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Debit indicates an expected call of Debit.
+func (mr *MockPaymentCollectorMockRecorder) Debit(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debit", reflect.TypeOf((*MockPaymentCollector)(nil).Debit), arg0, arg1, arg2)
+}
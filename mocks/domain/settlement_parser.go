@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/domain/interfaces (interfaces: SettlementParser)
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockSettlementParser is a mock of SettlementParser interface.
+type MockSettlementParser struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettlementParserMockRecorder
+}
+
+// MockSettlementParserMockRecorder is the mock recorder for MockSettlementParser.
+type MockSettlementParserMockRecorder struct {
+	mock *MockSettlementParser
+}
+
+// NewMockSettlementParser creates a new mock instance.
+func NewMockSettlementParser(ctrl *gomock.Controller) *MockSettlementParser {
+	mock := &MockSettlementParser{ctrl: ctrl}
+	mock.recorder = &MockSettlementParserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSettlementParser) EXPECT() *MockSettlementParserMockRecorder {
+	return m.recorder
+}
+
+// Format mocks base method.
+func (m *MockSettlementParser) Format() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Format")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Format indicates an expected call of Format.
+func (mr *MockSettlementParserMockRecorder) Format() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Format", reflect.TypeOf((*MockSettlementParser)(nil).Format))
+}
+
+// Parse mocks base method.
+func (m *MockSettlementParser) Parse(arg0 io.Reader) ([]entities.SettlementLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parse", arg0)
+	ret0, _ := ret[0].([]entities.SettlementLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockSettlementParserMockRecorder) Parse(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockSettlementParser)(nil).Parse), arg0)
+}
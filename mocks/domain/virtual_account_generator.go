@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/domain/interfaces (interfaces: VirtualAccountGenerator)
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockVirtualAccountGenerator is a mock of VirtualAccountGenerator interface.
+type MockVirtualAccountGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockVirtualAccountGeneratorMockRecorder
+}
+
+// MockVirtualAccountGeneratorMockRecorder is the mock recorder for MockVirtualAccountGenerator.
+type MockVirtualAccountGeneratorMockRecorder struct {
+	mock *MockVirtualAccountGenerator
+}
+
+// NewMockVirtualAccountGenerator creates a new mock instance.
+func NewMockVirtualAccountGenerator(ctrl *gomock.Controller) *MockVirtualAccountGenerator {
+	mock := &MockVirtualAccountGenerator{ctrl: ctrl}
+	mock.recorder = &MockVirtualAccountGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVirtualAccountGenerator) EXPECT() *MockVirtualAccountGeneratorMockRecorder {
+	return m.recorder
+}
+
+// BankCode mocks base method.
+func (m *MockVirtualAccountGenerator) BankCode() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BankCode")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BankCode indicates an expected call of BankCode.
+func (mr *MockVirtualAccountGeneratorMockRecorder) BankCode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BankCode", reflect.TypeOf((*MockVirtualAccountGenerator)(nil).BankCode))
+}
+
+// Generate mocks base method.
+func (m *MockVirtualAccountGenerator) Generate(arg0 context.Context, arg1 entities.Loan) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockVirtualAccountGeneratorMockRecorder) Generate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockVirtualAccountGenerator)(nil).Generate), arg0, arg1)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/restful (interfaces: ApiClientUsecase)
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockApiClientUsecase is a mock of ApiClientUsecase interface.
+type MockApiClientUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiClientUsecaseMockRecorder
+}
+
+// MockApiClientUsecaseMockRecorder is the mock recorder for MockApiClientUsecase.
+type MockApiClientUsecaseMockRecorder struct {
+	mock *MockApiClientUsecase
+}
+
+// NewMockApiClientUsecase creates a new mock instance.
+func NewMockApiClientUsecase(ctrl *gomock.Controller) *MockApiClientUsecase {
+	mock := &MockApiClientUsecase{ctrl: ctrl}
+	mock.recorder = &MockApiClientUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiClientUsecase) EXPECT() *MockApiClientUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateClient mocks base method.
+func (m *MockApiClientUsecase) CreateClient(arg0 context.Context, arg1 entities.ApiClientRequest) (*entities.ApiClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateClient", arg0, arg1)
+	ret0, _ := ret[0].(*entities.ApiClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateClient indicates an expected call of CreateClient.
+func (mr *MockApiClientUsecaseMockRecorder) CreateClient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClient", reflect.TypeOf((*MockApiClientUsecase)(nil).CreateClient), arg0, arg1)
+}
+
+// DisableClient mocks base method.
+func (m *MockApiClientUsecase) DisableClient(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableClient", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableClient indicates an expected call of DisableClient.
+func (mr *MockApiClientUsecaseMockRecorder) DisableClient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableClient", reflect.TypeOf((*MockApiClientUsecase)(nil).DisableClient), arg0, arg1)
+}
+
+// GetClientList mocks base method.
+func (m *MockApiClientUsecase) GetClientList(arg0 context.Context) (*[]entities.ApiClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientList", arg0)
+	ret0, _ := ret[0].(*[]entities.ApiClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientList indicates an expected call of GetClientList.
+func (mr *MockApiClientUsecaseMockRecorder) GetClientList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientList", reflect.TypeOf((*MockApiClientUsecase)(nil).GetClientList), arg0)
+}
+
+// RotateSecret mocks base method.
+func (m *MockApiClientUsecase) RotateSecret(arg0 context.Context, arg1 string) (*entities.ApiClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSecret", arg0, arg1)
+	ret0, _ := ret[0].(*entities.ApiClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSecret indicates an expected call of RotateSecret.
+func (mr *MockApiClientUsecaseMockRecorder) RotateSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSecret", reflect.TypeOf((*MockApiClientUsecase)(nil).RotateSecret), arg0, arg1)
+}
+
+// SetRateLimit mocks base method.
+func (m *MockApiClientUsecase) SetRateLimit(arg0 context.Context, arg1 entities.ApiClientRateLimitRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRateLimit", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRateLimit indicates an expected call of SetRateLimit.
+func (mr *MockApiClientUsecaseMockRecorder) SetRateLimit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRateLimit", reflect.TypeOf((*MockApiClientUsecase)(nil).SetRateLimit), arg0, arg1)
+}
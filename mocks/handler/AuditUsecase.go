@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/restful (interfaces: AuditUsecase)
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockAuditUsecase is a mock of AuditUsecase interface.
+type MockAuditUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditUsecaseMockRecorder
+}
+
+// MockAuditUsecaseMockRecorder is the mock recorder for MockAuditUsecase.
+type MockAuditUsecaseMockRecorder struct {
+	mock *MockAuditUsecase
+}
+
+// NewMockAuditUsecase creates a new mock instance.
+func NewMockAuditUsecase(ctrl *gomock.Controller) *MockAuditUsecase {
+	mock := &MockAuditUsecase{ctrl: ctrl}
+	mock.recorder = &MockAuditUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditUsecase) EXPECT() *MockAuditUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetLoanAuditTrail mocks base method.
+func (m *MockAuditUsecase) GetLoanAuditTrail(arg0 context.Context, arg1 string) (*[]entities.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanAuditTrail", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanAuditTrail indicates an expected call of GetLoanAuditTrail.
+func (mr *MockAuditUsecaseMockRecorder) GetLoanAuditTrail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanAuditTrail", reflect.TypeOf((*MockAuditUsecase)(nil).GetLoanAuditTrail), arg0, arg1)
+}
+
+// GetUserAuditTrail mocks base method.
+func (m *MockAuditUsecase) GetUserAuditTrail(arg0 context.Context, arg1 int64) (*[]entities.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAuditTrail", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAuditTrail indicates an expected call of GetUserAuditTrail.
+func (mr *MockAuditUsecaseMockRecorder) GetUserAuditTrail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAuditTrail", reflect.TypeOf((*MockAuditUsecase)(nil).GetUserAuditTrail), arg0, arg1)
+}
+
+// VerifyAuditChain mocks base method.
+func (m *MockAuditUsecase) VerifyAuditChain(arg0 context.Context) (*entities.AuditChainVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAuditChain", arg0)
+	ret0, _ := ret[0].(*entities.AuditChainVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyAuditChain indicates an expected call of VerifyAuditChain.
+func (mr *MockAuditUsecaseMockRecorder) VerifyAuditChain(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAuditChain", reflect.TypeOf((*MockAuditUsecase)(nil).VerifyAuditChain), arg0)
+}
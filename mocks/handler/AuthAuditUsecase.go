@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/restful (interfaces: AuthAuditUsecase)
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockAuthAuditUsecase is a mock of AuthAuditUsecase interface.
+type MockAuthAuditUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthAuditUsecaseMockRecorder
+}
+
+// MockAuthAuditUsecaseMockRecorder is the mock recorder for MockAuthAuditUsecase.
+type MockAuthAuditUsecaseMockRecorder struct {
+	mock *MockAuthAuditUsecase
+}
+
+// NewMockAuthAuditUsecase creates a new mock instance.
+func NewMockAuthAuditUsecase(ctrl *gomock.Controller) *MockAuthAuditUsecase {
+	mock := &MockAuthAuditUsecase{ctrl: ctrl}
+	mock.recorder = &MockAuthAuditUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthAuditUsecase) EXPECT() *MockAuthAuditUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetAuthFailureList mocks base method.
+func (m *MockAuthAuditUsecase) GetAuthFailureList(arg0 context.Context, arg1 string) (*[]entities.AuthFailure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthFailureList", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.AuthFailure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthFailureList indicates an expected call of GetAuthFailureList.
+func (mr *MockAuthAuditUsecaseMockRecorder) GetAuthFailureList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthFailureList", reflect.TypeOf((*MockAuthAuditUsecase)(nil).GetAuthFailureList), arg0, arg1)
+}
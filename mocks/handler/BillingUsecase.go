@@ -65,6 +65,21 @@ func (mr *MockBillingUsecaseMockRecorder) GetLoanListByUserId(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanListByUserId", reflect.TypeOf((*MockBillingUsecase)(nil).GetLoanListByUserId), arg0, arg1)
 }
 
+// GetOpenPromiseToPayListByUserId mocks base method.
+func (m *MockBillingUsecase) GetOpenPromiseToPayListByUserId(arg0 context.Context, arg1 int64) (*[]entities.PromiseToPay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenPromiseToPayListByUserId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.PromiseToPay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenPromiseToPayListByUserId indicates an expected call of GetOpenPromiseToPayListByUserId.
+func (mr *MockBillingUsecaseMockRecorder) GetOpenPromiseToPayListByUserId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenPromiseToPayListByUserId", reflect.TypeOf((*MockBillingUsecase)(nil).GetOpenPromiseToPayListByUserId), arg0, arg1)
+}
+
 // GetOutStandingAmountByReferenceID mocks base method.
 func (m *MockBillingUsecase) GetOutStandingAmountByReferenceID(arg0 context.Context, arg1 string) (*entities.OutStanding, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +110,21 @@ func (mr *MockBillingUsecaseMockRecorder) GetPaymentHistoryByReferenceID(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentHistoryByReferenceID", reflect.TypeOf((*MockBillingUsecase)(nil).GetPaymentHistoryByReferenceID), arg0, arg1)
 }
 
+// GetReceipt mocks base method.
+func (m *MockBillingUsecase) GetReceipt(arg0 context.Context, arg1 string) (*entities.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceipt", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceipt indicates an expected call of GetReceipt.
+func (mr *MockBillingUsecaseMockRecorder) GetReceipt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceipt", reflect.TypeOf((*MockBillingUsecase)(nil).GetReceipt), arg0, arg1)
+}
+
 // GetRepaymentInquiryByLoanReferenceId mocks base method.
 func (m *MockBillingUsecase) GetRepaymentInquiryByLoanReferenceId(arg0 context.Context, arg1 string) (*entities.RepaymentInquiry, error) {
 	m.ctrl.T.Helper()
@@ -139,3 +169,18 @@ func (mr *MockBillingUsecaseMockRecorder) MakePayment(arg0, arg1 interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakePayment", reflect.TypeOf((*MockBillingUsecase)(nil).MakePayment), arg0, arg1)
 }
+
+// ReversePayment mocks base method.
+func (m *MockBillingUsecase) ReversePayment(arg0 context.Context, arg1 entities.PaymentReversalRequest) (*entities.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReversePayment", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReversePayment indicates an expected call of ReversePayment.
+func (mr *MockBillingUsecaseMockRecorder) ReversePayment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReversePayment", reflect.TypeOf((*MockBillingUsecase)(nil).ReversePayment), arg0, arg1)
+}
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/restful (interfaces: CollectionUsecase)
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockCollectionUsecase is a mock of CollectionUsecase interface.
+type MockCollectionUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollectionUsecaseMockRecorder
+}
+
+// MockCollectionUsecaseMockRecorder is the mock recorder for MockCollectionUsecase.
+type MockCollectionUsecaseMockRecorder struct {
+	mock *MockCollectionUsecase
+}
+
+// NewMockCollectionUsecase creates a new mock instance.
+func NewMockCollectionUsecase(ctrl *gomock.Controller) *MockCollectionUsecase {
+	mock := &MockCollectionUsecase{ctrl: ctrl}
+	mock.recorder = &MockCollectionUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollectionUsecase) EXPECT() *MockCollectionUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateMandate mocks base method.
+func (m *MockCollectionUsecase) CreateMandate(arg0 context.Context, arg1 entities.DebitMandateRequest) (*entities.DebitMandate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMandate", arg0, arg1)
+	ret0, _ := ret[0].(*entities.DebitMandate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMandate indicates an expected call of CreateMandate.
+func (mr *MockCollectionUsecaseMockRecorder) CreateMandate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMandate", reflect.TypeOf((*MockCollectionUsecase)(nil).CreateMandate), arg0, arg1)
+}
+
+// CreatePromiseToPay mocks base method.
+func (m *MockCollectionUsecase) CreatePromiseToPay(arg0 context.Context, arg1 entities.PromiseToPayRequest) (*entities.PromiseToPay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromiseToPay", arg0, arg1)
+	ret0, _ := ret[0].(*entities.PromiseToPay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromiseToPay indicates an expected call of CreatePromiseToPay.
+func (mr *MockCollectionUsecaseMockRecorder) CreatePromiseToPay(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromiseToPay", reflect.TypeOf((*MockCollectionUsecase)(nil).CreatePromiseToPay), arg0, arg1)
+}
+
+// GetDebitInstructionListByLoanReferenceId mocks base method.
+func (m *MockCollectionUsecase) GetDebitInstructionListByLoanReferenceId(arg0 context.Context, arg1 string) (*[]entities.DebitInstruction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDebitInstructionListByLoanReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.DebitInstruction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDebitInstructionListByLoanReferenceId indicates an expected call of GetDebitInstructionListByLoanReferenceId.
+func (mr *MockCollectionUsecaseMockRecorder) GetDebitInstructionListByLoanReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDebitInstructionListByLoanReferenceId", reflect.TypeOf((*MockCollectionUsecase)(nil).GetDebitInstructionListByLoanReferenceId), arg0, arg1)
+}
+
+// GetMandateListByLoanReferenceId mocks base method.
+func (m *MockCollectionUsecase) GetMandateListByLoanReferenceId(arg0 context.Context, arg1 string) (*[]entities.DebitMandate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMandateListByLoanReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.DebitMandate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMandateListByLoanReferenceId indicates an expected call of GetMandateListByLoanReferenceId.
+func (mr *MockCollectionUsecaseMockRecorder) GetMandateListByLoanReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMandateListByLoanReferenceId", reflect.TypeOf((*MockCollectionUsecase)(nil).GetMandateListByLoanReferenceId), arg0, arg1)
+}
+
+// GetPromiseToPayListByLoanReferenceId mocks base method.
+func (m *MockCollectionUsecase) GetPromiseToPayListByLoanReferenceId(arg0 context.Context, arg1 string) (*[]entities.PromiseToPay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromiseToPayListByLoanReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.PromiseToPay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromiseToPayListByLoanReferenceId indicates an expected call of GetPromiseToPayListByLoanReferenceId.
+func (mr *MockCollectionUsecaseMockRecorder) GetPromiseToPayListByLoanReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromiseToPayListByLoanReferenceId", reflect.TypeOf((*MockCollectionUsecase)(nil).GetPromiseToPayListByLoanReferenceId), arg0, arg1)
+}
+
+// RevokeMandate mocks base method.
+func (m *MockCollectionUsecase) RevokeMandate(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeMandate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeMandate indicates an expected call of RevokeMandate.
+func (mr *MockCollectionUsecaseMockRecorder) RevokeMandate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeMandate", reflect.TypeOf((*MockCollectionUsecase)(nil).RevokeMandate), arg0, arg1)
+}
+
+// RunCollection mocks base method.
+func (m *MockCollectionUsecase) RunCollection(arg0 context.Context, arg1 time.Time) (*entities.CollectionRunResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunCollection", arg0, arg1)
+	ret0, _ := ret[0].(*entities.CollectionRunResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunCollection indicates an expected call of RunCollection.
+func (mr *MockCollectionUsecaseMockRecorder) RunCollection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunCollection", reflect.TypeOf((*MockCollectionUsecase)(nil).RunCollection), arg0, arg1)
+}
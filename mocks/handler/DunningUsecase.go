@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/restful (interfaces: DunningUsecase)
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockDunningUsecase is a mock of DunningUsecase interface.
+type MockDunningUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockDunningUsecaseMockRecorder
+}
+
+// MockDunningUsecaseMockRecorder is the mock recorder for MockDunningUsecase.
+type MockDunningUsecaseMockRecorder struct {
+	mock *MockDunningUsecase
+}
+
+// NewMockDunningUsecase creates a new mock instance.
+func NewMockDunningUsecase(ctrl *gomock.Controller) *MockDunningUsecase {
+	mock := &MockDunningUsecase{ctrl: ctrl}
+	mock.recorder = &MockDunningUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDunningUsecase) EXPECT() *MockDunningUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CompleteDunningAction mocks base method.
+func (m *MockDunningUsecase) CompleteDunningAction(arg0 context.Context, arg1 int64) (*entities.DunningActionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteDunningAction", arg0, arg1)
+	ret0, _ := ret[0].(*entities.DunningActionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteDunningAction indicates an expected call of CompleteDunningAction.
+func (mr *MockDunningUsecaseMockRecorder) CompleteDunningAction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteDunningAction", reflect.TypeOf((*MockDunningUsecase)(nil).CompleteDunningAction), arg0, arg1)
+}
+
+// GetDunningActionList mocks base method.
+func (m *MockDunningUsecase) GetDunningActionList(arg0 context.Context, arg1 entities.DunningActionStatus, arg2 entities.DunningAction) (*[]entities.DunningActionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDunningActionList", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]entities.DunningActionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDunningActionList indicates an expected call of GetDunningActionList.
+func (mr *MockDunningUsecaseMockRecorder) GetDunningActionList(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDunningActionList", reflect.TypeOf((*MockDunningUsecase)(nil).GetDunningActionList), arg0, arg1, arg2)
+}
+
+// GetDunningLevel mocks base method.
+func (m *MockDunningUsecase) GetDunningLevel(arg0 context.Context, arg1 string) (*entities.LoanDunningLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDunningLevel", arg0, arg1)
+	ret0, _ := ret[0].(*entities.LoanDunningLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDunningLevel indicates an expected call of GetDunningLevel.
+func (mr *MockDunningUsecaseMockRecorder) GetDunningLevel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDunningLevel", reflect.TypeOf((*MockDunningUsecase)(nil).GetDunningLevel), arg0, arg1)
+}
+
+// OverrideDunningLevel mocks base method.
+func (m *MockDunningUsecase) OverrideDunningLevel(arg0 context.Context, arg1 entities.DunningOverrideRequest) (*entities.LoanDunningLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OverrideDunningLevel", arg0, arg1)
+	ret0, _ := ret[0].(*entities.LoanDunningLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OverrideDunningLevel indicates an expected call of OverrideDunningLevel.
+func (mr *MockDunningUsecaseMockRecorder) OverrideDunningLevel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OverrideDunningLevel", reflect.TypeOf((*MockDunningUsecase)(nil).OverrideDunningLevel), arg0, arg1)
+}
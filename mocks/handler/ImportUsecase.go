@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/restful (interfaces: ImportUsecase)
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockImportUsecase is a mock of ImportUsecase interface.
+type MockImportUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportUsecaseMockRecorder
+}
+
+// MockImportUsecaseMockRecorder is the mock recorder for MockImportUsecase.
+type MockImportUsecaseMockRecorder struct {
+	mock *MockImportUsecase
+}
+
+// NewMockImportUsecase creates a new mock instance.
+func NewMockImportUsecase(ctrl *gomock.Controller) *MockImportUsecase {
+	mock := &MockImportUsecase{ctrl: ctrl}
+	mock.recorder = &MockImportUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportUsecase) EXPECT() *MockImportUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ImportLoans mocks base method.
+func (m *MockImportUsecase) ImportLoans(arg0 context.Context, arg1 entities.LoanImportRequest) (*entities.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportLoans", arg0, arg1)
+	ret0, _ := ret[0].(*entities.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportLoans indicates an expected call of ImportLoans.
+func (mr *MockImportUsecaseMockRecorder) ImportLoans(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportLoans", reflect.TypeOf((*MockImportUsecase)(nil).ImportLoans), arg0, arg1)
+}
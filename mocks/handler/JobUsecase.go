@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/restful (interfaces: JobUsecase)
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockJobUsecase is a mock of JobUsecase interface.
+type MockJobUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobUsecaseMockRecorder
+}
+
+// MockJobUsecaseMockRecorder is the mock recorder for MockJobUsecase.
+type MockJobUsecaseMockRecorder struct {
+	mock *MockJobUsecase
+}
+
+// NewMockJobUsecase creates a new mock instance.
+func NewMockJobUsecase(ctrl *gomock.Controller) *MockJobUsecase {
+	mock := &MockJobUsecase{ctrl: ctrl}
+	mock.recorder = &MockJobUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobUsecase) EXPECT() *MockJobUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetJobList mocks base method.
+func (m *MockJobUsecase) GetJobList(arg0 context.Context) (*[]entities.JobInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobList", arg0)
+	ret0, _ := ret[0].(*[]entities.JobInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobList indicates an expected call of GetJobList.
+func (mr *MockJobUsecaseMockRecorder) GetJobList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobList", reflect.TypeOf((*MockJobUsecase)(nil).GetJobList), arg0)
+}
+
+// GetJobRunList mocks base method.
+func (m *MockJobUsecase) GetJobRunList(arg0 context.Context, arg1 string) (*[]entities.JobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobRunList", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobRunList indicates an expected call of GetJobRunList.
+func (mr *MockJobUsecaseMockRecorder) GetJobRunList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobRunList", reflect.TypeOf((*MockJobUsecase)(nil).GetJobRunList), arg0, arg1)
+}
+
+// TriggerJob mocks base method.
+func (m *MockJobUsecase) TriggerJob(arg0 context.Context, arg1 entities.JobRunRequest) (*entities.JobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TriggerJob", arg0, arg1)
+	ret0, _ := ret[0].(*entities.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TriggerJob indicates an expected call of TriggerJob.
+func (mr *MockJobUsecaseMockRecorder) TriggerJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TriggerJob", reflect.TypeOf((*MockJobUsecase)(nil).TriggerJob), arg0, arg1)
+}
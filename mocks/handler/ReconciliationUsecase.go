@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/restful (interfaces: ReconciliationUsecase)
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockReconciliationUsecase is a mock of ReconciliationUsecase interface.
+type MockReconciliationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconciliationUsecaseMockRecorder
+}
+
+// MockReconciliationUsecaseMockRecorder is the mock recorder for MockReconciliationUsecase.
+type MockReconciliationUsecaseMockRecorder struct {
+	mock *MockReconciliationUsecase
+}
+
+// NewMockReconciliationUsecase creates a new mock instance.
+func NewMockReconciliationUsecase(ctrl *gomock.Controller) *MockReconciliationUsecase {
+	mock := &MockReconciliationUsecase{ctrl: ctrl}
+	mock.recorder = &MockReconciliationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReconciliationUsecase) EXPECT() *MockReconciliationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetReconciliationReport mocks base method.
+func (m *MockReconciliationUsecase) GetReconciliationReport(arg0 context.Context, arg1 entities.ReconciliationRequest) (*entities.ReconciliationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReconciliationReport", arg0, arg1)
+	ret0, _ := ret[0].(*entities.ReconciliationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReconciliationReport indicates an expected call of GetReconciliationReport.
+func (mr *MockReconciliationUsecaseMockRecorder) GetReconciliationReport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReconciliationReport", reflect.TypeOf((*MockReconciliationUsecase)(nil).GetReconciliationReport), arg0, arg1)
+}
+
+// ImportBankStatement mocks base method.
+func (m *MockReconciliationUsecase) ImportBankStatement(arg0 context.Context, arg1 entities.BankStatementImportRequest) (*entities.BankStatementImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportBankStatement", arg0, arg1)
+	ret0, _ := ret[0].(*entities.BankStatementImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportBankStatement indicates an expected call of ImportBankStatement.
+func (mr *MockReconciliationUsecaseMockRecorder) ImportBankStatement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBankStatement", reflect.TypeOf((*MockReconciliationUsecase)(nil).ImportBankStatement), arg0, arg1)
+}
+
+// MatchManually mocks base method.
+func (m *MockReconciliationUsecase) MatchManually(arg0 context.Context, arg1 entities.ManualMatchRequest) (*entities.BankStatementLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchManually", arg0, arg1)
+	ret0, _ := ret[0].(*entities.BankStatementLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MatchManually indicates an expected call of MatchManually.
+func (mr *MockReconciliationUsecaseMockRecorder) MatchManually(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchManually", reflect.TypeOf((*MockReconciliationUsecase)(nil).MatchManually), arg0, arg1)
+}
+
+// RunReconciliation mocks base method.
+func (m *MockReconciliationUsecase) RunReconciliation(arg0 context.Context, arg1 entities.ReconciliationRequest) (*entities.ReconciliationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunReconciliation", arg0, arg1)
+	ret0, _ := ret[0].(*entities.ReconciliationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunReconciliation indicates an expected call of RunReconciliation.
+func (mr *MockReconciliationUsecaseMockRecorder) RunReconciliation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunReconciliation", reflect.TypeOf((*MockReconciliationUsecase)(nil).RunReconciliation), arg0, arg1)
+}
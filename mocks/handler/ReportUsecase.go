@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/restful (interfaces: ReportUsecase)
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockReportUsecase is a mock of ReportUsecase interface.
+type MockReportUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportUsecaseMockRecorder
+}
+
+// MockReportUsecaseMockRecorder is the mock recorder for MockReportUsecase.
+type MockReportUsecaseMockRecorder struct {
+	mock *MockReportUsecase
+}
+
+// NewMockReportUsecase creates a new mock instance.
+func NewMockReportUsecase(ctrl *gomock.Controller) *MockReportUsecase {
+	mock := &MockReportUsecase{ctrl: ctrl}
+	mock.recorder = &MockReportUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportUsecase) EXPECT() *MockReportUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetCollectionRate mocks base method.
+func (m *MockReportUsecase) GetCollectionRate(arg0 context.Context, arg1 entities.ReportRequest) (*entities.CollectionRateReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectionRate", arg0, arg1)
+	ret0, _ := ret[0].(*entities.CollectionRateReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectionRate indicates an expected call of GetCollectionRate.
+func (mr *MockReportUsecaseMockRecorder) GetCollectionRate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionRate", reflect.TypeOf((*MockReportUsecase)(nil).GetCollectionRate), arg0, arg1)
+}
+
+// GetDisbursement mocks base method.
+func (m *MockReportUsecase) GetDisbursement(arg0 context.Context, arg1 entities.ReportRequest) (*entities.DisbursementReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDisbursement", arg0, arg1)
+	ret0, _ := ret[0].(*entities.DisbursementReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDisbursement indicates an expected call of GetDisbursement.
+func (mr *MockReportUsecaseMockRecorder) GetDisbursement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDisbursement", reflect.TypeOf((*MockReportUsecase)(nil).GetDisbursement), arg0, arg1)
+}
+
+// GetOutstandingBySchedule mocks base method.
+func (m *MockReportUsecase) GetOutstandingBySchedule(arg0 context.Context, arg1 entities.ReportRequest) (*entities.OutstandingByScheduleReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutstandingBySchedule", arg0, arg1)
+	ret0, _ := ret[0].(*entities.OutstandingByScheduleReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutstandingBySchedule indicates an expected call of GetOutstandingBySchedule.
+func (mr *MockReportUsecaseMockRecorder) GetOutstandingBySchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutstandingBySchedule", reflect.TypeOf((*MockReportUsecase)(nil).GetOutstandingBySchedule), arg0, arg1)
+}
+
+// GetPortfolioAtRisk mocks base method.
+func (m *MockReportUsecase) GetPortfolioAtRisk(arg0 context.Context, arg1 entities.ReportRequest) (*entities.PortfolioAtRiskReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPortfolioAtRisk", arg0, arg1)
+	ret0, _ := ret[0].(*entities.PortfolioAtRiskReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPortfolioAtRisk indicates an expected call of GetPortfolioAtRisk.
+func (mr *MockReportUsecaseMockRecorder) GetPortfolioAtRisk(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPortfolioAtRisk", reflect.TypeOf((*MockReportUsecase)(nil).GetPortfolioAtRisk), arg0, arg1)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/restful (interfaces: ScheduleUsecase)
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockScheduleUsecase is a mock of ScheduleUsecase interface.
+type MockScheduleUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduleUsecaseMockRecorder
+}
+
+// MockScheduleUsecaseMockRecorder is the mock recorder for MockScheduleUsecase.
+type MockScheduleUsecaseMockRecorder struct {
+	mock *MockScheduleUsecase
+}
+
+// NewMockScheduleUsecase creates a new mock instance.
+func NewMockScheduleUsecase(ctrl *gomock.Controller) *MockScheduleUsecase {
+	mock := &MockScheduleUsecase{ctrl: ctrl}
+	mock.recorder = &MockScheduleUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduleUsecase) EXPECT() *MockScheduleUsecaseMockRecorder {
+	return m.recorder
+}
+
+// DeferLoan mocks base method.
+func (m *MockScheduleUsecase) DeferLoan(arg0 context.Context, arg1 entities.DeferralRequest) (*entities.LoanScheduleVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeferLoan", arg0, arg1)
+	ret0, _ := ret[0].(*entities.LoanScheduleVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeferLoan indicates an expected call of DeferLoan.
+func (mr *MockScheduleUsecaseMockRecorder) DeferLoan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeferLoan", reflect.TypeOf((*MockScheduleUsecase)(nil).DeferLoan), arg0, arg1)
+}
+
+// GetScheduleVersionList mocks base method.
+func (m *MockScheduleUsecase) GetScheduleVersionList(arg0 context.Context, arg1 string) (*[]entities.LoanScheduleVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduleVersionList", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.LoanScheduleVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduleVersionList indicates an expected call of GetScheduleVersionList.
+func (mr *MockScheduleUsecaseMockRecorder) GetScheduleVersionList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduleVersionList", reflect.TypeOf((*MockScheduleUsecase)(nil).GetScheduleVersionList), arg0, arg1)
+}
+
+// RestructureLoan mocks base method.
+func (m *MockScheduleUsecase) RestructureLoan(arg0 context.Context, arg1 entities.RestructureRequest) (*entities.LoanScheduleVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestructureLoan", arg0, arg1)
+	ret0, _ := ret[0].(*entities.LoanScheduleVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestructureLoan indicates an expected call of RestructureLoan.
+func (mr *MockScheduleUsecaseMockRecorder) RestructureLoan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestructureLoan", reflect.TypeOf((*MockScheduleUsecase)(nil).RestructureLoan), arg0, arg1)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/restful (interfaces: SettlementUsecase)
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockSettlementUsecase is a mock of SettlementUsecase interface.
+type MockSettlementUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettlementUsecaseMockRecorder
+}
+
+// MockSettlementUsecaseMockRecorder is the mock recorder for MockSettlementUsecase.
+type MockSettlementUsecaseMockRecorder struct {
+	mock *MockSettlementUsecase
+}
+
+// NewMockSettlementUsecase creates a new mock instance.
+func NewMockSettlementUsecase(ctrl *gomock.Controller) *MockSettlementUsecase {
+	mock := &MockSettlementUsecase{ctrl: ctrl}
+	mock.recorder = &MockSettlementUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSettlementUsecase) EXPECT() *MockSettlementUsecaseMockRecorder {
+	return m.recorder
+}
+
+// DismissSettlementException mocks base method.
+func (m *MockSettlementUsecase) DismissSettlementException(arg0 context.Context, arg1 entities.SettlementDismissRequest) (*entities.SettlementException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissSettlementException", arg0, arg1)
+	ret0, _ := ret[0].(*entities.SettlementException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DismissSettlementException indicates an expected call of DismissSettlementException.
+func (mr *MockSettlementUsecaseMockRecorder) DismissSettlementException(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissSettlementException", reflect.TypeOf((*MockSettlementUsecase)(nil).DismissSettlementException), arg0, arg1)
+}
+
+// GetSettlementExceptionList mocks base method.
+func (m *MockSettlementUsecase) GetSettlementExceptionList(arg0 context.Context, arg1 string) (*[]entities.SettlementException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettlementExceptionList", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.SettlementException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettlementExceptionList indicates an expected call of GetSettlementExceptionList.
+func (mr *MockSettlementUsecaseMockRecorder) GetSettlementExceptionList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettlementExceptionList", reflect.TypeOf((*MockSettlementUsecase)(nil).GetSettlementExceptionList), arg0, arg1)
+}
+
+// ProcessSettlementFile mocks base method.
+func (m *MockSettlementUsecase) ProcessSettlementFile(arg0 context.Context, arg1 entities.SettlementUploadRequest) (*entities.SettlementResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessSettlementFile", arg0, arg1)
+	ret0, _ := ret[0].(*entities.SettlementResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessSettlementFile indicates an expected call of ProcessSettlementFile.
+func (mr *MockSettlementUsecaseMockRecorder) ProcessSettlementFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessSettlementFile", reflect.TypeOf((*MockSettlementUsecase)(nil).ProcessSettlementFile), arg0, arg1)
+}
+
+// RepostSettlementException mocks base method.
+func (m *MockSettlementUsecase) RepostSettlementException(arg0 context.Context, arg1 entities.SettlementRepostRequest) (*entities.SettlementException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepostSettlementException", arg0, arg1)
+	ret0, _ := ret[0].(*entities.SettlementException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepostSettlementException indicates an expected call of RepostSettlementException.
+func (mr *MockSettlementUsecaseMockRecorder) RepostSettlementException(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepostSettlementException", reflect.TypeOf((*MockSettlementUsecase)(nil).RepostSettlementException), arg0, arg1)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/restful (interfaces: SnapshotUsecase)
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockSnapshotUsecase is a mock of SnapshotUsecase interface.
+type MockSnapshotUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotUsecaseMockRecorder
+}
+
+// MockSnapshotUsecaseMockRecorder is the mock recorder for MockSnapshotUsecase.
+type MockSnapshotUsecaseMockRecorder struct {
+	mock *MockSnapshotUsecase
+}
+
+// NewMockSnapshotUsecase creates a new mock instance.
+func NewMockSnapshotUsecase(ctrl *gomock.Controller) *MockSnapshotUsecase {
+	mock := &MockSnapshotUsecase{ctrl: ctrl}
+	mock.recorder = &MockSnapshotUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotUsecase) EXPECT() *MockSnapshotUsecaseMockRecorder {
+	return m.recorder
+}
+
+// BackfillSnapshots mocks base method.
+func (m *MockSnapshotUsecase) BackfillSnapshots(arg0 context.Context, arg1 entities.SnapshotBackfillRequest) (*[]entities.SnapshotRunResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillSnapshots", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.SnapshotRunResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackfillSnapshots indicates an expected call of BackfillSnapshots.
+func (mr *MockSnapshotUsecaseMockRecorder) BackfillSnapshots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillSnapshots", reflect.TypeOf((*MockSnapshotUsecase)(nil).BackfillSnapshots), arg0, arg1)
+}
+
+// GetSnapshotList mocks base method.
+func (m *MockSnapshotUsecase) GetSnapshotList(arg0 context.Context, arg1 string) (*[]entities.LoanDailySnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSnapshotList", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.LoanDailySnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSnapshotList indicates an expected call of GetSnapshotList.
+func (mr *MockSnapshotUsecaseMockRecorder) GetSnapshotList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnapshotList", reflect.TypeOf((*MockSnapshotUsecase)(nil).GetSnapshotList), arg0, arg1)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/restful (interfaces: StatementUsecase)
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockStatementUsecase is a mock of StatementUsecase interface.
+type MockStatementUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementUsecaseMockRecorder
+}
+
+// MockStatementUsecaseMockRecorder is the mock recorder for MockStatementUsecase.
+type MockStatementUsecaseMockRecorder struct {
+	mock *MockStatementUsecase
+}
+
+// NewMockStatementUsecase creates a new mock instance.
+func NewMockStatementUsecase(ctrl *gomock.Controller) *MockStatementUsecase {
+	mock := &MockStatementUsecase{ctrl: ctrl}
+	mock.recorder = &MockStatementUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementUsecase) EXPECT() *MockStatementUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetStatement mocks base method.
+func (m *MockStatementUsecase) GetStatement(arg0 context.Context, arg1 entities.StatementRequest) (*entities.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatement", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatement indicates an expected call of GetStatement.
+func (mr *MockStatementUsecaseMockRecorder) GetStatement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatement", reflect.TypeOf((*MockStatementUsecase)(nil).GetStatement), arg0, arg1)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/restful (interfaces: TenantUsecase)
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockTenantUsecase is a mock of TenantUsecase interface.
+type MockTenantUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantUsecaseMockRecorder
+}
+
+// MockTenantUsecaseMockRecorder is the mock recorder for MockTenantUsecase.
+type MockTenantUsecaseMockRecorder struct {
+	mock *MockTenantUsecase
+}
+
+// NewMockTenantUsecase creates a new mock instance.
+func NewMockTenantUsecase(ctrl *gomock.Controller) *MockTenantUsecase {
+	mock := &MockTenantUsecase{ctrl: ctrl}
+	mock.recorder = &MockTenantUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantUsecase) EXPECT() *MockTenantUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetCurrentTenant mocks base method.
+func (m *MockTenantUsecase) GetCurrentTenant(arg0 context.Context) (*entities.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentTenant", arg0)
+	ret0, _ := ret[0].(*entities.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentTenant indicates an expected call of GetCurrentTenant.
+func (mr *MockTenantUsecaseMockRecorder) GetCurrentTenant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentTenant", reflect.TypeOf((*MockTenantUsecase)(nil).GetCurrentTenant), arg0)
+}
+
+// UpdateSettings mocks base method.
+func (m *MockTenantUsecase) UpdateSettings(arg0 context.Context, arg1 entities.TenantSettingsRequest) (*entities.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettings", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSettings indicates an expected call of UpdateSettings.
+func (mr *MockTenantUsecaseMockRecorder) UpdateSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettings", reflect.TypeOf((*MockTenantUsecase)(nil).UpdateSettings), arg0, arg1)
+}
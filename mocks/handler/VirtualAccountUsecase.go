@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/restful (interfaces: VirtualAccountUsecase)
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockVirtualAccountUsecase is a mock of VirtualAccountUsecase interface.
+type MockVirtualAccountUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockVirtualAccountUsecaseMockRecorder
+}
+
+// MockVirtualAccountUsecaseMockRecorder is the mock recorder for MockVirtualAccountUsecase.
+type MockVirtualAccountUsecaseMockRecorder struct {
+	mock *MockVirtualAccountUsecase
+}
+
+// NewMockVirtualAccountUsecase creates a new mock instance.
+func NewMockVirtualAccountUsecase(ctrl *gomock.Controller) *MockVirtualAccountUsecase {
+	mock := &MockVirtualAccountUsecase{ctrl: ctrl}
+	mock.recorder = &MockVirtualAccountUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVirtualAccountUsecase) EXPECT() *MockVirtualAccountUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateVirtualAccount mocks base method.
+func (m *MockVirtualAccountUsecase) CreateVirtualAccount(arg0 context.Context, arg1 entities.VirtualAccountRequest) (*entities.VirtualAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVirtualAccount", arg0, arg1)
+	ret0, _ := ret[0].(*entities.VirtualAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVirtualAccount indicates an expected call of CreateVirtualAccount.
+func (mr *MockVirtualAccountUsecaseMockRecorder) CreateVirtualAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVirtualAccount", reflect.TypeOf((*MockVirtualAccountUsecase)(nil).CreateVirtualAccount), arg0, arg1)
+}
+
+// GetVirtualAccountListByLoanReferenceId mocks base method.
+func (m *MockVirtualAccountUsecase) GetVirtualAccountListByLoanReferenceId(arg0 context.Context, arg1 string) (*[]entities.VirtualAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVirtualAccountListByLoanReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.VirtualAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVirtualAccountListByLoanReferenceId indicates an expected call of GetVirtualAccountListByLoanReferenceId.
+func (mr *MockVirtualAccountUsecaseMockRecorder) GetVirtualAccountListByLoanReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVirtualAccountListByLoanReferenceId", reflect.TypeOf((*MockVirtualAccountUsecase)(nil).GetVirtualAccountListByLoanReferenceId), arg0, arg1)
+}
+
+// HandlePaymentCallback mocks base method.
+func (m *MockVirtualAccountUsecase) HandlePaymentCallback(arg0 context.Context, arg1 entities.PaymentCallback) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandlePaymentCallback", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandlePaymentCallback indicates an expected call of HandlePaymentCallback.
+func (mr *MockVirtualAccountUsecaseMockRecorder) HandlePaymentCallback(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandlePaymentCallback", reflect.TypeOf((*MockVirtualAccountUsecase)(nil).HandlePaymentCallback), arg0, arg1)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/restful (interfaces: WebhookUsecase)
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockWebhookUsecase is a mock of WebhookUsecase interface.
+type MockWebhookUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookUsecaseMockRecorder
+}
+
+// MockWebhookUsecaseMockRecorder is the mock recorder for MockWebhookUsecase.
+type MockWebhookUsecaseMockRecorder struct {
+	mock *MockWebhookUsecase
+}
+
+// NewMockWebhookUsecase creates a new mock instance.
+func NewMockWebhookUsecase(ctrl *gomock.Controller) *MockWebhookUsecase {
+	mock := &MockWebhookUsecase{ctrl: ctrl}
+	mock.recorder = &MockWebhookUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookUsecase) EXPECT() *MockWebhookUsecaseMockRecorder {
+	return m.recorder
+}
+
+// DisableSubscription mocks base method.
+func (m *MockWebhookUsecase) DisableSubscription(arg0 context.Context, arg1 string, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableSubscription", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableSubscription indicates an expected call of DisableSubscription.
+func (mr *MockWebhookUsecaseMockRecorder) DisableSubscription(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableSubscription", reflect.TypeOf((*MockWebhookUsecase)(nil).DisableSubscription), arg0, arg1, arg2)
+}
+
+// GetDeliveryList mocks base method.
+func (m *MockWebhookUsecase) GetDeliveryList(arg0 context.Context, arg1 string, arg2 int64) (*[]entities.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryList", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]entities.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryList indicates an expected call of GetDeliveryList.
+func (mr *MockWebhookUsecaseMockRecorder) GetDeliveryList(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryList", reflect.TypeOf((*MockWebhookUsecase)(nil).GetDeliveryList), arg0, arg1, arg2)
+}
+
+// GetSubscriptionList mocks base method.
+func (m *MockWebhookUsecase) GetSubscriptionList(arg0 context.Context, arg1 string) (*[]entities.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionList", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionList indicates an expected call of GetSubscriptionList.
+func (mr *MockWebhookUsecaseMockRecorder) GetSubscriptionList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionList", reflect.TypeOf((*MockWebhookUsecase)(nil).GetSubscriptionList), arg0, arg1)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhookUsecase) Redeliver(arg0 context.Context, arg1 string, arg2 int64) (*entities.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entities.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookUsecaseMockRecorder) Redeliver(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhookUsecase)(nil).Redeliver), arg0, arg1, arg2)
+}
+
+// RegisterSubscription mocks base method.
+func (m *MockWebhookUsecase) RegisterSubscription(arg0 context.Context, arg1 string, arg2 entities.WebhookSubscriptionRequest) (*entities.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterSubscription", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entities.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterSubscription indicates an expected call of RegisterSubscription.
+func (mr *MockWebhookUsecaseMockRecorder) RegisterSubscription(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSubscription", reflect.TypeOf((*MockWebhookUsecase)(nil).RegisterSubscription), arg0, arg1, arg2)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/restful (interfaces: WriteOffUsecase)
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockWriteOffUsecase is a mock of WriteOffUsecase interface.
+type MockWriteOffUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriteOffUsecaseMockRecorder
+}
+
+// MockWriteOffUsecaseMockRecorder is the mock recorder for MockWriteOffUsecase.
+type MockWriteOffUsecaseMockRecorder struct {
+	mock *MockWriteOffUsecase
+}
+
+// NewMockWriteOffUsecase creates a new mock instance.
+func NewMockWriteOffUsecase(ctrl *gomock.Controller) *MockWriteOffUsecase {
+	mock := &MockWriteOffUsecase{ctrl: ctrl}
+	mock.recorder = &MockWriteOffUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriteOffUsecase) EXPECT() *MockWriteOffUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetWriteOff mocks base method.
+func (m *MockWriteOffUsecase) GetWriteOff(arg0 context.Context, arg1 string) (*entities.WriteOffDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWriteOff", arg0, arg1)
+	ret0, _ := ret[0].(*entities.WriteOffDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWriteOff indicates an expected call of GetWriteOff.
+func (mr *MockWriteOffUsecaseMockRecorder) GetWriteOff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWriteOff", reflect.TypeOf((*MockWriteOffUsecase)(nil).GetWriteOff), arg0, arg1)
+}
+
+// RecordRecovery mocks base method.
+func (m *MockWriteOffUsecase) RecordRecovery(arg0 context.Context, arg1 entities.RecoveryRequest) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordRecovery", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordRecovery indicates an expected call of RecordRecovery.
+func (mr *MockWriteOffUsecaseMockRecorder) RecordRecovery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordRecovery", reflect.TypeOf((*MockWriteOffUsecase)(nil).RecordRecovery), arg0, arg1)
+}
+
+// WriteOffLoan mocks base method.
+func (m *MockWriteOffUsecase) WriteOffLoan(arg0 context.Context, arg1 entities.WriteOffRequest) (*entities.WriteOff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteOffLoan", arg0, arg1)
+	ret0, _ := ret[0].(*entities.WriteOff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteOffLoan indicates an expected call of WriteOffLoan.
+func (mr *MockWriteOffUsecaseMockRecorder) WriteOffLoan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteOffLoan", reflect.TypeOf((*MockWriteOffUsecase)(nil).WriteOffLoan), arg0, arg1)
+}
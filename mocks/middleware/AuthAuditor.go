@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/middleware (interfaces: AuthAuditor)
+
+// Package mock_middleware is a generated GoMock package.
+package mock_middleware
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockAuthAuditor is a mock of AuthAuditor interface.
+type MockAuthAuditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthAuditorMockRecorder
+}
+
+// MockAuthAuditorMockRecorder is the mock recorder for MockAuthAuditor.
+type MockAuthAuditorMockRecorder struct {
+	mock *MockAuthAuditor
+}
+
+// NewMockAuthAuditor creates a new mock instance.
+func NewMockAuthAuditor(ctrl *gomock.Controller) *MockAuthAuditor {
+	mock := &MockAuthAuditor{ctrl: ctrl}
+	mock.recorder = &MockAuthAuditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthAuditor) EXPECT() *MockAuthAuditorMockRecorder {
+	return m.recorder
+}
+
+// IsClientBlocked mocks base method.
+func (m *MockAuthAuditor) IsClientBlocked(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsClientBlocked", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsClientBlocked indicates an expected call of IsClientBlocked.
+func (mr *MockAuthAuditorMockRecorder) IsClientBlocked(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsClientBlocked", reflect.TypeOf((*MockAuthAuditor)(nil).IsClientBlocked), arg0, arg1, arg2)
+}
+
+// RecordAuthFailure mocks base method.
+func (m *MockAuthAuditor) RecordAuthFailure(arg0 context.Context, arg1 entities.AuthFailure) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAuthFailure", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAuthFailure indicates an expected call of RecordAuthFailure.
+func (mr *MockAuthAuditorMockRecorder) RecordAuthFailure(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAuthFailure", reflect.TypeOf((*MockAuthAuditor)(nil).RecordAuthFailure), arg0, arg1)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/handlers/middleware (interfaces: ClientAuthenticator)
+
+// Package mock_middleware is a generated GoMock package.
+package mock_middleware
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockClientAuthenticator is a mock of ClientAuthenticator interface.
+type MockClientAuthenticator struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientAuthenticatorMockRecorder
+}
+
+// MockClientAuthenticatorMockRecorder is the mock recorder for MockClientAuthenticator.
+type MockClientAuthenticatorMockRecorder struct {
+	mock *MockClientAuthenticator
+}
+
+// NewMockClientAuthenticator creates a new mock instance.
+func NewMockClientAuthenticator(ctrl *gomock.Controller) *MockClientAuthenticator {
+	mock := &MockClientAuthenticator{ctrl: ctrl}
+	mock.recorder = &MockClientAuthenticatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClientAuthenticator) EXPECT() *MockClientAuthenticatorMockRecorder {
+	return m.recorder
+}
+
+// GetClientCredentials mocks base method.
+func (m *MockClientAuthenticator) GetClientCredentials(arg0 context.Context, arg1 string) (*entities.ClientCredentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientCredentials", arg0, arg1)
+	ret0, _ := ret[0].(*entities.ClientCredentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientCredentials indicates an expected call of GetClientCredentials.
+func (mr *MockClientAuthenticatorMockRecorder) GetClientCredentials(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientCredentials", reflect.TypeOf((*MockClientAuthenticator)(nil).GetClientCredentials), arg0, arg1)
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: ApiClientRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+	interfaces "github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// MockApiClientRepository is a mock of ApiClientRepository interface.
+type MockApiClientRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiClientRepositoryMockRecorder
+}
+
+// MockApiClientRepositoryMockRecorder is the mock recorder for MockApiClientRepository.
+type MockApiClientRepositoryMockRecorder struct {
+	mock *MockApiClientRepository
+}
+
+// NewMockApiClientRepository creates a new mock instance.
+func NewMockApiClientRepository(ctrl *gomock.Controller) *MockApiClientRepository {
+	mock := &MockApiClientRepository{ctrl: ctrl}
+	mock.recorder = &MockApiClientRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiClientRepository) EXPECT() *MockApiClientRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateApiClient mocks base method.
+func (m *MockApiClientRepository) CreateApiClient(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.ApiClient) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApiClient", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateApiClient indicates an expected call of CreateApiClient.
+func (mr *MockApiClientRepositoryMockRecorder) CreateApiClient(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApiClient", reflect.TypeOf((*MockApiClientRepository)(nil).CreateApiClient), arg0, arg1, arg2)
+}
+
+// SelectApiClientByClientKey mocks base method.
+func (m *MockApiClientRepository) SelectApiClientByClientKey(arg0 context.Context, arg1 string) (*entities.ApiClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectApiClientByClientKey", arg0, arg1)
+	ret0, _ := ret[0].(*entities.ApiClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectApiClientByClientKey indicates an expected call of SelectApiClientByClientKey.
+func (mr *MockApiClientRepositoryMockRecorder) SelectApiClientByClientKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectApiClientByClientKey", reflect.TypeOf((*MockApiClientRepository)(nil).SelectApiClientByClientKey), arg0, arg1)
+}
+
+// SelectApiClients mocks base method.
+func (m *MockApiClientRepository) SelectApiClients(arg0 context.Context) (*[]entities.ApiClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectApiClients", arg0)
+	ret0, _ := ret[0].(*[]entities.ApiClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectApiClients indicates an expected call of SelectApiClients.
+func (mr *MockApiClientRepositoryMockRecorder) SelectApiClients(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectApiClients", reflect.TypeOf((*MockApiClientRepository)(nil).SelectApiClients), arg0)
+}
+
+// UpdateApiClientRateLimit mocks base method.
+func (m *MockApiClientRepository) UpdateApiClientRateLimit(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 string, arg3 entities.RateLimit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateApiClientRateLimit", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateApiClientRateLimit indicates an expected call of UpdateApiClientRateLimit.
+func (mr *MockApiClientRepositoryMockRecorder) UpdateApiClientRateLimit(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApiClientRateLimit", reflect.TypeOf((*MockApiClientRepository)(nil).UpdateApiClientRateLimit), arg0, arg1, arg2, arg3)
+}
+
+// UpdateApiClientSecret mocks base method.
+func (m *MockApiClientRepository) UpdateApiClientSecret(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.ApiClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateApiClientSecret", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateApiClientSecret indicates an expected call of UpdateApiClientSecret.
+func (mr *MockApiClientRepositoryMockRecorder) UpdateApiClientSecret(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApiClientSecret", reflect.TypeOf((*MockApiClientRepository)(nil).UpdateApiClientSecret), arg0, arg1, arg2)
+}
+
+// UpdateApiClientStatus mocks base method.
+func (m *MockApiClientRepository) UpdateApiClientStatus(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 string, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateApiClientStatus", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateApiClientStatus indicates an expected call of UpdateApiClientStatus.
+func (mr *MockApiClientRepositoryMockRecorder) UpdateApiClientStatus(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApiClientStatus", reflect.TypeOf((*MockApiClientRepository)(nil).UpdateApiClientStatus), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: AuditRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockAuditRepository is a mock of AuditRepository interface.
+type MockAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepositoryMockRecorder
+}
+
+// MockAuditRepositoryMockRecorder is the mock recorder for MockAuditRepository.
+type MockAuditRepositoryMockRecorder struct {
+	mock *MockAuditRepository
+}
+
+// NewMockAuditRepository creates a new mock instance.
+func NewMockAuditRepository(ctrl *gomock.Controller) *MockAuditRepository {
+	mock := &MockAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepository) EXPECT() *MockAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// SelectAuditEntriesAfterId mocks base method.
+func (m *MockAuditRepository) SelectAuditEntriesAfterId(arg0 context.Context, arg1 int64, arg2 int) (*[]entities.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAuditEntriesAfterId", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]entities.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAuditEntriesAfterId indicates an expected call of SelectAuditEntriesAfterId.
+func (mr *MockAuditRepositoryMockRecorder) SelectAuditEntriesAfterId(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAuditEntriesAfterId", reflect.TypeOf((*MockAuditRepository)(nil).SelectAuditEntriesAfterId), arg0, arg1, arg2)
+}
+
+// SelectAuditEntriesByLoanId mocks base method.
+func (m *MockAuditRepository) SelectAuditEntriesByLoanId(arg0 context.Context, arg1 int64, arg2 int) (*[]entities.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAuditEntriesByLoanId", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]entities.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAuditEntriesByLoanId indicates an expected call of SelectAuditEntriesByLoanId.
+func (mr *MockAuditRepositoryMockRecorder) SelectAuditEntriesByLoanId(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAuditEntriesByLoanId", reflect.TypeOf((*MockAuditRepository)(nil).SelectAuditEntriesByLoanId), arg0, arg1, arg2)
+}
+
+// SelectAuditEntriesByUserId mocks base method.
+func (m *MockAuditRepository) SelectAuditEntriesByUserId(arg0 context.Context, arg1 int64, arg2 int) (*[]entities.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAuditEntriesByUserId", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]entities.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAuditEntriesByUserId indicates an expected call of SelectAuditEntriesByUserId.
+func (mr *MockAuditRepositoryMockRecorder) SelectAuditEntriesByUserId(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAuditEntriesByUserId", reflect.TypeOf((*MockAuditRepository)(nil).SelectAuditEntriesByUserId), arg0, arg1, arg2)
+}
+
+// SelectAuditHead mocks base method.
+func (m *MockAuditRepository) SelectAuditHead(arg0 context.Context) (int64, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAuditHead", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SelectAuditHead indicates an expected call of SelectAuditHead.
+func (mr *MockAuditRepositoryMockRecorder) SelectAuditHead(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAuditHead", reflect.TypeOf((*MockAuditRepository)(nil).SelectAuditHead), arg0)
+}
+
+// SelectLoanByReferenceId mocks base method.
+func (m *MockAuditRepository) SelectLoanByReferenceId(arg0 context.Context, arg1 string) (*entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanByReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanByReferenceId indicates an expected call of SelectLoanByReferenceId.
+func (mr *MockAuditRepositoryMockRecorder) SelectLoanByReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanByReferenceId", reflect.TypeOf((*MockAuditRepository)(nil).SelectLoanByReferenceId), arg0, arg1)
+}
@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: AuthAuditRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+	interfaces "github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// MockAuthAuditRepository is a mock of AuthAuditRepository interface.
+type MockAuthAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthAuditRepositoryMockRecorder
+}
+
+// MockAuthAuditRepositoryMockRecorder is the mock recorder for MockAuthAuditRepository.
+type MockAuthAuditRepositoryMockRecorder struct {
+	mock *MockAuthAuditRepository
+}
+
+// NewMockAuthAuditRepository creates a new mock instance.
+func NewMockAuthAuditRepository(ctrl *gomock.Controller) *MockAuthAuditRepository {
+	mock := &MockAuthAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAuthAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthAuditRepository) EXPECT() *MockAuthAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountInvalidSignatureFailures mocks base method.
+func (m *MockAuthAuditRepository) CountInvalidSignatureFailures(arg0 context.Context, arg1, arg2 string, arg3 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountInvalidSignatureFailures", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountInvalidSignatureFailures indicates an expected call of CountInvalidSignatureFailures.
+func (mr *MockAuthAuditRepositoryMockRecorder) CountInvalidSignatureFailures(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountInvalidSignatureFailures", reflect.TypeOf((*MockAuthAuditRepository)(nil).CountInvalidSignatureFailures), arg0, arg1, arg2, arg3)
+}
+
+// CreateAuthFailure mocks base method.
+func (m *MockAuthAuditRepository) CreateAuthFailure(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.AuthFailure) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuthFailure", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuthFailure indicates an expected call of CreateAuthFailure.
+func (mr *MockAuthAuditRepositoryMockRecorder) CreateAuthFailure(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuthFailure", reflect.TypeOf((*MockAuthAuditRepository)(nil).CreateAuthFailure), arg0, arg1, arg2)
+}
+
+// PurgeAuthFailures mocks base method.
+func (m *MockAuthAuditRepository) PurgeAuthFailures(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeAuthFailures", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeAuthFailures indicates an expected call of PurgeAuthFailures.
+func (mr *MockAuthAuditRepositoryMockRecorder) PurgeAuthFailures(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeAuthFailures", reflect.TypeOf((*MockAuthAuditRepository)(nil).PurgeAuthFailures), arg0, arg1)
+}
+
+// SelectAuthFailures mocks base method.
+func (m *MockAuthAuditRepository) SelectAuthFailures(arg0 context.Context, arg1 string, arg2 int) (*[]entities.AuthFailure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAuthFailures", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]entities.AuthFailure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAuthFailures indicates an expected call of SelectAuthFailures.
+func (mr *MockAuthAuditRepositoryMockRecorder) SelectAuthFailures(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAuthFailures", reflect.TypeOf((*MockAuthAuditRepository)(nil).SelectAuthFailures), arg0, arg1, arg2)
+}
@@ -0,0 +1,305 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: CollectionRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+	interfaces "github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// MockCollectionRepository is a mock of CollectionRepository interface.
+type MockCollectionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollectionRepositoryMockRecorder
+}
+
+// MockCollectionRepositoryMockRecorder is the mock recorder for MockCollectionRepository.
+type MockCollectionRepositoryMockRecorder struct {
+	mock *MockCollectionRepository
+}
+
+// NewMockCollectionRepository creates a new mock instance.
+func NewMockCollectionRepository(ctrl *gomock.Controller) *MockCollectionRepository {
+	mock := &MockCollectionRepository{ctrl: ctrl}
+	mock.recorder = &MockCollectionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollectionRepository) EXPECT() *MockCollectionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateDebitInstruction mocks base method.
+func (m *MockCollectionRepository) CreateDebitInstruction(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.DebitInstruction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDebitInstruction", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDebitInstruction indicates an expected call of CreateDebitInstruction.
+func (mr *MockCollectionRepositoryMockRecorder) CreateDebitInstruction(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDebitInstruction", reflect.TypeOf((*MockCollectionRepository)(nil).CreateDebitInstruction), arg0, arg1, arg2)
+}
+
+// CreateDebitMandate mocks base method.
+func (m *MockCollectionRepository) CreateDebitMandate(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.DebitMandate) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDebitMandate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDebitMandate indicates an expected call of CreateDebitMandate.
+func (mr *MockCollectionRepositoryMockRecorder) CreateDebitMandate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDebitMandate", reflect.TypeOf((*MockCollectionRepository)(nil).CreateDebitMandate), arg0, arg1, arg2)
+}
+
+// CreatePromiseToPay mocks base method.
+func (m *MockCollectionRepository) CreatePromiseToPay(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.PromiseToPay) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromiseToPay", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromiseToPay indicates an expected call of CreatePromiseToPay.
+func (mr *MockCollectionRepositoryMockRecorder) CreatePromiseToPay(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromiseToPay", reflect.TypeOf((*MockCollectionRepository)(nil).CreatePromiseToPay), arg0, arg1, arg2)
+}
+
+// SelectActiveDebitMandate mocks base method.
+func (m *MockCollectionRepository) SelectActiveDebitMandate(arg0 context.Context) (*[]entities.DebitMandate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectActiveDebitMandate", arg0)
+	ret0, _ := ret[0].(*[]entities.DebitMandate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectActiveDebitMandate indicates an expected call of SelectActiveDebitMandate.
+func (mr *MockCollectionRepositoryMockRecorder) SelectActiveDebitMandate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectActiveDebitMandate", reflect.TypeOf((*MockCollectionRepository)(nil).SelectActiveDebitMandate), arg0)
+}
+
+// SelectDebitInstructionByLoanId mocks base method.
+func (m *MockCollectionRepository) SelectDebitInstructionByLoanId(arg0 context.Context, arg1 int64) (*[]entities.DebitInstruction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectDebitInstructionByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.DebitInstruction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectDebitInstructionByLoanId indicates an expected call of SelectDebitInstructionByLoanId.
+func (mr *MockCollectionRepositoryMockRecorder) SelectDebitInstructionByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectDebitInstructionByLoanId", reflect.TypeOf((*MockCollectionRepository)(nil).SelectDebitInstructionByLoanId), arg0, arg1)
+}
+
+// SelectDebitMandateById mocks base method.
+func (m *MockCollectionRepository) SelectDebitMandateById(arg0 context.Context, arg1 int64) (*entities.DebitMandate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectDebitMandateById", arg0, arg1)
+	ret0, _ := ret[0].(*entities.DebitMandate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectDebitMandateById indicates an expected call of SelectDebitMandateById.
+func (mr *MockCollectionRepositoryMockRecorder) SelectDebitMandateById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectDebitMandateById", reflect.TypeOf((*MockCollectionRepository)(nil).SelectDebitMandateById), arg0, arg1)
+}
+
+// SelectDebitMandateByLoanId mocks base method.
+func (m *MockCollectionRepository) SelectDebitMandateByLoanId(arg0 context.Context, arg1 int64) (*[]entities.DebitMandate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectDebitMandateByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.DebitMandate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectDebitMandateByLoanId indicates an expected call of SelectDebitMandateByLoanId.
+func (mr *MockCollectionRepositoryMockRecorder) SelectDebitMandateByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectDebitMandateByLoanId", reflect.TypeOf((*MockCollectionRepository)(nil).SelectDebitMandateByLoanId), arg0, arg1)
+}
+
+// SelectDueDebitInstruction mocks base method.
+func (m *MockCollectionRepository) SelectDueDebitInstruction(arg0 context.Context, arg1 time.Time) (*[]entities.DebitInstruction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectDueDebitInstruction", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.DebitInstruction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectDueDebitInstruction indicates an expected call of SelectDueDebitInstruction.
+func (mr *MockCollectionRepositoryMockRecorder) SelectDueDebitInstruction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectDueDebitInstruction", reflect.TypeOf((*MockCollectionRepository)(nil).SelectDueDebitInstruction), arg0, arg1)
+}
+
+// SelectLoanById mocks base method.
+func (m *MockCollectionRepository) SelectLoanById(arg0 context.Context, arg1 int64) (*entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanById", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanById indicates an expected call of SelectLoanById.
+func (mr *MockCollectionRepositoryMockRecorder) SelectLoanById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanById", reflect.TypeOf((*MockCollectionRepository)(nil).SelectLoanById), arg0, arg1)
+}
+
+// SelectLoanByReferenceId mocks base method.
+func (m *MockCollectionRepository) SelectLoanByReferenceId(arg0 context.Context, arg1 string) (*entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanByReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanByReferenceId indicates an expected call of SelectLoanByReferenceId.
+func (mr *MockCollectionRepositoryMockRecorder) SelectLoanByReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanByReferenceId", reflect.TypeOf((*MockCollectionRepository)(nil).SelectLoanByReferenceId), arg0, arg1)
+}
+
+// SelectOpenPromiseToPay mocks base method.
+func (m *MockCollectionRepository) SelectOpenPromiseToPay(arg0 context.Context) (*[]entities.PromiseToPay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectOpenPromiseToPay", arg0)
+	ret0, _ := ret[0].(*[]entities.PromiseToPay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectOpenPromiseToPay indicates an expected call of SelectOpenPromiseToPay.
+func (mr *MockCollectionRepositoryMockRecorder) SelectOpenPromiseToPay(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectOpenPromiseToPay", reflect.TypeOf((*MockCollectionRepository)(nil).SelectOpenPromiseToPay), arg0)
+}
+
+// SelectOpenPromiseToPayByLoanId mocks base method.
+func (m *MockCollectionRepository) SelectOpenPromiseToPayByLoanId(arg0 context.Context, arg1 int64) (*entities.PromiseToPay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectOpenPromiseToPayByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.PromiseToPay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectOpenPromiseToPayByLoanId indicates an expected call of SelectOpenPromiseToPayByLoanId.
+func (mr *MockCollectionRepositoryMockRecorder) SelectOpenPromiseToPayByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectOpenPromiseToPayByLoanId", reflect.TypeOf((*MockCollectionRepository)(nil).SelectOpenPromiseToPayByLoanId), arg0, arg1)
+}
+
+// SelectPromiseToPayByLoanId mocks base method.
+func (m *MockCollectionRepository) SelectPromiseToPayByLoanId(arg0 context.Context, arg1 int64) (*[]entities.PromiseToPay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectPromiseToPayByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.PromiseToPay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectPromiseToPayByLoanId indicates an expected call of SelectPromiseToPayByLoanId.
+func (mr *MockCollectionRepositoryMockRecorder) SelectPromiseToPayByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectPromiseToPayByLoanId", reflect.TypeOf((*MockCollectionRepository)(nil).SelectPromiseToPayByLoanId), arg0, arg1)
+}
+
+// SelectRepaymentAmountByLoanIdBetween mocks base method.
+func (m *MockCollectionRepository) SelectRepaymentAmountByLoanIdBetween(arg0 context.Context, arg1 int64, arg2, arg3 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRepaymentAmountByLoanIdBetween", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRepaymentAmountByLoanIdBetween indicates an expected call of SelectRepaymentAmountByLoanIdBetween.
+func (mr *MockCollectionRepositoryMockRecorder) SelectRepaymentAmountByLoanIdBetween(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRepaymentAmountByLoanIdBetween", reflect.TypeOf((*MockCollectionRepository)(nil).SelectRepaymentAmountByLoanIdBetween), arg0, arg1, arg2, arg3)
+}
+
+// SelectRepaymentCountByLoanId mocks base method.
+func (m *MockCollectionRepository) SelectRepaymentCountByLoanId(arg0 context.Context, arg1 int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRepaymentCountByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRepaymentCountByLoanId indicates an expected call of SelectRepaymentCountByLoanId.
+func (mr *MockCollectionRepositoryMockRecorder) SelectRepaymentCountByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRepaymentCountByLoanId", reflect.TypeOf((*MockCollectionRepository)(nil).SelectRepaymentCountByLoanId), arg0, arg1)
+}
+
+// UpdateDebitInstruction mocks base method.
+func (m *MockCollectionRepository) UpdateDebitInstruction(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.DebitInstruction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDebitInstruction", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDebitInstruction indicates an expected call of UpdateDebitInstruction.
+func (mr *MockCollectionRepositoryMockRecorder) UpdateDebitInstruction(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDebitInstruction", reflect.TypeOf((*MockCollectionRepository)(nil).UpdateDebitInstruction), arg0, arg1, arg2)
+}
+
+// UpdateDebitMandateStatus mocks base method.
+func (m *MockCollectionRepository) UpdateDebitMandateStatus(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 int64, arg3 entities.MandateStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDebitMandateStatus", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDebitMandateStatus indicates an expected call of UpdateDebitMandateStatus.
+func (mr *MockCollectionRepositoryMockRecorder) UpdateDebitMandateStatus(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDebitMandateStatus", reflect.TypeOf((*MockCollectionRepository)(nil).UpdateDebitMandateStatus), arg0, arg1, arg2, arg3)
+}
+
+// UpdatePromiseToPay mocks base method.
+func (m *MockCollectionRepository) UpdatePromiseToPay(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.PromiseToPay) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePromiseToPay", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePromiseToPay indicates an expected call of UpdatePromiseToPay.
+func (mr *MockCollectionRepositoryMockRecorder) UpdatePromiseToPay(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePromiseToPay", reflect.TypeOf((*MockCollectionRepository)(nil).UpdatePromiseToPay), arg0, arg1, arg2)
+}
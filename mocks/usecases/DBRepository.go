@@ -7,6 +7,7 @@ package mock_usecase
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
@@ -51,6 +52,20 @@ func (mr *MockDBRepositoryMockRecorder) BeginTx(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*MockDBRepository)(nil).BeginTx), arg0)
 }
 
+// CancelReceipt mocks base method.
+func (m *MockDBRepository) CancelReceipt(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 int64, arg3 string, arg4 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelReceipt", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelReceipt indicates an expected call of CancelReceipt.
+func (mr *MockDBRepositoryMockRecorder) CancelReceipt(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelReceipt", reflect.TypeOf((*MockDBRepository)(nil).CancelReceipt), arg0, arg1, arg2, arg3, arg4)
+}
+
 // CreateLoan mocks base method.
 func (m *MockDBRepository) CreateLoan(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.Loan) (int64, error) {
 	m.ctrl.T.Helper()
@@ -66,6 +81,21 @@ func (mr *MockDBRepositoryMockRecorder) CreateLoan(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoan", reflect.TypeOf((*MockDBRepository)(nil).CreateLoan), arg0, arg1, arg2)
 }
 
+// CreateReceipt mocks base method.
+func (m *MockDBRepository) CreateReceipt(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.Receipt) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReceipt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReceipt indicates an expected call of CreateReceipt.
+func (mr *MockDBRepositoryMockRecorder) CreateReceipt(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReceipt", reflect.TypeOf((*MockDBRepository)(nil).CreateReceipt), arg0, arg1, arg2)
+}
+
 // CreateRepayment mocks base method.
 func (m *MockDBRepository) CreateRepayment(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.Repayment) (int64, error) {
 	m.ctrl.T.Helper()
@@ -81,6 +111,36 @@ func (mr *MockDBRepositoryMockRecorder) CreateRepayment(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRepayment", reflect.TypeOf((*MockDBRepository)(nil).CreateRepayment), arg0, arg1, arg2)
 }
 
+// NextReceiptSequence mocks base method.
+func (m *MockDBRepository) NextReceiptSequence(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 int64, arg3 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextReceiptSequence", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextReceiptSequence indicates an expected call of NextReceiptSequence.
+func (mr *MockDBRepositoryMockRecorder) NextReceiptSequence(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextReceiptSequence", reflect.TypeOf((*MockDBRepository)(nil).NextReceiptSequence), arg0, arg1, arg2, arg3)
+}
+
+// SelectLoanById mocks base method.
+func (m *MockDBRepository) SelectLoanById(arg0 context.Context, arg1 int64) (*entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanById", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanById indicates an expected call of SelectLoanById.
+func (mr *MockDBRepositoryMockRecorder) SelectLoanById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanById", reflect.TypeOf((*MockDBRepository)(nil).SelectLoanById), arg0, arg1)
+}
+
 // SelectLoanByReferenceId mocks base method.
 func (m *MockDBRepository) SelectLoanByReferenceId(arg0 context.Context, arg1 string) (*entities.Loan, error) {
 	m.ctrl.T.Helper()
@@ -111,6 +171,36 @@ func (mr *MockDBRepositoryMockRecorder) SelectLoanByUserId(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanByUserId", reflect.TypeOf((*MockDBRepository)(nil).SelectLoanByUserId), arg0, arg1)
 }
 
+// SelectOpenPromiseToPayByUserId mocks base method.
+func (m *MockDBRepository) SelectOpenPromiseToPayByUserId(arg0 context.Context, arg1 int64) (*[]entities.PromiseToPay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectOpenPromiseToPayByUserId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.PromiseToPay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectOpenPromiseToPayByUserId indicates an expected call of SelectOpenPromiseToPayByUserId.
+func (mr *MockDBRepositoryMockRecorder) SelectOpenPromiseToPayByUserId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectOpenPromiseToPayByUserId", reflect.TypeOf((*MockDBRepository)(nil).SelectOpenPromiseToPayByUserId), arg0, arg1)
+}
+
+// SelectReceiptByRepaymentReferenceId mocks base method.
+func (m *MockDBRepository) SelectReceiptByRepaymentReferenceId(arg0 context.Context, arg1 string) (*entities.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectReceiptByRepaymentReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectReceiptByRepaymentReferenceId indicates an expected call of SelectReceiptByRepaymentReferenceId.
+func (mr *MockDBRepositoryMockRecorder) SelectReceiptByRepaymentReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectReceiptByRepaymentReferenceId", reflect.TypeOf((*MockDBRepository)(nil).SelectReceiptByRepaymentReferenceId), arg0, arg1)
+}
+
 // SelectRepaymentByLoanId mocks base method.
 func (m *MockDBRepository) SelectRepaymentByLoanId(arg0 context.Context, arg1 int64) (*[]entities.Repayment, error) {
 	m.ctrl.T.Helper()
@@ -184,3 +274,17 @@ func (mr *MockDBRepositoryMockRecorder) UpdateLoanStatusByReferenceId(arg0, arg1
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoanStatusByReferenceId", reflect.TypeOf((*MockDBRepository)(nil).UpdateLoanStatusByReferenceId), arg0, arg1, arg2, arg3)
 }
+
+// UpdateRepaymentStatusById mocks base method.
+func (m *MockDBRepository) UpdateRepaymentStatusById(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 int64, arg3 entities.RepaymentStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRepaymentStatusById", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRepaymentStatusById indicates an expected call of UpdateRepaymentStatusById.
+func (mr *MockDBRepositoryMockRecorder) UpdateRepaymentStatusById(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRepaymentStatusById", reflect.TypeOf((*MockDBRepository)(nil).UpdateRepaymentStatusById), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,231 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: DunningRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+	interfaces "github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// MockDunningRepository is a mock of DunningRepository interface.
+type MockDunningRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDunningRepositoryMockRecorder
+}
+
+// MockDunningRepositoryMockRecorder is the mock recorder for MockDunningRepository.
+type MockDunningRepositoryMockRecorder struct {
+	mock *MockDunningRepository
+}
+
+// NewMockDunningRepository creates a new mock instance.
+func NewMockDunningRepository(ctrl *gomock.Controller) *MockDunningRepository {
+	mock := &MockDunningRepository{ctrl: ctrl}
+	mock.recorder = &MockDunningRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDunningRepository) EXPECT() *MockDunningRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BeginTx mocks base method.
+func (m *MockDunningRepository) BeginTx(arg0 context.Context) (interfaces.AtomicTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", arg0)
+	ret0, _ := ret[0].(interfaces.AtomicTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockDunningRepositoryMockRecorder) BeginTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*MockDunningRepository)(nil).BeginTx), arg0)
+}
+
+// CreateDunningAction mocks base method.
+func (m *MockDunningRepository) CreateDunningAction(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.DunningActionItem) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDunningAction", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDunningAction indicates an expected call of CreateDunningAction.
+func (mr *MockDunningRepositoryMockRecorder) CreateDunningAction(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDunningAction", reflect.TypeOf((*MockDunningRepository)(nil).CreateDunningAction), arg0, arg1, arg2)
+}
+
+// SelectDunningActionById mocks base method.
+func (m *MockDunningRepository) SelectDunningActionById(arg0 context.Context, arg1 int64) (*entities.DunningActionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectDunningActionById", arg0, arg1)
+	ret0, _ := ret[0].(*entities.DunningActionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectDunningActionById indicates an expected call of SelectDunningActionById.
+func (mr *MockDunningRepositoryMockRecorder) SelectDunningActionById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectDunningActionById", reflect.TypeOf((*MockDunningRepository)(nil).SelectDunningActionById), arg0, arg1)
+}
+
+// SelectDunningActionByStatus mocks base method.
+func (m *MockDunningRepository) SelectDunningActionByStatus(arg0 context.Context, arg1 entities.DunningActionStatus, arg2 entities.DunningAction) (*[]entities.DunningActionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectDunningActionByStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]entities.DunningActionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectDunningActionByStatus indicates an expected call of SelectDunningActionByStatus.
+func (mr *MockDunningRepositoryMockRecorder) SelectDunningActionByStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectDunningActionByStatus", reflect.TypeOf((*MockDunningRepository)(nil).SelectDunningActionByStatus), arg0, arg1, arg2)
+}
+
+// SelectLoanByReferenceId mocks base method.
+func (m *MockDunningRepository) SelectLoanByReferenceId(arg0 context.Context, arg1 string) (*entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanByReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanByReferenceId indicates an expected call of SelectLoanByReferenceId.
+func (mr *MockDunningRepositoryMockRecorder) SelectLoanByReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanByReferenceId", reflect.TypeOf((*MockDunningRepository)(nil).SelectLoanByReferenceId), arg0, arg1)
+}
+
+// SelectLoanCreatedBefore mocks base method.
+func (m *MockDunningRepository) SelectLoanCreatedBefore(arg0 context.Context, arg1 time.Time, arg2 int64, arg3 int) (*[]entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanCreatedBefore", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*[]entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanCreatedBefore indicates an expected call of SelectLoanCreatedBefore.
+func (mr *MockDunningRepositoryMockRecorder) SelectLoanCreatedBefore(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanCreatedBefore", reflect.TypeOf((*MockDunningRepository)(nil).SelectLoanCreatedBefore), arg0, arg1, arg2, arg3)
+}
+
+// SelectLoanDunningLevelByLoanId mocks base method.
+func (m *MockDunningRepository) SelectLoanDunningLevelByLoanId(arg0 context.Context, arg1 int64) (*entities.LoanDunningLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanDunningLevelByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.LoanDunningLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanDunningLevelByLoanId indicates an expected call of SelectLoanDunningLevelByLoanId.
+func (mr *MockDunningRepositoryMockRecorder) SelectLoanDunningLevelByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanDunningLevelByLoanId", reflect.TypeOf((*MockDunningRepository)(nil).SelectLoanDunningLevelByLoanId), arg0, arg1)
+}
+
+// SelectLoanDunningLevelByLoanIds mocks base method.
+func (m *MockDunningRepository) SelectLoanDunningLevelByLoanIds(arg0 context.Context, arg1 []int64) (map[int64]entities.LoanDunningLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanDunningLevelByLoanIds", arg0, arg1)
+	ret0, _ := ret[0].(map[int64]entities.LoanDunningLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanDunningLevelByLoanIds indicates an expected call of SelectLoanDunningLevelByLoanIds.
+func (mr *MockDunningRepositoryMockRecorder) SelectLoanDunningLevelByLoanIds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanDunningLevelByLoanIds", reflect.TypeOf((*MockDunningRepository)(nil).SelectLoanDunningLevelByLoanIds), arg0, arg1)
+}
+
+// SelectOpenPromiseToPayByLoanId mocks base method.
+func (m *MockDunningRepository) SelectOpenPromiseToPayByLoanId(arg0 context.Context, arg1 int64) (*entities.PromiseToPay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectOpenPromiseToPayByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.PromiseToPay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectOpenPromiseToPayByLoanId indicates an expected call of SelectOpenPromiseToPayByLoanId.
+func (mr *MockDunningRepositoryMockRecorder) SelectOpenPromiseToPayByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectOpenPromiseToPayByLoanId", reflect.TypeOf((*MockDunningRepository)(nil).SelectOpenPromiseToPayByLoanId), arg0, arg1)
+}
+
+// SelectRepaymentCountByLoanId mocks base method.
+func (m *MockDunningRepository) SelectRepaymentCountByLoanId(arg0 context.Context, arg1 int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRepaymentCountByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRepaymentCountByLoanId indicates an expected call of SelectRepaymentCountByLoanId.
+func (mr *MockDunningRepositoryMockRecorder) SelectRepaymentCountByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRepaymentCountByLoanId", reflect.TypeOf((*MockDunningRepository)(nil).SelectRepaymentCountByLoanId), arg0, arg1)
+}
+
+// SelectRepaymentCountByLoanIds mocks base method.
+func (m *MockDunningRepository) SelectRepaymentCountByLoanIds(arg0 context.Context, arg1 []int64, arg2 time.Time) (map[int64]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRepaymentCountByLoanIds", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[int64]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRepaymentCountByLoanIds indicates an expected call of SelectRepaymentCountByLoanIds.
+func (mr *MockDunningRepositoryMockRecorder) SelectRepaymentCountByLoanIds(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRepaymentCountByLoanIds", reflect.TypeOf((*MockDunningRepository)(nil).SelectRepaymentCountByLoanIds), arg0, arg1, arg2)
+}
+
+// UpdateDunningActionStatus mocks base method.
+func (m *MockDunningRepository) UpdateDunningActionStatus(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 int64, arg3 entities.DunningActionStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDunningActionStatus", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDunningActionStatus indicates an expected call of UpdateDunningActionStatus.
+func (mr *MockDunningRepositoryMockRecorder) UpdateDunningActionStatus(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDunningActionStatus", reflect.TypeOf((*MockDunningRepository)(nil).UpdateDunningActionStatus), arg0, arg1, arg2, arg3)
+}
+
+// UpsertLoanDunningLevel mocks base method.
+func (m *MockDunningRepository) UpsertLoanDunningLevel(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.LoanDunningLevel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertLoanDunningLevel", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertLoanDunningLevel indicates an expected call of UpsertLoanDunningLevel.
+func (mr *MockDunningRepositoryMockRecorder) UpsertLoanDunningLevel(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertLoanDunningLevel", reflect.TypeOf((*MockDunningRepository)(nil).UpsertLoanDunningLevel), arg0, arg1, arg2)
+}
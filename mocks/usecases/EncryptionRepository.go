@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: EncryptionRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+	interfaces "github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// MockEncryptionRepository is a mock of EncryptionRepository interface.
+type MockEncryptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEncryptionRepositoryMockRecorder
+}
+
+// MockEncryptionRepositoryMockRecorder is the mock recorder for MockEncryptionRepository.
+type MockEncryptionRepositoryMockRecorder struct {
+	mock *MockEncryptionRepository
+}
+
+// NewMockEncryptionRepository creates a new mock instance.
+func NewMockEncryptionRepository(ctrl *gomock.Controller) *MockEncryptionRepository {
+	mock := &MockEncryptionRepository{ctrl: ctrl}
+	mock.recorder = &MockEncryptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEncryptionRepository) EXPECT() *MockEncryptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// EncryptedColumns mocks base method.
+func (m *MockEncryptionRepository) EncryptedColumns() []entities.EncryptedColumn {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EncryptedColumns")
+	ret0, _ := ret[0].([]entities.EncryptedColumn)
+	return ret0
+}
+
+// EncryptedColumns indicates an expected call of EncryptedColumns.
+func (mr *MockEncryptionRepositoryMockRecorder) EncryptedColumns() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EncryptedColumns", reflect.TypeOf((*MockEncryptionRepository)(nil).EncryptedColumns))
+}
+
+// SelectEncryptedValues mocks base method.
+func (m *MockEncryptionRepository) SelectEncryptedValues(arg0 context.Context, arg1 entities.EncryptedColumn, arg2 int64, arg3 int) ([]entities.EncryptedValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectEncryptedValues", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]entities.EncryptedValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectEncryptedValues indicates an expected call of SelectEncryptedValues.
+func (mr *MockEncryptionRepositoryMockRecorder) SelectEncryptedValues(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectEncryptedValues", reflect.TypeOf((*MockEncryptionRepository)(nil).SelectEncryptedValues), arg0, arg1, arg2, arg3)
+}
+
+// UpdateEncryptedValue mocks base method.
+func (m *MockEncryptionRepository) UpdateEncryptedValue(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.EncryptedColumn, arg3 entities.EncryptedValue, arg4 []byte) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEncryptedValue", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEncryptedValue indicates an expected call of UpdateEncryptedValue.
+func (mr *MockEncryptionRepositoryMockRecorder) UpdateEncryptedValue(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEncryptedValue", reflect.TypeOf((*MockEncryptionRepository)(nil).UpdateEncryptedValue), arg0, arg1, arg2, arg3, arg4)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: ImportRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+	interfaces "github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// MockImportRepository is a mock of ImportRepository interface.
+type MockImportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportRepositoryMockRecorder
+}
+
+// MockImportRepositoryMockRecorder is the mock recorder for MockImportRepository.
+type MockImportRepositoryMockRecorder struct {
+	mock *MockImportRepository
+}
+
+// NewMockImportRepository creates a new mock instance.
+func NewMockImportRepository(ctrl *gomock.Controller) *MockImportRepository {
+	mock := &MockImportRepository{ctrl: ctrl}
+	mock.recorder = &MockImportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportRepository) EXPECT() *MockImportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BeginTx mocks base method.
+func (m *MockImportRepository) BeginTx(arg0 context.Context) (interfaces.AtomicTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", arg0)
+	ret0, _ := ret[0].(interfaces.AtomicTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockImportRepositoryMockRecorder) BeginTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*MockImportRepository)(nil).BeginTx), arg0)
+}
+
+// CreateImportedLoan mocks base method.
+func (m *MockImportRepository) CreateImportedLoan(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.Loan) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImportedLoan", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateImportedLoan indicates an expected call of CreateImportedLoan.
+func (mr *MockImportRepositoryMockRecorder) CreateImportedLoan(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImportedLoan", reflect.TypeOf((*MockImportRepository)(nil).CreateImportedLoan), arg0, arg1, arg2)
+}
+
+// CreateImportedRepayment mocks base method.
+func (m *MockImportRepository) CreateImportedRepayment(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.Repayment) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImportedRepayment", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateImportedRepayment indicates an expected call of CreateImportedRepayment.
+func (mr *MockImportRepositoryMockRecorder) CreateImportedRepayment(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImportedRepayment", reflect.TypeOf((*MockImportRepository)(nil).CreateImportedRepayment), arg0, arg1, arg2)
+}
+
+// SelectLoanByReferenceId mocks base method.
+func (m *MockImportRepository) SelectLoanByReferenceId(arg0 context.Context, arg1 string) (*entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanByReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanByReferenceId indicates an expected call of SelectLoanByReferenceId.
+func (mr *MockImportRepositoryMockRecorder) SelectLoanByReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanByReferenceId", reflect.TypeOf((*MockImportRepository)(nil).SelectLoanByReferenceId), arg0, arg1)
+}
+
+// SelectRepaymentByReferenceId mocks base method.
+func (m *MockImportRepository) SelectRepaymentByReferenceId(arg0 context.Context, arg1 string) (*entities.Repayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRepaymentByReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Repayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRepaymentByReferenceId indicates an expected call of SelectRepaymentByReferenceId.
+func (mr *MockImportRepositoryMockRecorder) SelectRepaymentByReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRepaymentByReferenceId", reflect.TypeOf((*MockImportRepository)(nil).SelectRepaymentByReferenceId), arg0, arg1)
+}
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: JobRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+	interfaces "github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// MockJobRepository is a mock of JobRepository interface.
+type MockJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobRepositoryMockRecorder
+}
+
+// MockJobRepositoryMockRecorder is the mock recorder for MockJobRepository.
+type MockJobRepositoryMockRecorder struct {
+	mock *MockJobRepository
+}
+
+// NewMockJobRepository creates a new mock instance.
+func NewMockJobRepository(ctrl *gomock.Controller) *MockJobRepository {
+	mock := &MockJobRepository{ctrl: ctrl}
+	mock.recorder = &MockJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobRepository) EXPECT() *MockJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcquireJobLease mocks base method.
+func (m *MockJobRepository) AcquireJobLease(arg0 context.Context, arg1, arg2 string, arg3, arg4 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireJobLease", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireJobLease indicates an expected call of AcquireJobLease.
+func (mr *MockJobRepositoryMockRecorder) AcquireJobLease(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireJobLease", reflect.TypeOf((*MockJobRepository)(nil).AcquireJobLease), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CreateJobRun mocks base method.
+func (m *MockJobRepository) CreateJobRun(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.JobRun) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJobRun", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJobRun indicates an expected call of CreateJobRun.
+func (mr *MockJobRepositoryMockRecorder) CreateJobRun(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJobRun", reflect.TypeOf((*MockJobRepository)(nil).CreateJobRun), arg0, arg1, arg2)
+}
+
+// ReleaseJobLease mocks base method.
+func (m *MockJobRepository) ReleaseJobLease(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseJobLease", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseJobLease indicates an expected call of ReleaseJobLease.
+func (mr *MockJobRepositoryMockRecorder) ReleaseJobLease(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseJobLease", reflect.TypeOf((*MockJobRepository)(nil).ReleaseJobLease), arg0, arg1, arg2)
+}
+
+// RenewJobLease mocks base method.
+func (m *MockJobRepository) RenewJobLease(arg0 context.Context, arg1, arg2 string, arg3, arg4 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewJobLease", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewJobLease indicates an expected call of RenewJobLease.
+func (mr *MockJobRepositoryMockRecorder) RenewJobLease(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewJobLease", reflect.TypeOf((*MockJobRepository)(nil).RenewJobLease), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SelectJobRunByBusinessDate mocks base method.
+func (m *MockJobRepository) SelectJobRunByBusinessDate(arg0 context.Context, arg1 string, arg2 time.Time) (*entities.JobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectJobRunByBusinessDate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entities.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectJobRunByBusinessDate indicates an expected call of SelectJobRunByBusinessDate.
+func (mr *MockJobRepositoryMockRecorder) SelectJobRunByBusinessDate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectJobRunByBusinessDate", reflect.TypeOf((*MockJobRepository)(nil).SelectJobRunByBusinessDate), arg0, arg1, arg2)
+}
+
+// SelectJobRunByJobName mocks base method.
+func (m *MockJobRepository) SelectJobRunByJobName(arg0 context.Context, arg1 string, arg2 int) (*[]entities.JobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectJobRunByJobName", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]entities.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectJobRunByJobName indicates an expected call of SelectJobRunByJobName.
+func (mr *MockJobRepositoryMockRecorder) SelectJobRunByJobName(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectJobRunByJobName", reflect.TypeOf((*MockJobRepository)(nil).SelectJobRunByJobName), arg0, arg1, arg2)
+}
+
+// UpdateJobRun mocks base method.
+func (m *MockJobRepository) UpdateJobRun(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.JobRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobRun", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJobRun indicates an expected call of UpdateJobRun.
+func (mr *MockJobRepositoryMockRecorder) UpdateJobRun(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobRun", reflect.TypeOf((*MockJobRepository)(nil).UpdateJobRun), arg0, arg1, arg2)
+}
@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: NotificationRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+	interfaces "github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// MockNotificationRepository is a mock of NotificationRepository interface.
+type MockNotificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationRepositoryMockRecorder
+}
+
+// MockNotificationRepositoryMockRecorder is the mock recorder for MockNotificationRepository.
+type MockNotificationRepositoryMockRecorder struct {
+	mock *MockNotificationRepository
+}
+
+// NewMockNotificationRepository creates a new mock instance.
+func NewMockNotificationRepository(ctrl *gomock.Controller) *MockNotificationRepository {
+	mock := &MockNotificationRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationRepository) EXPECT() *MockNotificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateNotificationDelivery mocks base method.
+func (m *MockNotificationRepository) CreateNotificationDelivery(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.NotificationDelivery) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotificationDelivery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotificationDelivery indicates an expected call of CreateNotificationDelivery.
+func (mr *MockNotificationRepositoryMockRecorder) CreateNotificationDelivery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotificationDelivery", reflect.TypeOf((*MockNotificationRepository)(nil).CreateNotificationDelivery), arg0, arg1, arg2)
+}
+
+// SelectLoanById mocks base method.
+func (m *MockNotificationRepository) SelectLoanById(arg0 context.Context, arg1 int64) (*entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanById", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanById indicates an expected call of SelectLoanById.
+func (mr *MockNotificationRepositoryMockRecorder) SelectLoanById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanById", reflect.TypeOf((*MockNotificationRepository)(nil).SelectLoanById), arg0, arg1)
+}
+
+// SelectLoanCreatedBefore mocks base method.
+func (m *MockNotificationRepository) SelectLoanCreatedBefore(arg0 context.Context, arg1 time.Time, arg2 int64, arg3 int) (*[]entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanCreatedBefore", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*[]entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanCreatedBefore indicates an expected call of SelectLoanCreatedBefore.
+func (mr *MockNotificationRepositoryMockRecorder) SelectLoanCreatedBefore(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanCreatedBefore", reflect.TypeOf((*MockNotificationRepository)(nil).SelectLoanCreatedBefore), arg0, arg1, arg2, arg3)
+}
+
+// SelectNotificationDeliveryByReference mocks base method.
+func (m *MockNotificationRepository) SelectNotificationDeliveryByReference(arg0 context.Context, arg1 entities.NotificationKind, arg2 string) (*[]entities.NotificationDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectNotificationDeliveryByReference", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]entities.NotificationDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectNotificationDeliveryByReference indicates an expected call of SelectNotificationDeliveryByReference.
+func (mr *MockNotificationRepositoryMockRecorder) SelectNotificationDeliveryByReference(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectNotificationDeliveryByReference", reflect.TypeOf((*MockNotificationRepository)(nil).SelectNotificationDeliveryByReference), arg0, arg1, arg2)
+}
+
+// SelectNotificationDeliveryByUserId mocks base method.
+func (m *MockNotificationRepository) SelectNotificationDeliveryByUserId(arg0 context.Context, arg1 int64) (*[]entities.NotificationDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectNotificationDeliveryByUserId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.NotificationDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectNotificationDeliveryByUserId indicates an expected call of SelectNotificationDeliveryByUserId.
+func (mr *MockNotificationRepositoryMockRecorder) SelectNotificationDeliveryByUserId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectNotificationDeliveryByUserId", reflect.TypeOf((*MockNotificationRepository)(nil).SelectNotificationDeliveryByUserId), arg0, arg1)
+}
+
+// SelectNotificationPreferenceByUserId mocks base method.
+func (m *MockNotificationRepository) SelectNotificationPreferenceByUserId(arg0 context.Context, arg1 int64) (*entities.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectNotificationPreferenceByUserId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectNotificationPreferenceByUserId indicates an expected call of SelectNotificationPreferenceByUserId.
+func (mr *MockNotificationRepositoryMockRecorder) SelectNotificationPreferenceByUserId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectNotificationPreferenceByUserId", reflect.TypeOf((*MockNotificationRepository)(nil).SelectNotificationPreferenceByUserId), arg0, arg1)
+}
+
+// SelectRepaymentCountByLoanIds mocks base method.
+func (m *MockNotificationRepository) SelectRepaymentCountByLoanIds(arg0 context.Context, arg1 []int64, arg2 time.Time) (map[int64]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRepaymentCountByLoanIds", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[int64]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRepaymentCountByLoanIds indicates an expected call of SelectRepaymentCountByLoanIds.
+func (mr *MockNotificationRepositoryMockRecorder) SelectRepaymentCountByLoanIds(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRepaymentCountByLoanIds", reflect.TypeOf((*MockNotificationRepository)(nil).SelectRepaymentCountByLoanIds), arg0, arg1, arg2)
+}
+
+// UpsertNotificationPreference mocks base method.
+func (m *MockNotificationRepository) UpsertNotificationPreference(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.NotificationPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertNotificationPreference", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertNotificationPreference indicates an expected call of UpsertNotificationPreference.
+func (mr *MockNotificationRepositoryMockRecorder) UpsertNotificationPreference(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertNotificationPreference", reflect.TypeOf((*MockNotificationRepository)(nil).UpsertNotificationPreference), arg0, arg1, arg2)
+}
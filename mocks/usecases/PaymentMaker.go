@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: PaymentMaker)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockPaymentMaker is a mock of PaymentMaker interface.
+type MockPaymentMaker struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentMakerMockRecorder
+}
+
+// MockPaymentMakerMockRecorder is the mock recorder for MockPaymentMaker.
+type MockPaymentMakerMockRecorder struct {
+	mock *MockPaymentMaker
+}
+
+// NewMockPaymentMaker creates a new mock instance.
+func NewMockPaymentMaker(ctrl *gomock.Controller) *MockPaymentMaker {
+	mock := &MockPaymentMaker{ctrl: ctrl}
+	mock.recorder = &MockPaymentMakerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentMaker) EXPECT() *MockPaymentMakerMockRecorder {
+	return m.recorder
+}
+
+// MakePayment mocks base method.
+func (m *MockPaymentMaker) MakePayment(arg0 context.Context, arg1 entities.RepaymentRequest) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MakePayment", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MakePayment indicates an expected call of MakePayment.
+func (mr *MockPaymentMakerMockRecorder) MakePayment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakePayment", reflect.TypeOf((*MockPaymentMaker)(nil).MakePayment), arg0, arg1)
+}
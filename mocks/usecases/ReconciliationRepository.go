@@ -0,0 +1,172 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: ReconciliationRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+	interfaces "github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// MockReconciliationRepository is a mock of ReconciliationRepository interface.
+type MockReconciliationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconciliationRepositoryMockRecorder
+}
+
+// MockReconciliationRepositoryMockRecorder is the mock recorder for MockReconciliationRepository.
+type MockReconciliationRepositoryMockRecorder struct {
+	mock *MockReconciliationRepository
+}
+
+// NewMockReconciliationRepository creates a new mock instance.
+func NewMockReconciliationRepository(ctrl *gomock.Controller) *MockReconciliationRepository {
+	mock := &MockReconciliationRepository{ctrl: ctrl}
+	mock.recorder = &MockReconciliationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReconciliationRepository) EXPECT() *MockReconciliationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BeginTx mocks base method.
+func (m *MockReconciliationRepository) BeginTx(arg0 context.Context) (interfaces.AtomicTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", arg0)
+	ret0, _ := ret[0].(interfaces.AtomicTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockReconciliationRepositoryMockRecorder) BeginTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*MockReconciliationRepository)(nil).BeginTx), arg0)
+}
+
+// CreateBankStatementLine mocks base method.
+func (m *MockReconciliationRepository) CreateBankStatementLine(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.BankStatementLine) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBankStatementLine", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBankStatementLine indicates an expected call of CreateBankStatementLine.
+func (mr *MockReconciliationRepositoryMockRecorder) CreateBankStatementLine(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBankStatementLine", reflect.TypeOf((*MockReconciliationRepository)(nil).CreateBankStatementLine), arg0, arg1, arg2)
+}
+
+// SelectBankStatementLineById mocks base method.
+func (m *MockReconciliationRepository) SelectBankStatementLineById(arg0 context.Context, arg1 int64) (*entities.BankStatementLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectBankStatementLineById", arg0, arg1)
+	ret0, _ := ret[0].(*entities.BankStatementLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectBankStatementLineById indicates an expected call of SelectBankStatementLineById.
+func (mr *MockReconciliationRepositoryMockRecorder) SelectBankStatementLineById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectBankStatementLineById", reflect.TypeOf((*MockReconciliationRepository)(nil).SelectBankStatementLineById), arg0, arg1)
+}
+
+// SelectBankStatementLineByRepaymentId mocks base method.
+func (m *MockReconciliationRepository) SelectBankStatementLineByRepaymentId(arg0 context.Context, arg1 int64) (*entities.BankStatementLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectBankStatementLineByRepaymentId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.BankStatementLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectBankStatementLineByRepaymentId indicates an expected call of SelectBankStatementLineByRepaymentId.
+func (mr *MockReconciliationRepositoryMockRecorder) SelectBankStatementLineByRepaymentId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectBankStatementLineByRepaymentId", reflect.TypeOf((*MockReconciliationRepository)(nil).SelectBankStatementLineByRepaymentId), arg0, arg1)
+}
+
+// SelectBankStatementLineByValueDate mocks base method.
+func (m *MockReconciliationRepository) SelectBankStatementLineByValueDate(arg0 context.Context, arg1, arg2 time.Time) (*[]entities.BankStatementLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectBankStatementLineByValueDate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]entities.BankStatementLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectBankStatementLineByValueDate indicates an expected call of SelectBankStatementLineByValueDate.
+func (mr *MockReconciliationRepositoryMockRecorder) SelectBankStatementLineByValueDate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectBankStatementLineByValueDate", reflect.TypeOf((*MockReconciliationRepository)(nil).SelectBankStatementLineByValueDate), arg0, arg1, arg2)
+}
+
+// SelectRepaymentByCreatedAt mocks base method.
+func (m *MockReconciliationRepository) SelectRepaymentByCreatedAt(arg0 context.Context, arg1, arg2 time.Time) (*[]entities.Repayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRepaymentByCreatedAt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]entities.Repayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRepaymentByCreatedAt indicates an expected call of SelectRepaymentByCreatedAt.
+func (mr *MockReconciliationRepositoryMockRecorder) SelectRepaymentByCreatedAt(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRepaymentByCreatedAt", reflect.TypeOf((*MockReconciliationRepository)(nil).SelectRepaymentByCreatedAt), arg0, arg1, arg2)
+}
+
+// SelectRepaymentByReferenceId mocks base method.
+func (m *MockReconciliationRepository) SelectRepaymentByReferenceId(arg0 context.Context, arg1 string) (*entities.Repayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRepaymentByReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Repayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRepaymentByReferenceId indicates an expected call of SelectRepaymentByReferenceId.
+func (mr *MockReconciliationRepositoryMockRecorder) SelectRepaymentByReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRepaymentByReferenceId", reflect.TypeOf((*MockReconciliationRepository)(nil).SelectRepaymentByReferenceId), arg0, arg1)
+}
+
+// SelectRepaymentReconciliationStatus mocks base method.
+func (m *MockReconciliationRepository) SelectRepaymentReconciliationStatus(arg0 context.Context, arg1 []int64) (map[int64]entities.ReconciliationStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRepaymentReconciliationStatus", arg0, arg1)
+	ret0, _ := ret[0].(map[int64]entities.ReconciliationStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRepaymentReconciliationStatus indicates an expected call of SelectRepaymentReconciliationStatus.
+func (mr *MockReconciliationRepositoryMockRecorder) SelectRepaymentReconciliationStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRepaymentReconciliationStatus", reflect.TypeOf((*MockReconciliationRepository)(nil).SelectRepaymentReconciliationStatus), arg0, arg1)
+}
+
+// UpdateBankStatementLineMatch mocks base method.
+func (m *MockReconciliationRepository) UpdateBankStatementLineMatch(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.BankStatementLine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBankStatementLineMatch", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBankStatementLineMatch indicates an expected call of UpdateBankStatementLineMatch.
+func (mr *MockReconciliationRepositoryMockRecorder) UpdateBankStatementLineMatch(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBankStatementLineMatch", reflect.TypeOf((*MockReconciliationRepository)(nil).UpdateBankStatementLineMatch), arg0, arg1, arg2)
+}
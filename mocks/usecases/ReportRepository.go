@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: ReportRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockReportRepository is a mock of ReportRepository interface.
+type MockReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRepositoryMockRecorder
+}
+
+// MockReportRepositoryMockRecorder is the mock recorder for MockReportRepository.
+type MockReportRepositoryMockRecorder struct {
+	mock *MockReportRepository
+}
+
+// NewMockReportRepository creates a new mock instance.
+func NewMockReportRepository(ctrl *gomock.Controller) *MockReportRepository {
+	mock := &MockReportRepository{ctrl: ctrl}
+	mock.recorder = &MockReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRepository) EXPECT() *MockReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// SelectAgingSummary mocks base method.
+func (m *MockReportRepository) SelectAgingSummary(arg0 context.Context, arg1 entities.ReportFilter) (*[]entities.AgingBucketSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAgingSummary", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.AgingBucketSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAgingSummary indicates an expected call of SelectAgingSummary.
+func (mr *MockReportRepositoryMockRecorder) SelectAgingSummary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAgingSummary", reflect.TypeOf((*MockReportRepository)(nil).SelectAgingSummary), arg0, arg1)
+}
+
+// SelectLoanByReportFilter mocks base method.
+func (m *MockReportRepository) SelectLoanByReportFilter(arg0 context.Context, arg1 entities.ReportFilter, arg2, arg3 time.Time) (*[]entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanByReportFilter", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*[]entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanByReportFilter indicates an expected call of SelectLoanByReportFilter.
+func (mr *MockReportRepositoryMockRecorder) SelectLoanByReportFilter(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanByReportFilter", reflect.TypeOf((*MockReportRepository)(nil).SelectLoanByReportFilter), arg0, arg1, arg2, arg3)
+}
+
+// SelectOutstandingBySchedule mocks base method.
+func (m *MockReportRepository) SelectOutstandingBySchedule(arg0 context.Context, arg1 entities.ReportFilter) (*[]entities.ScheduleOutstanding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectOutstandingBySchedule", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.ScheduleOutstanding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectOutstandingBySchedule indicates an expected call of SelectOutstandingBySchedule.
+func (mr *MockReportRepositoryMockRecorder) SelectOutstandingBySchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectOutstandingBySchedule", reflect.TypeOf((*MockReportRepository)(nil).SelectOutstandingBySchedule), arg0, arg1)
+}
+
+// SelectRepaymentCountByLoanIds mocks base method.
+func (m *MockReportRepository) SelectRepaymentCountByLoanIds(arg0 context.Context, arg1 []int64, arg2 time.Time) (map[int64]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRepaymentCountByLoanIds", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[int64]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRepaymentCountByLoanIds indicates an expected call of SelectRepaymentCountByLoanIds.
+func (mr *MockReportRepositoryMockRecorder) SelectRepaymentCountByLoanIds(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRepaymentCountByLoanIds", reflect.TypeOf((*MockReportRepository)(nil).SelectRepaymentCountByLoanIds), arg0, arg1, arg2)
+}
@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: ScheduleRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+	interfaces "github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// MockScheduleRepository is a mock of ScheduleRepository interface.
+type MockScheduleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduleRepositoryMockRecorder
+}
+
+// MockScheduleRepositoryMockRecorder is the mock recorder for MockScheduleRepository.
+type MockScheduleRepositoryMockRecorder struct {
+	mock *MockScheduleRepository
+}
+
+// NewMockScheduleRepository creates a new mock instance.
+func NewMockScheduleRepository(ctrl *gomock.Controller) *MockScheduleRepository {
+	mock := &MockScheduleRepository{ctrl: ctrl}
+	mock.recorder = &MockScheduleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduleRepository) EXPECT() *MockScheduleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BeginTx mocks base method.
+func (m *MockScheduleRepository) BeginTx(arg0 context.Context) (interfaces.AtomicTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", arg0)
+	ret0, _ := ret[0].(interfaces.AtomicTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockScheduleRepositoryMockRecorder) BeginTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*MockScheduleRepository)(nil).BeginTx), arg0)
+}
+
+// CloseLoanScheduleVersion mocks base method.
+func (m *MockScheduleRepository) CloseLoanScheduleVersion(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 int64, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseLoanScheduleVersion", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseLoanScheduleVersion indicates an expected call of CloseLoanScheduleVersion.
+func (mr *MockScheduleRepositoryMockRecorder) CloseLoanScheduleVersion(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseLoanScheduleVersion", reflect.TypeOf((*MockScheduleRepository)(nil).CloseLoanScheduleVersion), arg0, arg1, arg2, arg3)
+}
+
+// CreateLoanScheduleVersion mocks base method.
+func (m *MockScheduleRepository) CreateLoanScheduleVersion(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.LoanScheduleVersion) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoanScheduleVersion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLoanScheduleVersion indicates an expected call of CreateLoanScheduleVersion.
+func (mr *MockScheduleRepositoryMockRecorder) CreateLoanScheduleVersion(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoanScheduleVersion", reflect.TypeOf((*MockScheduleRepository)(nil).CreateLoanScheduleVersion), arg0, arg1, arg2)
+}
+
+// SelectLoanByReferenceId mocks base method.
+func (m *MockScheduleRepository) SelectLoanByReferenceId(arg0 context.Context, arg1 string) (*entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanByReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanByReferenceId indicates an expected call of SelectLoanByReferenceId.
+func (mr *MockScheduleRepositoryMockRecorder) SelectLoanByReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanByReferenceId", reflect.TypeOf((*MockScheduleRepository)(nil).SelectLoanByReferenceId), arg0, arg1)
+}
+
+// SelectLoanScheduleVersionByLoanId mocks base method.
+func (m *MockScheduleRepository) SelectLoanScheduleVersionByLoanId(arg0 context.Context, arg1 int64) (*[]entities.LoanScheduleVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanScheduleVersionByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.LoanScheduleVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanScheduleVersionByLoanId indicates an expected call of SelectLoanScheduleVersionByLoanId.
+func (mr *MockScheduleRepositoryMockRecorder) SelectLoanScheduleVersionByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanScheduleVersionByLoanId", reflect.TypeOf((*MockScheduleRepository)(nil).SelectLoanScheduleVersionByLoanId), arg0, arg1)
+}
+
+// SelectRepaymentCountByLoanId mocks base method.
+func (m *MockScheduleRepository) SelectRepaymentCountByLoanId(arg0 context.Context, arg1 int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRepaymentCountByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRepaymentCountByLoanId indicates an expected call of SelectRepaymentCountByLoanId.
+func (mr *MockScheduleRepositoryMockRecorder) SelectRepaymentCountByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRepaymentCountByLoanId", reflect.TypeOf((*MockScheduleRepository)(nil).SelectRepaymentCountByLoanId), arg0, arg1)
+}
+
+// SelectTotalRepaymentAmountByLoanId mocks base method.
+func (m *MockScheduleRepository) SelectTotalRepaymentAmountByLoanId(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectTotalRepaymentAmountByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectTotalRepaymentAmountByLoanId indicates an expected call of SelectTotalRepaymentAmountByLoanId.
+func (mr *MockScheduleRepositoryMockRecorder) SelectTotalRepaymentAmountByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectTotalRepaymentAmountByLoanId", reflect.TypeOf((*MockScheduleRepository)(nil).SelectTotalRepaymentAmountByLoanId), arg0, arg1)
+}
+
+// UpdateLoanSchedule mocks base method.
+func (m *MockScheduleRepository) UpdateLoanSchedule(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.Loan) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLoanSchedule", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLoanSchedule indicates an expected call of UpdateLoanSchedule.
+func (mr *MockScheduleRepositoryMockRecorder) UpdateLoanSchedule(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoanSchedule", reflect.TypeOf((*MockScheduleRepository)(nil).UpdateLoanSchedule), arg0, arg1, arg2)
+}
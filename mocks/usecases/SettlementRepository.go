@@ -0,0 +1,200 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: SettlementRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+	interfaces "github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// MockSettlementRepository is a mock of SettlementRepository interface.
+type MockSettlementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettlementRepositoryMockRecorder
+}
+
+// MockSettlementRepositoryMockRecorder is the mock recorder for MockSettlementRepository.
+type MockSettlementRepositoryMockRecorder struct {
+	mock *MockSettlementRepository
+}
+
+// NewMockSettlementRepository creates a new mock instance.
+func NewMockSettlementRepository(ctrl *gomock.Controller) *MockSettlementRepository {
+	mock := &MockSettlementRepository{ctrl: ctrl}
+	mock.recorder = &MockSettlementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSettlementRepository) EXPECT() *MockSettlementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSettlementException mocks base method.
+func (m *MockSettlementRepository) CreateSettlementException(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.SettlementException) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSettlementException", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSettlementException indicates an expected call of CreateSettlementException.
+func (mr *MockSettlementRepositoryMockRecorder) CreateSettlementException(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSettlementException", reflect.TypeOf((*MockSettlementRepository)(nil).CreateSettlementException), arg0, arg1, arg2)
+}
+
+// CreateSettlementFile mocks base method.
+func (m *MockSettlementRepository) CreateSettlementFile(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.SettlementFile) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSettlementFile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSettlementFile indicates an expected call of CreateSettlementFile.
+func (mr *MockSettlementRepositoryMockRecorder) CreateSettlementFile(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSettlementFile", reflect.TypeOf((*MockSettlementRepository)(nil).CreateSettlementFile), arg0, arg1, arg2)
+}
+
+// SelectLoanById mocks base method.
+func (m *MockSettlementRepository) SelectLoanById(arg0 context.Context, arg1 int64) (*entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanById", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanById indicates an expected call of SelectLoanById.
+func (mr *MockSettlementRepositoryMockRecorder) SelectLoanById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanById", reflect.TypeOf((*MockSettlementRepository)(nil).SelectLoanById), arg0, arg1)
+}
+
+// SelectLoansByReferenceId mocks base method.
+func (m *MockSettlementRepository) SelectLoansByReferenceId(arg0 context.Context, arg1 string) (*[]entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoansByReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoansByReferenceId indicates an expected call of SelectLoansByReferenceId.
+func (mr *MockSettlementRepositoryMockRecorder) SelectLoansByReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoansByReferenceId", reflect.TypeOf((*MockSettlementRepository)(nil).SelectLoansByReferenceId), arg0, arg1)
+}
+
+// SelectRepaymentByReferenceId mocks base method.
+func (m *MockSettlementRepository) SelectRepaymentByReferenceId(arg0 context.Context, arg1 string) (*entities.Repayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRepaymentByReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Repayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRepaymentByReferenceId indicates an expected call of SelectRepaymentByReferenceId.
+func (mr *MockSettlementRepositoryMockRecorder) SelectRepaymentByReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRepaymentByReferenceId", reflect.TypeOf((*MockSettlementRepository)(nil).SelectRepaymentByReferenceId), arg0, arg1)
+}
+
+// SelectSettlementExceptionById mocks base method.
+func (m *MockSettlementRepository) SelectSettlementExceptionById(arg0 context.Context, arg1 int64) (*entities.SettlementException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectSettlementExceptionById", arg0, arg1)
+	ret0, _ := ret[0].(*entities.SettlementException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectSettlementExceptionById indicates an expected call of SelectSettlementExceptionById.
+func (mr *MockSettlementRepositoryMockRecorder) SelectSettlementExceptionById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectSettlementExceptionById", reflect.TypeOf((*MockSettlementRepository)(nil).SelectSettlementExceptionById), arg0, arg1)
+}
+
+// SelectSettlementExceptionByStatus mocks base method.
+func (m *MockSettlementRepository) SelectSettlementExceptionByStatus(arg0 context.Context, arg1 entities.SettlementExceptionStatus) (*[]entities.SettlementException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectSettlementExceptionByStatus", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.SettlementException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectSettlementExceptionByStatus indicates an expected call of SelectSettlementExceptionByStatus.
+func (mr *MockSettlementRepositoryMockRecorder) SelectSettlementExceptionByStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectSettlementExceptionByStatus", reflect.TypeOf((*MockSettlementRepository)(nil).SelectSettlementExceptionByStatus), arg0, arg1)
+}
+
+// SelectSettlementFileByChecksum mocks base method.
+func (m *MockSettlementRepository) SelectSettlementFileByChecksum(arg0 context.Context, arg1, arg2 string) (*entities.SettlementFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectSettlementFileByChecksum", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entities.SettlementFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectSettlementFileByChecksum indicates an expected call of SelectSettlementFileByChecksum.
+func (mr *MockSettlementRepositoryMockRecorder) SelectSettlementFileByChecksum(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectSettlementFileByChecksum", reflect.TypeOf((*MockSettlementRepository)(nil).SelectSettlementFileByChecksum), arg0, arg1, arg2)
+}
+
+// SelectVirtualAccountByNumber mocks base method.
+func (m *MockSettlementRepository) SelectVirtualAccountByNumber(arg0 context.Context, arg1 string) (*entities.VirtualAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectVirtualAccountByNumber", arg0, arg1)
+	ret0, _ := ret[0].(*entities.VirtualAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectVirtualAccountByNumber indicates an expected call of SelectVirtualAccountByNumber.
+func (mr *MockSettlementRepositoryMockRecorder) SelectVirtualAccountByNumber(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectVirtualAccountByNumber", reflect.TypeOf((*MockSettlementRepository)(nil).SelectVirtualAccountByNumber), arg0, arg1)
+}
+
+// UpdateSettlementException mocks base method.
+func (m *MockSettlementRepository) UpdateSettlementException(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.SettlementException) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettlementException", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSettlementException indicates an expected call of UpdateSettlementException.
+func (mr *MockSettlementRepositoryMockRecorder) UpdateSettlementException(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettlementException", reflect.TypeOf((*MockSettlementRepository)(nil).UpdateSettlementException), arg0, arg1, arg2)
+}
+
+// UpdateSettlementFile mocks base method.
+func (m *MockSettlementRepository) UpdateSettlementFile(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.SettlementFile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettlementFile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSettlementFile indicates an expected call of UpdateSettlementFile.
+func (mr *MockSettlementRepositoryMockRecorder) UpdateSettlementFile(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettlementFile", reflect.TypeOf((*MockSettlementRepository)(nil).UpdateSettlementFile), arg0, arg1, arg2)
+}
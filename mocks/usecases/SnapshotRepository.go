@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: SnapshotRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+	interfaces "github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// MockSnapshotRepository is a mock of SnapshotRepository interface.
+type MockSnapshotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotRepositoryMockRecorder
+}
+
+// MockSnapshotRepositoryMockRecorder is the mock recorder for MockSnapshotRepository.
+type MockSnapshotRepositoryMockRecorder struct {
+	mock *MockSnapshotRepository
+}
+
+// NewMockSnapshotRepository creates a new mock instance.
+func NewMockSnapshotRepository(ctrl *gomock.Controller) *MockSnapshotRepository {
+	mock := &MockSnapshotRepository{ctrl: ctrl}
+	mock.recorder = &MockSnapshotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotRepository) EXPECT() *MockSnapshotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BeginTx mocks base method.
+func (m *MockSnapshotRepository) BeginTx(arg0 context.Context) (interfaces.AtomicTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", arg0)
+	ret0, _ := ret[0].(interfaces.AtomicTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockSnapshotRepositoryMockRecorder) BeginTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*MockSnapshotRepository)(nil).BeginTx), arg0)
+}
+
+// SelectLoanCreatedBefore mocks base method.
+func (m *MockSnapshotRepository) SelectLoanCreatedBefore(arg0 context.Context, arg1 time.Time, arg2 int64, arg3 int) (*[]entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanCreatedBefore", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*[]entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanCreatedBefore indicates an expected call of SelectLoanCreatedBefore.
+func (mr *MockSnapshotRepositoryMockRecorder) SelectLoanCreatedBefore(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanCreatedBefore", reflect.TypeOf((*MockSnapshotRepository)(nil).SelectLoanCreatedBefore), arg0, arg1, arg2, arg3)
+}
+
+// SelectLoanDailySnapshotByBusinessDate mocks base method.
+func (m *MockSnapshotRepository) SelectLoanDailySnapshotByBusinessDate(arg0 context.Context, arg1 time.Time) (*[]entities.LoanDailySnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanDailySnapshotByBusinessDate", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.LoanDailySnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanDailySnapshotByBusinessDate indicates an expected call of SelectLoanDailySnapshotByBusinessDate.
+func (mr *MockSnapshotRepositoryMockRecorder) SelectLoanDailySnapshotByBusinessDate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanDailySnapshotByBusinessDate", reflect.TypeOf((*MockSnapshotRepository)(nil).SelectLoanDailySnapshotByBusinessDate), arg0, arg1)
+}
+
+// SelectRepaymentCountByLoanIds mocks base method.
+func (m *MockSnapshotRepository) SelectRepaymentCountByLoanIds(arg0 context.Context, arg1 []int64, arg2 time.Time) (map[int64]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRepaymentCountByLoanIds", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[int64]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRepaymentCountByLoanIds indicates an expected call of SelectRepaymentCountByLoanIds.
+func (mr *MockSnapshotRepositoryMockRecorder) SelectRepaymentCountByLoanIds(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRepaymentCountByLoanIds", reflect.TypeOf((*MockSnapshotRepository)(nil).SelectRepaymentCountByLoanIds), arg0, arg1, arg2)
+}
+
+// UpsertLoanDailySnapshot mocks base method.
+func (m *MockSnapshotRepository) UpsertLoanDailySnapshot(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.LoanDailySnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertLoanDailySnapshot", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertLoanDailySnapshot indicates an expected call of UpsertLoanDailySnapshot.
+func (mr *MockSnapshotRepositoryMockRecorder) UpsertLoanDailySnapshot(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertLoanDailySnapshot", reflect.TypeOf((*MockSnapshotRepository)(nil).UpsertLoanDailySnapshot), arg0, arg1, arg2)
+}
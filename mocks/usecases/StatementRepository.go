@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: StatementRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+	interfaces "github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// MockStatementRepository is a mock of StatementRepository interface.
+type MockStatementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementRepositoryMockRecorder
+}
+
+// MockStatementRepositoryMockRecorder is the mock recorder for MockStatementRepository.
+type MockStatementRepositoryMockRecorder struct {
+	mock *MockStatementRepository
+}
+
+// NewMockStatementRepository creates a new mock instance.
+func NewMockStatementRepository(ctrl *gomock.Controller) *MockStatementRepository {
+	mock := &MockStatementRepository{ctrl: ctrl}
+	mock.recorder = &MockStatementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementRepository) EXPECT() *MockStatementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateStatement mocks base method.
+func (m *MockStatementRepository) CreateStatement(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.Statement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStatement", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateStatement indicates an expected call of CreateStatement.
+func (mr *MockStatementRepositoryMockRecorder) CreateStatement(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStatement", reflect.TypeOf((*MockStatementRepository)(nil).CreateStatement), arg0, arg1, arg2)
+}
+
+// SelectLoanByUserId mocks base method.
+func (m *MockStatementRepository) SelectLoanByUserId(arg0 context.Context, arg1 int64) (*[]entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanByUserId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanByUserId indicates an expected call of SelectLoanByUserId.
+func (mr *MockStatementRepositoryMockRecorder) SelectLoanByUserId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanByUserId", reflect.TypeOf((*MockStatementRepository)(nil).SelectLoanByUserId), arg0, arg1)
+}
+
+// SelectRepaymentByLoanId mocks base method.
+func (m *MockStatementRepository) SelectRepaymentByLoanId(arg0 context.Context, arg1 int64) (*[]entities.Repayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRepaymentByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.Repayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRepaymentByLoanId indicates an expected call of SelectRepaymentByLoanId.
+func (mr *MockStatementRepositoryMockRecorder) SelectRepaymentByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRepaymentByLoanId", reflect.TypeOf((*MockStatementRepository)(nil).SelectRepaymentByLoanId), arg0, arg1)
+}
+
+// SelectStatementByPeriod mocks base method.
+func (m *MockStatementRepository) SelectStatementByPeriod(arg0 context.Context, arg1 int64, arg2 time.Time) (*entities.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectStatementByPeriod", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entities.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectStatementByPeriod indicates an expected call of SelectStatementByPeriod.
+func (mr *MockStatementRepositoryMockRecorder) SelectStatementByPeriod(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectStatementByPeriod", reflect.TypeOf((*MockStatementRepository)(nil).SelectStatementByPeriod), arg0, arg1, arg2)
+}
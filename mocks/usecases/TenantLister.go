@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: TenantLister)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockTenantLister is a mock of TenantLister interface.
+type MockTenantLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantListerMockRecorder
+}
+
+// MockTenantListerMockRecorder is the mock recorder for MockTenantLister.
+type MockTenantListerMockRecorder struct {
+	mock *MockTenantLister
+}
+
+// NewMockTenantLister creates a new mock instance.
+func NewMockTenantLister(ctrl *gomock.Controller) *MockTenantLister {
+	mock := &MockTenantLister{ctrl: ctrl}
+	mock.recorder = &MockTenantListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantLister) EXPECT() *MockTenantListerMockRecorder {
+	return m.recorder
+}
+
+// GetTenantList mocks base method.
+func (m *MockTenantLister) GetTenantList(arg0 context.Context) (*[]entities.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenantList", arg0)
+	ret0, _ := ret[0].(*[]entities.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenantList indicates an expected call of GetTenantList.
+func (mr *MockTenantListerMockRecorder) GetTenantList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenantList", reflect.TypeOf((*MockTenantLister)(nil).GetTenantList), arg0)
+}
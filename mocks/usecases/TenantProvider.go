@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: TenantProvider)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MockTenantProvider is a mock of TenantProvider interface.
+type MockTenantProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantProviderMockRecorder
+}
+
+// MockTenantProviderMockRecorder is the mock recorder for MockTenantProvider.
+type MockTenantProviderMockRecorder struct {
+	mock *MockTenantProvider
+}
+
+// NewMockTenantProvider creates a new mock instance.
+func NewMockTenantProvider(ctrl *gomock.Controller) *MockTenantProvider {
+	mock := &MockTenantProvider{ctrl: ctrl}
+	mock.recorder = &MockTenantProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantProvider) EXPECT() *MockTenantProviderMockRecorder {
+	return m.recorder
+}
+
+// GetCurrentTenant mocks base method.
+func (m *MockTenantProvider) GetCurrentTenant(arg0 context.Context) (*entities.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentTenant", arg0)
+	ret0, _ := ret[0].(*entities.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentTenant indicates an expected call of GetCurrentTenant.
+func (mr *MockTenantProviderMockRecorder) GetCurrentTenant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentTenant", reflect.TypeOf((*MockTenantProvider)(nil).GetCurrentTenant), arg0)
+}
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: VirtualAccountRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+	interfaces "github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// MockVirtualAccountRepository is a mock of VirtualAccountRepository interface.
+type MockVirtualAccountRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVirtualAccountRepositoryMockRecorder
+}
+
+// MockVirtualAccountRepositoryMockRecorder is the mock recorder for MockVirtualAccountRepository.
+type MockVirtualAccountRepositoryMockRecorder struct {
+	mock *MockVirtualAccountRepository
+}
+
+// NewMockVirtualAccountRepository creates a new mock instance.
+func NewMockVirtualAccountRepository(ctrl *gomock.Controller) *MockVirtualAccountRepository {
+	mock := &MockVirtualAccountRepository{ctrl: ctrl}
+	mock.recorder = &MockVirtualAccountRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVirtualAccountRepository) EXPECT() *MockVirtualAccountRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateVirtualAccount mocks base method.
+func (m *MockVirtualAccountRepository) CreateVirtualAccount(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.VirtualAccount) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVirtualAccount", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVirtualAccount indicates an expected call of CreateVirtualAccount.
+func (mr *MockVirtualAccountRepositoryMockRecorder) CreateVirtualAccount(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVirtualAccount", reflect.TypeOf((*MockVirtualAccountRepository)(nil).CreateVirtualAccount), arg0, arg1, arg2)
+}
+
+// SelectLoanById mocks base method.
+func (m *MockVirtualAccountRepository) SelectLoanById(arg0 context.Context, arg1 int64) (*entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanById", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanById indicates an expected call of SelectLoanById.
+func (mr *MockVirtualAccountRepositoryMockRecorder) SelectLoanById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanById", reflect.TypeOf((*MockVirtualAccountRepository)(nil).SelectLoanById), arg0, arg1)
+}
+
+// SelectLoanByReferenceId mocks base method.
+func (m *MockVirtualAccountRepository) SelectLoanByReferenceId(arg0 context.Context, arg1 string) (*entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanByReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanByReferenceId indicates an expected call of SelectLoanByReferenceId.
+func (mr *MockVirtualAccountRepositoryMockRecorder) SelectLoanByReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanByReferenceId", reflect.TypeOf((*MockVirtualAccountRepository)(nil).SelectLoanByReferenceId), arg0, arg1)
+}
+
+// SelectRepaymentByReferenceId mocks base method.
+func (m *MockVirtualAccountRepository) SelectRepaymentByReferenceId(arg0 context.Context, arg1 string) (*entities.Repayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRepaymentByReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Repayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRepaymentByReferenceId indicates an expected call of SelectRepaymentByReferenceId.
+func (mr *MockVirtualAccountRepositoryMockRecorder) SelectRepaymentByReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRepaymentByReferenceId", reflect.TypeOf((*MockVirtualAccountRepository)(nil).SelectRepaymentByReferenceId), arg0, arg1)
+}
+
+// SelectVirtualAccountByLoanId mocks base method.
+func (m *MockVirtualAccountRepository) SelectVirtualAccountByLoanId(arg0 context.Context, arg1 int64) (*[]entities.VirtualAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectVirtualAccountByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.VirtualAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectVirtualAccountByLoanId indicates an expected call of SelectVirtualAccountByLoanId.
+func (mr *MockVirtualAccountRepositoryMockRecorder) SelectVirtualAccountByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectVirtualAccountByLoanId", reflect.TypeOf((*MockVirtualAccountRepository)(nil).SelectVirtualAccountByLoanId), arg0, arg1)
+}
+
+// SelectVirtualAccountByNumber mocks base method.
+func (m *MockVirtualAccountRepository) SelectVirtualAccountByNumber(arg0 context.Context, arg1 string) (*entities.VirtualAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectVirtualAccountByNumber", arg0, arg1)
+	ret0, _ := ret[0].(*entities.VirtualAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectVirtualAccountByNumber indicates an expected call of SelectVirtualAccountByNumber.
+func (mr *MockVirtualAccountRepositoryMockRecorder) SelectVirtualAccountByNumber(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectVirtualAccountByNumber", reflect.TypeOf((*MockVirtualAccountRepository)(nil).SelectVirtualAccountByNumber), arg0, arg1)
+}
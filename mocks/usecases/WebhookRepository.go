@@ -0,0 +1,216 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: WebhookRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+	interfaces "github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BeginTx mocks base method.
+func (m *MockWebhookRepository) BeginTx(arg0 context.Context) (interfaces.AtomicTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", arg0)
+	ret0, _ := ret[0].(interfaces.AtomicTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockWebhookRepositoryMockRecorder) BeginTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*MockWebhookRepository)(nil).BeginTx), arg0)
+}
+
+// CreateWebhookDelivery mocks base method.
+func (m *MockWebhookRepository) CreateWebhookDelivery(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.WebhookDelivery) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDelivery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookDelivery indicates an expected call of CreateWebhookDelivery.
+func (mr *MockWebhookRepositoryMockRecorder) CreateWebhookDelivery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).CreateWebhookDelivery), arg0, arg1, arg2)
+}
+
+// CreateWebhookDeliveryAttempt mocks base method.
+func (m *MockWebhookRepository) CreateWebhookDeliveryAttempt(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.WebhookDeliveryAttempt) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDeliveryAttempt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookDeliveryAttempt indicates an expected call of CreateWebhookDeliveryAttempt.
+func (mr *MockWebhookRepositoryMockRecorder) CreateWebhookDeliveryAttempt(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDeliveryAttempt", reflect.TypeOf((*MockWebhookRepository)(nil).CreateWebhookDeliveryAttempt), arg0, arg1, arg2)
+}
+
+// CreateWebhookSubscription mocks base method.
+func (m *MockWebhookRepository) CreateWebhookSubscription(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.WebhookSubscription) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookSubscription", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookSubscription indicates an expected call of CreateWebhookSubscription.
+func (mr *MockWebhookRepositoryMockRecorder) CreateWebhookSubscription(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookSubscription", reflect.TypeOf((*MockWebhookRepository)(nil).CreateWebhookSubscription), arg0, arg1, arg2)
+}
+
+// SelectDueWebhookDelivery mocks base method.
+func (m *MockWebhookRepository) SelectDueWebhookDelivery(arg0 context.Context, arg1 time.Time, arg2 int) (*[]entities.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectDueWebhookDelivery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]entities.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectDueWebhookDelivery indicates an expected call of SelectDueWebhookDelivery.
+func (mr *MockWebhookRepositoryMockRecorder) SelectDueWebhookDelivery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectDueWebhookDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).SelectDueWebhookDelivery), arg0, arg1, arg2)
+}
+
+// SelectWebhookDeliveryById mocks base method.
+func (m *MockWebhookRepository) SelectWebhookDeliveryById(arg0 context.Context, arg1 int64) (*entities.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectWebhookDeliveryById", arg0, arg1)
+	ret0, _ := ret[0].(*entities.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectWebhookDeliveryById indicates an expected call of SelectWebhookDeliveryById.
+func (mr *MockWebhookRepositoryMockRecorder) SelectWebhookDeliveryById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWebhookDeliveryById", reflect.TypeOf((*MockWebhookRepository)(nil).SelectWebhookDeliveryById), arg0, arg1)
+}
+
+// SelectWebhookDeliveryBySubscriptionId mocks base method.
+func (m *MockWebhookRepository) SelectWebhookDeliveryBySubscriptionId(arg0 context.Context, arg1 int64) (*[]entities.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectWebhookDeliveryBySubscriptionId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectWebhookDeliveryBySubscriptionId indicates an expected call of SelectWebhookDeliveryBySubscriptionId.
+func (mr *MockWebhookRepositoryMockRecorder) SelectWebhookDeliveryBySubscriptionId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWebhookDeliveryBySubscriptionId", reflect.TypeOf((*MockWebhookRepository)(nil).SelectWebhookDeliveryBySubscriptionId), arg0, arg1)
+}
+
+// SelectWebhookSubscriptionByClientKey mocks base method.
+func (m *MockWebhookRepository) SelectWebhookSubscriptionByClientKey(arg0 context.Context, arg1 string) (*[]entities.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectWebhookSubscriptionByClientKey", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectWebhookSubscriptionByClientKey indicates an expected call of SelectWebhookSubscriptionByClientKey.
+func (mr *MockWebhookRepositoryMockRecorder) SelectWebhookSubscriptionByClientKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWebhookSubscriptionByClientKey", reflect.TypeOf((*MockWebhookRepository)(nil).SelectWebhookSubscriptionByClientKey), arg0, arg1)
+}
+
+// SelectWebhookSubscriptionById mocks base method.
+func (m *MockWebhookRepository) SelectWebhookSubscriptionById(arg0 context.Context, arg1 int64) (*entities.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectWebhookSubscriptionById", arg0, arg1)
+	ret0, _ := ret[0].(*entities.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectWebhookSubscriptionById indicates an expected call of SelectWebhookSubscriptionById.
+func (mr *MockWebhookRepositoryMockRecorder) SelectWebhookSubscriptionById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWebhookSubscriptionById", reflect.TypeOf((*MockWebhookRepository)(nil).SelectWebhookSubscriptionById), arg0, arg1)
+}
+
+// SelectWebhookSubscriptionByTenantId mocks base method.
+func (m *MockWebhookRepository) SelectWebhookSubscriptionByTenantId(arg0 context.Context, arg1 int64) (*[]entities.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectWebhookSubscriptionByTenantId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectWebhookSubscriptionByTenantId indicates an expected call of SelectWebhookSubscriptionByTenantId.
+func (mr *MockWebhookRepositoryMockRecorder) SelectWebhookSubscriptionByTenantId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWebhookSubscriptionByTenantId", reflect.TypeOf((*MockWebhookRepository)(nil).SelectWebhookSubscriptionByTenantId), arg0, arg1)
+}
+
+// UpdateWebhookDelivery mocks base method.
+func (m *MockWebhookRepository) UpdateWebhookDelivery(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookDelivery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhookDelivery indicates an expected call of UpdateWebhookDelivery.
+func (mr *MockWebhookRepositoryMockRecorder) UpdateWebhookDelivery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).UpdateWebhookDelivery), arg0, arg1, arg2)
+}
+
+// UpdateWebhookSubscriptionStatus mocks base method.
+func (m *MockWebhookRepository) UpdateWebhookSubscriptionStatus(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 int64, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookSubscriptionStatus", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhookSubscriptionStatus indicates an expected call of UpdateWebhookSubscriptionStatus.
+func (mr *MockWebhookRepositoryMockRecorder) UpdateWebhookSubscriptionStatus(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookSubscriptionStatus", reflect.TypeOf((*MockWebhookRepository)(nil).UpdateWebhookSubscriptionStatus), arg0, arg1, arg2, arg3)
+}
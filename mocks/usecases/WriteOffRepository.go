@@ -0,0 +1,247 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sirait-kevin/BillingEngine/usecases (interfaces: WriteOffRepository)
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/sirait-kevin/BillingEngine/domain/entities"
+	interfaces "github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// MockWriteOffRepository is a mock of WriteOffRepository interface.
+type MockWriteOffRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriteOffRepositoryMockRecorder
+}
+
+// MockWriteOffRepositoryMockRecorder is the mock recorder for MockWriteOffRepository.
+type MockWriteOffRepositoryMockRecorder struct {
+	mock *MockWriteOffRepository
+}
+
+// NewMockWriteOffRepository creates a new mock instance.
+func NewMockWriteOffRepository(ctrl *gomock.Controller) *MockWriteOffRepository {
+	mock := &MockWriteOffRepository{ctrl: ctrl}
+	mock.recorder = &MockWriteOffRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriteOffRepository) EXPECT() *MockWriteOffRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BeginTx mocks base method.
+func (m *MockWriteOffRepository) BeginTx(arg0 context.Context) (interfaces.AtomicTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", arg0)
+	ret0, _ := ret[0].(interfaces.AtomicTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockWriteOffRepositoryMockRecorder) BeginTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*MockWriteOffRepository)(nil).BeginTx), arg0)
+}
+
+// CreateRecovery mocks base method.
+func (m *MockWriteOffRepository) CreateRecovery(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.Recovery) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecovery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecovery indicates an expected call of CreateRecovery.
+func (mr *MockWriteOffRepositoryMockRecorder) CreateRecovery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecovery", reflect.TypeOf((*MockWriteOffRepository)(nil).CreateRecovery), arg0, arg1, arg2)
+}
+
+// CreateWriteOff mocks base method.
+func (m *MockWriteOffRepository) CreateWriteOff(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 entities.WriteOff) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWriteOff", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWriteOff indicates an expected call of CreateWriteOff.
+func (mr *MockWriteOffRepositoryMockRecorder) CreateWriteOff(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWriteOff", reflect.TypeOf((*MockWriteOffRepository)(nil).CreateWriteOff), arg0, arg1, arg2)
+}
+
+// SelectLoanByReferenceId mocks base method.
+func (m *MockWriteOffRepository) SelectLoanByReferenceId(arg0 context.Context, arg1 string) (*entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanByReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanByReferenceId indicates an expected call of SelectLoanByReferenceId.
+func (mr *MockWriteOffRepositoryMockRecorder) SelectLoanByReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanByReferenceId", reflect.TypeOf((*MockWriteOffRepository)(nil).SelectLoanByReferenceId), arg0, arg1)
+}
+
+// SelectLoanCreatedBefore mocks base method.
+func (m *MockWriteOffRepository) SelectLoanCreatedBefore(arg0 context.Context, arg1 time.Time, arg2 int64, arg3 int) (*[]entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoanCreatedBefore", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*[]entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoanCreatedBefore indicates an expected call of SelectLoanCreatedBefore.
+func (mr *MockWriteOffRepositoryMockRecorder) SelectLoanCreatedBefore(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoanCreatedBefore", reflect.TypeOf((*MockWriteOffRepository)(nil).SelectLoanCreatedBefore), arg0, arg1, arg2, arg3)
+}
+
+// SelectOpenPromiseToPayByLoanId mocks base method.
+func (m *MockWriteOffRepository) SelectOpenPromiseToPayByLoanId(arg0 context.Context, arg1 int64) (*entities.PromiseToPay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectOpenPromiseToPayByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.PromiseToPay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectOpenPromiseToPayByLoanId indicates an expected call of SelectOpenPromiseToPayByLoanId.
+func (mr *MockWriteOffRepositoryMockRecorder) SelectOpenPromiseToPayByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectOpenPromiseToPayByLoanId", reflect.TypeOf((*MockWriteOffRepository)(nil).SelectOpenPromiseToPayByLoanId), arg0, arg1)
+}
+
+// SelectRecoveryByLoanId mocks base method.
+func (m *MockWriteOffRepository) SelectRecoveryByLoanId(arg0 context.Context, arg1 int64) (*[]entities.Recovery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRecoveryByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(*[]entities.Recovery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRecoveryByLoanId indicates an expected call of SelectRecoveryByLoanId.
+func (mr *MockWriteOffRepositoryMockRecorder) SelectRecoveryByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRecoveryByLoanId", reflect.TypeOf((*MockWriteOffRepository)(nil).SelectRecoveryByLoanId), arg0, arg1)
+}
+
+// SelectRecoveryByReferenceId mocks base method.
+func (m *MockWriteOffRepository) SelectRecoveryByReferenceId(arg0 context.Context, arg1 string) (*entities.Recovery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRecoveryByReferenceId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Recovery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRecoveryByReferenceId indicates an expected call of SelectRecoveryByReferenceId.
+func (mr *MockWriteOffRepositoryMockRecorder) SelectRecoveryByReferenceId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRecoveryByReferenceId", reflect.TypeOf((*MockWriteOffRepository)(nil).SelectRecoveryByReferenceId), arg0, arg1)
+}
+
+// SelectRepaymentCountByLoanId mocks base method.
+func (m *MockWriteOffRepository) SelectRepaymentCountByLoanId(arg0 context.Context, arg1 int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRepaymentCountByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRepaymentCountByLoanId indicates an expected call of SelectRepaymentCountByLoanId.
+func (mr *MockWriteOffRepositoryMockRecorder) SelectRepaymentCountByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRepaymentCountByLoanId", reflect.TypeOf((*MockWriteOffRepository)(nil).SelectRepaymentCountByLoanId), arg0, arg1)
+}
+
+// SelectRepaymentCountByLoanIds mocks base method.
+func (m *MockWriteOffRepository) SelectRepaymentCountByLoanIds(arg0 context.Context, arg1 []int64, arg2 time.Time) (map[int64]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRepaymentCountByLoanIds", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[int64]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRepaymentCountByLoanIds indicates an expected call of SelectRepaymentCountByLoanIds.
+func (mr *MockWriteOffRepositoryMockRecorder) SelectRepaymentCountByLoanIds(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRepaymentCountByLoanIds", reflect.TypeOf((*MockWriteOffRepository)(nil).SelectRepaymentCountByLoanIds), arg0, arg1, arg2)
+}
+
+// SelectTotalRepaymentAmountByLoanId mocks base method.
+func (m *MockWriteOffRepository) SelectTotalRepaymentAmountByLoanId(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectTotalRepaymentAmountByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectTotalRepaymentAmountByLoanId indicates an expected call of SelectTotalRepaymentAmountByLoanId.
+func (mr *MockWriteOffRepositoryMockRecorder) SelectTotalRepaymentAmountByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectTotalRepaymentAmountByLoanId", reflect.TypeOf((*MockWriteOffRepository)(nil).SelectTotalRepaymentAmountByLoanId), arg0, arg1)
+}
+
+// SelectWriteOffByLoanId mocks base method.
+func (m *MockWriteOffRepository) SelectWriteOffByLoanId(arg0 context.Context, arg1 int64) (*entities.WriteOff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectWriteOffByLoanId", arg0, arg1)
+	ret0, _ := ret[0].(*entities.WriteOff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectWriteOffByLoanId indicates an expected call of SelectWriteOffByLoanId.
+func (mr *MockWriteOffRepositoryMockRecorder) SelectWriteOffByLoanId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWriteOffByLoanId", reflect.TypeOf((*MockWriteOffRepository)(nil).SelectWriteOffByLoanId), arg0, arg1)
+}
+
+// SelectWriteOffByLoanIdForUpdate mocks base method.
+func (m *MockWriteOffRepository) SelectWriteOffByLoanIdForUpdate(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 int64) (*entities.WriteOff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectWriteOffByLoanIdForUpdate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entities.WriteOff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectWriteOffByLoanIdForUpdate indicates an expected call of SelectWriteOffByLoanIdForUpdate.
+func (mr *MockWriteOffRepositoryMockRecorder) SelectWriteOffByLoanIdForUpdate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWriteOffByLoanIdForUpdate", reflect.TypeOf((*MockWriteOffRepository)(nil).SelectWriteOffByLoanIdForUpdate), arg0, arg1, arg2)
+}
+
+// UpdateLoanStatusByReferenceId mocks base method.
+func (m *MockWriteOffRepository) UpdateLoanStatusByReferenceId(arg0 context.Context, arg1 interfaces.AtomicTransaction, arg2 string, arg3 entities.LoanStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLoanStatusByReferenceId", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLoanStatusByReferenceId indicates an expected call of UpdateLoanStatusByReferenceId.
+func (mr *MockWriteOffRepositoryMockRecorder) UpdateLoanStatusByReferenceId(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoanStatusByReferenceId", reflect.TypeOf((*MockWriteOffRepository)(nil).UpdateLoanStatusByReferenceId), arg0, arg1, arg2, arg3)
+}
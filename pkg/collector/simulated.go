@@ -0,0 +1,21 @@
+package collector
+
+import (
+	"context"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// SimulatedCollector stands in for a bank direct debit API. Every debit
+// succeeds unless the account number is listed in Failures, in which case
+// it fails with the listed reason code.
+type SimulatedCollector struct {
+	Failures map[string]string
+}
+
+func (c *SimulatedCollector) Debit(ctx context.Context, mandate entities.DebitMandate, instruction entities.DebitInstruction) (entities.DebitResult, error) {
+	if reasonCode, ok := c.Failures[mandate.AccountNumber]; ok {
+		return entities.DebitResult{Success: false, ReasonCode: reasonCode}, nil
+	}
+	return entities.DebitResult{Success: true}, nil
+}
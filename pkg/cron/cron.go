@@ -0,0 +1,204 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed five field cron expression:
+// minute hour day-of-month month day-of-week
+type Schedule struct {
+	expression string
+	minute     uint64
+	hour       uint64
+	dom        uint64
+	month      uint64
+	dow        uint64
+	domAny     bool
+	dowAny     bool
+}
+
+type bounds struct {
+	min, max int
+}
+
+var (
+	minuteBounds = bounds{0, 59}
+	hourBounds   = bounds{0, 23}
+	domBounds    = bounds{1, 31}
+	monthBounds  = bounds{1, 12}
+	dowBounds    = bounds{0, 7}
+)
+
+var descriptors = map[string]string{
+	"@yearly":  "0 0 1 1 *",
+	"@monthly": "0 0 1 * *",
+	"@weekly":  "0 0 * * 0",
+	"@daily":   "0 0 * * *",
+	"@hourly":  "0 * * * *",
+}
+
+// Parse parses a cron expression such as "30 6 * * 1-5" or one of the
+// descriptors @yearly, @monthly, @weekly, @daily and @hourly. Each field
+// accepts *, single values, ranges (a-b), lists (a,b) and steps (*/n, a-b/n).
+func Parse(expression string) (*Schedule, error) {
+	spec := strings.TrimSpace(expression)
+	if descriptor, ok := descriptors[spec]; ok {
+		spec = descriptor
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron: expected 5 fields, got %d in %q", len(fields), expression)
+	}
+
+	var (
+		s   = &Schedule{expression: expression}
+		err error
+	)
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, err
+	}
+	// both 0 and 7 stand for Sunday
+	if has(s.dow, 7) {
+		s.dow |= 1
+	}
+	s.domAny = fields[2] == "*"
+	s.dowAny = fields[4] == "*"
+
+	return s, nil
+}
+
+// MustParse is like Parse but panics on an invalid expression
+func MustParse(expression string) *Schedule {
+	s, err := Parse(expression)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (s *Schedule) String() string {
+	return s.expression
+}
+
+// Matches reports whether the minute t falls on is part of the schedule
+func (s *Schedule) Matches(t time.Time) bool {
+	if !has(s.minute, t.Minute()) || !has(s.hour, t.Hour()) || !has(s.month, int(t.Month())) {
+		return false
+	}
+
+	// like the classic cron, when both day fields are restricted either may match
+	domMatch := has(s.dom, t.Day())
+	dowMatch := has(s.dow, int(t.Weekday()))
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dowMatch
+	case s.dowAny:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
+
+// Next returns the first minute strictly after t that matches the schedule,
+// or the zero time if nothing matches within the next five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for next.Before(limit) {
+		if !has(s.month, int(next.Month())) {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !has(s.hour, next.Hour()) {
+			next = next.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if !s.Matches(next) {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+
+	return time.Time{}
+}
+
+func has(set uint64, value int) bool {
+	return set&(1<<uint(value)) != 0
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		bits, err := parsePart(part, b)
+		if err != nil {
+			return 0, err
+		}
+		set |= bits
+	}
+	return set, nil
+}
+
+func parsePart(part string, b bounds) (uint64, error) {
+	var (
+		rangePart = part
+		step      = 1
+		err       error
+	)
+
+	if i := strings.Index(part, "/"); i >= 0 {
+		rangePart = part[:i]
+		step, err = strconv.Atoi(part[i+1:])
+		if err != nil || step < 1 {
+			return 0, fmt.Errorf("cron: invalid step in %q", part)
+		}
+	}
+
+	start, end := b.min, b.max
+	if rangePart != "*" {
+		if i := strings.Index(rangePart, "-"); i >= 0 {
+			if start, err = strconv.Atoi(rangePart[:i]); err != nil {
+				return 0, fmt.Errorf("cron: invalid range in %q", part)
+			}
+			if end, err = strconv.Atoi(rangePart[i+1:]); err != nil {
+				return 0, fmt.Errorf("cron: invalid range in %q", part)
+			}
+		} else {
+			if start, err = strconv.Atoi(rangePart); err != nil {
+				return 0, fmt.Errorf("cron: invalid value %q", part)
+			}
+			end = start
+			if step > 1 {
+				end = b.max
+			}
+		}
+	}
+	if start < b.min || end > b.max || start > end {
+		return 0, fmt.Errorf("cron: %q is out of range %d-%d", part, b.min, b.max)
+	}
+
+	var set uint64
+	for v := start; v <= end; v += step {
+		set |= 1 << uint(v)
+	}
+	return set, nil
+}
@@ -0,0 +1,24 @@
+package event
+
+import (
+	"context"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// FanOut publishes every event to each of its publishers in turn. A failing
+// publisher does not keep the event from the ones after it, the first error
+// is returned once all of them were called.
+type FanOut []interfaces.EventPublisher
+
+func (f FanOut) Publish(ctx context.Context, event entities.Event) error {
+	var firstErr error
+	for _, publisher := range f {
+		err := publisher.Publish(ctx, event)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,44 @@
+package fakegateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+// Gateway imitates a payment gateway sending signed virtual account payment
+// notifications, for local runs and tests.
+type Gateway struct {
+	CallbackURL string
+	Secret      string
+	HTTPClient  *http.Client
+}
+
+func (g *Gateway) SendPayment(ctx context.Context, callback entities.PaymentCallback) (*http.Response, error) {
+	if callback.PaidAt.IsZero() {
+		callback.PaidAt = time.Now()
+	}
+
+	body, err := json.Marshal(callback)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.CallbackURL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Callback-Signature", helper.GenerateSignature(g.Secret, string(body)))
+
+	client := g.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
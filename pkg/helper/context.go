@@ -0,0 +1,57 @@
+package helper
+
+import "context"
+
+// GetClientKey returns the Client-Key that authenticated the current request
+func GetClientKey(ctx context.Context) string {
+	clientKey, _ := ctx.Value("client_key").(string)
+	return clientKey
+}
+
+// GetTenantId returns the tenant of the Client-Key that authenticated the
+// current request, 0 for the operator and outside of a request
+func GetTenantId(ctx context.Context) int64 {
+	tenantId, _ := ctx.Value("tenant_id").(int64)
+	return tenantId
+}
+
+// WithTenantId scopes what is done with ctx to the tenant
+func WithTenantId(ctx context.Context, tenantId int64) context.Context {
+	ctx = context.WithValue(ctx, "operator", false)
+	return context.WithValue(ctx, "tenant_id", tenantId)
+}
+
+// IsOperator reports whether ctx acts for the operator running the engine for
+// every tenant. A ctx with neither a tenant nor the operator sees no tenant.
+func IsOperator(ctx context.Context) bool {
+	operator, _ := ctx.Value("operator").(bool)
+	return operator
+}
+
+// WithOperator lets what is done with ctx see every tenant, as scheduled jobs,
+// payment gateway callbacks and the operator endpoints do
+func WithOperator(ctx context.Context) context.Context {
+	ctx = context.WithValue(ctx, "tenant_id", int64(0))
+	return context.WithValue(ctx, "operator", true)
+}
+
+// GetRequestId returns the X-Request-Id of the current request
+func GetRequestId(ctx context.Context) string {
+	requestId, _ := ctx.Value("request_id").(string)
+	return requestId
+}
+
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, "request_id", requestId)
+}
+
+// GetAdminUser returns the admin user running a command, the changes made
+// with ctx are audited as theirs
+func GetAdminUser(ctx context.Context) string {
+	adminUser, _ := ctx.Value("admin_user").(string)
+	return adminUser
+}
+
+func WithAdminUser(ctx context.Context, adminUser string) context.Context {
+	return context.WithValue(ctx, "admin_user", adminUser)
+}
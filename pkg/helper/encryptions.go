@@ -1,49 +1,60 @@
 package helper
 
 import (
-	"crypto/aes"
-	"crypto/cipher"
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"golang.org/x/crypto/bcrypt"
+
+	"github.com/sirait-kevin/BillingEngine/pkg/keyring"
 )
 
-var encryptionKey = []byte("a very very very very secret key") // 32 bytes
+var keys *keyring.Keyring
+
+// SetKeyring sets the keyring Encrypt and Decrypt use, it is loaded once at
+// startup with keyring.Load
+func SetKeyring(k *keyring.Keyring) {
+	keys = k
+}
 
-// Encrypt encrypts plain text string into cipher text
+// Encrypt seals plain text with the primary key of the keyring
 func Encrypt(plainText string) ([]byte, error) {
-	block, err := aes.NewCipher(encryptionKey)
-	if err != nil {
-		return nil, err
-	}
-	ciphertext := make([]byte, aes.BlockSize+len(plainText))
-	iv := ciphertext[:aes.BlockSize]
-	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
-		return nil, err
+	if keys == nil {
+		return nil, errors.New("encryption keyring is not set")
 	}
-	stream := cipher.NewCFBEncrypter(block, iv)
-	stream.XORKeyStream(ciphertext[aes.BlockSize:], []byte(plainText))
-	return ciphertext, nil
+	return keys.Seal([]byte(plainText))
 }
 
-// Decrypt decrypts cipher text into plain text string
+// Decrypt opens cipher text sealed with any key of the keyring
 func Decrypt(cipherText []byte) (string, error) {
-	block, err := aes.NewCipher(encryptionKey)
+	if keys == nil {
+		return "", errors.New("encryption keyring is not set")
+	}
+	plainText, err := keys.Open(cipherText)
 	if err != nil {
 		return "", err
 	}
-	if len(cipherText) < aes.BlockSize {
-		return "", err
+	return string(plainText), nil
+}
+
+// NeedsReencrypt tells whether the cipher text was sealed with a key that is
+// no longer the primary one
+func NeedsReencrypt(cipherText []byte) bool {
+	return keys != nil && keys.NeedsRewrap(cipherText)
+}
+
+// Reencrypt wraps the data key of the cipher text under the primary key
+func Reencrypt(cipherText []byte) ([]byte, error) {
+	if keys == nil {
+		return nil, errors.New("encryption keyring is not set")
 	}
-	iv := cipherText[:aes.BlockSize]
-	ciphertext := cipherText[aes.BlockSize:]
-	stream := cipher.NewCFBDecrypter(block, iv)
-	stream.XORKeyStream(ciphertext, ciphertext)
-	return string(ciphertext), nil
+	return keys.Rewrap(cipherText)
 }
 
 // HashPassword hashes plain text password
@@ -59,3 +70,25 @@ func GenerateSignature(key, data string) string {
 
 	return fmt.Sprintf("%x", string(h.Sum(nil)))
 }
+
+// SignedRequestData is what a client signs a request with: the X-Timestamp
+// and X-Nonce headers, the request URI and the raw body, one per line. A
+// nonce must pass IsValidNonce so no field can run into the next one.
+func SignedRequestData(timestamp, nonce, requestURI string, body []byte) string {
+	return timestamp + "\n" + nonce + "\n" + requestURI + "\n" + string(body)
+}
+
+// IsValidNonce reports whether the X-Nonce holds neither the line break
+// delimiting the signed fields nor the slash a request URI starts with
+func IsValidNonce(nonce string) bool {
+	return !strings.ContainsAny(nonce, "\n/")
+}
+
+// GenerateRandomString returns a hex encoded string of n random bytes
+func GenerateRandomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,50 @@
+package helper
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+// IsPublicIP tells whether the address can be reached on the internet, it is
+// none of the loopback, private, link-local, multicast or unspecified ones
+func IsPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+// IsPublicHttpsUrl tells whether the url is an https url whose host is not
+// local, so calling it can not reach the engine or its network. A host name
+// can still resolve to a local address, DialPublicOnly catches that.
+func IsPublicHttpsUrl(rawUrl string) bool {
+	parsedUrl, err := url.ParseRequestURI(rawUrl)
+	if err != nil || parsedUrl.Scheme != "https" || parsedUrl.User != nil {
+		return false
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(parsedUrl.Hostname()), ".")
+	if host == "" || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return IsPublicIP(ip)
+	}
+
+	return true
+}
+
+// DialPublicOnly is a net.Dialer Control refusing to connect to an address
+// that is not public, whatever host name resolved to it
+func DialPublicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicIP(ip) {
+		return errors.New("connecting to " + host + " is not allowed")
+	}
+	return nil
+}
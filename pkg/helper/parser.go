@@ -1,6 +1,7 @@
 package helper
 
 import (
+	"math"
 	"time"
 )
 
@@ -42,3 +43,31 @@ func YearsBetween(start, end time.Time) int {
 
 	return years
 }
+
+// DateLayout is the layout of every date only parameter, e.g. 2024-01-31
+const DateLayout = "2006-01-02"
+
+// MonthLayout is the layout of every month parameter, e.g. 2024-01
+const MonthLayout = "2006-01"
+
+// ParseDate parses a date only parameter in the given location
+func ParseDate(value string, loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation(DateLayout, value, loc)
+}
+
+// TruncateToDay returns midnight of the day t falls on, in the location of t
+func TruncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// DateIn returns midnight of the calendar date of t in the given location
+func DateIn(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// DaysBetween counts the calendar days from start to end, negative when end is before start
+func DaysBetween(start, end time.Time) int {
+	start = TruncateToDay(start)
+	end = TruncateToDay(end.In(start.Location()))
+	return int(math.Round(end.Sub(start).Hours() / 24))
+}
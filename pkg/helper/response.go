@@ -2,10 +2,12 @@ package helper
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"net/http"
 
 	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/pdf"
 )
 
 type Response struct {
@@ -29,3 +31,43 @@ func JSON(w http.ResponseWriter, ctx context.Context, data interface{}, err erro
 	w.WriteHeader(responseCode)
 	json.NewEncoder(w).Encode(Response{Code: responseCode, Message: responseMsg, Data: data})
 }
+
+// CSVTable is implemented by responses that can be downloaded as CSV
+type CSVTable interface {
+	CSVHeader() []string
+	CSVRows() [][]string
+}
+
+// CSV writes the table as a CSV attachment, errors are still written as JSON
+func CSV(w http.ResponseWriter, ctx context.Context, filename string, table CSVTable, err error) {
+	if err != nil {
+		JSON(w, ctx, nil, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.Write(table.CSVHeader())
+	writer.WriteAll(table.CSVRows())
+}
+
+// PDFDocument is implemented by responses that can be downloaded as PDF
+type PDFDocument interface {
+	PDFLines() []string
+}
+
+// PDF writes the document as a PDF attachment, errors are still written as JSON
+func PDF(w http.ResponseWriter, ctx context.Context, filename string, document PDFDocument, err error) {
+	if err != nil {
+		JSON(w, ctx, nil, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(pdf.Render(document.PDFLines()))
+}
@@ -0,0 +1,190 @@
+package keyring
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// version is the first byte of every sealed value, it leaves room to change
+// the format later without guessing what a stored value is
+const version byte = 1
+
+// KeySize is the size of the key encryption keys and of the data keys, both
+// are AES-256 keys
+const KeySize = 32
+
+var ErrMalformed = errors.New("keyring: malformed ciphertext")
+
+// Keyring seals values with envelope encryption. Every value gets its own
+// random data key that encrypts it with AES-GCM, the data key is then wrapped
+// with AES-GCM under the primary key of the keyring. The id of that key is
+// stored in the clear with the value so older keys keep opening what they
+// sealed after a rotation, until the values are rewrapped.
+//
+// A sealed value is laid out as
+//
+//	version | key id length | key id | wrapped data key | data nonce | ciphertext
+type Keyring struct {
+	primary string
+	keys    map[string][]byte
+}
+
+// New returns a keyring sealing with the primary key and opening with any of the keys
+func New(primary string, keys map[string][]byte) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("keyring: no keys")
+	}
+	if _, ok := keys[primary]; !ok {
+		return nil, fmt.Errorf("keyring: primary key %q is not in the keyring", primary)
+	}
+	k := &Keyring{primary: primary, keys: make(map[string][]byte, len(keys))}
+	for id, key := range keys {
+		if id == "" || len(id) > 255 {
+			return nil, fmt.Errorf("keyring: key id %q must be 1 to 255 bytes", id)
+		}
+		if len(key) != KeySize {
+			return nil, fmt.Errorf("keyring: key %q must be %d bytes", id, KeySize)
+		}
+		k.keys[id] = append([]byte(nil), key...)
+	}
+	return k, nil
+}
+
+// Primary is the id of the key new values are sealed with
+func (k *Keyring) Primary() string {
+	return k.primary
+}
+
+// Seal encrypts the plain text under a new data key wrapped with the primary key
+func (k *Keyring) Seal(plaintext []byte) ([]byte, error) {
+	dataKey := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+	data, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, data.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	header, wrapped, err := k.wrap(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	sealed := append(header, wrapped...)
+	sealed = append(sealed, nonce...)
+	return data.Seal(sealed, nonce, plaintext, nil), nil
+}
+
+// Open decrypts a value sealed with any key of the keyring
+func (k *Keyring) Open(sealed []byte) ([]byte, error) {
+	dataKey, body, err := k.unwrap(sealed)
+	if err != nil {
+		return nil, err
+	}
+	data, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) < data.NonceSize() {
+		return nil, ErrMalformed
+	}
+	plaintext, err := data.Open(nil, body[:data.NonceSize()], body[data.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("keyring: can not open value: %w", err)
+	}
+	return plaintext, nil
+}
+
+// NeedsRewrap tells whether the value was sealed with a key other than the primary one
+func (k *Keyring) NeedsRewrap(sealed []byte) bool {
+	id, _, err := parseHeader(sealed)
+	return err == nil && id != k.primary
+}
+
+// Rewrap wraps the data key of the value again under the primary key. The
+// data itself is not decrypted, only the data key is.
+func (k *Keyring) Rewrap(sealed []byte) ([]byte, error) {
+	dataKey, body, err := k.unwrap(sealed)
+	if err != nil {
+		return nil, err
+	}
+	header, wrapped, err := k.wrap(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return append(append(header, wrapped...), body...), nil
+}
+
+// KeyIdOf returns the id of the key the value was sealed with
+func KeyIdOf(sealed []byte) (string, error) {
+	id, _, err := parseHeader(sealed)
+	return id, err
+}
+
+// wrap encrypts the data key under the primary key, the header is
+// authenticated with it so the key id of a value can not be swapped
+func (k *Keyring) wrap(dataKey []byte) (header, wrapped []byte, err error) {
+	header = append([]byte{version, byte(len(k.primary))}, k.primary...)
+	wrapper, err := newGCM(k.keys[k.primary])
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce := make([]byte, wrapper.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, err
+	}
+	return header, wrapper.Seal(nonce, nonce, dataKey, header), nil
+}
+
+// unwrap returns the data key of the value and what follows the wrapped data key
+func (k *Keyring) unwrap(sealed []byte) (dataKey, body []byte, err error) {
+	id, rest, err := parseHeader(sealed)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, ok := k.keys[id]
+	if !ok {
+		return nil, nil, fmt.Errorf("keyring: key %q is not in the keyring", id)
+	}
+	wrapper, err := newGCM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	wrappedSize := wrapper.NonceSize() + KeySize + wrapper.Overhead()
+	if len(rest) < wrappedSize {
+		return nil, nil, ErrMalformed
+	}
+	header := sealed[:len(sealed)-len(rest)]
+	dataKey, err = wrapper.Open(nil, rest[:wrapper.NonceSize()], rest[wrapper.NonceSize():wrappedSize], header)
+	if err != nil {
+		return nil, nil, fmt.Errorf("keyring: can not unwrap data key: %w", err)
+	}
+	return dataKey, rest[wrappedSize:], nil
+}
+
+func parseHeader(sealed []byte) (id string, rest []byte, err error) {
+	if len(sealed) < 2 || sealed[0] != version {
+		return "", nil, ErrMalformed
+	}
+	end := 2 + int(sealed[1])
+	if len(sealed) < end {
+		return "", nil, ErrMalformed
+	}
+	return string(sealed[2:end]), sealed[end:], nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
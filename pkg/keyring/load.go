@@ -0,0 +1,75 @@
+package keyring
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// File is the JSON a keyring file holds, the keys are base64 encoded
+//
+//	{"primary": "2024-10", "keys": {"2024-10": "...", "2024-04": "..."}}
+type File struct {
+	Primary string            `json:"primary"`
+	Keys    map[string]string `json:"keys"`
+}
+
+// Load reads the keyring from the file ENCRYPTION_KEYRING_FILE points to,
+// or from ENCRYPTION_KEYS when it is not set
+func Load() (*Keyring, error) {
+	if path := os.Getenv("ENCRYPTION_KEYRING_FILE"); path != "" {
+		return FromFile(path)
+	}
+	return FromEnv()
+}
+
+func FromFile(path string) (*Keyring, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file File
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("keyring: can not read %s: %w", path, err)
+	}
+
+	keys := make(map[string][]byte, len(file.Keys))
+	for id, encoded := range file.Keys {
+		keys[id], err = base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("keyring: key %q is not base64: %w", id, err)
+		}
+	}
+	return New(file.Primary, keys)
+}
+
+// FromEnv reads ENCRYPTION_KEYS, a comma separated list of id:base64 keys.
+// New values are sealed with ENCRYPTION_PRIMARY_KEY, or the first key listed
+// when it is not set, so a rotation adds the new key in front.
+func FromEnv() (*Keyring, error) {
+	value := strings.TrimSpace(os.Getenv("ENCRYPTION_KEYS"))
+	if value == "" {
+		return nil, errors.New("keyring: neither ENCRYPTION_KEYRING_FILE nor ENCRYPTION_KEYS is set")
+	}
+
+	primary := os.Getenv("ENCRYPTION_PRIMARY_KEY")
+	keys := make(map[string][]byte)
+	for _, entry := range strings.Split(value, ",") {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("keyring: %q is not an id:base64 key", entry)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("keyring: key %q is not base64: %w", id, err)
+		}
+		keys[id] = key
+		if primary == "" {
+			primary = id
+		}
+	}
+	return New(primary, keys)
+}
@@ -0,0 +1,53 @@
+package nonce
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps the nonces in the process. It is enough for a single
+// instance, several instances behind a load balancer need a shared store.
+// The expired nonces are dropped once a minute as new ones come in.
+type MemoryStore struct {
+	mu        sync.Mutex
+	nonces    map[string]time.Time
+	nextPurge time.Time
+}
+
+func (s *MemoryStore) UseNonce(ctx context.Context, clientKey, nonce string, now, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.nonces == nil {
+		s.nonces = make(map[string]time.Time)
+	}
+	if !now.Before(s.nextPurge) {
+		s.purge(now)
+		s.nextPurge = now.Add(time.Minute)
+	}
+	key := clientKey + "\x00" + nonce
+	if until, ok := s.nonces[key]; ok && now.Before(until) {
+		return false, nil
+	}
+	s.nonces[key] = expiresAt
+	return true, nil
+}
+
+func (s *MemoryStore) PurgeExpiredNonces(ctx context.Context, now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.purge(now), nil
+}
+
+func (s *MemoryStore) purge(now time.Time) int64 {
+	var purged int64
+	for key, until := range s.nonces {
+		if !now.Before(until) {
+			delete(s.nonces, key)
+			purged++
+		}
+	}
+	return purged
+}
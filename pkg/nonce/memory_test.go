@@ -0,0 +1,67 @@
+package nonce
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore_UseNonce(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	type args struct {
+		clientKey string
+		nonce     string
+		now       time.Time
+	}
+	// every case runs against the store left by the previous ones
+	store := &MemoryStore{}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "first use",
+			args: args{clientKey: "client1", nonce: "nonce1", now: now},
+			want: true,
+		},
+		{
+			name: "reused before it expires",
+			args: args{clientKey: "client1", nonce: "nonce1", now: now.Add(4 * time.Minute)},
+			want: false,
+		},
+		{
+			name: "same nonce of another client",
+			args: args{clientKey: "client2", nonce: "nonce1", now: now.Add(4 * time.Minute)},
+			want: true,
+		},
+		{
+			name: "reused once it expired",
+			args: args{clientKey: "client1", nonce: "nonce1", now: now.Add(5 * time.Minute)},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.UseNonce(context.Background(), tt.args.clientKey, tt.args.nonce, tt.args.now, tt.args.now.Add(5*time.Minute))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMemoryStore_PurgeExpiredNonces(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	store := &MemoryStore{}
+	store.UseNonce(context.Background(), "client1", "nonce1", now, now.Add(time.Minute))
+	store.UseNonce(context.Background(), "client1", "nonce2", now, now.Add(5*time.Minute))
+
+	purged, err := store.PurgeExpiredNonces(context.Background(), now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, purged)
+
+	used, _ := store.UseNonce(context.Background(), "client1", "nonce2", now.Add(time.Minute), now.Add(6*time.Minute))
+	assert.False(t, used)
+}
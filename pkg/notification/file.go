@@ -0,0 +1,42 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// FileNotifier appends every message as a line of JSON to a file instead of
+// sending it, so local runs can be followed with tail -f.
+type FileNotifier struct {
+	Name entities.NotificationChannel
+	Path string
+
+	mu sync.Mutex
+}
+
+func (n *FileNotifier) Channel() entities.NotificationChannel {
+	return n.Name
+}
+
+func (n *FileNotifier) Send(ctx context.Context, message entities.NotificationMessage) error {
+	line, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.OpenFile(n.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,40 @@
+package notification
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MemoryNotifier keeps the messages it is given instead of sending them, for
+// tests and local runs. Messages to the users listed in Failures fail.
+type MemoryNotifier struct {
+	Name     entities.NotificationChannel
+	Failures map[int64]string
+
+	mu       sync.Mutex
+	messages []entities.NotificationMessage
+}
+
+func (n *MemoryNotifier) Channel() entities.NotificationChannel {
+	return n.Name
+}
+
+func (n *MemoryNotifier) Send(ctx context.Context, message entities.NotificationMessage) error {
+	if reason, ok := n.Failures[message.UserId]; ok {
+		return errNotDelivered(reason)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+// Messages returns a copy of the messages sent so far
+func (n *MemoryNotifier) Messages() []entities.NotificationMessage {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]entities.NotificationMessage(nil), n.messages...)
+}
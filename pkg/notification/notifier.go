@@ -0,0 +1,21 @@
+package notification
+
+import (
+	"fmt"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// NewRegistry indexes notifiers by their channel
+func NewRegistry(notifiers ...interfaces.Notifier) map[entities.NotificationChannel]interfaces.Notifier {
+	registry := make(map[entities.NotificationChannel]interfaces.Notifier, len(notifiers))
+	for _, n := range notifiers {
+		registry[n.Channel()] = n
+	}
+	return registry
+}
+
+func errNotDelivered(reason string) error {
+	return fmt.Errorf("message not delivered: %s", reason)
+}
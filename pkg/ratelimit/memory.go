@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// MemoryStore keeps the token buckets in the process, every instance behind
+// a load balancer then limits on its own. The buckets that filled up again
+// are dropped once a minute as requests come in.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	nextPurge time.Time
+}
+
+type bucket struct {
+	tokens    float64
+	burst     float64
+	perSecond float64
+	updatedAt time.Time
+}
+
+func (s *MemoryStore) TakeToken(ctx context.Context, key string, limit entities.RateLimit, now time.Time) (*entities.RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.buckets == nil {
+		s.buckets = make(map[string]*bucket)
+	}
+	if !now.Before(s.nextPurge) {
+		s.purge(now)
+		s.nextPurge = now.Add(time.Minute)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updatedAt: now}
+		s.buckets[key] = b
+	}
+	b.burst = float64(limit.Burst)
+	b.perSecond = float64(limit.RequestsPerMinute) / 60
+	b.refill(now)
+
+	if b.tokens < 1 {
+		return &entities.RateLimitResult{
+			RetryAfter: time.Duration((1 - b.tokens) / b.perSecond * float64(time.Second)),
+		}, nil
+	}
+	b.tokens--
+	return &entities.RateLimitResult{Allowed: true, Remaining: int(math.Floor(b.tokens))}, nil
+}
+
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.updatedAt).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed*b.perSecond)
+		b.updatedAt = now
+	}
+}
+
+func (s *MemoryStore) purge(now time.Time) {
+	for key, b := range s.buckets {
+		b.refill(now)
+		if b.tokens >= b.burst {
+			delete(s.buckets, key)
+		}
+	}
+}
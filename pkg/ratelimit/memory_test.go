@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+func TestMemoryStore_TakeToken(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	limit := entities.RateLimit{RequestsPerMinute: 60, Burst: 2}
+	type args struct {
+		key string
+		now time.Time
+	}
+	// every case runs against the buckets left by the previous ones
+	store := &MemoryStore{}
+	tests := []struct {
+		name string
+		args args
+		want entities.RateLimitResult
+	}{
+		{
+			name: "first request of the burst",
+			args: args{key: "client1 GET /payment/history", now: now},
+			want: entities.RateLimitResult{Allowed: true, Remaining: 1},
+		},
+		{
+			name: "last request of the burst",
+			args: args{key: "client1 GET /payment/history", now: now},
+			want: entities.RateLimitResult{Allowed: true, Remaining: 0},
+		},
+		{
+			name: "burst used up",
+			args: args{key: "client1 GET /payment/history", now: now.Add(500 * time.Millisecond)},
+			want: entities.RateLimitResult{RetryAfter: 500 * time.Millisecond},
+		},
+		{
+			name: "other route has its own bucket",
+			args: args{key: "client1 POST /payment", now: now.Add(500 * time.Millisecond)},
+			want: entities.RateLimitResult{Allowed: true, Remaining: 1},
+		},
+		{
+			name: "token refilled after a second",
+			args: args{key: "client1 GET /payment/history", now: now.Add(time.Second)},
+			want: entities.RateLimitResult{Allowed: true, Remaining: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.TakeToken(context.Background(), tt.args.key, limit, tt.args.now)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, *got)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package settlement
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+// Field is the position of a value in a fixed width line, Start is the
+// zero based offset and Length the number of characters
+type Field struct {
+	Start  int
+	Length int
+}
+
+// FixedWidthParser reads the fixed width files most banks still send. Only
+// lines starting with DetailPrefix are payments, header and trailer lines
+// are skipped. A field with no length is not in the file.
+type FixedWidthParser struct {
+	Name                 string
+	DetailPrefix         string
+	TransactionId        Field
+	LoanReferenceId      Field
+	VirtualAccountNumber Field
+	Amount               Field
+	PaidAt               Field
+	PaidAtLayout         string
+	Location             *time.Location
+}
+
+func (p FixedWidthParser) Format() string {
+	return p.Name
+}
+
+func (p FixedWidthParser) Parse(r io.Reader) ([]entities.SettlementLine, error) {
+	var lines []entities.SettlementLine
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" || !strings.HasPrefix(text, p.DetailPrefix) {
+			continue
+		}
+
+		line, errMessage := newLine(lineNumber, p.value(text, p.TransactionId), p.value(text, p.LoanReferenceId),
+			p.value(text, p.VirtualAccountNumber), p.value(text, p.Amount))
+
+		location := p.Location
+		if location == nil {
+			location = time.Local
+		}
+		paidAt, err := time.ParseInLocation(p.PaidAtLayout, p.value(text, p.PaidAt), location)
+		if err != nil {
+			errMessage = append(errMessage, "paid_at must be formatted as "+p.PaidAtLayout)
+		}
+		line.PaidAt = paidAt
+		line.Error = strings.Join(errMessage, "; ")
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+func (p FixedWidthParser) value(text string, field Field) string {
+	if field.Length == 0 || field.Start >= len(text) {
+		return ""
+	}
+	end := field.Start + field.Length
+	if end > len(text) {
+		end = len(text)
+	}
+	return strings.TrimSpace(text[field.Start:end])
+}
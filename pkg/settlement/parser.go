@@ -0,0 +1,127 @@
+package settlement
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+// NewRegistry indexes parsers by their format
+func NewRegistry(parsers ...interfaces.SettlementParser) map[string]interfaces.SettlementParser {
+	registry := make(map[string]interfaces.SettlementParser, len(parsers))
+	for _, p := range parsers {
+		registry[p.Format()] = p
+	}
+	return registry
+}
+
+var csvRequiredColumns = []string{"transaction_id", "amount", "paid_at"}
+
+// CSVParser reads settlement files with a header line. Every line needs a
+// transaction_id, amount and paid_at, and a loan_reference_id or a
+// virtual_account_number to match it to a loan.
+type CSVParser struct{}
+
+func (p CSVParser) Format() string {
+	return "csv"
+}
+
+func (p CSVParser) Parse(r io.Reader) ([]entities.SettlementLine, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, errors.New("file is empty")
+		}
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	var missing []string
+	for _, name := range csvRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, errors.New("missing columns " + strings.Join(missing, ", "))
+	}
+
+	var lines []entities.SettlementLine
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return lines, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		lineNumber, _ := reader.FieldPos(0)
+		value := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(fields) {
+				return ""
+			}
+			return strings.TrimSpace(fields[i])
+		}
+
+		line, errMessage := newLine(lineNumber, value("transaction_id"), value("loan_reference_id"),
+			value("virtual_account_number"), value("amount"))
+		paidAt, err := parseCSVTime(value("paid_at"))
+		if err != nil {
+			errMessage = append(errMessage, err.Error())
+		}
+		line.PaidAt = paidAt
+		line.Error = strings.Join(errMessage, "; ")
+		lines = append(lines, line)
+	}
+}
+
+// parseCSVTime accepts RFC 3339 timestamps and plain dates in local time
+func parseCSVTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := helper.ParseDate(value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, errors.New("paid_at " + strconv.Quote(value) + " must be formatted as RFC 3339 or " + helper.DateLayout)
+}
+
+func newLine(lineNumber int, transactionId, loanReferenceId, virtualAccountNumber, amount string) (entities.SettlementLine, []string) {
+	var errMessage []string
+
+	line := entities.SettlementLine{
+		LineNumber:           lineNumber,
+		TransactionId:        transactionId,
+		LoanReferenceId:      loanReferenceId,
+		VirtualAccountNumber: virtualAccountNumber,
+	}
+	if transactionId == "" {
+		errMessage = append(errMessage, "transaction id can not be empty")
+	}
+	if loanReferenceId == "" && virtualAccountNumber == "" {
+		errMessage = append(errMessage, "loan reference id or virtual account number is required")
+	}
+
+	var err error
+	line.Amount, err = strconv.ParseInt(amount, 10, 64)
+	if err != nil {
+		errMessage = append(errMessage, "amount is not a number")
+	}
+
+	return line, errMessage
+}
@@ -0,0 +1,63 @@
+package virtualaccount
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// PrefixGenerator builds virtual account numbers the way most banks do:
+// a company prefix assigned by the bank, the zero padded loan id and a
+// Luhn check digit.
+type PrefixGenerator struct {
+	Bank   string
+	Prefix string
+	Digits int
+}
+
+func (g PrefixGenerator) BankCode() string {
+	return g.Bank
+}
+
+func (g PrefixGenerator) Generate(ctx context.Context, loan entities.Loan) (string, error) {
+	if loan.Id < 1 {
+		return "", fmt.Errorf("loan id is invalid")
+	}
+
+	loanPart := strconv.FormatInt(loan.Id, 10)
+	if len(loanPart) > g.Digits {
+		return "", fmt.Errorf("loan id %d does not fit in %d digits", loan.Id, g.Digits)
+	}
+
+	number := fmt.Sprintf("%s%0*d", g.Prefix, g.Digits, loan.Id)
+	return number + strconv.Itoa(luhnCheckDigit(number)), nil
+}
+
+// NewRegistry indexes generators by their bank code
+func NewRegistry(generators ...interfaces.VirtualAccountGenerator) map[string]interfaces.VirtualAccountGenerator {
+	registry := make(map[string]interfaces.VirtualAccountGenerator, len(generators))
+	for _, g := range generators {
+		registry[g.BankCode()] = g
+	}
+	return registry
+}
+
+func luhnCheckDigit(number string) int {
+	sum := 0
+	double := true
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return (10 - sum%10) % 10
+}
@@ -0,0 +1,244 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const (
+	insertApiClientQuery = `INSERT INTO api_clients
+			(tenant_id, client_key, name, scopes, requests_per_minute, burst, secret, is_active)
+			VALUES(?,?,?,?,?,?,?,?);`
+
+	selectApiClientColumns = `SELECT id, client_key, name, scopes, requests_per_minute, burst, secret, previous_secret, previous_secret_expires_at,
+			is_active, created_at, updated_at, tenant_id
+			FROM api_clients `
+
+	selectApiClientByClientKeyQuery = selectApiClientColumns + `WHERE client_key = ? AND ` + tenantFilter + `;`
+
+	selectApiClientQuery = selectApiClientColumns + `WHERE ` + tenantFilter + ` ORDER BY id ASC;`
+
+	selectApiClientByClientKeyForUpdateQuery = selectApiClientColumns + `WHERE client_key = ? FOR UPDATE;`
+
+	updateApiClientStatusQuery = `UPDATE api_clients SET is_active = ? WHERE client_key = ?;`
+
+	updateApiClientRateLimitQuery = `UPDATE api_clients SET requests_per_minute = ?, burst = ? WHERE client_key = ?;`
+
+	updateApiClientSecretQuery = `UPDATE api_clients SET secret = ?, previous_secret = ?, previous_secret_expires_at = ?
+			WHERE client_key = ?;`
+)
+
+func (r *DBRepository) CreateApiClient(ctx context.Context, tx interfaces.AtomicTransaction, client entities.ApiClient) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting api client into database: ", client.ClientKey)
+	var id int64
+
+	secret, err := helper.Encrypt(client.Secret)
+	if err != nil {
+		logger.Error("Error encrypting api client secret: ", err)
+		return 0, err
+	}
+
+	client.TenantId = tenantIdOf(ctx, client.TenantId)
+	args := []interface{}{client.TenantId, client.ClientKey, client.Name, joinScopes(client.Scopes), client.RateLimit.RequestsPerMinute,
+		client.RateLimit.Burst, secret, client.IsActive}
+	err = r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		result, err := tx.ExecContext(ctx, insertApiClientQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		id, err = result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		client.Id = id
+		return []auditChange{{
+			Action:     entities.AuditApiClientCreated,
+			EntityType: "api_client",
+			EntityId:   id,
+			TenantId:   client.TenantId,
+			After:      auditApiClient(client),
+		}}, nil
+	})
+	if err != nil {
+		logger.Error("Error creating api client: ", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// SelectApiClientByClientKey returns the client with its secrets decrypted
+func (r *DBRepository) SelectApiClientByClientKey(ctx context.Context, clientKey string) (*entities.ApiClient, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select api client by client key: ", clientKey)
+	var (
+		err    error
+		client apiClientTable
+	)
+
+	err = r.DB.GetContext(ctx, &client, selectApiClientByClientKeyQuery, withTenant(ctx, clientKey)...)
+	if err != nil {
+		logger.Error("SelectApiClientByClientKey: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	resp, err := client.toEntities()
+	if err != nil {
+		logger.Error("Error decrypting api client secret: ", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SelectApiClients lists the clients of the tenant without their secrets
+func (r *DBRepository) SelectApiClients(ctx context.Context) (*[]entities.ApiClient, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select api clients")
+	var (
+		err     error
+		clients = []apiClientTable{}
+	)
+
+	err = r.DB.SelectContext(ctx, &clients, selectApiClientQuery, withTenant(ctx)...)
+	if err != nil {
+		logger.Error("SelectApiClients: ", err)
+		return nil, err
+	}
+
+	resp := make([]entities.ApiClient, len(clients))
+	for i, c := range clients {
+		c.Secret, c.PreviousSecret = nil, nil
+		client, err := c.toEntities()
+		if err != nil {
+			return nil, err
+		}
+		resp[i] = *client
+	}
+
+	return &resp, nil
+}
+
+func (r *DBRepository) UpdateApiClientStatus(ctx context.Context, tx interfaces.AtomicTransaction, clientKey string, isActive bool) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Update api client status: ", clientKey, isActive)
+
+	err := r.updateApiClient(ctx, tx, clientKey, entities.AuditApiClientStatusUpdated, updateApiClientStatusQuery, isActive, clientKey)
+	if err != nil {
+		logger.Error("Error UpdateApiClientStatus: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *DBRepository) UpdateApiClientRateLimit(ctx context.Context, tx interfaces.AtomicTransaction, clientKey string, limit entities.RateLimit) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Update api client rate limit: ", clientKey, limit)
+
+	err := r.updateApiClient(ctx, tx, clientKey, entities.AuditApiClientRateLimitUpdated, updateApiClientRateLimitQuery,
+		limit.RequestsPerMinute, limit.Burst, clientKey)
+	if err != nil {
+		logger.Error("Error UpdateApiClientRateLimit: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateApiClientSecret stores the secrets of the client, an empty previous
+// secret is stored as NULL
+func (r *DBRepository) UpdateApiClientSecret(ctx context.Context, tx interfaces.AtomicTransaction, client entities.ApiClient) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Update api client secret: ", client.ClientKey)
+
+	secret, err := helper.Encrypt(client.Secret)
+	if err != nil {
+		logger.Error("Error encrypting api client secret: ", err)
+		return err
+	}
+	var previousSecret []byte
+	if client.PreviousSecret != "" {
+		previousSecret, err = helper.Encrypt(client.PreviousSecret)
+		if err != nil {
+			logger.Error("Error encrypting api client secret: ", err)
+			return err
+		}
+	}
+
+	err = r.updateApiClient(ctx, tx, client.ClientKey, entities.AuditApiClientSecretRotated, updateApiClientSecretQuery,
+		secret, previousSecret, nullTime(client.PreviousSecretExpiresAt), client.ClientKey)
+	if err != nil {
+		logger.Error("Error UpdateApiClientSecret: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// updateApiClient runs an update of the client and audits it with the
+// client before and after, without its secrets
+func (r *DBRepository) updateApiClient(ctx context.Context, tx interfaces.AtomicTransaction, clientKey string,
+	action entities.AuditAction, query string, args ...interface{}) error {
+	return r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		before, err := selectApiClientForUpdate(ctx, tx, clientKey)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		after, err := selectApiClientForUpdate(ctx, tx, clientKey)
+		if err != nil {
+			return nil, err
+		}
+		return []auditChange{{
+			Action:     action,
+			EntityType: "api_client",
+			EntityId:   after.Id,
+			TenantId:   after.TenantId,
+			Before:     auditApiClient(*before),
+			After:      auditApiClient(*after),
+		}}, nil
+	})
+}
+
+// selectApiClientForUpdate locks the client, its secrets are left encrypted
+// and out of the result
+func selectApiClientForUpdate(ctx context.Context, tx sqlx.ExtContext, clientKey string) (*entities.ApiClient, error) {
+	var client apiClientTable
+
+	err := sqlx.GetContext(ctx, tx, &client, selectApiClientByClientKeyForUpdateQuery, clientKey)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+	client.Secret, client.PreviousSecret = nil, nil
+
+	return client.toEntities()
+}
+
+func joinScopes(scopes []entities.ApiScope) string {
+	values := make([]string, len(scopes))
+	for i, scope := range scopes {
+		values[i] = string(scope)
+	}
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,327 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const (
+	// the head is locked by every change until its transaction ends, so the
+	// entries are chained one after the other with ids without gaps
+	selectAuditHeadForUpdateQuery = `SELECT last_id, last_hash FROM audit_log_head WHERE id = 1 FOR UPDATE;`
+
+	selectAuditHeadQuery = `SELECT last_id, last_hash FROM audit_log_head WHERE id = 1;`
+
+	updateAuditHeadQuery = `UPDATE audit_log_head SET last_id = ?, last_hash = ? WHERE id = 1;`
+
+	insertAuditEntryQuery = `INSERT INTO audit_log
+			(id, tenant_id, actor_type, actor, action, entity_type, entity_id, loan_id, user_id, before_json, after_json,
+			request_id, previous_hash, hash, created_at)
+			VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?);`
+
+	selectAuditEntryColumns = `SELECT id, tenant_id, actor_type, actor, action, entity_type, entity_id, loan_id, user_id, before_json,
+			after_json, request_id, previous_hash, hash, created_at
+			FROM audit_log `
+
+	selectAuditEntryByLoanIdQuery = selectAuditEntryColumns + `WHERE loan_id = ? AND ` + tenantFilter + ` ORDER BY id DESC LIMIT ?;`
+
+	selectAuditEntryByUserIdQuery = selectAuditEntryColumns + `WHERE user_id = ? AND ` + tenantFilter + ` ORDER BY id DESC LIMIT ?;`
+
+	// the chain runs through every tenant, it is verified as a whole
+	selectAuditEntryAfterIdQuery = selectAuditEntryColumns + `WHERE id > ? ORDER BY id ASC LIMIT ?;`
+
+	selectLoanOwnerQuery = `SELECT user_id, tenant_id FROM loans WHERE id = ?;`
+)
+
+// auditChange is a change a repository method made, appended to the audit
+// log in the transaction of the change by audited. Before and After are
+// marshalled to JSON, nil is stored as NULL.
+type auditChange struct {
+	Action     entities.AuditAction
+	EntityType string
+	EntityId   int64
+	TenantId   int64
+	LoanId     int64
+	UserId     int64
+	Before     interface{}
+	After      interface{}
+}
+
+// audited runs the change in the transaction and appends what it returns to
+// the audit log in the same transaction. A transaction is opened, and
+// committed, when the caller has none, so a change is never made without
+// its entry.
+func (r *DBRepository) audited(ctx context.Context, tx interfaces.AtomicTransaction,
+	change func(tx sqlx.ExtContext) ([]auditChange, error)) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+
+	ownTx := tx == nil
+	if ownTx {
+		var err error
+		tx, err = r.BeginTx(ctx)
+		if err != nil {
+			logger.Error("Error opening audited transaction: ", err)
+			return err
+		}
+		defer tx.Rollback()
+	}
+	ext, ok := tx.(sqlx.ExtContext)
+	if !ok {
+		return errors.New("audited change needs a transaction opened with BeginTx")
+	}
+
+	changes, err := change(ext)
+	if err != nil {
+		return err
+	}
+	for _, c := range changes {
+		if err = r.appendAudit(ctx, ext, c); err != nil {
+			logger.Error("Error appending audit entry: ", err)
+			return err
+		}
+	}
+
+	if ownTx {
+		return tx.Commit()
+	}
+	return nil
+}
+
+func (r *DBRepository) appendAudit(ctx context.Context, tx sqlx.ExtContext, change auditChange) error {
+	var (
+		err  error
+		head auditHeadTable
+	)
+
+	// a change about a loan is filed under the borrower and the tenant of the loan
+	if change.LoanId != 0 && (change.UserId == 0 || change.TenantId == 0) {
+		var owner loanOwnerTable
+		err = sqlx.GetContext(ctx, tx, &owner, selectLoanOwnerQuery, change.LoanId)
+		if err != nil {
+			return err
+		}
+		change.UserId, change.TenantId = owner.UserId, owner.TenantId
+	}
+	before, err := auditJSON(change.Before)
+	if err != nil {
+		return err
+	}
+	after, err := auditJSON(change.After)
+	if err != nil {
+		return err
+	}
+
+	err = sqlx.GetContext(ctx, tx, &head, selectAuditHeadForUpdateQuery)
+	if err != nil {
+		return err
+	}
+
+	actorType, actor := auditActorOf(ctx)
+	entry := entities.AuditEntry{
+		Id:           head.LastId + 1,
+		TenantId:     tenantIdOf(ctx, change.TenantId),
+		ActorType:    actorType,
+		Actor:        actor,
+		Action:       change.Action,
+		EntityType:   change.EntityType,
+		EntityId:     change.EntityId,
+		LoanId:       change.LoanId,
+		UserId:       change.UserId,
+		Before:       before,
+		After:        after,
+		RequestId:    helper.GetRequestId(ctx),
+		PreviousHash: head.LastHash,
+		CreatedAt:    time.Now().UTC().Truncate(time.Microsecond),
+	}
+	entry.Hash = entry.ComputeHash()
+
+	_, err = tx.ExecContext(ctx, insertAuditEntryQuery, entry.Id, entry.TenantId, entry.ActorType, entry.Actor, entry.Action,
+		entry.EntityType, entry.EntityId, entry.LoanId, entry.UserId, nullJSON(entry.Before), nullJSON(entry.After),
+		entry.RequestId, entry.PreviousHash, entry.Hash, entry.CreatedAt)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, updateAuditHeadQuery, entry.Id, entry.Hash)
+	return err
+}
+
+// SelectAuditEntriesByLoanId returns the latest entries of the loan first
+func (r *DBRepository) SelectAuditEntriesByLoanId(ctx context.Context, loanId int64, limit int) (*[]entities.AuditEntry, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select audit entries by loan id: ", loanId)
+
+	return r.selectAuditEntries(ctx, selectAuditEntryByLoanIdQuery, append(withTenant(ctx, loanId), limit)...)
+}
+
+// SelectAuditEntriesByUserId returns the latest entries of the loans of the
+// user first
+func (r *DBRepository) SelectAuditEntriesByUserId(ctx context.Context, userId int64, limit int) (*[]entities.AuditEntry, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select audit entries by user id: ", userId)
+
+	return r.selectAuditEntries(ctx, selectAuditEntryByUserIdQuery, append(withTenant(ctx, userId), limit)...)
+}
+
+// SelectAuditEntriesAfterId returns the entries of every tenant following
+// the id in the order they were chained
+func (r *DBRepository) SelectAuditEntriesAfterId(ctx context.Context, afterId int64, limit int) (*[]entities.AuditEntry, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select audit entries after id: ", afterId)
+
+	return r.selectAuditEntries(ctx, selectAuditEntryAfterIdQuery, afterId, limit)
+}
+
+// SelectAuditHead returns the id and the hash of the last entry chained
+func (r *DBRepository) SelectAuditHead(ctx context.Context) (int64, string, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select audit head")
+	var head auditHeadTable
+
+	err := r.DB.GetContext(ctx, &head, selectAuditHeadQuery)
+	if err != nil {
+		logger.Error("SelectAuditHead: ", err)
+		return 0, "", err
+	}
+
+	return head.LastId, head.LastHash, nil
+}
+
+func (r *DBRepository) selectAuditEntries(ctx context.Context, query string, args ...interface{}) (*[]entities.AuditEntry, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	entries := []auditEntryTable{}
+
+	err := r.DB.SelectContext(ctx, &entries, query, args...)
+	if err != nil {
+		logger.Error("SelectAuditEntries: ", err)
+		return nil, err
+	}
+
+	resp := make([]entities.AuditEntry, len(entries))
+	for i, e := range entries {
+		resp[i] = *e.toEntities()
+	}
+
+	return &resp, nil
+}
+
+// auditActorOf tells who makes the changes of ctx: the admin user running a
+// command, else the client of the request, else the engine itself
+func auditActorOf(ctx context.Context) (entities.AuditActorType, string) {
+	if adminUser := helper.GetAdminUser(ctx); adminUser != "" {
+		return entities.AuditActorAdmin, adminUser
+	}
+	if clientKey := helper.GetClientKey(ctx); clientKey != "" {
+		return entities.AuditActorClient, clientKey
+	}
+	return entities.AuditActorSystem, "billing-engine"
+}
+
+func auditJSON(value interface{}) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return json.Marshal(value)
+}
+
+// nullJSON stores the JSON as it was hashed, as text rather than in a JSON
+// column that would normalize it
+func nullJSON(value json.RawMessage) interface{} {
+	if value == nil {
+		return nil
+	}
+	return string(value)
+}
+
+// auditLoan is the loan as it is kept in the audit log, without the personal
+// data of the borrower that is only stored encrypted
+func auditLoan(loan entities.Loan) entities.Loan {
+	loan.Borrower = entities.Borrower{}
+	return loan
+}
+
+// auditApiClient is the client as it is kept in the audit log, without its secrets
+func auditApiClient(client entities.ApiClient) entities.ApiClient {
+	client.Secret, client.PreviousSecret = "", ""
+	return client
+}
+
+func loanCreated(ctx context.Context, action entities.AuditAction, id int64, loan entities.Loan) auditChange {
+	loan.Id = id
+	loan.TenantId = tenantIdOf(ctx, loan.TenantId)
+	loan.Currency = currencyOf(loan.Currency)
+	return auditChange{
+		Action:     action,
+		EntityType: "loan",
+		EntityId:   id,
+		TenantId:   loan.TenantId,
+		LoanId:     id,
+		UserId:     loan.UserId,
+		After:      auditLoan(loan),
+	}
+}
+
+// loansUpdated pairs the loans selected before and after an update
+func loansUpdated(action entities.AuditAction, before, after []loansTable) []auditChange {
+	var changes []auditChange
+	for i := range before {
+		if i >= len(after) {
+			break
+		}
+		loanBefore, loanAfter := before[i].toEntities(), after[i].toEntities()
+		changes = append(changes, auditChange{
+			Action:     action,
+			EntityType: "loan",
+			EntityId:   loanAfter.Id,
+			TenantId:   loanAfter.TenantId,
+			LoanId:     loanAfter.Id,
+			UserId:     loanAfter.UserId,
+			Before:     auditLoan(*loanBefore),
+			After:      auditLoan(*loanAfter),
+		})
+	}
+	return changes
+}
+
+func repaymentCreated(ctx context.Context, action entities.AuditAction, id int64, repayment entities.Repayment) auditChange {
+	repayment.Id = id
+	repayment.TenantId = tenantIdOf(ctx, repayment.TenantId)
+	repayment.Currency = currencyOf(repayment.Currency)
+	return auditChange{
+		Action:     action,
+		EntityType: "repayment",
+		EntityId:   id,
+		TenantId:   repayment.TenantId,
+		LoanId:     repayment.LoanId,
+		After:      repayment,
+	}
+}
+
+// repaymentsUpdated pairs the repayments selected before and after an update
+func repaymentsUpdated(action entities.AuditAction, before, after []repaymentTable) []auditChange {
+	var changes []auditChange
+	for i := range before {
+		if i >= len(after) {
+			break
+		}
+		repaymentAfter := after[i].toEntities()
+		changes = append(changes, auditChange{
+			Action:     action,
+			EntityType: "repayment",
+			EntityId:   repaymentAfter.Id,
+			TenantId:   repaymentAfter.TenantId,
+			LoanId:     repaymentAfter.LoanId,
+			Before:     *before[i].toEntities(),
+			After:      *repaymentAfter,
+		})
+	}
+	return changes
+}
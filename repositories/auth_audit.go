@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+const (
+	insertAuthFailureQuery = `INSERT INTO auth_failures
+			(client_key, method, path, reason, source_ip, created_at)
+			VALUES(?,?,?,?,?,?);`
+
+	countInvalidSignatureFailureQuery = `SELECT COUNT(*) FROM auth_failures
+			WHERE client_key = ? AND source_ip = ? AND reason = ? AND created_at >= ?;`
+
+	selectAuthFailureColumns = `SELECT id, client_key, method, path, reason, source_ip, created_at
+			FROM auth_failures `
+
+	selectAuthFailureByClientKeyQuery = selectAuthFailureColumns + `WHERE client_key = ? ORDER BY id DESC LIMIT ?;`
+
+	selectAuthFailureQuery = selectAuthFailureColumns + `ORDER BY id DESC LIMIT ?;`
+
+	deleteAuthFailureBeforeQuery = `DELETE FROM auth_failures WHERE created_at < ?;`
+)
+
+func (r *DBRepository) CreateAuthFailure(ctx context.Context, tx interfaces.AtomicTransaction, failure entities.AuthFailure) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting auth failure into database: ", failure.ClientKey, failure.Reason)
+	var (
+		err    error
+		result sql.Result
+	)
+
+	args := []interface{}{failure.ClientKey, failure.Method, failure.Path, failure.Reason, failure.SourceIp,
+		failure.CreatedAt}
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, insertAuthFailureQuery, args...)
+	} else {
+		result, err = r.DB.ExecContext(ctx, insertAuthFailureQuery, args...)
+	}
+	if err != nil {
+		logger.Error("Error creating auth failure: ", err)
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+// CountInvalidSignatureFailures counts the requests with a wrong signature
+// sent from the source ip for the client key since the given time
+func (r *DBRepository) CountInvalidSignatureFailures(ctx context.Context, clientKey, sourceIp string, since time.Time) (int, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("count invalid signature failures: ", clientKey, sourceIp, since)
+	var count int
+
+	err := r.DB.GetContext(ctx, &count, countInvalidSignatureFailureQuery, clientKey, sourceIp,
+		entities.AuthFailureInvalidSignature, since)
+	if err != nil {
+		logger.Error("CountInvalidSignatureFailures: ", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// SelectAuthFailures returns the latest failures, of every client key when
+// clientKey is empty
+func (r *DBRepository) SelectAuthFailures(ctx context.Context, clientKey string, limit int) (*[]entities.AuthFailure, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select auth failures: ", clientKey, limit)
+	var (
+		err      error
+		failures = []authFailureTable{}
+	)
+
+	if clientKey != "" {
+		err = r.DB.SelectContext(ctx, &failures, selectAuthFailureByClientKeyQuery, clientKey, limit)
+	} else {
+		err = r.DB.SelectContext(ctx, &failures, selectAuthFailureQuery, limit)
+	}
+	if err != nil {
+		logger.Error("SelectAuthFailures: ", err)
+		return nil, err
+	}
+
+	resp := make([]entities.AuthFailure, len(failures))
+	for i, f := range failures {
+		resp[i] = *f.toEntities()
+	}
+
+	return &resp, nil
+}
+
+// PurgeAuthFailures deletes the failures recorded before the given time
+func (r *DBRepository) PurgeAuthFailures(ctx context.Context, before time.Time) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Purge auth failures before: ", before)
+
+	result, err := r.DB.ExecContext(ctx, deleteAuthFailureBeforeQuery, before)
+	if err != nil {
+		logger.Error("Error PurgeAuthFailures: ", err)
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
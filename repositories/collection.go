@@ -0,0 +1,291 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+const (
+	insertDebitMandateQuery = `INSERT INTO debit_mandates
+			(loan_id, bank_code, account_number, account_name, status)
+			VALUES(?,?,?,?,?);`
+
+	selectDebitMandateColumns = `SELECT m.id, m.loan_id, m.bank_code, m.account_number, m.account_name, m.status, m.created_at, m.updated_at
+			FROM debit_mandates m
+			JOIN loans l ON l.id = m.loan_id `
+
+	selectDebitMandateByIdQuery = selectDebitMandateColumns + `WHERE m.id = ? AND ` + loanTenantFilter + `;`
+
+	selectDebitMandateByLoanIdQuery = selectDebitMandateColumns + `WHERE m.loan_id = ? AND ` + loanTenantFilter + ` ORDER BY m.id DESC;`
+
+	selectActiveDebitMandateQuery = selectDebitMandateColumns + `WHERE m.status = 'active' AND ` + loanTenantFilter + ` ORDER BY m.id ASC;`
+
+	updateDebitMandateStatusQuery = `UPDATE debit_mandates SET status = ? WHERE id = ?;`
+
+	// the mandate is only updated once it was read from the tenant
+	selectDebitMandateByIdForUpdateQuery = selectDebitMandateColumns + `WHERE m.id = ? AND ` + loanTenantFilter + ` FOR UPDATE;`
+
+	insertDebitInstructionQuery = `INSERT INTO debit_instructions
+			(mandate_id, loan_id, reference_id, installment_number, amount, due_date, status, attempts, reason_code, next_attempt_at, repayment_id)
+			VALUES(?,?,?,?,?,?,?,?,?,?,?);`
+
+	selectDebitInstructionColumns = `SELECT i.id, i.mandate_id, i.loan_id, i.reference_id, i.installment_number, i.amount, i.due_date,
+			i.status, i.attempts, i.reason_code, i.next_attempt_at, i.repayment_id, i.created_at, i.updated_at
+			FROM debit_instructions i
+			JOIN loans l ON l.id = i.loan_id `
+
+	selectDebitInstructionByLoanIdQuery = selectDebitInstructionColumns + `WHERE i.loan_id = ? AND ` + loanTenantFilter + ` ORDER BY i.id DESC;`
+
+	selectDueDebitInstructionQuery = selectDebitInstructionColumns +
+		`WHERE i.status IN ('pending', 'retrying', 'debited') AND i.next_attempt_at <= ? AND ` + loanTenantFilter + `
+		ORDER BY i.next_attempt_at ASC, i.id ASC;`
+
+	updateDebitInstructionQuery = `UPDATE debit_instructions
+			SET status = ?, attempts = ?, reason_code = ?, next_attempt_at = ?, repayment_id = ?
+			WHERE id = ?;`
+
+	// the instruction is only updated once it was read from the tenant
+	selectDebitInstructionByIdForUpdateQuery = selectDebitInstructionColumns + `WHERE i.id = ? AND ` + loanTenantFilter + ` FOR UPDATE;`
+)
+
+func (r *DBRepository) CreateDebitMandate(ctx context.Context, tx interfaces.AtomicTransaction, mandate entities.DebitMandate) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting debit mandate into database: ", mandate.LoanId, mandate.BankCode)
+	var id int64
+
+	args := []interface{}{mandate.LoanId, mandate.BankCode, mandate.AccountNumber, mandate.AccountName, mandate.Status}
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		result, err := tx.ExecContext(ctx, insertDebitMandateQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		id, err = result.LastInsertId()
+		if err != nil {
+			logger.Error("Error getting last insert ID: ", err)
+			return nil, err
+		}
+		mandate.Id = id
+		return []auditChange{{
+			Action:     entities.AuditMandateCreated,
+			EntityType: "debit_mandate",
+			EntityId:   id,
+			LoanId:     mandate.LoanId,
+			After:      mandate,
+		}}, nil
+	})
+	if err != nil {
+		logger.Error("Error creating debit mandate: ", err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *DBRepository) SelectDebitMandateById(ctx context.Context, id int64) (*entities.DebitMandate, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select debit mandate by id: ", id)
+	var (
+		err     error
+		mandate debitMandateTable
+	)
+
+	err = r.DB.GetContext(ctx, &mandate, selectDebitMandateByIdQuery, withTenant(ctx, id)...)
+	if err != nil {
+		logger.Error("SelectDebitMandateById: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return mandate.toEntities(), nil
+}
+
+func (r *DBRepository) SelectDebitMandateByLoanId(ctx context.Context, loanId int64) (*[]entities.DebitMandate, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select debit mandate by loan id: ", loanId)
+
+	return r.selectDebitMandates(ctx, logger, selectDebitMandateByLoanIdQuery, withTenant(ctx, loanId)...)
+}
+
+func (r *DBRepository) SelectActiveDebitMandate(ctx context.Context) (*[]entities.DebitMandate, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select active debit mandate")
+
+	return r.selectDebitMandates(ctx, logger, selectActiveDebitMandateQuery, withTenant(ctx)...)
+}
+
+func (r *DBRepository) selectDebitMandates(ctx context.Context, logger *logrus.Entry, query string, args ...interface{}) (*[]entities.DebitMandate, error) {
+	var (
+		err      error
+		mandates = []debitMandateTable{}
+	)
+
+	err = r.DB.SelectContext(ctx, &mandates, query, args...)
+	if err != nil {
+		logger.Error("selectDebitMandates: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	resp := make([]entities.DebitMandate, len(mandates))
+	for i, m := range mandates {
+		resp[i] = *m.toEntities()
+	}
+
+	return &resp, nil
+}
+
+func (r *DBRepository) UpdateDebitMandateStatus(ctx context.Context, tx interfaces.AtomicTransaction, id int64, status entities.MandateStatus) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug(fmt.Sprintf("Update debit mandate id: %v, status: %v", id, status))
+
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		var before, after debitMandateTable
+		err := sqlx.GetContext(ctx, tx, &before, selectDebitMandateByIdForUpdateQuery, withTenant(ctx, id)...)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.ExecContext(ctx, updateDebitMandateStatusQuery, status, id)
+		if err != nil {
+			return nil, err
+		}
+		err = sqlx.GetContext(ctx, tx, &after, selectDebitMandateByIdForUpdateQuery, withTenant(ctx, id)...)
+		if err != nil {
+			return nil, err
+		}
+		return []auditChange{{
+			Action:     entities.AuditMandateStatusUpdated,
+			EntityType: "debit_mandate",
+			EntityId:   id,
+			LoanId:     after.LoanId,
+			Before:     *before.toEntities(),
+			After:      *after.toEntities(),
+		}}, nil
+	})
+	if err != nil {
+		logger.Error("Error UpdateDebitMandateStatus: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *DBRepository) CreateDebitInstruction(ctx context.Context, tx interfaces.AtomicTransaction, instruction entities.DebitInstruction) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting debit instruction into database: ", instruction.ReferenceId)
+	var id int64
+
+	args := []interface{}{instruction.MandateId, instruction.LoanId, instruction.ReferenceId, instruction.InstallmentNumber,
+		instruction.Amount, instruction.DueDate, instruction.Status, instruction.Attempts, instruction.ReasonCode,
+		nullTime(instruction.NextAttemptAt), instruction.RepaymentId}
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		result, err := tx.ExecContext(ctx, insertDebitInstructionQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		id, err = result.LastInsertId()
+		if err != nil {
+			logger.Error("Error getting last insert ID: ", err)
+			return nil, err
+		}
+		instruction.Id = id
+		return []auditChange{{
+			Action:     entities.AuditDebitInstructionCreated,
+			EntityType: "debit_instruction",
+			EntityId:   id,
+			LoanId:     instruction.LoanId,
+			After:      instruction,
+		}}, nil
+	})
+	if err != nil {
+		logger.Error("Error creating debit instruction: ", err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *DBRepository) SelectDebitInstructionByLoanId(ctx context.Context, loanId int64) (*[]entities.DebitInstruction, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select debit instruction by loan id: ", loanId)
+
+	return r.selectDebitInstructions(ctx, logger, selectDebitInstructionByLoanIdQuery, withTenant(ctx, loanId)...)
+}
+
+func (r *DBRepository) SelectDueDebitInstruction(ctx context.Context, collectionDate time.Time) (*[]entities.DebitInstruction, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select due debit instruction: ", collectionDate)
+
+	return r.selectDebitInstructions(ctx, logger, selectDueDebitInstructionQuery, withTenant(ctx, collectionDate)...)
+}
+
+func (r *DBRepository) selectDebitInstructions(ctx context.Context, logger *logrus.Entry, query string, args ...interface{}) (*[]entities.DebitInstruction, error) {
+	var (
+		err          error
+		instructions = []debitInstructionTable{}
+	)
+
+	err = r.DB.SelectContext(ctx, &instructions, query, args...)
+	if err != nil {
+		logger.Error("selectDebitInstructions: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	resp := make([]entities.DebitInstruction, len(instructions))
+	for i, instruction := range instructions {
+		resp[i] = *instruction.toEntities()
+	}
+
+	return &resp, nil
+}
+
+func (r *DBRepository) UpdateDebitInstruction(ctx context.Context, tx interfaces.AtomicTransaction, instruction entities.DebitInstruction) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug(fmt.Sprintf("Update debit instruction id: %v, status: %v", instruction.Id, instruction.Status))
+
+	args := []interface{}{instruction.Status, instruction.Attempts, instruction.ReasonCode,
+		nullTime(instruction.NextAttemptAt), instruction.RepaymentId, instruction.Id}
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		var before, after debitInstructionTable
+		err := sqlx.GetContext(ctx, tx, &before, selectDebitInstructionByIdForUpdateQuery, withTenant(ctx, instruction.Id)...)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.ExecContext(ctx, updateDebitInstructionQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		err = sqlx.GetContext(ctx, tx, &after, selectDebitInstructionByIdForUpdateQuery, withTenant(ctx, instruction.Id)...)
+		if err != nil {
+			return nil, err
+		}
+		return []auditChange{{
+			Action:     entities.AuditDebitInstructionUpdated,
+			EntityType: "debit_instruction",
+			EntityId:   instruction.Id,
+			LoanId:     after.LoanId,
+			Before:     *before.toEntities(),
+			After:      *after.toEntities(),
+		}}, nil
+	})
+	if err != nil {
+		logger.Error("Error UpdateDebitInstruction: ", err)
+		return err
+	}
+
+	return nil
+}
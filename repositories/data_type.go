@@ -2,9 +2,12 @@ package repositories
 
 import (
 	"database/sql"
+	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
 )
 
 type (
@@ -13,6 +16,7 @@ type (
 		ReferenceId       string       `db:"reference_id"`
 		UserId            int64        `db:"user_id"`
 		Amount            int64        `db:"amount"`
+		Currency          string       `db:"currency"`
 		RatePercentage    int          `db:"rate_percentage"`
 		Status            int64        `db:"status"`
 		RepaymentSchedule string       `db:"repayment_schedule"`
@@ -20,6 +24,16 @@ type (
 		RepaymentAmount   int64        `db:"repayment_amount"`
 		CreatedAt         sql.NullTime `db:"created_at"`
 		UpdatedAt         sql.NullTime `db:"updated_at"`
+
+		ScheduleVersion    int          `db:"schedule_version"`
+		ScheduleStartAt    sql.NullTime `db:"schedule_start_at"`
+		InstallmentsOffset int          `db:"installments_offset"`
+		PaidOffset         int64        `db:"paid_offset"`
+		TenantId           int64        `db:"tenant_id"`
+
+		BorrowerName        encryptedString `db:"borrower_name"`
+		BorrowerNationalId  encryptedString `db:"borrower_national_id"`
+		BorrowerBankAccount encryptedString `db:"borrower_bank_account"`
 	}
 
 	repaymentTable struct {
@@ -27,16 +41,20 @@ type (
 		LoanId      int64        `db:"loan_id"`
 		ReferenceId string       `db:"reference_id"`
 		Amount      int64        `db:"amount"`
+		Currency    string       `db:"currency"`
+		Status      string       `db:"status"`
 		CreatedAt   sql.NullTime `db:"created_at"`
 		UpdatedAt   sql.NullTime `db:"updated_at"`
+		TenantId    int64        `db:"tenant_id"`
 	}
 )
 
 func (d *loansTable) toEntities() *entities.Loan {
 
 	var (
-		createdAt time.Time
-		updatedAt time.Time
+		createdAt       time.Time
+		updatedAt       time.Time
+		scheduleStartAt time.Time
 	)
 
 	if d.CreatedAt.Valid {
@@ -45,12 +63,16 @@ func (d *loansTable) toEntities() *entities.Loan {
 	if d.UpdatedAt.Valid {
 		updatedAt = d.UpdatedAt.Time
 	}
+	if d.ScheduleStartAt.Valid {
+		scheduleStartAt = d.ScheduleStartAt.Time
+	}
 
 	return &entities.Loan{
 		Id:                d.Id,
 		ReferenceId:       d.ReferenceId,
 		UserId:            d.UserId,
 		Amount:            d.Amount,
+		Currency:          d.Currency,
 		RatePercentage:    d.RatePercentage,
 		Status:            entities.LoanStatus(d.Status),
 		RepaymentSchedule: entities.RepaymentScheduleType(d.RepaymentSchedule),
@@ -58,6 +80,17 @@ func (d *loansTable) toEntities() *entities.Loan {
 		RepaymentAmount:   d.RepaymentAmount,
 		CreatedAt:         createdAt,
 		UpdatedAt:         updatedAt,
+
+		ScheduleVersion:    d.ScheduleVersion,
+		ScheduleStartAt:    scheduleStartAt,
+		InstallmentsOffset: d.InstallmentsOffset,
+		PaidOffset:         d.PaidOffset,
+		TenantId:           d.TenantId,
+		Borrower: entities.Borrower{
+			Name:        string(d.BorrowerName),
+			NationalId:  string(d.BorrowerNationalId),
+			BankAccount: string(d.BorrowerBankAccount),
+		},
 	}
 }
 
@@ -79,7 +112,1120 @@ func (d *repaymentTable) toEntities() *entities.Repayment {
 		LoanId:      d.LoanId,
 		ReferenceId: d.ReferenceId,
 		Amount:      d.Amount,
+		Currency:    d.Currency,
+		Status:      entities.RepaymentStatus(d.Status),
 		CreatedAt:   createdAt,
 		UpdatedAt:   updatedAt,
+		TenantId:    d.TenantId,
+	}
+}
+
+type (
+	webhookSubscriptionTable struct {
+		Id         int64           `db:"id"`
+		ClientKey  string          `db:"client_key"`
+		Url        string          `db:"url"`
+		EventTypes string          `db:"event_types"`
+		Secret     encryptedString `db:"secret"`
+		IsActive   bool            `db:"is_active"`
+		CreatedAt  sql.NullTime    `db:"created_at"`
+		UpdatedAt  sql.NullTime    `db:"updated_at"`
+	}
+
+	webhookDeliveryTable struct {
+		Id               int64        `db:"id"`
+		SubscriptionId   int64        `db:"subscription_id"`
+		EventId          string       `db:"event_id"`
+		EventType        string       `db:"event_type"`
+		Payload          string       `db:"payload"`
+		Status           string       `db:"status"`
+		Attempts         int          `db:"attempts"`
+		NextAttemptAt    sql.NullTime `db:"next_attempt_at"`
+		LastResponseCode int          `db:"last_response_code"`
+		LastError        string       `db:"last_error"`
+		DeliveredAt      sql.NullTime `db:"delivered_at"`
+		CreatedAt        sql.NullTime `db:"created_at"`
+		UpdatedAt        sql.NullTime `db:"updated_at"`
+	}
+)
+
+func (d *webhookSubscriptionTable) toEntities() *entities.WebhookSubscription {
+	var (
+		createdAt time.Time
+		updatedAt time.Time
+	)
+
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+	if d.UpdatedAt.Valid {
+		updatedAt = d.UpdatedAt.Time
+	}
+
+	return &entities.WebhookSubscription{
+		Id:         d.Id,
+		ClientKey:  d.ClientKey,
+		Url:        d.Url,
+		EventTypes: strings.Split(d.EventTypes, ","),
+		Secret:     string(d.Secret),
+		IsActive:   d.IsActive,
+		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
+	}
+}
+
+func (d *webhookDeliveryTable) toEntities() *entities.WebhookDelivery {
+	var (
+		nextAttemptAt time.Time
+		deliveredAt   time.Time
+		createdAt     time.Time
+		updatedAt     time.Time
+	)
+
+	if d.NextAttemptAt.Valid {
+		nextAttemptAt = d.NextAttemptAt.Time
+	}
+	if d.DeliveredAt.Valid {
+		deliveredAt = d.DeliveredAt.Time
+	}
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+	if d.UpdatedAt.Valid {
+		updatedAt = d.UpdatedAt.Time
+	}
+
+	return &entities.WebhookDelivery{
+		Id:               d.Id,
+		SubscriptionId:   d.SubscriptionId,
+		EventId:          d.EventId,
+		EventType:        d.EventType,
+		Payload:          d.Payload,
+		Status:           entities.WebhookDeliveryStatus(d.Status),
+		Attempts:         d.Attempts,
+		NextAttemptAt:    nextAttemptAt,
+		LastResponseCode: d.LastResponseCode,
+		LastError:        d.LastError,
+		DeliveredAt:      deliveredAt,
+		CreatedAt:        createdAt,
+		UpdatedAt:        updatedAt,
+	}
+}
+
+// nullTime stores the zero time as NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+type virtualAccountTable struct {
+	Id        int64        `db:"id"`
+	LoanId    int64        `db:"loan_id"`
+	BankCode  string       `db:"bank_code"`
+	Number    string       `db:"number"`
+	Status    string       `db:"status"`
+	CreatedAt sql.NullTime `db:"created_at"`
+	UpdatedAt sql.NullTime `db:"updated_at"`
+}
+
+func (d *virtualAccountTable) toEntities() *entities.VirtualAccount {
+	var (
+		createdAt time.Time
+		updatedAt time.Time
+	)
+
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+	if d.UpdatedAt.Valid {
+		updatedAt = d.UpdatedAt.Time
+	}
+
+	return &entities.VirtualAccount{
+		Id:        d.Id,
+		LoanId:    d.LoanId,
+		BankCode:  d.BankCode,
+		Number:    d.Number,
+		Status:    entities.VirtualAccountStatus(d.Status),
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+}
+
+type debitMandateTable struct {
+	Id            int64        `db:"id"`
+	LoanId        int64        `db:"loan_id"`
+	BankCode      string       `db:"bank_code"`
+	AccountNumber string       `db:"account_number"`
+	AccountName   string       `db:"account_name"`
+	Status        string       `db:"status"`
+	CreatedAt     sql.NullTime `db:"created_at"`
+	UpdatedAt     sql.NullTime `db:"updated_at"`
+}
+
+func (d *debitMandateTable) toEntities() *entities.DebitMandate {
+	var (
+		createdAt time.Time
+		updatedAt time.Time
+	)
+
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+	if d.UpdatedAt.Valid {
+		updatedAt = d.UpdatedAt.Time
+	}
+
+	return &entities.DebitMandate{
+		Id:            d.Id,
+		LoanId:        d.LoanId,
+		BankCode:      d.BankCode,
+		AccountNumber: d.AccountNumber,
+		AccountName:   d.AccountName,
+		Status:        entities.MandateStatus(d.Status),
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
+	}
+}
+
+type debitInstructionTable struct {
+	Id                int64        `db:"id"`
+	MandateId         int64        `db:"mandate_id"`
+	LoanId            int64        `db:"loan_id"`
+	ReferenceId       string       `db:"reference_id"`
+	InstallmentNumber int          `db:"installment_number"`
+	Amount            int64        `db:"amount"`
+	DueDate           sql.NullTime `db:"due_date"`
+	Status            string       `db:"status"`
+	Attempts          int          `db:"attempts"`
+	ReasonCode        string       `db:"reason_code"`
+	NextAttemptAt     sql.NullTime `db:"next_attempt_at"`
+	RepaymentId       int64        `db:"repayment_id"`
+	CreatedAt         sql.NullTime `db:"created_at"`
+	UpdatedAt         sql.NullTime `db:"updated_at"`
+}
+
+func (d *debitInstructionTable) toEntities() *entities.DebitInstruction {
+	var (
+		dueDate       time.Time
+		nextAttemptAt time.Time
+		createdAt     time.Time
+		updatedAt     time.Time
+	)
+
+	if d.DueDate.Valid {
+		dueDate = d.DueDate.Time
+	}
+	if d.NextAttemptAt.Valid {
+		nextAttemptAt = d.NextAttemptAt.Time
+	}
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+	if d.UpdatedAt.Valid {
+		updatedAt = d.UpdatedAt.Time
+	}
+
+	return &entities.DebitInstruction{
+		Id:                d.Id,
+		MandateId:         d.MandateId,
+		LoanId:            d.LoanId,
+		ReferenceId:       d.ReferenceId,
+		InstallmentNumber: d.InstallmentNumber,
+		Amount:            d.Amount,
+		DueDate:           dueDate,
+		Status:            entities.DebitInstructionStatus(d.Status),
+		Attempts:          d.Attempts,
+		ReasonCode:        d.ReasonCode,
+		NextAttemptAt:     nextAttemptAt,
+		RepaymentId:       d.RepaymentId,
+		CreatedAt:         createdAt,
+		UpdatedAt:         updatedAt,
+	}
+}
+
+type jobRunTable struct {
+	Id           int64        `db:"id"`
+	JobName      string       `db:"job_name"`
+	BusinessDate sql.NullTime `db:"business_date"`
+	Status       string       `db:"status"`
+	TriggeredBy  string       `db:"triggered_by"`
+	Owner        string       `db:"owner"`
+	Attempts     int          `db:"attempts"`
+	Error        string       `db:"error"`
+	StartedAt    sql.NullTime `db:"started_at"`
+	FinishedAt   sql.NullTime `db:"finished_at"`
+	CreatedAt    sql.NullTime `db:"created_at"`
+	UpdatedAt    sql.NullTime `db:"updated_at"`
+}
+
+func (d *jobRunTable) toEntities() *entities.JobRun {
+	var (
+		businessDate time.Time
+		startedAt    time.Time
+		finishedAt   time.Time
+		createdAt    time.Time
+		updatedAt    time.Time
+	)
+
+	if d.BusinessDate.Valid {
+		businessDate = localDate(d.BusinessDate.Time)
+	}
+	if d.StartedAt.Valid {
+		startedAt = d.StartedAt.Time
+	}
+	if d.FinishedAt.Valid {
+		finishedAt = d.FinishedAt.Time
+	}
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+	if d.UpdatedAt.Valid {
+		updatedAt = d.UpdatedAt.Time
+	}
+
+	return &entities.JobRun{
+		Id:           d.Id,
+		JobName:      d.JobName,
+		BusinessDate: businessDate,
+		Status:       entities.JobRunStatus(d.Status),
+		Trigger:      entities.JobTrigger(d.TriggeredBy),
+		Owner:        d.Owner,
+		Attempts:     d.Attempts,
+		Error:        d.Error,
+		StartedAt:    startedAt,
+		FinishedAt:   finishedAt,
+		CreatedAt:    createdAt,
+		UpdatedAt:    updatedAt,
+	}
+}
+
+type loanDailySnapshotTable struct {
+	Id                   int64        `db:"id"`
+	BusinessDate         sql.NullTime `db:"business_date"`
+	LoanId               int64        `db:"loan_id"`
+	LoanReferenceId      string       `db:"loan_reference_id"`
+	UserId               int64        `db:"user_id"`
+	Status               int64        `db:"status"`
+	InstallmentsPaid     int          `db:"installments_paid"`
+	PrincipalOutstanding int64        `db:"principal_outstanding"`
+	InterestOutstanding  int64        `db:"interest_outstanding"`
+	PenaltyOutstanding   int64        `db:"penalty_outstanding"`
+	DaysPastDue          int          `db:"days_past_due"`
+	AgingBucket          string       `db:"aging_bucket"`
+	CreatedAt            sql.NullTime `db:"created_at"`
+	UpdatedAt            sql.NullTime `db:"updated_at"`
+}
+
+func (d *loanDailySnapshotTable) toEntities() *entities.LoanDailySnapshot {
+	var (
+		businessDate time.Time
+		createdAt    time.Time
+		updatedAt    time.Time
+	)
+
+	if d.BusinessDate.Valid {
+		businessDate = localDate(d.BusinessDate.Time)
+	}
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+	if d.UpdatedAt.Valid {
+		updatedAt = d.UpdatedAt.Time
+	}
+
+	return &entities.LoanDailySnapshot{
+		Id:                   d.Id,
+		BusinessDate:         businessDate,
+		LoanId:               d.LoanId,
+		LoanReferenceId:      d.LoanReferenceId,
+		UserId:               d.UserId,
+		Status:               entities.LoanStatus(d.Status),
+		InstallmentsPaid:     d.InstallmentsPaid,
+		PrincipalOutstanding: d.PrincipalOutstanding,
+		InterestOutstanding:  d.InterestOutstanding,
+		PenaltyOutstanding:   d.PenaltyOutstanding,
+		DaysPastDue:          d.DaysPastDue,
+		AgingBucket:          d.AgingBucket,
+		CreatedAt:            createdAt,
+		UpdatedAt:            updatedAt,
+	}
+}
+
+// localDate turns a DATE column, read back as UTC midnight, into the local day it stands for
+func localDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+type receiptTable struct {
+	Id                   int64        `db:"id"`
+	TenantId             int64        `db:"tenant_id"`
+	ReceiptNumber        string       `db:"receipt_number"`
+	RepaymentId          int64        `db:"repayment_id"`
+	RepaymentReferenceId string       `db:"repayment_reference_id"`
+	LoanId               int64        `db:"loan_id"`
+	LoanReferenceId      string       `db:"loan_reference_id"`
+	UserId               int64        `db:"user_id"`
+	InstallmentNumber    int          `db:"installment_number"`
+	Amount               int64        `db:"amount"`
+	Currency             string       `db:"currency"`
+	Principal            int64        `db:"principal"`
+	Interest             int64        `db:"interest"`
+	Fees                 int64        `db:"fees"`
+	Status               string       `db:"status"`
+	CancellationNote     string       `db:"cancellation_note"`
+	IssuedAt             sql.NullTime `db:"issued_at"`
+	CancelledAt          sql.NullTime `db:"cancelled_at"`
+	CreatedAt            sql.NullTime `db:"created_at"`
+	UpdatedAt            sql.NullTime `db:"updated_at"`
+}
+
+func (d *receiptTable) toEntities() *entities.Receipt {
+	var (
+		issuedAt    time.Time
+		cancelledAt time.Time
+		createdAt   time.Time
+		updatedAt   time.Time
+	)
+
+	if d.IssuedAt.Valid {
+		issuedAt = d.IssuedAt.Time
+	}
+	if d.CancelledAt.Valid {
+		cancelledAt = d.CancelledAt.Time
+	}
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+	if d.UpdatedAt.Valid {
+		updatedAt = d.UpdatedAt.Time
+	}
+
+	return &entities.Receipt{
+		Id:                   d.Id,
+		TenantId:             d.TenantId,
+		ReceiptNumber:        d.ReceiptNumber,
+		RepaymentId:          d.RepaymentId,
+		RepaymentReferenceId: d.RepaymentReferenceId,
+		LoanId:               d.LoanId,
+		LoanReferenceId:      d.LoanReferenceId,
+		UserId:               d.UserId,
+		InstallmentNumber:    d.InstallmentNumber,
+		Amount:               d.Amount,
+		Currency:             d.Currency,
+		Principal:            d.Principal,
+		Interest:             d.Interest,
+		Fees:                 d.Fees,
+		Status:               entities.ReceiptStatus(d.Status),
+		CancellationNote:     d.CancellationNote,
+		IssuedAt:             issuedAt,
+		CancelledAt:          cancelledAt,
+		CreatedAt:            createdAt,
+		UpdatedAt:            updatedAt,
+	}
+}
+
+// statementTable keeps the generated statement as JSON so it is returned
+// unchanged however the loans and repayments change afterwards
+type statementTable struct {
+	Id          int64        `db:"id"`
+	UserId      int64        `db:"user_id"`
+	PeriodStart sql.NullTime `db:"period_start"`
+	PeriodEnd   sql.NullTime `db:"period_end"`
+	Content     string       `db:"content"`
+	CreatedAt   sql.NullTime `db:"created_at"`
+}
+
+func (d *statementTable) toEntities() (*entities.Statement, error) {
+	statement := &entities.Statement{}
+	err := json.Unmarshal([]byte(d.Content), statement)
+	if err != nil {
+		return nil, err
+	}
+
+	statement.Id = d.Id
+	statement.UserId = d.UserId
+	if d.PeriodStart.Valid {
+		statement.PeriodStart = localDate(d.PeriodStart.Time)
+	}
+	if d.PeriodEnd.Valid {
+		statement.PeriodEnd = localDate(d.PeriodEnd.Time)
+	}
+	if d.CreatedAt.Valid {
+		statement.CreatedAt = d.CreatedAt.Time
+	}
+
+	return statement, nil
+}
+
+type settlementFileTable struct {
+	Id         int64        `db:"id"`
+	BankCode   string       `db:"bank_code"`
+	Format     string       `db:"format"`
+	FileName   string       `db:"file_name"`
+	Checksum   string       `db:"checksum"`
+	Status     string       `db:"status"`
+	TotalLines int          `db:"total_lines"`
+	Posted     int          `db:"posted"`
+	Duplicates int          `db:"duplicates"`
+	Exceptions int          `db:"exceptions"`
+	CreatedAt  sql.NullTime `db:"created_at"`
+	UpdatedAt  sql.NullTime `db:"updated_at"`
+}
+
+func (d *settlementFileTable) toEntities() *entities.SettlementFile {
+	var (
+		createdAt time.Time
+		updatedAt time.Time
+	)
+
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+	if d.UpdatedAt.Valid {
+		updatedAt = d.UpdatedAt.Time
+	}
+
+	return &entities.SettlementFile{
+		Id:         d.Id,
+		BankCode:   d.BankCode,
+		Format:     d.Format,
+		FileName:   d.FileName,
+		Checksum:   d.Checksum,
+		Status:     entities.SettlementFileStatus(d.Status),
+		TotalLines: d.TotalLines,
+		Posted:     d.Posted,
+		Duplicates: d.Duplicates,
+		Exceptions: d.Exceptions,
+		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
+	}
+}
+
+type settlementExceptionTable struct {
+	Id                   int64        `db:"id"`
+	FileId               int64        `db:"file_id"`
+	LineNumber           int          `db:"line_number"`
+	BankCode             string       `db:"bank_code"`
+	TransactionId        string       `db:"transaction_id"`
+	LoanReferenceId      string       `db:"loan_reference_id"`
+	VirtualAccountNumber string       `db:"virtual_account_number"`
+	Amount               int64        `db:"amount"`
+	PaidAt               sql.NullTime `db:"paid_at"`
+	Reason               string       `db:"reason"`
+	Status               string       `db:"status"`
+	RepaymentId          int64        `db:"repayment_id"`
+	Note                 string       `db:"note"`
+	CreatedAt            sql.NullTime `db:"created_at"`
+	UpdatedAt            sql.NullTime `db:"updated_at"`
+}
+
+func (d *settlementExceptionTable) toEntities() *entities.SettlementException {
+	var (
+		paidAt    time.Time
+		createdAt time.Time
+		updatedAt time.Time
+	)
+
+	if d.PaidAt.Valid {
+		paidAt = d.PaidAt.Time
+	}
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+	if d.UpdatedAt.Valid {
+		updatedAt = d.UpdatedAt.Time
+	}
+
+	return &entities.SettlementException{
+		Id:                   d.Id,
+		FileId:               d.FileId,
+		LineNumber:           d.LineNumber,
+		BankCode:             d.BankCode,
+		TransactionId:        d.TransactionId,
+		LoanReferenceId:      d.LoanReferenceId,
+		VirtualAccountNumber: d.VirtualAccountNumber,
+		Amount:               d.Amount,
+		PaidAt:               paidAt,
+		Reason:               d.Reason,
+		Status:               entities.SettlementExceptionStatus(d.Status),
+		RepaymentId:          d.RepaymentId,
+		Note:                 d.Note,
+		CreatedAt:            createdAt,
+		UpdatedAt:            updatedAt,
+	}
+}
+
+type bankStatementLineTable struct {
+	Id            int64         `db:"id"`
+	BankCode      string        `db:"bank_code"`
+	TransactionId string        `db:"transaction_id"`
+	ValueDate     sql.NullTime  `db:"value_date"`
+	Amount        int64         `db:"amount"`
+	Description   string        `db:"description"`
+	Status        string        `db:"status"`
+	RepaymentId   sql.NullInt64 `db:"repayment_id"`
+	MatchType     string        `db:"match_type"`
+	Reason        string        `db:"reason"`
+	Note          string        `db:"note"`
+	CreatedAt     sql.NullTime  `db:"created_at"`
+	UpdatedAt     sql.NullTime  `db:"updated_at"`
+}
+
+func (d *bankStatementLineTable) toEntities() *entities.BankStatementLine {
+	var (
+		valueDate time.Time
+		createdAt time.Time
+		updatedAt time.Time
+	)
+
+	if d.ValueDate.Valid {
+		valueDate = localDate(d.ValueDate.Time)
+	}
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+	if d.UpdatedAt.Valid {
+		updatedAt = d.UpdatedAt.Time
+	}
+
+	return &entities.BankStatementLine{
+		Id:            d.Id,
+		BankCode:      d.BankCode,
+		TransactionId: d.TransactionId,
+		ValueDate:     valueDate,
+		Amount:        d.Amount,
+		Description:   d.Description,
+		Status:        entities.ReconciliationStatus(d.Status),
+		RepaymentId:   d.RepaymentId.Int64,
+		MatchType:     entities.MatchType(d.MatchType),
+		Reason:        d.Reason,
+		Note:          d.Note,
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
+	}
+}
+
+type writeOffTable struct {
+	Id              int64        `db:"id"`
+	LoanId          int64        `db:"loan_id"`
+	LoanReferenceId string       `db:"loan_reference_id"`
+	Amount          int64        `db:"amount"`
+	DaysPastDue     int          `db:"days_past_due"`
+	WriteOffType    string       `db:"write_off_type"`
+	Reason          string       `db:"reason"`
+	CreatedAt       sql.NullTime `db:"created_at"`
+}
+
+func (d *writeOffTable) toEntities() *entities.WriteOff {
+	var createdAt time.Time
+
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+
+	return &entities.WriteOff{
+		Id:              d.Id,
+		LoanId:          d.LoanId,
+		LoanReferenceId: d.LoanReferenceId,
+		Amount:          d.Amount,
+		DaysPastDue:     d.DaysPastDue,
+		Type:            entities.WriteOffType(d.WriteOffType),
+		Reason:          d.Reason,
+		CreatedAt:       createdAt,
+	}
+}
+
+type recoveryTable struct {
+	Id          int64        `db:"id"`
+	LoanId      int64        `db:"loan_id"`
+	ReferenceId string       `db:"reference_id"`
+	Amount      int64        `db:"amount"`
+	CreatedAt   sql.NullTime `db:"created_at"`
+}
+
+func (d *recoveryTable) toEntities() *entities.Recovery {
+	var createdAt time.Time
+
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+
+	return &entities.Recovery{
+		Id:          d.Id,
+		LoanId:      d.LoanId,
+		ReferenceId: d.ReferenceId,
+		Amount:      d.Amount,
+		CreatedAt:   createdAt,
+	}
+}
+
+type loanScheduleVersionTable struct {
+	Id                   int64        `db:"id"`
+	LoanId               int64        `db:"loan_id"`
+	Version              int          `db:"version"`
+	ChangeType           string       `db:"change_type"`
+	Principal            int64        `db:"principal"`
+	RatePercentage       int          `db:"rate_percentage"`
+	Tenor                int          `db:"tenor"`
+	RepaymentAmount      int64        `db:"repayment_amount"`
+	RepaymentSchedule    string       `db:"repayment_schedule"`
+	InstallmentsBefore   int          `db:"installments_before"`
+	PaidBefore           int64        `db:"paid_before"`
+	StartAt              sql.NullTime `db:"start_at"`
+	DeferredInstallments int          `db:"deferred_installments"`
+	DeferralInterest     string       `db:"deferral_interest"`
+	DeferredInterest     int64        `db:"deferred_interest"`
+	Reason               string       `db:"reason"`
+	ClosedAt             sql.NullTime `db:"closed_at"`
+	CreatedAt            sql.NullTime `db:"created_at"`
+}
+
+func (d *loanScheduleVersionTable) toEntities() *entities.LoanScheduleVersion {
+	var (
+		startAt   time.Time
+		closedAt  time.Time
+		createdAt time.Time
+	)
+
+	if d.StartAt.Valid {
+		startAt = d.StartAt.Time
+	}
+	if d.ClosedAt.Valid {
+		closedAt = d.ClosedAt.Time
+	}
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+
+	return &entities.LoanScheduleVersion{
+		Id:                   d.Id,
+		LoanId:               d.LoanId,
+		Version:              d.Version,
+		Type:                 entities.ScheduleChangeType(d.ChangeType),
+		Principal:            d.Principal,
+		RatePercentage:       d.RatePercentage,
+		Tenor:                d.Tenor,
+		RepaymentAmount:      d.RepaymentAmount,
+		RepaymentSchedule:    entities.RepaymentScheduleType(d.RepaymentSchedule),
+		InstallmentsBefore:   d.InstallmentsBefore,
+		PaidBefore:           d.PaidBefore,
+		StartAt:              startAt,
+		DeferredInstallments: d.DeferredInstallments,
+		DeferralInterest:     entities.DeferralInterestType(d.DeferralInterest),
+		DeferredInterest:     d.DeferredInterest,
+		Reason:               d.Reason,
+		ClosedAt:             closedAt,
+		CreatedAt:            createdAt,
+	}
+}
+
+type promiseToPayTable struct {
+	Id              int64        `db:"id"`
+	LoanId          int64        `db:"loan_id"`
+	LoanReferenceId string       `db:"loan_reference_id"`
+	Amount          int64        `db:"amount"`
+	PromisedDate    sql.NullTime `db:"promised_date"`
+	Agent           string       `db:"agent"`
+	Status          string       `db:"status"`
+	PaidAmount      int64        `db:"paid_amount"`
+	ResolvedAt      sql.NullTime `db:"resolved_at"`
+	CreatedAt       sql.NullTime `db:"created_at"`
+}
+
+func (d *promiseToPayTable) toEntities() *entities.PromiseToPay {
+	var (
+		promisedDate time.Time
+		resolvedAt   time.Time
+		createdAt    time.Time
+	)
+
+	if d.PromisedDate.Valid {
+		promisedDate = localDate(d.PromisedDate.Time)
+	}
+	if d.ResolvedAt.Valid {
+		resolvedAt = d.ResolvedAt.Time
+	}
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+
+	return &entities.PromiseToPay{
+		Id:              d.Id,
+		LoanId:          d.LoanId,
+		LoanReferenceId: d.LoanReferenceId,
+		Amount:          d.Amount,
+		PromisedDate:    promisedDate,
+		Agent:           d.Agent,
+		Status:          entities.PromiseStatus(d.Status),
+		PaidAmount:      d.PaidAmount,
+		ResolvedAt:      resolvedAt,
+		CreatedAt:       createdAt,
+	}
+}
+
+type loanDunningLevelTable struct {
+	Id              int64        `db:"id"`
+	LoanId          int64        `db:"loan_id"`
+	LoanReferenceId string       `db:"loan_reference_id"`
+	Level           int          `db:"level"`
+	DaysPastDue     int          `db:"days_past_due"`
+	IsOverridden    bool         `db:"is_overridden"`
+	OverrideReason  string       `db:"override_reason"`
+	BusinessDate    sql.NullTime `db:"business_date"`
+	CreatedAt       sql.NullTime `db:"created_at"`
+	UpdatedAt       sql.NullTime `db:"updated_at"`
+}
+
+func (d *loanDunningLevelTable) toEntities() *entities.LoanDunningLevel {
+	var (
+		businessDate time.Time
+		createdAt    time.Time
+		updatedAt    time.Time
+	)
+
+	if d.BusinessDate.Valid {
+		businessDate = localDate(d.BusinessDate.Time)
+	}
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+	if d.UpdatedAt.Valid {
+		updatedAt = d.UpdatedAt.Time
+	}
+
+	return &entities.LoanDunningLevel{
+		Id:              d.Id,
+		LoanId:          d.LoanId,
+		LoanReferenceId: d.LoanReferenceId,
+		Level:           d.Level,
+		DaysPastDue:     d.DaysPastDue,
+		IsOverridden:    d.IsOverridden,
+		OverrideReason:  d.OverrideReason,
+		BusinessDate:    businessDate,
+		CreatedAt:       createdAt,
+		UpdatedAt:       updatedAt,
+	}
+}
+
+type dunningActionTable struct {
+	Id              int64        `db:"id"`
+	LoanId          int64        `db:"loan_id"`
+	LoanReferenceId string       `db:"loan_reference_id"`
+	Level           int          `db:"level"`
+	Action          string       `db:"action"`
+	DaysPastDue     int          `db:"days_past_due"`
+	BusinessDate    sql.NullTime `db:"business_date"`
+	Status          string       `db:"status"`
+	CreatedAt       sql.NullTime `db:"created_at"`
+	UpdatedAt       sql.NullTime `db:"updated_at"`
+}
+
+func (d *dunningActionTable) toEntities() *entities.DunningActionItem {
+	var (
+		businessDate time.Time
+		createdAt    time.Time
+		updatedAt    time.Time
+	)
+
+	if d.BusinessDate.Valid {
+		businessDate = localDate(d.BusinessDate.Time)
+	}
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+	if d.UpdatedAt.Valid {
+		updatedAt = d.UpdatedAt.Time
+	}
+
+	return &entities.DunningActionItem{
+		Id:              d.Id,
+		LoanId:          d.LoanId,
+		LoanReferenceId: d.LoanReferenceId,
+		Level:           d.Level,
+		Action:          entities.DunningAction(d.Action),
+		DaysPastDue:     d.DaysPastDue,
+		BusinessDate:    businessDate,
+		Status:          entities.DunningActionStatus(d.Status),
+		CreatedAt:       createdAt,
+		UpdatedAt:       updatedAt,
+	}
+}
+
+type notificationPreferenceTable struct {
+	UserId    int64        `db:"user_id"`
+	Locale    string       `db:"locale"`
+	OptedOut  string       `db:"opted_out"`
+	CreatedAt sql.NullTime `db:"created_at"`
+	UpdatedAt sql.NullTime `db:"updated_at"`
+}
+
+func (d *notificationPreferenceTable) toEntities() *entities.NotificationPreference {
+	var (
+		createdAt time.Time
+		updatedAt time.Time
+		optedOut  = []entities.NotificationChannel{}
+	)
+
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+	if d.UpdatedAt.Valid {
+		updatedAt = d.UpdatedAt.Time
+	}
+	if d.OptedOut != "" {
+		for _, channel := range strings.Split(d.OptedOut, ",") {
+			optedOut = append(optedOut, entities.NotificationChannel(channel))
+		}
+	}
+
+	return &entities.NotificationPreference{
+		UserId:    d.UserId,
+		Locale:    d.Locale,
+		OptedOut:  optedOut,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+}
+
+type notificationDeliveryTable struct {
+	Id        int64        `db:"id"`
+	UserId    int64        `db:"user_id"`
+	LoanId    int64        `db:"loan_id"`
+	Channel   string       `db:"channel"`
+	Kind      string       `db:"kind"`
+	Reference string       `db:"reference"`
+	Locale    string       `db:"locale"`
+	Subject   string       `db:"subject"`
+	Body      string       `db:"body"`
+	Status    string       `db:"status"`
+	Error     string       `db:"error"`
+	CreatedAt sql.NullTime `db:"created_at"`
+}
+
+func (d *notificationDeliveryTable) toEntities() *entities.NotificationDelivery {
+	var createdAt time.Time
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+
+	return &entities.NotificationDelivery{
+		Id:        d.Id,
+		UserId:    d.UserId,
+		LoanId:    d.LoanId,
+		Channel:   entities.NotificationChannel(d.Channel),
+		Kind:      entities.NotificationKind(d.Kind),
+		Reference: d.Reference,
+		Locale:    d.Locale,
+		Subject:   d.Subject,
+		Body:      d.Body,
+		Status:    entities.NotificationDeliveryStatus(d.Status),
+		Error:     d.Error,
+		CreatedAt: createdAt,
+	}
+}
+
+type apiClientTable struct {
+	Id                      int64        `db:"id"`
+	TenantId                int64        `db:"tenant_id"`
+	ClientKey               string       `db:"client_key"`
+	Name                    string       `db:"name"`
+	Scopes                  string       `db:"scopes"`
+	RequestsPerMinute       int          `db:"requests_per_minute"`
+	Burst                   int          `db:"burst"`
+	Secret                  []byte       `db:"secret"`
+	PreviousSecret          []byte       `db:"previous_secret"`
+	PreviousSecretExpiresAt sql.NullTime `db:"previous_secret_expires_at"`
+	IsActive                bool         `db:"is_active"`
+	CreatedAt               sql.NullTime `db:"created_at"`
+	UpdatedAt               sql.NullTime `db:"updated_at"`
+}
+
+// toEntities decrypts the secrets that were selected
+func (d *apiClientTable) toEntities() (*entities.ApiClient, error) {
+	var (
+		err                     error
+		secret                  string
+		previousSecret          string
+		previousSecretExpiresAt time.Time
+		createdAt               time.Time
+		updatedAt               time.Time
+		scopes                  = []entities.ApiScope{}
+	)
+
+	if len(d.Secret) != 0 {
+		secret, err = helper.Decrypt(d.Secret)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(d.PreviousSecret) != 0 {
+		previousSecret, err = helper.Decrypt(d.PreviousSecret)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if d.PreviousSecretExpiresAt.Valid {
+		previousSecretExpiresAt = d.PreviousSecretExpiresAt.Time
+	}
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+	if d.UpdatedAt.Valid {
+		updatedAt = d.UpdatedAt.Time
+	}
+	if d.Scopes != "" {
+		for _, scope := range strings.Split(d.Scopes, ",") {
+			scopes = append(scopes, entities.ApiScope(scope))
+		}
+	}
+
+	return &entities.ApiClient{
+		Id:                      d.Id,
+		TenantId:                d.TenantId,
+		ClientKey:               d.ClientKey,
+		Name:                    d.Name,
+		Scopes:                  scopes,
+		RateLimit:               entities.RateLimit{RequestsPerMinute: d.RequestsPerMinute, Burst: d.Burst},
+		Secret:                  secret,
+		PreviousSecret:          previousSecret,
+		PreviousSecretExpiresAt: previousSecretExpiresAt,
+		IsActive:                d.IsActive,
+		CreatedAt:               createdAt,
+		UpdatedAt:               updatedAt,
+	}, nil
+}
+
+type authFailureTable struct {
+	Id        int64        `db:"id"`
+	ClientKey string       `db:"client_key"`
+	Method    string       `db:"method"`
+	Path      string       `db:"path"`
+	Reason    string       `db:"reason"`
+	SourceIp  string       `db:"source_ip"`
+	CreatedAt sql.NullTime `db:"created_at"`
+}
+
+func (d *authFailureTable) toEntities() *entities.AuthFailure {
+	var createdAt time.Time
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+
+	return &entities.AuthFailure{
+		Id:        d.Id,
+		ClientKey: d.ClientKey,
+		Method:    d.Method,
+		Path:      d.Path,
+		Reason:    entities.AuthFailureReason(d.Reason),
+		SourceIp:  d.SourceIp,
+		CreatedAt: createdAt,
+	}
+}
+
+type tenantTable struct {
+	Id                    int64        `db:"id"`
+	Code                  string       `db:"code"`
+	Name                  string       `db:"name"`
+	Timezone              string       `db:"timezone"`
+	Currency              string       `db:"currency"`
+	DelinquentAfterMissed int          `db:"delinquent_after_missed"`
+	CreatedAt             sql.NullTime `db:"created_at"`
+	UpdatedAt             sql.NullTime `db:"updated_at"`
+}
+
+func (d *tenantTable) toEntities() *entities.Tenant {
+	var (
+		createdAt time.Time
+		updatedAt time.Time
+	)
+
+	if d.CreatedAt.Valid {
+		createdAt = d.CreatedAt.Time
+	}
+	if d.UpdatedAt.Valid {
+		updatedAt = d.UpdatedAt.Time
+	}
+
+	return &entities.Tenant{
+		Id:                    d.Id,
+		Code:                  d.Code,
+		Name:                  d.Name,
+		Timezone:              d.Timezone,
+		Currency:              d.Currency,
+		DelinquentAfterMissed: d.DelinquentAfterMissed,
+		CreatedAt:             createdAt,
+		UpdatedAt:             updatedAt,
+	}
+}
+
+type encryptedValueTable struct {
+	Id    int64  `db:"id"`
+	Value []byte `db:"value"`
+}
+
+func (d *encryptedValueTable) toEntities() *entities.EncryptedValue {
+	return &entities.EncryptedValue{
+		Id:    d.Id,
+		Value: d.Value,
+	}
+}
+
+type (
+	auditHeadTable struct {
+		LastId   int64  `db:"last_id"`
+		LastHash string `db:"last_hash"`
+	}
+
+	loanOwnerTable struct {
+		UserId   int64 `db:"user_id"`
+		TenantId int64 `db:"tenant_id"`
+	}
+
+	auditEntryTable struct {
+		Id           int64          `db:"id"`
+		TenantId     int64          `db:"tenant_id"`
+		ActorType    string         `db:"actor_type"`
+		Actor        string         `db:"actor"`
+		Action       string         `db:"action"`
+		EntityType   string         `db:"entity_type"`
+		EntityId     int64          `db:"entity_id"`
+		LoanId       int64          `db:"loan_id"`
+		UserId       int64          `db:"user_id"`
+		BeforeJson   sql.NullString `db:"before_json"`
+		AfterJson    sql.NullString `db:"after_json"`
+		RequestId    string         `db:"request_id"`
+		PreviousHash string         `db:"previous_hash"`
+		Hash         string         `db:"hash"`
+		CreatedAt    time.Time      `db:"created_at"`
+	}
+)
+
+func (d *auditEntryTable) toEntities() *entities.AuditEntry {
+	var before, after json.RawMessage
+
+	if d.BeforeJson.Valid {
+		before = json.RawMessage(d.BeforeJson.String)
+	}
+	if d.AfterJson.Valid {
+		after = json.RawMessage(d.AfterJson.String)
+	}
+
+	return &entities.AuditEntry{
+		Id:           d.Id,
+		TenantId:     d.TenantId,
+		ActorType:    entities.AuditActorType(d.ActorType),
+		Actor:        d.Actor,
+		Action:       entities.AuditAction(d.Action),
+		EntityType:   d.EntityType,
+		EntityId:     d.EntityId,
+		LoanId:       d.LoanId,
+		UserId:       d.UserId,
+		Before:       before,
+		After:        after,
+		RequestId:    d.RequestId,
+		PreviousHash: d.PreviousHash,
+		Hash:         d.Hash,
+		CreatedAt:    d.CreatedAt,
 	}
 }
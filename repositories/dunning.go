@@ -0,0 +1,239 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const (
+	upsertLoanDunningLevelQuery = `INSERT INTO loan_dunning_levels
+			(loan_id, level, days_past_due, is_overridden, override_reason, business_date)
+			VALUES(?,?,?,?,?,?)
+			ON DUPLICATE KEY UPDATE level = VALUES(level), days_past_due = VALUES(days_past_due),
+			is_overridden = VALUES(is_overridden), override_reason = VALUES(override_reason),
+			business_date = VALUES(business_date);`
+
+	selectLoanDunningLevelColumns = `SELECT d.id, d.loan_id, l.reference_id AS loan_reference_id, d.level, d.days_past_due,
+			d.is_overridden, d.override_reason, d.business_date, d.created_at, d.updated_at
+			FROM loan_dunning_levels d
+			JOIN loans l ON l.id = d.loan_id `
+
+	selectLoanDunningLevelByLoanIdQuery = selectLoanDunningLevelColumns + `WHERE d.loan_id = ? AND ` + loanTenantFilter + `;`
+
+	// the level is only changed once it was read from the tenant, a loan never
+	// dunned has none yet
+	selectLoanDunningLevelByLoanIdForUpdateQuery = selectLoanDunningLevelColumns + `WHERE d.loan_id = ? AND ` + loanTenantFilter + ` FOR UPDATE;`
+
+	selectLoanDunningLevelByLoanIdsQuery = selectLoanDunningLevelColumns + `WHERE d.loan_id IN (?) AND ` + loanTenantFilter + `;`
+
+	insertDunningActionQuery = `INSERT INTO dunning_actions
+			(loan_id, level, action, days_past_due, business_date, status)
+			VALUES(?,?,?,?,?,?);`
+
+	selectDunningActionColumns = `SELECT a.id, a.loan_id, l.reference_id AS loan_reference_id, a.level, a.action, a.days_past_due,
+			a.business_date, a.status, a.created_at, a.updated_at
+			FROM dunning_actions a
+			JOIN loans l ON l.id = a.loan_id `
+
+	selectDunningActionByIdQuery = selectDunningActionColumns + `WHERE a.id = ? AND ` + loanTenantFilter + `;`
+
+	selectDunningActionByStatusQuery = selectDunningActionColumns + `WHERE a.status = ? AND ` + loanTenantFilter + ` ORDER BY a.id ASC;`
+
+	selectDunningActionByStatusAndActionQuery = selectDunningActionColumns +
+		`WHERE a.status = ? AND a.action = ? AND ` + loanTenantFilter + ` ORDER BY a.id ASC;`
+
+	updateDunningActionStatusQuery = `UPDATE dunning_actions SET status = ? WHERE id = ?;`
+)
+
+func (r *DBRepository) UpsertLoanDunningLevel(ctx context.Context, tx interfaces.AtomicTransaction, level entities.LoanDunningLevel) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Upsert loan dunning level: ", level.LoanId, level.Level)
+
+	args := []interface{}{level.LoanId, level.Level, level.DaysPastDue, level.IsOverridden, level.OverrideReason,
+		level.BusinessDate.Format(helper.DateLayout)}
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		var before, after []loanDunningLevelTable
+		err := sqlx.SelectContext(ctx, tx, &before, selectLoanDunningLevelByLoanIdForUpdateQuery, withTenant(ctx, level.LoanId)...)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.ExecContext(ctx, upsertLoanDunningLevelQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		err = sqlx.SelectContext(ctx, tx, &after, selectLoanDunningLevelByLoanIdForUpdateQuery, withTenant(ctx, level.LoanId)...)
+		if err != nil {
+			return nil, err
+		}
+		if len(after) == 0 {
+			return nil, sql.ErrNoRows
+		}
+
+		change := auditChange{
+			Action:     entities.AuditDunningLevelUpdated,
+			EntityType: "loan_dunning_level",
+			EntityId:   after[0].Id,
+			LoanId:     level.LoanId,
+			After:      *after[0].toEntities(),
+		}
+		if len(before) != 0 {
+			change.Before = *before[0].toEntities()
+		}
+		return []auditChange{change}, nil
+	})
+	if err != nil {
+		logger.Error("Error UpsertLoanDunningLevel: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// SelectLoanDunningLevelByLoanId is not found for a loan that was never dunned
+func (r *DBRepository) SelectLoanDunningLevelByLoanId(ctx context.Context, loanId int64) (*entities.LoanDunningLevel, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select loan dunning level by loan id: ", loanId)
+	var (
+		err   error
+		level loanDunningLevelTable
+	)
+
+	err = r.DB.GetContext(ctx, &level, selectLoanDunningLevelByLoanIdQuery, withTenant(ctx, loanId)...)
+	if err != nil {
+		logger.Error("SelectLoanDunningLevelByLoanId: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return level.toEntities(), nil
+}
+
+// SelectLoanDunningLevelByLoanIds leaves the loans that were never dunned out of the map
+func (r *DBRepository) SelectLoanDunningLevelByLoanIds(ctx context.Context, loanIds []int64) (map[int64]entities.LoanDunningLevel, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select loan dunning level by loan ids: ", len(loanIds))
+
+	resp := map[int64]entities.LoanDunningLevel{}
+	if len(loanIds) == 0 {
+		return resp, nil
+	}
+
+	query, args, err := sqlx.In(selectLoanDunningLevelByLoanIdsQuery, withTenant(ctx, loanIds)...)
+	if err != nil {
+		logger.Error("SelectLoanDunningLevelByLoanIds: ", err)
+		return nil, err
+	}
+
+	levels := []loanDunningLevelTable{}
+	err = r.DB.SelectContext(ctx, &levels, r.DB.Rebind(query), args...)
+	if err != nil {
+		logger.Error("SelectLoanDunningLevelByLoanIds: ", err)
+		return nil, err
+	}
+
+	for _, level := range levels {
+		resp[level.LoanId] = *level.toEntities()
+	}
+
+	return resp, nil
+}
+
+func (r *DBRepository) CreateDunningAction(ctx context.Context, tx interfaces.AtomicTransaction, action entities.DunningActionItem) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting dunning action into database: ", action.LoanId, action.Action)
+	var (
+		err    error
+		result sql.Result
+	)
+
+	args := []interface{}{action.LoanId, action.Level, action.Action, action.DaysPastDue,
+		action.BusinessDate.Format(helper.DateLayout), action.Status}
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, insertDunningActionQuery, args...)
+	} else {
+		result, err = r.DB.ExecContext(ctx, insertDunningActionQuery, args...)
+	}
+	if err != nil {
+		logger.Error("Error creating dunning action: ", err)
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+func (r *DBRepository) SelectDunningActionById(ctx context.Context, id int64) (*entities.DunningActionItem, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select dunning action by id: ", id)
+	var (
+		err    error
+		action dunningActionTable
+	)
+
+	err = r.DB.GetContext(ctx, &action, selectDunningActionByIdQuery, withTenant(ctx, id)...)
+	if err != nil {
+		logger.Error("SelectDunningActionById: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return action.toEntities(), nil
+}
+
+// SelectDunningActionByStatus lists the actions with the status, of every
+// kind when the action is empty
+func (r *DBRepository) SelectDunningActionByStatus(ctx context.Context, status entities.DunningActionStatus, action entities.DunningAction) (*[]entities.DunningActionItem, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select dunning action by status: ", status, action)
+	var (
+		err     error
+		actions = []dunningActionTable{}
+	)
+
+	if action == "" {
+		err = r.DB.SelectContext(ctx, &actions, selectDunningActionByStatusQuery, withTenant(ctx, status)...)
+	} else {
+		err = r.DB.SelectContext(ctx, &actions, selectDunningActionByStatusAndActionQuery, withTenant(ctx, status, action)...)
+	}
+	if err != nil {
+		logger.Error("SelectDunningActionByStatus: ", err)
+		return nil, err
+	}
+
+	resp := make([]entities.DunningActionItem, len(actions))
+	for i, a := range actions {
+		resp[i] = *a.toEntities()
+	}
+
+	return &resp, nil
+}
+
+func (r *DBRepository) UpdateDunningActionStatus(ctx context.Context, tx interfaces.AtomicTransaction, id int64, status entities.DunningActionStatus) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Update dunning action status: ", id, status)
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, updateDunningActionStatusQuery, status, id)
+	} else {
+		_, err = r.DB.ExecContext(ctx, updateDunningActionStatusQuery, status, id)
+	}
+	if err != nil {
+		logger.Error("Error UpdateDunningActionStatus: ", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+// encryptedColumns are the columns sealed with helper.Encrypt, every one of
+// them has a BIGINT id primary key
+var encryptedColumns = []entities.EncryptedColumn{
+	{Table: "loans", Column: "borrower_name"},
+	{Table: "loans", Column: "borrower_national_id"},
+	{Table: "loans", Column: "borrower_bank_account"},
+	{Table: "api_clients", Column: "secret"},
+	{Table: "api_clients", Column: "previous_secret"},
+	{Table: "webhook_subscriptions", Column: "secret"},
+}
+
+// encryptedString is a column sealed with helper.Encrypt. It is decrypted as
+// it is scanned so the table structs and their toEntities only see the plain
+// text, and encrypted when it is passed as a query argument. The empty string
+// is stored as NULL.
+type encryptedString string
+
+func (s *encryptedString) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*s = ""
+		return nil
+	case []byte:
+		if len(v) == 0 {
+			*s = ""
+			return nil
+		}
+		plainText, err := helper.Decrypt(v)
+		if err != nil {
+			return err
+		}
+		*s = encryptedString(plainText)
+		return nil
+	}
+	return fmt.Errorf("can not scan %T into an encrypted column", value)
+}
+
+func (s encryptedString) Value() (driver.Value, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return helper.Encrypt(string(s))
+}
+
+// EncryptedColumns lists the columns that are stored encrypted
+func (r *DBRepository) EncryptedColumns() []entities.EncryptedColumn {
+	return encryptedColumns
+}
+
+// SelectEncryptedValues returns the next values of the column after the id,
+// still encrypted
+func (r *DBRepository) SelectEncryptedValues(ctx context.Context, column entities.EncryptedColumn, afterId int64, limit int) ([]entities.EncryptedValue, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select encrypted values: ", column, afterId)
+
+	if !isEncryptedColumn(column) {
+		return nil, fmt.Errorf("%s.%s is not an encrypted column", column.Table, column.Column)
+	}
+	query := fmt.Sprintf(`SELECT id, %[2]s AS value FROM %[1]s WHERE id > ? AND %[2]s IS NOT NULL ORDER BY id ASC LIMIT ?;`,
+		column.Table, column.Column)
+
+	values := []encryptedValueTable{}
+	err := r.DB.SelectContext(ctx, &values, query, afterId, limit)
+	if err != nil {
+		logger.Error("SelectEncryptedValues: ", err)
+		return nil, err
+	}
+
+	resp := make([]entities.EncryptedValue, len(values))
+	for i, v := range values {
+		resp[i] = *v.toEntities()
+	}
+
+	return resp, nil
+}
+
+// UpdateEncryptedValue replaces the value of the column as long as it still
+// holds the previous one, it tells whether the row was updated
+func (r *DBRepository) UpdateEncryptedValue(ctx context.Context, tx interfaces.AtomicTransaction, column entities.EncryptedColumn,
+	value entities.EncryptedValue, previous []byte) (bool, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("update encrypted value: ", column, value.Id)
+
+	if !isEncryptedColumn(column) {
+		return false, fmt.Errorf("%s.%s is not an encrypted column", column.Table, column.Column)
+	}
+	query := fmt.Sprintf(`UPDATE %[1]s SET %[2]s = ? WHERE id = ? AND %[2]s = ?;`, column.Table, column.Column)
+
+	var (
+		err    error
+		result sql.Result
+	)
+	args := []interface{}{value.Value, value.Id, previous}
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, query, args...)
+	} else {
+		result, err = r.DB.ExecContext(ctx, query, args...)
+	}
+	if err != nil {
+		logger.Error("UpdateEncryptedValue: ", err)
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func isEncryptedColumn(column entities.EncryptedColumn) bool {
+	for _, c := range encryptedColumns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+// CreateImportedLoan inserts a loan keeping its original created_at
+func (r *DBRepository) CreateImportedLoan(ctx context.Context, tx interfaces.AtomicTransaction, loan entities.Loan) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting imported loan into database: ", loan.ReferenceId)
+	var id int64
+
+	args := append([]interface{}{tenantIdOf(ctx, loan.TenantId), loan.ReferenceId, loan.UserId, loan.Amount, currencyOf(loan.Currency), loan.RatePercentage,
+		loan.RepaymentAmount, loan.Status, loan.Tenor, loan.RepaymentSchedule, loan.CreatedAt}, borrowerArgs(loan.Borrower)...)
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		result, err := tx.ExecContext(ctx, insertImportedLoanQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		id, err = result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		return []auditChange{loanCreated(ctx, entities.AuditLoanImported, id, loan)}, nil
+	})
+	if err != nil {
+		logger.Error("Error CreateImportedLoan: ", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// CreateImportedRepayment inserts a repayment keeping its original created_at
+func (r *DBRepository) CreateImportedRepayment(ctx context.Context, tx interfaces.AtomicTransaction, repayment entities.Repayment) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting imported repayment into database: ", repayment.ReferenceId)
+	var id int64
+
+	args := []interface{}{tenantIdOf(ctx, repayment.TenantId), repayment.LoanId, repayment.ReferenceId, repayment.Amount,
+		currencyOf(repayment.Currency), repayment.CreatedAt}
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		result, err := tx.ExecContext(ctx, insertImportedRepaymentQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		id, err = result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		return []auditChange{repaymentCreated(ctx, entities.AuditRepaymentImported, id, repayment)}, nil
+	})
+	if err != nil {
+		logger.Error("Error CreateImportedRepayment: ", err)
+		return 0, err
+	}
+
+	return id, nil
+}
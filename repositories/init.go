@@ -12,6 +12,11 @@ type DBRepository struct {
 	DB *sqlx.DB
 }
 
+// BeginTx opens a sqlx transaction, the audited changes also read with it
 func (r *DBRepository) BeginTx(ctx context.Context) (interfaces.AtomicTransaction, error) {
-	return r.DB.BeginTx(ctx, nil)
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
 }
@@ -0,0 +1,200 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+// The jobs run for every tenant at once, their leases and runs have no tenant
+// and are only shown to the operator, see middleware.OperatorOnlyMiddleware.
+const (
+	insertJobLeaseQuery = `INSERT IGNORE INTO job_leases (job_name, owner, lease_until) VALUES(?, '', NULL);`
+
+	// the lease is only taken over once it is released or has expired, even by
+	// its owner, so an instance never runs the same job twice at a time
+	acquireJobLeaseQuery = `UPDATE job_leases SET owner = ?, lease_until = ?
+			WHERE job_name = ? AND (lease_until IS NULL OR lease_until < ?);`
+
+	renewJobLeaseQuery = `UPDATE job_leases SET lease_until = ?
+			WHERE job_name = ? AND owner = ? AND lease_until >= ?;`
+
+	releaseJobLeaseQuery = `UPDATE job_leases SET lease_until = NULL WHERE job_name = ? AND owner = ?;`
+
+	insertJobRunQuery = `INSERT INTO job_runs
+			(job_name, business_date, status, triggered_by, owner, attempts, error, started_at, finished_at)
+			VALUES(?,?,?,?,?,?,?,?,?);`
+
+	selectJobRunColumns = `SELECT id, job_name, business_date, status, triggered_by, owner, attempts, error,
+			started_at, finished_at, created_at, updated_at
+			FROM job_runs `
+
+	selectJobRunByBusinessDateQuery = selectJobRunColumns + `WHERE job_name = ? AND business_date = ?;`
+
+	selectJobRunByJobNameQuery = selectJobRunColumns + `WHERE job_name = ? ORDER BY business_date DESC LIMIT ?;`
+
+	updateJobRunQuery = `UPDATE job_runs
+			SET status = ?, triggered_by = ?, owner = ?, attempts = ?, error = ?, started_at = ?, finished_at = ?
+			WHERE id = ?;`
+)
+
+// AcquireJobLease makes owner the only instance running the job until
+// leaseUntil. It reports false when another instance holds a live lease.
+func (r *DBRepository) AcquireJobLease(ctx context.Context, jobName, owner string, now, leaseUntil time.Time) (bool, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug(fmt.Sprintf("Acquire job lease: %v, owner: %v", jobName, owner))
+
+	_, err := r.DB.ExecContext(ctx, insertJobLeaseQuery, jobName)
+	if err != nil {
+		logger.Error("Error creating job lease: ", err)
+		return false, err
+	}
+
+	result, err := r.DB.ExecContext(ctx, acquireJobLeaseQuery, owner, leaseUntil, jobName, now)
+	if err != nil {
+		logger.Error("Error AcquireJobLease: ", err)
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		logger.Error("Error getting rows affected: ", err)
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// RenewJobLease extends the live lease of owner until leaseUntil. It reports
+// false when the lease has expired or was taken over by another instance.
+func (r *DBRepository) RenewJobLease(ctx context.Context, jobName, owner string, now, leaseUntil time.Time) (bool, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug(fmt.Sprintf("Renew job lease: %v, owner: %v", jobName, owner))
+
+	result, err := r.DB.ExecContext(ctx, renewJobLeaseQuery, leaseUntil, jobName, owner, now)
+	if err != nil {
+		logger.Error("Error RenewJobLease: ", err)
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		logger.Error("Error getting rows affected: ", err)
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *DBRepository) ReleaseJobLease(ctx context.Context, jobName, owner string) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug(fmt.Sprintf("Release job lease: %v, owner: %v", jobName, owner))
+
+	_, err := r.DB.ExecContext(ctx, releaseJobLeaseQuery, jobName, owner)
+	if err != nil {
+		logger.Error("Error ReleaseJobLease: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *DBRepository) CreateJobRun(ctx context.Context, tx interfaces.AtomicTransaction, run entities.JobRun) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting job run into database: ", run.JobName, run.BusinessDate)
+	var (
+		err    error
+		result sql.Result
+	)
+
+	args := []interface{}{run.JobName, run.BusinessDate.Format(helper.DateLayout), run.Status, run.Trigger, run.Owner,
+		run.Attempts, run.Error, nullTime(run.StartedAt), nullTime(run.FinishedAt)}
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, insertJobRunQuery, args...)
+	} else {
+		result, err = r.DB.ExecContext(ctx, insertJobRunQuery, args...)
+	}
+	if err != nil {
+		logger.Error("Error creating job run: ", err)
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error getting last insert ID: ", err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *DBRepository) SelectJobRunByBusinessDate(ctx context.Context, jobName string, businessDate time.Time) (*entities.JobRun, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select job run by business date: ", jobName, businessDate)
+	var (
+		err error
+		run jobRunTable
+	)
+
+	err = r.DB.GetContext(ctx, &run, selectJobRunByBusinessDateQuery, jobName, businessDate.Format(helper.DateLayout))
+	if err != nil {
+		logger.Error("SelectJobRunByBusinessDate: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return run.toEntities(), nil
+}
+
+func (r *DBRepository) SelectJobRunByJobName(ctx context.Context, jobName string, limit int) (*[]entities.JobRun, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select job run by job name: ", jobName)
+	var (
+		err  error
+		runs = []jobRunTable{}
+	)
+
+	err = r.DB.SelectContext(ctx, &runs, selectJobRunByJobNameQuery, jobName, limit)
+	if err != nil {
+		logger.Error("SelectJobRunByJobName: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	resp := make([]entities.JobRun, len(runs))
+	for i, run := range runs {
+		resp[i] = *run.toEntities()
+	}
+
+	return &resp, nil
+}
+
+func (r *DBRepository) UpdateJobRun(ctx context.Context, tx interfaces.AtomicTransaction, run entities.JobRun) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug(fmt.Sprintf("Update job run id: %v, status: %v", run.Id, run.Status))
+
+	var err error
+
+	args := []interface{}{run.Status, run.Trigger, run.Owner, run.Attempts, run.Error,
+		nullTime(run.StartedAt), nullTime(run.FinishedAt), run.Id}
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, updateJobRunQuery, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, updateJobRunQuery, args...)
+	}
+	if err != nil {
+		logger.Error("Error UpdateJobRun: ", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// an expired nonce is taken over, a live one is left as it is and no row is affected
+	useRequestNonceQuery = `INSERT INTO request_nonces (client_key, nonce, expires_at) VALUES(?,?,?)
+			ON DUPLICATE KEY UPDATE expires_at = IF(expires_at <= ?, VALUES(expires_at), expires_at);`
+
+	deleteExpiredRequestNonceQuery = `DELETE FROM request_nonces WHERE expires_at <= ?;`
+)
+
+// UseNonce reports false when the client already used the nonce and it has
+// not expired yet
+func (r *DBRepository) UseNonce(ctx context.Context, clientKey, nonce string, now, expiresAt time.Time) (bool, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Use request nonce: ", clientKey, nonce)
+
+	result, err := r.DB.ExecContext(ctx, useRequestNonceQuery, clientKey, nonce, expiresAt, now)
+	if err != nil {
+		logger.Error("Error UseNonce: ", err)
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		logger.Error("Error getting rows affected: ", err)
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *DBRepository) PurgeExpiredNonces(ctx context.Context, now time.Time) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Purge expired request nonces: ", now)
+
+	result, err := r.DB.ExecContext(ctx, deleteExpiredRequestNonceQuery, now)
+	if err != nil {
+		logger.Error("Error PurgeExpiredNonces: ", err)
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
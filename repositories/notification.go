@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+const (
+	upsertNotificationPreferenceQuery = `INSERT INTO notification_preferences
+			(tenant_id, user_id, locale, opted_out)
+			VALUES(?,?,?,?)
+			ON DUPLICATE KEY UPDATE locale = VALUES(locale), opted_out = VALUES(opted_out);`
+
+	// user ids are only unique within a tenant, a preference is always read
+	// from the tenant it was saved for
+	selectNotificationPreferenceByUserIdQuery = `SELECT user_id, locale, opted_out, created_at, updated_at
+			FROM notification_preferences
+			WHERE tenant_id = ? AND user_id = ?;`
+
+	insertNotificationDeliveryQuery = `INSERT INTO notification_deliveries
+			(tenant_id, user_id, loan_id, channel, kind, reference, locale, subject, body, status, error)
+			VALUES(?,?,?,?,?,?,?,?,?,?,?);`
+
+	selectNotificationDeliveryColumns = `SELECT id, user_id, loan_id, channel, kind, reference, locale, subject, body, status, error, created_at
+			FROM notification_deliveries `
+
+	selectNotificationDeliveryByUserIdQuery = selectNotificationDeliveryColumns + `WHERE user_id = ? AND ` + tenantFilter + ` ORDER BY id DESC;`
+
+	selectNotificationDeliveryByReferenceQuery = selectNotificationDeliveryColumns + `WHERE kind = ? AND reference = ? AND ` + tenantFilter + `;`
+)
+
+func (r *DBRepository) UpsertNotificationPreference(ctx context.Context, tx interfaces.AtomicTransaction, preference entities.NotificationPreference) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Upsert notification preference: ", preference.UserId)
+	var err error
+
+	optedOut := make([]string, len(preference.OptedOut))
+	for i, channel := range preference.OptedOut {
+		optedOut[i] = string(channel)
+	}
+
+	args := []interface{}{tenantIdOf(ctx, 0), preference.UserId, preference.Locale, strings.Join(optedOut, ",")}
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, upsertNotificationPreferenceQuery, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, upsertNotificationPreferenceQuery, args...)
+	}
+	if err != nil {
+		logger.Error("Error UpsertNotificationPreference: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *DBRepository) SelectNotificationPreferenceByUserId(ctx context.Context, userId int64) (*entities.NotificationPreference, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select notification preference by user id: ", userId)
+	var (
+		err        error
+		preference notificationPreferenceTable
+	)
+
+	err = r.DB.GetContext(ctx, &preference, selectNotificationPreferenceByUserIdQuery, tenantIdOf(ctx, 0), userId)
+	if err != nil {
+		logger.Error("SelectNotificationPreferenceByUserId: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return preference.toEntities(), nil
+}
+
+func (r *DBRepository) CreateNotificationDelivery(ctx context.Context, tx interfaces.AtomicTransaction, delivery entities.NotificationDelivery) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting notification delivery into database: ", delivery.Kind, delivery.Reference, delivery.Channel)
+	var (
+		err    error
+		result sql.Result
+	)
+
+	args := []interface{}{tenantIdOf(ctx, 0), delivery.UserId, delivery.LoanId, delivery.Channel, delivery.Kind, delivery.Reference,
+		delivery.Locale, delivery.Subject, delivery.Body, delivery.Status, delivery.Error}
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, insertNotificationDeliveryQuery, args...)
+	} else {
+		result, err = r.DB.ExecContext(ctx, insertNotificationDeliveryQuery, args...)
+	}
+	if err != nil {
+		logger.Error("Error creating notification delivery: ", err)
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+func (r *DBRepository) SelectNotificationDeliveryByUserId(ctx context.Context, userId int64) (*[]entities.NotificationDelivery, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select notification delivery by user id: ", userId)
+
+	return r.selectNotificationDeliveries(ctx, logger, selectNotificationDeliveryByUserIdQuery, withTenant(ctx, userId)...)
+}
+
+func (r *DBRepository) SelectNotificationDeliveryByReference(ctx context.Context, kind entities.NotificationKind, reference string) (*[]entities.NotificationDelivery, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select notification delivery by reference: ", kind, reference)
+
+	return r.selectNotificationDeliveries(ctx, logger, selectNotificationDeliveryByReferenceQuery, withTenant(ctx, kind, reference)...)
+}
+
+func (r *DBRepository) selectNotificationDeliveries(ctx context.Context, logger *logrus.Entry, query string, args ...interface{}) (*[]entities.NotificationDelivery, error) {
+	var (
+		err        error
+		deliveries = []notificationDeliveryTable{}
+	)
+
+	err = r.DB.SelectContext(ctx, &deliveries, query, args...)
+	if err != nil {
+		logger.Error("Error selecting notification deliveries: ", err)
+		return nil, err
+	}
+
+	resp := make([]entities.NotificationDelivery, len(deliveries))
+	for i, d := range deliveries {
+		resp[i] = *d.toEntities()
+	}
+
+	return &resp, nil
+}
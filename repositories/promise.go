@@ -0,0 +1,193 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const (
+	insertPromiseToPayQuery = `INSERT INTO promises_to_pay
+			(loan_id, amount, promised_date, agent, status)
+			VALUES(?,?,?,?,?);`
+
+	selectPromiseToPayColumns = `SELECT p.id, p.loan_id, l.reference_id AS loan_reference_id, p.amount, p.promised_date, p.agent,
+			p.status, p.paid_amount, p.resolved_at, p.created_at
+			FROM promises_to_pay p
+			JOIN loans l ON l.id = p.loan_id `
+
+	selectPromiseToPayByLoanIdQuery = selectPromiseToPayColumns + `WHERE p.loan_id = ? AND ` + loanTenantFilter + ` ORDER BY p.id DESC;`
+
+	selectOpenPromiseToPayByLoanIdQuery = selectPromiseToPayColumns + `WHERE p.loan_id = ? AND p.status = 'open' AND ` + loanTenantFilter + `;`
+
+	selectOpenPromiseToPayByUserIdQuery = selectPromiseToPayColumns +
+		`WHERE l.user_id = ? AND p.status = 'open' AND ` + loanTenantFilter + ` ORDER BY p.promised_date ASC, p.id ASC;`
+
+	selectOpenPromiseToPayQuery = selectPromiseToPayColumns + `WHERE p.status = 'open' AND ` + loanTenantFilter + ` ORDER BY p.id ASC;`
+
+	updatePromiseToPayQuery = `UPDATE promises_to_pay SET status = ?, paid_amount = ?, resolved_at = ? WHERE id = ?;`
+
+	selectPromiseToPayByIdForUpdateQuery = selectPromiseToPayColumns + `WHERE p.id = ? FOR UPDATE;`
+
+	selectRepaymentAmountByLoanIdBetweenQuery = `SELECT COALESCE(SUM(amount), 0)
+			FROM repayments
+			WHERE loan_id = ? AND created_at >= ? AND created_at < ? AND status = 'posted' AND ` + tenantFilter + `;`
+)
+
+func (r *DBRepository) CreatePromiseToPay(ctx context.Context, tx interfaces.AtomicTransaction, promise entities.PromiseToPay) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting promise to pay into database: ", promise.LoanId, promise.PromisedDate)
+	var id int64
+
+	args := []interface{}{promise.LoanId, promise.Amount, promise.PromisedDate.Format(helper.DateLayout), promise.Agent, promise.Status}
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		result, err := tx.ExecContext(ctx, insertPromiseToPayQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		id, err = result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		promise.Id = id
+		return []auditChange{{
+			Action:     entities.AuditPromiseCreated,
+			EntityType: "promise_to_pay",
+			EntityId:   id,
+			LoanId:     promise.LoanId,
+			After:      promise,
+		}}, nil
+	})
+	if err != nil {
+		logger.Error("Error creating promise to pay: ", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *DBRepository) SelectPromiseToPayByLoanId(ctx context.Context, loanId int64) (*[]entities.PromiseToPay, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select promise to pay by loan id: ", loanId)
+
+	return r.selectPromisesToPay(ctx, logger, selectPromiseToPayByLoanIdQuery, withTenant(ctx, loanId)...)
+}
+
+// SelectOpenPromiseToPayByLoanId is not found when the loan has no open promise
+func (r *DBRepository) SelectOpenPromiseToPayByLoanId(ctx context.Context, loanId int64) (*entities.PromiseToPay, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select open promise to pay by loan id: ", loanId)
+	var (
+		err     error
+		promise promiseToPayTable
+	)
+
+	err = r.DB.GetContext(ctx, &promise, selectOpenPromiseToPayByLoanIdQuery, withTenant(ctx, loanId)...)
+	if err != nil {
+		logger.Error("SelectOpenPromiseToPayByLoanId: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return promise.toEntities(), nil
+}
+
+func (r *DBRepository) SelectOpenPromiseToPayByUserId(ctx context.Context, userId int64) (*[]entities.PromiseToPay, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select open promise to pay by user id: ", userId)
+
+	return r.selectPromisesToPay(ctx, logger, selectOpenPromiseToPayByUserIdQuery, withTenant(ctx, userId)...)
+}
+
+func (r *DBRepository) SelectOpenPromiseToPay(ctx context.Context) (*[]entities.PromiseToPay, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select open promise to pay")
+
+	return r.selectPromisesToPay(ctx, logger, selectOpenPromiseToPayQuery, withTenant(ctx)...)
+}
+
+func (r *DBRepository) selectPromisesToPay(ctx context.Context, logger *logrus.Entry, query string, args ...interface{}) (*[]entities.PromiseToPay, error) {
+	var (
+		err      error
+		promises = []promiseToPayTable{}
+	)
+
+	err = r.DB.SelectContext(ctx, &promises, query, args...)
+	if err != nil {
+		logger.Error("selectPromisesToPay: ", err)
+		return nil, err
+	}
+
+	resp := make([]entities.PromiseToPay, len(promises))
+	for i, p := range promises {
+		resp[i] = *p.toEntities()
+	}
+
+	return &resp, nil
+}
+
+func (r *DBRepository) UpdatePromiseToPay(ctx context.Context, tx interfaces.AtomicTransaction, promise entities.PromiseToPay) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Update promise to pay: ", promise.Id, promise.Status)
+
+	args := []interface{}{promise.Status, promise.PaidAmount, nullTime(promise.ResolvedAt), promise.Id}
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		var before, after promiseToPayTable
+		err := sqlx.GetContext(ctx, tx, &before, selectPromiseToPayByIdForUpdateQuery, promise.Id)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.ExecContext(ctx, updatePromiseToPayQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		err = sqlx.GetContext(ctx, tx, &after, selectPromiseToPayByIdForUpdateQuery, promise.Id)
+		if err != nil {
+			return nil, err
+		}
+		return []auditChange{{
+			Action:     entities.AuditPromiseUpdated,
+			EntityType: "promise_to_pay",
+			EntityId:   promise.Id,
+			LoanId:     after.LoanId,
+			Before:     *before.toEntities(),
+			After:      *after.toEntities(),
+		}}, nil
+	})
+	if err != nil {
+		logger.Error("Error UpdatePromiseToPay: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// SelectRepaymentAmountByLoanIdBetween sums the posted repayments of the loan
+// made from the start time up to, but not including, the end time
+func (r *DBRepository) SelectRepaymentAmountByLoanIdBetween(ctx context.Context, loanId int64, from, to time.Time) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select repayment amount by loan id between: ", loanId, from, to)
+	var (
+		err    error
+		amount int64
+	)
+
+	err = r.DB.GetContext(ctx, &amount, selectRepaymentAmountByLoanIdBetweenQuery, withTenant(ctx, loanId, from, to)...)
+	if err != nil {
+		logger.Error("SelectRepaymentAmountByLoanIdBetween: ", err)
+		return 0, err
+	}
+
+	return amount, nil
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+const (
+	// the buckets are kept as the theoretical arrival time of the next request
+	// (GCRA), which behaves as a token bucket but takes a single atomic upsert.
+	// A request is let through when moving tat one interval past now keeps it
+	// within burst intervals, a rejected request leaves the row as it is.
+	takeRateLimitTokenQuery = `INSERT INTO rate_limit_buckets (bucket_key, tat) VALUES(?,?)
+			ON DUPLICATE KEY UPDATE tat = IF(GREATEST(tat, ?) + ? <= ?, GREATEST(tat, ?) + ?, tat);`
+
+	selectRateLimitBucketQuery = `SELECT tat FROM rate_limit_buckets WHERE bucket_key = ?;`
+)
+
+// TakeToken uses a bucket shared by every instance
+func (r *DBRepository) TakeToken(ctx context.Context, key string, limit entities.RateLimit, now time.Time) (*entities.RateLimitResult, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Take rate limit token: ", key)
+
+	var (
+		nowMicro  = now.UnixMicro()
+		interval  = int64(time.Minute/time.Microsecond) / int64(limit.RequestsPerMinute)
+		tolerance = interval * int64(limit.Burst)
+		tat       int64
+	)
+
+	result, err := r.DB.ExecContext(ctx, takeRateLimitTokenQuery, key, nowMicro+interval,
+		nowMicro, interval, nowMicro+tolerance, nowMicro, interval)
+	if err != nil {
+		logger.Error("Error TakeToken: ", err)
+		return nil, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		logger.Error("Error getting rows affected: ", err)
+		return nil, err
+	}
+
+	err = r.DB.GetContext(ctx, &tat, selectRateLimitBucketQuery, key)
+	if err != nil {
+		logger.Error("Error selecting rate limit bucket: ", err)
+		return nil, err
+	}
+
+	if affected == 0 {
+		retryAfter := tat + interval - nowMicro - tolerance
+		if retryAfter < 0 {
+			retryAfter = 0
+		}
+		return &entities.RateLimitResult{RetryAfter: time.Duration(retryAfter) * time.Microsecond}, nil
+	}
+
+	remaining := (tolerance - (tat - nowMicro)) / interval
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &entities.RateLimitResult{Allowed: true, Remaining: int(remaining)}, nil
+}
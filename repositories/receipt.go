@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+const (
+	// LAST_INSERT_ID(expr) hands the new number back through the insert id of
+	// the statement. The row stays locked until the transaction ends, so a
+	// rolled back payment gives its number back and the sequence has no gaps.
+	// Every tenant numbers its receipts on its own.
+	nextReceiptSequenceQuery = `INSERT INTO receipt_sequences (tenant_id, period, last_number) VALUES(?, ?, LAST_INSERT_ID(1))
+			ON DUPLICATE KEY UPDATE last_number = LAST_INSERT_ID(last_number + 1);`
+
+	insertReceiptQuery = `INSERT INTO receipts
+			(tenant_id, receipt_number, repayment_id, repayment_reference_id, loan_id, loan_reference_id, user_id,
+			installment_number, amount, currency, principal, interest, fees, status, issued_at)
+			VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?);`
+
+	selectReceiptColumns = `SELECT id, tenant_id, receipt_number, repayment_id, repayment_reference_id, loan_id, loan_reference_id, user_id,
+			installment_number, amount, currency, principal, interest, fees, status, cancellation_note, issued_at, cancelled_at, created_at, updated_at
+			FROM receipts `
+
+	selectReceiptByRepaymentReferenceIdQuery = selectReceiptColumns + `WHERE repayment_reference_id = ? AND ` + tenantFilter + `;`
+
+	// the receipt is only cancelled once it was read from the tenant
+	selectReceiptByIdForUpdateQuery = selectReceiptColumns + `WHERE id = ? AND ` + tenantFilter + ` FOR UPDATE;`
+
+	cancelReceiptQuery = `UPDATE receipts SET status = ?, cancellation_note = ?, cancelled_at = ? WHERE id = ?;`
+)
+
+// NextReceiptSequence must run inside the transaction that stores the receipt
+func (r *DBRepository) NextReceiptSequence(ctx context.Context, tx interfaces.AtomicTransaction, tenantId int64, period string) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Next receipt sequence: ", tenantId, period)
+
+	var (
+		err error
+		res sql.Result
+	)
+
+	args := []interface{}{tenantIdOf(ctx, tenantId), period}
+	if tx != nil {
+		res, err = tx.ExecContext(ctx, nextReceiptSequenceQuery, args...)
+	} else {
+		res, err = r.DB.ExecContext(ctx, nextReceiptSequenceQuery, args...)
+	}
+	if err != nil {
+		logger.Error("Error NextReceiptSequence: ", err)
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+func (r *DBRepository) CreateReceipt(ctx context.Context, tx interfaces.AtomicTransaction, receipt entities.Receipt) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Create receipt: ", receipt.ReceiptNumber)
+
+	var (
+		err error
+		res sql.Result
+	)
+
+	args := []interface{}{tenantIdOf(ctx, receipt.TenantId), receipt.ReceiptNumber, receipt.RepaymentId, receipt.RepaymentReferenceId, receipt.LoanId,
+		receipt.LoanReferenceId, receipt.UserId, receipt.InstallmentNumber, receipt.Amount, currencyOf(receipt.Currency), receipt.Principal,
+		receipt.Interest, receipt.Fees, receipt.Status, receipt.IssuedAt}
+	if tx != nil {
+		res, err = tx.ExecContext(ctx, insertReceiptQuery, args...)
+	} else {
+		res, err = r.DB.ExecContext(ctx, insertReceiptQuery, args...)
+	}
+	if err != nil {
+		logger.Error("Error CreateReceipt: ", err)
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+func (r *DBRepository) SelectReceiptByRepaymentReferenceId(ctx context.Context, referenceId string) (*entities.Receipt, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select receipt by repayment reference id: ", referenceId)
+	var (
+		err     error
+		receipt receiptTable
+	)
+
+	err = r.DB.GetContext(ctx, &receipt, selectReceiptByRepaymentReferenceIdQuery, withTenant(ctx, referenceId)...)
+	if err != nil {
+		logger.Error("SelectReceiptByRepaymentReferenceId: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return receipt.toEntities(), nil
+}
+
+func (r *DBRepository) CancelReceipt(ctx context.Context, tx interfaces.AtomicTransaction, id int64, note string, cancelledAt time.Time) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Cancel receipt: ", id)
+
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		var before, after receiptTable
+		err := sqlx.GetContext(ctx, tx, &before, selectReceiptByIdForUpdateQuery, withTenant(ctx, id)...)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.ExecContext(ctx, cancelReceiptQuery, entities.ReceiptCancelled, note, cancelledAt, id)
+		if err != nil {
+			return nil, err
+		}
+		err = sqlx.GetContext(ctx, tx, &after, selectReceiptByIdForUpdateQuery, withTenant(ctx, id)...)
+		if err != nil {
+			return nil, err
+		}
+		return []auditChange{{
+			Action:     entities.AuditReceiptCancelled,
+			EntityType: "receipt",
+			EntityId:   id,
+			TenantId:   after.TenantId,
+			LoanId:     after.LoanId,
+			UserId:     after.UserId,
+			Before:     *before.toEntities(),
+			After:      *after.toEntities(),
+		}}, nil
+	})
+	if err != nil {
+		logger.Error("Error CancelReceipt: ", err)
+		return err
+	}
+
+	return nil
+}
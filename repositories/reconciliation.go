@@ -0,0 +1,234 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const (
+	// a statement imported twice keeps the lines of the first import
+	insertBankStatementLineQuery = `INSERT IGNORE INTO bank_statement_lines
+			(bank_code, transaction_id, value_date, amount, description, status)
+			VALUES(?,?,?,?,?,?);`
+
+	selectBankStatementLineColumns = `SELECT id, bank_code, transaction_id, value_date, amount, description, status,
+			repayment_id, match_type, reason, note, created_at, updated_at
+			FROM bank_statement_lines `
+
+	selectBankStatementLineByIdQuery = selectBankStatementLineColumns + `WHERE id = ?;`
+
+	selectBankStatementLineByRepaymentIdQuery = selectBankStatementLineColumns + `WHERE repayment_id = ?;`
+
+	selectBankStatementLineByValueDateQuery = selectBankStatementLineColumns +
+		`WHERE value_date >= ? AND value_date <= ? ORDER BY value_date ASC, id ASC;`
+
+	selectRepaymentReconciliationStatusQuery = `SELECT repayment_id, status
+			FROM bank_statement_lines
+			WHERE repayment_id IN (?);`
+
+	selectBankStatementLineByIdForUpdateQuery = selectBankStatementLineColumns + `WHERE id = ? FOR UPDATE;`
+
+	updateBankStatementLineMatchQuery = `UPDATE bank_statement_lines
+			SET status = ?, repayment_id = ?, match_type = ?, reason = ?, note = ?
+			WHERE id = ?;`
+
+	selectRepaymentByCreatedAtQuery = selectRepaymentColumns +
+		`WHERE status = 'posted' AND created_at >= ? AND created_at < ? AND ` + tenantFilter + `
+			ORDER BY created_at ASC, id ASC;`
+)
+
+// CreateBankStatementLine returns 0 when the bank already reported the transaction
+func (r *DBRepository) CreateBankStatementLine(ctx context.Context, tx interfaces.AtomicTransaction, line entities.BankStatementLine) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting bank statement line into database: ", line.BankCode, line.TransactionId)
+	var (
+		err    error
+		result sql.Result
+	)
+
+	args := []interface{}{line.BankCode, line.TransactionId, line.ValueDate.Format(helper.DateLayout), line.Amount,
+		line.Description, line.Status}
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, insertBankStatementLineQuery, args...)
+	} else {
+		result, err = r.DB.ExecContext(ctx, insertBankStatementLineQuery, args...)
+	}
+	if err != nil {
+		logger.Error("Error creating bank statement line: ", err)
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error getting last insert ID: ", err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *DBRepository) SelectBankStatementLineById(ctx context.Context, id int64) (*entities.BankStatementLine, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select bank statement line by id: ", id)
+
+	return r.selectBankStatementLine(ctx, logger, selectBankStatementLineByIdQuery, id)
+}
+
+func (r *DBRepository) SelectBankStatementLineByRepaymentId(ctx context.Context, repaymentId int64) (*entities.BankStatementLine, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select bank statement line by repayment id: ", repaymentId)
+
+	return r.selectBankStatementLine(ctx, logger, selectBankStatementLineByRepaymentIdQuery, repaymentId)
+}
+
+func (r *DBRepository) selectBankStatementLine(ctx context.Context, logger *logrus.Entry, query string, args ...interface{}) (*entities.BankStatementLine, error) {
+	var (
+		err  error
+		line bankStatementLineTable
+	)
+
+	err = r.DB.GetContext(ctx, &line, query, args...)
+	if err != nil {
+		logger.Error("selectBankStatementLine: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return line.toEntities(), nil
+}
+
+// SelectBankStatementLineByValueDate returns the lines with a value date
+// from fromDate up to and including toDate
+func (r *DBRepository) SelectBankStatementLineByValueDate(ctx context.Context, fromDate, toDate time.Time) (*[]entities.BankStatementLine, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select bank statement line by value date: ", fromDate, toDate)
+	var (
+		err   error
+		lines = []bankStatementLineTable{}
+	)
+
+	err = r.DB.SelectContext(ctx, &lines, selectBankStatementLineByValueDateQuery,
+		fromDate.Format(helper.DateLayout), toDate.Format(helper.DateLayout))
+	if err != nil {
+		logger.Error("SelectBankStatementLineByValueDate: ", err)
+		return nil, err
+	}
+
+	resp := make([]entities.BankStatementLine, len(lines))
+	for i, l := range lines {
+		resp[i] = *l.toEntities()
+	}
+
+	return &resp, nil
+}
+
+// SelectRepaymentReconciliationStatus returns the status of the statement
+// line holding each repayment. Repayments no line holds are left out of the map.
+func (r *DBRepository) SelectRepaymentReconciliationStatus(ctx context.Context, repaymentIds []int64) (map[int64]entities.ReconciliationStatus, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select repayment reconciliation status: ", len(repaymentIds))
+
+	statuses := map[int64]entities.ReconciliationStatus{}
+	if len(repaymentIds) == 0 {
+		return statuses, nil
+	}
+
+	query, args, err := sqlx.In(selectRepaymentReconciliationStatusQuery, repaymentIds)
+	if err != nil {
+		logger.Error("SelectRepaymentReconciliationStatus: ", err)
+		return nil, err
+	}
+
+	rows := []struct {
+		RepaymentId int64  `db:"repayment_id"`
+		Status      string `db:"status"`
+	}{}
+	err = r.DB.SelectContext(ctx, &rows, r.DB.Rebind(query), args...)
+	if err != nil {
+		logger.Error("SelectRepaymentReconciliationStatus: ", err)
+		return nil, err
+	}
+
+	for _, row := range rows {
+		statuses[row.RepaymentId] = entities.ReconciliationStatus(row.Status)
+	}
+
+	return statuses, nil
+}
+
+// UpdateBankStatementLineMatch is audited without a loan, a statement line
+// holds money of every tenant
+func (r *DBRepository) UpdateBankStatementLineMatch(ctx context.Context, tx interfaces.AtomicTransaction, line entities.BankStatementLine) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Update bank statement line match: ", line.Id, line.Status, line.RepaymentId)
+
+	// repayment_id is unique, a line without a repayment stores NULL
+	repaymentId := sql.NullInt64{Int64: line.RepaymentId, Valid: line.RepaymentId != 0}
+	args := []interface{}{line.Status, repaymentId, line.MatchType, line.Reason, line.Note, line.Id}
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		var before, after bankStatementLineTable
+		err := sqlx.GetContext(ctx, tx, &before, selectBankStatementLineByIdForUpdateQuery, line.Id)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.ExecContext(ctx, updateBankStatementLineMatchQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		err = sqlx.GetContext(ctx, tx, &after, selectBankStatementLineByIdForUpdateQuery, line.Id)
+		if err != nil {
+			return nil, err
+		}
+		return []auditChange{{
+			Action:     entities.AuditBankStatementLineMatched,
+			EntityType: "bank_statement_line",
+			EntityId:   line.Id,
+			Before:     *before.toEntities(),
+			After:      *after.toEntities(),
+		}}, nil
+	})
+	if err != nil {
+		logger.Error("Error UpdateBankStatementLineMatch: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// SelectRepaymentByCreatedAt returns the posted repayments made from
+// createdFrom up to createdBefore
+func (r *DBRepository) SelectRepaymentByCreatedAt(ctx context.Context, createdFrom, createdBefore time.Time) (*[]entities.Repayment, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select repayment by created at: ", createdFrom, createdBefore)
+	var (
+		err        error
+		repayments = []repaymentTable{}
+	)
+
+	err = r.DB.SelectContext(ctx, &repayments, selectRepaymentByCreatedAtQuery, withTenant(ctx, createdFrom, createdBefore)...)
+	if err != nil {
+		logger.Error("SelectRepaymentByCreatedAt: ", err)
+		return nil, err
+	}
+
+	resp := make([]entities.Repayment, len(repayments))
+	for i, l := range repayments {
+		resp[i] = *l.toEntities()
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const (
+	// rejected loans were never disbursed and written off loans were taken off
+	// the books, so neither is part of the portfolio. Amounts are only added up
+	// within a currency.
+	selectAgingSummaryQuery = `SELECT l.currency, s.aging_bucket, COUNT(s.id) AS loans,
+			COALESCE(SUM(s.principal_outstanding), 0) AS principal_outstanding,
+			COALESCE(SUM(s.interest_outstanding), 0) AS interest_outstanding
+			FROM loan_daily_snapshot s
+			JOIN loans l ON l.id = s.loan_id
+			WHERE s.business_date = ? AND s.status NOT IN (?, ?)`
+
+	selectOutstandingByScheduleQuery = `SELECT l.currency, l.repayment_schedule, COUNT(s.id) AS loans,
+			COALESCE(SUM(s.principal_outstanding), 0) AS principal_outstanding,
+			COALESCE(SUM(s.interest_outstanding), 0) AS interest_outstanding
+			FROM loan_daily_snapshot s
+			JOIN loans l ON l.id = s.loan_id
+			WHERE s.business_date = ? AND s.status NOT IN (?, ?)`
+
+	selectLoanByReportFilterQuery = selectLoanColumns + `WHERE created_at >= ? AND created_at < ? AND status <> ?`
+)
+
+func (r *DBRepository) SelectAgingSummary(ctx context.Context, filter entities.ReportFilter) (*[]entities.AgingBucketSummary, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select aging summary: ", filter)
+	var (
+		err     error
+		buckets = []entities.AgingBucketSummary{}
+	)
+
+	query, args := snapshotReportFilter(selectAgingSummaryQuery, filter)
+	query += " AND " + loanTenantFilter
+	args = withTenant(ctx, args...)
+	query += " GROUP BY l.currency, s.aging_bucket ORDER BY l.currency ASC, MIN(s.days_past_due) ASC;"
+
+	err = r.DB.SelectContext(ctx, &buckets, query, args...)
+	if err != nil {
+		logger.Error("SelectAgingSummary: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return &buckets, nil
+}
+
+func (r *DBRepository) SelectOutstandingBySchedule(ctx context.Context, filter entities.ReportFilter) (*[]entities.ScheduleOutstanding, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select outstanding by schedule: ", filter)
+	var (
+		err       error
+		schedules = []entities.ScheduleOutstanding{}
+	)
+
+	query, args := snapshotReportFilter(selectOutstandingByScheduleQuery, filter)
+	query += " AND " + loanTenantFilter
+	args = withTenant(ctx, args...)
+	query += " GROUP BY l.currency, l.repayment_schedule ORDER BY l.currency ASC, l.repayment_schedule ASC;"
+
+	err = r.DB.SelectContext(ctx, &schedules, query, args...)
+	if err != nil {
+		logger.Error("SelectOutstandingBySchedule: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return &schedules, nil
+}
+
+// SelectLoanByReportFilter returns the loans created in [createdFrom, createdBefore)
+// matching the repayment schedule and current status of the filter
+func (r *DBRepository) SelectLoanByReportFilter(ctx context.Context, filter entities.ReportFilter, createdFrom, createdBefore time.Time) (*[]entities.Loan, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select loan by report filter: ", filter, createdFrom, createdBefore)
+	var (
+		err   error
+		loans = []loansTable{}
+	)
+
+	query := selectLoanByReportFilterQuery
+	args := []interface{}{createdFrom, createdBefore, entities.LoanStatusRejected}
+	if filter.RepaymentSchedule != "" {
+		query += " AND repayment_schedule = ?"
+		args = append(args, filter.RepaymentSchedule)
+	}
+	if filter.Status != 0 {
+		query += " AND status = ?"
+		args = append(args, filter.Status)
+	}
+	if filter.Restructured != nil {
+		query += restructuredCondition("schedule_version", *filter.Restructured)
+	}
+	query += " AND " + tenantFilter + " ORDER BY id ASC;"
+	args = withTenant(ctx, args...)
+
+	err = r.DB.SelectContext(ctx, &loans, query, args...)
+	if err != nil {
+		logger.Error("SelectLoanByReportFilter: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	resp := make([]entities.Loan, len(loans))
+	for i, l := range loans {
+		resp[i] = *l.toEntities()
+	}
+
+	return &resp, nil
+}
+
+// snapshotReportFilter narrows a snapshot query aliased s joined with loans l
+// down to the snapshots of the filter's to date
+func snapshotReportFilter(query string, filter entities.ReportFilter) (string, []interface{}) {
+	args := []interface{}{filter.ToDate.Format(helper.DateLayout), entities.LoanStatusRejected, entities.LoanStatusWrittenOff}
+	if filter.RepaymentSchedule != "" {
+		query += " AND l.repayment_schedule = ?"
+		args = append(args, filter.RepaymentSchedule)
+	}
+	if filter.Status != 0 {
+		query += " AND s.status = ?"
+		args = append(args, filter.Status)
+	}
+	if filter.Restructured != nil {
+		query += restructuredCondition("l.schedule_version", *filter.Restructured)
+	}
+	return query, args
+}
+
+// restructuredCondition keeps the loans that were rescheduled at least once, or the others
+func restructuredCondition(column string, restructured bool) string {
+	if restructured {
+		return " AND " + column + " > 1"
+	}
+	return " AND " + column + " = 1"
+}
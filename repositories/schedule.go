@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+)
+
+const (
+	insertLoanScheduleVersionQuery = `INSERT INTO loan_schedule_versions
+			(loan_id, version, change_type, principal, rate_percentage, tenor, repayment_amount, repayment_schedule,
+			installments_before, paid_before, start_at, deferred_installments, deferral_interest, deferred_interest,
+			reason, closed_at)
+			VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?);`
+
+	selectLoanScheduleVersionColumns = `SELECT v.id, v.loan_id, v.version, v.change_type, v.principal, v.rate_percentage, v.tenor,
+			v.repayment_amount, v.repayment_schedule, v.installments_before, v.paid_before, v.start_at, v.deferred_installments,
+			v.deferral_interest, v.deferred_interest, v.reason, v.closed_at, v.created_at
+			FROM loan_schedule_versions v
+			JOIN loans l ON l.id = v.loan_id `
+
+	selectLoanScheduleVersionByLoanIdQuery = selectLoanScheduleVersionColumns + `WHERE v.loan_id = ? AND ` + loanTenantFilter + ` ORDER BY v.version ASC;`
+
+	// the version is only closed once it was read from the tenant
+	selectLoanScheduleVersionByIdForUpdateQuery = selectLoanScheduleVersionColumns + `WHERE v.id = ? AND ` + loanTenantFilter + ` FOR UPDATE;`
+
+	closeLoanScheduleVersionQuery = `UPDATE loan_schedule_versions SET closed_at = ? WHERE id = ? AND closed_at IS NULL;`
+
+	updateLoanScheduleQuery = `UPDATE loans
+			SET rate_percentage = ?, tenor = ?, repayment_amount = ?, schedule_version = ?, schedule_start_at = ?,
+			installments_offset = ?, paid_offset = ?
+			WHERE id = ? AND ` + tenantFilter + `;`
+)
+
+func (r *DBRepository) CreateLoanScheduleVersion(ctx context.Context, tx interfaces.AtomicTransaction, version entities.LoanScheduleVersion) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting loan schedule version into database: ", version.LoanId, version.Version)
+	var id int64
+
+	args := []interface{}{version.LoanId, version.Version, version.Type, version.Principal, version.RatePercentage,
+		version.Tenor, version.RepaymentAmount, version.RepaymentSchedule, version.InstallmentsBefore,
+		version.PaidBefore, nullTime(version.StartAt), version.DeferredInstallments, version.DeferralInterest,
+		version.DeferredInterest, version.Reason, nullTime(version.ClosedAt)}
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		res, err := tx.ExecContext(ctx, insertLoanScheduleVersionQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		id, err = res.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		version.Id = id
+		return []auditChange{{
+			Action:     entities.AuditScheduleVersionCreated,
+			EntityType: "loan_schedule_version",
+			EntityId:   id,
+			LoanId:     version.LoanId,
+			After:      version,
+		}}, nil
+	})
+	if err != nil {
+		logger.Error("Error CreateLoanScheduleVersion: ", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// SelectLoanScheduleVersionByLoanId is empty for a loan that was never rescheduled
+func (r *DBRepository) SelectLoanScheduleVersionByLoanId(ctx context.Context, loanId int64) (*[]entities.LoanScheduleVersion, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select loan schedule version by loan id: ", loanId)
+	var (
+		err      error
+		versions = []loanScheduleVersionTable{}
+	)
+
+	err = r.DB.SelectContext(ctx, &versions, selectLoanScheduleVersionByLoanIdQuery, withTenant(ctx, loanId)...)
+	if err != nil {
+		logger.Error("SelectLoanScheduleVersionByLoanId: ", err)
+		return nil, err
+	}
+
+	resp := make([]entities.LoanScheduleVersion, len(versions))
+	for i, v := range versions {
+		resp[i] = *v.toEntities()
+	}
+
+	return &resp, nil
+}
+
+// CloseLoanScheduleVersion leaves a version closed before as it was
+func (r *DBRepository) CloseLoanScheduleVersion(ctx context.Context, tx interfaces.AtomicTransaction, id int64, closedAt time.Time) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Close loan schedule version: ", id)
+
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		var before, after loanScheduleVersionTable
+		err := sqlx.GetContext(ctx, tx, &before, selectLoanScheduleVersionByIdForUpdateQuery, withTenant(ctx, id)...)
+		if err != nil {
+			return nil, err
+		}
+		if before.ClosedAt.Valid {
+			return nil, nil
+		}
+		_, err = tx.ExecContext(ctx, closeLoanScheduleVersionQuery, closedAt, id)
+		if err != nil {
+			return nil, err
+		}
+		err = sqlx.GetContext(ctx, tx, &after, selectLoanScheduleVersionByIdForUpdateQuery, withTenant(ctx, id)...)
+		if err != nil {
+			return nil, err
+		}
+		return []auditChange{{
+			Action:     entities.AuditScheduleVersionClosed,
+			EntityType: "loan_schedule_version",
+			EntityId:   id,
+			LoanId:     after.LoanId,
+			Before:     *before.toEntities(),
+			After:      *after.toEntities(),
+		}}, nil
+	})
+	if err != nil {
+		logger.Error("Error CloseLoanScheduleVersion: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateLoanSchedule stores the terms of the current schedule version on the loan
+func (r *DBRepository) UpdateLoanSchedule(ctx context.Context, tx interfaces.AtomicTransaction, loan entities.Loan) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Update loan schedule: ", loan.ReferenceId, loan.ScheduleVersion)
+
+	args := withTenant(ctx, loan.RatePercentage, loan.Tenor, loan.RepaymentAmount, loan.ScheduleVersion,
+		nullTime(loan.ScheduleStartAt), loan.InstallmentsOffset, loan.PaidOffset, loan.Id)
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		var before, after []loansTable
+		err := sqlx.SelectContext(ctx, tx, &before, selectLoanByIdForUpdateQuery, withTenant(ctx, loan.Id)...)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.ExecContext(ctx, updateLoanScheduleQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		err = sqlx.SelectContext(ctx, tx, &after, selectLoanByIdForUpdateQuery, withTenant(ctx, loan.Id)...)
+		if err != nil {
+			return nil, err
+		}
+		return loansUpdated(entities.AuditLoanScheduleUpdated, before, after), nil
+	})
+	if err != nil {
+		logger.Error("Error UpdateLoanSchedule: ", err)
+		return err
+	}
+
+	return nil
+}
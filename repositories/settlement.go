@@ -0,0 +1,226 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+// The settlement files of a bank and their exceptions hold the lines of every
+// tenant, and of no known loan at all, so they have no tenant. They are only
+// read and worked on by the operator, see middleware.OperatorOnlyMiddleware.
+const (
+	insertSettlementFileQuery = `INSERT INTO settlement_files
+			(bank_code, format, file_name, checksum, status)
+			VALUES(?,?,?,?,?);`
+
+	selectSettlementFileByChecksumQuery = `SELECT id, bank_code, format, file_name, checksum, status,
+			total_lines, posted, duplicates, exceptions, created_at, updated_at
+			FROM settlement_files
+			WHERE bank_code = ? AND checksum = ?;`
+
+	updateSettlementFileQuery = `UPDATE settlement_files
+			SET status = ?, total_lines = ?, posted = ?, duplicates = ?, exceptions = ?
+			WHERE id = ?;`
+
+	// a file processed again after a failure raises the exceptions of its
+	// lines again, the existing row is kept and its id handed back
+	insertSettlementExceptionQuery = `INSERT INTO settlement_exceptions
+			(file_id, line_number, bank_code, transaction_id, loan_reference_id, virtual_account_number, amount, paid_at, reason, status)
+			VALUES(?,?,?,?,?,?,?,?,?,?)
+			ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id);`
+
+	selectSettlementExceptionColumns = `SELECT id, file_id, line_number, bank_code, transaction_id, loan_reference_id,
+			virtual_account_number, amount, paid_at, reason, status, repayment_id, note, created_at, updated_at
+			FROM settlement_exceptions `
+
+	selectSettlementExceptionByIdQuery = selectSettlementExceptionColumns + `WHERE id = ?;`
+
+	selectSettlementExceptionByStatusQuery = selectSettlementExceptionColumns + `WHERE status = ? ORDER BY id ASC;`
+
+	selectSettlementExceptionByIdForUpdateQuery = selectSettlementExceptionColumns + `WHERE id = ? FOR UPDATE;`
+
+	updateSettlementExceptionQuery = `UPDATE settlement_exceptions
+			SET loan_reference_id = ?, reason = ?, status = ?, repayment_id = ?, note = ?
+			WHERE id = ?;`
+)
+
+func (r *DBRepository) CreateSettlementFile(ctx context.Context, tx interfaces.AtomicTransaction, file entities.SettlementFile) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting settlement file into database: ", file.BankCode, file.FileName)
+	var (
+		err    error
+		result sql.Result
+	)
+
+	args := []interface{}{file.BankCode, file.Format, file.FileName, file.Checksum, file.Status}
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, insertSettlementFileQuery, args...)
+	} else {
+		result, err = r.DB.ExecContext(ctx, insertSettlementFileQuery, args...)
+	}
+	if err != nil {
+		logger.Error("Error creating settlement file: ", err)
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error getting last insert ID: ", err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *DBRepository) SelectSettlementFileByChecksum(ctx context.Context, bankCode, checksum string) (*entities.SettlementFile, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select settlement file by checksum: ", bankCode, checksum)
+	var (
+		err  error
+		file settlementFileTable
+	)
+
+	err = r.DB.GetContext(ctx, &file, selectSettlementFileByChecksumQuery, bankCode, checksum)
+	if err != nil {
+		logger.Error("SelectSettlementFileByChecksum: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return file.toEntities(), nil
+}
+
+func (r *DBRepository) UpdateSettlementFile(ctx context.Context, tx interfaces.AtomicTransaction, file entities.SettlementFile) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Update settlement file: ", file.Id, file.Status)
+	var err error
+
+	args := []interface{}{file.Status, file.TotalLines, file.Posted, file.Duplicates, file.Exceptions, file.Id}
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, updateSettlementFileQuery, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, updateSettlementFileQuery, args...)
+	}
+	if err != nil {
+		logger.Error("Error UpdateSettlementFile: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *DBRepository) CreateSettlementException(ctx context.Context, tx interfaces.AtomicTransaction, exception entities.SettlementException) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting settlement exception into database: ", exception.FileId, exception.LineNumber)
+	var (
+		err    error
+		result sql.Result
+	)
+
+	args := []interface{}{exception.FileId, exception.LineNumber, exception.BankCode, exception.TransactionId,
+		exception.LoanReferenceId, exception.VirtualAccountNumber, exception.Amount, nullTime(exception.PaidAt),
+		exception.Reason, exception.Status}
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, insertSettlementExceptionQuery, args...)
+	} else {
+		result, err = r.DB.ExecContext(ctx, insertSettlementExceptionQuery, args...)
+	}
+	if err != nil {
+		logger.Error("Error creating settlement exception: ", err)
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error getting last insert ID: ", err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *DBRepository) SelectSettlementExceptionById(ctx context.Context, id int64) (*entities.SettlementException, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select settlement exception by id: ", id)
+	var (
+		err       error
+		exception settlementExceptionTable
+	)
+
+	err = r.DB.GetContext(ctx, &exception, selectSettlementExceptionByIdQuery, id)
+	if err != nil {
+		logger.Error("SelectSettlementExceptionById: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return exception.toEntities(), nil
+}
+
+func (r *DBRepository) SelectSettlementExceptionByStatus(ctx context.Context, status entities.SettlementExceptionStatus) (*[]entities.SettlementException, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select settlement exception by status: ", status)
+	var (
+		err        error
+		exceptions = []settlementExceptionTable{}
+	)
+
+	err = r.DB.SelectContext(ctx, &exceptions, selectSettlementExceptionByStatusQuery, status)
+	if err != nil {
+		logger.Error("SelectSettlementExceptionByStatus: ", err)
+		return nil, err
+	}
+
+	result := make([]entities.SettlementException, len(exceptions))
+	for i, exception := range exceptions {
+		result[i] = *exception.toEntities()
+	}
+
+	return &result, nil
+}
+
+// UpdateSettlementException is audited without a loan, the exception may be
+// of no known loan
+func (r *DBRepository) UpdateSettlementException(ctx context.Context, tx interfaces.AtomicTransaction, exception entities.SettlementException) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Update settlement exception: ", exception.Id, exception.Status)
+
+	args := []interface{}{exception.LoanReferenceId, exception.Reason, exception.Status, exception.RepaymentId,
+		exception.Note, exception.Id}
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		var before, after settlementExceptionTable
+		err := sqlx.GetContext(ctx, tx, &before, selectSettlementExceptionByIdForUpdateQuery, exception.Id)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.ExecContext(ctx, updateSettlementExceptionQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		err = sqlx.GetContext(ctx, tx, &after, selectSettlementExceptionByIdForUpdateQuery, exception.Id)
+		if err != nil {
+			return nil, err
+		}
+		return []auditChange{{
+			Action:     entities.AuditSettlementExceptionUpdated,
+			EntityType: "settlement_exception",
+			EntityId:   exception.Id,
+			Before:     *before.toEntities(),
+			After:      *after.toEntities(),
+		}}, nil
+	})
+	if err != nil {
+		logger.Error("Error UpdateSettlementException: ", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const (
+	selectLoanCreatedBeforeQuery = selectLoanColumns + `WHERE created_at < ? AND id > ? AND ` + tenantFilter + ` ORDER BY id ASC LIMIT ?;`
+
+	selectRepaymentCountByLoanIdsQuery = `SELECT loan_id, COUNT(id) AS repayment_count
+			FROM repayments
+			WHERE loan_id IN (?) AND created_at < ? AND status = 'posted' AND ` + tenantFilter + `
+			GROUP BY loan_id;`
+
+	upsertLoanDailySnapshotQuery = `INSERT INTO loan_daily_snapshot
+			(business_date, loan_id, loan_reference_id, user_id, status, installments_paid,
+			principal_outstanding, interest_outstanding, penalty_outstanding, days_past_due, aging_bucket)
+			VALUES(?,?,?,?,?,?,?,?,?,?,?)
+			ON DUPLICATE KEY UPDATE status = VALUES(status), installments_paid = VALUES(installments_paid),
+			principal_outstanding = VALUES(principal_outstanding), interest_outstanding = VALUES(interest_outstanding),
+			penalty_outstanding = VALUES(penalty_outstanding), days_past_due = VALUES(days_past_due),
+			aging_bucket = VALUES(aging_bucket);`
+
+	selectLoanDailySnapshotByBusinessDateQuery = `SELECT id, business_date, loan_id, loan_reference_id, user_id, status, installments_paid,
+			principal_outstanding, interest_outstanding, penalty_outstanding, days_past_due, aging_bucket, created_at, updated_at
+			FROM loan_daily_snapshot
+			WHERE business_date = ? ORDER BY loan_id ASC;`
+)
+
+func (r *DBRepository) SelectLoanCreatedBefore(ctx context.Context, createdBefore time.Time, afterId int64, limit int) (*[]entities.Loan, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select loan created before: ", createdBefore, afterId)
+	var (
+		err   error
+		loans = []loansTable{}
+	)
+
+	err = r.DB.SelectContext(ctx, &loans, selectLoanCreatedBeforeQuery, append(withTenant(ctx, createdBefore, afterId), limit)...)
+	if err != nil {
+		logger.Error("SelectLoanCreatedBefore: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	resp := make([]entities.Loan, len(loans))
+	for i, l := range loans {
+		resp[i] = *l.toEntities()
+	}
+
+	return &resp, nil
+}
+
+// SelectRepaymentCountByLoanIds counts the repayments of every loan made
+// before the given time. Loans without repayments are left out of the map.
+func (r *DBRepository) SelectRepaymentCountByLoanIds(ctx context.Context, loanIds []int64, createdBefore time.Time) (map[int64]int, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select repayment count by loan ids: ", len(loanIds), createdBefore)
+
+	counts := map[int64]int{}
+	if len(loanIds) == 0 {
+		return counts, nil
+	}
+
+	query, args, err := sqlx.In(selectRepaymentCountByLoanIdsQuery, withTenant(ctx, loanIds, createdBefore)...)
+	if err != nil {
+		logger.Error("SelectRepaymentCountByLoanIds: ", err)
+		return nil, err
+	}
+
+	rows := []struct {
+		LoanId         int64 `db:"loan_id"`
+		RepaymentCount int   `db:"repayment_count"`
+	}{}
+	err = r.DB.SelectContext(ctx, &rows, r.DB.Rebind(query), args...)
+	if err != nil {
+		logger.Error("SelectRepaymentCountByLoanIds: ", err)
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.LoanId] = row.RepaymentCount
+	}
+
+	return counts, nil
+}
+
+func (r *DBRepository) UpsertLoanDailySnapshot(ctx context.Context, tx interfaces.AtomicTransaction, snapshot entities.LoanDailySnapshot) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Upsert loan daily snapshot: ", snapshot.LoanId, snapshot.BusinessDate)
+
+	var err error
+
+	args := []interface{}{snapshot.BusinessDate.Format(helper.DateLayout), snapshot.LoanId, snapshot.LoanReferenceId, snapshot.UserId,
+		snapshot.Status, snapshot.InstallmentsPaid, snapshot.PrincipalOutstanding, snapshot.InterestOutstanding,
+		snapshot.PenaltyOutstanding, snapshot.DaysPastDue, snapshot.AgingBucket}
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, upsertLoanDailySnapshotQuery, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, upsertLoanDailySnapshotQuery, args...)
+	}
+	if err != nil {
+		logger.Error("Error UpsertLoanDailySnapshot: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *DBRepository) SelectLoanDailySnapshotByBusinessDate(ctx context.Context, businessDate time.Time) (*[]entities.LoanDailySnapshot, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select loan daily snapshot by business date: ", businessDate)
+	var (
+		err       error
+		snapshots = []loanDailySnapshotTable{}
+	)
+
+	err = r.DB.SelectContext(ctx, &snapshots, selectLoanDailySnapshotByBusinessDateQuery, businessDate.Format(helper.DateLayout))
+	if err != nil {
+		logger.Error("SelectLoanDailySnapshotByBusinessDate: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	resp := make([]entities.LoanDailySnapshot, len(snapshots))
+	for i, snapshot := range snapshots {
+		resp[i] = *snapshot.toEntities()
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const (
+	// a statement generated concurrently for the same period keeps the first one
+	createStatementQuery = `INSERT IGNORE INTO statements (tenant_id, user_id, period_start, period_end, content) VALUES(?,?,?,?,?);`
+
+	// user ids are only unique within a tenant, a statement is always read
+	// from the tenant it was stored for
+	selectStatementByPeriodQuery = `SELECT id, user_id, period_start, period_end, content, created_at
+			FROM statements
+			WHERE tenant_id = ? AND user_id = ? AND period_start = ?;`
+)
+
+func (r *DBRepository) CreateStatement(ctx context.Context, tx interfaces.AtomicTransaction, statement entities.Statement) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Create statement: ", statement.UserId, statement.PeriodStart)
+
+	content, err := json.Marshal(statement)
+	if err != nil {
+		logger.Error("Error CreateStatement: ", err)
+		return err
+	}
+
+	args := []interface{}{tenantIdOf(ctx, 0), statement.UserId, statement.PeriodStart.Format(helper.DateLayout),
+		statement.PeriodEnd.Format(helper.DateLayout), string(content)}
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, createStatementQuery, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, createStatementQuery, args...)
+	}
+	if err != nil {
+		logger.Error("Error CreateStatement: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *DBRepository) SelectStatementByPeriod(ctx context.Context, userId int64, periodStart time.Time) (*entities.Statement, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select statement by period: ", userId, periodStart)
+	var (
+		err       error
+		statement statementTable
+	)
+
+	err = r.DB.GetContext(ctx, &statement, selectStatementByPeriodQuery, tenantIdOf(ctx, 0), userId,
+		periodStart.Format(helper.DateLayout))
+	if err != nil {
+		logger.Error("SelectStatementByPeriod: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	resp, err := statement.toEntities()
+	if err != nil {
+		logger.Error("SelectStatementByPeriod: ", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
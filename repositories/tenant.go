@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const (
+	// tenantFilter scopes a query on a table with a tenant_id to the tenant of
+	// the request, see withTenant. Only a ctx marked with helper.WithOperator,
+	// as scheduled jobs, payment gateway callbacks and the operator endpoints
+	// are, sees every tenant. A ctx with neither sees nothing, so a path that
+	// forgot its tenant fails closed instead of reaching every tenant.
+	tenantFilter = `(? OR tenant_id = ?)`
+
+	// loanTenantFilter is tenantFilter for a query joined with loans l, the
+	// tables of a loan are scoped through it
+	loanTenantFilter = `(? OR l.tenant_id = ?)`
+
+	// clientTenantFilter is tenantFilter for a query joined with api_clients c
+	clientTenantFilter = `(? OR c.tenant_id = ?)`
+
+	insertTenantQuery = `INSERT INTO tenants
+			(code, name, timezone, currency, delinquent_after_missed)
+			VALUES(?,?,?,?,?);`
+
+	selectTenantByIdQuery = `SELECT id, code, name, timezone, currency, delinquent_after_missed, created_at, updated_at
+			FROM tenants
+			WHERE id = ?;`
+
+	selectTenantsQuery = `SELECT id, code, name, timezone, currency, delinquent_after_missed, created_at, updated_at
+			FROM tenants
+			ORDER BY id;`
+
+	updateTenantSettingsQuery = `UPDATE tenants SET name = ?, timezone = ?, currency = ?, delinquent_after_missed = ?
+			WHERE id = ?;`
+
+	selectTenantByIdForUpdateQuery = `SELECT id, code, name, timezone, currency, delinquent_after_missed, created_at, updated_at
+			FROM tenants
+			WHERE id = ? FOR UPDATE;`
+)
+
+// withTenant appends the arguments of tenantFilter to args
+func withTenant(ctx context.Context, args ...interface{}) []interface{} {
+	return append(args, helper.IsOperator(ctx), helper.GetTenantId(ctx))
+}
+
+// tenantIdOf is the tenant a new row belongs to: the one it was given, or
+// else the one of the request, or else the default tenant
+func tenantIdOf(ctx context.Context, tenantId int64) int64 {
+	if tenantId != 0 {
+		return tenantId
+	}
+	if tenantId = helper.GetTenantId(ctx); tenantId != 0 {
+		return tenantId
+	}
+	return entities.DefaultTenantId
+}
+
+func (r *DBRepository) CreateTenant(ctx context.Context, tx interfaces.AtomicTransaction, tenant entities.Tenant) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting tenant into database: ", tenant.Code)
+	var id int64
+
+	args := []interface{}{tenant.Code, tenant.Name, tenant.Timezone, tenant.Currency, tenant.DelinquentAfterMissed}
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		result, err := tx.ExecContext(ctx, insertTenantQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		id, err = result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		tenant.Id = id
+		return []auditChange{{
+			Action:     entities.AuditTenantCreated,
+			EntityType: "tenant",
+			EntityId:   id,
+			TenantId:   id,
+			After:      tenant,
+		}}, nil
+	})
+	if err != nil {
+		logger.Error("Error creating tenant: ", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *DBRepository) SelectTenantById(ctx context.Context, id int64) (*entities.Tenant, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select tenant by id: ", id)
+	var (
+		err    error
+		tenant tenantTable
+	)
+
+	err = r.DB.GetContext(ctx, &tenant, selectTenantByIdQuery, id)
+	if err != nil {
+		logger.Error("SelectTenantById: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return tenant.toEntities(), nil
+}
+
+func (r *DBRepository) SelectTenants(ctx context.Context) (*[]entities.Tenant, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select tenants")
+	tenants := []tenantTable{}
+
+	err := r.DB.SelectContext(ctx, &tenants, selectTenantsQuery)
+	if err != nil {
+		logger.Error("SelectTenants: ", err)
+		return nil, err
+	}
+
+	resp := make([]entities.Tenant, len(tenants))
+	for i, t := range tenants {
+		resp[i] = *t.toEntities()
+	}
+
+	return &resp, nil
+}
+
+func (r *DBRepository) UpdateTenantSettings(ctx context.Context, tx interfaces.AtomicTransaction, tenant entities.Tenant) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Update tenant settings: ", tenant.Id)
+
+	args := []interface{}{tenant.Name, tenant.Timezone, tenant.Currency, tenant.DelinquentAfterMissed, tenant.Id}
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		var before, after tenantTable
+		err := sqlx.GetContext(ctx, tx, &before, selectTenantByIdForUpdateQuery, tenant.Id)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.ExecContext(ctx, updateTenantSettingsQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		err = sqlx.GetContext(ctx, tx, &after, selectTenantByIdForUpdateQuery, tenant.Id)
+		if err != nil {
+			return nil, err
+		}
+		return []auditChange{{
+			Action:     entities.AuditTenantSettingsUpdated,
+			EntityType: "tenant",
+			EntityId:   tenant.Id,
+			TenantId:   tenant.Id,
+			Before:     *before.toEntities(),
+			After:      *after.toEntities(),
+		}}, nil
+	})
+	if err != nil {
+		logger.Error("Error UpdateTenantSettings: ", err)
+		return err
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 
 	"github.com/sirait-kevin/BillingEngine/domain/entities"
@@ -15,68 +16,88 @@ import (
 
 const (
 	insertLoanQuery = `INSERT INTO loans
-			(reference_id, user_id, amount, rate_percentage, repayment_amount, status, tenor, repayment_schedule)
-			VALUES(?,?,?,?,?,?,?,?);`
+			(tenant_id, reference_id, user_id, amount, currency, rate_percentage, repayment_amount, status, tenor, repayment_schedule,
+			borrower_name, borrower_national_id, borrower_bank_account)
+			VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?);`
 
+	// a repayment without a paid time is made now
 	insertRepaymentQuery = `INSERT INTO repayments
-			(loan_id, reference_id, amount)
-			VALUES(?,?,?);`
+			(tenant_id, loan_id, reference_id, amount, currency, created_at)
+			VALUES(?,?,?,?,?,COALESCE(?, CURRENT_TIMESTAMP));`
 
-	selectLoanByReferenceIdQuery = `SELECT id, reference_id, user_id, amount, rate_percentage, repayment_amount, status, created_at, updated_at, tenor, repayment_schedule
-			FROM loans
-			WHERE reference_id = ? ORDER BY id DESC;`
+	insertImportedLoanQuery = `INSERT INTO loans
+			(tenant_id, reference_id, user_id, amount, currency, rate_percentage, repayment_amount, status, tenor, repayment_schedule, created_at,
+			borrower_name, borrower_national_id, borrower_bank_account)
+			VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?);`
 
-	selectActiveLoanByReferenceIdQuery = `SELECT id, reference_id, user_id, amount, rate_percentage, repayment_amount, status, created_at, updated_at, tenor, repayment_schedule
-			FROM loans
-			WHERE reference_id = ? and status=1;`
+	insertImportedRepaymentQuery = `INSERT INTO repayments
+			(tenant_id, loan_id, reference_id, amount, currency, created_at)
+			VALUES(?,?,?,?,?,?);`
 
-	selectLoanByUserIdQuery = `SELECT id, reference_id, user_id, amount, rate_percentage, repayment_amount, status, created_at, updated_at, tenor, repayment_schedule
-			FROM loans
-			WHERE user_id = ? ORDER BY id DESC;`
+	selectLoanColumns = `SELECT id, reference_id, user_id, amount, rate_percentage, repayment_amount, status, created_at, updated_at, tenor, repayment_schedule,
+			schedule_version, schedule_start_at, installments_offset, paid_offset, tenant_id, currency,
+			borrower_name, borrower_national_id, borrower_bank_account
+			FROM loans `
 
-	selectRepaymentByReferenceId = `SELECT id, loan_id, reference_id, amount, created_at, updated_at
-			FROM repayments
-			WHERE reference_id = ?;`
+	selectLoanByReferenceIdQuery = selectLoanColumns + `WHERE reference_id = ? AND ` + tenantFilter + ` ORDER BY id DESC;`
 
-	selectRepaymentByLoanId = `SELECT id, loan_id, reference_id, amount, created_at, updated_at
-			FROM repayments
-			WHERE loan_id = ? ORDER BY id DESC;`
+	selectLoanByIdQuery = selectLoanColumns + `WHERE id = ? AND ` + tenantFilter + `;`
+
+	selectActiveLoanByReferenceIdQuery = selectLoanColumns + `WHERE reference_id = ? and status=1 AND ` + tenantFilter + `;`
+
+	selectLoanByUserIdQuery = selectLoanColumns + `WHERE user_id = ? AND ` + tenantFilter + ` ORDER BY id DESC;`
+
+	// the loans a change is about to update, locked until the change and its
+	// audit entry are committed
+	selectLoanByReferenceIdForUpdateQuery = selectLoanColumns + `WHERE reference_id = ? AND ` + tenantFilter + ` ORDER BY id ASC FOR UPDATE;`
+
+	selectLoanByIdForUpdateQuery = selectLoanColumns + `WHERE id = ? AND ` + tenantFilter + ` FOR UPDATE;`
+
+	selectRepaymentColumns = `SELECT id, loan_id, reference_id, amount, status, created_at, updated_at, tenant_id, currency
+			FROM repayments `
+
+	selectRepaymentByReferenceId = selectRepaymentColumns + `WHERE reference_id = ? AND ` + tenantFilter + `;`
+
+	selectRepaymentByLoanId = selectRepaymentColumns + `WHERE loan_id = ? AND ` + tenantFilter + ` ORDER BY id DESC;`
+
+	selectRepaymentByIdForUpdateQuery = selectRepaymentColumns + `WHERE id = ? AND ` + tenantFilter + ` FOR UPDATE;`
 
 	selectTotalRepaymentAmountByLoanId = `SELECT IFNULL(SUM(amount), 0)
 			FROM repayments
-			WHERE loan_id = ?;`
+			WHERE loan_id = ? AND status = 'posted' AND ` + tenantFilter + `;`
 
 	selectRepaymentCountByLoanId = `SELECT IFNULL(COUNT(id),0)
 			FROM repayments
-			WHERE loan_id = ?;`
+			WHERE loan_id = ? AND status = 'posted' AND ` + tenantFilter + `;`
+
+	updateLoanStatusByReferenceId = `UPDATE loans SET status = ? WHERE reference_id = ? AND ` + tenantFilter + `;`
 
-	updateLoanStatusByReferenceId = `UPDATE loans SET status = ? WHERE reference_id = ?;`
+	updateRepaymentStatusById = `UPDATE repayments SET status = ? WHERE id = ? AND ` + tenantFilter + `;`
 )
 
 func (r *DBRepository) CreateLoan(ctx context.Context, tx interfaces.AtomicTransaction, loan entities.Loan) (int64, error) {
 	logger := ctx.Value("logger").(*logrus.Entry)
 	logger.Debug("Inserting loan into database: ", loan)
-	var (
-		err    error
-		result sql.Result
-	)
-
-	if tx != nil {
-		result, err = tx.ExecContext(ctx, insertLoanQuery,
-			loan.ReferenceId, loan.UserId, loan.Amount, loan.RatePercentage, loan.RepaymentAmount, loan.Status, loan.Tenor, loan.RepaymentSchedule)
-	} else {
-		result, err = r.DB.ExecContext(ctx, insertLoanQuery,
-			loan.ReferenceId, loan.UserId, loan.Amount, loan.RatePercentage, loan.RepaymentAmount, loan.Status, loan.Tenor, loan.RepaymentSchedule)
-	}
+	var id int64
+
+	args := append([]interface{}{tenantIdOf(ctx, loan.TenantId), loan.ReferenceId, loan.UserId, loan.Amount, currencyOf(loan.Currency),
+		loan.RatePercentage, loan.RepaymentAmount, loan.Status, loan.Tenor, loan.RepaymentSchedule}, borrowerArgs(loan.Borrower)...)
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		result, err := tx.ExecContext(ctx, insertLoanQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		id, err = result.LastInsertId()
+		if err != nil {
+			logger.Error("Error getting last insert ID: ", err)
+			return nil, err
+		}
+		return []auditChange{loanCreated(ctx, entities.AuditLoanCreated, id, loan)}, nil
+	})
 	if err != nil {
 		logger.Error("Error creating loan: ", err)
 		return 0, err
 	}
-	id, err := result.LastInsertId()
-	if err != nil {
-		logger.Error("Error getting last insert ID: ", err)
-		return 0, err
-	}
 	return id, nil
 }
 
@@ -88,7 +109,7 @@ func (r *DBRepository) SelectLoanByReferenceId(ctx context.Context, referenceID
 		loan loansTable
 	)
 
-	err = r.DB.GetContext(ctx, &loan, selectLoanByReferenceIdQuery, referenceID)
+	err = r.DB.GetContext(ctx, &loan, selectLoanByReferenceIdQuery, withTenant(ctx, referenceID)...)
 	if err != nil {
 		logger.Error("SelectLoanByReferenceId: ", err)
 		if err == sql.ErrNoRows {
@@ -101,6 +122,50 @@ func (r *DBRepository) SelectLoanByReferenceId(ctx context.Context, referenceID
 
 }
 
+// SelectLoansByReferenceId returns every loan with the reference id the ctx
+// sees, the operator may see one per tenant
+func (r *DBRepository) SelectLoansByReferenceId(ctx context.Context, referenceID string) (*[]entities.Loan, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select loans by reference id: ", referenceID)
+	var (
+		err  error
+		loan = []loansTable{}
+	)
+
+	err = r.DB.SelectContext(ctx, &loan, selectLoanByReferenceIdQuery, withTenant(ctx, referenceID)...)
+	if err != nil {
+		logger.Error("SelectLoansByReferenceId: ", err)
+		return nil, err
+	}
+
+	resp := make([]entities.Loan, len(loan))
+	for i, l := range loan {
+		resp[i] = *l.toEntities()
+	}
+
+	return &resp, nil
+}
+
+func (r *DBRepository) SelectLoanById(ctx context.Context, id int64) (*entities.Loan, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select loan by id: ", id)
+	var (
+		err  error
+		loan loansTable
+	)
+
+	err = r.DB.GetContext(ctx, &loan, selectLoanByIdQuery, withTenant(ctx, id)...)
+	if err != nil {
+		logger.Error("SelectLoanById: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return loan.toEntities(), nil
+}
+
 func (r *DBRepository) SelectActiveLoanByReferenceId(ctx context.Context, referenceID string) (*entities.Loan, error) {
 	logger := ctx.Value("logger").(*logrus.Entry)
 	logger.Debug("select loan by reference id: ", referenceID)
@@ -109,7 +174,7 @@ func (r *DBRepository) SelectActiveLoanByReferenceId(ctx context.Context, refere
 		loan loansTable
 	)
 
-	err = r.DB.GetContext(ctx, &loan, selectActiveLoanByReferenceIdQuery, referenceID)
+	err = r.DB.GetContext(ctx, &loan, selectActiveLoanByReferenceIdQuery, withTenant(ctx, referenceID)...)
 	if err != nil {
 		logger.Error("SelectActiveLoanByReferenceId: ", err)
 		if err == sql.ErrNoRows {
@@ -130,7 +195,7 @@ func (r *DBRepository) SelectLoanByUserId(ctx context.Context, userId int64) (*[
 		loan = []loansTable{}
 	)
 
-	err = r.DB.SelectContext(ctx, &loan, selectLoanByUserIdQuery, userId)
+	err = r.DB.SelectContext(ctx, &loan, selectLoanByUserIdQuery, withTenant(ctx, userId)...)
 	if err != nil {
 		logger.Error("SelectLoanByUserId: ", err)
 		if err == sql.ErrNoRows {
@@ -151,26 +216,26 @@ func (r *DBRepository) SelectLoanByUserId(ctx context.Context, userId int64) (*[
 func (r *DBRepository) CreateRepayment(ctx context.Context, tx interfaces.AtomicTransaction, repayment entities.Repayment) (int64, error) {
 	logger := ctx.Value("logger").(*logrus.Entry)
 	logger.Debug("Inserting loan repayment database: ", repayment)
-	var (
-		err    error
-		result sql.Result
-	)
-
-	if tx != nil {
-		result, err = tx.ExecContext(ctx, insertRepaymentQuery, repayment.LoanId, repayment.ReferenceId, repayment.Amount)
-	} else {
-		result, err = r.DB.ExecContext(ctx, insertRepaymentQuery,
-			repayment.LoanId, repayment.ReferenceId, repayment.Amount)
-	}
+	var id int64
+
+	args := []interface{}{tenantIdOf(ctx, repayment.TenantId), repayment.LoanId, repayment.ReferenceId, repayment.Amount,
+		currencyOf(repayment.Currency), nullTime(repayment.CreatedAt)}
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		result, err := tx.ExecContext(ctx, insertRepaymentQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		id, err = result.LastInsertId()
+		if err != nil {
+			logger.Error("Error getting last insert ID: ", err)
+			return nil, err
+		}
+		return []auditChange{repaymentCreated(ctx, entities.AuditRepaymentCreated, id, repayment)}, nil
+	})
 	if err != nil {
 		logger.Error("Error creating loan: ", err)
 		return 0, err
 	}
-	id, err := result.LastInsertId()
-	if err != nil {
-		logger.Error("Error getting last insert ID: ", err)
-		return 0, err
-	}
 	return id, nil
 }
 
@@ -182,7 +247,7 @@ func (r *DBRepository) SelectRepaymentByReferenceId(ctx context.Context, referen
 		repayment = repaymentTable{}
 	)
 
-	err = r.DB.GetContext(ctx, &repayment, selectRepaymentByReferenceId, referenceID)
+	err = r.DB.GetContext(ctx, &repayment, selectRepaymentByReferenceId, withTenant(ctx, referenceID)...)
 	if err != nil {
 		logger.Error("SelectRepaymentByReferenceId: ", err)
 		if err == sql.ErrNoRows {
@@ -203,7 +268,7 @@ func (r *DBRepository) SelectRepaymentByLoanId(ctx context.Context, loanId int64
 		repayments []repaymentTable
 	)
 
-	err = r.DB.SelectContext(ctx, &repayments, selectRepaymentByLoanId, loanId)
+	err = r.DB.SelectContext(ctx, &repayments, selectRepaymentByLoanId, withTenant(ctx, loanId)...)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			logger.Error("Error SelectRepaymentByLoanId: ", err)
@@ -227,7 +292,7 @@ func (r *DBRepository) SelectTotalRepaymentAmountByLoanId(ctx context.Context, l
 		totalRepayment int64
 	)
 
-	err = r.DB.GetContext(ctx, &totalRepayment, selectTotalRepaymentAmountByLoanId, loanId)
+	err = r.DB.GetContext(ctx, &totalRepayment, selectTotalRepaymentAmountByLoanId, withTenant(ctx, loanId)...)
 	if err != nil {
 		logger.Error("Error fetching SelectTotalRepaymentAmountByLoanId: ", err)
 		if err == sql.ErrNoRows {
@@ -247,7 +312,7 @@ func (r *DBRepository) SelectRepaymentCountByLoanId(ctx context.Context, loanId
 		totalRepayment int
 	)
 
-	err = r.DB.GetContext(ctx, &totalRepayment, selectRepaymentCountByLoanId, loanId)
+	err = r.DB.GetContext(ctx, &totalRepayment, selectRepaymentCountByLoanId, withTenant(ctx, loanId)...)
 	if err != nil {
 		logger.Error("Error SelectRepaymentCountByLoanId: ", err)
 		if err == sql.ErrNoRows {
@@ -263,26 +328,69 @@ func (r *DBRepository) UpdateLoanStatusByReferenceId(ctx context.Context, tx int
 	logger := ctx.Value("logger").(*logrus.Entry)
 	logger.Debug(fmt.Sprintf("Update loan status by reference id: %v, status: %v", referenceId, status))
 
-	var (
-		err error
-		row sql.Result
-	)
-
-	if tx != nil {
-		row, err = tx.ExecContext(ctx, updateLoanStatusByReferenceId, status, referenceId)
-	} else {
-		row, err = r.DB.ExecContext(ctx, updateLoanStatusByReferenceId, status, referenceId)
-	}
-
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		var before, after []loansTable
+		err := sqlx.SelectContext(ctx, tx, &before, selectLoanByReferenceIdForUpdateQuery, withTenant(ctx, referenceId)...)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.ExecContext(ctx, updateLoanStatusByReferenceId, withTenant(ctx, status, referenceId)...)
+		if err != nil {
+			return nil, err
+		}
+		err = sqlx.SelectContext(ctx, tx, &after, selectLoanByReferenceIdForUpdateQuery, withTenant(ctx, referenceId)...)
+		if err != nil {
+			return nil, err
+		}
+		return loansUpdated(entities.AuditLoanStatusUpdated, before, after), nil
+	})
 	if err != nil {
 		logger.Error("Error UpdateLoanStatusByReferenceId: ", err)
 		return err
 	}
-	if row == nil {
-		err = errs.Wrap(http.StatusNotFound, err)
-		logger.Error("Error UpdateLoanStatusByReferenceId: ", err)
+
+	return nil
+}
+
+func (r *DBRepository) UpdateRepaymentStatusById(ctx context.Context, tx interfaces.AtomicTransaction, id int64, status entities.RepaymentStatus) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug(fmt.Sprintf("Update repayment status by id: %v, status: %v", id, status))
+
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		var before, after []repaymentTable
+		err := sqlx.SelectContext(ctx, tx, &before, selectRepaymentByIdForUpdateQuery, withTenant(ctx, id)...)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.ExecContext(ctx, updateRepaymentStatusById, withTenant(ctx, status, id)...)
+		if err != nil {
+			return nil, err
+		}
+		err = sqlx.SelectContext(ctx, tx, &after, selectRepaymentByIdForUpdateQuery, withTenant(ctx, id)...)
+		if err != nil {
+			return nil, err
+		}
+		return repaymentsUpdated(entities.AuditRepaymentStatusUpdated, before, after), nil
+	})
+	if err != nil {
+		logger.Error("Error UpdateRepaymentStatusById: ", err)
 		return err
 	}
 
 	return nil
 }
+
+// currencyOf is the currency a new row is stored in, a row created without
+// one is in the default currency
+func currencyOf(currency string) string {
+	if currency == "" {
+		return entities.DefaultCurrency
+	}
+	return currency
+}
+
+// borrowerArgs are the borrower columns of a new loan, encrypted as they are
+// passed to the query
+func borrowerArgs(borrower entities.Borrower) []interface{} {
+	return []interface{}{encryptedString(borrower.Name), encryptedString(borrower.NationalId), encryptedString(borrower.BankAccount)}
+}
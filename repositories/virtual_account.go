@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+const (
+	insertVirtualAccountQuery = `INSERT INTO virtual_accounts
+			(loan_id, bank_code, number, status)
+			VALUES(?,?,?,?);`
+
+	selectVirtualAccountColumns = `SELECT v.id, v.loan_id, v.bank_code, v.number, v.status, v.created_at, v.updated_at
+			FROM virtual_accounts v
+			JOIN loans l ON l.id = v.loan_id `
+
+	selectVirtualAccountByNumberQuery = selectVirtualAccountColumns + `WHERE v.number = ? AND ` + loanTenantFilter + `;`
+
+	selectVirtualAccountByLoanIdQuery = selectVirtualAccountColumns + `WHERE v.loan_id = ? AND ` + loanTenantFilter + ` ORDER BY v.id DESC;`
+)
+
+func (r *DBRepository) CreateVirtualAccount(ctx context.Context, tx interfaces.AtomicTransaction, virtualAccount entities.VirtualAccount) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting virtual account into database: ", virtualAccount)
+	var id int64
+
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		result, err := tx.ExecContext(ctx, insertVirtualAccountQuery,
+			virtualAccount.LoanId, virtualAccount.BankCode, virtualAccount.Number, virtualAccount.Status)
+		if err != nil {
+			return nil, err
+		}
+		id, err = result.LastInsertId()
+		if err != nil {
+			logger.Error("Error getting last insert ID: ", err)
+			return nil, err
+		}
+		virtualAccount.Id = id
+		return []auditChange{{
+			Action:     entities.AuditVirtualAccountCreated,
+			EntityType: "virtual_account",
+			EntityId:   id,
+			LoanId:     virtualAccount.LoanId,
+			After:      virtualAccount,
+		}}, nil
+	})
+	if err != nil {
+		logger.Error("Error creating virtual account: ", err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *DBRepository) SelectVirtualAccountByNumber(ctx context.Context, number string) (*entities.VirtualAccount, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select virtual account by number: ", number)
+	var (
+		err            error
+		virtualAccount virtualAccountTable
+	)
+
+	err = r.DB.GetContext(ctx, &virtualAccount, selectVirtualAccountByNumberQuery, withTenant(ctx, number)...)
+	if err != nil {
+		logger.Error("SelectVirtualAccountByNumber: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return virtualAccount.toEntities(), nil
+}
+
+func (r *DBRepository) SelectVirtualAccountByLoanId(ctx context.Context, loanId int64) (*[]entities.VirtualAccount, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select virtual account by loan id: ", loanId)
+	var (
+		err             error
+		virtualAccounts = []virtualAccountTable{}
+	)
+
+	err = r.DB.SelectContext(ctx, &virtualAccounts, selectVirtualAccountByLoanIdQuery, withTenant(ctx, loanId)...)
+	if err != nil {
+		logger.Error("SelectVirtualAccountByLoanId: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	resp := make([]entities.VirtualAccount, len(virtualAccounts))
+	for i, va := range virtualAccounts {
+		resp[i] = *va.toEntities()
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,306 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+const (
+	insertWebhookSubscriptionQuery = `INSERT INTO webhook_subscriptions
+			(client_key, url, event_types, secret, is_active)
+			VALUES(?,?,?,?,?);`
+
+	// a subscription belongs to the tenant of its client
+	selectWebhookSubscriptionColumns = `SELECT s.id, s.client_key, s.url, s.event_types, s.secret, s.is_active, s.created_at, s.updated_at
+			FROM webhook_subscriptions s
+			JOIN api_clients c ON c.client_key = s.client_key `
+
+	selectWebhookSubscriptionByIdQuery = selectWebhookSubscriptionColumns + `WHERE s.id = ? AND ` + clientTenantFilter + `;`
+
+	selectWebhookSubscriptionByClientKeyQuery = selectWebhookSubscriptionColumns +
+		`WHERE s.client_key = ? AND ` + clientTenantFilter + ` ORDER BY s.id DESC;`
+
+	// the subscriptions of every client of the tenant
+	selectWebhookSubscriptionByTenantIdQuery = selectWebhookSubscriptionColumns + `WHERE c.tenant_id = ? AND c.is_active = 1 ORDER BY s.id ASC;`
+
+	updateWebhookSubscriptionStatusQuery = `UPDATE webhook_subscriptions SET is_active = ? WHERE id = ?;`
+
+	insertWebhookDeliveryQuery = `INSERT INTO webhook_deliveries
+			(subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at)
+			VALUES(?,?,?,?,?,?,?);`
+
+	selectWebhookDeliveryColumns = `SELECT d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+			d.next_attempt_at, d.last_response_code, d.last_error, d.delivered_at, d.created_at, d.updated_at
+			FROM webhook_deliveries d
+			JOIN webhook_subscriptions s ON s.id = d.subscription_id
+			JOIN api_clients c ON c.client_key = s.client_key `
+
+	selectWebhookDeliveryByIdQuery = selectWebhookDeliveryColumns + `WHERE d.id = ? AND ` + clientTenantFilter + `;`
+
+	selectWebhookDeliveryBySubscriptionIdQuery = selectWebhookDeliveryColumns +
+		`WHERE d.subscription_id = ? AND ` + clientTenantFilter + ` ORDER BY d.id DESC;`
+
+	selectDueWebhookDeliveryQuery = `SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+			last_response_code, last_error, delivered_at, created_at, updated_at
+			FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= ? ORDER BY next_attempt_at LIMIT ?;`
+
+	updateWebhookDeliveryQuery = `UPDATE webhook_deliveries
+			SET status = ?, attempts = ?, next_attempt_at = ?, last_response_code = ?, last_error = ?, delivered_at = ?
+			WHERE id = ?;`
+
+	insertWebhookDeliveryAttemptQuery = `INSERT INTO webhook_delivery_attempts
+			(delivery_id, attempt, response_code, error)
+			VALUES(?,?,?,?);`
+)
+
+func (r *DBRepository) CreateWebhookSubscription(ctx context.Context, tx interfaces.AtomicTransaction, subscription entities.WebhookSubscription) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting webhook subscription into database: ", subscription.ClientKey, subscription.Url)
+	var (
+		err    error
+		result sql.Result
+	)
+
+	args := []interface{}{subscription.ClientKey, subscription.Url, strings.Join(subscription.EventTypes, ","),
+		encryptedString(subscription.Secret), subscription.IsActive}
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, insertWebhookSubscriptionQuery, args...)
+	} else {
+		result, err = r.DB.ExecContext(ctx, insertWebhookSubscriptionQuery, args...)
+	}
+	if err != nil {
+		logger.Error("Error creating webhook subscription: ", err)
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error getting last insert ID: ", err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *DBRepository) SelectWebhookSubscriptionById(ctx context.Context, id int64) (*entities.WebhookSubscription, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select webhook subscription by id: ", id)
+	var (
+		err          error
+		subscription webhookSubscriptionTable
+	)
+
+	err = r.DB.GetContext(ctx, &subscription, selectWebhookSubscriptionByIdQuery, withTenant(ctx, id)...)
+	if err != nil {
+		logger.Error("SelectWebhookSubscriptionById: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return subscription.toEntities(), nil
+}
+
+func (r *DBRepository) SelectWebhookSubscriptionByClientKey(ctx context.Context, clientKey string) (*[]entities.WebhookSubscription, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select webhook subscription by client key: ", clientKey)
+	var (
+		err           error
+		subscriptions = []webhookSubscriptionTable{}
+	)
+
+	err = r.DB.SelectContext(ctx, &subscriptions, selectWebhookSubscriptionByClientKeyQuery, withTenant(ctx, clientKey)...)
+	if err != nil {
+		logger.Error("SelectWebhookSubscriptionByClientKey: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	resp := make([]entities.WebhookSubscription, len(subscriptions))
+	for i, s := range subscriptions {
+		resp[i] = *s.toEntities()
+	}
+
+	return &resp, nil
+}
+
+// SelectWebhookSubscriptionByTenantId returns the subscriptions of the active
+// clients of the tenant
+func (r *DBRepository) SelectWebhookSubscriptionByTenantId(ctx context.Context, tenantId int64) (*[]entities.WebhookSubscription, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select webhook subscription by tenant id: ", tenantId)
+	subscriptions := []webhookSubscriptionTable{}
+
+	err := r.DB.SelectContext(ctx, &subscriptions, selectWebhookSubscriptionByTenantIdQuery, tenantId)
+	if err != nil {
+		logger.Error("SelectWebhookSubscriptionByTenantId: ", err)
+		return nil, err
+	}
+
+	resp := make([]entities.WebhookSubscription, len(subscriptions))
+	for i, s := range subscriptions {
+		resp[i] = *s.toEntities()
+	}
+
+	return &resp, nil
+}
+
+func (r *DBRepository) UpdateWebhookSubscriptionStatus(ctx context.Context, tx interfaces.AtomicTransaction, id int64, isActive bool) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug(fmt.Sprintf("Update webhook subscription status by id: %v, is active: %v", id, isActive))
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, updateWebhookSubscriptionStatusQuery, isActive, id)
+	} else {
+		_, err = r.DB.ExecContext(ctx, updateWebhookSubscriptionStatusQuery, isActive, id)
+	}
+	if err != nil {
+		logger.Error("Error UpdateWebhookSubscriptionStatus: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *DBRepository) CreateWebhookDelivery(ctx context.Context, tx interfaces.AtomicTransaction, delivery entities.WebhookDelivery) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting webhook delivery into database: ", delivery.SubscriptionId, delivery.EventId)
+	var (
+		err    error
+		result sql.Result
+	)
+
+	args := []interface{}{delivery.SubscriptionId, delivery.EventId, delivery.EventType, delivery.Payload, delivery.Status, delivery.Attempts, nullTime(delivery.NextAttemptAt)}
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, insertWebhookDeliveryQuery, args...)
+	} else {
+		result, err = r.DB.ExecContext(ctx, insertWebhookDeliveryQuery, args...)
+	}
+	if err != nil {
+		logger.Error("Error creating webhook delivery: ", err)
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error getting last insert ID: ", err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *DBRepository) SelectWebhookDeliveryById(ctx context.Context, id int64) (*entities.WebhookDelivery, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select webhook delivery by id: ", id)
+	var (
+		err      error
+		delivery webhookDeliveryTable
+	)
+
+	err = r.DB.GetContext(ctx, &delivery, selectWebhookDeliveryByIdQuery, withTenant(ctx, id)...)
+	if err != nil {
+		logger.Error("SelectWebhookDeliveryById: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return delivery.toEntities(), nil
+}
+
+func (r *DBRepository) SelectWebhookDeliveryBySubscriptionId(ctx context.Context, subscriptionId int64) (*[]entities.WebhookDelivery, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select webhook delivery by subscription id: ", subscriptionId)
+
+	return r.selectWebhookDeliveries(ctx, logger, selectWebhookDeliveryBySubscriptionIdQuery, withTenant(ctx, subscriptionId)...)
+}
+
+func (r *DBRepository) SelectDueWebhookDelivery(ctx context.Context, now time.Time, limit int) (*[]entities.WebhookDelivery, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select due webhook delivery at: ", now)
+
+	return r.selectWebhookDeliveries(ctx, logger, selectDueWebhookDeliveryQuery, now, limit)
+}
+
+func (r *DBRepository) selectWebhookDeliveries(ctx context.Context, logger *logrus.Entry, query string, args ...interface{}) (*[]entities.WebhookDelivery, error) {
+	var (
+		err        error
+		deliveries = []webhookDeliveryTable{}
+	)
+
+	err = r.DB.SelectContext(ctx, &deliveries, query, args...)
+	if err != nil {
+		logger.Error("Error selecting webhook deliveries: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	resp := make([]entities.WebhookDelivery, len(deliveries))
+	for i, d := range deliveries {
+		resp[i] = *d.toEntities()
+	}
+
+	return &resp, nil
+}
+
+func (r *DBRepository) UpdateWebhookDelivery(ctx context.Context, tx interfaces.AtomicTransaction, delivery entities.WebhookDelivery) error {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug(fmt.Sprintf("Update webhook delivery id: %v, status: %v", delivery.Id, delivery.Status))
+
+	var err error
+
+	args := []interface{}{delivery.Status, delivery.Attempts, nullTime(delivery.NextAttemptAt), delivery.LastResponseCode,
+		delivery.LastError, nullTime(delivery.DeliveredAt), delivery.Id}
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, updateWebhookDeliveryQuery, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, updateWebhookDeliveryQuery, args...)
+	}
+	if err != nil {
+		logger.Error("Error UpdateWebhookDelivery: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *DBRepository) CreateWebhookDeliveryAttempt(ctx context.Context, tx interfaces.AtomicTransaction, attempt entities.WebhookDeliveryAttempt) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting webhook delivery attempt into database: ", attempt.DeliveryId, attempt.Attempt)
+	var (
+		err    error
+		result sql.Result
+	)
+
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, insertWebhookDeliveryAttemptQuery, attempt.DeliveryId, attempt.Attempt, attempt.ResponseCode, attempt.Error)
+	} else {
+		result, err = r.DB.ExecContext(ctx, insertWebhookDeliveryAttemptQuery, attempt.DeliveryId, attempt.Attempt, attempt.ResponseCode, attempt.Error)
+	}
+	if err != nil {
+		logger.Error("Error creating webhook delivery attempt: ", err)
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error getting last insert ID: ", err)
+		return 0, err
+	}
+	return id, nil
+}
@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+const (
+	insertWriteOffQuery = `INSERT INTO loan_write_offs
+			(loan_id, loan_reference_id, amount, days_past_due, write_off_type, reason)
+			VALUES(?,?,?,?,?,?);`
+
+	selectWriteOffColumns = `SELECT w.id, w.loan_id, w.loan_reference_id, w.amount, w.days_past_due, w.write_off_type, w.reason, w.created_at
+			FROM loan_write_offs w
+			JOIN loans l ON l.id = w.loan_id `
+
+	selectWriteOffByLoanIdQuery = selectWriteOffColumns + `WHERE w.loan_id = ? AND ` + loanTenantFilter + `;`
+
+	selectWriteOffByLoanIdForUpdateQuery = selectWriteOffColumns + `WHERE w.loan_id = ? AND ` + loanTenantFilter + ` FOR UPDATE;`
+
+	insertRecoveryQuery = `INSERT INTO loan_recoveries (loan_id, reference_id, amount) VALUES(?,?,?);`
+
+	selectRecoveryColumns = `SELECT r.id, r.loan_id, r.reference_id, r.amount, r.created_at
+			FROM loan_recoveries r
+			JOIN loans l ON l.id = r.loan_id `
+
+	selectRecoveryByReferenceIdQuery = selectRecoveryColumns + `WHERE r.reference_id = ? AND ` + loanTenantFilter + `;`
+
+	selectRecoveryByLoanIdQuery = selectRecoveryColumns + `WHERE r.loan_id = ? AND ` + loanTenantFilter + ` ORDER BY r.id ASC;`
+)
+
+func (r *DBRepository) CreateWriteOff(ctx context.Context, tx interfaces.AtomicTransaction, writeOff entities.WriteOff) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting write-off into database: ", writeOff.LoanReferenceId)
+	var id int64
+
+	args := []interface{}{writeOff.LoanId, writeOff.LoanReferenceId, writeOff.Amount, writeOff.DaysPastDue,
+		writeOff.Type, writeOff.Reason}
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		res, err := tx.ExecContext(ctx, insertWriteOffQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		id, err = res.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		writeOff.Id = id
+		return []auditChange{{
+			Action:     entities.AuditWriteOffCreated,
+			EntityType: "write_off",
+			EntityId:   id,
+			LoanId:     writeOff.LoanId,
+			After:      writeOff,
+		}}, nil
+	})
+	if err != nil {
+		logger.Error("Error CreateWriteOff: ", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *DBRepository) SelectWriteOffByLoanId(ctx context.Context, loanId int64) (*entities.WriteOff, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select write-off by loan id: ", loanId)
+	var (
+		err      error
+		writeOff writeOffTable
+	)
+
+	err = r.DB.GetContext(ctx, &writeOff, selectWriteOffByLoanIdQuery, withTenant(ctx, loanId)...)
+	if err != nil {
+		logger.Error("SelectWriteOffByLoanId: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return writeOff.toEntities(), nil
+}
+
+// SelectWriteOffByLoanIdForUpdate locks the write-off of the loan until the
+// transaction ends
+func (r *DBRepository) SelectWriteOffByLoanIdForUpdate(ctx context.Context, tx interfaces.AtomicTransaction, loanId int64) (*entities.WriteOff, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select write-off by loan id for update: ", loanId)
+	var writeOff writeOffTable
+
+	ext, ok := tx.(sqlx.ExtContext)
+	if !ok {
+		return nil, errors.New("locking a write-off needs a transaction opened with BeginTx")
+	}
+	err := sqlx.GetContext(ctx, ext, &writeOff, selectWriteOffByLoanIdForUpdateQuery, withTenant(ctx, loanId)...)
+	if err != nil {
+		logger.Error("SelectWriteOffByLoanIdForUpdate: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return writeOff.toEntities(), nil
+}
+
+func (r *DBRepository) CreateRecovery(ctx context.Context, tx interfaces.AtomicTransaction, recovery entities.Recovery) (int64, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("Inserting recovery into database: ", recovery.ReferenceId)
+	var id int64
+
+	args := []interface{}{recovery.LoanId, recovery.ReferenceId, recovery.Amount}
+	err := r.audited(ctx, tx, func(tx sqlx.ExtContext) ([]auditChange, error) {
+		res, err := tx.ExecContext(ctx, insertRecoveryQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		id, err = res.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		recovery.Id = id
+		return []auditChange{{
+			Action:     entities.AuditRecoveryCreated,
+			EntityType: "recovery",
+			EntityId:   id,
+			LoanId:     recovery.LoanId,
+			After:      recovery,
+		}}, nil
+	})
+	if err != nil {
+		logger.Error("Error CreateRecovery: ", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *DBRepository) SelectRecoveryByReferenceId(ctx context.Context, referenceId string) (*entities.Recovery, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select recovery by reference id: ", referenceId)
+	var (
+		err      error
+		recovery recoveryTable
+	)
+
+	err = r.DB.GetContext(ctx, &recovery, selectRecoveryByReferenceIdQuery, withTenant(ctx, referenceId)...)
+	if err != nil {
+		logger.Error("SelectRecoveryByReferenceId: ", err)
+		if err == sql.ErrNoRows {
+			err = errs.Wrap(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+
+	return recovery.toEntities(), nil
+}
+
+func (r *DBRepository) SelectRecoveryByLoanId(ctx context.Context, loanId int64) (*[]entities.Recovery, error) {
+	logger := ctx.Value("logger").(*logrus.Entry)
+	logger.Debug("select recovery by loan id: ", loanId)
+	var (
+		err        error
+		recoveries = []recoveryTable{}
+	)
+
+	err = r.DB.SelectContext(ctx, &recoveries, selectRecoveryByLoanIdQuery, withTenant(ctx, loanId)...)
+	if err != nil {
+		logger.Error("SelectRecoveryByLoanId: ", err)
+		return nil, err
+	}
+
+	resp := make([]entities.Recovery, len(recoveries))
+	for i, l := range recoveries {
+		resp[i] = *l.toEntities()
+	}
+
+	return &resp, nil
+}
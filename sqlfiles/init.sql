@@ -2,35 +2,538 @@ CREATE DATABASE IF NOT EXISTS BillingEngine;
 
 USE BillingEngine;
 
--- Create the loans table
+-- Create the tenants table, the lending partners the engine runs for and their settings
+CREATE TABLE tenants
+(
+	id                      BIGINT AUTO_INCREMENT PRIMARY KEY,
+	code                    VARCHAR(64)  NOT NULL UNIQUE,
+	name                    VARCHAR(255) NOT NULL,
+	timezone                VARCHAR(64)  NOT NULL,
+	currency                CHAR(3)      NOT NULL,
+	delinquent_after_missed INT          NOT NULL,
+	created_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at              TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP
+);
+
+INSERT INTO tenants (id, code, name, timezone, currency, delinquent_after_missed)
+VALUES (1, 'default', 'Default', 'Asia/Jakarta', 'IDR', 2);
+
+-- Create the loans table, reference ids are unique per tenant and amounts are in the minor unit of the currency, whole rupiah for IDR
 CREATE TABLE loans
 (
 	id                 BIGINT AUTO_INCREMENT PRIMARY KEY,
-	reference_id       VARCHAR(255) NOT NULL UNIQUE,
+	tenant_id          BIGINT       NOT NULL DEFAULT 1,
+	reference_id       VARCHAR(255) NOT NULL,
 	user_id            BIGINT       NOT NULL,
 	amount             BIGINT       NOT NULL,
+	currency           CHAR(3)      NOT NULL DEFAULT 'IDR',
 	rate_percentage    INT          NOT NULL,
 	repayment_amount   BIGINT       NOT NULL,
 	repayment_schedule VARCHAR(10)  NOT NULL,
 	status             INT          NOT NULL,
 	tenor              INT          NOT NULL,
 	created_at         TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-	updated_at         TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP
+	updated_at         TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	-- the current schedule once the loan is rescheduled, see loan_schedule_versions
+	schedule_version    INT       NOT NULL DEFAULT 1,
+	schedule_start_at   TIMESTAMP NULL DEFAULT NULL,
+	installments_offset INT       NOT NULL DEFAULT 0,
+	paid_offset         BIGINT    NOT NULL DEFAULT 0,
+	-- personal data of the borrower, sealed with the encryption keyring
+	borrower_name         VARBINARY(512) NULL DEFAULT NULL,
+	borrower_national_id  VARBINARY(512) NULL DEFAULT NULL,
+	borrower_bank_account VARBINARY(512) NULL DEFAULT NULL,
+	UNIQUE KEY uniq_tenant_reference_id (tenant_id, reference_id)
 );
 
--- Create the repayments table
+-- Create the repayments table, reference ids are unique per tenant
 CREATE TABLE repayments
+(
+	id           BIGINT AUTO_INCREMENT PRIMARY KEY,
+	tenant_id    BIGINT       NOT NULL DEFAULT 1,
+	loan_id      BIGINT       NOT NULL,
+	reference_id VARCHAR(255) NOT NULL,
+	amount       BIGINT       NOT NULL,
+	currency     CHAR(3)      NOT NULL DEFAULT 'IDR',
+	status       VARCHAR(20)  NOT NULL DEFAULT 'posted',
+	created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at   TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	UNIQUE KEY uniq_tenant_reference_id (tenant_id, reference_id)
+);
+
+-- Create the API clients table, the secrets are stored encrypted
+CREATE TABLE api_clients
+(
+	id                         BIGINT AUTO_INCREMENT PRIMARY KEY,
+	tenant_id                  BIGINT         NOT NULL DEFAULT 1,
+	client_key                 VARCHAR(64)    NOT NULL UNIQUE,
+	name                       VARCHAR(255)   NOT NULL,
+	scopes                     VARCHAR(255)   NOT NULL,
+	requests_per_minute        INT            NOT NULL DEFAULT 0,
+	burst                      INT            NOT NULL DEFAULT 0,
+	secret                     VARBINARY(512) NOT NULL,
+	previous_secret            VARBINARY(512) NULL DEFAULT NULL,
+	previous_secret_expires_at TIMESTAMP      NULL DEFAULT NULL,
+	is_active                  TINYINT(1)     NOT NULL DEFAULT 1,
+	created_at                 TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at                 TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Create the auth failures table, the signed requests that were turned away
+CREATE TABLE auth_failures
+(
+	id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+	client_key VARCHAR(64)  NOT NULL DEFAULT '',
+	method     VARCHAR(10)  NOT NULL,
+	path       VARCHAR(255) NOT NULL,
+	reason     VARCHAR(50)  NOT NULL,
+	source_ip  VARCHAR(45)  NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	INDEX idx_client_key_created_at (client_key, created_at),
+	INDEX idx_client_key_source_ip_created_at (client_key, source_ip, created_at)
+);
+
+-- Create the rate limit buckets table, the shared token buckets per client and route.
+-- tat is the theoretical arrival time of the next request in unix microseconds
+CREATE TABLE rate_limit_buckets
+(
+	bucket_key VARCHAR(255) PRIMARY KEY,
+	tat        BIGINT       NOT NULL
+);
+
+-- Create the request nonces table, the nonces of signed requests are kept until they expire
+CREATE TABLE request_nonces
+(
+	client_key VARCHAR(64) NOT NULL,
+	nonce      VARCHAR(64) NOT NULL,
+	expires_at TIMESTAMP   NOT NULL,
+	PRIMARY KEY (client_key, nonce),
+	INDEX idx_expires_at (expires_at)
+);
+
+-- Create the webhook subscriptions table, the signing secrets are stored encrypted
+CREATE TABLE webhook_subscriptions
+(
+	id          BIGINT AUTO_INCREMENT PRIMARY KEY,
+	client_key  VARCHAR(255)   NOT NULL,
+	url         VARCHAR(2048)  NOT NULL,
+	event_types VARCHAR(1024)  NOT NULL,
+	secret      VARBINARY(512) NOT NULL,
+	is_active   TINYINT(1)     NOT NULL DEFAULT 1,
+	created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at  TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Create the webhook deliveries table, one row per event sent to a subscription
+CREATE TABLE webhook_deliveries
+(
+	id                 BIGINT AUTO_INCREMENT PRIMARY KEY,
+	subscription_id    BIGINT        NOT NULL,
+	event_id           VARCHAR(64)   NOT NULL,
+	event_type         VARCHAR(64)   NOT NULL,
+	payload            TEXT          NOT NULL,
+	status             VARCHAR(20)   NOT NULL,
+	attempts           INT           NOT NULL DEFAULT 0,
+	next_attempt_at    TIMESTAMP     NULL DEFAULT NULL,
+	last_response_code INT           NOT NULL DEFAULT 0,
+	last_error         VARCHAR(1024) NOT NULL DEFAULT '',
+	delivered_at       TIMESTAMP     NULL DEFAULT NULL,
+	created_at         TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at         TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Create the webhook delivery attempts table, the log of every send
+CREATE TABLE webhook_delivery_attempts
+(
+	id            BIGINT AUTO_INCREMENT PRIMARY KEY,
+	delivery_id   BIGINT        NOT NULL,
+	attempt       INT           NOT NULL,
+	response_code INT           NOT NULL DEFAULT 0,
+	error         VARCHAR(1024) NOT NULL DEFAULT '',
+	created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create the virtual accounts table, bank accounts a borrower pays a loan into
+CREATE TABLE virtual_accounts
+(
+	id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+	loan_id    BIGINT      NOT NULL,
+	bank_code  VARCHAR(20) NOT NULL,
+	number     VARCHAR(64) NOT NULL UNIQUE,
+	status     VARCHAR(20) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Create the debit mandates table, a borrower's authorization to debit their bank account
+CREATE TABLE debit_mandates
+(
+	id             BIGINT AUTO_INCREMENT PRIMARY KEY,
+	loan_id        BIGINT       NOT NULL,
+	bank_code      VARCHAR(20)  NOT NULL,
+	account_number VARCHAR(64)  NOT NULL,
+	account_name   VARCHAR(255) NOT NULL DEFAULT '',
+	status         VARCHAR(20)  NOT NULL,
+	created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at     TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Create the promises to pay table, a loan has at most one open promise at a time
+CREATE TABLE promises_to_pay
+(
+	id            BIGINT AUTO_INCREMENT PRIMARY KEY,
+	loan_id       BIGINT       NOT NULL,
+	amount        BIGINT       NOT NULL,
+	promised_date DATE         NOT NULL,
+	agent         VARCHAR(255) NOT NULL,
+	status        VARCHAR(20)  NOT NULL,
+	paid_amount   BIGINT       NOT NULL DEFAULT 0,
+	resolved_at   TIMESTAMP    NULL DEFAULT NULL,
+	created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create the dunning levels table, the level every loan being dunned is at
+CREATE TABLE loan_dunning_levels
+(
+	id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+	loan_id         BIGINT        NOT NULL UNIQUE,
+	level           INT           NOT NULL,
+	days_past_due   INT           NOT NULL,
+	is_overridden   TINYINT(1)    NOT NULL DEFAULT 0,
+	override_reason VARCHAR(1024) NOT NULL DEFAULT '',
+	business_date   DATE          NOT NULL,
+	created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at      TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Create the dunning actions table, the work queue of reminders, calls and visits
+CREATE TABLE dunning_actions
+(
+	id            BIGINT AUTO_INCREMENT PRIMARY KEY,
+	loan_id       BIGINT      NOT NULL,
+	level         INT         NOT NULL,
+	action        VARCHAR(30) NOT NULL,
+	days_past_due INT         NOT NULL,
+	business_date DATE        NOT NULL,
+	status        VARCHAR(20) NOT NULL DEFAULT 'pending',
+	created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at    TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Create the notification preferences table, the locale and opt-outs of every borrower
+CREATE TABLE notification_preferences
+(
+	id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+	tenant_id  BIGINT       NOT NULL DEFAULT 1,
+	user_id    BIGINT       NOT NULL,
+	locale     VARCHAR(10)  NOT NULL,
+	opted_out  VARCHAR(100) NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	UNIQUE KEY uniq_tenant_user_id (tenant_id, user_id)
+);
+
+-- Create the notification deliveries table, the log of every message sent to a borrower
+CREATE TABLE notification_deliveries
+(
+	id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+	tenant_id  BIGINT        NOT NULL DEFAULT 1,
+	user_id    BIGINT        NOT NULL,
+	loan_id    BIGINT        NOT NULL,
+	channel    VARCHAR(10)   NOT NULL,
+	kind       VARCHAR(30)   NOT NULL,
+	reference  VARCHAR(100)  NOT NULL,
+	locale     VARCHAR(10)   NOT NULL,
+	subject    VARCHAR(255)  NOT NULL DEFAULT '',
+	body       TEXT          NOT NULL,
+	status     VARCHAR(20)   NOT NULL,
+	error      VARCHAR(1024) NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create the debit instructions table, one row per installment collected by direct debit
+CREATE TABLE debit_instructions
+(
+	id                 BIGINT AUTO_INCREMENT PRIMARY KEY,
+	mandate_id         BIGINT       NOT NULL,
+	loan_id            BIGINT       NOT NULL,
+	reference_id       VARCHAR(255) NOT NULL UNIQUE,
+	installment_number INT          NOT NULL,
+	amount             BIGINT       NOT NULL,
+	due_date           TIMESTAMP    NOT NULL,
+	status             VARCHAR(20)  NOT NULL,
+	attempts           INT          NOT NULL DEFAULT 0,
+	reason_code        VARCHAR(64)  NOT NULL DEFAULT '',
+	next_attempt_at    TIMESTAMP    NULL DEFAULT NULL,
+	repayment_id       BIGINT       NOT NULL DEFAULT 0,
+	created_at         TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at         TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Create the job leases table, only the instance holding a live lease runs the job
+CREATE TABLE job_leases
+(
+	job_name    VARCHAR(100) PRIMARY KEY,
+	owner       VARCHAR(255) NOT NULL DEFAULT '',
+	lease_until TIMESTAMP    NULL DEFAULT NULL,
+	updated_at  TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Create the job runs table, the history of every scheduled job per business date
+CREATE TABLE job_runs
+(
+	id            BIGINT AUTO_INCREMENT PRIMARY KEY,
+	job_name      VARCHAR(100)  NOT NULL,
+	business_date DATE          NOT NULL,
+	status        VARCHAR(20)   NOT NULL,
+	triggered_by  VARCHAR(20)   NOT NULL,
+	owner         VARCHAR(255)  NOT NULL DEFAULT '',
+	attempts      INT           NOT NULL DEFAULT 0,
+	error         VARCHAR(1024) NOT NULL DEFAULT '',
+	started_at    TIMESTAMP     NULL DEFAULT NULL,
+	finished_at   TIMESTAMP     NULL DEFAULT NULL,
+	created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at    TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	UNIQUE KEY uniq_job_name_business_date (job_name, business_date)
+);
+
+-- Create the loan daily snapshot table, the end of day state of every loan
+CREATE TABLE loan_daily_snapshot
+(
+	id                    BIGINT AUTO_INCREMENT PRIMARY KEY,
+	business_date         DATE         NOT NULL,
+	loan_id               BIGINT       NOT NULL,
+	loan_reference_id     VARCHAR(255) NOT NULL,
+	user_id               BIGINT       NOT NULL,
+	status                INT          NOT NULL,
+	installments_paid     INT          NOT NULL,
+	principal_outstanding BIGINT       NOT NULL,
+	interest_outstanding  BIGINT       NOT NULL,
+	penalty_outstanding   BIGINT       NOT NULL DEFAULT 0,
+	days_past_due         INT          NOT NULL DEFAULT 0,
+	aging_bucket          VARCHAR(20)  NOT NULL,
+	created_at            TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at            TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	UNIQUE KEY uniq_business_date_loan_id (business_date, loan_id)
+);
+
+-- Create the statements table, the generated statement is kept as JSON
+CREATE TABLE statements
+(
+	id           BIGINT AUTO_INCREMENT PRIMARY KEY,
+	tenant_id    BIGINT     NOT NULL DEFAULT 1,
+	user_id      BIGINT     NOT NULL,
+	period_start DATE       NOT NULL,
+	period_end   DATE       NOT NULL,
+	content      MEDIUMTEXT NOT NULL,
+	created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE KEY uniq_tenant_user_id_period_start (tenant_id, user_id, period_start)
+);
+
+-- Create the receipt sequences table, the last receipt number a tenant issued in every month
+CREATE TABLE receipt_sequences
+(
+	tenant_id   BIGINT  NOT NULL,
+	period      CHAR(6) NOT NULL,
+	last_number BIGINT  NOT NULL,
+	PRIMARY KEY (tenant_id, period)
+);
+
+-- Create the receipts table, one receipt per repayment
+CREATE TABLE receipts
+(
+	id                     BIGINT AUTO_INCREMENT PRIMARY KEY,
+	tenant_id              BIGINT        NOT NULL DEFAULT 1,
+	receipt_number         VARCHAR(32)   NOT NULL,
+	repayment_id           BIGINT        NOT NULL UNIQUE,
+	repayment_reference_id VARCHAR(255)  NOT NULL,
+	loan_id                BIGINT        NOT NULL,
+	loan_reference_id      VARCHAR(255)  NOT NULL,
+	user_id                BIGINT        NOT NULL,
+	installment_number     INT           NOT NULL,
+	amount                 BIGINT        NOT NULL,
+	currency               CHAR(3)       NOT NULL DEFAULT 'IDR',
+	principal              BIGINT        NOT NULL,
+	interest               BIGINT        NOT NULL,
+	fees                   BIGINT        NOT NULL DEFAULT 0,
+	status                 VARCHAR(20)   NOT NULL,
+	cancellation_note      VARCHAR(1024) NOT NULL DEFAULT '',
+	issued_at              TIMESTAMP     NOT NULL,
+	cancelled_at           TIMESTAMP     NULL DEFAULT NULL,
+	created_at             TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at             TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	UNIQUE KEY uniq_tenant_receipt_number (tenant_id, receipt_number),
+	UNIQUE KEY uniq_tenant_repayment_reference_id (tenant_id, repayment_reference_id)
+);
+
+-- Create the settlement files table, every bank settlement file that was uploaded
+CREATE TABLE settlement_files
+(
+	id          BIGINT AUTO_INCREMENT PRIMARY KEY,
+	bank_code   VARCHAR(20)  NOT NULL,
+	format      VARCHAR(20)  NOT NULL,
+	file_name   VARCHAR(255) NOT NULL DEFAULT '',
+	checksum    CHAR(64)     NOT NULL,
+	status      VARCHAR(20)  NOT NULL,
+	total_lines INT          NOT NULL DEFAULT 0,
+	posted      INT          NOT NULL DEFAULT 0,
+	duplicates  INT          NOT NULL DEFAULT 0,
+	exceptions  INT          NOT NULL DEFAULT 0,
+	created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at  TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	UNIQUE KEY uniq_bank_code_checksum (bank_code, checksum)
+);
+
+-- Create the settlement exceptions table, settlement lines waiting for operations
+CREATE TABLE settlement_exceptions
+(
+	id                     BIGINT AUTO_INCREMENT PRIMARY KEY,
+	file_id                BIGINT        NOT NULL,
+	line_number            INT           NOT NULL,
+	bank_code              VARCHAR(20)   NOT NULL,
+	transaction_id         VARCHAR(255)  NOT NULL DEFAULT '',
+	loan_reference_id      VARCHAR(255)  NOT NULL DEFAULT '',
+	virtual_account_number VARCHAR(64)   NOT NULL DEFAULT '',
+	amount                 BIGINT        NOT NULL DEFAULT 0,
+	paid_at                TIMESTAMP     NULL DEFAULT NULL,
+	reason                 VARCHAR(1024) NOT NULL DEFAULT '',
+	status                 VARCHAR(20)   NOT NULL,
+	repayment_id           BIGINT        NOT NULL DEFAULT 0,
+	note                   VARCHAR(1024) NOT NULL DEFAULT '',
+	created_at             TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at             TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	UNIQUE KEY uniq_file_id_line_number (file_id, line_number),
+	INDEX idx_status (status)
+);
+
+-- Create the bank statement lines table, money received according to the bank and its matching repayment
+CREATE TABLE bank_statement_lines
+(
+	id             BIGINT AUTO_INCREMENT PRIMARY KEY,
+	bank_code      VARCHAR(20)   NOT NULL,
+	transaction_id VARCHAR(255)  NOT NULL,
+	value_date     DATE          NOT NULL,
+	amount         BIGINT        NOT NULL,
+	description    VARCHAR(1024) NOT NULL DEFAULT '',
+	status         VARCHAR(20)   NOT NULL,
+	repayment_id   BIGINT        NULL DEFAULT NULL UNIQUE,
+	match_type     VARCHAR(20)   NOT NULL DEFAULT '',
+	reason         VARCHAR(1024) NOT NULL DEFAULT '',
+	note           VARCHAR(1024) NOT NULL DEFAULT '',
+	created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at     TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	UNIQUE KEY uniq_bank_code_transaction_id (bank_code, transaction_id),
+	INDEX idx_value_date (value_date)
+);
+
+-- Create the loan write-offs table, the amount still owed when a loan was written off
+CREATE TABLE loan_write_offs
+(
+	id                BIGINT AUTO_INCREMENT PRIMARY KEY,
+	loan_id           BIGINT        NOT NULL UNIQUE,
+	loan_reference_id VARCHAR(255)  NOT NULL,
+	amount            BIGINT        NOT NULL,
+	days_past_due     INT           NOT NULL,
+	write_off_type    VARCHAR(20)   NOT NULL,
+	reason            VARCHAR(1024) NOT NULL DEFAULT '',
+	created_at        TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create the loan recoveries table, money collected after a loan was written off
+CREATE TABLE loan_recoveries
 (
 	id           BIGINT AUTO_INCREMENT PRIMARY KEY,
 	loan_id      BIGINT       NOT NULL,
 	reference_id VARCHAR(255) NOT NULL UNIQUE,
 	amount       BIGINT       NOT NULL,
 	created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-	updated_at   TIMESTAMP DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP
+	INDEX idx_loan_id (loan_id)
 );
 
+-- Create the loan schedule versions table, the terms a loan was repaid under before and after each reschedule
+CREATE TABLE loan_schedule_versions
+(
+	id                    BIGINT AUTO_INCREMENT PRIMARY KEY,
+	loan_id               BIGINT        NOT NULL,
+	version               INT           NOT NULL,
+	change_type           VARCHAR(20)   NOT NULL,
+	principal             BIGINT        NOT NULL,
+	rate_percentage       INT           NOT NULL,
+	tenor                 INT           NOT NULL,
+	repayment_amount      BIGINT        NOT NULL,
+	repayment_schedule    VARCHAR(10)   NOT NULL,
+	installments_before   INT           NOT NULL,
+	paid_before           BIGINT        NOT NULL,
+	start_at              TIMESTAMP     NULL DEFAULT NULL,
+	-- only set for a deferral
+	deferred_installments INT           NOT NULL DEFAULT 0,
+	deferral_interest     VARCHAR(20)   NOT NULL DEFAULT '',
+	deferred_interest     BIGINT        NOT NULL DEFAULT 0,
+	reason                VARCHAR(1024) NOT NULL DEFAULT '',
+	closed_at             TIMESTAMP     NULL DEFAULT NULL,
+	created_at            TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE KEY uniq_loan_id_version (loan_id, version)
+);
+
+-- Create the audit log table, every change of a loan, a repayment or the setup of a tenant.
+-- Entries are chained with hash, ids are given from audit_log_head so they follow without gaps
+CREATE TABLE audit_log
+(
+	id            BIGINT       PRIMARY KEY,
+	tenant_id     BIGINT       NOT NULL DEFAULT 1,
+	actor_type    VARCHAR(16)  NOT NULL,
+	actor         VARCHAR(255) NOT NULL,
+	action        VARCHAR(64)  NOT NULL,
+	entity_type   VARCHAR(64)  NOT NULL,
+	entity_id     BIGINT       NOT NULL,
+	loan_id       BIGINT       NOT NULL DEFAULT 0,
+	user_id       BIGINT       NOT NULL DEFAULT 0,
+	-- kept as text, byte for byte as it was hashed
+	before_json   MEDIUMTEXT   NULL,
+	after_json    MEDIUMTEXT   NULL,
+	request_id    VARCHAR(64)  NOT NULL DEFAULT '',
+	previous_hash CHAR(64)     NOT NULL,
+	hash          CHAR(64)     NOT NULL,
+	created_at    DATETIME(6)  NOT NULL,
+	INDEX idx_tenant_loan_id (tenant_id, loan_id, id),
+	INDEX idx_tenant_user_id (tenant_id, user_id, id)
+);
+
+-- Create the audit log head table, the single row locked while an entry is chained
+CREATE TABLE audit_log_head
+(
+	id        TINYINT  PRIMARY KEY,
+	last_id   BIGINT   NOT NULL,
+	last_hash CHAR(64) NOT NULL
+);
+
+INSERT INTO audit_log_head (id, last_id, last_hash) VALUES (1, 0, '');
+
 -- Add indexes for faster queries in descending order
-CREATE INDEX idx_user_id ON loans (user_id DESC);
+CREATE INDEX idx_tenant_user_id ON loans (tenant_id, user_id DESC);
 CREATE INDEX idx_reference_id ON loans (reference_id DESC);
 CREATE INDEX idx_loan_id ON repayments (loan_id DESC);
 CREATE INDEX idx_reference_id ON repayments (reference_id DESC);
+CREATE INDEX idx_client_key ON webhook_subscriptions (client_key);
+CREATE INDEX idx_subscription_id ON webhook_deliveries (subscription_id DESC);
+CREATE INDEX idx_status_next_attempt_at ON webhook_deliveries (status, next_attempt_at);
+CREATE INDEX idx_delivery_id ON webhook_delivery_attempts (delivery_id);
+CREATE INDEX idx_loan_id ON virtual_accounts (loan_id DESC);
+CREATE INDEX idx_loan_id ON debit_mandates (loan_id DESC);
+CREATE INDEX idx_status ON debit_mandates (status);
+CREATE INDEX idx_loan_id ON debit_instructions (loan_id DESC);
+CREATE INDEX idx_loan_id_status ON promises_to_pay (loan_id, status);
+CREATE INDEX idx_loan_id ON dunning_actions (loan_id DESC);
+CREATE INDEX idx_status_action ON dunning_actions (status, action);
+CREATE INDEX idx_status ON promises_to_pay (status);
+CREATE INDEX idx_user_id ON notification_deliveries (user_id DESC);
+CREATE INDEX idx_kind_reference ON notification_deliveries (kind, reference);
+CREATE INDEX idx_status_next_attempt_at ON debit_instructions (status, next_attempt_at);
+CREATE INDEX idx_loan_id_created_at ON repayments (loan_id, created_at);
+CREATE INDEX idx_created_at ON loans (created_at);
+
+-- The audit log is append only
+CREATE TRIGGER audit_log_no_update BEFORE UPDATE ON audit_log FOR EACH ROW
+	SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_log is append only';
+CREATE TRIGGER audit_log_no_delete BEFORE DELETE ON audit_log FOR EACH ROW
+	SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_log is append only';
@@ -0,0 +1,186 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const defaultRotationGracePeriod = 24 * time.Hour
+
+// CreateClient registers a client with a new key and secret. The secret is
+// only returned here and when it is rotated.
+func (u *ApiClientUseCase) CreateClient(ctx context.Context, request entities.ApiClientRequest) (*entities.ApiClient, error) {
+	var errMessage []string
+
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" {
+		errMessage = append(errMessage, "name can not be empty")
+	}
+	if len(request.Scopes) == 0 {
+		errMessage = append(errMessage, "scopes can not be empty")
+	}
+	var scopes []entities.ApiScope
+	for _, scope := range request.Scopes {
+		if !scope.IsValid() {
+			errMessage = append(errMessage, "scope "+string(scope)+" is not supported")
+			continue
+		}
+		if !containsScope(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	errMessage = append(errMessage, validateRateLimit(request.RateLimit)...)
+	if errMessage != nil || len(errMessage) != 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	clientKey, err := helper.GenerateRandomString(16)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := helper.GenerateRandomString(32)
+	if err != nil {
+		return nil, err
+	}
+
+	client := entities.ApiClient{
+		ClientKey: clientKey,
+		Name:      request.Name,
+		Scopes:    scopes,
+		RateLimit: request.RateLimit,
+		Secret:    secret,
+		IsActive:  true,
+	}
+	client.Id, err = u.ApiClientRepo.CreateApiClient(ctx, nil, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &client, nil
+}
+
+func (u *ApiClientUseCase) GetClientList(ctx context.Context) (*[]entities.ApiClient, error) {
+	return u.ApiClientRepo.SelectApiClients(ctx)
+}
+
+func (u *ApiClientUseCase) DisableClient(ctx context.Context, clientKey string) error {
+	if clientKey == "" {
+		return errs.NewWithMessage(http.StatusBadRequest, "client key can not be empty")
+	}
+
+	client, err := u.ApiClientRepo.SelectApiClientByClientKey(ctx, clientKey)
+	if err != nil {
+		return err
+	}
+
+	return u.ApiClientRepo.UpdateApiClientStatus(ctx, nil, client.ClientKey, false)
+}
+
+// SetRateLimit changes how often the client may call each route, a zero
+// limit puts it back on the default of the route
+func (u *ApiClientUseCase) SetRateLimit(ctx context.Context, request entities.ApiClientRateLimitRequest) error {
+	if request.ClientKey == "" {
+		return errs.NewWithMessage(http.StatusBadRequest, "client key can not be empty")
+	}
+	if errMessage := validateRateLimit(request.RateLimit); len(errMessage) != 0 {
+		return errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	client, err := u.ApiClientRepo.SelectApiClientByClientKey(ctx, request.ClientKey)
+	if err != nil {
+		return err
+	}
+
+	return u.ApiClientRepo.UpdateApiClientRateLimit(ctx, nil, client.ClientKey, request.RateLimit)
+}
+
+// RotateSecret gives the client a new secret. The secret it replaces keeps
+// working for the grace period, a secret replaced by an earlier rotation
+// stops working right away.
+func (u *ApiClientUseCase) RotateSecret(ctx context.Context, clientKey string) (*entities.ApiClient, error) {
+	if clientKey == "" {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "client key can not be empty")
+	}
+
+	client, err := u.ApiClientRepo.SelectApiClientByClientKey(ctx, clientKey)
+	if err != nil {
+		return nil, err
+	}
+	if !client.IsActive {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "client is disabled")
+	}
+
+	secret, err := helper.GenerateRandomString(32)
+	if err != nil {
+		return nil, err
+	}
+
+	gracePeriod := u.RotationGracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = defaultRotationGracePeriod
+	}
+	client.PreviousSecret = client.Secret
+	client.PreviousSecretExpiresAt = u.Clock.Now().Add(gracePeriod)
+	client.Secret = secret
+
+	err = u.ApiClientRepo.UpdateApiClientSecret(ctx, nil, *client)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// GetClientCredentials is what a request signed by the client is checked
+// against. An unknown or disabled client is unauthorized.
+func (u *ApiClientUseCase) GetClientCredentials(ctx context.Context, clientKey string) (*entities.ClientCredentials, error) {
+	client, err := u.ApiClientRepo.SelectApiClientByClientKey(ctx, clientKey)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+		return nil, errs.NewWithMessage(http.StatusUnauthorized, "Invalid Client-Key")
+	}
+	if !client.IsActive {
+		return nil, errs.NewWithMessage(http.StatusUnauthorized, "Invalid Client-Key")
+	}
+
+	credentials := &entities.ClientCredentials{
+		ClientKey: client.ClientKey,
+		TenantId:  client.TenantId,
+		Secrets:   []string{client.Secret},
+		Scopes:    client.Scopes,
+		RateLimit: client.RateLimit,
+	}
+	if client.PreviousSecret != "" && u.Clock.Now().Before(client.PreviousSecretExpiresAt) {
+		credentials.Secrets = append(credentials.Secrets, client.PreviousSecret)
+	}
+
+	return credentials, nil
+}
+
+func validateRateLimit(limit entities.RateLimit) []string {
+	var errMessage []string
+	if limit.RequestsPerMinute < 0 || limit.Burst < 0 {
+		errMessage = append(errMessage, "rate limit can not be negative")
+	}
+	if (limit.RequestsPerMinute == 0) != (limit.Burst == 0) {
+		errMessage = append(errMessage, "rate limit needs both requests per minute and burst")
+	}
+	return errMessage
+}
+
+func containsScope(scopes []entities.ApiScope, scope entities.ApiScope) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
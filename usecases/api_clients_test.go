@@ -0,0 +1,471 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+func TestApiClientUseCase_CreateClient(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.ApiClientRequest
+	}
+	type fields struct {
+		ApiClientRepo *mock_usecase.MockApiClientRepository
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientRepo: mock_usecase.NewMockApiClientRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.ApiClientRequest{
+					Name:   " partner ",
+					Scopes: []entities.ApiScope{entities.ScopeRead, entities.ScopePayments, entities.ScopeRead},
+				},
+			},
+			mock: func(f fields, args input) {
+				f.ApiClientRepo.EXPECT().CreateApiClient(gomock.Any(), nil, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, tx interface{}, client entities.ApiClient) (int64, error) {
+						assert.Equal(t, "partner", client.Name)
+						assert.Equal(t, []entities.ApiScope{entities.ScopeRead, entities.ScopePayments}, client.Scopes)
+						assert.Len(t, client.ClientKey, 32)
+						assert.Len(t, client.Secret, 64)
+						assert.True(t, client.IsActive)
+						return 1, nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name: "error invalid request",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientRepo: mock_usecase.NewMockApiClientRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.ApiClientRequest{Scopes: []entities.ApiScope{"superuser"}},
+			},
+			mock: func(f fields, args input) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "error create api client",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientRepo: mock_usecase.NewMockApiClientRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.ApiClientRequest{Name: "partner", Scopes: []entities.ApiScope{entities.ScopeRead}},
+			},
+			mock: func(f fields, args input) {
+				f.ApiClientRepo.EXPECT().CreateApiClient(gomock.Any(), nil, gomock.Any()).Return(int64(0), errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := ApiClientUseCase{
+				ApiClientRepo: f.ApiClientRepo,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.CreateClient(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, int64(1), got.Id)
+			assert.NotEmpty(t, got.Secret)
+		})
+	}
+}
+
+func TestApiClientUseCase_RotateSecret(t *testing.T) {
+	type input struct {
+		ctx       context.Context
+		clientKey string
+	}
+	type fields struct {
+		ApiClientRepo *mock_usecase.MockApiClientRepository
+		Clock         *mock_domain.MockClock
+	}
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientRepo: mock_usecase.NewMockApiClientRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:       context.Background(),
+				clientKey: "client1",
+			},
+			mock: func(f fields, args input) {
+				f.ApiClientRepo.EXPECT().SelectApiClientByClientKey(gomock.Any(), "client1").Return(&entities.ApiClient{
+					Id:             1,
+					ClientKey:      "client1",
+					Secret:         "secret2",
+					PreviousSecret: "secret1",
+					IsActive:       true,
+				}, nil)
+				f.Clock.EXPECT().Now().Return(now)
+				f.ApiClientRepo.EXPECT().UpdateApiClientSecret(gomock.Any(), nil, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, tx interface{}, client entities.ApiClient) error {
+						assert.Equal(t, "secret2", client.PreviousSecret)
+						assert.Equal(t, now.Add(time.Hour), client.PreviousSecretExpiresAt)
+						assert.NotEqual(t, "secret2", client.Secret)
+						assert.Len(t, client.Secret, 64)
+						return nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name: "error client is disabled",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientRepo: mock_usecase.NewMockApiClientRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:       context.Background(),
+				clientKey: "client1",
+			},
+			mock: func(f fields, args input) {
+				f.ApiClientRepo.EXPECT().SelectApiClientByClientKey(gomock.Any(), "client1").Return(&entities.ApiClient{
+					Id:        1,
+					ClientKey: "client1",
+					IsActive:  false,
+				}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error client not found",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientRepo: mock_usecase.NewMockApiClientRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:       context.Background(),
+				clientKey: "client1",
+			},
+			mock: func(f fields, args input) {
+				f.ApiClientRepo.EXPECT().SelectApiClientByClientKey(gomock.Any(), "client1").Return(nil, errs.Wrap(http.StatusNotFound, errors.New("not found")))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := ApiClientUseCase{
+				ApiClientRepo:       f.ApiClientRepo,
+				Clock:               f.Clock,
+				RotationGracePeriod: time.Hour,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.RotateSecret(tt.input.ctx, tt.input.clientKey)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, "client1", got.ClientKey)
+		})
+	}
+}
+
+func TestApiClientUseCase_GetClientCredentials(t *testing.T) {
+	type input struct {
+		ctx       context.Context
+		clientKey string
+	}
+	type fields struct {
+		ApiClientRepo *mock_usecase.MockApiClientRepository
+		Clock         *mock_domain.MockClock
+	}
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.Local)
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		input    input
+		mock     func(f fields, input input)
+		want     *entities.ClientCredentials
+		wantCode int
+	}{
+		{
+			name: "success previous secret is valid during the grace period",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientRepo: mock_usecase.NewMockApiClientRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:       context.Background(),
+				clientKey: "client1",
+			},
+			mock: func(f fields, args input) {
+				f.ApiClientRepo.EXPECT().SelectApiClientByClientKey(gomock.Any(), "client1").Return(&entities.ApiClient{
+					ClientKey:               "client1",
+					Scopes:                  []entities.ApiScope{entities.ScopeRead},
+					Secret:                  "secret2",
+					PreviousSecret:          "secret1",
+					PreviousSecretExpiresAt: now.Add(time.Minute),
+					IsActive:                true,
+				}, nil)
+				f.Clock.EXPECT().Now().Return(now)
+			},
+			want: &entities.ClientCredentials{
+				ClientKey: "client1",
+				Secrets:   []string{"secret2", "secret1"},
+				Scopes:    []entities.ApiScope{entities.ScopeRead},
+			},
+		},
+		{
+			name: "success previous secret expired",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientRepo: mock_usecase.NewMockApiClientRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:       context.Background(),
+				clientKey: "client1",
+			},
+			mock: func(f fields, args input) {
+				f.ApiClientRepo.EXPECT().SelectApiClientByClientKey(gomock.Any(), "client1").Return(&entities.ApiClient{
+					ClientKey:               "client1",
+					Scopes:                  []entities.ApiScope{entities.ScopeRead},
+					Secret:                  "secret2",
+					PreviousSecret:          "secret1",
+					PreviousSecretExpiresAt: now,
+					IsActive:                true,
+				}, nil)
+				f.Clock.EXPECT().Now().Return(now)
+			},
+			want: &entities.ClientCredentials{
+				ClientKey: "client1",
+				Secrets:   []string{"secret2"},
+				Scopes:    []entities.ApiScope{entities.ScopeRead},
+			},
+		},
+		{
+			name: "error client is disabled",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientRepo: mock_usecase.NewMockApiClientRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:       context.Background(),
+				clientKey: "client1",
+			},
+			mock: func(f fields, args input) {
+				f.ApiClientRepo.EXPECT().SelectApiClientByClientKey(gomock.Any(), "client1").Return(&entities.ApiClient{
+					ClientKey: "client1",
+					Secret:    "secret1",
+				}, nil)
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "error unknown client",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientRepo: mock_usecase.NewMockApiClientRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:       context.Background(),
+				clientKey: "client9",
+			},
+			mock: func(f fields, args input) {
+				f.ApiClientRepo.EXPECT().SelectApiClientByClientKey(gomock.Any(), "client9").Return(nil, errs.Wrap(http.StatusNotFound, errors.New("not found")))
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "error select api client",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientRepo: mock_usecase.NewMockApiClientRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:       context.Background(),
+				clientKey: "client1",
+			},
+			mock: func(f fields, args input) {
+				f.ApiClientRepo.EXPECT().SelectApiClientByClientKey(gomock.Any(), "client1").Return(nil, errors.New("some error"))
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := ApiClientUseCase{
+				ApiClientRepo: f.ApiClientRepo,
+				Clock:         f.Clock,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.GetClientCredentials(tt.input.ctx, tt.input.clientKey)
+			if tt.wantCode != 0 {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantCode, errs.GetHTTPCode(err))
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestApiClientUseCase_SetRateLimit(t *testing.T) {
+	type input struct {
+		ctx     context.Context
+		request entities.ApiClientRateLimitRequest
+	}
+	type fields struct {
+		ApiClientRepo *mock_usecase.MockApiClientRepository
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientRepo: mock_usecase.NewMockApiClientRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				request: entities.ApiClientRateLimitRequest{
+					ClientKey: "client1",
+					RateLimit: entities.RateLimit{RequestsPerMinute: 120, Burst: 20},
+				},
+			},
+			mock: func(f fields, input input) {
+				f.ApiClientRepo.EXPECT().SelectApiClientByClientKey(gomock.Any(), "client1").
+					Return(&entities.ApiClient{ClientKey: "client1", IsActive: true}, nil)
+				f.ApiClientRepo.EXPECT().UpdateApiClientRateLimit(gomock.Any(), nil, "client1",
+					entities.RateLimit{RequestsPerMinute: 120, Burst: 20}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error rate limit without burst",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientRepo: mock_usecase.NewMockApiClientRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				request: entities.ApiClientRateLimitRequest{
+					ClientKey: "client1",
+					RateLimit: entities.RateLimit{RequestsPerMinute: 120},
+				},
+			},
+			mock: func(f fields, input input) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "error client not found",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ApiClientRepo: mock_usecase.NewMockApiClientRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:     context.Background(),
+				request: entities.ApiClientRateLimitRequest{ClientKey: "client1"},
+			},
+			mock: func(f fields, input input) {
+				f.ApiClientRepo.EXPECT().SelectApiClientByClientKey(gomock.Any(), "client1").
+					Return(nil, errs.Wrap(http.StatusNotFound, errors.New("not found")))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := ApiClientUseCase{
+				ApiClientRepo: f.ApiClientRepo,
+			}
+			tt.mock(f, tt.input)
+
+			err := u.SetRateLimit(tt.input.ctx, tt.input.request)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+const (
+	auditEntryListLimit         = 500
+	defaultAuditVerifyBatchSize = 1000
+)
+
+// GetLoanAuditTrail returns the latest changes of the loan and its repayments
+func (u *AuditUseCase) GetLoanAuditTrail(ctx context.Context, loanReferenceId string) (*[]entities.AuditEntry, error) {
+	if loanReferenceId == "" {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loan reference id can not be empty")
+	}
+
+	loan, err := u.AuditRepo.SelectLoanByReferenceId(ctx, loanReferenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.AuditRepo.SelectAuditEntriesByLoanId(ctx, loan.Id, auditEntryListLimit)
+}
+
+// GetUserAuditTrail returns the latest changes of every loan of the user
+func (u *AuditUseCase) GetUserAuditTrail(ctx context.Context, userId int64) (*[]entities.AuditEntry, error) {
+	if userId <= 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "user id must be greater than 0")
+	}
+
+	return u.AuditRepo.SelectAuditEntriesByUserId(ctx, userId, auditEntryListLimit)
+}
+
+// VerifyAuditChain walks the audit log from its first entry up to the head
+// read when it starts. Every entry must follow the previous one by id, carry
+// its hash and hash to what it stores, and the last one must be the head, so
+// an entry changed, removed or added behind the log's back is reported.
+func (u *AuditUseCase) VerifyAuditChain(ctx context.Context) (*entities.AuditChainVerification, error) {
+	batchSize := u.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultAuditVerifyBatchSize
+	}
+
+	headId, headHash, err := u.AuditRepo.SelectAuditHead(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := entities.AuditChainVerification{}
+	var (
+		lastId   int64
+		lastHash string
+	)
+	for lastId < headId {
+		entries, err := u.AuditRepo.SelectAuditEntriesAfterId(ctx, lastId, batchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range *entries {
+			// entries chained after the head was read are left for the next run
+			if entry.Id > headId {
+				break
+			}
+			if reason := brokenAuditLink(entry, lastId, lastHash); reason != "" {
+				result.BrokenAt, result.Reason = lastId+1, reason
+				return &result, nil
+			}
+			result.Checked++
+			lastId, lastHash = entry.Id, entry.Hash
+		}
+
+		if len(*entries) < batchSize {
+			break
+		}
+	}
+
+	switch {
+	case lastId < headId:
+		result.BrokenAt, result.Reason = lastId+1, "entry is missing"
+	case lastHash != headHash:
+		result.BrokenAt, result.Reason = headId, "hash does not match the head of the log"
+	default:
+		result.Valid = true
+	}
+
+	return &result, nil
+}
+
+// brokenAuditLink tells why the entry does not follow the previous one, or
+// returns an empty string when it does
+func brokenAuditLink(entry entities.AuditEntry, previousId int64, previousHash string) string {
+	switch {
+	case entry.Id != previousId+1:
+		return "entry is missing"
+	case entry.PreviousHash != previousHash:
+		return "previous hash does not match the previous entry"
+	case entry.ComputeHash() != entry.Hash:
+		return "hash does not match the entry"
+	}
+	return ""
+}
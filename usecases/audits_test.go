@@ -0,0 +1,315 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+)
+
+func TestAuditUseCase_GetLoanAuditTrail(t *testing.T) {
+	entries := []entities.AuditEntry{
+		{Id: 2, Action: entities.AuditRepaymentCreated, LoanId: 10, UserId: 7},
+		{Id: 1, Action: entities.AuditLoanCreated, LoanId: 10, UserId: 7},
+	}
+
+	type input struct {
+		ctx         context.Context
+		referenceId string
+	}
+	type fields struct {
+		AuditRepo *mock_usecase.MockAuditRepository
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *[]entities.AuditEntry
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuditRepo: mock_usecase.NewMockAuditRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:         context.Background(),
+				referenceId: "loan-1",
+			},
+			mock: func(f fields, args input) {
+				f.AuditRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan-1").Return(&entities.Loan{Id: 10}, nil)
+				f.AuditRepo.EXPECT().SelectAuditEntriesByLoanId(gomock.Any(), int64(10), auditEntryListLimit).Return(&entries, nil)
+			},
+			want:    &entries,
+			wantErr: false,
+		},
+		{
+			name: "error empty reference id",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuditRepo: mock_usecase.NewMockAuditRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock:    func(f fields, args input) {},
+			wantErr: true,
+		},
+		{
+			name: "error loan not found",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuditRepo: mock_usecase.NewMockAuditRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:         context.Background(),
+				referenceId: "loan-1",
+			},
+			mock: func(f fields, args input) {
+				f.AuditRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan-1").Return(nil, errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := AuditUseCase{
+				AuditRepo: f.AuditRepo,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.GetLoanAuditTrail(tt.input.ctx, tt.input.referenceId)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAuditUseCase_VerifyAuditChain(t *testing.T) {
+	// chain returns n entries hashed one after the other
+	chain := func(n int) []entities.AuditEntry {
+		entries := make([]entities.AuditEntry, n)
+		var previousHash string
+		for i := range entries {
+			entries[i] = entities.AuditEntry{
+				Id:           int64(i + 1),
+				TenantId:     1,
+				ActorType:    entities.AuditActorClient,
+				Actor:        "client-1",
+				Action:       entities.AuditLoanCreated,
+				EntityType:   "loan",
+				EntityId:     int64(i + 1),
+				LoanId:       int64(i + 1),
+				UserId:       7,
+				After:        json.RawMessage(`{"amount":1000000}`),
+				PreviousHash: previousHash,
+				CreatedAt:    time.Date(2024, 5, 1, 10, 0, i, 0, time.UTC),
+			}
+			entries[i].Hash = entries[i].ComputeHash()
+			previousHash = entries[i].Hash
+		}
+		return entries
+	}
+
+	type input struct {
+		ctx context.Context
+	}
+	type fields struct {
+		AuditRepo *mock_usecase.MockAuditRepository
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.AuditChainVerification
+		wantErr bool
+	}{
+		{
+			name: "success chain is valid",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuditRepo: mock_usecase.NewMockAuditRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+				entries := chain(3)
+				f.AuditRepo.EXPECT().SelectAuditHead(gomock.Any()).Return(int64(3), entries[2].Hash, nil)
+				first, second := entries[:2], entries[2:]
+				f.AuditRepo.EXPECT().SelectAuditEntriesAfterId(gomock.Any(), int64(0), 2).Return(&first, nil)
+				f.AuditRepo.EXPECT().SelectAuditEntriesAfterId(gomock.Any(), int64(2), 2).Return(&second, nil)
+			},
+			want:    &entities.AuditChainVerification{Checked: 3, Valid: true},
+			wantErr: false,
+		},
+		{
+			name: "success entries chained after the head are left out",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuditRepo: mock_usecase.NewMockAuditRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+				entries := chain(3)
+				f.AuditRepo.EXPECT().SelectAuditHead(gomock.Any()).Return(int64(1), entries[0].Hash, nil)
+				first := entries[:2]
+				f.AuditRepo.EXPECT().SelectAuditEntriesAfterId(gomock.Any(), int64(0), 2).Return(&first, nil)
+			},
+			want:    &entities.AuditChainVerification{Checked: 1, Valid: true},
+			wantErr: false,
+		},
+		{
+			name: "success empty log",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuditRepo: mock_usecase.NewMockAuditRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+				f.AuditRepo.EXPECT().SelectAuditHead(gomock.Any()).Return(int64(0), "", nil)
+			},
+			want:    &entities.AuditChainVerification{Valid: true},
+			wantErr: false,
+		},
+		{
+			name: "broken entry changed",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuditRepo: mock_usecase.NewMockAuditRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+				entries := chain(2)
+				entries[1].After = json.RawMessage(`{"amount":1}`)
+				f.AuditRepo.EXPECT().SelectAuditHead(gomock.Any()).Return(int64(2), entries[1].Hash, nil)
+				f.AuditRepo.EXPECT().SelectAuditEntriesAfterId(gomock.Any(), int64(0), 2).Return(&entries, nil)
+			},
+			want:    &entities.AuditChainVerification{Checked: 1, BrokenAt: 2, Reason: "hash does not match the entry"},
+			wantErr: false,
+		},
+		{
+			name: "broken entry removed",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuditRepo: mock_usecase.NewMockAuditRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+				entries := chain(3)
+				f.AuditRepo.EXPECT().SelectAuditHead(gomock.Any()).Return(int64(3), entries[2].Hash, nil)
+				remaining := []entities.AuditEntry{entries[0], entries[2]}
+				f.AuditRepo.EXPECT().SelectAuditEntriesAfterId(gomock.Any(), int64(0), 2).Return(&remaining, nil)
+			},
+			want:    &entities.AuditChainVerification{Checked: 1, BrokenAt: 2, Reason: "entry is missing"},
+			wantErr: false,
+		},
+		{
+			name: "broken entry rehashed",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuditRepo: mock_usecase.NewMockAuditRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+				entries := chain(2)
+				entries[0].Actor = "someone-else"
+				entries[0].Hash = entries[0].ComputeHash()
+				f.AuditRepo.EXPECT().SelectAuditHead(gomock.Any()).Return(int64(2), entries[1].Hash, nil)
+				f.AuditRepo.EXPECT().SelectAuditEntriesAfterId(gomock.Any(), int64(0), 2).Return(&entries, nil)
+			},
+			want:    &entities.AuditChainVerification{Checked: 1, BrokenAt: 2, Reason: "previous hash does not match the previous entry"},
+			wantErr: false,
+		},
+		{
+			name: "broken last entry removed",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuditRepo: mock_usecase.NewMockAuditRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+				entries := chain(2)
+				f.AuditRepo.EXPECT().SelectAuditHead(gomock.Any()).Return(int64(2), entries[1].Hash, nil)
+				first := entries[:1]
+				f.AuditRepo.EXPECT().SelectAuditEntriesAfterId(gomock.Any(), int64(0), 2).Return(&first, nil)
+			},
+			want:    &entities.AuditChainVerification{Checked: 1, BrokenAt: 2, Reason: "entry is missing"},
+			wantErr: false,
+		},
+		{
+			name: "error select audit head",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuditRepo: mock_usecase.NewMockAuditRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+				f.AuditRepo.EXPECT().SelectAuditHead(gomock.Any()).Return(int64(0), "", errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := AuditUseCase{
+				AuditRepo: f.AuditRepo,
+				BatchSize: 2,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.VerifyAuditChain(tt.input.ctx)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+)
+
+const (
+	defaultMaxAuthFailures   = 10
+	defaultAuthBlockWindow   = 15 * time.Minute
+	defaultAuthRetention     = 30 * 24 * time.Hour
+	authFailureListLimit     = 100
+	maxAuthFailurePathLength = 255
+)
+
+func (u *AuthAuditUseCase) RecordAuthFailure(ctx context.Context, failure entities.AuthFailure) error {
+	if len(failure.Path) > maxAuthFailurePathLength {
+		failure.Path = failure.Path[:maxAuthFailurePathLength]
+	}
+	failure.CreatedAt = u.Clock.Now()
+
+	_, err := u.AuthAuditRepo.CreateAuthFailure(ctx, nil, failure)
+	return err
+}
+
+// IsClientBlocked reports whether requests for the client key from the source
+// ip were signed wrongly too often lately. Only invalid signatures count, they
+// are recorded for known client keys alone, so a key can neither be locked out
+// from another address nor by requests that were stale or out of scope.
+// Requests without a client key are never blocked here.
+func (u *AuthAuditUseCase) IsClientBlocked(ctx context.Context, clientKey, sourceIp string) (bool, error) {
+	if clientKey == "" {
+		return false, nil
+	}
+
+	maxFailures := u.MaxFailures
+	if maxFailures <= 0 {
+		maxFailures = defaultMaxAuthFailures
+	}
+	window := u.BlockWindow
+	if window <= 0 {
+		window = defaultAuthBlockWindow
+	}
+
+	count, err := u.AuthAuditRepo.CountInvalidSignatureFailures(ctx, clientKey, sourceIp, u.Clock.Now().Add(-window))
+	if err != nil {
+		return false, err
+	}
+
+	return count >= maxFailures, nil
+}
+
+// GetAuthFailureList returns the latest failures of the client key, or of
+// every client key when it is empty
+func (u *AuthAuditUseCase) GetAuthFailureList(ctx context.Context, clientKey string) (*[]entities.AuthFailure, error) {
+	return u.AuthAuditRepo.SelectAuthFailures(ctx, clientKey, authFailureListLimit)
+}
+
+// PurgeAuthFailures deletes the failures older than the retention. The
+// retention is never shorter than the block window, so purging can not
+// unblock a client key.
+func (u *AuthAuditUseCase) PurgeAuthFailures(ctx context.Context) (int64, error) {
+	retention := u.Retention
+	if retention <= 0 {
+		retention = defaultAuthRetention
+	}
+	if retention < u.BlockWindow {
+		retention = u.BlockWindow
+	}
+
+	return u.AuthAuditRepo.PurgeAuthFailures(ctx, u.Clock.Now().Add(-retention))
+}
@@ -0,0 +1,308 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+)
+
+func TestAuthAuditUseCase_RecordAuthFailure(t *testing.T) {
+	type input struct {
+		ctx     context.Context
+		failure entities.AuthFailure
+	}
+	type fields struct {
+		AuthAuditRepo *mock_usecase.MockAuthAuditRepository
+		Clock         *mock_domain.MockClock
+	}
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuthAuditRepo: mock_usecase.NewMockAuthAuditRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				failure: entities.AuthFailure{
+					ClientKey: "client1",
+					Method:    "POST",
+					Path:      "/make/payment",
+					Reason:    entities.AuthFailureInvalidSignature,
+					SourceIp:  "10.0.0.1",
+				},
+			},
+			mock: func(f fields, input input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.AuthAuditRepo.EXPECT().CreateAuthFailure(gomock.Any(), nil, entities.AuthFailure{
+					ClientKey: "client1",
+					Method:    "POST",
+					Path:      "/make/payment",
+					Reason:    entities.AuthFailureInvalidSignature,
+					SourceIp:  "10.0.0.1",
+					CreatedAt: now,
+				}).Return(int64(1), nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error create auth failure",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuthAuditRepo: mock_usecase.NewMockAuthAuditRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:     context.Background(),
+				failure: entities.AuthFailure{ClientKey: "client1", Reason: entities.AuthFailureStaleRequest},
+			},
+			mock: func(f fields, input input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.AuthAuditRepo.EXPECT().CreateAuthFailure(gomock.Any(), nil, gomock.Any()).Return(int64(0), errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := AuthAuditUseCase{
+				AuthAuditRepo: f.AuthAuditRepo,
+				Clock:         f.Clock,
+			}
+			tt.mock(f, tt.input)
+
+			err := u.RecordAuthFailure(tt.input.ctx, tt.input.failure)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestAuthAuditUseCase_IsClientBlocked(t *testing.T) {
+	type input struct {
+		ctx       context.Context
+		clientKey string
+		sourceIp  string
+	}
+	type fields struct {
+		AuthAuditRepo *mock_usecase.MockAuthAuditRepository
+		Clock         *mock_domain.MockClock
+	}
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "blocked after max failures",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuthAuditRepo: mock_usecase.NewMockAuthAuditRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:       context.Background(),
+				clientKey: "client1",
+				sourceIp:  "10.0.0.1",
+			},
+			mock: func(f fields, input input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.AuthAuditRepo.EXPECT().CountInvalidSignatureFailures(gomock.Any(), "client1", "10.0.0.1", now.Add(-10*time.Minute)).Return(5, nil)
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "not blocked below max failures",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuthAuditRepo: mock_usecase.NewMockAuthAuditRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:       context.Background(),
+				clientKey: "client1",
+				sourceIp:  "10.0.0.1",
+			},
+			mock: func(f fields, input input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.AuthAuditRepo.EXPECT().CountInvalidSignatureFailures(gomock.Any(), "client1", "10.0.0.1", now.Add(-10*time.Minute)).Return(4, nil)
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "not blocked without client key",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuthAuditRepo: mock_usecase.NewMockAuthAuditRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, input input) {
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "error count auth failures",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuthAuditRepo: mock_usecase.NewMockAuthAuditRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:       context.Background(),
+				clientKey: "client1",
+				sourceIp:  "10.0.0.1",
+			},
+			mock: func(f fields, input input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.AuthAuditRepo.EXPECT().CountInvalidSignatureFailures(gomock.Any(), "client1", "10.0.0.1", gomock.Any()).Return(0, errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := AuthAuditUseCase{
+				AuthAuditRepo: f.AuthAuditRepo,
+				Clock:         f.Clock,
+				MaxFailures:   5,
+				BlockWindow:   10 * time.Minute,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.IsClientBlocked(tt.input.ctx, tt.input.clientKey, tt.input.sourceIp)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAuthAuditUseCase_PurgeAuthFailures(t *testing.T) {
+	type fields struct {
+		AuthAuditRepo *mock_usecase.MockAuthAuditRepository
+		Clock         *mock_domain.MockClock
+	}
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.Local)
+	tests := []struct {
+		name      string
+		fields    func(ctrl *gomock.Controller) fields
+		retention time.Duration
+		mock      func(f fields)
+		want      int64
+		wantErr   bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuthAuditRepo: mock_usecase.NewMockAuthAuditRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			retention: 24 * time.Hour,
+			mock: func(f fields) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.AuthAuditRepo.EXPECT().PurgeAuthFailures(gomock.Any(), now.Add(-24*time.Hour)).Return(int64(3), nil)
+			},
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name: "success retention shorter than the block window keeps the window",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuthAuditRepo: mock_usecase.NewMockAuthAuditRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			retention: time.Minute,
+			mock: func(f fields) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.AuthAuditRepo.EXPECT().PurgeAuthFailures(gomock.Any(), now.Add(-15*time.Minute)).Return(int64(0), nil)
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "error purge",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					AuthAuditRepo: mock_usecase.NewMockAuthAuditRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			mock: func(f fields) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.AuthAuditRepo.EXPECT().PurgeAuthFailures(gomock.Any(), now.Add(-defaultAuthRetention)).Return(int64(0), errors.New("some error"))
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := AuthAuditUseCase{
+				AuthAuditRepo: f.AuthAuditRepo,
+				Clock:         f.Clock,
+				BlockWindow:   15 * time.Minute,
+				Retention:     tt.retention,
+			}
+			tt.mock(f)
+
+			got, err := u.PurgeAuthFailures(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
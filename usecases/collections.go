@@ -0,0 +1,353 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func (u *CollectionUseCase) CreateMandate(ctx context.Context, request entities.DebitMandateRequest) (*entities.DebitMandate, error) {
+	var errMessage []string
+
+	if request.LoanReferenceId == "" {
+		errMessage = append(errMessage, "loan reference id can not be empty")
+	}
+	if request.BankCode == "" {
+		errMessage = append(errMessage, "bank code can not be empty")
+	}
+	if request.AccountNumber == "" {
+		errMessage = append(errMessage, "account number can not be empty")
+	}
+	if errMessage != nil || len(errMessage) != 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	loan, err := u.CollectionRepo.SelectLoanByReferenceId(ctx, request.LoanReferenceId)
+	if err != nil {
+		return nil, err
+	}
+	if !loan.Status.IsActive() {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loan status has been "+loan.Status.String())
+	}
+
+	mandates, err := u.CollectionRepo.SelectDebitMandateByLoanId(ctx, loan.Id)
+	if err != nil && errs.GetHTTPCode(err) != http.StatusNotFound {
+		return nil, err
+	}
+	if mandates != nil {
+		for _, m := range *mandates {
+			if m.Status == entities.MandateActive {
+				return nil, errs.NewWithMessage(http.StatusBadRequest, "loan already has an active mandate")
+			}
+		}
+	}
+
+	mandate := entities.DebitMandate{
+		LoanId:        loan.Id,
+		BankCode:      strings.ToUpper(request.BankCode),
+		AccountNumber: request.AccountNumber,
+		AccountName:   request.AccountName,
+		Status:        entities.MandateActive,
+	}
+	mandate.Id, err = u.CollectionRepo.CreateDebitMandate(ctx, nil, mandate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mandate, nil
+}
+
+func (u *CollectionUseCase) RevokeMandate(ctx context.Context, mandateId int64) error {
+	if mandateId < 1 {
+		return errs.NewWithMessage(http.StatusBadRequest, "mandate id is invalid")
+	}
+
+	mandate, err := u.CollectionRepo.SelectDebitMandateById(ctx, mandateId)
+	if err != nil {
+		return err
+	}
+	if mandate.Status == entities.MandateRevoked {
+		return nil
+	}
+
+	return u.CollectionRepo.UpdateDebitMandateStatus(ctx, nil, mandate.Id, entities.MandateRevoked)
+}
+
+func (u *CollectionUseCase) GetMandateListByLoanReferenceId(ctx context.Context, referenceId string) (*[]entities.DebitMandate, error) {
+	if referenceId == "" {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loan reference id can not be empty")
+	}
+
+	loan, err := u.CollectionRepo.SelectLoanByReferenceId(ctx, referenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	mandates, err := u.CollectionRepo.SelectDebitMandateByLoanId(ctx, loan.Id)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+		return &[]entities.DebitMandate{}, nil
+	}
+
+	return mandates, nil
+}
+
+func (u *CollectionUseCase) GetDebitInstructionListByLoanReferenceId(ctx context.Context, referenceId string) (*[]entities.DebitInstruction, error) {
+	if referenceId == "" {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loan reference id can not be empty")
+	}
+
+	loan, err := u.CollectionRepo.SelectLoanByReferenceId(ctx, referenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	instructions, err := u.CollectionRepo.SelectDebitInstructionByLoanId(ctx, loan.Id)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+		return &[]entities.DebitInstruction{}, nil
+	}
+
+	return instructions, nil
+}
+
+// RunCollection creates a debit instruction for every mandated installment due
+// on or before the collection date, then debits every instruction whose next
+// attempt is due. Running it again for the same date does not debit twice.
+// A zero collection date means today, a later one is rejected so nothing is
+// debited before it is due.
+func (u *CollectionUseCase) RunCollection(ctx context.Context, collectionDate time.Time) (*entities.CollectionRunResult, error) {
+	today, err := todayOf(ctx, u.Tenants, u.Clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if collectionDate.IsZero() {
+		collectionDate = today
+	}
+	collectionDate = helper.DateIn(collectionDate, today.Location())
+	if collectionDate.After(today) {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "collection date can not be after the business date")
+	}
+	result := &entities.CollectionRunResult{CollectionDate: collectionDate}
+
+	mandates, err := u.CollectionRepo.SelectActiveDebitMandate(ctx)
+	if err != nil && errs.GetHTTPCode(err) != http.StatusNotFound {
+		return nil, err
+	}
+	if mandates != nil {
+		for _, mandate := range *mandates {
+			created, err := u.createDueInstruction(ctx, mandate, collectionDate)
+			if err != nil {
+				return nil, err
+			}
+			if created {
+				result.Created++
+			}
+		}
+	}
+
+	instructions, err := u.CollectionRepo.SelectDueDebitInstruction(ctx, collectionDate)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	for _, instruction := range *instructions {
+		collected, err := u.collect(ctx, instruction, collectionDate)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Attempted++
+		switch collected.Status {
+		case entities.DebitInstructionSucceeded:
+			result.Succeeded++
+		case entities.DebitInstructionRetrying:
+			result.Retrying++
+		case entities.DebitInstructionFailed:
+			result.Failed++
+		case entities.DebitInstructionCancelled:
+			result.Cancelled++
+		case entities.DebitInstructionDebited:
+			result.Unposted++
+		}
+	}
+
+	return result, nil
+}
+
+func (u *CollectionUseCase) createDueInstruction(ctx context.Context, mandate entities.DebitMandate, collectionDate time.Time) (bool, error) {
+	loan, err := u.CollectionRepo.SelectLoanById(ctx, mandate.LoanId)
+	if err != nil {
+		return false, err
+	}
+	if !loan.Status.IsActive() {
+		return false, nil
+	}
+
+	repaymentCount, err := u.CollectionRepo.SelectRepaymentCountByLoanId(ctx, loan.Id)
+	if err != nil && errs.GetHTTPCode(err) != http.StatusNotFound {
+		return false, err
+	}
+	if repaymentCount >= loan.Tenor {
+		return false, nil
+	}
+
+	installmentNumber := repaymentCount + 1
+	dueDate := loan.DueDate(installmentNumber)
+	if helper.TruncateToDay(dueDate.In(collectionDate.Location())).After(collectionDate) {
+		return false, nil
+	}
+
+	instructions, err := u.CollectionRepo.SelectDebitInstructionByLoanId(ctx, loan.Id)
+	if err != nil && errs.GetHTTPCode(err) != http.StatusNotFound {
+		return false, err
+	}
+	sequence := 1
+	if instructions != nil {
+		for _, instruction := range *instructions {
+			if instruction.InstallmentNumber != installmentNumber {
+				continue
+			}
+			// only an instruction whose repayment was reversed since, as the
+			// installment is unpaid again, is collected once more
+			if instruction.Status != entities.DebitInstructionSucceeded {
+				return false, nil
+			}
+			sequence++
+		}
+	}
+	referenceId := entities.DebitInstructionReferenceId(loan.Id, installmentNumber, sequence)
+
+	_, err = u.CollectionRepo.CreateDebitInstruction(ctx, nil, entities.DebitInstruction{
+		MandateId:         mandate.Id,
+		LoanId:            loan.Id,
+		ReferenceId:       referenceId,
+		InstallmentNumber: installmentNumber,
+		Amount:            loan.RepaymentAmount,
+		DueDate:           dueDate,
+		Status:            entities.DebitInstructionPending,
+		NextAttemptAt:     collectionDate,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (u *CollectionUseCase) collect(ctx context.Context, instruction entities.DebitInstruction, collectionDate time.Time) (*entities.DebitInstruction, error) {
+	loan, err := u.CollectionRepo.SelectLoanById(ctx, instruction.LoanId)
+	if err != nil {
+		return nil, err
+	}
+	if instruction.Status == entities.DebitInstructionDebited {
+		return u.post(ctx, *loan, instruction, collectionDate)
+	}
+	mandate, err := u.CollectionRepo.SelectDebitMandateById(ctx, instruction.MandateId)
+	if err != nil {
+		return nil, err
+	}
+	repaymentCount, err := u.CollectionRepo.SelectRepaymentCountByLoanId(ctx, loan.Id)
+	if err != nil && errs.GetHTTPCode(err) != http.StatusNotFound {
+		return nil, err
+	}
+
+	switch {
+	case !loan.Status.IsActive():
+		return u.closeInstruction(ctx, instruction, entities.DebitInstructionCancelled, entities.DebitReasonLoanNotActive)
+	case repaymentCount >= instruction.InstallmentNumber:
+		return u.closeInstruction(ctx, instruction, entities.DebitInstructionCancelled, entities.DebitReasonAlreadyPaid)
+	case mandate.Status != entities.MandateActive:
+		return u.closeInstruction(ctx, instruction, entities.DebitInstructionFailed, entities.DebitReasonMandateRevoked)
+	}
+
+	instruction.Attempts++
+	debitResult, err := u.Collector.Debit(ctx, *mandate, instruction)
+	if err != nil {
+		debitResult = entities.DebitResult{Success: false, ReasonCode: entities.DebitReasonTechnicalError}
+	}
+
+	if debitResult.Success {
+		// the debit is kept before the repayment is posted, so a posting that
+		// fails is never debited again
+		instruction.Status = entities.DebitInstructionDebited
+		instruction.ReasonCode = ""
+		instruction.NextAttemptAt = collectionDate
+		if err = u.CollectionRepo.UpdateDebitInstruction(ctx, nil, instruction); err != nil {
+			return nil, err
+		}
+		return u.post(ctx, *loan, instruction, collectionDate)
+	}
+
+	if u.retryPolicy().ShouldRetry(debitResult.ReasonCode, instruction.Attempts) {
+		instruction.Status = entities.DebitInstructionRetrying
+		instruction.ReasonCode = debitResult.ReasonCode
+		instruction.NextAttemptAt = collectionDate.Add(u.retryPolicy().Interval)
+		if err = u.CollectionRepo.UpdateDebitInstruction(ctx, nil, instruction); err != nil {
+			return nil, err
+		}
+		return &instruction, nil
+	}
+
+	return u.closeInstruction(ctx, instruction, entities.DebitInstructionFailed, debitResult.ReasonCode)
+}
+
+// post records the repayment of a debited instruction. A posting that fails
+// is tried again on the next run after the retry interval, one the billing
+// rejects stays debited without a next attempt until the operator settles it.
+func (u *CollectionUseCase) post(ctx context.Context, loan entities.Loan, instruction entities.DebitInstruction, collectionDate time.Time) (*entities.DebitInstruction, error) {
+	// the loan reference id is only unique within the tenant of the loan
+	repaymentId, err := u.Payments.MakePayment(helper.WithTenantId(ctx, loan.TenantId), entities.RepaymentRequest{
+		LoanReferenceId:      loan.ReferenceId,
+		RepaymentReferenceId: instruction.ReferenceId,
+		Amount:               instruction.Amount,
+	})
+	if err == nil {
+		instruction.RepaymentId = repaymentId
+		return u.closeInstruction(ctx, instruction, entities.DebitInstructionSucceeded, "")
+	}
+
+	instruction.ReasonCode = entities.DebitReasonPostingFailed
+	instruction.NextAttemptAt = collectionDate.Add(u.retryPolicy().Interval)
+	if errs.GetHTTPCode(err) < http.StatusInternalServerError {
+		instruction.ReasonCode = entities.DebitReasonPaymentRejected
+		instruction.NextAttemptAt = time.Time{}
+	}
+	if err = u.CollectionRepo.UpdateDebitInstruction(ctx, nil, instruction); err != nil {
+		return nil, err
+	}
+
+	return &instruction, nil
+}
+
+func (u *CollectionUseCase) closeInstruction(ctx context.Context, instruction entities.DebitInstruction, status entities.DebitInstructionStatus, reasonCode string) (*entities.DebitInstruction, error) {
+	instruction.Status = status
+	instruction.ReasonCode = reasonCode
+	instruction.NextAttemptAt = time.Time{}
+
+	err := u.CollectionRepo.UpdateDebitInstruction(ctx, nil, instruction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instruction, nil
+}
+
+func (u *CollectionUseCase) retryPolicy() entities.RetryPolicy {
+	if u.RetryPolicy.MaxAttempts < 1 {
+		return entities.DefaultRetryPolicy
+	}
+	return u.RetryPolicy
+}
@@ -0,0 +1,617 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+func TestCollectionUseCase_CreateMandate(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.DebitMandateRequest
+	}
+	type fields struct {
+		CollectionRepo *mock_usecase.MockCollectionRepository
+	}
+	sampleRequest := entities.DebitMandateRequest{
+		LoanReferenceId: "loan1",
+		BankCode:        "bca",
+		AccountNumber:   "1234567890",
+		AccountName:     "John Doe",
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.DebitMandate
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: sampleRequest,
+			},
+			mock: func(f fields, args input) {
+				f.CollectionRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&entities.Loan{Id: 1, Status: entities.LoanStatusActive}, nil)
+				f.CollectionRepo.EXPECT().SelectDebitMandateByLoanId(gomock.Any(), int64(1)).Return(&[]entities.DebitMandate{
+					{Id: 2, LoanId: 1, Status: entities.MandateRevoked},
+				}, nil)
+				f.CollectionRepo.EXPECT().CreateDebitMandate(gomock.Any(), nil, entities.DebitMandate{
+					LoanId:        1,
+					BankCode:      "BCA",
+					AccountNumber: "1234567890",
+					AccountName:   "John Doe",
+					Status:        entities.MandateActive,
+				}).Return(int64(3), nil)
+			},
+			want: &entities.DebitMandate{
+				Id:            3,
+				LoanId:        1,
+				BankCode:      "BCA",
+				AccountNumber: "1234567890",
+				AccountName:   "John Doe",
+				Status:        entities.MandateActive,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error parameter",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.DebitMandateRequest{},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error active mandate exists",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: sampleRequest,
+			},
+			mock: func(f fields, args input) {
+				f.CollectionRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&entities.Loan{Id: 1, Status: entities.LoanStatusActive}, nil)
+				f.CollectionRepo.EXPECT().SelectDebitMandateByLoanId(gomock.Any(), int64(1)).Return(&[]entities.DebitMandate{
+					{Id: 2, LoanId: 1, Status: entities.MandateActive},
+				}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error loan not active",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: sampleRequest,
+			},
+			mock: func(f fields, args input) {
+				f.CollectionRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&entities.Loan{Id: 1, Status: entities.LoanStatusCompleted}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := CollectionUseCase{
+				CollectionRepo: f.CollectionRepo,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.CreateMandate(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestCollectionUseCase_RunCollection(t *testing.T) {
+	type input struct {
+		ctx            context.Context
+		collectionDate time.Time
+	}
+	type fields struct {
+		CollectionRepo *mock_usecase.MockCollectionRepository
+		Collector      *mock_domain.MockPaymentCollector
+		Payments       *mock_usecase.MockPaymentMaker
+		Clock          *mock_domain.MockClock
+		Tenants        *mock_usecase.MockTenantProvider
+	}
+	// the collection date is a business date of the default tenant
+	collectionDate := time.Date(2000, 2, 1, 0, 0, 0, 0, entities.DefaultTenant.Location())
+	loan := entities.Loan{
+		Id:                1,
+		ReferenceId:       "loan1",
+		RepaymentSchedule: entities.RepaymentMonthly,
+		Tenor:             12,
+		RepaymentAmount:   1000,
+		Status:            entities.LoanStatusActive,
+		CreatedAt:         time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	mandate := entities.DebitMandate{Id: 2, LoanId: 1, BankCode: "BCA", AccountNumber: "1234567890", Status: entities.MandateActive}
+	instruction := entities.DebitInstruction{
+		Id:                3,
+		MandateId:         2,
+		LoanId:            1,
+		ReferenceId:       "DD-1-1-1",
+		InstallmentNumber: 1,
+		Amount:            1000,
+		DueDate:           time.Date(2000, 2, 1, 0, 0, 0, 0, time.UTC),
+		Status:            entities.DebitInstructionPending,
+		NextAttemptAt:     collectionDate,
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.CollectionRunResult
+		wantErr bool
+	}{
+		{
+			name: "success debit collected",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Collector:      mock_domain.NewMockPaymentCollector(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:            context.Background(),
+				collectionDate: collectionDate.Add(10 * time.Hour),
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(collectionDate.Add(10 * time.Hour))
+				f.CollectionRepo.EXPECT().SelectActiveDebitMandate(gomock.Any()).Return(&[]entities.DebitMandate{mandate}, nil)
+				f.CollectionRepo.EXPECT().SelectLoanById(gomock.Any(), int64(1)).Return(&loan, nil).Times(2)
+				f.CollectionRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(0, nil).Times(2)
+				f.CollectionRepo.EXPECT().SelectDebitInstructionByLoanId(gomock.Any(), int64(1)).Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+				f.CollectionRepo.EXPECT().CreateDebitInstruction(gomock.Any(), nil, entities.DebitInstruction{
+					MandateId:         2,
+					LoanId:            1,
+					ReferenceId:       "DD-1-1-1",
+					InstallmentNumber: 1,
+					Amount:            1000,
+					DueDate:           time.Date(2000, 2, 1, 0, 0, 0, 0, time.UTC),
+					Status:            entities.DebitInstructionPending,
+					NextAttemptAt:     collectionDate,
+				}).Return(int64(3), nil)
+				f.CollectionRepo.EXPECT().SelectDueDebitInstruction(gomock.Any(), collectionDate).Return(&[]entities.DebitInstruction{instruction}, nil)
+				f.CollectionRepo.EXPECT().SelectDebitMandateById(gomock.Any(), int64(2)).Return(&mandate, nil)
+				f.Collector.EXPECT().Debit(gomock.Any(), mandate, gomock.Any()).Return(entities.DebitResult{Success: true}, nil)
+				debited := instruction
+				debited.Attempts = 1
+				debited.Status = entities.DebitInstructionDebited
+				f.CollectionRepo.EXPECT().UpdateDebitInstruction(gomock.Any(), nil, debited).Return(nil)
+				f.Payments.EXPECT().MakePayment(gomock.Any(), entities.RepaymentRequest{
+					LoanReferenceId:      "loan1",
+					RepaymentReferenceId: "DD-1-1-1",
+					Amount:               1000,
+				}).Return(int64(9), nil)
+				succeeded := instruction
+				succeeded.Attempts = 1
+				succeeded.Status = entities.DebitInstructionSucceeded
+				succeeded.RepaymentId = 9
+				succeeded.NextAttemptAt = time.Time{}
+				f.CollectionRepo.EXPECT().UpdateDebitInstruction(gomock.Any(), nil, succeeded).Return(nil)
+			},
+			want: &entities.CollectionRunResult{
+				CollectionDate: collectionDate,
+				Created:        1,
+				Attempted:      1,
+				Succeeded:      1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success reversed installment is collected under a new reference",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Collector:      mock_domain.NewMockPaymentCollector(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:            context.Background(),
+				collectionDate: collectionDate,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(collectionDate.Add(10 * time.Hour))
+				f.CollectionRepo.EXPECT().SelectActiveDebitMandate(gomock.Any()).Return(&[]entities.DebitMandate{mandate}, nil)
+				f.CollectionRepo.EXPECT().SelectLoanById(gomock.Any(), int64(1)).Return(&loan, nil)
+				f.CollectionRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(0, nil)
+				// the repayment of the first instruction was reversed
+				collected := instruction
+				collected.Status = entities.DebitInstructionSucceeded
+				collected.RepaymentId = 9
+				f.CollectionRepo.EXPECT().SelectDebitInstructionByLoanId(gomock.Any(), int64(1)).Return(&[]entities.DebitInstruction{collected}, nil)
+				f.CollectionRepo.EXPECT().CreateDebitInstruction(gomock.Any(), nil, entities.DebitInstruction{
+					MandateId:         2,
+					LoanId:            1,
+					ReferenceId:       "DD-1-1-2",
+					InstallmentNumber: 1,
+					Amount:            1000,
+					DueDate:           time.Date(2000, 2, 1, 0, 0, 0, 0, time.UTC),
+					Status:            entities.DebitInstructionPending,
+					NextAttemptAt:     collectionDate,
+				}).Return(int64(4), nil)
+				f.CollectionRepo.EXPECT().SelectDueDebitInstruction(gomock.Any(), collectionDate).Return(&[]entities.DebitInstruction{}, nil)
+			},
+			want: &entities.CollectionRunResult{
+				CollectionDate: collectionDate,
+				Created:        1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success installment with an open instruction is not created again",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Collector:      mock_domain.NewMockPaymentCollector(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:            context.Background(),
+				collectionDate: collectionDate,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(collectionDate.Add(10 * time.Hour))
+				f.CollectionRepo.EXPECT().SelectActiveDebitMandate(gomock.Any()).Return(&[]entities.DebitMandate{mandate}, nil)
+				f.CollectionRepo.EXPECT().SelectLoanById(gomock.Any(), int64(1)).Return(&loan, nil)
+				f.CollectionRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(0, nil)
+				debited := instruction
+				debited.Status = entities.DebitInstructionDebited
+				f.CollectionRepo.EXPECT().SelectDebitInstructionByLoanId(gomock.Any(), int64(1)).Return(&[]entities.DebitInstruction{debited}, nil)
+				f.CollectionRepo.EXPECT().SelectDueDebitInstruction(gomock.Any(), collectionDate).Return(&[]entities.DebitInstruction{}, nil)
+			},
+			want: &entities.CollectionRunResult{
+				CollectionDate: collectionDate,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success failed posting keeps the debit for the next run",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Collector:      mock_domain.NewMockPaymentCollector(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:            context.Background(),
+				collectionDate: collectionDate,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(collectionDate.Add(10 * time.Hour))
+				f.CollectionRepo.EXPECT().SelectActiveDebitMandate(gomock.Any()).Return(&[]entities.DebitMandate{}, nil)
+				f.CollectionRepo.EXPECT().SelectDueDebitInstruction(gomock.Any(), collectionDate).Return(&[]entities.DebitInstruction{instruction}, nil)
+				f.CollectionRepo.EXPECT().SelectDebitMandateById(gomock.Any(), int64(2)).Return(&mandate, nil)
+				f.CollectionRepo.EXPECT().SelectLoanById(gomock.Any(), int64(1)).Return(&loan, nil)
+				f.CollectionRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(0, nil)
+				f.Collector.EXPECT().Debit(gomock.Any(), mandate, gomock.Any()).Return(entities.DebitResult{Success: true}, nil)
+				debited := instruction
+				debited.Attempts = 1
+				debited.Status = entities.DebitInstructionDebited
+				f.CollectionRepo.EXPECT().UpdateDebitInstruction(gomock.Any(), nil, debited).Return(nil)
+				f.Payments.EXPECT().MakePayment(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("some error"))
+				unposted := debited
+				unposted.ReasonCode = entities.DebitReasonPostingFailed
+				unposted.NextAttemptAt = collectionDate.Add(24 * time.Hour)
+				f.CollectionRepo.EXPECT().UpdateDebitInstruction(gomock.Any(), nil, unposted).Return(nil)
+			},
+			want: &entities.CollectionRunResult{
+				CollectionDate: collectionDate,
+				Attempted:      1,
+				Unposted:       1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success debited instruction is posted without another debit",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Collector:      mock_domain.NewMockPaymentCollector(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:            context.Background(),
+				collectionDate: collectionDate,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(collectionDate.Add(10 * time.Hour))
+				debited := instruction
+				debited.Attempts = 1
+				debited.Status = entities.DebitInstructionDebited
+				debited.ReasonCode = entities.DebitReasonPostingFailed
+				f.CollectionRepo.EXPECT().SelectActiveDebitMandate(gomock.Any()).Return(&[]entities.DebitMandate{}, nil)
+				f.CollectionRepo.EXPECT().SelectDueDebitInstruction(gomock.Any(), collectionDate).Return(&[]entities.DebitInstruction{debited}, nil)
+				f.CollectionRepo.EXPECT().SelectLoanById(gomock.Any(), int64(1)).Return(&loan, nil)
+				f.Payments.EXPECT().MakePayment(gomock.Any(), entities.RepaymentRequest{
+					LoanReferenceId:      "loan1",
+					RepaymentReferenceId: "DD-1-1-1",
+					Amount:               1000,
+				}).Return(int64(9), nil)
+				succeeded := debited
+				succeeded.Status = entities.DebitInstructionSucceeded
+				succeeded.ReasonCode = ""
+				succeeded.RepaymentId = 9
+				succeeded.NextAttemptAt = time.Time{}
+				f.CollectionRepo.EXPECT().UpdateDebitInstruction(gomock.Any(), nil, succeeded).Return(nil)
+			},
+			want: &entities.CollectionRunResult{
+				CollectionDate: collectionDate,
+				Attempted:      1,
+				Succeeded:      1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success rejected posting is held without a next attempt",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Collector:      mock_domain.NewMockPaymentCollector(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:            context.Background(),
+				collectionDate: collectionDate,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(collectionDate.Add(10 * time.Hour))
+				debited := instruction
+				debited.Attempts = 1
+				debited.Status = entities.DebitInstructionDebited
+				f.CollectionRepo.EXPECT().SelectActiveDebitMandate(gomock.Any()).Return(&[]entities.DebitMandate{}, nil)
+				f.CollectionRepo.EXPECT().SelectDueDebitInstruction(gomock.Any(), collectionDate).Return(&[]entities.DebitInstruction{debited}, nil)
+				f.CollectionRepo.EXPECT().SelectLoanById(gomock.Any(), int64(1)).Return(&loan, nil)
+				f.Payments.EXPECT().MakePayment(gomock.Any(), gomock.Any()).Return(int64(0), errs.NewWithMessage(http.StatusBadRequest, "loan status has been completed"))
+				held := debited
+				held.ReasonCode = entities.DebitReasonPaymentRejected
+				held.NextAttemptAt = time.Time{}
+				f.CollectionRepo.EXPECT().UpdateDebitInstruction(gomock.Any(), nil, held).Return(nil)
+			},
+			want: &entities.CollectionRunResult{
+				CollectionDate: collectionDate,
+				Attempted:      1,
+				Unposted:       1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success insufficient funds is retried",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Collector:      mock_domain.NewMockPaymentCollector(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+					Tenants:        mock_usecase.NewMockTenantProvider(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(collectionDate.Add(time.Hour))
+				f.Tenants.EXPECT().GetCurrentTenant(gomock.Any()).Return(&entities.Tenant{Id: 2, Timezone: "Asia/Jakarta"}, nil)
+				f.CollectionRepo.EXPECT().SelectActiveDebitMandate(gomock.Any()).Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+				f.CollectionRepo.EXPECT().SelectDueDebitInstruction(gomock.Any(), collectionDate).Return(&[]entities.DebitInstruction{instruction}, nil)
+				f.CollectionRepo.EXPECT().SelectDebitMandateById(gomock.Any(), int64(2)).Return(&mandate, nil)
+				f.CollectionRepo.EXPECT().SelectLoanById(gomock.Any(), int64(1)).Return(&loan, nil)
+				f.CollectionRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(0, nil)
+				f.Collector.EXPECT().Debit(gomock.Any(), mandate, gomock.Any()).Return(entities.DebitResult{ReasonCode: entities.DebitReasonInsufficientFunds}, nil)
+				retrying := instruction
+				retrying.Attempts = 1
+				retrying.Status = entities.DebitInstructionRetrying
+				retrying.ReasonCode = entities.DebitReasonInsufficientFunds
+				retrying.NextAttemptAt = collectionDate.Add(24 * time.Hour)
+				f.CollectionRepo.EXPECT().UpdateDebitInstruction(gomock.Any(), nil, retrying).Return(nil)
+			},
+			want: &entities.CollectionRunResult{
+				CollectionDate: collectionDate,
+				Attempted:      1,
+				Retrying:       1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success last attempt fails",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Collector:      mock_domain.NewMockPaymentCollector(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:            context.Background(),
+				collectionDate: collectionDate,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(collectionDate.Add(10 * time.Hour))
+				retried := instruction
+				retried.Attempts = 2
+				retried.Status = entities.DebitInstructionRetrying
+				f.CollectionRepo.EXPECT().SelectActiveDebitMandate(gomock.Any()).Return(&[]entities.DebitMandate{}, nil)
+				f.CollectionRepo.EXPECT().SelectDueDebitInstruction(gomock.Any(), collectionDate).Return(&[]entities.DebitInstruction{retried}, nil)
+				f.CollectionRepo.EXPECT().SelectDebitMandateById(gomock.Any(), int64(2)).Return(&mandate, nil)
+				f.CollectionRepo.EXPECT().SelectLoanById(gomock.Any(), int64(1)).Return(&loan, nil)
+				f.CollectionRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(0, nil)
+				f.Collector.EXPECT().Debit(gomock.Any(), mandate, gomock.Any()).Return(entities.DebitResult{ReasonCode: entities.DebitReasonInsufficientFunds}, nil)
+				failed := retried
+				failed.Attempts = 3
+				failed.Status = entities.DebitInstructionFailed
+				failed.ReasonCode = entities.DebitReasonInsufficientFunds
+				failed.NextAttemptAt = time.Time{}
+				f.CollectionRepo.EXPECT().UpdateDebitInstruction(gomock.Any(), nil, failed).Return(nil)
+			},
+			want: &entities.CollectionRunResult{
+				CollectionDate: collectionDate,
+				Attempted:      1,
+				Failed:         1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success revoked mandate is not debited",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Collector:      mock_domain.NewMockPaymentCollector(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:            context.Background(),
+				collectionDate: collectionDate,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(collectionDate.Add(10 * time.Hour))
+				revoked := mandate
+				revoked.Status = entities.MandateRevoked
+				f.CollectionRepo.EXPECT().SelectActiveDebitMandate(gomock.Any()).Return(&[]entities.DebitMandate{}, nil)
+				f.CollectionRepo.EXPECT().SelectDueDebitInstruction(gomock.Any(), collectionDate).Return(&[]entities.DebitInstruction{instruction}, nil)
+				f.CollectionRepo.EXPECT().SelectDebitMandateById(gomock.Any(), int64(2)).Return(&revoked, nil)
+				f.CollectionRepo.EXPECT().SelectLoanById(gomock.Any(), int64(1)).Return(&loan, nil)
+				f.CollectionRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(0, nil)
+				failed := instruction
+				failed.Status = entities.DebitInstructionFailed
+				failed.ReasonCode = entities.DebitReasonMandateRevoked
+				failed.NextAttemptAt = time.Time{}
+				f.CollectionRepo.EXPECT().UpdateDebitInstruction(gomock.Any(), nil, failed).Return(nil)
+			},
+			want: &entities.CollectionRunResult{
+				CollectionDate: collectionDate,
+				Attempted:      1,
+				Failed:         1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error select active mandate",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Collector:      mock_domain.NewMockPaymentCollector(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:            context.Background(),
+				collectionDate: collectionDate,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(collectionDate.Add(10 * time.Hour))
+				f.CollectionRepo.EXPECT().SelectActiveDebitMandate(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error collection date after the business date",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Collector:      mock_domain.NewMockPaymentCollector(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:            context.Background(),
+				collectionDate: collectionDate.AddDate(0, 0, 1),
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(collectionDate.Add(10 * time.Hour))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := CollectionUseCase{
+				CollectionRepo: f.CollectionRepo,
+				Collector:      f.Collector,
+				Payments:       f.Payments,
+				Clock:          f.Clock,
+				RetryPolicy:    entities.DefaultRetryPolicy,
+			}
+			if f.Tenants != nil {
+				u.Tenants = f.Tenants
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.RunCollection(tt.input.ctx, tt.input.collectionDate)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
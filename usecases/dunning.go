@@ -0,0 +1,301 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const dunningBatchSize = 500
+
+// EvaluateDunning moves every active loan to the level its days past due on
+// the business date reach under the policy. Reaching a higher level records a
+// pending action for it and publishes a dunning.escalated event. Escalation is
+// held while the borrower has an open promise to pay, and an overridden level
+// is kept until the loan is no longer past due. A business date can be run
+// again, the loans already at their level are left as they are.
+func (u *DunningUseCase) EvaluateDunning(ctx context.Context, businessDate time.Time) (*entities.DunningRunResult, error) {
+	if businessDate.IsZero() {
+		var err error
+		businessDate, err = todayOf(ctx, u.Tenants, u.Clock.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+	businessDate = helper.TruncateToDay(businessDate)
+	result := &entities.DunningRunResult{BusinessDate: businessDate}
+	endOfDay := businessDate.AddDate(0, 0, 1)
+
+	var afterId int64
+	for {
+		loans, err := u.DunningRepo.SelectLoanCreatedBefore(ctx, endOfDay, afterId, dunningBatchSize)
+		if err != nil {
+			if errs.GetHTTPCode(err) != http.StatusNotFound {
+				return nil, err
+			}
+			return result, nil
+		}
+		if len(*loans) == 0 {
+			return result, nil
+		}
+
+		var activeLoanIds []int64
+		for _, loan := range *loans {
+			if loan.Status.IsActive() {
+				activeLoanIds = append(activeLoanIds, loan.Id)
+			}
+		}
+		repaymentCounts := map[int64]int{}
+		levels := map[int64]entities.LoanDunningLevel{}
+		if len(activeLoanIds) != 0 {
+			repaymentCounts, err = u.DunningRepo.SelectRepaymentCountByLoanIds(ctx, activeLoanIds, endOfDay)
+			if err != nil {
+				return nil, err
+			}
+			levels, err = u.DunningRepo.SelectLoanDunningLevelByLoanIds(ctx, activeLoanIds)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, loan := range *loans {
+			if !loan.Status.IsActive() {
+				continue
+			}
+			result.Loans++
+
+			daysPastDue := daysPastDueOf(loan, repaymentCounts[loan.Id], businessDate)
+			rule := u.Policy.RuleFor(daysPastDue)
+			current := levels[loan.Id]
+			if current.IsOverridden && daysPastDue > 0 {
+				continue
+			}
+			if rule.Level == current.Level && !current.IsOverridden {
+				continue
+			}
+
+			level := entities.LoanDunningLevel{
+				LoanId:          loan.Id,
+				LoanReferenceId: loan.ReferenceId,
+				Level:           rule.Level,
+				Action:          rule.Action,
+				DaysPastDue:     daysPastDue,
+				BusinessDate:    businessDate,
+			}
+			if rule.Level < current.Level || rule.Level == 0 {
+				err = u.DunningRepo.UpsertLoanDunningLevel(ctx, nil, level)
+				if err != nil {
+					return nil, err
+				}
+				result.Lowered++
+				continue
+			}
+
+			// an open promise to pay holds the escalation until it is kept or broken
+			_, err = u.DunningRepo.SelectOpenPromiseToPayByLoanId(ctx, loan.Id)
+			if err == nil {
+				result.OnPromise++
+				continue
+			}
+			if errs.GetHTTPCode(err) != http.StatusNotFound {
+				return nil, err
+			}
+
+			err = u.escalate(ctx, loan, level)
+			if err != nil {
+				return nil, err
+			}
+			result.Escalated++
+		}
+
+		afterId = (*loans)[len(*loans)-1].Id
+		if len(*loans) < dunningBatchSize {
+			return result, nil
+		}
+	}
+}
+
+// GetDunningLevel is level 0 for a loan that was never dunned
+func (u *DunningUseCase) GetDunningLevel(ctx context.Context, loanReferenceId string) (*entities.LoanDunningLevel, error) {
+	if loanReferenceId == "" {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loan reference id can not be empty")
+	}
+
+	loan, err := u.DunningRepo.SelectLoanByReferenceId(ctx, loanReferenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	level, err := u.DunningRepo.SelectLoanDunningLevelByLoanId(ctx, loan.Id)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+		return &entities.LoanDunningLevel{LoanId: loan.Id, LoanReferenceId: loan.ReferenceId}, nil
+	}
+
+	rule, _ := u.Policy.RuleOf(level.Level)
+	level.Action = rule.Action
+
+	return level, nil
+}
+
+// OverrideDunningLevel puts a loan on the requested level until it is no
+// longer past due. Raising the level records a pending action for it.
+func (u *DunningUseCase) OverrideDunningLevel(ctx context.Context, request entities.DunningOverrideRequest) (*entities.LoanDunningLevel, error) {
+	var errMessage []string
+
+	if request.LoanReferenceId == "" {
+		errMessage = append(errMessage, "loan reference id can not be empty")
+	}
+	rule, ok := u.Policy.RuleOf(request.Level)
+	if !ok && request.Level != 0 {
+		errMessage = append(errMessage, "level is invalid")
+	}
+	if strings.TrimSpace(request.Reason) == "" {
+		errMessage = append(errMessage, "reason can not be empty")
+	}
+	if errMessage != nil || len(errMessage) != 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	loan, err := u.DunningRepo.SelectLoanByReferenceId(ctx, request.LoanReferenceId)
+	if err != nil {
+		return nil, err
+	}
+	if !loan.Status.IsActive() {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loan status has been "+loan.Status.String())
+	}
+
+	var currentLevel int
+	current, err := u.DunningRepo.SelectLoanDunningLevelByLoanId(ctx, loan.Id)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+	} else {
+		currentLevel = current.Level
+	}
+
+	installmentsPaid, err := u.DunningRepo.SelectRepaymentCountByLoanId(ctx, loan.Id)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+	}
+
+	businessDate, err := todayOf(ctx, u.Tenants, u.Clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	level := entities.LoanDunningLevel{
+		LoanId:          loan.Id,
+		LoanReferenceId: loan.ReferenceId,
+		Level:           request.Level,
+		Action:          rule.Action,
+		DaysPastDue:     daysPastDueOf(*loan, installmentsPaid, businessDate),
+		IsOverridden:    true,
+		OverrideReason:  strings.TrimSpace(request.Reason),
+		BusinessDate:    businessDate,
+	}
+
+	if level.Level > currentLevel {
+		err = u.escalate(ctx, *loan, level)
+	} else {
+		err = u.DunningRepo.UpsertLoanDunningLevel(ctx, nil, level)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &level, nil
+}
+
+func (u *DunningUseCase) GetDunningActionList(ctx context.Context, status entities.DunningActionStatus, action entities.DunningAction) (*[]entities.DunningActionItem, error) {
+	if status == "" {
+		status = entities.DunningActionPending
+	}
+	if !status.IsValid() {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "status must be pending or done")
+	}
+
+	return u.DunningRepo.SelectDunningActionByStatus(ctx, status, action)
+}
+
+// CompleteDunningAction marks a pending action as done once the notification
+// was sent or the collector worked the loan
+func (u *DunningUseCase) CompleteDunningAction(ctx context.Context, actionId int64) (*entities.DunningActionItem, error) {
+	if actionId < 1 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "action id is invalid")
+	}
+
+	action, err := u.DunningRepo.SelectDunningActionById(ctx, actionId)
+	if err != nil {
+		return nil, err
+	}
+	if action.Status != entities.DunningActionPending {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "action "+strconv.FormatInt(actionId, 10)+" is already "+string(action.Status))
+	}
+
+	err = u.DunningRepo.UpdateDunningActionStatus(ctx, nil, action.Id, entities.DunningActionDone)
+	if err != nil {
+		return nil, err
+	}
+	action.Status = entities.DunningActionDone
+
+	return action, nil
+}
+
+// escalate records the level and its pending action in one transaction and
+// publishes the escalation
+func (u *DunningUseCase) escalate(ctx context.Context, loan entities.Loan, level entities.LoanDunningLevel) error {
+	dbTx, err := u.DunningRepo.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback()
+
+	err = u.DunningRepo.UpsertLoanDunningLevel(ctx, dbTx, level)
+	if err != nil {
+		return err
+	}
+
+	_, err = u.DunningRepo.CreateDunningAction(ctx, dbTx, entities.DunningActionItem{
+		LoanId:          loan.Id,
+		LoanReferenceId: loan.ReferenceId,
+		Level:           level.Level,
+		Action:          level.Action,
+		DaysPastDue:     level.DaysPastDue,
+		BusinessDate:    level.BusinessDate,
+		Status:          entities.DunningActionPending,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = dbTx.Commit()
+	if err != nil {
+		return err
+	}
+
+	u.publishEvent(ctx, loan.TenantId, entities.EventDunningEscalated, entities.DunningEventData{
+		LoanId:          loan.Id,
+		LoanReferenceId: loan.ReferenceId,
+		UserId:          loan.UserId,
+		Level:           level.Level,
+		Action:          level.Action,
+		DaysPastDue:     level.DaysPastDue,
+	})
+
+	return nil
+}
+
+func (u *DunningUseCase) publishEvent(ctx context.Context, tenantId int64, eventType string, data interface{}) {
+	publishEvent(ctx, u.Events, u.Clock, tenantId, eventType, data)
+}
@@ -0,0 +1,440 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+func TestDunningUseCase_EvaluateDunning(t *testing.T) {
+	type input struct {
+		ctx          context.Context
+		businessDate time.Time
+	}
+	type fields struct {
+		DunningRepo *mock_usecase.MockDunningRepository
+		Clock       *mock_domain.MockClock
+		Events      *mock_domain.MockEventPublisher
+		Tx          *mock_domain.MockAtomicTransaction
+	}
+	businessDate := time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local)
+	notFound := errs.Wrap(http.StatusNotFound, errors.New("not found"))
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.DunningRunResult
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DunningRepo: mock_usecase.NewMockDunningRepository(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+					Events:      mock_domain.NewMockEventPublisher(ctrl),
+					Tx:          mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:          context.Background(),
+				businessDate: businessDate.Add(7 * time.Hour),
+			},
+			mock: func(f fields, args input) {
+				f.DunningRepo.EXPECT().SelectLoanCreatedBefore(gomock.Any(), businessDate.AddDate(0, 0, 1), int64(0), dunningBatchSize).Return(&[]entities.Loan{
+					{Id: 1, TenantId: 2, ReferenceId: "loan1", UserId: 10, Status: entities.LoanStatusActive, RepaymentSchedule: entities.RepaymentMonthly,
+						Tenor: 6, RepaymentAmount: 200, CreatedAt: time.Date(2024, 4, 20, 9, 0, 0, 0, time.Local)},
+					{Id: 2, ReferenceId: "loan2", Status: entities.LoanStatusActive, RepaymentSchedule: entities.RepaymentMonthly,
+						Tenor: 6, RepaymentAmount: 200, CreatedAt: time.Date(2024, 5, 1, 9, 0, 0, 0, time.Local)},
+					{Id: 3, ReferenceId: "loan3", Status: entities.LoanStatusActive, RepaymentSchedule: entities.RepaymentMonthly,
+						Tenor: 6, RepaymentAmount: 200, CreatedAt: time.Date(2024, 4, 1, 9, 0, 0, 0, time.Local)},
+					{Id: 4, ReferenceId: "loan4", Status: entities.LoanStatusCompleted, RepaymentSchedule: entities.RepaymentMonthly,
+						Tenor: 1, RepaymentAmount: 200, CreatedAt: time.Date(2023, 1, 1, 9, 0, 0, 0, time.Local)},
+				}, nil)
+				f.DunningRepo.EXPECT().SelectRepaymentCountByLoanIds(gomock.Any(), []int64{1, 2, 3}, businessDate.AddDate(0, 0, 1)).Return(map[int64]int{}, nil)
+				f.DunningRepo.EXPECT().SelectLoanDunningLevelByLoanIds(gomock.Any(), []int64{1, 2, 3}).Return(map[int64]entities.LoanDunningLevel{
+					2: {LoanId: 2, Level: 1},
+					3: {LoanId: 3, Level: 2, IsOverridden: true},
+				}, nil)
+				f.DunningRepo.EXPECT().SelectOpenPromiseToPayByLoanId(gomock.Any(), int64(1)).Return(nil, notFound)
+				f.DunningRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.DunningRepo.EXPECT().UpsertLoanDunningLevel(gomock.Any(), f.Tx, entities.LoanDunningLevel{
+					LoanId:          1,
+					LoanReferenceId: "loan1",
+					Level:           2,
+					Action:          entities.DunningSecondNotice,
+					DaysPastDue:     12,
+					BusinessDate:    businessDate,
+				}).Return(nil)
+				f.DunningRepo.EXPECT().CreateDunningAction(gomock.Any(), f.Tx, entities.DunningActionItem{
+					LoanId:          1,
+					LoanReferenceId: "loan1",
+					Level:           2,
+					Action:          entities.DunningSecondNotice,
+					DaysPastDue:     12,
+					BusinessDate:    businessDate,
+					Status:          entities.DunningActionPending,
+				}).Return(int64(7), nil)
+				f.Tx.EXPECT().Commit().Return(nil)
+				f.Tx.EXPECT().Rollback().Return(nil)
+				f.Clock.EXPECT().Now().Return(businessDate.Add(7 * time.Hour))
+				f.Events.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event entities.Event) error {
+					assert.Equal(t, entities.EventDunningEscalated, event.Type)
+					assert.Equal(t, int64(2), event.TenantId)
+					assert.Equal(t, entities.DunningEventData{
+						LoanId:          1,
+						LoanReferenceId: "loan1",
+						UserId:          10,
+						Level:           2,
+						Action:          entities.DunningSecondNotice,
+						DaysPastDue:     12,
+					}, event.Data)
+					return nil
+				})
+				f.DunningRepo.EXPECT().UpsertLoanDunningLevel(gomock.Any(), nil, entities.LoanDunningLevel{
+					LoanId:          2,
+					LoanReferenceId: "loan2",
+					BusinessDate:    businessDate,
+				}).Return(nil)
+			},
+			want: &entities.DunningRunResult{
+				BusinessDate: businessDate,
+				Loans:        3,
+				Escalated:    1,
+				Lowered:      1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success open promise to pay holds the escalation",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DunningRepo: mock_usecase.NewMockDunningRepository(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+					Events:      mock_domain.NewMockEventPublisher(ctrl),
+					Tx:          mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:          context.Background(),
+				businessDate: businessDate,
+			},
+			mock: func(f fields, args input) {
+				f.DunningRepo.EXPECT().SelectLoanCreatedBefore(gomock.Any(), businessDate.AddDate(0, 0, 1), int64(0), dunningBatchSize).Return(&[]entities.Loan{
+					{Id: 1, ReferenceId: "loan1", Status: entities.LoanStatusActive, RepaymentSchedule: entities.RepaymentMonthly,
+						Tenor: 6, RepaymentAmount: 200, CreatedAt: time.Date(2024, 4, 1, 9, 0, 0, 0, time.Local)},
+				}, nil)
+				f.DunningRepo.EXPECT().SelectRepaymentCountByLoanIds(gomock.Any(), []int64{1}, businessDate.AddDate(0, 0, 1)).Return(map[int64]int{}, nil)
+				f.DunningRepo.EXPECT().SelectLoanDunningLevelByLoanIds(gomock.Any(), []int64{1}).Return(map[int64]entities.LoanDunningLevel{
+					1: {LoanId: 1, Level: 3},
+				}, nil)
+				f.DunningRepo.EXPECT().SelectOpenPromiseToPayByLoanId(gomock.Any(), int64(1)).Return(&entities.PromiseToPay{Id: 2, LoanId: 1}, nil)
+			},
+			want: &entities.DunningRunResult{
+				BusinessDate: businessDate,
+				Loans:        1,
+				OnPromise:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success zero business date evaluates today of the tenant",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DunningRepo: mock_usecase.NewMockDunningRepository(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+					Events:      mock_domain.NewMockEventPublisher(ctrl),
+					Tx:          mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+				// already the next day in the timezone of the default tenant
+				f.Clock.EXPECT().Now().Return(time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC))
+				f.DunningRepo.EXPECT().SelectLoanCreatedBefore(gomock.Any(), time.Date(2024, 6, 3, 0, 0, 0, 0, entities.DefaultTenant.Location()), int64(0), dunningBatchSize).Return(&[]entities.Loan{}, nil)
+			},
+			want: &entities.DunningRunResult{
+				BusinessDate: time.Date(2024, 6, 2, 0, 0, 0, 0, entities.DefaultTenant.Location()),
+			},
+			wantErr: false,
+		},
+		{
+			name: "error select loan",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DunningRepo: mock_usecase.NewMockDunningRepository(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+					Events:      mock_domain.NewMockEventPublisher(ctrl),
+					Tx:          mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:          context.Background(),
+				businessDate: businessDate,
+			},
+			mock: func(f fields, args input) {
+				f.DunningRepo.EXPECT().SelectLoanCreatedBefore(gomock.Any(), gomock.Any(), int64(0), dunningBatchSize).Return(nil, errors.New("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := DunningUseCase{
+				DunningRepo: f.DunningRepo,
+				Clock:       f.Clock,
+				Events:      f.Events,
+				Policy:      entities.DefaultDunningPolicy,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.EvaluateDunning(tt.input.ctx, tt.input.businessDate)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestDunningUseCase_OverrideDunningLevel(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.DunningOverrideRequest
+	}
+	type fields struct {
+		DunningRepo *mock_usecase.MockDunningRepository
+		Clock       *mock_domain.MockClock
+	}
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	today := time.Date(2024, 6, 1, 0, 0, 0, 0, entities.DefaultTenant.Location())
+	notFound := errs.Wrap(http.StatusNotFound, errors.New("not found"))
+	activeLoan := &entities.Loan{Id: 1, ReferenceId: "loan1", Status: entities.LoanStatusActive, RepaymentSchedule: entities.RepaymentMonthly,
+		Tenor: 6, RepaymentAmount: 200, CreatedAt: time.Date(2024, 4, 1, 9, 0, 0, 0, time.Local)}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.LoanDunningLevel
+		wantErr bool
+	}{
+		{
+			name: "success lower level",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DunningRepo: mock_usecase.NewMockDunningRepository(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.DunningOverrideRequest{LoanReferenceId: "loan1", Level: 1, Reason: " hardship "},
+			},
+			mock: func(f fields, args input) {
+				f.DunningRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(activeLoan, nil)
+				f.DunningRepo.EXPECT().SelectLoanDunningLevelByLoanId(gomock.Any(), int64(1)).Return(&entities.LoanDunningLevel{LoanId: 1, Level: 4}, nil)
+				f.DunningRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(0, notFound)
+				f.Clock.EXPECT().Now().Return(now)
+				f.DunningRepo.EXPECT().UpsertLoanDunningLevel(gomock.Any(), nil, entities.LoanDunningLevel{
+					LoanId:          1,
+					LoanReferenceId: "loan1",
+					Level:           1,
+					Action:          entities.DunningReminder,
+					DaysPastDue:     31,
+					IsOverridden:    true,
+					OverrideReason:  "hardship",
+					BusinessDate:    today,
+				}).Return(nil)
+			},
+			want: &entities.LoanDunningLevel{
+				LoanId:          1,
+				LoanReferenceId: "loan1",
+				Level:           1,
+				Action:          entities.DunningReminder,
+				DaysPastDue:     31,
+				IsOverridden:    true,
+				OverrideReason:  "hardship",
+				BusinessDate:    today,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error invalid request",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DunningRepo: mock_usecase.NewMockDunningRepository(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.DunningOverrideRequest{LoanReferenceId: "loan1", Level: 9},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error loan is not active",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DunningRepo: mock_usecase.NewMockDunningRepository(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.DunningOverrideRequest{LoanReferenceId: "loan1", Level: 3, Reason: "broken promise"},
+			},
+			mock: func(f fields, args input) {
+				f.DunningRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&entities.Loan{
+					Id:     1,
+					Status: entities.LoanStatusCompleted,
+				}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := DunningUseCase{
+				DunningRepo: f.DunningRepo,
+				Clock:       f.Clock,
+				Policy:      entities.DefaultDunningPolicy,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.OverrideDunningLevel(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestDunningUseCase_CompleteDunningAction(t *testing.T) {
+	type input struct {
+		ctx      context.Context
+		actionId int64
+	}
+	type fields struct {
+		DunningRepo *mock_usecase.MockDunningRepository
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.DunningActionItem
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DunningRepo: mock_usecase.NewMockDunningRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:      context.Background(),
+				actionId: 7,
+			},
+			mock: func(f fields, args input) {
+				f.DunningRepo.EXPECT().SelectDunningActionById(gomock.Any(), int64(7)).Return(&entities.DunningActionItem{
+					Id:     7,
+					Action: entities.DunningCallQueue,
+					Status: entities.DunningActionPending,
+				}, nil)
+				f.DunningRepo.EXPECT().UpdateDunningActionStatus(gomock.Any(), nil, int64(7), entities.DunningActionDone).Return(nil)
+			},
+			want: &entities.DunningActionItem{
+				Id:     7,
+				Action: entities.DunningCallQueue,
+				Status: entities.DunningActionDone,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error action already done",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DunningRepo: mock_usecase.NewMockDunningRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:      context.Background(),
+				actionId: 7,
+			},
+			mock: func(f fields, args input) {
+				f.DunningRepo.EXPECT().SelectDunningActionById(gomock.Any(), int64(7)).Return(&entities.DunningActionItem{
+					Id:     7,
+					Status: entities.DunningActionDone,
+				}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error invalid action id",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DunningRepo: mock_usecase.NewMockDunningRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := DunningUseCase{
+				DunningRepo: f.DunningRepo,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.CompleteDunningAction(tt.input.ctx, tt.input.actionId)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
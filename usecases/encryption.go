@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const defaultReencryptBatchSize = 500
+
+// Reencrypt wraps again under the primary key every value sealed with an
+// older key, so the older key can be dropped from the keyring. A value
+// changed while it was re-encrypted is left for the next run.
+func (u *EncryptionUseCase) Reencrypt(ctx context.Context) ([]entities.ReencryptResult, error) {
+	batchSize := u.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultReencryptBatchSize
+	}
+
+	var results []entities.ReencryptResult
+	for _, column := range u.EncryptionRepo.EncryptedColumns() {
+		result := entities.ReencryptResult{Column: column}
+		var afterId int64
+		for {
+			values, err := u.EncryptionRepo.SelectEncryptedValues(ctx, column, afterId, batchSize)
+			if err != nil {
+				return results, err
+			}
+
+			for _, value := range values {
+				afterId = value.Id
+				result.Scanned++
+				if !helper.NeedsReencrypt(value.Value) {
+					continue
+				}
+
+				reencrypted, err := helper.Reencrypt(value.Value)
+				if err != nil {
+					return append(results, result), err
+				}
+				updated, err := u.EncryptionRepo.UpdateEncryptedValue(ctx, nil, column,
+					entities.EncryptedValue{Id: value.Id, Value: reencrypted}, value.Value)
+				if err != nil {
+					return append(results, result), err
+				}
+				if updated {
+					result.Reencrypted++
+				}
+			}
+
+			if len(values) < batchSize {
+				break
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+	"github.com/sirait-kevin/BillingEngine/pkg/keyring"
+)
+
+func TestEncryptionUseCase_Reencrypt(t *testing.T) {
+	oldKey, newKey := bytes.Repeat([]byte{1}, keyring.KeySize), bytes.Repeat([]byte{2}, keyring.KeySize)
+	oldKeyring, err := keyring.New("k1", map[string][]byte{"k1": oldKey})
+	assert.Nil(t, err)
+	rotatedKeyring, err := keyring.New("k2", map[string][]byte{"k1": oldKey, "k2": newKey})
+	assert.Nil(t, err)
+
+	sealedWithOldKey, err := oldKeyring.Seal([]byte("3171234567890001"))
+	assert.Nil(t, err)
+	sealedWithNewKey, err := rotatedKeyring.Seal([]byte("1234567890"))
+	assert.Nil(t, err)
+
+	helper.SetKeyring(rotatedKeyring)
+	defer helper.SetKeyring(nil)
+
+	column := entities.EncryptedColumn{Table: "loans", Column: "borrower_national_id"}
+	assertReencrypted := func(t *testing.T, value entities.EncryptedValue) {
+		keyId, err := keyring.KeyIdOf(value.Value)
+		assert.Nil(t, err)
+		assert.Equal(t, "k2", keyId)
+		plainText, err := helper.Decrypt(value.Value)
+		assert.Nil(t, err)
+		assert.Equal(t, "3171234567890001", plainText)
+	}
+
+	type input struct {
+		ctx context.Context
+	}
+	type fields struct {
+		EncryptionRepo *mock_usecase.MockEncryptionRepository
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    []entities.ReencryptResult
+		wantErr bool
+	}{
+		{
+			name: "success re-encrypt the values sealed with an older key",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					EncryptionRepo: mock_usecase.NewMockEncryptionRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+				f.EncryptionRepo.EXPECT().EncryptedColumns().Return([]entities.EncryptedColumn{column})
+				f.EncryptionRepo.EXPECT().SelectEncryptedValues(gomock.Any(), column, int64(0), 2).Return([]entities.EncryptedValue{
+					{Id: 1, Value: sealedWithOldKey},
+					{Id: 2, Value: sealedWithNewKey},
+				}, nil)
+				f.EncryptionRepo.EXPECT().UpdateEncryptedValue(gomock.Any(), nil, column, gomock.Any(), sealedWithOldKey).DoAndReturn(
+					func(ctx context.Context, tx interface{}, column entities.EncryptedColumn, value entities.EncryptedValue, previous []byte) (bool, error) {
+						assert.Equal(t, int64(1), value.Id)
+						assertReencrypted(t, value)
+						return true, nil
+					})
+				f.EncryptionRepo.EXPECT().SelectEncryptedValues(gomock.Any(), column, int64(2), 2).Return([]entities.EncryptedValue{
+					{Id: 3, Value: sealedWithOldKey},
+				}, nil)
+				// the value changed since it was selected
+				f.EncryptionRepo.EXPECT().UpdateEncryptedValue(gomock.Any(), nil, column, gomock.Any(), sealedWithOldKey).Return(false, nil)
+			},
+			want: []entities.ReencryptResult{
+				{Column: column, Scanned: 3, Reencrypted: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success nothing to re-encrypt",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					EncryptionRepo: mock_usecase.NewMockEncryptionRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+				f.EncryptionRepo.EXPECT().EncryptedColumns().Return([]entities.EncryptedColumn{column})
+				f.EncryptionRepo.EXPECT().SelectEncryptedValues(gomock.Any(), column, int64(0), 2).Return([]entities.EncryptedValue{}, nil)
+			},
+			want: []entities.ReencryptResult{
+				{Column: column},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error select encrypted values",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					EncryptionRepo: mock_usecase.NewMockEncryptionRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+				f.EncryptionRepo.EXPECT().EncryptedColumns().Return([]entities.EncryptedColumn{column})
+				f.EncryptionRepo.EXPECT().SelectEncryptedValues(gomock.Any(), column, int64(0), 2).Return(nil, errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := EncryptionUseCase{
+				EncryptionRepo: f.EncryptionRepo,
+				BatchSize:      2,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.Reencrypt(tt.input.ctx)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
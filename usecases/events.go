@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+// publishEvent notifies the subscribers of the tenant about a committed
+// change. A failure to publish must never fail the operation that produced
+// the event, it is only logged.
+func publishEvent(ctx context.Context, events interfaces.EventPublisher, clock interfaces.Clock, tenantId int64, eventType string, data interface{}) {
+	if events == nil {
+		return
+	}
+
+	eventId, err := helper.GenerateRandomString(16)
+	if err != nil {
+		logEventError(ctx, eventType, err)
+		return
+	}
+
+	err = events.Publish(ctx, entities.Event{
+		Id:         eventId,
+		Type:       eventType,
+		OccurredAt: clock.Now(),
+		Data:       data,
+		TenantId:   tenantId,
+	})
+	if err != nil {
+		logEventError(ctx, eventType, err)
+	}
+}
+
+func logEventError(ctx context.Context, eventType string, err error) {
+	if logger, ok := ctx.Value("logger").(*logrus.Entry); ok {
+		logger.Error("Error publishing event "+eventType+": ", err)
+	}
+}
@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+)
+
+func Test_publishEvent(t *testing.T) {
+	type fields struct {
+		Events *mock_domain.MockEventPublisher
+		Clock  *mock_domain.MockClock
+	}
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		mock    func(f fields)
+		wantLog bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Events: mock_domain.NewMockEventPublisher(ctrl),
+					Clock:  mock_domain.NewMockClock(ctrl),
+				}
+			},
+			mock: func(f fields) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.Events.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event entities.Event) error {
+					assert.Len(t, event.Id, 32)
+					assert.EqualValues(t, entities.EventLoanCreated, event.Type)
+					assert.EqualValues(t, now, event.OccurredAt)
+					assert.EqualValues(t, 2, event.TenantId)
+					return nil
+				})
+			},
+			wantLog: false,
+		},
+		{
+			name: "error publish is logged",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					Events: mock_domain.NewMockEventPublisher(ctrl),
+					Clock:  mock_domain.NewMockClock(ctrl),
+				}
+			},
+			mock: func(f fields) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.Events.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+			},
+			wantLog: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			tt.mock(f)
+
+			log, hook := test.NewNullLogger()
+			ctx := context.WithValue(context.Background(), "logger", logrus.NewEntry(log))
+			publishEvent(ctx, f.Events, f.Clock, 2, entities.EventLoanCreated, entities.LoanEventData{})
+			assert.Equal(t, tt.wantLog, len(hook.AllEntries()) > 0)
+		})
+	}
+}
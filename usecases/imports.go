@@ -0,0 +1,381 @@
+package usecases
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+var (
+	// created_at is optional, a loan without it is created now
+	loanImportColumns      = []string{"reference_id", "user_id", "amount", "rate_percentage", "repayment_schedule", "tenor"}
+	repaymentImportColumns = []string{"loan_reference_id", "reference_id", "amount", "paid_at"}
+)
+
+type csvRecord struct {
+	line   int
+	values map[string]string
+}
+
+// importLoan is a row of the loans file with its repayments, sorted by payment time
+type importLoan struct {
+	record     csvRecord
+	repayments []csvRecord
+}
+
+// ImportLoans creates the loans of a partner's existing book. Every row is
+// checked with the rules of CreateLoan and saved in its own transaction with
+// its repayments, so a bad row does not stop the others. Imported loans keep
+// their original dates, skip the delinquency check and get no receipts.
+func (u *ImportUseCase) ImportLoans(ctx context.Context, request entities.LoanImportRequest) (*entities.ImportResult, error) {
+	if request.Loans == nil {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loans file is required")
+	}
+
+	loanRecords, err := readCSV(request.Loans, loanImportColumns)
+	if err != nil {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loans file: "+err.Error())
+	}
+
+	loans := make([]importLoan, len(loanRecords))
+	loanIndex := map[string]int{}
+	for i, record := range loanRecords {
+		loans[i].record = record
+		if _, ok := loanIndex[record.values["reference_id"]]; !ok {
+			loanIndex[record.values["reference_id"]] = i
+		}
+	}
+
+	if request.Repayments != nil {
+		repaymentRecords, err := readCSV(request.Repayments, repaymentImportColumns)
+		if err != nil {
+			return nil, errs.NewWithMessage(http.StatusBadRequest, "repayments file: "+err.Error())
+		}
+
+		var errMessage []string
+		for _, record := range repaymentRecords {
+			i, ok := loanIndex[record.values["loan_reference_id"]]
+			if !ok {
+				errMessage = append(errMessage, fmt.Sprintf("repayments file line %d: loan is not in the loans file", record.line))
+				continue
+			}
+			loans[i].repayments = append(loans[i].repayments, record)
+		}
+		if len(errMessage) > 0 {
+			return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+		}
+	}
+
+	tenant, err := tenantOf(ctx, u.Tenants)
+	if err != nil {
+		return nil, err
+	}
+
+	now := u.Clock.Now()
+	result := &entities.ImportResult{
+		DryRun: request.DryRun,
+		Rows:   make([]entities.ImportRowResult, len(loans)),
+	}
+	seenLoans := map[string]bool{}
+	seenRepayments := map[string]bool{}
+	for i, loan := range loans {
+		row, err := u.importLoan(ctx, loan, now, tenant.Currency, request.DryRun, seenLoans, seenRepayments)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Rows[i] = row
+		result.Total++
+		if row.Status == entities.ImportRowFailed {
+			result.Failed++
+		} else {
+			result.Succeeded++
+		}
+	}
+
+	return result, nil
+}
+
+// importLoan returns an error only when the import can not go on, a row that
+// can not be imported is reported as failed
+func (u *ImportUseCase) importLoan(ctx context.Context, loan importLoan, now time.Time, defaultCurrency string, dryRun bool,
+	seenLoans, seenRepayments map[string]bool) (entities.ImportRowResult, error) {
+	values := loan.record.values
+	row := entities.ImportRowResult{
+		Row:             loan.record.line,
+		LoanReferenceId: values["reference_id"],
+		Repayments:      len(loan.repayments),
+	}
+
+	newLoan, errMessage := parseImportLoan(values, now, defaultCurrency)
+	if newLoan.ReferenceId != "" {
+		if seenLoans[newLoan.ReferenceId] {
+			errMessage = append(errMessage, "reference id is duplicated in the file")
+		}
+		seenLoans[newLoan.ReferenceId] = true
+
+		_, err := u.ImportRepo.SelectLoanByReferenceId(ctx, newLoan.ReferenceId)
+		if err == nil {
+			errMessage = append(errMessage, "reference id already exists")
+		} else if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return row, err
+		}
+	}
+
+	repayments, repaymentErrMessage, err := u.parseImportRepayments(ctx, loan.repayments, *newLoan, now, seenRepayments)
+	if err != nil {
+		return row, err
+	}
+	errMessage = append(errMessage, repaymentErrMessage...)
+
+	if len(errMessage) > 0 {
+		row.Status = entities.ImportRowFailed
+		row.Error = strings.Join(errMessage, "; ")
+		return row, nil
+	}
+
+	if len(repayments) == newLoan.Tenor {
+		newLoan.Status = entities.LoanStatusCompleted
+	}
+
+	if dryRun {
+		row.Status = entities.ImportRowValid
+		return row, nil
+	}
+
+	row.LoanId, err = u.saveImportLoan(ctx, *newLoan, repayments)
+	if err != nil {
+		row.Status = entities.ImportRowFailed
+		row.Error = err.Error()
+		return row, nil
+	}
+	row.Status = entities.ImportRowCreated
+
+	return row, nil
+}
+
+func (u *ImportUseCase) saveImportLoan(ctx context.Context, loan entities.Loan, repayments []entities.Repayment) (int64, error) {
+	dbTx, err := u.ImportRepo.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer dbTx.Rollback()
+
+	loanId, err := u.ImportRepo.CreateImportedLoan(ctx, dbTx, loan)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, repayment := range repayments {
+		repayment.LoanId = loanId
+		_, err = u.ImportRepo.CreateImportedRepayment(ctx, dbTx, repayment)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = dbTx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return loanId, nil
+}
+
+// parseImportLoan reads a row of the loans file, a row without a currency is
+// in the default currency
+func parseImportLoan(values map[string]string, now time.Time, defaultCurrency string) (*entities.Loan, []string) {
+	var errMessage []string
+
+	userId, err := strconv.ParseInt(values["user_id"], 10, 64)
+	if err != nil {
+		errMessage = append(errMessage, "user_id is not a number")
+	}
+	amount, err := strconv.ParseInt(values["amount"], 10, 64)
+	if err != nil {
+		errMessage = append(errMessage, "amount is not a number")
+	}
+	ratePercentage, err := strconv.Atoi(values["rate_percentage"])
+	if err != nil {
+		errMessage = append(errMessage, "rate_percentage is not a number")
+	}
+	tenor, err := strconv.Atoi(values["tenor"])
+	if err != nil {
+		errMessage = append(errMessage, "tenor is not a number")
+	}
+
+	loanRequest := entities.LoanRequest{
+		ReferenceId:       values["reference_id"],
+		UserId:            userId,
+		Amount:            amount,
+		RatePercentage:    ratePercentage,
+		RepaymentSchedule: entities.RepaymentScheduleType(strings.ToLower(values["repayment_schedule"])),
+		Tenor:             tenor,
+		Currency:          values["currency"],
+	}
+	errMessage = append(errMessage, validateLoanRequest(loanRequest)...)
+
+	createdAt := now
+	if values["created_at"] != "" {
+		createdAt, err = parseImportTime(values["created_at"])
+		if err != nil {
+			errMessage = append(errMessage, err.Error())
+		} else if createdAt.After(now) {
+			errMessage = append(errMessage, "created_at can not be in the future")
+		}
+	}
+
+	loan := &entities.Loan{
+		ReferenceId:       loanRequest.ReferenceId,
+		UserId:            loanRequest.UserId,
+		Amount:            loanRequest.Amount,
+		Currency:          entities.NormalizeCurrency(loanRequest.Currency),
+		RatePercentage:    loanRequest.RatePercentage,
+		Status:            entities.LoanStatusActive,
+		RepaymentSchedule: loanRequest.RepaymentSchedule,
+		Tenor:             loanRequest.Tenor,
+		CreatedAt:         createdAt,
+	}
+	if loan.Currency == "" {
+		loan.Currency = defaultCurrency
+	}
+	if loanRequest.Tenor > 0 {
+		loan.RepaymentAmount = repaymentAmountOf(loanRequest)
+	}
+
+	return loan, errMessage
+}
+
+func (u *ImportUseCase) parseImportRepayments(ctx context.Context, records []csvRecord, loan entities.Loan, now time.Time,
+	seenRepayments map[string]bool) ([]entities.Repayment, []string, error) {
+	var (
+		errMessage []string
+		repayments = make([]entities.Repayment, 0, len(records))
+	)
+
+	if loan.Tenor > 0 && len(records) > loan.Tenor {
+		errMessage = append(errMessage, "more repayments than the tenor")
+	}
+
+	for _, record := range records {
+		prefix := fmt.Sprintf("repayment line %d: ", record.line)
+		referenceId := record.values["reference_id"]
+
+		if referenceId == "" {
+			errMessage = append(errMessage, prefix+"reference id can not be empty")
+		} else {
+			if seenRepayments[referenceId] {
+				errMessage = append(errMessage, prefix+"reference id is duplicated in the file")
+			}
+			seenRepayments[referenceId] = true
+
+			_, err := u.ImportRepo.SelectRepaymentByReferenceId(ctx, referenceId)
+			if err == nil {
+				errMessage = append(errMessage, prefix+"reference id already exists")
+			} else if errs.GetHTTPCode(err) != http.StatusNotFound {
+				return nil, nil, err
+			}
+		}
+
+		amount, err := strconv.ParseInt(record.values["amount"], 10, 64)
+		if err != nil {
+			errMessage = append(errMessage, prefix+"amount is not a number")
+		} else if amount != loan.RepaymentAmount {
+			errMessage = append(errMessage, prefix+"amount is invalid, expected: "+loan.Money(loan.RepaymentAmount).String())
+		}
+
+		paidAt, err := parseImportTime(record.values["paid_at"])
+		if err != nil {
+			errMessage = append(errMessage, prefix+err.Error())
+		} else if paidAt.Before(loan.CreatedAt) || paidAt.After(now) {
+			errMessage = append(errMessage, prefix+"paid_at must be between the loan created_at and now")
+		}
+
+		repayments = append(repayments, entities.Repayment{
+			ReferenceId: referenceId,
+			Amount:      amount,
+			Currency:    loan.Currency,
+			CreatedAt:   paidAt,
+		})
+	}
+
+	sort.SliceStable(repayments, func(i, j int) bool {
+		return repayments[i].CreatedAt.Before(repayments[j].CreatedAt)
+	})
+
+	return repayments, errMessage, nil
+}
+
+// parseImportTime accepts RFC 3339 timestamps and plain dates in local time
+func parseImportTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := helper.ParseDate(value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, errors.New("time " + strconv.Quote(value) + " must be formatted as RFC 3339 or " + helper.DateLayout)
+}
+
+// readCSV reads a CSV file with a header line into records keyed by column name
+func readCSV(r io.Reader, requiredColumns []string) ([]csvRecord, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, errors.New("file is empty")
+		}
+		return nil, err
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+
+	var missing []string
+	for _, column := range requiredColumns {
+		found := false
+		for _, name := range header {
+			if name == column {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, column)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, errors.New("missing columns " + strings.Join(missing, ", "))
+	}
+
+	var records []csvRecord
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		values := make(map[string]string, len(header))
+		for i, name := range header {
+			values[name] = strings.TrimSpace(fields[i])
+		}
+		records = append(records, csvRecord{line: line, values: values})
+	}
+}
@@ -0,0 +1,224 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+func TestImportUseCase_ImportLoans(t *testing.T) {
+	type input struct {
+		ctx        context.Context
+		loans      string
+		repayments string
+		dryRun     bool
+	}
+	type fields struct {
+		ImportRepo *mock_usecase.MockImportRepository
+		Clock      *mock_domain.MockClock
+		Tx         *mock_domain.MockAtomicTransaction
+	}
+	now := time.Date(2000, 3, 15, 8, 0, 0, 0, time.Local)
+	notFound := errs.NewWithMessage(http.StatusNotFound, "")
+	loansFile := "reference_id,user_id,amount,rate_percentage,repayment_schedule,tenor,created_at\n" +
+		"loan1,1,1000,10,Weekly,2,2000-03-01\n" +
+		"loan2,0,1000,10,daily,2,\n"
+	repaymentsFile := "loan_reference_id,reference_id,amount,paid_at\n" +
+		"loan1,repay2,550,2000-03-14T07:00:00Z\n" +
+		"loan1,repay1,550,2000-03-08\n"
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.ImportResult
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ImportRepo: mock_usecase.NewMockImportRepository(ctrl),
+					Clock:      mock_domain.NewMockClock(ctrl),
+					Tx:         mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:        context.Background(),
+				loans:      loansFile,
+				repayments: repaymentsFile,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.ImportRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(nil, notFound)
+				f.ImportRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "repay2").Return(nil, notFound)
+				f.ImportRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "repay1").Return(nil, notFound)
+				f.ImportRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.ImportRepo.EXPECT().CreateImportedLoan(gomock.Any(), f.Tx, entities.Loan{
+					ReferenceId:       "loan1",
+					UserId:            1,
+					Amount:            1000,
+					Currency:          entities.DefaultCurrency,
+					RatePercentage:    10,
+					Status:            entities.LoanStatusCompleted,
+					RepaymentSchedule: entities.RepaymentWeekly,
+					Tenor:             2,
+					RepaymentAmount:   550,
+					CreatedAt:         time.Date(2000, 3, 1, 0, 0, 0, 0, time.Local),
+				}).Return(int64(10), nil)
+				gomock.InOrder(
+					f.ImportRepo.EXPECT().CreateImportedRepayment(gomock.Any(), f.Tx, entities.Repayment{
+						LoanId:      10,
+						ReferenceId: "repay1",
+						Amount:      550,
+						Currency:    entities.DefaultCurrency,
+						CreatedAt:   time.Date(2000, 3, 8, 0, 0, 0, 0, time.Local),
+					}).Return(int64(1), nil),
+					f.ImportRepo.EXPECT().CreateImportedRepayment(gomock.Any(), f.Tx, entities.Repayment{
+						LoanId:      10,
+						ReferenceId: "repay2",
+						Amount:      550,
+						Currency:    entities.DefaultCurrency,
+						CreatedAt:   time.Date(2000, 3, 14, 7, 0, 0, 0, time.UTC),
+					}).Return(int64(2), nil),
+				)
+				f.Tx.EXPECT().Commit().Return(nil)
+				f.Tx.EXPECT().Rollback().Return(nil)
+				f.ImportRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan2").Return(nil, notFound)
+			},
+			want: &entities.ImportResult{
+				Total:     2,
+				Succeeded: 1,
+				Failed:    1,
+				Rows: []entities.ImportRowResult{
+					{Row: 2, LoanReferenceId: "loan1", Status: entities.ImportRowCreated, LoanId: 10, Repayments: 2},
+					{Row: 3, LoanReferenceId: "loan2", Status: entities.ImportRowFailed, Error: "UserId is invalid; Repayment schedule is invalid"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success dry run",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ImportRepo: mock_usecase.NewMockImportRepository(ctrl),
+					Clock:      mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				loans: "reference_id,user_id,amount,rate_percentage,repayment_schedule,tenor\n" +
+					"loan1,1,1000,10,monthly,2\n" +
+					"loan1,1,1000,10,monthly,2\n",
+				dryRun: true,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.ImportRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(nil, notFound).Times(2)
+			},
+			want: &entities.ImportResult{
+				DryRun:    true,
+				Total:     2,
+				Succeeded: 1,
+				Failed:    1,
+				Rows: []entities.ImportRowResult{
+					{Row: 2, LoanReferenceId: "loan1", Status: entities.ImportRowValid},
+					{Row: 3, LoanReferenceId: "loan1", Status: entities.ImportRowFailed, Error: "reference id is duplicated in the file"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error missing column",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ImportRepo: mock_usecase.NewMockImportRepository(ctrl),
+					Clock:      mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				loans: "reference_id,user_id,amount\nloan1,1,1000\n",
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error repayment of unknown loan",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ImportRepo: mock_usecase.NewMockImportRepository(ctrl),
+					Clock:      mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:        context.Background(),
+				loans:      loansFile,
+				repayments: "loan_reference_id,reference_id,amount,paid_at\nloan9,repay1,550,2000-03-08\n",
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error repository",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ImportRepo: mock_usecase.NewMockImportRepository(ctrl),
+					Clock:      mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				loans: loansFile,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.ImportRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(nil, errors.New("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := ImportUseCase{
+				ImportRepo: f.ImportRepo,
+				Clock:      f.Clock,
+			}
+			tt.mock(f, tt.input)
+
+			request := entities.LoanImportRequest{
+				Loans:  strings.NewReader(tt.input.loans),
+				DryRun: tt.input.dryRun,
+			}
+			if tt.input.repayments != "" {
+				request.Repayments = strings.NewReader(tt.input.repayments)
+			}
+			got, err := u.ImportLoans(tt.input.ctx, request)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
@@ -2,9 +2,12 @@ package usecases
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/sirait-kevin/BillingEngine/domain/entities"
 	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/cron"
 )
 
 //go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/DBRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases DBRepository
@@ -18,11 +21,433 @@ type DBRepository interface {
 	SelectTotalRepaymentAmountByLoanId(ctx context.Context, loanId int64) (int64, error)
 	SelectRepaymentCountByLoanId(ctx context.Context, loanId int64) (int, error)
 	UpdateLoanStatusByReferenceId(ctx context.Context, tx interfaces.AtomicTransaction, referenceId string, status entities.LoanStatus) error
+	SelectLoanById(ctx context.Context, id int64) (*entities.Loan, error)
+	UpdateRepaymentStatusById(ctx context.Context, tx interfaces.AtomicTransaction, id int64, status entities.RepaymentStatus) error
+	NextReceiptSequence(ctx context.Context, tx interfaces.AtomicTransaction, tenantId int64, period string) (int64, error)
+	CreateReceipt(ctx context.Context, tx interfaces.AtomicTransaction, receipt entities.Receipt) (int64, error)
+	SelectReceiptByRepaymentReferenceId(ctx context.Context, referenceId string) (*entities.Receipt, error)
+	CancelReceipt(ctx context.Context, tx interfaces.AtomicTransaction, id int64, note string, cancelledAt time.Time) error
+	SelectOpenPromiseToPayByUserId(ctx context.Context, userId int64) (*[]entities.PromiseToPay, error)
 
 	BeginTx(ctx context.Context) (interfaces.AtomicTransaction, error)
 }
 
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/WebhookRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases WebhookRepository
+type WebhookRepository interface {
+	CreateWebhookSubscription(ctx context.Context, tx interfaces.AtomicTransaction, subscription entities.WebhookSubscription) (int64, error)
+	SelectWebhookSubscriptionById(ctx context.Context, id int64) (*entities.WebhookSubscription, error)
+	SelectWebhookSubscriptionByClientKey(ctx context.Context, clientKey string) (*[]entities.WebhookSubscription, error)
+	SelectWebhookSubscriptionByTenantId(ctx context.Context, tenantId int64) (*[]entities.WebhookSubscription, error)
+	UpdateWebhookSubscriptionStatus(ctx context.Context, tx interfaces.AtomicTransaction, id int64, isActive bool) error
+	CreateWebhookDelivery(ctx context.Context, tx interfaces.AtomicTransaction, delivery entities.WebhookDelivery) (int64, error)
+	SelectWebhookDeliveryById(ctx context.Context, id int64) (*entities.WebhookDelivery, error)
+	SelectWebhookDeliveryBySubscriptionId(ctx context.Context, subscriptionId int64) (*[]entities.WebhookDelivery, error)
+	SelectDueWebhookDelivery(ctx context.Context, now time.Time, limit int) (*[]entities.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, tx interfaces.AtomicTransaction, delivery entities.WebhookDelivery) error
+	CreateWebhookDeliveryAttempt(ctx context.Context, tx interfaces.AtomicTransaction, attempt entities.WebhookDeliveryAttempt) (int64, error)
+
+	BeginTx(ctx context.Context) (interfaces.AtomicTransaction, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/VirtualAccountRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases VirtualAccountRepository
+type VirtualAccountRepository interface {
+	CreateVirtualAccount(ctx context.Context, tx interfaces.AtomicTransaction, virtualAccount entities.VirtualAccount) (int64, error)
+	SelectVirtualAccountByNumber(ctx context.Context, number string) (*entities.VirtualAccount, error)
+	SelectVirtualAccountByLoanId(ctx context.Context, loanId int64) (*[]entities.VirtualAccount, error)
+	SelectLoanById(ctx context.Context, id int64) (*entities.Loan, error)
+	SelectLoanByReferenceId(ctx context.Context, referenceID string) (*entities.Loan, error)
+	SelectRepaymentByReferenceId(ctx context.Context, referenceID string) (*entities.Repayment, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/CollectionRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases CollectionRepository
+type CollectionRepository interface {
+	CreateDebitMandate(ctx context.Context, tx interfaces.AtomicTransaction, mandate entities.DebitMandate) (int64, error)
+	SelectDebitMandateById(ctx context.Context, id int64) (*entities.DebitMandate, error)
+	SelectDebitMandateByLoanId(ctx context.Context, loanId int64) (*[]entities.DebitMandate, error)
+	SelectActiveDebitMandate(ctx context.Context) (*[]entities.DebitMandate, error)
+	UpdateDebitMandateStatus(ctx context.Context, tx interfaces.AtomicTransaction, id int64, status entities.MandateStatus) error
+	CreateDebitInstruction(ctx context.Context, tx interfaces.AtomicTransaction, instruction entities.DebitInstruction) (int64, error)
+	SelectDebitInstructionByLoanId(ctx context.Context, loanId int64) (*[]entities.DebitInstruction, error)
+	SelectDueDebitInstruction(ctx context.Context, collectionDate time.Time) (*[]entities.DebitInstruction, error)
+	UpdateDebitInstruction(ctx context.Context, tx interfaces.AtomicTransaction, instruction entities.DebitInstruction) error
+	SelectLoanById(ctx context.Context, id int64) (*entities.Loan, error)
+	SelectLoanByReferenceId(ctx context.Context, referenceID string) (*entities.Loan, error)
+	SelectRepaymentCountByLoanId(ctx context.Context, loanId int64) (int, error)
+	SelectRepaymentAmountByLoanIdBetween(ctx context.Context, loanId int64, from, to time.Time) (int64, error)
+	CreatePromiseToPay(ctx context.Context, tx interfaces.AtomicTransaction, promise entities.PromiseToPay) (int64, error)
+	SelectPromiseToPayByLoanId(ctx context.Context, loanId int64) (*[]entities.PromiseToPay, error)
+	SelectOpenPromiseToPayByLoanId(ctx context.Context, loanId int64) (*entities.PromiseToPay, error)
+	SelectOpenPromiseToPay(ctx context.Context) (*[]entities.PromiseToPay, error)
+	UpdatePromiseToPay(ctx context.Context, tx interfaces.AtomicTransaction, promise entities.PromiseToPay) error
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/JobRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases JobRepository
+type JobRepository interface {
+	AcquireJobLease(ctx context.Context, jobName, owner string, now, leaseUntil time.Time) (bool, error)
+	RenewJobLease(ctx context.Context, jobName, owner string, now, leaseUntil time.Time) (bool, error)
+	ReleaseJobLease(ctx context.Context, jobName, owner string) error
+	CreateJobRun(ctx context.Context, tx interfaces.AtomicTransaction, run entities.JobRun) (int64, error)
+	SelectJobRunByBusinessDate(ctx context.Context, jobName string, businessDate time.Time) (*entities.JobRun, error)
+	SelectJobRunByJobName(ctx context.Context, jobName string, limit int) (*[]entities.JobRun, error)
+	UpdateJobRun(ctx context.Context, tx interfaces.AtomicTransaction, run entities.JobRun) error
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/SnapshotRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases SnapshotRepository
+type SnapshotRepository interface {
+	SelectLoanCreatedBefore(ctx context.Context, createdBefore time.Time, afterId int64, limit int) (*[]entities.Loan, error)
+	SelectRepaymentCountByLoanIds(ctx context.Context, loanIds []int64, createdBefore time.Time) (map[int64]int, error)
+	UpsertLoanDailySnapshot(ctx context.Context, tx interfaces.AtomicTransaction, snapshot entities.LoanDailySnapshot) error
+	SelectLoanDailySnapshotByBusinessDate(ctx context.Context, businessDate time.Time) (*[]entities.LoanDailySnapshot, error)
+
+	BeginTx(ctx context.Context) (interfaces.AtomicTransaction, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/ImportRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases ImportRepository
+type ImportRepository interface {
+	SelectLoanByReferenceId(ctx context.Context, referenceID string) (*entities.Loan, error)
+	SelectRepaymentByReferenceId(ctx context.Context, referenceID string) (*entities.Repayment, error)
+	CreateImportedLoan(ctx context.Context, tx interfaces.AtomicTransaction, loan entities.Loan) (int64, error)
+	CreateImportedRepayment(ctx context.Context, tx interfaces.AtomicTransaction, repayment entities.Repayment) (int64, error)
+
+	BeginTx(ctx context.Context) (interfaces.AtomicTransaction, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/StatementRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases StatementRepository
+type StatementRepository interface {
+	SelectLoanByUserId(ctx context.Context, userId int64) (*[]entities.Loan, error)
+	SelectRepaymentByLoanId(ctx context.Context, loanId int64) (*[]entities.Repayment, error)
+	CreateStatement(ctx context.Context, tx interfaces.AtomicTransaction, statement entities.Statement) error
+	SelectStatementByPeriod(ctx context.Context, userId int64, periodStart time.Time) (*entities.Statement, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/ReportRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases ReportRepository
+type ReportRepository interface {
+	SelectAgingSummary(ctx context.Context, filter entities.ReportFilter) (*[]entities.AgingBucketSummary, error)
+	SelectOutstandingBySchedule(ctx context.Context, filter entities.ReportFilter) (*[]entities.ScheduleOutstanding, error)
+	SelectLoanByReportFilter(ctx context.Context, filter entities.ReportFilter, createdFrom, createdBefore time.Time) (*[]entities.Loan, error)
+	SelectRepaymentCountByLoanIds(ctx context.Context, loanIds []int64, createdBefore time.Time) (map[int64]int, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/SettlementRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases SettlementRepository
+type SettlementRepository interface {
+	CreateSettlementFile(ctx context.Context, tx interfaces.AtomicTransaction, file entities.SettlementFile) (int64, error)
+	SelectSettlementFileByChecksum(ctx context.Context, bankCode, checksum string) (*entities.SettlementFile, error)
+	UpdateSettlementFile(ctx context.Context, tx interfaces.AtomicTransaction, file entities.SettlementFile) error
+	CreateSettlementException(ctx context.Context, tx interfaces.AtomicTransaction, exception entities.SettlementException) (int64, error)
+	SelectSettlementExceptionById(ctx context.Context, id int64) (*entities.SettlementException, error)
+	SelectSettlementExceptionByStatus(ctx context.Context, status entities.SettlementExceptionStatus) (*[]entities.SettlementException, error)
+	UpdateSettlementException(ctx context.Context, tx interfaces.AtomicTransaction, exception entities.SettlementException) error
+	SelectVirtualAccountByNumber(ctx context.Context, number string) (*entities.VirtualAccount, error)
+	SelectLoanById(ctx context.Context, id int64) (*entities.Loan, error)
+	SelectLoansByReferenceId(ctx context.Context, referenceID string) (*[]entities.Loan, error)
+	SelectRepaymentByReferenceId(ctx context.Context, referenceID string) (*entities.Repayment, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/ReconciliationRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases ReconciliationRepository
+type ReconciliationRepository interface {
+	CreateBankStatementLine(ctx context.Context, tx interfaces.AtomicTransaction, line entities.BankStatementLine) (int64, error)
+	SelectBankStatementLineById(ctx context.Context, id int64) (*entities.BankStatementLine, error)
+	SelectBankStatementLineByRepaymentId(ctx context.Context, repaymentId int64) (*entities.BankStatementLine, error)
+	SelectBankStatementLineByValueDate(ctx context.Context, fromDate, toDate time.Time) (*[]entities.BankStatementLine, error)
+	SelectRepaymentReconciliationStatus(ctx context.Context, repaymentIds []int64) (map[int64]entities.ReconciliationStatus, error)
+	UpdateBankStatementLineMatch(ctx context.Context, tx interfaces.AtomicTransaction, line entities.BankStatementLine) error
+	SelectRepaymentByCreatedAt(ctx context.Context, createdFrom, createdBefore time.Time) (*[]entities.Repayment, error)
+	SelectRepaymentByReferenceId(ctx context.Context, referenceID string) (*entities.Repayment, error)
+
+	BeginTx(ctx context.Context) (interfaces.AtomicTransaction, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/WriteOffRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases WriteOffRepository
+type WriteOffRepository interface {
+	SelectLoanByReferenceId(ctx context.Context, referenceID string) (*entities.Loan, error)
+	SelectLoanCreatedBefore(ctx context.Context, createdBefore time.Time, afterId int64, limit int) (*[]entities.Loan, error)
+	SelectRepaymentCountByLoanId(ctx context.Context, loanId int64) (int, error)
+	SelectRepaymentCountByLoanIds(ctx context.Context, loanIds []int64, createdBefore time.Time) (map[int64]int, error)
+	SelectTotalRepaymentAmountByLoanId(ctx context.Context, loanId int64) (int64, error)
+	UpdateLoanStatusByReferenceId(ctx context.Context, tx interfaces.AtomicTransaction, referenceId string, status entities.LoanStatus) error
+	CreateWriteOff(ctx context.Context, tx interfaces.AtomicTransaction, writeOff entities.WriteOff) (int64, error)
+	SelectWriteOffByLoanId(ctx context.Context, loanId int64) (*entities.WriteOff, error)
+	SelectWriteOffByLoanIdForUpdate(ctx context.Context, tx interfaces.AtomicTransaction, loanId int64) (*entities.WriteOff, error)
+	CreateRecovery(ctx context.Context, tx interfaces.AtomicTransaction, recovery entities.Recovery) (int64, error)
+	SelectRecoveryByReferenceId(ctx context.Context, referenceId string) (*entities.Recovery, error)
+	SelectRecoveryByLoanId(ctx context.Context, loanId int64) (*[]entities.Recovery, error)
+	SelectOpenPromiseToPayByLoanId(ctx context.Context, loanId int64) (*entities.PromiseToPay, error)
+
+	BeginTx(ctx context.Context) (interfaces.AtomicTransaction, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/ScheduleRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases ScheduleRepository
+type ScheduleRepository interface {
+	SelectLoanByReferenceId(ctx context.Context, referenceID string) (*entities.Loan, error)
+	SelectRepaymentCountByLoanId(ctx context.Context, loanId int64) (int, error)
+	SelectTotalRepaymentAmountByLoanId(ctx context.Context, loanId int64) (int64, error)
+	SelectLoanScheduleVersionByLoanId(ctx context.Context, loanId int64) (*[]entities.LoanScheduleVersion, error)
+	CreateLoanScheduleVersion(ctx context.Context, tx interfaces.AtomicTransaction, version entities.LoanScheduleVersion) (int64, error)
+	CloseLoanScheduleVersion(ctx context.Context, tx interfaces.AtomicTransaction, id int64, closedAt time.Time) error
+	UpdateLoanSchedule(ctx context.Context, tx interfaces.AtomicTransaction, loan entities.Loan) error
+
+	BeginTx(ctx context.Context) (interfaces.AtomicTransaction, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/DunningRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases DunningRepository
+type DunningRepository interface {
+	SelectLoanByReferenceId(ctx context.Context, referenceID string) (*entities.Loan, error)
+	SelectLoanCreatedBefore(ctx context.Context, createdBefore time.Time, afterId int64, limit int) (*[]entities.Loan, error)
+	SelectRepaymentCountByLoanId(ctx context.Context, loanId int64) (int, error)
+	SelectRepaymentCountByLoanIds(ctx context.Context, loanIds []int64, createdBefore time.Time) (map[int64]int, error)
+	SelectOpenPromiseToPayByLoanId(ctx context.Context, loanId int64) (*entities.PromiseToPay, error)
+	SelectLoanDunningLevelByLoanId(ctx context.Context, loanId int64) (*entities.LoanDunningLevel, error)
+	SelectLoanDunningLevelByLoanIds(ctx context.Context, loanIds []int64) (map[int64]entities.LoanDunningLevel, error)
+	UpsertLoanDunningLevel(ctx context.Context, tx interfaces.AtomicTransaction, level entities.LoanDunningLevel) error
+	CreateDunningAction(ctx context.Context, tx interfaces.AtomicTransaction, action entities.DunningActionItem) (int64, error)
+	SelectDunningActionById(ctx context.Context, id int64) (*entities.DunningActionItem, error)
+	SelectDunningActionByStatus(ctx context.Context, status entities.DunningActionStatus, action entities.DunningAction) (*[]entities.DunningActionItem, error)
+	UpdateDunningActionStatus(ctx context.Context, tx interfaces.AtomicTransaction, id int64, status entities.DunningActionStatus) error
+
+	BeginTx(ctx context.Context) (interfaces.AtomicTransaction, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/NotificationRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases NotificationRepository
+type NotificationRepository interface {
+	SelectLoanById(ctx context.Context, id int64) (*entities.Loan, error)
+	SelectLoanCreatedBefore(ctx context.Context, createdBefore time.Time, afterId int64, limit int) (*[]entities.Loan, error)
+	SelectRepaymentCountByLoanIds(ctx context.Context, loanIds []int64, createdBefore time.Time) (map[int64]int, error)
+	SelectNotificationPreferenceByUserId(ctx context.Context, userId int64) (*entities.NotificationPreference, error)
+	UpsertNotificationPreference(ctx context.Context, tx interfaces.AtomicTransaction, preference entities.NotificationPreference) error
+	CreateNotificationDelivery(ctx context.Context, tx interfaces.AtomicTransaction, delivery entities.NotificationDelivery) (int64, error)
+	SelectNotificationDeliveryByUserId(ctx context.Context, userId int64) (*[]entities.NotificationDelivery, error)
+	SelectNotificationDeliveryByReference(ctx context.Context, kind entities.NotificationKind, reference string) (*[]entities.NotificationDelivery, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/ApiClientRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases ApiClientRepository
+type ApiClientRepository interface {
+	CreateApiClient(ctx context.Context, tx interfaces.AtomicTransaction, client entities.ApiClient) (int64, error)
+	SelectApiClientByClientKey(ctx context.Context, clientKey string) (*entities.ApiClient, error)
+	SelectApiClients(ctx context.Context) (*[]entities.ApiClient, error)
+	UpdateApiClientStatus(ctx context.Context, tx interfaces.AtomicTransaction, clientKey string, isActive bool) error
+	UpdateApiClientRateLimit(ctx context.Context, tx interfaces.AtomicTransaction, clientKey string, limit entities.RateLimit) error
+	UpdateApiClientSecret(ctx context.Context, tx interfaces.AtomicTransaction, client entities.ApiClient) error
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/AuthAuditRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases AuthAuditRepository
+type AuthAuditRepository interface {
+	CreateAuthFailure(ctx context.Context, tx interfaces.AtomicTransaction, failure entities.AuthFailure) (int64, error)
+	CountInvalidSignatureFailures(ctx context.Context, clientKey, sourceIp string, since time.Time) (int, error)
+	SelectAuthFailures(ctx context.Context, clientKey string, limit int) (*[]entities.AuthFailure, error)
+	PurgeAuthFailures(ctx context.Context, before time.Time) (int64, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/TenantRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases TenantRepository
+type TenantRepository interface {
+	CreateTenant(ctx context.Context, tx interfaces.AtomicTransaction, tenant entities.Tenant) (int64, error)
+	SelectTenantById(ctx context.Context, id int64) (*entities.Tenant, error)
+	SelectTenants(ctx context.Context) (*[]entities.Tenant, error)
+	UpdateTenantSettings(ctx context.Context, tx interfaces.AtomicTransaction, tenant entities.Tenant) error
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/EncryptionRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases EncryptionRepository
+type EncryptionRepository interface {
+	EncryptedColumns() []entities.EncryptedColumn
+	SelectEncryptedValues(ctx context.Context, column entities.EncryptedColumn, afterId int64, limit int) ([]entities.EncryptedValue, error)
+	UpdateEncryptedValue(ctx context.Context, tx interfaces.AtomicTransaction, column entities.EncryptedColumn, value entities.EncryptedValue, previous []byte) (bool, error)
+}
+
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/AuditRepository.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases AuditRepository
+type AuditRepository interface {
+	SelectLoanByReferenceId(ctx context.Context, referenceID string) (*entities.Loan, error)
+	SelectAuditEntriesByLoanId(ctx context.Context, loanId int64, limit int) (*[]entities.AuditEntry, error)
+	SelectAuditEntriesByUserId(ctx context.Context, userId int64, limit int) (*[]entities.AuditEntry, error)
+	SelectAuditEntriesAfterId(ctx context.Context, afterId int64, limit int) (*[]entities.AuditEntry, error)
+	SelectAuditHead(ctx context.Context) (int64, string, error)
+}
+
+// TenantProvider gives the settings of the tenant of the current request.
+//
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/TenantProvider.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases TenantProvider
+type TenantProvider interface {
+	GetCurrentTenant(ctx context.Context) (*entities.Tenant, error)
+}
+
+// TenantLister gives every tenant a job runs for.
+//
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/TenantLister.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases TenantLister
+type TenantLister interface {
+	GetTenantList(ctx context.Context) (*[]entities.Tenant, error)
+}
+
+// PaymentMaker records a repayment through the regular repayment flow.
+//
+//go:generate mockgen -build_flags=-mod=mod -destination ../mocks/usecases/PaymentMaker.go -package=mock_usecase github.com/sirait-kevin/BillingEngine/usecases PaymentMaker
+type PaymentMaker interface {
+	MakePayment(ctx context.Context, repaymentRequest entities.RepaymentRequest) (int64, error)
+}
+
+// BillingUseCase takes the delinquency policy from the tenant of the request,
+// or from the default tenant when Tenants is nil.
 type BillingUseCase struct {
-	DBRepo DBRepository
-	Clock  interfaces.Clock
+	DBRepo  DBRepository
+	Clock   interfaces.Clock
+	Events  interfaces.EventPublisher
+	Tenants TenantProvider
+}
+
+type TenantUseCase struct {
+	TenantRepo TenantRepository
+}
+
+// EncryptionUseCase re-encrypts the encrypted columns under the primary key
+// of the keyring after a rotation, BatchSize rows at a time
+type EncryptionUseCase struct {
+	EncryptionRepo EncryptionRepository
+	BatchSize      int
+}
+
+// AuditUseCase reads the audit log and verifies its hash chain, BatchSize
+// entries at a time
+type AuditUseCase struct {
+	AuditRepo AuditRepository
+	BatchSize int
+}
+
+// ApiClientUseCase manages the clients allowed to call the API. A rotated
+// secret stays valid for RotationGracePeriod after the rotation.
+type ApiClientUseCase struct {
+	ApiClientRepo       ApiClientRepository
+	Clock               interfaces.Clock
+	RotationGracePeriod time.Duration
+}
+
+// AuthAuditUseCase keeps the requests turned away by the signature check. A
+// client key with MaxFailures failures within BlockWindow is blocked until
+// its older failures fall out of the window.
+type AuthAuditUseCase struct {
+	AuthAuditRepo AuthAuditRepository
+	Clock         interfaces.Clock
+	MaxFailures   int
+	BlockWindow   time.Duration
+	Retention     time.Duration
+}
+
+type WebhookUseCase struct {
+	WebhookRepo WebhookRepository
+	HTTPClient  interfaces.HTTPClient
+	Clock       interfaces.Clock
+	MaxAttempts int
+	BaseBackoff time.Duration
+}
+
+type VirtualAccountUseCase struct {
+	VirtualAccountRepo VirtualAccountRepository
+	Generators         map[string]interfaces.VirtualAccountGenerator
+	Payments           PaymentMaker
+	Clock              interfaces.Clock
+}
+
+// CollectionUseCase dates today in the timezone of the tenant of the
+// request, or of the default tenant when Tenants is nil.
+type CollectionUseCase struct {
+	CollectionRepo CollectionRepository
+	Collector      interfaces.PaymentCollector
+	Payments       PaymentMaker
+	Clock          interfaces.Clock
+	RetryPolicy    entities.RetryPolicy
+	PromisePolicy  entities.PromisePolicy
+	Tenants        TenantProvider
+}
+
+type SettlementUseCase struct {
+	SettlementRepo SettlementRepository
+	Parsers        map[string]interfaces.SettlementParser
+	Payments       PaymentMaker
+}
+
+type ReconciliationUseCase struct {
+	ReconciliationRepo ReconciliationRepository
+	Clock              interfaces.Clock
+	Tolerance          entities.MatchTolerance
+}
+
+// WriteOffUseCase dates today in the timezone of the tenant of the request,
+// or of the default tenant when Tenants is nil.
+type WriteOffUseCase struct {
+	WriteOffRepo WriteOffRepository
+	Clock        interfaces.Clock
+	Policy       entities.WriteOffPolicy
+	Tenants      TenantProvider
+}
+
+type ScheduleUseCase struct {
+	ScheduleRepo ScheduleRepository
+	Clock        interfaces.Clock
+}
+
+// DunningUseCase dates today in the timezone of the tenant of the request,
+// or of the default tenant when Tenants is nil.
+type DunningUseCase struct {
+	DunningRepo DunningRepository
+	Clock       interfaces.Clock
+	Events      interfaces.EventPublisher
+	Policy      entities.DunningPolicy
+	Tenants     TenantProvider
+}
+
+// NotificationUseCase sends borrowers their messages on every channel that
+// has a notifier. Due reminders go out ReminderDays days before the due date.
+type NotificationUseCase struct {
+	NotificationRepo NotificationRepository
+	Notifiers        map[entities.NotificationChannel]interfaces.Notifier
+	Templates        []entities.NotificationTemplate
+	ReminderDays     int
+}
+
+type SnapshotUseCase struct {
+	SnapshotRepo SnapshotRepository
+	Clock        interfaces.Clock
+}
+
+// ImportUseCase books a loan without a currency column in the currency of
+// the tenant, or of the default tenant when Tenants is nil.
+type ImportUseCase struct {
+	ImportRepo ImportRepository
+	Clock      interfaces.Clock
+	Tenants    TenantProvider
+}
+
+type StatementUseCase struct {
+	StatementRepo StatementRepository
+	Clock         interfaces.Clock
+}
+
+type ReportUseCase struct {
+	ReportRepo ReportRepository
+	Clock      interfaces.Clock
+}
+
+// Job is a task the scheduler runs once per business date on the schedule.
+// Run must be safe to call again for a business date it already processed.
+// A PerTenant job is run once for every tenant, within the tenant and with
+// the business date in the timezone of the tenant, any other job sees every
+// tenant at once.
+type Job struct {
+	Name      string
+	Schedule  *cron.Schedule
+	PerTenant bool
+	Run       func(ctx context.Context, businessDate time.Time) error
+}
+
+// JobUseCase runs the per tenant jobs for every tenant of Tenants, or for the
+// default tenant when Tenants is nil. The business dates of manual runs are
+// dates of Location, time.Local when it is nil.
+type JobUseCase struct {
+	JobRepo       JobRepository
+	Tenants       TenantLister
+	Clock         interfaces.Clock
+	Jobs          []Job
+	InstanceId    string
+	LeaseDuration time.Duration
+	Location      *time.Location
+
+	mu sync.Mutex
+	// the scheduled occurrences RunDue could not run yet
+	pending []jobOccurrence
 }
@@ -0,0 +1,299 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const (
+	defaultJobLeaseDuration = 15 * time.Minute
+	jobRunHistoryLimit      = 100
+)
+
+func (u *JobUseCase) GetJobList(ctx context.Context) (*[]entities.JobInfo, error) {
+	now := u.Clock.Now()
+
+	jobs := make([]entities.JobInfo, len(u.Jobs))
+	for i, job := range u.Jobs {
+		jobs[i] = entities.JobInfo{
+			Name:     job.Name,
+			Schedule: job.Schedule.String(),
+			NextRun:  job.Schedule.Next(now),
+		}
+	}
+
+	return &jobs, nil
+}
+
+func (u *JobUseCase) GetJobRunList(ctx context.Context, jobName string) (*[]entities.JobRun, error) {
+	if _, ok := u.findJob(jobName); !ok {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "job name is not registered")
+	}
+
+	runs, err := u.JobRepo.SelectJobRunByJobName(ctx, jobName, jobRunHistoryLimit)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+		return &[]entities.JobRun{}, nil
+	}
+
+	return runs, nil
+}
+
+type jobOccurrence struct {
+	job          Job
+	businessDate time.Time
+}
+
+// RunDue runs every job with a scheduled occurrence in (from, to] and returns
+// the runs it made. An occurrence whose lease is held by another run is kept
+// and tried again by the next calls until the lease frees up, a business date
+// the other run already finished is not run twice. Any other error stops the
+// round, the occurrences it did not get to are kept as well.
+func (u *JobUseCase) RunDue(ctx context.Context, from, to time.Time) (*[]entities.JobRun, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	due := u.pending
+	u.pending = nil
+	for _, job := range u.Jobs {
+		occurrence := job.Schedule.Next(from)
+		if occurrence.IsZero() || occurrence.After(to) {
+			continue
+		}
+		due = append(due, jobOccurrence{job: job, businessDate: helper.TruncateToDay(occurrence)})
+	}
+
+	runs := []entities.JobRun{}
+	for i, occurrence := range due {
+		run, err := u.runJob(ctx, occurrence.job, occurrence.businessDate, entities.JobTriggerScheduled, false)
+		if err != nil {
+			if errs.GetHTTPCode(err) == http.StatusConflict {
+				u.pending = append(u.pending, occurrence)
+				continue
+			}
+			u.pending = append(u.pending, due[i+1:]...)
+			return &runs, err
+		}
+		runs = append(runs, *run)
+	}
+
+	return &runs, nil
+}
+
+// TriggerJob runs a job by hand. A business date that already succeeded is
+// only run again when forced.
+func (u *JobUseCase) TriggerJob(ctx context.Context, request entities.JobRunRequest) (*entities.JobRun, error) {
+	job, ok := u.findJob(request.JobName)
+	if !ok {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "job name is not registered")
+	}
+
+	location := u.Location
+	if location == nil {
+		location = time.Local
+	}
+
+	businessDate := helper.TruncateToDay(u.Clock.Now().In(location))
+	if request.BusinessDate != "" {
+		var err error
+		businessDate, err = helper.ParseDate(request.BusinessDate, location)
+		if err != nil {
+			return nil, errs.NewWithMessage(http.StatusBadRequest, "business date must be formatted as "+helper.DateLayout)
+		}
+	}
+
+	return u.runJob(ctx, job, businessDate, entities.JobTriggerManual, request.Force)
+}
+
+func (u *JobUseCase) runJob(ctx context.Context, job Job, businessDate time.Time, trigger entities.JobTrigger, force bool) (*entities.JobRun, error) {
+	// a job runs for every tenant, whoever triggered it
+	ctx = helper.WithOperator(ctx)
+
+	now := u.Clock.Now()
+	acquired, err := u.JobRepo.AcquireJobLease(ctx, job.Name, u.InstanceId, now, now.Add(u.leaseDuration()))
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, errs.NewWithMessage(http.StatusConflict, "job "+job.Name+" is already running")
+	}
+	// a lease that fails to be released simply expires
+	defer u.JobRepo.ReleaseJobLease(ctx, job.Name, u.InstanceId)
+
+	run, err := u.JobRepo.SelectJobRunByBusinessDate(ctx, job.Name, businessDate)
+	if err != nil && errs.GetHTTPCode(err) != http.StatusNotFound {
+		return nil, err
+	}
+	if run != nil && run.Status == entities.JobRunSucceeded && !force {
+		return run, nil
+	}
+
+	if run == nil {
+		run = &entities.JobRun{
+			JobName:      job.Name,
+			BusinessDate: businessDate,
+		}
+	}
+	run.Status = entities.JobRunRunning
+	run.Trigger = trigger
+	run.Owner = u.InstanceId
+	run.Attempts++
+	run.Error = ""
+	run.StartedAt = now
+	run.FinishedAt = time.Time{}
+
+	if run.Id == 0 {
+		run.Id, err = u.JobRepo.CreateJobRun(ctx, nil, *run)
+	} else {
+		err = u.JobRepo.UpdateJobRun(ctx, nil, *run)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	leaseCtx, stopLease := u.keepLease(ctx, job.Name)
+	if job.PerTenant {
+		err = u.executePerTenant(leaseCtx, job, businessDate)
+	} else {
+		err = executeJob(leaseCtx, job, businessDate)
+	}
+	leaseLost := stopLease()
+
+	run.Status = entities.JobRunSucceeded
+	switch {
+	case leaseLost:
+		run.Status = entities.JobRunFailed
+		run.Error = "job lease was lost before the job finished"
+	case err != nil:
+		run.Status = entities.JobRunFailed
+		run.Error = err.Error()
+	}
+	run.FinishedAt = u.Clock.Now()
+
+	err = u.JobRepo.UpdateJobRun(ctx, nil, *run)
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// RunLeased runs work that has to run on one instance at a time but is not a
+// scheduled job, like the webhook dispatcher, under the lease of the name.
+// Nothing is recorded about the run. It reports whether the lease was free,
+// the work is skipped when another instance holds it.
+func (u *JobUseCase) RunLeased(ctx context.Context, name string, work func(ctx context.Context) error) (bool, error) {
+	now := u.Clock.Now()
+	acquired, err := u.JobRepo.AcquireJobLease(ctx, name, u.InstanceId, now, now.Add(u.leaseDuration()))
+	if err != nil || !acquired {
+		return false, err
+	}
+	defer u.JobRepo.ReleaseJobLease(ctx, name, u.InstanceId)
+
+	leaseCtx, stopLease := u.keepLease(ctx, name)
+	err = work(leaseCtx)
+	if stopLease() {
+		return true, errors.Join(err, errors.New("lease of "+name+" was lost before the work finished"))
+	}
+
+	return true, err
+}
+
+// keepLease renews the lease of the job every third of the lease duration
+// while the job runs. The context given to the job is cancelled as soon as
+// the lease can not be renewed, stop ends the renewals and reports whether
+// the lease was lost.
+func (u *JobUseCase) keepLease(ctx context.Context, jobName string) (context.Context, func() bool) {
+	leaseCtx, cancel := context.WithCancel(ctx)
+	done, stopped := make(chan struct{}), make(chan struct{})
+	lost := false
+
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(u.leaseDuration() / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				now := u.Clock.Now()
+				renewed, err := u.JobRepo.RenewJobLease(ctx, jobName, u.InstanceId, now, now.Add(u.leaseDuration()))
+				// a failed renewal is retried while the lease is still live
+				if err == nil && !renewed {
+					lost = true
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	return leaseCtx, func() bool {
+		close(done)
+		<-stopped
+		cancel()
+		return lost
+	}
+}
+
+// executeJob keeps a panicking job from taking the scheduler down with it
+func executeJob(ctx context.Context, job Job, businessDate time.Time) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return job.Run(ctx, businessDate)
+}
+
+// executePerTenant runs the job for every tenant on the calendar date of the
+// business date in the timezone of the tenant. A tenant that fails does not
+// stop the others, the run fails with the errors of all of them.
+func (u *JobUseCase) executePerTenant(ctx context.Context, job Job, businessDate time.Time) error {
+	tenants := &[]entities.Tenant{entities.DefaultTenant}
+	if u.Tenants != nil {
+		var err error
+		tenants, err = u.Tenants.GetTenantList(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	var errList []error
+	for _, tenant := range *tenants {
+		tenantCtx := helper.WithTenantId(ctx, tenant.Id)
+		err := executeJob(tenantCtx, job, helper.DateIn(businessDate, tenant.Location()))
+		if err != nil {
+			errList = append(errList, fmt.Errorf("tenant %s: %w", tenant.Code, err))
+		}
+	}
+
+	return errors.Join(errList...)
+}
+
+func (u *JobUseCase) findJob(name string) (Job, bool) {
+	for _, job := range u.Jobs {
+		if job.Name == name {
+			return job, true
+		}
+	}
+	return Job{}, false
+}
+
+func (u *JobUseCase) leaseDuration() time.Duration {
+	if u.LeaseDuration <= 0 {
+		return defaultJobLeaseDuration
+	}
+	return u.LeaseDuration
+}
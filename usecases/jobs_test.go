@@ -0,0 +1,744 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/cron"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func TestJobUseCase_TriggerJob(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.JobRunRequest
+	}
+	type fields struct {
+		JobRepo *mock_usecase.MockJobRepository
+		Clock   *mock_domain.MockClock
+		Tenants *mock_usecase.MockTenantLister
+	}
+	now := time.Date(2000, 12, 1, 8, 0, 0, 0, time.Local)
+	businessDate := time.Date(2000, 11, 30, 0, 0, 0, 0, time.Local)
+	newYork, _ := time.LoadLocation("America/New_York")
+	tenants := []entities.Tenant{entities.DefaultTenant, {Id: 2, Code: "acme", Timezone: "America/New_York"}}
+	tests := []struct {
+		name   string
+		fields func(ctrl *gomock.Controller) fields
+		input  input
+		jobErr error
+		// how long the job runs, and the lease it runs under
+		jobDuration   time.Duration
+		leaseDuration time.Duration
+		// the timezone of the business dates, time.Local when nil
+		location *time.Location
+		mock     func(f fields, input input)
+		want     *entities.JobRun
+		// the business date each tenant ran a per tenant job on
+		wantTenantDates map[int64]time.Time
+		wantErr         bool
+	}{
+		{
+			name: "success first run",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				// triggered by an admin of a tenant, the job still runs for every tenant
+				ctx:   helper.WithTenantId(context.Background(), 2),
+				param: entities.JobRunRequest{JobName: "test_job", BusinessDate: "2000-11-30"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.JobRepo.EXPECT().AcquireJobLease(gomock.Any(), "test_job", "instance1", now, now.Add(15*time.Minute)).Return(true, nil)
+				f.JobRepo.EXPECT().ReleaseJobLease(gomock.Any(), "test_job", "instance1").Return(nil)
+				f.JobRepo.EXPECT().SelectJobRunByBusinessDate(gomock.Any(), "test_job", businessDate).Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+				f.JobRepo.EXPECT().CreateJobRun(gomock.Any(), nil, entities.JobRun{
+					JobName:      "test_job",
+					BusinessDate: businessDate,
+					Status:       entities.JobRunRunning,
+					Trigger:      entities.JobTriggerManual,
+					Owner:        "instance1",
+					Attempts:     1,
+					StartedAt:    now,
+				}).Return(int64(1), nil)
+				f.JobRepo.EXPECT().UpdateJobRun(gomock.Any(), nil, gomock.Any()).Return(nil)
+			},
+			want: &entities.JobRun{
+				Id:           1,
+				JobName:      "test_job",
+				BusinessDate: businessDate,
+				Status:       entities.JobRunSucceeded,
+				Trigger:      entities.JobTriggerManual,
+				Owner:        "instance1",
+				Attempts:     1,
+				StartedAt:    now,
+				FinishedAt:   now,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success business date is a date of the location",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.JobRunRequest{JobName: "test_job", BusinessDate: "2000-11-30"},
+			},
+			location: newYork,
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.JobRepo.EXPECT().AcquireJobLease(gomock.Any(), "test_job", "instance1", now, now.Add(15*time.Minute)).Return(true, nil)
+				f.JobRepo.EXPECT().ReleaseJobLease(gomock.Any(), "test_job", "instance1").Return(nil)
+				f.JobRepo.EXPECT().SelectJobRunByBusinessDate(gomock.Any(), "test_job", time.Date(2000, 11, 30, 0, 0, 0, 0, newYork)).
+					Return(&entities.JobRun{Id: 1, JobName: "test_job", Status: entities.JobRunSucceeded}, nil)
+			},
+			want:    &entities.JobRun{Id: 1, JobName: "test_job", Status: entities.JobRunSucceeded},
+			wantErr: false,
+		},
+		{
+			name: "success day already succeeded",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.JobRunRequest{JobName: "test_job", BusinessDate: "2000-11-30"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.JobRepo.EXPECT().AcquireJobLease(gomock.Any(), "test_job", "instance1", gomock.Any(), gomock.Any()).Return(true, nil)
+				f.JobRepo.EXPECT().ReleaseJobLease(gomock.Any(), "test_job", "instance1").Return(nil)
+				f.JobRepo.EXPECT().SelectJobRunByBusinessDate(gomock.Any(), "test_job", businessDate).Return(&entities.JobRun{
+					Id: 1, JobName: "test_job", BusinessDate: businessDate, Status: entities.JobRunSucceeded, Attempts: 1,
+				}, nil)
+			},
+			want: &entities.JobRun{
+				Id: 1, JobName: "test_job", BusinessDate: businessDate, Status: entities.JobRunSucceeded, Attempts: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success failed job is recorded",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.JobRunRequest{JobName: "test_job", BusinessDate: "2000-11-30"},
+			},
+			jobErr: errors.New("some error"),
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.JobRepo.EXPECT().AcquireJobLease(gomock.Any(), "test_job", "instance1", gomock.Any(), gomock.Any()).Return(true, nil)
+				f.JobRepo.EXPECT().ReleaseJobLease(gomock.Any(), "test_job", "instance1").Return(nil)
+				f.JobRepo.EXPECT().SelectJobRunByBusinessDate(gomock.Any(), "test_job", businessDate).Return(&entities.JobRun{
+					Id: 1, JobName: "test_job", BusinessDate: businessDate, Status: entities.JobRunFailed, Attempts: 1, Error: "old error",
+				}, nil)
+				f.JobRepo.EXPECT().UpdateJobRun(gomock.Any(), nil, gomock.Any()).Return(nil).Times(2)
+			},
+			want: &entities.JobRun{
+				Id:           1,
+				JobName:      "test_job",
+				BusinessDate: businessDate,
+				Status:       entities.JobRunFailed,
+				Trigger:      entities.JobTriggerManual,
+				Owner:        "instance1",
+				Attempts:     2,
+				Error:        "some error",
+				StartedAt:    now,
+				FinishedAt:   now,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error lease held by another instance",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.JobRunRequest{JobName: "test_job"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.JobRepo.EXPECT().AcquireJobLease(gomock.Any(), "test_job", "instance1", gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error unknown job",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.JobRunRequest{JobName: "unknown"},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error business date format",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.JobRunRequest{JobName: "test_job", BusinessDate: "30-11-2000"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success lease renewed while the job runs",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.JobRunRequest{JobName: "test_job", BusinessDate: "2000-11-30"},
+			},
+			jobDuration:   100 * time.Millisecond,
+			leaseDuration: 30 * time.Millisecond,
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.JobRepo.EXPECT().AcquireJobLease(gomock.Any(), "test_job", "instance1", now, now.Add(30*time.Millisecond)).Return(true, nil)
+				f.JobRepo.EXPECT().RenewJobLease(gomock.Any(), "test_job", "instance1", now, now.Add(30*time.Millisecond)).Return(true, nil).MinTimes(1)
+				f.JobRepo.EXPECT().ReleaseJobLease(gomock.Any(), "test_job", "instance1").Return(nil)
+				f.JobRepo.EXPECT().SelectJobRunByBusinessDate(gomock.Any(), "test_job", businessDate).Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+				f.JobRepo.EXPECT().CreateJobRun(gomock.Any(), nil, gomock.Any()).Return(int64(1), nil)
+				f.JobRepo.EXPECT().UpdateJobRun(gomock.Any(), nil, gomock.Any()).Return(nil)
+			},
+			want: &entities.JobRun{
+				Id:           1,
+				JobName:      "test_job",
+				BusinessDate: businessDate,
+				Status:       entities.JobRunSucceeded,
+				Trigger:      entities.JobTriggerManual,
+				Owner:        "instance1",
+				Attempts:     1,
+				StartedAt:    now,
+				FinishedAt:   now,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success run failed when the lease is lost",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.JobRunRequest{JobName: "test_job", BusinessDate: "2000-11-30"},
+			},
+			jobDuration:   time.Minute,
+			leaseDuration: 30 * time.Millisecond,
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.JobRepo.EXPECT().AcquireJobLease(gomock.Any(), "test_job", "instance1", gomock.Any(), gomock.Any()).Return(true, nil)
+				f.JobRepo.EXPECT().RenewJobLease(gomock.Any(), "test_job", "instance1", gomock.Any(), gomock.Any()).Return(false, nil)
+				f.JobRepo.EXPECT().ReleaseJobLease(gomock.Any(), "test_job", "instance1").Return(nil)
+				f.JobRepo.EXPECT().SelectJobRunByBusinessDate(gomock.Any(), "test_job", businessDate).Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+				f.JobRepo.EXPECT().CreateJobRun(gomock.Any(), nil, gomock.Any()).Return(int64(1), nil)
+				f.JobRepo.EXPECT().UpdateJobRun(gomock.Any(), nil, gomock.Any()).Return(nil)
+			},
+			want: &entities.JobRun{
+				Id:           1,
+				JobName:      "test_job",
+				BusinessDate: businessDate,
+				Status:       entities.JobRunFailed,
+				Trigger:      entities.JobTriggerManual,
+				Owner:        "instance1",
+				Attempts:     1,
+				Error:        "job lease was lost before the job finished",
+				StartedAt:    now,
+				FinishedAt:   now,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success per tenant job runs within every tenant on its own date",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+					Tenants: mock_usecase.NewMockTenantLister(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.JobRunRequest{JobName: "tenant_job", BusinessDate: "2000-11-30"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.JobRepo.EXPECT().AcquireJobLease(gomock.Any(), "tenant_job", "instance1", gomock.Any(), gomock.Any()).Return(true, nil)
+				f.JobRepo.EXPECT().ReleaseJobLease(gomock.Any(), "tenant_job", "instance1").Return(nil)
+				f.JobRepo.EXPECT().SelectJobRunByBusinessDate(gomock.Any(), "tenant_job", businessDate).Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+				f.JobRepo.EXPECT().CreateJobRun(gomock.Any(), nil, gomock.Any()).Return(int64(1), nil)
+				f.Tenants.EXPECT().GetTenantList(gomock.Any()).Return(&tenants, nil)
+				f.JobRepo.EXPECT().UpdateJobRun(gomock.Any(), nil, gomock.Any()).Return(nil)
+			},
+			want: &entities.JobRun{
+				Id:           1,
+				JobName:      "tenant_job",
+				BusinessDate: businessDate,
+				Status:       entities.JobRunSucceeded,
+				Trigger:      entities.JobTriggerManual,
+				Owner:        "instance1",
+				Attempts:     1,
+				StartedAt:    now,
+				FinishedAt:   now,
+			},
+			wantTenantDates: map[int64]time.Time{
+				1: time.Date(2000, 11, 30, 0, 0, 0, 0, entities.DefaultTenant.Location()),
+				2: time.Date(2000, 11, 30, 0, 0, 0, 0, newYork),
+			},
+			wantErr: false,
+		},
+		{
+			name: "success per tenant job failing for one tenant still runs the others",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+					Tenants: mock_usecase.NewMockTenantLister(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.JobRunRequest{JobName: "tenant_job", BusinessDate: "2000-11-30"},
+			},
+			jobErr: errors.New("boom"),
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.JobRepo.EXPECT().AcquireJobLease(gomock.Any(), "tenant_job", "instance1", gomock.Any(), gomock.Any()).Return(true, nil)
+				f.JobRepo.EXPECT().ReleaseJobLease(gomock.Any(), "tenant_job", "instance1").Return(nil)
+				f.JobRepo.EXPECT().SelectJobRunByBusinessDate(gomock.Any(), "tenant_job", businessDate).Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+				f.JobRepo.EXPECT().CreateJobRun(gomock.Any(), nil, gomock.Any()).Return(int64(1), nil)
+				f.Tenants.EXPECT().GetTenantList(gomock.Any()).Return(&tenants, nil)
+				f.JobRepo.EXPECT().UpdateJobRun(gomock.Any(), nil, gomock.Any()).Return(nil)
+			},
+			want: &entities.JobRun{
+				Id:           1,
+				JobName:      "tenant_job",
+				BusinessDate: businessDate,
+				Status:       entities.JobRunFailed,
+				Trigger:      entities.JobTriggerManual,
+				Owner:        "instance1",
+				Attempts:     1,
+				Error:        "tenant default: boom",
+				StartedAt:    now,
+				FinishedAt:   now,
+			},
+			wantTenantDates: map[int64]time.Time{
+				1: time.Date(2000, 11, 30, 0, 0, 0, 0, entities.DefaultTenant.Location()),
+				2: time.Date(2000, 11, 30, 0, 0, 0, 0, newYork),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			tenantDates := map[int64]time.Time{}
+			u := JobUseCase{
+				JobRepo:       f.JobRepo,
+				Clock:         f.Clock,
+				InstanceId:    "instance1",
+				LeaseDuration: tt.leaseDuration,
+				Location:      tt.location,
+				Jobs: []Job{
+					{
+						Name:     "test_job",
+						Schedule: cron.MustParse("0 6 * * *"),
+						Run: func(ctx context.Context, businessDate time.Time) error {
+							assert.Zero(t, helper.GetTenantId(ctx))
+							assert.True(t, helper.IsOperator(ctx))
+							select {
+							case <-ctx.Done():
+								return ctx.Err()
+							case <-time.After(tt.jobDuration):
+							}
+							return tt.jobErr
+						},
+					},
+					{
+						Name:      "tenant_job",
+						Schedule:  cron.MustParse("0 6 * * *"),
+						PerTenant: true,
+						Run: func(ctx context.Context, businessDate time.Time) error {
+							assert.False(t, helper.IsOperator(ctx))
+							tenantDates[helper.GetTenantId(ctx)] = businessDate
+							if helper.GetTenantId(ctx) == entities.DefaultTenantId {
+								return tt.jobErr
+							}
+							return nil
+						},
+					},
+				},
+			}
+			if f.Tenants != nil {
+				u.Tenants = f.Tenants
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.TriggerJob(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+			if tt.wantTenantDates != nil {
+				assert.Equal(t, tt.wantTenantDates, tenantDates)
+			}
+		})
+	}
+}
+
+func TestJobUseCase_RunDue(t *testing.T) {
+	type input struct {
+		ctx  context.Context
+		from time.Time
+		to   time.Time
+	}
+	type fields struct {
+		JobRepo *mock_usecase.MockJobRepository
+		Clock   *mock_domain.MockClock
+	}
+	now := time.Date(2000, 12, 1, 6, 0, 30, 0, time.Local)
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		input    input
+		mock     func(f fields, input input)
+		wantRuns int
+		wantErr  bool
+	}{
+		{
+			name: "success job due",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:  context.Background(),
+				from: now.Add(-time.Minute),
+				to:   now,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.JobRepo.EXPECT().AcquireJobLease(gomock.Any(), "test_job", "instance1", gomock.Any(), gomock.Any()).Return(true, nil)
+				f.JobRepo.EXPECT().ReleaseJobLease(gomock.Any(), "test_job", "instance1").Return(nil)
+				f.JobRepo.EXPECT().SelectJobRunByBusinessDate(gomock.Any(), "test_job", time.Date(2000, 12, 1, 0, 0, 0, 0, time.Local)).Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+				f.JobRepo.EXPECT().CreateJobRun(gomock.Any(), nil, gomock.Any()).Return(int64(1), nil)
+				f.JobRepo.EXPECT().UpdateJobRun(gomock.Any(), nil, gomock.Any()).Return(nil)
+			},
+			wantRuns: 1,
+			wantErr:  false,
+		},
+		{
+			name: "success job not due",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:  context.Background(),
+				from: now,
+				to:   now.Add(time.Minute),
+			},
+			mock: func(f fields, args input) {
+			},
+			wantRuns: 0,
+			wantErr:  false,
+		},
+		{
+			name: "success lease held by another instance",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:  context.Background(),
+				from: now.Add(-time.Minute),
+				to:   now,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.JobRepo.EXPECT().AcquireJobLease(gomock.Any(), "test_job", "instance1", gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantRuns: 0,
+			wantErr:  false,
+		},
+		{
+			name: "error acquire lease",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:  context.Background(),
+				from: now.Add(-time.Minute),
+				to:   now,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.JobRepo.EXPECT().AcquireJobLease(gomock.Any(), "test_job", "instance1", gomock.Any(), gomock.Any()).Return(false, errors.New("some error"))
+			},
+			wantRuns: 0,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := JobUseCase{
+				JobRepo:    f.JobRepo,
+				Clock:      f.Clock,
+				InstanceId: "instance1",
+				Jobs: []Job{
+					{
+						Name:     "test_job",
+						Schedule: cron.MustParse("0 6 * * *"),
+						Run: func(ctx context.Context, businessDate time.Time) error {
+							return nil
+						},
+					},
+				},
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.RunDue(tt.input.ctx, tt.input.from, tt.input.to)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Len(t, *got, tt.wantRuns)
+		})
+	}
+}
+
+func TestJobUseCase_RunLeased(t *testing.T) {
+	type fields struct {
+		JobRepo *mock_usecase.MockJobRepository
+		Clock   *mock_domain.MockClock
+	}
+	now := time.Date(2000, 12, 1, 6, 0, 30, 0, time.Local)
+	tests := []struct {
+		name        string
+		fields      func(ctrl *gomock.Controller) fields
+		workErr     error
+		mock        func(f fields)
+		wantRan     bool
+		wantWorkRan bool
+		wantErr     bool
+	}{
+		{
+			name: "success lease free",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			mock: func(f fields) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.JobRepo.EXPECT().AcquireJobLease(gomock.Any(), "leased_work", "instance1", now, now.Add(time.Minute)).Return(true, nil)
+				f.JobRepo.EXPECT().ReleaseJobLease(gomock.Any(), "leased_work", "instance1").Return(nil)
+			},
+			wantRan:     true,
+			wantWorkRan: true,
+			wantErr:     false,
+		},
+		{
+			name: "success lease held by another instance",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			mock: func(f fields) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.JobRepo.EXPECT().AcquireJobLease(gomock.Any(), "leased_work", "instance1", now, now.Add(time.Minute)).Return(false, nil)
+			},
+			wantRan:     false,
+			wantWorkRan: false,
+			wantErr:     false,
+		},
+		{
+			name: "error work",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			workErr: errors.New("boom"),
+			mock: func(f fields) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.JobRepo.EXPECT().AcquireJobLease(gomock.Any(), "leased_work", "instance1", now, now.Add(time.Minute)).Return(true, nil)
+				f.JobRepo.EXPECT().ReleaseJobLease(gomock.Any(), "leased_work", "instance1").Return(nil)
+			},
+			wantRan:     true,
+			wantWorkRan: true,
+			wantErr:     true,
+		},
+		{
+			name: "error acquire lease",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					JobRepo: mock_usecase.NewMockJobRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+				}
+			},
+			mock: func(f fields) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.JobRepo.EXPECT().AcquireJobLease(gomock.Any(), "leased_work", "instance1", now, now.Add(time.Minute)).Return(false, errors.New("db down"))
+			},
+			wantRan:     false,
+			wantWorkRan: false,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := JobUseCase{
+				JobRepo:       f.JobRepo,
+				Clock:         f.Clock,
+				InstanceId:    "instance1",
+				LeaseDuration: time.Minute,
+			}
+			tt.mock(f)
+
+			workRan := false
+			ran, err := u.RunLeased(context.Background(), "leased_work", func(ctx context.Context) error {
+				workRan = true
+				return tt.workErr
+			})
+			assert.EqualValues(t, tt.wantRan, ran)
+			assert.EqualValues(t, tt.wantWorkRan, workRan)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestJobUseCase_RunDue_RetriesSkippedOccurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobRepo := mock_usecase.NewMockJobRepository(ctrl)
+	clock := mock_domain.NewMockClock(ctrl)
+	now := time.Date(2000, 12, 1, 6, 0, 30, 0, time.Local)
+	businessDate := time.Date(2000, 12, 1, 0, 0, 0, 0, time.Local)
+	u := JobUseCase{
+		JobRepo:    jobRepo,
+		Clock:      clock,
+		InstanceId: "instance1",
+		Jobs: []Job{
+			{
+				Name:     "test_job",
+				Schedule: cron.MustParse("0 6 * * *"),
+				Run: func(ctx context.Context, businessDate time.Time) error {
+					return nil
+				},
+			},
+		},
+	}
+
+	clock.EXPECT().Now().Return(now).AnyTimes()
+	gomock.InOrder(
+		// the lease is held by a manual run of another day
+		jobRepo.EXPECT().AcquireJobLease(gomock.Any(), "test_job", "instance1", gomock.Any(), gomock.Any()).Return(false, nil),
+		jobRepo.EXPECT().AcquireJobLease(gomock.Any(), "test_job", "instance1", gomock.Any(), gomock.Any()).Return(false, nil),
+		jobRepo.EXPECT().AcquireJobLease(gomock.Any(), "test_job", "instance1", gomock.Any(), gomock.Any()).Return(true, nil),
+	)
+	jobRepo.EXPECT().ReleaseJobLease(gomock.Any(), "test_job", "instance1").Return(nil)
+	jobRepo.EXPECT().SelectJobRunByBusinessDate(gomock.Any(), "test_job", businessDate).Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+	jobRepo.EXPECT().CreateJobRun(gomock.Any(), nil, gomock.Any()).Return(int64(1), nil)
+	jobRepo.EXPECT().UpdateJobRun(gomock.Any(), nil, gomock.Any()).Return(nil)
+
+	runs, err := u.RunDue(context.Background(), now.Add(-time.Minute), now)
+	assert.Nil(t, err)
+	assert.Len(t, *runs, 0)
+
+	// no occurrence is due any more, the skipped one is tried again
+	runs, err = u.RunDue(context.Background(), now, now.Add(time.Minute))
+	assert.Nil(t, err)
+	assert.Len(t, *runs, 0)
+
+	runs, err = u.RunDue(context.Background(), now.Add(time.Minute), now.Add(2*time.Minute))
+	assert.Nil(t, err)
+	if assert.Len(t, *runs, 1) {
+		assert.EqualValues(t, businessDate, (*runs)[0].BusinessDate)
+	}
+
+	runs, err = u.RunDue(context.Background(), now.Add(2*time.Minute), now.Add(3*time.Minute))
+	assert.Nil(t, err)
+	assert.Len(t, *runs, 0)
+}
@@ -0,0 +1,344 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const (
+	defaultReminderDays           = 3
+	notificationReminderBatchSize = 500
+)
+
+// Publish notifies the borrower of the payments received on their loans, of
+// the loans they completed and of the dunning notices of their late loans.
+// Other events are not sent to borrowers.
+func (u *NotificationUseCase) Publish(ctx context.Context, event entities.Event) error {
+	// the borrower is looked up in the tenant of the loan, events of jobs are
+	// published without a tenant
+	if event.TenantId != 0 {
+		ctx = helper.WithTenantId(ctx, event.TenantId)
+	}
+
+	switch data := event.Data.(type) {
+	case entities.PaymentEventData:
+		if event.Type != entities.EventPaymentReceived {
+			return nil
+		}
+		loan, err := u.NotificationRepo.SelectLoanById(ctx, data.LoanId)
+		if err != nil {
+			return err
+		}
+		_, err = u.notify(ctx, loan.UserId, loan.Id, entities.NotificationPaymentReceived, event.Id, entities.NotificationData{
+			LoanReferenceId: data.LoanReferenceId,
+			Amount:          data.Amount,
+		})
+		return err
+	case entities.LoanEventData:
+		if event.Type != entities.EventLoanCompleted {
+			return nil
+		}
+		_, err := u.notify(ctx, data.UserId, data.LoanId, entities.NotificationLoanCompleted, event.Id, entities.NotificationData{
+			LoanReferenceId: data.LoanReferenceId,
+			Amount:          data.Amount,
+		})
+		return err
+	case entities.DunningEventData:
+		kind, ok := entities.DunningNotificationKinds[data.Action]
+		if event.Type != entities.EventDunningEscalated || !ok {
+			return nil
+		}
+		_, err := u.notify(ctx, data.UserId, data.LoanId, kind, event.Id, entities.NotificationData{
+			LoanReferenceId: data.LoanReferenceId,
+			DaysPastDue:     data.DaysPastDue,
+		})
+		return err
+	}
+
+	return nil
+}
+
+// SendReminders reminds the borrowers of every active loan of the installments
+// falling due within the reminder days of the business date, and tells them of
+// the installments that became late. Each installment gets one reminder and one
+// late notice, so a business date can be run again.
+func (u *NotificationUseCase) SendReminders(ctx context.Context, businessDate time.Time) (*entities.NotificationRunResult, error) {
+	businessDate = helper.TruncateToDay(businessDate)
+	result := &entities.NotificationRunResult{BusinessDate: businessDate}
+	endOfDay := businessDate.AddDate(0, 0, 1)
+
+	reminderDays := u.ReminderDays
+	if reminderDays < 1 {
+		reminderDays = defaultReminderDays
+	}
+
+	var afterId int64
+	for {
+		loans, err := u.NotificationRepo.SelectLoanCreatedBefore(ctx, endOfDay, afterId, notificationReminderBatchSize)
+		if err != nil {
+			if errs.GetHTTPCode(err) != http.StatusNotFound {
+				return nil, err
+			}
+			return result, nil
+		}
+		if len(*loans) == 0 {
+			return result, nil
+		}
+
+		var activeLoanIds []int64
+		for _, loan := range *loans {
+			if loan.Status.IsActive() {
+				activeLoanIds = append(activeLoanIds, loan.Id)
+			}
+		}
+		repaymentCounts := map[int64]int{}
+		if len(activeLoanIds) != 0 {
+			repaymentCounts, err = u.NotificationRepo.SelectRepaymentCountByLoanIds(ctx, activeLoanIds, endOfDay)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, loan := range *loans {
+			if !loan.Status.IsActive() {
+				continue
+			}
+			result.Loans++
+
+			for installment := repaymentCounts[loan.Id] + 1; installment <= loan.Tenor; installment++ {
+				dueDate := loan.DueDate(installment).In(businessDate.Location())
+				daysUntilDue := helper.DaysBetween(businessDate, dueDate)
+				if daysUntilDue > reminderDays {
+					break
+				}
+
+				kind := entities.NotificationDueReminder
+				if daysUntilDue < 0 {
+					kind = entities.NotificationLateNotice
+				}
+				sent, err := u.notify(helper.WithTenantId(ctx, loan.TenantId), loan.UserId, loan.Id, kind, loan.ReferenceId+"#"+strconv.Itoa(installment), entities.NotificationData{
+					LoanReferenceId: loan.ReferenceId,
+					Installment:     installment,
+					Amount:          loan.Money(loan.RepaymentAmount),
+					DueDate:         dueDate.Format(helper.DateLayout),
+				})
+				if err != nil {
+					return nil, err
+				}
+				if !sent {
+					continue
+				}
+				if kind == entities.NotificationLateNotice {
+					result.LateNotices++
+				} else {
+					result.DueReminders++
+				}
+			}
+		}
+
+		afterId = (*loans)[len(*loans)-1].Id
+		if len(*loans) < notificationReminderBatchSize {
+			return result, nil
+		}
+	}
+}
+
+func (u *NotificationUseCase) SavePreference(ctx context.Context, request entities.NotificationPreferenceRequest) (*entities.NotificationPreference, error) {
+	var errMessage []string
+
+	if !IsUserValid(request.UserId) {
+		errMessage = append(errMessage, "UserId is invalid")
+	}
+	if request.Locale == "" {
+		request.Locale = entities.DefaultNotificationLocale
+	}
+	if !u.hasLocale(request.Locale) {
+		errMessage = append(errMessage, "locale "+request.Locale+" is not supported")
+	}
+	for _, channel := range request.OptedOut {
+		if !channel.IsValid() {
+			errMessage = append(errMessage, "channel "+string(channel)+" is not supported")
+		}
+	}
+	if errMessage != nil || len(errMessage) != 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	preference := entities.NotificationPreference{
+		UserId:   request.UserId,
+		Locale:   request.Locale,
+		OptedOut: request.OptedOut,
+	}
+	if preference.OptedOut == nil {
+		preference.OptedOut = []entities.NotificationChannel{}
+	}
+	err := u.NotificationRepo.UpsertNotificationPreference(ctx, nil, preference)
+	if err != nil {
+		return nil, err
+	}
+
+	return &preference, nil
+}
+
+// GetPreference is the default preference for a borrower who never saved one
+func (u *NotificationUseCase) GetPreference(ctx context.Context, userId int64) (*entities.NotificationPreference, error) {
+	if !IsUserValid(userId) {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "UserId is invalid")
+	}
+
+	return u.preferenceOf(ctx, userId)
+}
+
+func (u *NotificationUseCase) GetDeliveryList(ctx context.Context, userId int64) (*[]entities.NotificationDelivery, error) {
+	if !IsUserValid(userId) {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "UserId is invalid")
+	}
+
+	return u.NotificationRepo.SelectNotificationDeliveryByUserId(ctx, userId)
+}
+
+// notify sends the message about the reference on every channel the borrower
+// did not opt out of and it was not sent on yet, logging every attempt. It is
+// false when nothing was attempted.
+func (u *NotificationUseCase) notify(ctx context.Context, userId, loanId int64, kind entities.NotificationKind, reference string, data entities.NotificationData) (bool, error) {
+	deliveries, err := u.NotificationRepo.SelectNotificationDeliveryByReference(ctx, kind, reference)
+	if err != nil {
+		return false, err
+	}
+	sentOn := map[entities.NotificationChannel]bool{}
+	for _, delivery := range *deliveries {
+		if delivery.Status == entities.NotificationSent {
+			sentOn[delivery.Channel] = true
+		}
+	}
+
+	preference, err := u.preferenceOf(ctx, userId)
+	if err != nil {
+		return false, err
+	}
+
+	var attempted bool
+	for _, channel := range entities.NotificationChannels {
+		notifier, ok := u.Notifiers[channel]
+		if !ok || sentOn[channel] || preference.IsOptedOutOf(channel) {
+			continue
+		}
+
+		message, err := u.render(userId, channel, kind, preference.Locale, data)
+		if err != nil {
+			return attempted, err
+		}
+
+		delivery := entities.NotificationDelivery{
+			UserId:    userId,
+			LoanId:    loanId,
+			Channel:   channel,
+			Kind:      kind,
+			Reference: reference,
+			Locale:    message.Locale,
+			Subject:   message.Subject,
+			Body:      message.Body,
+			Status:    entities.NotificationSent,
+		}
+		err = notifier.Send(ctx, message)
+		if err != nil {
+			delivery.Status = entities.NotificationFailed
+			delivery.Error = err.Error()
+		}
+
+		_, err = u.NotificationRepo.CreateNotificationDelivery(ctx, nil, delivery)
+		if err != nil {
+			return attempted, err
+		}
+		attempted = true
+	}
+
+	return attempted, nil
+}
+
+func (u *NotificationUseCase) preferenceOf(ctx context.Context, userId int64) (*entities.NotificationPreference, error) {
+	preference, err := u.NotificationRepo.SelectNotificationPreferenceByUserId(ctx, userId)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+		return &entities.NotificationPreference{
+			UserId:   userId,
+			Locale:   entities.DefaultNotificationLocale,
+			OptedOut: []entities.NotificationChannel{},
+		}, nil
+	}
+
+	return preference, nil
+}
+
+// render fills in the template of the kind in the locale, falling back to the
+// default locale when the locale has none. Only email carries a subject.
+func (u *NotificationUseCase) render(userId int64, channel entities.NotificationChannel, kind entities.NotificationKind, locale string, data entities.NotificationData) (entities.NotificationMessage, error) {
+	message := entities.NotificationMessage{UserId: userId, Channel: channel, Kind: kind}
+
+	tmpl, ok := u.templateOf(kind, locale)
+	if !ok {
+		tmpl, ok = u.templateOf(kind, entities.DefaultNotificationLocale)
+	}
+	if !ok {
+		return message, fmt.Errorf("no %s notification template", kind)
+	}
+	message.Locale = tmpl.Locale
+
+	var err error
+	if channel == entities.NotificationEmail {
+		message.Subject, err = renderTemplate(tmpl.Subject, data)
+		if err != nil {
+			return message, err
+		}
+	}
+	message.Body, err = renderTemplate(tmpl.Body, data)
+	if err != nil {
+		return message, err
+	}
+
+	return message, nil
+}
+
+func (u *NotificationUseCase) templateOf(kind entities.NotificationKind, locale string) (entities.NotificationTemplate, bool) {
+	for _, tmpl := range u.Templates {
+		if tmpl.Kind == kind && tmpl.Locale == locale {
+			return tmpl, true
+		}
+	}
+	return entities.NotificationTemplate{}, false
+}
+
+func (u *NotificationUseCase) hasLocale(locale string) bool {
+	for _, tmpl := range u.Templates {
+		if tmpl.Locale == locale {
+			return true
+		}
+	}
+	return false
+}
+
+func renderTemplate(source string, data entities.NotificationData) (string, error) {
+	tmpl, err := template.New("notification").Option("missingkey=error").Parse(source)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,489 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func TestNotificationUseCase_Publish(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		event entities.Event
+	}
+	type fields struct {
+		NotificationRepo *mock_usecase.MockNotificationRepository
+		Email            *mock_domain.MockNotifier
+		SMS              *mock_domain.MockNotifier
+	}
+	notFound := errs.Wrap(http.StatusNotFound, errors.New("not found"))
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		wantErr bool
+	}{
+		{
+			name: "success payment received",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					NotificationRepo: mock_usecase.NewMockNotificationRepository(ctrl),
+					Email:            mock_domain.NewMockNotifier(ctrl),
+					SMS:              mock_domain.NewMockNotifier(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				event: entities.Event{
+					Id:   "event1",
+					Type: entities.EventPaymentReceived,
+					Data: entities.PaymentEventData{LoanId: 1, LoanReferenceId: "loan1", Amount: entities.NewMoney(1050, "USD")},
+				},
+			},
+			mock: func(f fields, args input) {
+				f.NotificationRepo.EXPECT().SelectLoanById(gomock.Any(), int64(1)).Return(&entities.Loan{Id: 1, UserId: 10}, nil)
+				f.NotificationRepo.EXPECT().SelectNotificationDeliveryByReference(gomock.Any(), entities.NotificationPaymentReceived, "event1").
+					Return(&[]entities.NotificationDelivery{}, nil)
+				f.NotificationRepo.EXPECT().SelectNotificationPreferenceByUserId(gomock.Any(), int64(10)).Return(&entities.NotificationPreference{
+					UserId:   10,
+					Locale:   "id",
+					OptedOut: []entities.NotificationChannel{entities.NotificationSMS},
+				}, nil)
+				message := entities.NotificationMessage{
+					UserId:  10,
+					Channel: entities.NotificationEmail,
+					Kind:    entities.NotificationPaymentReceived,
+					Locale:  "id",
+					Subject: "Pembayaran pinjaman loan1 diterima",
+					Body:    "Pembayaran Anda sebesar USD 10.50 untuk pinjaman loan1 telah kami terima. Terima kasih.",
+				}
+				f.Email.EXPECT().Send(gomock.Any(), message).Return(nil)
+				f.NotificationRepo.EXPECT().CreateNotificationDelivery(gomock.Any(), nil, entities.NotificationDelivery{
+					UserId:    10,
+					LoanId:    1,
+					Channel:   entities.NotificationEmail,
+					Kind:      entities.NotificationPaymentReceived,
+					Reference: "event1",
+					Locale:    "id",
+					Subject:   message.Subject,
+					Body:      message.Body,
+					Status:    entities.NotificationSent,
+				}).Return(int64(1), nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success failed send is logged",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					NotificationRepo: mock_usecase.NewMockNotificationRepository(ctrl),
+					Email:            mock_domain.NewMockNotifier(ctrl),
+					SMS:              mock_domain.NewMockNotifier(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				event: entities.Event{
+					Id:   "event2",
+					Type: entities.EventLoanCompleted,
+					Data: entities.LoanEventData{LoanId: 1, LoanReferenceId: "loan1", UserId: 10, Amount: entities.NewMoney(1000, "USD")},
+				},
+			},
+			mock: func(f fields, args input) {
+				f.NotificationRepo.EXPECT().SelectNotificationDeliveryByReference(gomock.Any(), entities.NotificationLoanCompleted, "event2").
+					Return(&[]entities.NotificationDelivery{{Channel: entities.NotificationEmail, Status: entities.NotificationSent}}, nil)
+				f.NotificationRepo.EXPECT().SelectNotificationPreferenceByUserId(gomock.Any(), int64(10)).Return(nil, notFound)
+				f.SMS.EXPECT().Send(gomock.Any(), entities.NotificationMessage{
+					UserId:  10,
+					Channel: entities.NotificationSMS,
+					Kind:    entities.NotificationLoanCompleted,
+					Locale:  "en",
+					Body:    "Your loan loan1 is fully paid. Thank you for borrowing with us.",
+				}).Return(errors.New("provider down"))
+				f.NotificationRepo.EXPECT().CreateNotificationDelivery(gomock.Any(), nil, entities.NotificationDelivery{
+					UserId:    10,
+					LoanId:    1,
+					Channel:   entities.NotificationSMS,
+					Kind:      entities.NotificationLoanCompleted,
+					Reference: "event2",
+					Locale:    "en",
+					Body:      "Your loan loan1 is fully paid. Thank you for borrowing with us.",
+					Status:    entities.NotificationFailed,
+					Error:     "provider down",
+				}).Return(int64(2), nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success event is not for borrowers",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					NotificationRepo: mock_usecase.NewMockNotificationRepository(ctrl),
+					Email:            mock_domain.NewMockNotifier(ctrl),
+					SMS:              mock_domain.NewMockNotifier(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				event: entities.Event{
+					Id:   "event3",
+					Type: entities.EventLoanCreated,
+					Data: entities.LoanEventData{LoanId: 1, UserId: 10},
+				},
+			},
+			mock: func(f fields, args input) {
+			},
+			wantErr: false,
+		},
+		{
+			name: "success dunning notice",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					NotificationRepo: mock_usecase.NewMockNotificationRepository(ctrl),
+					Email:            mock_domain.NewMockNotifier(ctrl),
+					SMS:              mock_domain.NewMockNotifier(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				event: entities.Event{
+					Id:       "event4",
+					Type:     entities.EventDunningEscalated,
+					TenantId: 2,
+					Data: entities.DunningEventData{LoanId: 1, LoanReferenceId: "loan1", UserId: 10, Level: 2,
+						Action: entities.DunningSecondNotice, DaysPastDue: 7},
+				},
+			},
+			mock: func(f fields, args input) {
+				f.NotificationRepo.EXPECT().SelectNotificationDeliveryByReference(gomock.Any(), entities.NotificationDunningSecondNotice, "event4").
+					Return(&[]entities.NotificationDelivery{}, nil)
+				f.NotificationRepo.EXPECT().SelectNotificationPreferenceByUserId(gomock.Any(), int64(10)).
+					DoAndReturn(func(ctx context.Context, userId int64) (*entities.NotificationPreference, error) {
+						// the borrower belongs to the tenant of the loan
+						assert.Equal(t, int64(2), helper.GetTenantId(ctx))
+						return &entities.NotificationPreference{
+							UserId:   10,
+							Locale:   "en",
+							OptedOut: []entities.NotificationChannel{entities.NotificationSMS},
+						}, nil
+					})
+				message := entities.NotificationMessage{
+					UserId:  10,
+					Channel: entities.NotificationEmail,
+					Kind:    entities.NotificationDunningSecondNotice,
+					Locale:  "en",
+					Subject: "Second notice: loan loan1 is past due",
+					Body:    "Your loan loan1 is now 7 days past due. Please pay the late installments now to avoid further collection action.",
+				}
+				f.Email.EXPECT().Send(gomock.Any(), message).Return(nil)
+				f.NotificationRepo.EXPECT().CreateNotificationDelivery(gomock.Any(), nil, entities.NotificationDelivery{
+					UserId:    10,
+					LoanId:    1,
+					Channel:   entities.NotificationEmail,
+					Kind:      entities.NotificationDunningSecondNotice,
+					Reference: "event4",
+					Locale:    "en",
+					Subject:   message.Subject,
+					Body:      message.Body,
+					Status:    entities.NotificationSent,
+				}).Return(int64(3), nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success dunning action for collectors is not sent",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					NotificationRepo: mock_usecase.NewMockNotificationRepository(ctrl),
+					Email:            mock_domain.NewMockNotifier(ctrl),
+					SMS:              mock_domain.NewMockNotifier(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				event: entities.Event{
+					Id:   "event5",
+					Type: entities.EventDunningEscalated,
+					Data: entities.DunningEventData{LoanId: 1, UserId: 10, Level: 3, Action: entities.DunningCallQueue, DaysPastDue: 15},
+				},
+			},
+			mock: func(f fields, args input) {
+			},
+			wantErr: false,
+		},
+		{
+			name: "error select loan",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					NotificationRepo: mock_usecase.NewMockNotificationRepository(ctrl),
+					Email:            mock_domain.NewMockNotifier(ctrl),
+					SMS:              mock_domain.NewMockNotifier(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				event: entities.Event{
+					Id:   "event1",
+					Type: entities.EventPaymentReceived,
+					Data: entities.PaymentEventData{LoanId: 1},
+				},
+			},
+			mock: func(f fields, args input) {
+				f.NotificationRepo.EXPECT().SelectLoanById(gomock.Any(), int64(1)).Return(nil, errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := NotificationUseCase{
+				NotificationRepo: f.NotificationRepo,
+				Notifiers: map[entities.NotificationChannel]interfaces.Notifier{
+					entities.NotificationEmail: f.Email,
+					entities.NotificationSMS:   f.SMS,
+				},
+				Templates: entities.DefaultNotificationTemplates,
+			}
+			tt.mock(f, tt.input)
+
+			err := u.Publish(tt.input.ctx, tt.input.event)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestNotificationUseCase_SendReminders(t *testing.T) {
+	type input struct {
+		ctx          context.Context
+		businessDate time.Time
+	}
+	type fields struct {
+		NotificationRepo *mock_usecase.MockNotificationRepository
+		Push             *mock_domain.MockNotifier
+	}
+	businessDate := time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local)
+	notFound := errs.Wrap(http.StatusNotFound, errors.New("not found"))
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.NotificationRunResult
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					NotificationRepo: mock_usecase.NewMockNotificationRepository(ctrl),
+					Push:             mock_domain.NewMockNotifier(ctrl),
+				}
+			},
+			input: input{
+				ctx:          context.Background(),
+				businessDate: businessDate.Add(8 * time.Hour),
+			},
+			mock: func(f fields, args input) {
+				f.NotificationRepo.EXPECT().SelectLoanCreatedBefore(gomock.Any(), businessDate.AddDate(0, 0, 1), int64(0), notificationReminderBatchSize).Return(&[]entities.Loan{
+					{Id: 1, ReferenceId: "loan1", UserId: 10, Status: entities.LoanStatusActive, RepaymentSchedule: entities.RepaymentMonthly,
+						Tenor: 6, RepaymentAmount: 20050, Currency: "USD", CreatedAt: time.Date(2024, 4, 20, 9, 0, 0, 0, time.Local)},
+					{Id: 2, ReferenceId: "loan2", UserId: 11, Status: entities.LoanStatusActive, RepaymentSchedule: entities.RepaymentMonthly,
+						Tenor: 6, RepaymentAmount: 300, CreatedAt: time.Date(2024, 5, 3, 9, 0, 0, 0, time.Local)},
+					{Id: 3, ReferenceId: "loan3", UserId: 12, Status: entities.LoanStatusCompleted, RepaymentSchedule: entities.RepaymentMonthly,
+						Tenor: 1, RepaymentAmount: 200, CreatedAt: time.Date(2024, 1, 1, 9, 0, 0, 0, time.Local)},
+				}, nil)
+				f.NotificationRepo.EXPECT().SelectRepaymentCountByLoanIds(gomock.Any(), []int64{1, 2}, businessDate.AddDate(0, 0, 1)).Return(map[int64]int{}, nil)
+
+				// installment 1 of loan1 was due on 2024-05-20
+				f.NotificationRepo.EXPECT().SelectNotificationDeliveryByReference(gomock.Any(), entities.NotificationLateNotice, "loan1#1").
+					Return(&[]entities.NotificationDelivery{}, nil)
+				f.NotificationRepo.EXPECT().SelectNotificationPreferenceByUserId(gomock.Any(), int64(10)).Return(nil, notFound)
+				f.Push.EXPECT().Send(gomock.Any(), entities.NotificationMessage{
+					UserId:  10,
+					Channel: entities.NotificationPush,
+					Kind:    entities.NotificationLateNotice,
+					Locale:  "en",
+					Body:    "Your installment 1 of USD 200.50 for loan loan1 was due on 2024-05-20 and is now late. Please pay it as soon as possible.",
+				}).Return(nil)
+				f.NotificationRepo.EXPECT().CreateNotificationDelivery(gomock.Any(), nil, gomock.Any()).Return(int64(1), nil)
+
+				// installment 1 of loan2 is due on 2024-06-03 and was already reminded of
+				f.NotificationRepo.EXPECT().SelectNotificationDeliveryByReference(gomock.Any(), entities.NotificationDueReminder, "loan2#1").
+					Return(&[]entities.NotificationDelivery{{Channel: entities.NotificationPush, Status: entities.NotificationSent}}, nil)
+				f.NotificationRepo.EXPECT().SelectNotificationPreferenceByUserId(gomock.Any(), int64(11)).Return(nil, notFound)
+			},
+			want: &entities.NotificationRunResult{
+				BusinessDate: businessDate,
+				Loans:        2,
+				LateNotices:  1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error select loan",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					NotificationRepo: mock_usecase.NewMockNotificationRepository(ctrl),
+					Push:             mock_domain.NewMockNotifier(ctrl),
+				}
+			},
+			input: input{
+				ctx:          context.Background(),
+				businessDate: businessDate,
+			},
+			mock: func(f fields, args input) {
+				f.NotificationRepo.EXPECT().SelectLoanCreatedBefore(gomock.Any(), gomock.Any(), int64(0), notificationReminderBatchSize).Return(nil, errors.New("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := NotificationUseCase{
+				NotificationRepo: f.NotificationRepo,
+				Notifiers: map[entities.NotificationChannel]interfaces.Notifier{
+					entities.NotificationPush: f.Push,
+				},
+				Templates:    entities.DefaultNotificationTemplates,
+				ReminderDays: 3,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.SendReminders(tt.input.ctx, tt.input.businessDate)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestNotificationUseCase_SavePreference(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.NotificationPreferenceRequest
+	}
+	type fields struct {
+		NotificationRepo *mock_usecase.MockNotificationRepository
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.NotificationPreference
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					NotificationRepo: mock_usecase.NewMockNotificationRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.NotificationPreferenceRequest{
+					UserId:   10,
+					OptedOut: []entities.NotificationChannel{entities.NotificationSMS},
+				},
+			},
+			mock: func(f fields, args input) {
+				f.NotificationRepo.EXPECT().UpsertNotificationPreference(gomock.Any(), nil, entities.NotificationPreference{
+					UserId:   10,
+					Locale:   "en",
+					OptedOut: []entities.NotificationChannel{entities.NotificationSMS},
+				}).Return(nil)
+			},
+			want: &entities.NotificationPreference{
+				UserId:   10,
+				Locale:   "en",
+				OptedOut: []entities.NotificationChannel{entities.NotificationSMS},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error invalid request",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					NotificationRepo: mock_usecase.NewMockNotificationRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.NotificationPreferenceRequest{
+					UserId:   10,
+					Locale:   "fr",
+					OptedOut: []entities.NotificationChannel{"fax"},
+				},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error upsert preference",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					NotificationRepo: mock_usecase.NewMockNotificationRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.NotificationPreferenceRequest{UserId: 10, Locale: "id"},
+			},
+			mock: func(f fields, args input) {
+				f.NotificationRepo.EXPECT().UpsertNotificationPreference(gomock.Any(), nil, gomock.Any()).Return(errors.New("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := NotificationUseCase{
+				NotificationRepo: f.NotificationRepo,
+				Templates:        entities.DefaultNotificationTemplates,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.SavePreference(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func (u *CollectionUseCase) CreatePromiseToPay(ctx context.Context, request entities.PromiseToPayRequest) (*entities.PromiseToPay, error) {
+	var errMessage []string
+
+	if request.LoanReferenceId == "" {
+		errMessage = append(errMessage, "loan reference id can not be empty")
+	}
+	if request.Amount < 1 {
+		errMessage = append(errMessage, "amount is invalid")
+	}
+	if strings.TrimSpace(request.Agent) == "" {
+		errMessage = append(errMessage, "agent can not be empty")
+	}
+	today, err := todayOf(ctx, u.Tenants, u.Clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	promisedDate, err := helper.ParseDate(request.PromisedDate, today.Location())
+	if err != nil {
+		errMessage = append(errMessage, "promised date must be formatted as "+helper.DateLayout)
+	} else if promisedDate.Before(today) {
+		errMessage = append(errMessage, "promised date can not be in the past")
+	} else if maxDays := u.PromisePolicy.MaxDays; maxDays > 0 && promisedDate.After(today.AddDate(0, 0, maxDays)) {
+		errMessage = append(errMessage, "promised date can not be more than "+strconv.Itoa(maxDays)+" days away")
+	}
+	if errMessage != nil || len(errMessage) != 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	loan, err := u.CollectionRepo.SelectLoanByReferenceId(ctx, request.LoanReferenceId)
+	if err != nil {
+		return nil, err
+	}
+	if !loan.Status.IsActive() {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loan status has been "+loan.Status.String())
+	}
+
+	_, err = u.CollectionRepo.SelectOpenPromiseToPayByLoanId(ctx, loan.Id)
+	if err == nil {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loan already has an open promise to pay")
+	}
+	if errs.GetHTTPCode(err) != http.StatusNotFound {
+		return nil, err
+	}
+	err = u.checkBrokenPromises(ctx, loan.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	promise := entities.PromiseToPay{
+		LoanId:          loan.Id,
+		LoanReferenceId: loan.ReferenceId,
+		Amount:          request.Amount,
+		PromisedDate:    promisedDate,
+		Agent:           strings.TrimSpace(request.Agent),
+		Status:          entities.PromiseOpen,
+	}
+	promise.Id, err = u.CollectionRepo.CreatePromiseToPay(ctx, nil, promise)
+	if err != nil {
+		return nil, err
+	}
+
+	return &promise, nil
+}
+
+// checkBrokenPromises refuses a new promise on a loan whose latest promises
+// were all broken, so promising again can not keep putting the loan off
+func (u *CollectionUseCase) checkBrokenPromises(ctx context.Context, loanId int64) error {
+	maxBroken := u.PromisePolicy.MaxBrokenInARow
+	if maxBroken < 1 {
+		return nil
+	}
+
+	promises, err := u.CollectionRepo.SelectPromiseToPayByLoanId(ctx, loanId)
+	if err != nil {
+		return err
+	}
+
+	// the promises come newest first
+	broken := 0
+	for _, promise := range *promises {
+		if promise.Status != entities.PromiseBroken {
+			break
+		}
+		broken++
+	}
+	if broken >= maxBroken {
+		return errs.NewWithMessage(http.StatusBadRequest, "loan has broken its last "+strconv.Itoa(broken)+" promises to pay")
+	}
+
+	return nil
+}
+
+func (u *CollectionUseCase) GetPromiseToPayListByLoanReferenceId(ctx context.Context, referenceId string) (*[]entities.PromiseToPay, error) {
+	if referenceId == "" {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loan reference id can not be empty")
+	}
+
+	loan, err := u.CollectionRepo.SelectLoanByReferenceId(ctx, referenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.CollectionRepo.SelectPromiseToPayByLoanId(ctx, loan.Id)
+}
+
+// EvaluatePromisesToPay matches the open promises against the repayments made
+// since they were given. A promise is kept once the repayments made by the end
+// of the promised date add up to its amount, and broken when the promised date
+// ends before they do.
+func (u *CollectionUseCase) EvaluatePromisesToPay(ctx context.Context, businessDate time.Time) (*entities.PromiseToPayRunResult, error) {
+	businessDate = helper.TruncateToDay(businessDate)
+	result := &entities.PromiseToPayRunResult{BusinessDate: businessDate}
+
+	promises, err := u.CollectionRepo.SelectOpenPromiseToPay(ctx)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	endOfDay := businessDate.AddDate(0, 0, 1)
+	for _, promise := range *promises {
+		result.Evaluated++
+
+		// the promised date is a calendar date of the tenant
+		promisedDate := helper.DateIn(promise.PromisedDate, businessDate.Location())
+		until := promisedDate.AddDate(0, 0, 1)
+		if endOfDay.Before(until) {
+			until = endOfDay
+		}
+		promise.PaidAmount, err = u.CollectionRepo.SelectRepaymentAmountByLoanIdBetween(ctx, promise.LoanId, promise.CreatedAt, until)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case promise.PaidAmount >= promise.Amount:
+			promise.Status = entities.PromiseKept
+			result.Kept++
+		case !businessDate.Before(promisedDate):
+			promise.Status = entities.PromiseBroken
+			result.Broken++
+		default:
+			continue
+		}
+
+		promise.ResolvedAt = u.Clock.Now()
+		err = u.CollectionRepo.UpdatePromiseToPay(ctx, nil, promise)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,369 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+func TestCollectionUseCase_CreatePromiseToPay(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.PromiseToPayRequest
+	}
+	type fields struct {
+		CollectionRepo *mock_usecase.MockCollectionRepository
+		Clock          *mock_domain.MockClock
+	}
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.Local)
+	sampleRequest := entities.PromiseToPayRequest{
+		LoanReferenceId: "loan1",
+		Amount:          500,
+		PromisedDate:    "2024-05-10",
+		Agent:           "agent1",
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.PromiseToPay
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: sampleRequest,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.CollectionRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&entities.Loan{
+					Id:          1,
+					ReferenceId: "loan1",
+					Status:      entities.LoanStatusActive,
+				}, nil)
+				f.CollectionRepo.EXPECT().SelectOpenPromiseToPayByLoanId(gomock.Any(), int64(1)).Return(nil, errs.Wrap(http.StatusNotFound, errors.New("not found")))
+				f.CollectionRepo.EXPECT().SelectPromiseToPayByLoanId(gomock.Any(), int64(1)).Return(&[]entities.PromiseToPay{
+					{Id: 2, Status: entities.PromiseBroken},
+					{Id: 1, Status: entities.PromiseKept},
+				}, nil)
+				f.CollectionRepo.EXPECT().CreatePromiseToPay(gomock.Any(), nil, entities.PromiseToPay{
+					LoanId:          1,
+					LoanReferenceId: "loan1",
+					Amount:          500,
+					PromisedDate:    time.Date(2024, 5, 10, 0, 0, 0, 0, entities.DefaultTenant.Location()),
+					Agent:           "agent1",
+					Status:          entities.PromiseOpen,
+				}).Return(int64(3), nil)
+			},
+			want: &entities.PromiseToPay{
+				Id:              3,
+				LoanId:          1,
+				LoanReferenceId: "loan1",
+				Amount:          500,
+				PromisedDate:    time.Date(2024, 5, 10, 0, 0, 0, 0, entities.DefaultTenant.Location()),
+				Agent:           "agent1",
+				Status:          entities.PromiseOpen,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error promised date in the past",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.PromiseToPayRequest{
+					LoanReferenceId: "loan1",
+					Amount:          500,
+					PromisedDate:    "2024-04-30",
+					Agent:           "agent1",
+				},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error promised date too far away",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.PromiseToPayRequest{
+					LoanReferenceId: "loan1",
+					Amount:          500,
+					PromisedDate:    "2024-05-16",
+					Agent:           "agent1",
+				},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error invalid request",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.PromiseToPayRequest{LoanReferenceId: "loan1", PromisedDate: "10/05/2024"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error loan already has an open promise",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: sampleRequest,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.CollectionRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&entities.Loan{
+					Id:     1,
+					Status: entities.LoanStatusActive,
+				}, nil)
+				f.CollectionRepo.EXPECT().SelectOpenPromiseToPayByLoanId(gomock.Any(), int64(1)).Return(&entities.PromiseToPay{Id: 2}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error loan broke its last promises",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: sampleRequest,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.CollectionRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&entities.Loan{
+					Id:     1,
+					Status: entities.LoanStatusActive,
+				}, nil)
+				f.CollectionRepo.EXPECT().SelectOpenPromiseToPayByLoanId(gomock.Any(), int64(1)).Return(nil, errs.Wrap(http.StatusNotFound, errors.New("not found")))
+				f.CollectionRepo.EXPECT().SelectPromiseToPayByLoanId(gomock.Any(), int64(1)).Return(&[]entities.PromiseToPay{
+					{Id: 3, Status: entities.PromiseBroken},
+					{Id: 2, Status: entities.PromiseBroken},
+					{Id: 1, Status: entities.PromiseKept},
+				}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error loan not active",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: sampleRequest,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.CollectionRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&entities.Loan{
+					Id:     1,
+					Status: entities.LoanStatusCompleted,
+				}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := CollectionUseCase{
+				CollectionRepo: f.CollectionRepo,
+				Clock:          f.Clock,
+				PromisePolicy:  entities.PromisePolicy{MaxDays: 14, MaxBrokenInARow: 2},
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.CreatePromiseToPay(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestCollectionUseCase_EvaluatePromisesToPay(t *testing.T) {
+	type input struct {
+		ctx          context.Context
+		businessDate time.Time
+	}
+	type fields struct {
+		CollectionRepo *mock_usecase.MockCollectionRepository
+		Clock          *mock_domain.MockClock
+	}
+	businessDate := time.Date(2024, 5, 10, 0, 0, 0, 0, time.Local)
+	now := time.Date(2024, 5, 11, 0, 5, 0, 0, time.Local)
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.PromiseToPayRunResult
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:          context.Background(),
+				businessDate: businessDate.Add(3 * time.Hour),
+			},
+			mock: func(f fields, args input) {
+				f.CollectionRepo.EXPECT().SelectOpenPromiseToPay(gomock.Any()).Return(&[]entities.PromiseToPay{
+					{Id: 1, LoanId: 1, Amount: 500, PromisedDate: time.Date(2024, 5, 8, 0, 0, 0, 0, time.Local), Status: entities.PromiseOpen, CreatedAt: createdAt},
+					{Id: 2, LoanId: 2, Amount: 500, PromisedDate: businessDate, Status: entities.PromiseOpen, CreatedAt: createdAt},
+					{Id: 3, LoanId: 3, Amount: 500, PromisedDate: time.Date(2024, 5, 20, 0, 0, 0, 0, time.Local), Status: entities.PromiseOpen, CreatedAt: createdAt},
+				}, nil)
+				f.CollectionRepo.EXPECT().SelectRepaymentAmountByLoanIdBetween(gomock.Any(), int64(1), createdAt, time.Date(2024, 5, 9, 0, 0, 0, 0, time.Local)).Return(int64(600), nil)
+				f.CollectionRepo.EXPECT().SelectRepaymentAmountByLoanIdBetween(gomock.Any(), int64(2), createdAt, time.Date(2024, 5, 11, 0, 0, 0, 0, time.Local)).Return(int64(200), nil)
+				f.CollectionRepo.EXPECT().SelectRepaymentAmountByLoanIdBetween(gomock.Any(), int64(3), createdAt, time.Date(2024, 5, 11, 0, 0, 0, 0, time.Local)).Return(int64(0), nil)
+				f.Clock.EXPECT().Now().Return(now).Times(2)
+				f.CollectionRepo.EXPECT().UpdatePromiseToPay(gomock.Any(), nil, entities.PromiseToPay{
+					Id: 1, LoanId: 1, Amount: 500, PromisedDate: time.Date(2024, 5, 8, 0, 0, 0, 0, time.Local),
+					Status: entities.PromiseKept, PaidAmount: 600, ResolvedAt: now, CreatedAt: createdAt,
+				}).Return(nil)
+				f.CollectionRepo.EXPECT().UpdatePromiseToPay(gomock.Any(), nil, entities.PromiseToPay{
+					Id: 2, LoanId: 2, Amount: 500, PromisedDate: businessDate,
+					Status: entities.PromiseBroken, PaidAmount: 200, ResolvedAt: now, CreatedAt: createdAt,
+				}).Return(nil)
+			},
+			want: &entities.PromiseToPayRunResult{
+				BusinessDate: businessDate,
+				Evaluated:    3,
+				Kept:         1,
+				Broken:       1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success no open promise",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:          context.Background(),
+				businessDate: businessDate,
+			},
+			mock: func(f fields, args input) {
+				f.CollectionRepo.EXPECT().SelectOpenPromiseToPay(gomock.Any()).Return(&[]entities.PromiseToPay{}, nil)
+			},
+			want: &entities.PromiseToPayRunResult{
+				BusinessDate: businessDate,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error repayment amount",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					CollectionRepo: mock_usecase.NewMockCollectionRepository(ctrl),
+					Clock:          mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:          context.Background(),
+				businessDate: businessDate,
+			},
+			mock: func(f fields, args input) {
+				f.CollectionRepo.EXPECT().SelectOpenPromiseToPay(gomock.Any()).Return(&[]entities.PromiseToPay{
+					{Id: 1, LoanId: 1, Amount: 500, PromisedDate: businessDate, Status: entities.PromiseOpen, CreatedAt: createdAt},
+				}, nil)
+				f.CollectionRepo.EXPECT().SelectRepaymentAmountByLoanIdBetween(gomock.Any(), int64(1), gomock.Any(), gomock.Any()).Return(int64(0), errors.New("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := CollectionUseCase{
+				CollectionRepo: f.CollectionRepo,
+				Clock:          f.Clock,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.EvaluatePromisesToPay(tt.input.ctx, tt.input.businessDate)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
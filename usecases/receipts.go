@@ -0,0 +1,132 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+func (u *BillingUseCase) GetReceipt(ctx context.Context, repaymentReferenceId string) (*entities.Receipt, error) {
+	if repaymentReferenceId == "" {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "reference id can not be empty")
+	}
+
+	return u.DBRepo.SelectReceiptByRepaymentReferenceId(ctx, repaymentReferenceId)
+}
+
+// ReversePayment takes a repayment back. The repayment stops counting as paid,
+// a completed loan becomes active again and the receipt is cancelled with the
+// reason as its note. Receipt numbers are never reused.
+func (u *BillingUseCase) ReversePayment(ctx context.Context, request entities.PaymentReversalRequest) (*entities.Receipt, error) {
+	var errMessage []string
+
+	if request.RepaymentReferenceId == "" {
+		errMessage = append(errMessage, "reference id can not be empty")
+	}
+	if strings.TrimSpace(request.Reason) == "" {
+		errMessage = append(errMessage, "reason can not be empty")
+	}
+	if len(errMessage) != 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	repayment, err := u.DBRepo.SelectRepaymentByReferenceId(ctx, request.RepaymentReferenceId)
+	if err != nil {
+		return nil, err
+	}
+	if repayment.Status == entities.RepaymentReversed {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "payment has been reversed")
+	}
+
+	loan, err := u.DBRepo.SelectLoanById(ctx, repayment.LoanId)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := u.DBRepo.SelectReceiptByRepaymentReferenceId(ctx, request.RepaymentReferenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	dbTx, err := u.DBRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer dbTx.Rollback()
+
+	err = u.DBRepo.UpdateRepaymentStatusById(ctx, dbTx, repayment.Id, entities.RepaymentReversed)
+	if err != nil {
+		return nil, err
+	}
+
+	if loan.Status == entities.LoanStatusCompleted {
+		err = u.DBRepo.UpdateLoanStatusByReferenceId(ctx, dbTx, loan.ReferenceId, entities.LoanStatusActive)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	receipt.Status = entities.ReceiptCancelled
+	receipt.CancellationNote = "Payment reversed: " + strings.TrimSpace(request.Reason)
+	receipt.CancelledAt = u.Clock.Now()
+	err = u.DBRepo.CancelReceipt(ctx, dbTx, receipt.Id, receipt.CancellationNote, receipt.CancelledAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = dbTx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	u.publishEvent(ctx, loan.TenantId, entities.EventPaymentReversed, entities.PaymentEventData{
+		LoanId:               loan.Id,
+		LoanReferenceId:      loan.ReferenceId,
+		RepaymentId:          repayment.Id,
+		RepaymentReferenceId: repayment.ReferenceId,
+		Amount:               loan.Money(repayment.Amount),
+	})
+
+	return receipt, nil
+}
+
+// issueReceipt numbers and stores the receipt of a repayment inside the
+// repayment transaction. Every installment is paid in full, so the payment
+// covers the installment after the ones already paid.
+func (u *BillingUseCase) issueReceipt(ctx context.Context, dbTx interfaces.AtomicTransaction, loan entities.Loan,
+	repaymentId int64, repaymentReferenceId string, previouslyPaid int64) error {
+	installmentNumber := int(previouslyPaid/loan.RepaymentAmount) + 1
+	interest := loan.InterestPaid(installmentNumber) - loan.InterestPaid(installmentNumber-1)
+	// the engine does not charge fees yet
+	var fees int64
+
+	issuedAt := u.Clock.Now()
+	period := entities.ReceiptPeriod(issuedAt)
+	sequence, err := u.DBRepo.NextReceiptSequence(ctx, dbTx, loan.TenantId, period)
+	if err != nil {
+		return err
+	}
+
+	_, err = u.DBRepo.CreateReceipt(ctx, dbTx, entities.Receipt{
+		TenantId:             loan.TenantId,
+		ReceiptNumber:        entities.FormatReceiptNumber(period, sequence),
+		RepaymentId:          repaymentId,
+		RepaymentReferenceId: repaymentReferenceId,
+		LoanId:               loan.Id,
+		LoanReferenceId:      loan.ReferenceId,
+		UserId:               loan.UserId,
+		InstallmentNumber:    installmentNumber,
+		Amount:               loan.RepaymentAmount,
+		Currency:             loan.Currency,
+		Principal:            loan.RepaymentAmount - interest - fees,
+		Interest:             interest,
+		Fees:                 fees,
+		Status:               entities.ReceiptIssued,
+		IssuedAt:             issuedAt,
+	})
+	return err
+}
@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+func TestBillingUseCase_ReversePayment(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.PaymentReversalRequest
+	}
+	type fields struct {
+		DBRepo *mock_usecase.MockDBRepository
+		Clock  *mock_domain.MockClock
+		Tx     *mock_domain.MockAtomicTransaction
+	}
+	now := time.Date(2000, 3, 15, 8, 0, 0, 0, time.Local)
+	repayment := &entities.Repayment{Id: 2, LoanId: 1, ReferenceId: "repay2", Amount: 1000, Status: entities.RepaymentPosted}
+	receipt := func() *entities.Receipt {
+		return &entities.Receipt{Id: 5, ReceiptNumber: "RCP-200003-000005", RepaymentId: 2, RepaymentReferenceId: "repay2", Status: entities.ReceiptIssued}
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.Receipt
+		wantErr bool
+	}{
+		{
+			name: "success reopen completed loan",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DBRepo: mock_usecase.NewMockDBRepository(ctrl),
+					Clock:  mock_domain.NewMockClock(ctrl),
+					Tx:     mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.PaymentReversalRequest{RepaymentReferenceId: "repay2", Reason: "bounced transfer"},
+			},
+			mock: func(f fields, args input) {
+				f.DBRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "repay2").Return(repayment, nil)
+				f.DBRepo.EXPECT().SelectLoanById(gomock.Any(), int64(1)).Return(&entities.Loan{Id: 1, ReferenceId: "loan1", Status: entities.LoanStatusCompleted}, nil)
+				f.DBRepo.EXPECT().SelectReceiptByRepaymentReferenceId(gomock.Any(), "repay2").Return(receipt(), nil)
+				f.DBRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.DBRepo.EXPECT().UpdateRepaymentStatusById(gomock.Any(), f.Tx, int64(2), entities.RepaymentReversed).Return(nil)
+				f.DBRepo.EXPECT().UpdateLoanStatusByReferenceId(gomock.Any(), f.Tx, "loan1", entities.LoanStatusActive).Return(nil)
+				f.Clock.EXPECT().Now().Return(now)
+				f.DBRepo.EXPECT().CancelReceipt(gomock.Any(), f.Tx, int64(5), "Payment reversed: bounced transfer", now).Return(nil)
+				f.Tx.EXPECT().Commit().Return(nil)
+				f.Tx.EXPECT().Rollback().Return(nil)
+			},
+			want: &entities.Receipt{
+				Id:                   5,
+				ReceiptNumber:        "RCP-200003-000005",
+				RepaymentId:          2,
+				RepaymentReferenceId: "repay2",
+				Status:               entities.ReceiptCancelled,
+				CancellationNote:     "Payment reversed: bounced transfer",
+				CancelledAt:          now,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error parameter",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DBRepo: mock_usecase.NewMockDBRepository(ctrl),
+					Clock:  mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.PaymentReversalRequest{RepaymentReferenceId: "repay2", Reason: " "},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error already reversed",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DBRepo: mock_usecase.NewMockDBRepository(ctrl),
+					Clock:  mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.PaymentReversalRequest{RepaymentReferenceId: "repay2", Reason: "duplicate"},
+			},
+			mock: func(f fields, args input) {
+				f.DBRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "repay2").Return(&entities.Repayment{Id: 2, Status: entities.RepaymentReversed}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error receipt not found",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DBRepo: mock_usecase.NewMockDBRepository(ctrl),
+					Clock:  mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.PaymentReversalRequest{RepaymentReferenceId: "repay2", Reason: "duplicate"},
+			},
+			mock: func(f fields, args input) {
+				f.DBRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "repay2").Return(repayment, nil)
+				f.DBRepo.EXPECT().SelectLoanById(gomock.Any(), int64(1)).Return(&entities.Loan{Id: 1, ReferenceId: "loan1", Status: entities.LoanStatusActive}, nil)
+				f.DBRepo.EXPECT().SelectReceiptByRepaymentReferenceId(gomock.Any(), "repay2").Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error cancel receipt",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DBRepo: mock_usecase.NewMockDBRepository(ctrl),
+					Clock:  mock_domain.NewMockClock(ctrl),
+					Tx:     mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.PaymentReversalRequest{RepaymentReferenceId: "repay2", Reason: "duplicate"},
+			},
+			mock: func(f fields, args input) {
+				f.DBRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "repay2").Return(repayment, nil)
+				f.DBRepo.EXPECT().SelectLoanById(gomock.Any(), int64(1)).Return(&entities.Loan{Id: 1, ReferenceId: "loan1", Status: entities.LoanStatusActive}, nil)
+				f.DBRepo.EXPECT().SelectReceiptByRepaymentReferenceId(gomock.Any(), "repay2").Return(receipt(), nil)
+				f.DBRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.DBRepo.EXPECT().UpdateRepaymentStatusById(gomock.Any(), f.Tx, int64(2), entities.RepaymentReversed).Return(nil)
+				f.Clock.EXPECT().Now().Return(now)
+				f.DBRepo.EXPECT().CancelReceipt(gomock.Any(), f.Tx, int64(5), "Payment reversed: duplicate", now).Return(errors.New("some error"))
+				f.Tx.EXPECT().Rollback().Return(nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := BillingUseCase{
+				DBRepo: f.DBRepo,
+				Clock:  f.Clock,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.ReversePayment(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
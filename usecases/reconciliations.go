@@ -0,0 +1,455 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+// description is optional, it usually holds the reference the payer typed
+var bankStatementColumns = []string{"transaction_id", "value_date", "amount"}
+
+// referenceSearchDays is how far from the value date a repayment whose
+// reference the bank reported is still found, and reported as mismatched
+// when it is beyond the date tolerance
+const referenceSearchDays = 31
+
+// ImportBankStatement stores the lines of a bank statement and matches the
+// new ones to repayments. A transaction the bank already reported in an
+// earlier statement is counted as a duplicate and left as it is.
+func (u *ReconciliationUseCase) ImportBankStatement(ctx context.Context, request entities.BankStatementImportRequest) (*entities.BankStatementImportResult, error) {
+	var errMessage []string
+
+	bankCode := strings.ToUpper(strings.TrimSpace(request.BankCode))
+	if bankCode == "" {
+		errMessage = append(errMessage, "bank code can not be empty")
+	}
+	if request.File == nil {
+		errMessage = append(errMessage, "bank statement file is required")
+	}
+	if errMessage != nil || len(errMessage) != 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	records, err := readCSV(request.File, bankStatementColumns)
+	if err != nil {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "bank statement file: "+err.Error())
+	}
+
+	lines := make([]entities.BankStatementLine, 0, len(records))
+	for _, record := range records {
+		line, lineErrMessage := parseBankStatementLine(record, bankCode)
+		errMessage = append(errMessage, lineErrMessage...)
+		lines = append(lines, line)
+	}
+	if len(errMessage) > 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	result := &entities.BankStatementImportResult{Total: len(lines)}
+	newLines := make([]entities.BankStatementLine, 0, len(lines))
+	for _, line := range lines {
+		line.Id, err = u.ReconciliationRepo.CreateBankStatementLine(ctx, nil, line)
+		if err != nil {
+			return nil, err
+		}
+		if line.Id == 0 {
+			result.Duplicates++
+			continue
+		}
+		newLines = append(newLines, line)
+	}
+
+	result.Lines, err = u.matchLines(ctx, newLines)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range result.Lines {
+		switch line.Status {
+		case entities.ReconciliationMatched:
+			result.Matched++
+		case entities.ReconciliationMismatched:
+			result.Mismatched++
+		default:
+			result.Unmatched++
+		}
+	}
+
+	return result, nil
+}
+
+// RunReconciliation matches again the lines of the period that are not
+// matched yet, e.g. once the repayments of a late gateway callback are posted
+func (u *ReconciliationUseCase) RunReconciliation(ctx context.Context, request entities.ReconciliationRequest) (*entities.ReconciliationReport, error) {
+	fromDate, toDate, err := u.parseReconciliationRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := u.ReconciliationRepo.SelectBankStatementLineByValueDate(ctx, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]entities.BankStatementLine, 0, len(*lines))
+	for _, line := range *lines {
+		if line.Status != entities.ReconciliationMatched {
+			pending = append(pending, line)
+		}
+	}
+	_, err = u.matchLines(ctx, pending)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetReconciliationReport(ctx, request)
+}
+
+// GetReconciliationReport lists the statement lines of the period by status
+// and the repayments of the period no statement line was matched to
+func (u *ReconciliationUseCase) GetReconciliationReport(ctx context.Context, request entities.ReconciliationRequest) (*entities.ReconciliationReport, error) {
+	fromDate, toDate, err := u.parseReconciliationRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := u.ReconciliationRepo.SelectBankStatementLineByValueDate(ctx, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	repayments, err := u.ReconciliationRepo.SelectRepaymentByCreatedAt(ctx, fromDate, toDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	repaymentIds := make([]int64, len(*repayments))
+	for i, repayment := range *repayments {
+		repaymentIds[i] = repayment.Id
+	}
+	statuses, err := u.ReconciliationRepo.SelectRepaymentReconciliationStatus(ctx, repaymentIds)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entities.ReconciliationReport{
+		FromDate:               fromDate,
+		ToDate:                 toDate,
+		Matched:                []entities.BankStatementLine{},
+		Mismatched:             []entities.BankStatementLine{},
+		Unmatched:              []entities.BankStatementLine{},
+		UnreconciledRepayments: []entities.Repayment{},
+	}
+	for _, line := range *lines {
+		switch line.Status {
+		case entities.ReconciliationMatched:
+			report.Matched = append(report.Matched, line)
+			report.MatchedAmount += line.Amount
+		case entities.ReconciliationMismatched:
+			report.Mismatched = append(report.Mismatched, line)
+			report.MismatchedAmount += line.Amount
+		default:
+			report.Unmatched = append(report.Unmatched, line)
+			report.UnmatchedAmount += line.Amount
+		}
+	}
+	for _, repayment := range *repayments {
+		if statuses[repayment.Id] != entities.ReconciliationMatched {
+			report.UnreconciledRepayments = append(report.UnreconciledRepayments, repayment)
+			report.UnreconciledAmount += repayment.Amount
+		}
+	}
+
+	return report, nil
+}
+
+// MatchManually matches a statement line the automatic matching could not
+// match. A repayment held as the candidate of a mismatched line is taken from
+// that line, which goes back to unmatched.
+func (u *ReconciliationUseCase) MatchManually(ctx context.Context, request entities.ManualMatchRequest) (*entities.BankStatementLine, error) {
+	var errMessage []string
+
+	if request.LineId < 1 {
+		errMessage = append(errMessage, "line id is invalid")
+	}
+	if request.RepaymentReferenceId == "" {
+		errMessage = append(errMessage, "repayment reference id can not be empty")
+	}
+	if strings.TrimSpace(request.Note) == "" {
+		errMessage = append(errMessage, "note can not be empty")
+	}
+	if errMessage != nil || len(errMessage) != 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	line, err := u.ReconciliationRepo.SelectBankStatementLineById(ctx, request.LineId)
+	if err != nil {
+		return nil, err
+	}
+	if line.Status == entities.ReconciliationMatched {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "bank statement line is already matched")
+	}
+
+	repayment, err := u.ReconciliationRepo.SelectRepaymentByReferenceId(ctx, request.RepaymentReferenceId)
+	if err != nil {
+		return nil, err
+	}
+	if repayment.Status == entities.RepaymentReversed {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "repayment has been reversed")
+	}
+
+	holder, err := u.ReconciliationRepo.SelectBankStatementLineByRepaymentId(ctx, repayment.Id)
+	if err != nil && errs.GetHTTPCode(err) != http.StatusNotFound {
+		return nil, err
+	}
+	if err == nil && holder.Id != line.Id && holder.Status == entities.ReconciliationMatched {
+		return nil, errs.NewWithMessage(http.StatusBadRequest,
+			"repayment is already matched to bank statement line "+strconv.FormatInt(holder.Id, 10))
+	}
+
+	dbTx, err := u.ReconciliationRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer dbTx.Rollback()
+
+	if holder != nil && holder.Id != line.Id {
+		holder.Status = entities.ReconciliationUnmatched
+		holder.RepaymentId = 0
+		holder.MatchType = ""
+		holder.Reason = "candidate repayment was matched to bank statement line " + strconv.FormatInt(line.Id, 10)
+		err = u.ReconciliationRepo.UpdateBankStatementLineMatch(ctx, dbTx, *holder)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	line.Status = entities.ReconciliationMatched
+	line.RepaymentId = repayment.Id
+	line.MatchType = entities.MatchManual
+	line.Reason = ""
+	line.Note = request.Note
+	err = u.ReconciliationRepo.UpdateBankStatementLineMatch(ctx, dbTx, *line)
+	if err != nil {
+		return nil, err
+	}
+
+	err = dbTx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return line, nil
+}
+
+// matchLines matches every line to the repayments made around its value date
+// that no other line holds yet, and saves the outcome
+func (u *ReconciliationUseCase) matchLines(ctx context.Context, lines []entities.BankStatementLine) ([]entities.BankStatementLine, error) {
+	if len(lines) == 0 {
+		return lines, nil
+	}
+
+	fromDate, toDate := lines[0].ValueDate, lines[0].ValueDate
+	for _, line := range lines {
+		if line.ValueDate.Before(fromDate) {
+			fromDate = line.ValueDate
+		}
+		if line.ValueDate.After(toDate) {
+			toDate = line.ValueDate
+		}
+	}
+
+	searchDays := referenceSearchDays
+	if u.Tolerance.Days > searchDays {
+		searchDays = u.Tolerance.Days
+	}
+	repayments, err := u.ReconciliationRepo.SelectRepaymentByCreatedAt(ctx,
+		fromDate.AddDate(0, 0, -searchDays), toDate.AddDate(0, 0, searchDays+1))
+	if err != nil {
+		return nil, err
+	}
+	repaymentIds := make([]int64, len(*repayments))
+	for i, repayment := range *repayments {
+		repaymentIds[i] = repayment.Id
+	}
+	statuses, err := u.ReconciliationRepo.SelectRepaymentReconciliationStatus(ctx, repaymentIds)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[int64]bool, len(statuses))
+	for repaymentId := range statuses {
+		taken[repaymentId] = true
+	}
+
+	for i := range lines {
+		// a mismatched line gives its candidate back before it is matched again
+		if lines[i].RepaymentId != 0 {
+			delete(taken, lines[i].RepaymentId)
+		}
+
+		u.matchLine(&lines[i], *repayments, taken)
+		if lines[i].RepaymentId != 0 {
+			taken[lines[i].RepaymentId] = true
+		}
+
+		err = u.ReconciliationRepo.UpdateBankStatementLineMatch(ctx, nil, lines[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return lines, nil
+}
+
+// matchLine prefers a repayment whose reference the bank reported. Such a
+// repayment outside the tolerances makes the line mismatched. Without a
+// reference the line is only matched when exactly one repayment fits the
+// amount and date.
+func (u *ReconciliationUseCase) matchLine(line *entities.BankStatementLine, repayments []entities.Repayment, taken map[int64]bool) {
+	var byReference, byAmount []entities.Repayment
+	for _, repayment := range repayments {
+		if taken[repayment.Id] {
+			continue
+		}
+		if referencesMatch(*line, repayment) {
+			byReference = append(byReference, repayment)
+			continue
+		}
+		if u.amountDifference(*line, repayment) == 0 && u.dayDifference(*line, repayment) == 0 {
+			byAmount = append(byAmount, repayment)
+		}
+	}
+
+	line.Status = entities.ReconciliationUnmatched
+	line.RepaymentId = 0
+	line.MatchType = ""
+	line.Reason = ""
+
+	switch {
+	case len(byReference) > 0:
+		repayment := byReference[0]
+		line.RepaymentId = repayment.Id
+		line.MatchType = entities.MatchAuto
+		line.Status = entities.ReconciliationMatched
+		if difference := u.amountDifference(*line, repayment); difference != 0 {
+			line.Status = entities.ReconciliationMismatched
+			line.Reason = "amount differs from repayment " + repayment.ReferenceId + " by " + strconv.FormatInt(difference, 10)
+		} else if days := u.dayDifference(*line, repayment); days != 0 {
+			line.Status = entities.ReconciliationMismatched
+			line.Reason = fmt.Sprintf("value date is %d days from repayment %s", days, repayment.ReferenceId)
+		}
+	case len(byAmount) == 1:
+		line.RepaymentId = byAmount[0].Id
+		line.MatchType = entities.MatchAuto
+		line.Status = entities.ReconciliationMatched
+	case len(byAmount) > 1:
+		line.Reason = strconv.Itoa(len(byAmount)) + " repayments match the amount and date"
+	default:
+		line.Reason = "no repayment matches the reference, amount and date"
+	}
+}
+
+// amountDifference is how much the line is off the repayment beyond the
+// tolerance, 0 when it is within
+func (u *ReconciliationUseCase) amountDifference(line entities.BankStatementLine, repayment entities.Repayment) int64 {
+	difference := line.Amount - repayment.Amount
+	if difference >= -u.Tolerance.Amount && difference <= u.Tolerance.Amount {
+		return 0
+	}
+	return difference
+}
+
+// dayDifference is how many days the value date is off the payment day
+// beyond the tolerance, 0 when it is within
+func (u *ReconciliationUseCase) dayDifference(line entities.BankStatementLine, repayment entities.Repayment) int {
+	days := helper.DaysBetween(repayment.CreatedAt.In(line.ValueDate.Location()), line.ValueDate)
+	if days >= -u.Tolerance.Days && days <= u.Tolerance.Days {
+		return 0
+	}
+	return days
+}
+
+// referencesMatch tells whether the bank reported the repayment reference,
+// either as its transaction id, the way gateway and settlement references are
+// built, or in the description the payer typed
+func referencesMatch(line entities.BankStatementLine, repayment entities.Repayment) bool {
+	reference := strings.ToUpper(repayment.ReferenceId)
+	transactionId := strings.ToUpper(line.TransactionId)
+	if reference == transactionId || strings.HasSuffix(reference, "-"+transactionId) {
+		return true
+	}
+	return line.Description != "" && strings.Contains(strings.ToUpper(line.Description), reference)
+}
+
+func parseBankStatementLine(record csvRecord, bankCode string) (entities.BankStatementLine, []string) {
+	var errMessage []string
+	prefix := fmt.Sprintf("line %d: ", record.line)
+
+	line := entities.BankStatementLine{
+		BankCode:      bankCode,
+		TransactionId: record.values["transaction_id"],
+		Description:   record.values["description"],
+		Status:        entities.ReconciliationUnmatched,
+	}
+	if line.TransactionId == "" {
+		errMessage = append(errMessage, prefix+"transaction id can not be empty")
+	}
+
+	var err error
+	line.ValueDate, err = helper.ParseDate(record.values["value_date"], time.Local)
+	if err != nil {
+		errMessage = append(errMessage, prefix+"value date must be formatted as "+helper.DateLayout)
+	}
+	line.Amount, err = strconv.ParseInt(record.values["amount"], 10, 64)
+	if err != nil {
+		errMessage = append(errMessage, prefix+"amount is not a number")
+	} else if line.Amount < 1 {
+		errMessage = append(errMessage, prefix+"amount is invalid")
+	}
+
+	return line, errMessage
+}
+
+// parseReconciliationRequest defaults the period to the last 30 completed days
+func (u *ReconciliationUseCase) parseReconciliationRequest(request entities.ReconciliationRequest) (time.Time, time.Time, error) {
+	var (
+		errMessage []string
+		err        error
+	)
+
+	toDate := helper.TruncateToDay(u.Clock.Now()).AddDate(0, 0, -1)
+	if request.ToDate != "" {
+		toDate, err = helper.ParseDate(request.ToDate, time.Local)
+		if err != nil {
+			errMessage = append(errMessage, "to date must be formatted as "+helper.DateLayout)
+		}
+	}
+
+	fromDate := toDate.AddDate(0, 0, 1-defaultReportDays)
+	if request.FromDate != "" {
+		fromDate, err = helper.ParseDate(request.FromDate, time.Local)
+		if err != nil {
+			errMessage = append(errMessage, "from date must be formatted as "+helper.DateLayout)
+		}
+	}
+
+	if len(errMessage) == 0 {
+		if toDate.Before(fromDate) {
+			errMessage = append(errMessage, "to date can not be before from date")
+		} else if helper.DaysBetween(fromDate, toDate) >= maxReportDays {
+			errMessage = append(errMessage, "date range can not be longer than a year")
+		}
+	}
+
+	if len(errMessage) > 0 {
+		return time.Time{}, time.Time{}, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	return fromDate, toDate, nil
+}
@@ -0,0 +1,492 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+func TestReconciliationUseCase_ImportBankStatement(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.BankStatementImportRequest
+	}
+	type fields struct {
+		ReconciliationRepo *mock_usecase.MockReconciliationRepository
+	}
+	jan2 := time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)
+	jan3 := time.Date(2024, 1, 3, 0, 0, 0, 0, time.Local)
+	repayments := &[]entities.Repayment{
+		{Id: 1, ReferenceId: "VA-BCA-trx1", Amount: 100, CreatedAt: jan2.Add(10 * time.Hour)},
+		{Id: 2, ReferenceId: "REPAY-9", Amount: 120, CreatedAt: jan3.Add(9 * time.Hour)},
+		{Id: 3, ReferenceId: "repay3", Amount: 300, CreatedAt: jan2.Add(11 * time.Hour)},
+		{Id: 4, ReferenceId: "repay4", Amount: 500, CreatedAt: jan2.Add(12 * time.Hour)},
+		{Id: 5, ReferenceId: "repay5", Amount: 500, CreatedAt: jan2.Add(13 * time.Hour)},
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.BankStatementImportResult
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationRepo: mock_usecase.NewMockReconciliationRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.BankStatementImportRequest{
+					BankCode: "bca",
+					File: strings.NewReader("transaction_id,value_date,amount,description\n" +
+						"trx1,2024-01-02,100,\n" +
+						"trx2,2024-01-03,100,payment for repay-9\n" +
+						"trx3,2024-01-03,300,\n" +
+						"trx4,2024-01-02,500,\n" +
+						"trx5,2024-01-02,100,\n"),
+				},
+			},
+			mock: func(f fields, args input) {
+				f.ReconciliationRepo.EXPECT().CreateBankStatementLine(gomock.Any(), nil, entities.BankStatementLine{
+					BankCode:      "BCA",
+					TransactionId: "trx1",
+					ValueDate:     jan2,
+					Amount:        100,
+					Status:        entities.ReconciliationUnmatched,
+				}).Return(int64(11), nil)
+				f.ReconciliationRepo.EXPECT().CreateBankStatementLine(gomock.Any(), nil, gomock.Any()).Return(int64(12), nil)
+				f.ReconciliationRepo.EXPECT().CreateBankStatementLine(gomock.Any(), nil, gomock.Any()).Return(int64(13), nil)
+				f.ReconciliationRepo.EXPECT().CreateBankStatementLine(gomock.Any(), nil, gomock.Any()).Return(int64(14), nil)
+				f.ReconciliationRepo.EXPECT().CreateBankStatementLine(gomock.Any(), nil, gomock.Any()).Return(int64(0), nil)
+				f.ReconciliationRepo.EXPECT().SelectRepaymentByCreatedAt(gomock.Any(), jan2.AddDate(0, 0, -31), jan3.AddDate(0, 0, 32)).Return(repayments, nil)
+				f.ReconciliationRepo.EXPECT().SelectRepaymentReconciliationStatus(gomock.Any(), []int64{1, 2, 3, 4, 5}).Return(map[int64]entities.ReconciliationStatus{}, nil)
+				f.ReconciliationRepo.EXPECT().UpdateBankStatementLineMatch(gomock.Any(), nil, gomock.Any()).Return(nil).Times(4)
+			},
+			want: &entities.BankStatementImportResult{
+				Total:      5,
+				Duplicates: 1,
+				Matched:    2,
+				Mismatched: 1,
+				Unmatched:  1,
+				Lines: []entities.BankStatementLine{
+					{Id: 11, BankCode: "BCA", TransactionId: "trx1", ValueDate: jan2, Amount: 100,
+						Status: entities.ReconciliationMatched, RepaymentId: 1, MatchType: entities.MatchAuto},
+					{Id: 12, BankCode: "BCA", TransactionId: "trx2", ValueDate: jan3, Amount: 100, Description: "payment for repay-9",
+						Status: entities.ReconciliationMismatched, RepaymentId: 2, MatchType: entities.MatchAuto,
+						Reason: "amount differs from repayment REPAY-9 by -20"},
+					{Id: 13, BankCode: "BCA", TransactionId: "trx3", ValueDate: jan3, Amount: 300,
+						Status: entities.ReconciliationMatched, RepaymentId: 3, MatchType: entities.MatchAuto},
+					{Id: 14, BankCode: "BCA", TransactionId: "trx4", ValueDate: jan2, Amount: 500,
+						Status: entities.ReconciliationUnmatched, Reason: "2 repayments match the amount and date"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success repayment held by another line",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationRepo: mock_usecase.NewMockReconciliationRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.BankStatementImportRequest{
+					BankCode: "BCA",
+					File:     strings.NewReader("transaction_id,value_date,amount\ntrx1,2024-01-02,100\n"),
+				},
+			},
+			mock: func(f fields, args input) {
+				f.ReconciliationRepo.EXPECT().CreateBankStatementLine(gomock.Any(), nil, gomock.Any()).Return(int64(11), nil)
+				f.ReconciliationRepo.EXPECT().SelectRepaymentByCreatedAt(gomock.Any(), gomock.Any(), gomock.Any()).Return(repayments, nil)
+				f.ReconciliationRepo.EXPECT().SelectRepaymentReconciliationStatus(gomock.Any(), gomock.Any()).Return(map[int64]entities.ReconciliationStatus{
+					1: entities.ReconciliationMatched,
+				}, nil)
+				f.ReconciliationRepo.EXPECT().UpdateBankStatementLineMatch(gomock.Any(), nil, gomock.Any()).Return(nil)
+			},
+			want: &entities.BankStatementImportResult{
+				Total:     1,
+				Unmatched: 1,
+				Lines: []entities.BankStatementLine{
+					{Id: 11, BankCode: "BCA", TransactionId: "trx1", ValueDate: jan2, Amount: 100,
+						Status: entities.ReconciliationUnmatched, Reason: "no repayment matches the reference, amount and date"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error invalid lines",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationRepo: mock_usecase.NewMockReconciliationRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.BankStatementImportRequest{
+					BankCode: "BCA",
+					File:     strings.NewReader("transaction_id,value_date,amount\n,02/01/2024,abc\n"),
+				},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error missing columns",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationRepo: mock_usecase.NewMockReconciliationRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.BankStatementImportRequest{
+					BankCode: "BCA",
+					File:     strings.NewReader("transaction_id,amount\ntrx1,100\n"),
+				},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error create line",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationRepo: mock_usecase.NewMockReconciliationRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.BankStatementImportRequest{
+					BankCode: "BCA",
+					File:     strings.NewReader("transaction_id,value_date,amount\ntrx1,2024-01-02,100\n"),
+				},
+			},
+			mock: func(f fields, args input) {
+				f.ReconciliationRepo.EXPECT().CreateBankStatementLine(gomock.Any(), nil, gomock.Any()).Return(int64(0), errors.New("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := ReconciliationUseCase{
+				ReconciliationRepo: f.ReconciliationRepo,
+				Tolerance:          entities.DefaultMatchTolerance,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.ImportBankStatement(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestReconciliationUseCase_GetReconciliationReport(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.ReconciliationRequest
+	}
+	type fields struct {
+		ReconciliationRepo *mock_usecase.MockReconciliationRepository
+		Clock              *mock_domain.MockClock
+	}
+	jan1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	jan31 := time.Date(2024, 1, 31, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.ReconciliationReport
+		wantErr bool
+	}{
+		{
+			name: "success default period",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationRepo: mock_usecase.NewMockReconciliationRepository(ctrl),
+					Clock:              mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.ReconciliationRequest{},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(time.Date(2024, 2, 1, 8, 0, 0, 0, time.Local))
+				f.ReconciliationRepo.EXPECT().SelectBankStatementLineByValueDate(gomock.Any(), jan1.AddDate(0, 0, 1), jan31).Return(&[]entities.BankStatementLine{
+					{Id: 11, Amount: 100, Status: entities.ReconciliationMatched, RepaymentId: 1},
+					{Id: 12, Amount: 200, Status: entities.ReconciliationMismatched, RepaymentId: 2},
+					{Id: 13, Amount: 300, Status: entities.ReconciliationUnmatched},
+				}, nil)
+				f.ReconciliationRepo.EXPECT().SelectRepaymentByCreatedAt(gomock.Any(), jan1.AddDate(0, 0, 1), jan31.AddDate(0, 0, 1)).Return(&[]entities.Repayment{
+					{Id: 1, Amount: 100},
+					{Id: 2, Amount: 250},
+				}, nil)
+				f.ReconciliationRepo.EXPECT().SelectRepaymentReconciliationStatus(gomock.Any(), []int64{1, 2}).Return(map[int64]entities.ReconciliationStatus{
+					1: entities.ReconciliationMatched,
+					2: entities.ReconciliationMismatched,
+				}, nil)
+			},
+			want: &entities.ReconciliationReport{
+				FromDate:           jan1.AddDate(0, 0, 1),
+				ToDate:             jan31,
+				MatchedAmount:      100,
+				MismatchedAmount:   200,
+				UnmatchedAmount:    300,
+				UnreconciledAmount: 250,
+				Matched:            []entities.BankStatementLine{{Id: 11, Amount: 100, Status: entities.ReconciliationMatched, RepaymentId: 1}},
+				Mismatched:         []entities.BankStatementLine{{Id: 12, Amount: 200, Status: entities.ReconciliationMismatched, RepaymentId: 2}},
+				Unmatched:          []entities.BankStatementLine{{Id: 13, Amount: 300, Status: entities.ReconciliationUnmatched}},
+				UnreconciledRepayments: []entities.Repayment{
+					{Id: 2, Amount: 250},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error invalid period",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationRepo: mock_usecase.NewMockReconciliationRepository(ctrl),
+					Clock:              mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.ReconciliationRequest{FromDate: "2024-02-01", ToDate: "2024-01-01"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(time.Date(2024, 2, 1, 8, 0, 0, 0, time.Local))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := ReconciliationUseCase{
+				ReconciliationRepo: f.ReconciliationRepo,
+				Clock:              f.Clock,
+				Tolerance:          entities.DefaultMatchTolerance,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.GetReconciliationReport(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestReconciliationUseCase_MatchManually(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.ManualMatchRequest
+	}
+	type fields struct {
+		ReconciliationRepo *mock_usecase.MockReconciliationRepository
+		Tx                 *mock_domain.MockAtomicTransaction
+	}
+	notFound := errs.Wrap(http.StatusNotFound, errors.New("not found"))
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.BankStatementLine
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationRepo: mock_usecase.NewMockReconciliationRepository(ctrl),
+					Tx:                 mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.ManualMatchRequest{LineId: 14, RepaymentReferenceId: "repay4", Note: "confirmed with the payer"},
+			},
+			mock: func(f fields, args input) {
+				f.ReconciliationRepo.EXPECT().SelectBankStatementLineById(gomock.Any(), int64(14)).Return(&entities.BankStatementLine{
+					Id: 14, Amount: 500, Status: entities.ReconciliationUnmatched, Reason: "2 repayments match the amount and date",
+				}, nil)
+				f.ReconciliationRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "repay4").Return(&entities.Repayment{
+					Id: 4, ReferenceId: "repay4", Amount: 500, Status: entities.RepaymentPosted,
+				}, nil)
+				f.ReconciliationRepo.EXPECT().SelectBankStatementLineByRepaymentId(gomock.Any(), int64(4)).Return(nil, notFound)
+				f.ReconciliationRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.ReconciliationRepo.EXPECT().UpdateBankStatementLineMatch(gomock.Any(), f.Tx, entities.BankStatementLine{
+					Id: 14, Amount: 500, Status: entities.ReconciliationMatched, RepaymentId: 4,
+					MatchType: entities.MatchManual, Note: "confirmed with the payer",
+				}).Return(nil)
+				f.Tx.EXPECT().Commit().Return(nil)
+				f.Tx.EXPECT().Rollback().Return(nil)
+			},
+			want: &entities.BankStatementLine{
+				Id: 14, Amount: 500, Status: entities.ReconciliationMatched, RepaymentId: 4,
+				MatchType: entities.MatchManual, Note: "confirmed with the payer",
+			},
+			wantErr: false,
+		},
+		{
+			name: "success taken from mismatched line",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationRepo: mock_usecase.NewMockReconciliationRepository(ctrl),
+					Tx:                 mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.ManualMatchRequest{LineId: 14, RepaymentReferenceId: "repay2", Note: "paid in two transfers"},
+			},
+			mock: func(f fields, args input) {
+				f.ReconciliationRepo.EXPECT().SelectBankStatementLineById(gomock.Any(), int64(14)).Return(&entities.BankStatementLine{
+					Id: 14, Amount: 120, Status: entities.ReconciliationUnmatched,
+				}, nil)
+				f.ReconciliationRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "repay2").Return(&entities.Repayment{
+					Id: 2, ReferenceId: "repay2", Amount: 120, Status: entities.RepaymentPosted,
+				}, nil)
+				f.ReconciliationRepo.EXPECT().SelectBankStatementLineByRepaymentId(gomock.Any(), int64(2)).Return(&entities.BankStatementLine{
+					Id: 12, Amount: 100, Status: entities.ReconciliationMismatched, RepaymentId: 2, MatchType: entities.MatchAuto,
+					Reason: "amount differs from repayment repay2 by -20",
+				}, nil)
+				f.ReconciliationRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.ReconciliationRepo.EXPECT().UpdateBankStatementLineMatch(gomock.Any(), f.Tx, entities.BankStatementLine{
+					Id: 12, Amount: 100, Status: entities.ReconciliationUnmatched,
+					Reason: "candidate repayment was matched to bank statement line 14",
+				}).Return(nil)
+				f.ReconciliationRepo.EXPECT().UpdateBankStatementLineMatch(gomock.Any(), f.Tx, gomock.Any()).Return(nil)
+				f.Tx.EXPECT().Commit().Return(nil)
+				f.Tx.EXPECT().Rollback().Return(nil)
+			},
+			want: &entities.BankStatementLine{
+				Id: 14, Amount: 120, Status: entities.ReconciliationMatched, RepaymentId: 2,
+				MatchType: entities.MatchManual, Note: "paid in two transfers",
+			},
+			wantErr: false,
+		},
+		{
+			name: "error repayment already matched",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationRepo: mock_usecase.NewMockReconciliationRepository(ctrl),
+					Tx:                 mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.ManualMatchRequest{LineId: 14, RepaymentReferenceId: "repay1", Note: "note"},
+			},
+			mock: func(f fields, args input) {
+				f.ReconciliationRepo.EXPECT().SelectBankStatementLineById(gomock.Any(), int64(14)).Return(&entities.BankStatementLine{
+					Id: 14, Status: entities.ReconciliationUnmatched,
+				}, nil)
+				f.ReconciliationRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "repay1").Return(&entities.Repayment{
+					Id: 1, Status: entities.RepaymentPosted,
+				}, nil)
+				f.ReconciliationRepo.EXPECT().SelectBankStatementLineByRepaymentId(gomock.Any(), int64(1)).Return(&entities.BankStatementLine{
+					Id: 11, Status: entities.ReconciliationMatched, RepaymentId: 1,
+				}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error line already matched",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationRepo: mock_usecase.NewMockReconciliationRepository(ctrl),
+					Tx:                 mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.ManualMatchRequest{LineId: 11, RepaymentReferenceId: "repay1", Note: "note"},
+			},
+			mock: func(f fields, args input) {
+				f.ReconciliationRepo.EXPECT().SelectBankStatementLineById(gomock.Any(), int64(11)).Return(&entities.BankStatementLine{
+					Id: 11, Status: entities.ReconciliationMatched, RepaymentId: 1,
+				}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error empty note",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReconciliationRepo: mock_usecase.NewMockReconciliationRepository(ctrl),
+					Tx:                 mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.ManualMatchRequest{LineId: 14, RepaymentReferenceId: "repay1"},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := ReconciliationUseCase{
+				ReconciliationRepo: f.ReconciliationRepo,
+				Tolerance:          entities.DefaultMatchTolerance,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.MatchManually(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const (
+	defaultReportDays = 30
+	maxReportDays     = 366
+)
+
+// GetPortfolioAtRisk reports the outstanding principal by aging bucket from
+// the snapshot of the to date, once per currency. PAR1, PAR30 and PAR90 are
+// the loans more than 0, 30 and 90 days past due.
+func (u *ReportUseCase) GetPortfolioAtRisk(ctx context.Context, request entities.ReportRequest) (*entities.PortfolioAtRiskReport, error) {
+	filter, err := u.parseReportRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets, err := u.ReportRepo.SelectAgingSummary(ctx, *filter)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+		buckets = &[]entities.AgingBucketSummary{}
+	}
+
+	report := &entities.PortfolioAtRiskReport{
+		AsOf:       filter.ToDate,
+		Portfolios: []entities.PortfolioAtRisk{},
+	}
+	// the buckets come ordered by currency
+	for _, bucket := range *buckets {
+		if len(report.Portfolios) == 0 || report.Portfolios[len(report.Portfolios)-1].Currency != bucket.Currency {
+			report.Portfolios = append(report.Portfolios, entities.PortfolioAtRisk{
+				Currency: bucket.Currency,
+				Aging:    []entities.AgingBucketSummary{},
+			})
+		}
+		portfolio := &report.Portfolios[len(report.Portfolios)-1]
+		portfolio.Aging = append(portfolio.Aging, bucket)
+		portfolio.Total.Loans += bucket.Loans
+		portfolio.Total.PrincipalOutstanding += bucket.PrincipalOutstanding
+
+		switch bucket.AgingBucket {
+		case entities.AgingCurrent:
+			continue
+		case entities.AgingOver90:
+			addMeasure(&portfolio.PAR90, bucket)
+			fallthrough
+		case entities.Aging61To90, entities.Aging31To60:
+			addMeasure(&portfolio.PAR30, bucket)
+		}
+		addMeasure(&portfolio.PAR1, bucket)
+	}
+
+	for i := range report.Portfolios {
+		portfolio := &report.Portfolios[i]
+		portfolio.Total.RatioPercent = 100
+		if portfolio.Total.PrincipalOutstanding == 0 {
+			portfolio.Total.RatioPercent = 0
+		}
+		for _, measure := range []*entities.PortfolioMeasure{&portfolio.PAR1, &portfolio.PAR30, &portfolio.PAR90} {
+			measure.RatioPercent = entities.Percent(measure.PrincipalOutstanding, portfolio.Total.PrincipalOutstanding)
+		}
+	}
+
+	return report, nil
+}
+
+func (u *ReportUseCase) GetOutstandingBySchedule(ctx context.Context, request entities.ReportRequest) (*entities.OutstandingByScheduleReport, error) {
+	filter, err := u.parseReportRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := u.ReportRepo.SelectOutstandingBySchedule(ctx, *filter)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+		schedules = &[]entities.ScheduleOutstanding{}
+	}
+
+	return &entities.OutstandingByScheduleReport{
+		AsOf:      filter.ToDate,
+		Schedules: *schedules,
+	}, nil
+}
+
+// GetDisbursement reports the loans created on every day of the period,
+// days without loans included, once per currency loans were booked in.
+func (u *ReportUseCase) GetDisbursement(ctx context.Context, request entities.ReportRequest) (*entities.DisbursementReport, error) {
+	filter, err := u.parseReportRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	loans, err := u.ReportRepo.SelectLoanByReportFilter(ctx, *filter, filter.FromDate, filter.ToDate.AddDate(0, 0, 1))
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+		loans = &[]entities.Loan{}
+	}
+
+	report := &entities.DisbursementReport{
+		FromDate:   filter.FromDate,
+		ToDate:     filter.ToDate,
+		Currencies: []entities.CurrencyDisbursement{},
+	}
+	dayIndex := map[string]int{}
+	var days []entities.DailyDisbursement
+	for date := filter.FromDate; !date.After(filter.ToDate); date = date.AddDate(0, 0, 1) {
+		dayIndex[date.Format(helper.DateLayout)] = len(days)
+		days = append(days, entities.DailyDisbursement{Date: date})
+	}
+	currencyIndex := map[string]int{}
+	for _, loan := range *loans {
+		i, ok := dayIndex[loan.CreatedAt.In(time.Local).Format(helper.DateLayout)]
+		if !ok {
+			continue
+		}
+		c, ok := currencyIndex[loan.Currency]
+		if !ok {
+			c = len(report.Currencies)
+			currencyIndex[loan.Currency] = c
+			report.Currencies = append(report.Currencies, entities.CurrencyDisbursement{
+				Currency: loan.Currency,
+				Days:     append([]entities.DailyDisbursement(nil), days...),
+			})
+		}
+		currency := &report.Currencies[c]
+		currency.Days[i].Loans++
+		currency.Days[i].Amount += loan.Amount
+		currency.TotalLoans++
+		currency.TotalAmount += loan.Amount
+	}
+
+	return report, nil
+}
+
+// GetCollectionRate compares the installments falling due within the period
+// with those of them paid by the end of the period, once per currency.
+// Installments are paid in order, so installment n is paid once the loan has
+// n repayments.
+func (u *ReportUseCase) GetCollectionRate(ctx context.Context, request entities.ReportRequest) (*entities.CollectionRateReport, error) {
+	filter, err := u.parseReportRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	endOfPeriod := filter.ToDate.AddDate(0, 0, 1)
+
+	report := &entities.CollectionRateReport{
+		FromDate:   filter.FromDate,
+		ToDate:     filter.ToDate,
+		Currencies: []entities.CurrencyCollectionRate{},
+	}
+
+	loans, err := u.ReportRepo.SelectLoanByReportFilter(ctx, *filter, time.Time{}, endOfPeriod)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+		return report, nil
+	}
+	if len(*loans) == 0 {
+		return report, nil
+	}
+
+	loanIds := make([]int64, len(*loans))
+	for i, loan := range *loans {
+		loanIds[i] = loan.Id
+	}
+	repaymentCounts, err := u.ReportRepo.SelectRepaymentCountByLoanIds(ctx, loanIds, endOfPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	currencyIndex := map[string]int{}
+	for _, loan := range *loans {
+		for installment := 1; installment <= loan.Tenor; installment++ {
+			dueDate := helper.TruncateToDay(loan.DueDate(installment))
+			if dueDate.Before(filter.FromDate) {
+				continue
+			}
+			if dueDate.After(filter.ToDate) {
+				break
+			}
+
+			c, ok := currencyIndex[loan.Currency]
+			if !ok {
+				c = len(report.Currencies)
+				currencyIndex[loan.Currency] = c
+				report.Currencies = append(report.Currencies, entities.CurrencyCollectionRate{Currency: loan.Currency})
+			}
+			currency := &report.Currencies[c]
+			currency.InstallmentsDue++
+			currency.AmountDue += loan.RepaymentAmount
+			if installment <= repaymentCounts[loan.Id] {
+				currency.InstallmentsCollected++
+				currency.AmountCollected += loan.RepaymentAmount
+			}
+		}
+	}
+	for i := range report.Currencies {
+		report.Currencies[i].CollectionRatePercent = entities.Percent(report.Currencies[i].AmountCollected, report.Currencies[i].AmountDue)
+	}
+
+	return report, nil
+}
+
+// parseReportRequest defaults the period to the last 30 completed days
+func (u *ReportUseCase) parseReportRequest(request entities.ReportRequest) (*entities.ReportFilter, error) {
+	var (
+		errMessage []string
+		filter     = &entities.ReportFilter{}
+		err        error
+	)
+
+	filter.ToDate = helper.TruncateToDay(u.Clock.Now()).AddDate(0, 0, -1)
+	if request.ToDate != "" {
+		filter.ToDate, err = helper.ParseDate(request.ToDate, time.Local)
+		if err != nil {
+			errMessage = append(errMessage, "to date must be formatted as "+helper.DateLayout)
+		}
+	}
+
+	filter.FromDate = filter.ToDate.AddDate(0, 0, 1-defaultReportDays)
+	if request.FromDate != "" {
+		filter.FromDate, err = helper.ParseDate(request.FromDate, time.Local)
+		if err != nil {
+			errMessage = append(errMessage, "from date must be formatted as "+helper.DateLayout)
+		}
+	}
+
+	if request.RepaymentSchedule != "" {
+		filter.RepaymentSchedule = entities.RepaymentScheduleType(strings.ToLower(request.RepaymentSchedule))
+		if !filter.RepaymentSchedule.IsValid() {
+			errMessage = append(errMessage, "repayment schedule is not valid")
+		}
+	}
+
+	if request.Status != "" {
+		var ok bool
+		filter.Status, ok = entities.ParseLoanStatus(request.Status)
+		if !ok {
+			errMessage = append(errMessage, "status is not valid")
+		}
+	}
+
+	if request.Restructured != "" {
+		restructured, err := strconv.ParseBool(request.Restructured)
+		if err != nil {
+			errMessage = append(errMessage, "restructured must be true or false")
+		} else {
+			filter.Restructured = &restructured
+		}
+	}
+
+	if len(errMessage) == 0 {
+		if filter.ToDate.Before(filter.FromDate) {
+			errMessage = append(errMessage, "to date can not be before from date")
+		} else if helper.DaysBetween(filter.FromDate, filter.ToDate) >= maxReportDays {
+			errMessage = append(errMessage, "date range can not be longer than a year")
+		}
+	}
+
+	if len(errMessage) > 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	return filter, nil
+}
+
+func addMeasure(measure *entities.PortfolioMeasure, bucket entities.AgingBucketSummary) {
+	measure.Loans += bucket.Loans
+	measure.PrincipalOutstanding += bucket.PrincipalOutstanding
+}
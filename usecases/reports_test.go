@@ -0,0 +1,326 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+func TestReportUseCase_GetPortfolioAtRisk(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.ReportRequest
+	}
+	type fields struct {
+		ReportRepo *mock_usecase.MockReportRepository
+		Clock      *mock_domain.MockClock
+	}
+	now := time.Date(2000, 3, 15, 8, 0, 0, 0, time.Local)
+	asOf := time.Date(2000, 3, 14, 0, 0, 0, 0, time.Local)
+	buckets := []entities.AgingBucketSummary{
+		{Currency: "IDR", AgingBucket: entities.AgingCurrent, Loans: 6, PrincipalOutstanding: 6000},
+		{Currency: "IDR", AgingBucket: entities.Aging1To30, Loans: 2, PrincipalOutstanding: 2000},
+		{Currency: "IDR", AgingBucket: entities.Aging31To60, Loans: 1, PrincipalOutstanding: 1000},
+		{Currency: "IDR", AgingBucket: entities.AgingOver90, Loans: 1, PrincipalOutstanding: 1000},
+		{Currency: "USD", AgingBucket: entities.AgingCurrent, Loans: 1, PrincipalOutstanding: 500},
+		{Currency: "USD", AgingBucket: entities.Aging1To30, Loans: 1, PrincipalOutstanding: 500},
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.PortfolioAtRiskReport
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReportRepo: mock_usecase.NewMockReportRepository(ctrl),
+					Clock:      mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.ReportRequest{RepaymentSchedule: "Monthly"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.ReportRepo.EXPECT().SelectAgingSummary(gomock.Any(), entities.ReportFilter{
+					FromDate:          time.Date(2000, 2, 14, 0, 0, 0, 0, time.Local),
+					ToDate:            asOf,
+					RepaymentSchedule: entities.RepaymentMonthly,
+				}).Return(&buckets, nil)
+			},
+			want: &entities.PortfolioAtRiskReport{
+				AsOf: asOf,
+				Portfolios: []entities.PortfolioAtRisk{
+					{
+						Currency: "IDR",
+						Total:    entities.PortfolioMeasure{Loans: 10, PrincipalOutstanding: 10000, RatioPercent: 100},
+						PAR1:     entities.PortfolioMeasure{Loans: 4, PrincipalOutstanding: 4000, RatioPercent: 40},
+						PAR30:    entities.PortfolioMeasure{Loans: 2, PrincipalOutstanding: 2000, RatioPercent: 20},
+						PAR90:    entities.PortfolioMeasure{Loans: 1, PrincipalOutstanding: 1000, RatioPercent: 10},
+						Aging:    buckets[:4],
+					},
+					{
+						Currency: "USD",
+						Total:    entities.PortfolioMeasure{Loans: 2, PrincipalOutstanding: 1000, RatioPercent: 100},
+						PAR1:     entities.PortfolioMeasure{Loans: 1, PrincipalOutstanding: 500, RatioPercent: 50},
+						Aging:    buckets[4:],
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success no snapshot",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReportRepo: mock_usecase.NewMockReportRepository(ctrl),
+					Clock:      mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.ReportRequest{ToDate: "2000-03-14"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.ReportRepo.EXPECT().SelectAgingSummary(gomock.Any(), gomock.Any()).Return(nil, errs.NewWithMessage(404, "not found"))
+			},
+			want: &entities.PortfolioAtRiskReport{
+				AsOf:       asOf,
+				Portfolios: []entities.PortfolioAtRisk{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error invalid filter",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReportRepo: mock_usecase.NewMockReportRepository(ctrl),
+					Clock:      mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.ReportRequest{RepaymentSchedule: "daily", Status: "unknown"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error to date before from date",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReportRepo: mock_usecase.NewMockReportRepository(ctrl),
+					Clock:      mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.ReportRequest{FromDate: "2000-03-10", ToDate: "2000-03-01"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error repository",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReportRepo: mock_usecase.NewMockReportRepository(ctrl),
+					Clock:      mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.ReportRepo.EXPECT().SelectAgingSummary(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := ReportUseCase{
+				ReportRepo: f.ReportRepo,
+				Clock:      f.Clock,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.GetPortfolioAtRisk(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestReportUseCase_GetCollectionRate(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.ReportRequest
+	}
+	type fields struct {
+		ReportRepo *mock_usecase.MockReportRepository
+		Clock      *mock_domain.MockClock
+	}
+	now := time.Date(2000, 4, 15, 8, 0, 0, 0, time.Local)
+	fromDate := time.Date(2000, 3, 1, 0, 0, 0, 0, time.Local)
+	toDate := time.Date(2000, 3, 31, 0, 0, 0, 0, time.Local)
+	endOfPeriod := time.Date(2000, 4, 1, 0, 0, 0, 0, time.Local)
+	loans := []entities.Loan{
+		{
+			Id:                1,
+			Amount:            1200,
+			Currency:          "IDR",
+			RatePercentage:    10,
+			Status:            entities.LoanStatusActive,
+			RepaymentSchedule: entities.RepaymentMonthly,
+			Tenor:             12,
+			RepaymentAmount:   110,
+			CreatedAt:         time.Date(2000, 1, 1, 10, 0, 0, 0, time.Local),
+		},
+		{
+			Id:                2,
+			Amount:            1000,
+			Currency:          "USD",
+			RatePercentage:    10,
+			Status:            entities.LoanStatusCompleted,
+			RepaymentSchedule: entities.RepaymentWeekly,
+			Tenor:             2,
+			RepaymentAmount:   550,
+			CreatedAt:         time.Date(2000, 3, 1, 10, 0, 0, 0, time.Local),
+		},
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.CollectionRateReport
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReportRepo: mock_usecase.NewMockReportRepository(ctrl),
+					Clock:      mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.ReportRequest{FromDate: "2000-03-01", ToDate: "2000-03-31"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.ReportRepo.EXPECT().SelectLoanByReportFilter(gomock.Any(), entities.ReportFilter{
+					FromDate: fromDate,
+					ToDate:   toDate,
+				}, time.Time{}, endOfPeriod).Return(&loans, nil)
+				// loan 1 has its second installment due on 2000-03-01 unpaid,
+				// loan 2 paid both weekly installments due in March
+				f.ReportRepo.EXPECT().SelectRepaymentCountByLoanIds(gomock.Any(), []int64{1, 2}, endOfPeriod).Return(map[int64]int{1: 1, 2: 2}, nil)
+			},
+			want: &entities.CollectionRateReport{
+				FromDate: fromDate,
+				ToDate:   toDate,
+				Currencies: []entities.CurrencyCollectionRate{
+					{Currency: "IDR", InstallmentsDue: 1, AmountDue: 110},
+					{Currency: "USD", InstallmentsDue: 2, InstallmentsCollected: 2, AmountDue: 1100, AmountCollected: 1100, CollectionRatePercent: 100},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success no loan",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReportRepo: mock_usecase.NewMockReportRepository(ctrl),
+					Clock:      mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.ReportRequest{FromDate: "2000-03-01", ToDate: "2000-03-31"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.ReportRepo.EXPECT().SelectLoanByReportFilter(gomock.Any(), gomock.Any(), time.Time{}, endOfPeriod).Return(&[]entities.Loan{}, nil)
+			},
+			want: &entities.CollectionRateReport{
+				FromDate:   fromDate,
+				ToDate:     toDate,
+				Currencies: []entities.CurrencyCollectionRate{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error repayment count",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ReportRepo: mock_usecase.NewMockReportRepository(ctrl),
+					Clock:      mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.ReportRequest{FromDate: "2000-03-01", ToDate: "2000-03-31"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.ReportRepo.EXPECT().SelectLoanByReportFilter(gomock.Any(), gomock.Any(), time.Time{}, endOfPeriod).Return(&loans, nil)
+				f.ReportRepo.EXPECT().SelectRepaymentCountByLoanIds(gomock.Any(), []int64{1, 2}, endOfPeriod).Return(nil, errors.New("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := ReportUseCase{
+				ReportRepo: f.ReportRepo,
+				Clock:      f.Clock,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.GetCollectionRate(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
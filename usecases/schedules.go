@@ -0,0 +1,261 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+// RestructureLoan closes the remaining installments of an active loan and
+// continues it under a new schedule version starting today. The reference id,
+// the repayments made so far and the earlier versions are kept.
+func (u *ScheduleUseCase) RestructureLoan(ctx context.Context, request entities.RestructureRequest) (*entities.LoanScheduleVersion, error) {
+	var errMessage []string
+
+	if request.LoanReferenceId == "" {
+		errMessage = append(errMessage, "loan reference id can not be empty")
+	}
+	if request.Tenor < 1 {
+		errMessage = append(errMessage, "tenor is required")
+	}
+	if request.RatePercentage < 0 {
+		errMessage = append(errMessage, "rate percentage is invalid")
+	}
+	if strings.TrimSpace(request.Reason) == "" {
+		errMessage = append(errMessage, "reason can not be empty")
+	}
+	if errMessage != nil || len(errMessage) != 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	loan, installmentsPaid, current, err := u.currentSchedule(ctx, request.LoanReferenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	totalRepayments, err := u.ScheduleRepo.SelectTotalRepaymentAmountByLoanId(ctx, loan.Id)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+	}
+
+	now := u.Clock.Now()
+	principal := current.RemainingPrincipal(installmentsPaid)
+	if request.CapitalizeArrears {
+		principal += current.InstallmentInterest() * int64(overdueInstallments(*loan, installmentsPaid, now))
+	}
+
+	next := entities.LoanScheduleVersion{
+		LoanId:             loan.Id,
+		Version:            current.Version + 1,
+		Type:               entities.ScheduleRestructure,
+		Principal:          principal,
+		RatePercentage:     request.RatePercentage,
+		Tenor:              request.Tenor,
+		RepaymentAmount:    installmentAmountOf(principal, request.RatePercentage, request.Tenor),
+		RepaymentSchedule:  loan.RepaymentSchedule,
+		InstallmentsBefore: installmentsPaid,
+		PaidBefore:         totalRepayments,
+		StartAt:            helper.TruncateToDay(now),
+		Reason:             request.Reason,
+	}
+	if next.RepaymentAmount < 1 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "tenor is too long for the remaining principal")
+	}
+
+	err = u.saveScheduleVersion(ctx, *loan, current, next, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &next, nil
+}
+
+// DeferLoan postpones the next unpaid installments of an active loan, and the
+// ones after them, by the requested number of periods. The interest of the
+// deferred periods is capitalized, spread over the remaining installments or
+// waived, as requested.
+func (u *ScheduleUseCase) DeferLoan(ctx context.Context, request entities.DeferralRequest) (*entities.LoanScheduleVersion, error) {
+	var errMessage []string
+
+	if request.LoanReferenceId == "" {
+		errMessage = append(errMessage, "loan reference id can not be empty")
+	}
+	if request.Installments < 1 {
+		errMessage = append(errMessage, "installments is required")
+	}
+	if !request.Interest.IsValid() {
+		errMessage = append(errMessage, "interest must be capitalized, spread or waived")
+	}
+	if strings.TrimSpace(request.Reason) == "" {
+		errMessage = append(errMessage, "reason can not be empty")
+	}
+	if errMessage != nil || len(errMessage) != 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	loan, installmentsPaid, current, err := u.currentSchedule(ctx, request.LoanReferenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	totalRepayments, err := u.ScheduleRepo.SelectTotalRepaymentAmountByLoanId(ctx, loan.Id)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+	}
+
+	remaining := loan.Tenor - installmentsPaid
+	principal := current.RemainingPrincipal(installmentsPaid)
+	deferredInterest := current.InstallmentInterest() * int64(request.Installments)
+
+	var spreadInterest int64
+	switch request.Interest {
+	case entities.DeferralInterestCapitalized:
+		principal += deferredInterest
+	case entities.DeferralInterestSpread:
+		spreadInterest = deferredInterest / int64(remaining)
+	case entities.DeferralInterestWaived:
+		deferredInterest = 0
+	}
+
+	// the first remaining installment falls due as many periods later as are deferred
+	startAt := entities.AddTime(loan.DueDate(installmentsPaid+1), request.Installments-1, loan.RepaymentSchedule)
+
+	next := entities.LoanScheduleVersion{
+		LoanId:               loan.Id,
+		Version:              current.Version + 1,
+		Type:                 entities.ScheduleDeferral,
+		Principal:            principal,
+		RatePercentage:       current.RatePercentage,
+		Tenor:                remaining,
+		RepaymentAmount:      installmentAmountOf(principal, current.RatePercentage, remaining) + spreadInterest,
+		RepaymentSchedule:    loan.RepaymentSchedule,
+		InstallmentsBefore:   installmentsPaid,
+		PaidBefore:           totalRepayments,
+		StartAt:              startAt,
+		DeferredInstallments: request.Installments,
+		DeferralInterest:     request.Interest,
+		DeferredInterest:     deferredInterest,
+		Reason:               request.Reason,
+	}
+
+	err = u.saveScheduleVersion(ctx, *loan, current, next, u.Clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &next, nil
+}
+
+func (u *ScheduleUseCase) GetScheduleVersionList(ctx context.Context, loanReferenceId string) (*[]entities.LoanScheduleVersion, error) {
+	if loanReferenceId == "" {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loan reference id can not be empty")
+	}
+
+	loan, err := u.ScheduleRepo.SelectLoanByReferenceId(ctx, loanReferenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := u.ScheduleRepo.SelectLoanScheduleVersionByLoanId(ctx, loan.Id)
+	if err != nil {
+		return nil, err
+	}
+	if len(*versions) == 0 {
+		return &[]entities.LoanScheduleVersion{entities.OriginalScheduleVersion(*loan)}, nil
+	}
+
+	return versions, nil
+}
+
+// currentSchedule loads an active loan with unpaid installments left and the
+// schedule version it is repaid under
+func (u *ScheduleUseCase) currentSchedule(ctx context.Context, loanReferenceId string) (*entities.Loan, int, entities.LoanScheduleVersion, error) {
+	var current entities.LoanScheduleVersion
+
+	loan, err := u.ScheduleRepo.SelectLoanByReferenceId(ctx, loanReferenceId)
+	if err != nil {
+		return nil, 0, current, err
+	}
+	if !loan.Status.IsActive() {
+		return nil, 0, current, errs.NewWithMessage(http.StatusBadRequest, "loan status has been "+loan.Status.String())
+	}
+
+	installmentsPaid, err := u.ScheduleRepo.SelectRepaymentCountByLoanId(ctx, loan.Id)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, 0, current, err
+		}
+	}
+	if installmentsPaid >= loan.Tenor {
+		return nil, 0, current, errs.NewWithMessage(http.StatusBadRequest, "loan has no installments left")
+	}
+
+	versions, err := u.ScheduleRepo.SelectLoanScheduleVersionByLoanId(ctx, loan.Id)
+	if err != nil {
+		return nil, 0, current, err
+	}
+	current = entities.OriginalScheduleVersion(*loan)
+	if len(*versions) != 0 {
+		current = (*versions)[len(*versions)-1]
+	}
+
+	return loan, installmentsPaid, current, nil
+}
+
+// saveScheduleVersion closes the current version, storing the original one
+// first when the loan is rescheduled for the first time, and moves the loan
+// onto the next version in one transaction
+func (u *ScheduleUseCase) saveScheduleVersion(ctx context.Context, loan entities.Loan, current, next entities.LoanScheduleVersion, closedAt time.Time) error {
+	dbTx, err := u.ScheduleRepo.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback()
+
+	if current.Id == 0 {
+		current.ClosedAt = closedAt
+		_, err = u.ScheduleRepo.CreateLoanScheduleVersion(ctx, dbTx, current)
+	} else {
+		err = u.ScheduleRepo.CloseLoanScheduleVersion(ctx, dbTx, current.Id, closedAt)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = u.ScheduleRepo.CreateLoanScheduleVersion(ctx, dbTx, next)
+	if err != nil {
+		return err
+	}
+
+	loan.RatePercentage = next.RatePercentage
+	loan.Tenor = next.InstallmentsBefore + next.Tenor
+	loan.RepaymentAmount = next.RepaymentAmount
+	loan.ScheduleVersion = next.Version
+	loan.ScheduleStartAt = next.StartAt
+	loan.InstallmentsOffset = next.InstallmentsBefore
+	loan.PaidOffset = next.PaidBefore
+	err = u.ScheduleRepo.UpdateLoanSchedule(ctx, dbTx, loan)
+	if err != nil {
+		return err
+	}
+
+	return dbTx.Commit()
+}
+
+// overdueInstallments counts the unpaid installments whose due date has passed
+func overdueInstallments(loan entities.Loan, installmentsPaid int, now time.Time) int {
+	var overdue int
+	for installment := installmentsPaid + 1; installment <= loan.Tenor && loan.DueDate(installment).Before(now); installment++ {
+		overdue++
+	}
+	return overdue
+}
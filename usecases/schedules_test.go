@@ -0,0 +1,698 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+func TestScheduleUseCase_RestructureLoan(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.RestructureRequest
+	}
+	type fields struct {
+		ScheduleRepo *mock_usecase.MockScheduleRepository
+		Clock        *mock_domain.MockClock
+		Tx           *mock_domain.MockAtomicTransaction
+	}
+	createdAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.Local)
+	now := time.Date(2024, 5, 1, 8, 0, 0, 0, time.Local)
+	loan := entities.Loan{
+		Id:                1,
+		ReferenceId:       "loan1",
+		Amount:            1200,
+		RatePercentage:    10,
+		Status:            entities.LoanStatusActive,
+		RepaymentSchedule: entities.RepaymentMonthly,
+		Tenor:             12,
+		RepaymentAmount:   110,
+		ScheduleVersion:   1,
+		CreatedAt:         createdAt,
+	}
+	restructured := loan
+	restructured.RatePercentage = 5
+	restructured.Tenor = 22
+	restructured.RepaymentAmount = 53
+	restructured.ScheduleVersion = 2
+	restructured.ScheduleStartAt = time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	restructured.InstallmentsOffset = 2
+	restructured.PaidOffset = 220
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.LoanScheduleVersion
+		wantErr bool
+	}{
+		{
+			name: "success first restructure with arrears capitalized",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleRepo: mock_usecase.NewMockScheduleRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.RestructureRequest{
+					LoanReferenceId:   "loan1",
+					Tenor:             20,
+					RatePercentage:    5,
+					CapitalizeArrears: true,
+					Reason:            "job loss",
+				},
+			},
+			mock: func(f fields, args input) {
+				original := entities.OriginalScheduleVersion(loan)
+				original.ClosedAt = now
+				l := loan
+				f.ScheduleRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&l, nil)
+				f.ScheduleRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(2, nil)
+				f.ScheduleRepo.EXPECT().SelectLoanScheduleVersionByLoanId(gomock.Any(), int64(1)).Return(&[]entities.LoanScheduleVersion{}, nil)
+				f.ScheduleRepo.EXPECT().SelectTotalRepaymentAmountByLoanId(gomock.Any(), int64(1)).Return(int64(220), nil)
+				f.Clock.EXPECT().Now().Return(now)
+				f.ScheduleRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.ScheduleRepo.EXPECT().CreateLoanScheduleVersion(gomock.Any(), f.Tx, original).Return(int64(1), nil)
+				f.ScheduleRepo.EXPECT().CreateLoanScheduleVersion(gomock.Any(), f.Tx, gomock.Any()).Return(int64(2), nil)
+				f.ScheduleRepo.EXPECT().UpdateLoanSchedule(gomock.Any(), f.Tx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, tx interface{}, updated entities.Loan) error {
+						assert.Equal(t, 22, updated.Tenor)
+						assert.Equal(t, int64(53), updated.RepaymentAmount)
+						assert.Equal(t, 2, updated.ScheduleVersion)
+						assert.Equal(t, 2, updated.InstallmentsOffset)
+						assert.Equal(t, int64(220), updated.PaidOffset)
+						return nil
+					})
+				f.Tx.EXPECT().Commit().Return(nil)
+				f.Tx.EXPECT().Rollback().Return(nil)
+			},
+			want: &entities.LoanScheduleVersion{
+				LoanId:             1,
+				Version:            2,
+				Type:               entities.ScheduleRestructure,
+				Principal:          1010,
+				RatePercentage:     5,
+				Tenor:              20,
+				RepaymentAmount:    53,
+				RepaymentSchedule:  entities.RepaymentMonthly,
+				InstallmentsBefore: 2,
+				PaidBefore:         220,
+				StartAt:            time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local),
+				Reason:             "job loss",
+			},
+			wantErr: false,
+		},
+		{
+			name: "success restructure an already restructured loan",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleRepo: mock_usecase.NewMockScheduleRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.RestructureRequest{LoanReferenceId: "loan1", Tenor: 10, RatePercentage: 0, Reason: "hardship"},
+			},
+			mock: func(f fields, args input) {
+				l := restructured
+				f.ScheduleRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&l, nil)
+				f.ScheduleRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(12, nil)
+				f.ScheduleRepo.EXPECT().SelectLoanScheduleVersionByLoanId(gomock.Any(), int64(1)).Return(&[]entities.LoanScheduleVersion{
+					{Id: 1, LoanId: 1, Version: 1, Type: entities.ScheduleOriginal, Principal: 1200, RatePercentage: 10, Tenor: 12},
+					{Id: 2, LoanId: 1, Version: 2, Type: entities.ScheduleRestructure, Principal: 1000, RatePercentage: 5,
+						Tenor: 20, InstallmentsBefore: 2, PaidBefore: 220},
+				}, nil)
+				f.ScheduleRepo.EXPECT().SelectTotalRepaymentAmountByLoanId(gomock.Any(), int64(1)).Return(int64(750), nil)
+				f.Clock.EXPECT().Now().Return(now)
+				f.ScheduleRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.ScheduleRepo.EXPECT().CloseLoanScheduleVersion(gomock.Any(), f.Tx, int64(2), now).Return(nil)
+				f.ScheduleRepo.EXPECT().CreateLoanScheduleVersion(gomock.Any(), f.Tx, gomock.Any()).Return(int64(3), nil)
+				f.ScheduleRepo.EXPECT().UpdateLoanSchedule(gomock.Any(), f.Tx, gomock.Any()).Return(nil)
+				f.Tx.EXPECT().Commit().Return(nil)
+				f.Tx.EXPECT().Rollback().Return(nil)
+			},
+			want: &entities.LoanScheduleVersion{
+				LoanId:             1,
+				Version:            3,
+				Type:               entities.ScheduleRestructure,
+				Principal:          500,
+				RatePercentage:     0,
+				Tenor:              10,
+				RepaymentAmount:    50,
+				RepaymentSchedule:  entities.RepaymentMonthly,
+				InstallmentsBefore: 12,
+				PaidBefore:         750,
+				StartAt:            time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local),
+				Reason:             "hardship",
+			},
+			wantErr: false,
+		},
+		{
+			name: "error validation",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleRepo: mock_usecase.NewMockScheduleRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.RestructureRequest{LoanReferenceId: "loan1", Tenor: 0, Reason: " "},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error loan not active",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleRepo: mock_usecase.NewMockScheduleRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.RestructureRequest{LoanReferenceId: "loan1", Tenor: 20, Reason: "job loss"},
+			},
+			mock: func(f fields, args input) {
+				f.ScheduleRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&entities.Loan{
+					Id:     1,
+					Status: entities.LoanStatusCompleted,
+				}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error tenor too long",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleRepo: mock_usecase.NewMockScheduleRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.RestructureRequest{LoanReferenceId: "loan1", Tenor: 2000, Reason: "job loss"},
+			},
+			mock: func(f fields, args input) {
+				l := loan
+				f.ScheduleRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&l, nil)
+				f.ScheduleRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(0, errs.Wrap(http.StatusNotFound, errors.New("not found")))
+				f.ScheduleRepo.EXPECT().SelectLoanScheduleVersionByLoanId(gomock.Any(), int64(1)).Return(&[]entities.LoanScheduleVersion{}, nil)
+				f.ScheduleRepo.EXPECT().SelectTotalRepaymentAmountByLoanId(gomock.Any(), int64(1)).Return(int64(0), nil)
+				f.Clock.EXPECT().Now().Return(now)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error update loan schedule",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleRepo: mock_usecase.NewMockScheduleRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.RestructureRequest{LoanReferenceId: "loan1", Tenor: 20, Reason: "job loss"},
+			},
+			mock: func(f fields, args input) {
+				l := loan
+				f.ScheduleRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&l, nil)
+				f.ScheduleRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(2, nil)
+				f.ScheduleRepo.EXPECT().SelectLoanScheduleVersionByLoanId(gomock.Any(), int64(1)).Return(&[]entities.LoanScheduleVersion{}, nil)
+				f.ScheduleRepo.EXPECT().SelectTotalRepaymentAmountByLoanId(gomock.Any(), int64(1)).Return(int64(220), nil)
+				f.Clock.EXPECT().Now().Return(now)
+				f.ScheduleRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.ScheduleRepo.EXPECT().CreateLoanScheduleVersion(gomock.Any(), f.Tx, gomock.Any()).Return(int64(1), nil).Times(2)
+				f.ScheduleRepo.EXPECT().UpdateLoanSchedule(gomock.Any(), f.Tx, gomock.Any()).Return(errors.New("some error"))
+				f.Tx.EXPECT().Rollback().Return(nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := ScheduleUseCase{
+				ScheduleRepo: f.ScheduleRepo,
+				Clock:        f.Clock,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.RestructureLoan(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestScheduleUseCase_DeferLoan(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.DeferralRequest
+	}
+	type fields struct {
+		ScheduleRepo *mock_usecase.MockScheduleRepository
+		Clock        *mock_domain.MockClock
+		Tx           *mock_domain.MockAtomicTransaction
+	}
+	now := time.Date(2024, 5, 1, 8, 0, 0, 0, time.Local)
+	loan := entities.Loan{
+		Id:                1,
+		ReferenceId:       "loan1",
+		Amount:            1200,
+		RatePercentage:    10,
+		Status:            entities.LoanStatusActive,
+		RepaymentSchedule: entities.RepaymentMonthly,
+		Tenor:             12,
+		RepaymentAmount:   110,
+		ScheduleVersion:   1,
+		CreatedAt:         time.Date(2024, 1, 1, 9, 0, 0, 0, time.Local),
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.LoanScheduleVersion
+		wantErr bool
+	}{
+		{
+			name: "success interest capitalized",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleRepo: mock_usecase.NewMockScheduleRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.DeferralRequest{
+					LoanReferenceId: "loan1",
+					Installments:    3,
+					Interest:        entities.DeferralInterestCapitalized,
+					Reason:          "flood",
+				},
+			},
+			mock: func(f fields, args input) {
+				original := entities.OriginalScheduleVersion(loan)
+				original.ClosedAt = now
+				l := loan
+				f.ScheduleRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&l, nil)
+				f.ScheduleRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(2, nil)
+				f.ScheduleRepo.EXPECT().SelectLoanScheduleVersionByLoanId(gomock.Any(), int64(1)).Return(&[]entities.LoanScheduleVersion{}, nil)
+				f.ScheduleRepo.EXPECT().SelectTotalRepaymentAmountByLoanId(gomock.Any(), int64(1)).Return(int64(220), nil)
+				f.Clock.EXPECT().Now().Return(now)
+				f.ScheduleRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.ScheduleRepo.EXPECT().CreateLoanScheduleVersion(gomock.Any(), f.Tx, original).Return(int64(1), nil)
+				f.ScheduleRepo.EXPECT().CreateLoanScheduleVersion(gomock.Any(), f.Tx, gomock.Any()).Return(int64(2), nil)
+				f.ScheduleRepo.EXPECT().UpdateLoanSchedule(gomock.Any(), f.Tx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, tx interface{}, updated entities.Loan) error {
+						assert.Equal(t, 12, updated.Tenor)
+						assert.Equal(t, int64(113), updated.RepaymentAmount)
+						assert.Equal(t, time.Date(2024, 6, 1, 9, 0, 0, 0, time.Local), updated.ScheduleStartAt)
+						assert.Equal(t, time.Date(2024, 7, 1, 9, 0, 0, 0, time.Local), updated.DueDate(3))
+						assert.Equal(t, 0, updated.MissedInstallments(now, 2))
+						return nil
+					})
+				f.Tx.EXPECT().Commit().Return(nil)
+				f.Tx.EXPECT().Rollback().Return(nil)
+			},
+			want: &entities.LoanScheduleVersion{
+				LoanId:               1,
+				Version:              2,
+				Type:                 entities.ScheduleDeferral,
+				Principal:            1030,
+				RatePercentage:       10,
+				Tenor:                10,
+				RepaymentAmount:      113,
+				RepaymentSchedule:    entities.RepaymentMonthly,
+				InstallmentsBefore:   2,
+				PaidBefore:           220,
+				StartAt:              time.Date(2024, 6, 1, 9, 0, 0, 0, time.Local),
+				DeferredInstallments: 3,
+				DeferralInterest:     entities.DeferralInterestCapitalized,
+				DeferredInterest:     30,
+				Reason:               "flood",
+			},
+			wantErr: false,
+		},
+		{
+			name: "success interest waived",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleRepo: mock_usecase.NewMockScheduleRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.DeferralRequest{
+					LoanReferenceId: "loan1",
+					Installments:    1,
+					Interest:        entities.DeferralInterestWaived,
+					Reason:          "hardship",
+				},
+			},
+			mock: func(f fields, args input) {
+				l := loan
+				f.ScheduleRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&l, nil)
+				f.ScheduleRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(2, nil)
+				f.ScheduleRepo.EXPECT().SelectLoanScheduleVersionByLoanId(gomock.Any(), int64(1)).Return(&[]entities.LoanScheduleVersion{}, nil)
+				f.ScheduleRepo.EXPECT().SelectTotalRepaymentAmountByLoanId(gomock.Any(), int64(1)).Return(int64(220), nil)
+				f.Clock.EXPECT().Now().Return(now)
+				f.ScheduleRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.ScheduleRepo.EXPECT().CreateLoanScheduleVersion(gomock.Any(), f.Tx, gomock.Any()).Return(int64(1), nil).Times(2)
+				f.ScheduleRepo.EXPECT().UpdateLoanSchedule(gomock.Any(), f.Tx, gomock.Any()).Return(nil)
+				f.Tx.EXPECT().Commit().Return(nil)
+				f.Tx.EXPECT().Rollback().Return(nil)
+			},
+			want: &entities.LoanScheduleVersion{
+				LoanId:               1,
+				Version:              2,
+				Type:                 entities.ScheduleDeferral,
+				Principal:            1000,
+				RatePercentage:       10,
+				Tenor:                10,
+				RepaymentAmount:      110,
+				RepaymentSchedule:    entities.RepaymentMonthly,
+				InstallmentsBefore:   2,
+				PaidBefore:           220,
+				StartAt:              time.Date(2024, 4, 1, 9, 0, 0, 0, time.Local),
+				DeferredInstallments: 1,
+				DeferralInterest:     entities.DeferralInterestWaived,
+				Reason:               "hardship",
+			},
+			wantErr: false,
+		},
+		{
+			name: "success interest spread",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleRepo: mock_usecase.NewMockScheduleRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.DeferralRequest{
+					LoanReferenceId: "loan1",
+					Installments:    3,
+					Interest:        entities.DeferralInterestSpread,
+					Reason:          "hardship",
+				},
+			},
+			mock: func(f fields, args input) {
+				l := loan
+				f.ScheduleRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&l, nil)
+				f.ScheduleRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(2, nil)
+				f.ScheduleRepo.EXPECT().SelectLoanScheduleVersionByLoanId(gomock.Any(), int64(1)).Return(&[]entities.LoanScheduleVersion{
+					{Id: 1, LoanId: 1, Version: 1, Type: entities.ScheduleOriginal, Principal: 1200, RatePercentage: 10, Tenor: 12},
+				}, nil)
+				f.ScheduleRepo.EXPECT().SelectTotalRepaymentAmountByLoanId(gomock.Any(), int64(1)).Return(int64(220), nil)
+				f.Clock.EXPECT().Now().Return(now)
+				f.ScheduleRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.ScheduleRepo.EXPECT().CloseLoanScheduleVersion(gomock.Any(), f.Tx, int64(1), now).Return(nil)
+				f.ScheduleRepo.EXPECT().CreateLoanScheduleVersion(gomock.Any(), f.Tx, gomock.Any()).Return(int64(2), nil)
+				f.ScheduleRepo.EXPECT().UpdateLoanSchedule(gomock.Any(), f.Tx, gomock.Any()).Return(nil)
+				f.Tx.EXPECT().Commit().Return(nil)
+				f.Tx.EXPECT().Rollback().Return(nil)
+			},
+			want: &entities.LoanScheduleVersion{
+				LoanId:               1,
+				Version:              2,
+				Type:                 entities.ScheduleDeferral,
+				Principal:            1000,
+				RatePercentage:       10,
+				Tenor:                10,
+				RepaymentAmount:      113,
+				RepaymentSchedule:    entities.RepaymentMonthly,
+				InstallmentsBefore:   2,
+				PaidBefore:           220,
+				StartAt:              time.Date(2024, 6, 1, 9, 0, 0, 0, time.Local),
+				DeferredInstallments: 3,
+				DeferralInterest:     entities.DeferralInterestSpread,
+				DeferredInterest:     30,
+				Reason:               "hardship",
+			},
+			wantErr: false,
+		},
+		{
+			name: "error invalid interest",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleRepo: mock_usecase.NewMockScheduleRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.DeferralRequest{LoanReferenceId: "loan1", Installments: 3, Interest: "forgiven", Reason: "flood"},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error loan not active",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleRepo: mock_usecase.NewMockScheduleRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.DeferralRequest{LoanReferenceId: "loan1", Installments: 3, Interest: entities.DeferralInterestWaived, Reason: "flood"},
+			},
+			mock: func(f fields, args input) {
+				f.ScheduleRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&entities.Loan{
+					Id:     1,
+					Status: entities.LoanStatusWrittenOff,
+				}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error begin transaction",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleRepo: mock_usecase.NewMockScheduleRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.DeferralRequest{LoanReferenceId: "loan1", Installments: 3, Interest: entities.DeferralInterestWaived, Reason: "flood"},
+			},
+			mock: func(f fields, args input) {
+				l := loan
+				f.ScheduleRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&l, nil)
+				f.ScheduleRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(2, nil)
+				f.ScheduleRepo.EXPECT().SelectLoanScheduleVersionByLoanId(gomock.Any(), int64(1)).Return(&[]entities.LoanScheduleVersion{}, nil)
+				f.ScheduleRepo.EXPECT().SelectTotalRepaymentAmountByLoanId(gomock.Any(), int64(1)).Return(int64(220), nil)
+				f.Clock.EXPECT().Now().Return(now)
+				f.ScheduleRepo.EXPECT().BeginTx(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := ScheduleUseCase{
+				ScheduleRepo: f.ScheduleRepo,
+				Clock:        f.Clock,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.DeferLoan(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestScheduleUseCase_GetScheduleVersionList(t *testing.T) {
+	type input struct {
+		ctx             context.Context
+		loanReferenceId string
+	}
+	type fields struct {
+		ScheduleRepo *mock_usecase.MockScheduleRepository
+	}
+	createdAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.Local)
+	loan := &entities.Loan{
+		Id:                1,
+		ReferenceId:       "loan1",
+		Amount:            1200,
+		RatePercentage:    10,
+		Status:            entities.LoanStatusActive,
+		RepaymentSchedule: entities.RepaymentMonthly,
+		Tenor:             12,
+		RepaymentAmount:   110,
+		ScheduleVersion:   1,
+		CreatedAt:         createdAt,
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *[]entities.LoanScheduleVersion
+		wantErr bool
+	}{
+		{
+			name: "success never rescheduled",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleRepo: mock_usecase.NewMockScheduleRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:             context.Background(),
+				loanReferenceId: "loan1",
+			},
+			mock: func(f fields, args input) {
+				f.ScheduleRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(loan, nil)
+				f.ScheduleRepo.EXPECT().SelectLoanScheduleVersionByLoanId(gomock.Any(), int64(1)).Return(&[]entities.LoanScheduleVersion{}, nil)
+			},
+			want: &[]entities.LoanScheduleVersion{
+				{
+					LoanId:            1,
+					Version:           1,
+					Type:              entities.ScheduleOriginal,
+					Principal:         1200,
+					RatePercentage:    10,
+					Tenor:             12,
+					RepaymentAmount:   110,
+					RepaymentSchedule: entities.RepaymentMonthly,
+					StartAt:           createdAt,
+					CreatedAt:         createdAt,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success stored versions",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleRepo: mock_usecase.NewMockScheduleRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:             context.Background(),
+				loanReferenceId: "loan1",
+			},
+			mock: func(f fields, args input) {
+				f.ScheduleRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(loan, nil)
+				f.ScheduleRepo.EXPECT().SelectLoanScheduleVersionByLoanId(gomock.Any(), int64(1)).Return(&[]entities.LoanScheduleVersion{
+					{Id: 1, LoanId: 1, Version: 1},
+					{Id: 2, LoanId: 1, Version: 2},
+				}, nil)
+			},
+			want: &[]entities.LoanScheduleVersion{
+				{Id: 1, LoanId: 1, Version: 1},
+				{Id: 2, LoanId: 1, Version: 2},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error empty loan reference id",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleRepo: mock_usecase.NewMockScheduleRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error select loan",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					ScheduleRepo: mock_usecase.NewMockScheduleRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:             context.Background(),
+				loanReferenceId: "loan1",
+			},
+			mock: func(f fields, args input) {
+				f.ScheduleRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(nil, errs.Wrap(http.StatusNotFound, errors.New("not found")))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := ScheduleUseCase{
+				ScheduleRepo: f.ScheduleRepo,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.GetScheduleVersionList(tt.input.ctx, tt.input.loanReferenceId)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
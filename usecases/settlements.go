@@ -0,0 +1,302 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+// ProcessSettlementFile posts every payment of a bank settlement file through
+// the regular repayment flow. A line that can not be posted goes to the
+// exception queue instead of failing the file. The same content is only
+// processed once per bank, a file that was interrupted is picked up again
+// and its already posted lines are reported as duplicates.
+func (u *SettlementUseCase) ProcessSettlementFile(ctx context.Context, request entities.SettlementUploadRequest) (*entities.SettlementResult, error) {
+	var errMessage []string
+
+	bankCode := strings.ToUpper(strings.TrimSpace(request.BankCode))
+	if bankCode == "" {
+		errMessage = append(errMessage, "bank code can not be empty")
+	}
+	parser, ok := u.Parsers[strings.ToLower(request.Format)]
+	if !ok {
+		errMessage = append(errMessage, "format is not supported")
+	}
+	if request.File == nil {
+		errMessage = append(errMessage, "settlement file is required")
+	}
+	if errMessage != nil || len(errMessage) != 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	content, err := io.ReadAll(request.File)
+	if err != nil {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "settlement file can not be read")
+	}
+	lines, err := parser.Parse(bytes.NewReader(content))
+	if err != nil {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "settlement file: "+err.Error())
+	}
+
+	checksum := sha256.Sum256(content)
+	file, err := u.SettlementRepo.SelectSettlementFileByChecksum(ctx, bankCode, hex.EncodeToString(checksum[:]))
+	if err == nil && file.Status == entities.SettlementFileCompleted {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "settlement file has already been processed")
+	}
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+
+		file = &entities.SettlementFile{
+			BankCode: bankCode,
+			Format:   parser.Format(),
+			FileName: request.FileName,
+			Checksum: hex.EncodeToString(checksum[:]),
+			Status:   entities.SettlementFileProcessing,
+		}
+		file.Id, err = u.SettlementRepo.CreateSettlementFile(ctx, nil, *file)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &entities.SettlementResult{
+		Lines: make([]entities.SettlementLineResult, 0, len(lines)),
+	}
+	file.Posted, file.Duplicates, file.Exceptions = 0, 0, 0
+	for _, line := range lines {
+		lineResult, err := u.settleLine(ctx, *file, line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch lineResult.Status {
+		case entities.SettlementLinePosted:
+			file.Posted++
+		case entities.SettlementLineDuplicate:
+			file.Duplicates++
+		case entities.SettlementLineException:
+			file.Exceptions++
+		}
+		result.Lines = append(result.Lines, lineResult)
+	}
+
+	file.Status = entities.SettlementFileCompleted
+	file.TotalLines = len(lines)
+	err = u.SettlementRepo.UpdateSettlementFile(ctx, nil, *file)
+	if err != nil {
+		return nil, err
+	}
+	result.File = *file
+
+	return result, nil
+}
+
+// settleLine returns an error only when the line could neither be posted nor
+// put in the exception queue
+func (u *SettlementUseCase) settleLine(ctx context.Context, file entities.SettlementFile, line entities.SettlementLine) (entities.SettlementLineResult, error) {
+	lineResult := entities.SettlementLineResult{
+		LineNumber:    line.LineNumber,
+		TransactionId: line.TransactionId,
+	}
+
+	repaymentId, duplicate, err := u.postLine(ctx, file.BankCode, line)
+	if err == nil {
+		lineResult.Status = entities.SettlementLinePosted
+		if duplicate {
+			lineResult.Status = entities.SettlementLineDuplicate
+		}
+		lineResult.RepaymentId = repaymentId
+		return lineResult, nil
+	}
+
+	lineResult.Status = entities.SettlementLineException
+	lineResult.Error = err.Error()
+	lineResult.ExceptionId, err = u.SettlementRepo.CreateSettlementException(ctx, nil, entities.SettlementException{
+		FileId:               file.Id,
+		LineNumber:           line.LineNumber,
+		BankCode:             file.BankCode,
+		TransactionId:        line.TransactionId,
+		LoanReferenceId:      line.LoanReferenceId,
+		VirtualAccountNumber: line.VirtualAccountNumber,
+		Amount:               line.Amount,
+		PaidAt:               line.PaidAt,
+		Reason:               lineResult.Error,
+		Status:               entities.SettlementExceptionOpen,
+	})
+	if err != nil {
+		return lineResult, err
+	}
+
+	return lineResult, nil
+}
+
+// postLine matches the line to its loan and makes the payment. A line that
+// was already posted, by an earlier file or by the payment gateway, returns
+// the existing repayment.
+func (u *SettlementUseCase) postLine(ctx context.Context, bankCode string, line entities.SettlementLine) (int64, bool, error) {
+	if line.Error != "" {
+		return 0, false, errs.NewWithMessage(http.StatusBadRequest, line.Error)
+	}
+
+	loan, err := u.loanOf(ctx, bankCode, line)
+	if err != nil {
+		return 0, false, err
+	}
+	// the file is the operator's, the line is posted within the tenant of its loan
+	ctx = helper.WithTenantId(ctx, loan.TenantId)
+
+	repaymentReferenceId := line.RepaymentReferenceId(bankCode)
+	repayment, err := u.SettlementRepo.SelectRepaymentByReferenceId(ctx, repaymentReferenceId)
+	if err == nil {
+		return repayment.Id, true, nil
+	}
+	if errs.GetHTTPCode(err) != http.StatusNotFound {
+		return 0, false, err
+	}
+
+	repaymentId, err := u.Payments.MakePayment(ctx, entities.RepaymentRequest{
+		LoanReferenceId:      loan.ReferenceId,
+		RepaymentReferenceId: repaymentReferenceId,
+		Amount:               line.Amount,
+	})
+	if err != nil {
+		if errs.GetHTTPCode(err) == http.StatusNotFound {
+			return 0, false, errs.NewWithMessage(http.StatusNotFound, "loan is not found")
+		}
+		return 0, false, err
+	}
+
+	return repaymentId, false, nil
+}
+
+// loanOf finds the loan of the line by its loan reference id, or else by its
+// virtual account number. Loan reference ids are only unique within a tenant,
+// one held by loans of several tenants needs the virtual account number.
+func (u *SettlementUseCase) loanOf(ctx context.Context, bankCode string, line entities.SettlementLine) (*entities.Loan, error) {
+	if line.LoanReferenceId != "" {
+		loans, err := u.SettlementRepo.SelectLoansByReferenceId(ctx, line.LoanReferenceId)
+		if err != nil {
+			return nil, err
+		}
+		switch len(*loans) {
+		case 0:
+			return nil, errs.NewWithMessage(http.StatusNotFound, "loan is not found")
+		case 1:
+			return &(*loans)[0], nil
+		default:
+			return nil, errs.NewWithMessage(http.StatusConflict, "loan reference id belongs to more than one tenant")
+		}
+	}
+
+	if line.VirtualAccountNumber == "" {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loan reference id or virtual account number is required")
+	}
+
+	virtualAccount, err := u.SettlementRepo.SelectVirtualAccountByNumber(ctx, line.VirtualAccountNumber)
+	if err != nil {
+		if errs.GetHTTPCode(err) == http.StatusNotFound {
+			return nil, errs.NewWithMessage(http.StatusNotFound, "virtual account is not found")
+		}
+		return nil, err
+	}
+	if virtualAccount.Status != entities.VirtualAccountActive {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "virtual account is "+string(virtualAccount.Status))
+	}
+	if !strings.EqualFold(bankCode, virtualAccount.BankCode) {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "bank code does not match the virtual account")
+	}
+
+	return u.SettlementRepo.SelectLoanById(ctx, virtualAccount.LoanId)
+}
+
+func (u *SettlementUseCase) GetSettlementExceptionList(ctx context.Context, status string) (*[]entities.SettlementException, error) {
+	exceptionStatus := entities.SettlementExceptionOpen
+	if status != "" {
+		exceptionStatus = entities.SettlementExceptionStatus(strings.ToLower(status))
+	}
+	if !exceptionStatus.IsValid() {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "status is invalid")
+	}
+
+	return u.SettlementRepo.SelectSettlementExceptionByStatus(ctx, exceptionStatus)
+}
+
+// RepostSettlementException posts an open exception again once operations
+// fixed its cause, e.g. created the missing virtual account or gave the
+// right loan reference id
+func (u *SettlementUseCase) RepostSettlementException(ctx context.Context, request entities.SettlementRepostRequest) (*entities.SettlementException, error) {
+	exception, err := u.openSettlementException(ctx, request.ExceptionId)
+	if err != nil {
+		return nil, err
+	}
+	if exception.TransactionId == "" {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "settlement exception has no transaction id, it can only be dismissed")
+	}
+
+	line := exception.Line()
+	if request.LoanReferenceId != "" {
+		line.LoanReferenceId = request.LoanReferenceId
+	}
+	repaymentId, _, err := u.postLine(ctx, exception.BankCode, line)
+	if err != nil {
+		return nil, err
+	}
+
+	exception.LoanReferenceId = line.LoanReferenceId
+	exception.Status = entities.SettlementExceptionPosted
+	exception.RepaymentId = repaymentId
+	err = u.SettlementRepo.UpdateSettlementException(ctx, nil, *exception)
+	if err != nil {
+		return nil, err
+	}
+
+	return exception, nil
+}
+
+// DismissSettlementException closes an exception that will not be posted,
+// e.g. a payment that was refunded to the payer
+func (u *SettlementUseCase) DismissSettlementException(ctx context.Context, request entities.SettlementDismissRequest) (*entities.SettlementException, error) {
+	if strings.TrimSpace(request.Note) == "" {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "note can not be empty")
+	}
+
+	exception, err := u.openSettlementException(ctx, request.ExceptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	exception.Status = entities.SettlementExceptionDismissed
+	exception.Note = request.Note
+	err = u.SettlementRepo.UpdateSettlementException(ctx, nil, *exception)
+	if err != nil {
+		return nil, err
+	}
+
+	return exception, nil
+}
+
+func (u *SettlementUseCase) openSettlementException(ctx context.Context, id int64) (*entities.SettlementException, error) {
+	if id < 1 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "exception id is invalid")
+	}
+
+	exception, err := u.SettlementRepo.SelectSettlementExceptionById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if exception.Status != entities.SettlementExceptionOpen {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "settlement exception has been "+string(exception.Status))
+	}
+
+	return exception, nil
+}
@@ -0,0 +1,575 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func TestSettlementUseCase_ProcessSettlementFile(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.SettlementUploadRequest
+	}
+	type fields struct {
+		SettlementRepo *mock_usecase.MockSettlementRepository
+		Parser         *mock_domain.MockSettlementParser
+		Payments       *mock_usecase.MockPaymentMaker
+	}
+	notFound := errs.Wrap(http.StatusNotFound, errors.New("not found"))
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.SettlementResult
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementRepo: mock_usecase.NewMockSettlementRepository(ctrl),
+					Parser:         mock_domain.NewMockSettlementParser(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+				}
+			},
+			input: input{
+				ctx: helper.WithOperator(context.Background()),
+				param: entities.SettlementUploadRequest{
+					BankCode: "bca",
+					Format:   "CSV",
+					FileName: "settlement.csv",
+					File:     strings.NewReader("content"),
+				},
+			},
+			mock: func(f fields, args input) {
+				f.Parser.EXPECT().Format().Return("csv").AnyTimes()
+				f.Parser.EXPECT().Parse(gomock.Any()).Return([]entities.SettlementLine{
+					{LineNumber: 2, TransactionId: "trx1", LoanReferenceId: "loan1", Amount: 100},
+					{LineNumber: 3, TransactionId: "trx2", VirtualAccountNumber: "8808001", Amount: 100},
+					{LineNumber: 4, TransactionId: "trx3", VirtualAccountNumber: "8808002", Amount: 100},
+					{LineNumber: 5, TransactionId: "trx4", Error: "amount is not a number"},
+				}, nil)
+				f.SettlementRepo.EXPECT().SelectSettlementFileByChecksum(gomock.Any(), "BCA", gomock.Any()).Return(nil, notFound)
+				f.SettlementRepo.EXPECT().CreateSettlementFile(gomock.Any(), nil, gomock.Any()).Return(int64(7), nil)
+
+				f.SettlementRepo.EXPECT().SelectLoansByReferenceId(gomock.Any(), "loan1").Return(&[]entities.Loan{
+					{Id: 1, TenantId: 2, ReferenceId: "loan1"},
+				}, nil)
+				f.SettlementRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "STL-BCA-trx1").Return(nil, notFound)
+				// the file is the operator's, the payment is made within the tenant of the loan
+				f.Payments.EXPECT().MakePayment(gomock.Any(), entities.RepaymentRequest{
+					LoanReferenceId:      "loan1",
+					RepaymentReferenceId: "STL-BCA-trx1",
+					Amount:               100,
+				}).DoAndReturn(func(ctx context.Context, request entities.RepaymentRequest) (int64, error) {
+					assert.Equal(t, int64(2), helper.GetTenantId(ctx))
+					assert.False(t, helper.IsOperator(ctx))
+					return int64(11), nil
+				})
+
+				f.SettlementRepo.EXPECT().SelectVirtualAccountByNumber(gomock.Any(), "8808001").Return(&entities.VirtualAccount{
+					LoanId: 2, BankCode: "BCA", Number: "8808001", Status: entities.VirtualAccountActive,
+				}, nil)
+				f.SettlementRepo.EXPECT().SelectLoanById(gomock.Any(), int64(2)).Return(&entities.Loan{Id: 2, ReferenceId: "loan2"}, nil)
+				f.SettlementRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "VA-BCA-trx2").Return(&entities.Repayment{Id: 12}, nil)
+
+				f.SettlementRepo.EXPECT().SelectVirtualAccountByNumber(gomock.Any(), "8808002").Return(nil, notFound)
+				f.SettlementRepo.EXPECT().CreateSettlementException(gomock.Any(), nil, entities.SettlementException{
+					FileId:               7,
+					LineNumber:           4,
+					BankCode:             "BCA",
+					TransactionId:        "trx3",
+					VirtualAccountNumber: "8808002",
+					Amount:               100,
+					Reason:               "virtual account is not found",
+					Status:               entities.SettlementExceptionOpen,
+				}).Return(int64(21), nil)
+				f.SettlementRepo.EXPECT().CreateSettlementException(gomock.Any(), nil, gomock.Any()).Return(int64(22), nil)
+
+				f.SettlementRepo.EXPECT().UpdateSettlementFile(gomock.Any(), nil, gomock.Any()).Return(nil)
+			},
+			want: &entities.SettlementResult{
+				Lines: []entities.SettlementLineResult{
+					{LineNumber: 2, TransactionId: "trx1", Status: entities.SettlementLinePosted, RepaymentId: 11},
+					{LineNumber: 3, TransactionId: "trx2", Status: entities.SettlementLineDuplicate, RepaymentId: 12},
+					{LineNumber: 4, TransactionId: "trx3", Status: entities.SettlementLineException, ExceptionId: 21, Error: "virtual account is not found"},
+					{LineNumber: 5, TransactionId: "trx4", Status: entities.SettlementLineException, ExceptionId: 22, Error: "amount is not a number"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success rejected payment",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementRepo: mock_usecase.NewMockSettlementRepository(ctrl),
+					Parser:         mock_domain.NewMockSettlementParser(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.SettlementUploadRequest{
+					BankCode: "BCA",
+					Format:   "csv",
+					File:     strings.NewReader("content"),
+				},
+			},
+			mock: func(f fields, args input) {
+				f.Parser.EXPECT().Parse(gomock.Any()).Return([]entities.SettlementLine{
+					{LineNumber: 2, TransactionId: "trx1", LoanReferenceId: "loan1", Amount: 90},
+				}, nil)
+				f.SettlementRepo.EXPECT().SelectSettlementFileByChecksum(gomock.Any(), "BCA", gomock.Any()).Return(&entities.SettlementFile{
+					Id: 7, BankCode: "BCA", Status: entities.SettlementFileProcessing,
+				}, nil)
+				f.SettlementRepo.EXPECT().SelectLoansByReferenceId(gomock.Any(), "loan1").Return(&[]entities.Loan{
+					{Id: 1, TenantId: 2, ReferenceId: "loan1"},
+				}, nil)
+				f.SettlementRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "STL-BCA-trx1").Return(nil, notFound)
+				f.Payments.EXPECT().MakePayment(gomock.Any(), gomock.Any()).Return(int64(0),
+					errs.NewWithMessage(http.StatusBadRequest, "payment amount is invalid, expected: 100"))
+				f.SettlementRepo.EXPECT().CreateSettlementException(gomock.Any(), nil, gomock.Any()).Return(int64(21), nil)
+				f.SettlementRepo.EXPECT().UpdateSettlementFile(gomock.Any(), nil, entities.SettlementFile{
+					Id:         7,
+					BankCode:   "BCA",
+					Status:     entities.SettlementFileCompleted,
+					TotalLines: 1,
+					Exceptions: 1,
+				}).Return(nil)
+			},
+			want: &entities.SettlementResult{
+				File: entities.SettlementFile{
+					Id:         7,
+					BankCode:   "BCA",
+					Status:     entities.SettlementFileCompleted,
+					TotalLines: 1,
+					Exceptions: 1,
+				},
+				Lines: []entities.SettlementLineResult{
+					{LineNumber: 2, TransactionId: "trx1", Status: entities.SettlementLineException, ExceptionId: 21,
+						Error: "payment amount is invalid, expected: 100"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success loan reference id of several tenants goes to the exception queue",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementRepo: mock_usecase.NewMockSettlementRepository(ctrl),
+					Parser:         mock_domain.NewMockSettlementParser(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+				}
+			},
+			input: input{
+				ctx: helper.WithOperator(context.Background()),
+				param: entities.SettlementUploadRequest{
+					BankCode: "BCA",
+					Format:   "csv",
+					File:     strings.NewReader("content"),
+				},
+			},
+			mock: func(f fields, args input) {
+				f.Parser.EXPECT().Parse(gomock.Any()).Return([]entities.SettlementLine{
+					{LineNumber: 2, TransactionId: "trx1", LoanReferenceId: "loan1", Amount: 100},
+				}, nil)
+				f.SettlementRepo.EXPECT().SelectSettlementFileByChecksum(gomock.Any(), "BCA", gomock.Any()).Return(&entities.SettlementFile{
+					Id: 7, BankCode: "BCA", Status: entities.SettlementFileProcessing,
+				}, nil)
+				f.SettlementRepo.EXPECT().SelectLoansByReferenceId(gomock.Any(), "loan1").Return(&[]entities.Loan{
+					{Id: 1, TenantId: 2, ReferenceId: "loan1"},
+					{Id: 5, TenantId: 3, ReferenceId: "loan1"},
+				}, nil)
+				f.SettlementRepo.EXPECT().CreateSettlementException(gomock.Any(), nil, gomock.Any()).Return(int64(21), nil)
+				f.SettlementRepo.EXPECT().UpdateSettlementFile(gomock.Any(), nil, gomock.Any()).Return(nil)
+			},
+			want: &entities.SettlementResult{
+				File: entities.SettlementFile{
+					Id:         7,
+					BankCode:   "BCA",
+					Status:     entities.SettlementFileCompleted,
+					TotalLines: 1,
+					Exceptions: 1,
+				},
+				Lines: []entities.SettlementLineResult{
+					{LineNumber: 2, TransactionId: "trx1", Status: entities.SettlementLineException, ExceptionId: 21,
+						Error: "loan reference id belongs to more than one tenant"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error format not supported",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementRepo: mock_usecase.NewMockSettlementRepository(ctrl),
+					Parser:         mock_domain.NewMockSettlementParser(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.SettlementUploadRequest{
+					BankCode: "BCA",
+					Format:   "xml",
+					File:     strings.NewReader("content"),
+				},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error invalid file",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementRepo: mock_usecase.NewMockSettlementRepository(ctrl),
+					Parser:         mock_domain.NewMockSettlementParser(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.SettlementUploadRequest{
+					BankCode: "BCA",
+					Format:   "csv",
+					File:     strings.NewReader("content"),
+				},
+			},
+			mock: func(f fields, args input) {
+				f.Parser.EXPECT().Parse(gomock.Any()).Return(nil, errors.New("missing columns amount"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error already processed",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementRepo: mock_usecase.NewMockSettlementRepository(ctrl),
+					Parser:         mock_domain.NewMockSettlementParser(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.SettlementUploadRequest{
+					BankCode: "BCA",
+					Format:   "csv",
+					File:     strings.NewReader("content"),
+				},
+			},
+			mock: func(f fields, args input) {
+				f.Parser.EXPECT().Parse(gomock.Any()).Return([]entities.SettlementLine{}, nil)
+				f.SettlementRepo.EXPECT().SelectSettlementFileByChecksum(gomock.Any(), "BCA", gomock.Any()).Return(&entities.SettlementFile{
+					Id: 7, Status: entities.SettlementFileCompleted,
+				}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error create exception",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementRepo: mock_usecase.NewMockSettlementRepository(ctrl),
+					Parser:         mock_domain.NewMockSettlementParser(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.SettlementUploadRequest{
+					BankCode: "BCA",
+					Format:   "csv",
+					File:     strings.NewReader("content"),
+				},
+			},
+			mock: func(f fields, args input) {
+				f.Parser.EXPECT().Format().Return("csv").AnyTimes()
+				f.Parser.EXPECT().Parse(gomock.Any()).Return([]entities.SettlementLine{
+					{LineNumber: 2, Error: "transaction id can not be empty"},
+				}, nil)
+				f.SettlementRepo.EXPECT().SelectSettlementFileByChecksum(gomock.Any(), "BCA", gomock.Any()).Return(nil, notFound)
+				f.SettlementRepo.EXPECT().CreateSettlementFile(gomock.Any(), nil, gomock.Any()).Return(int64(7), nil)
+				f.SettlementRepo.EXPECT().CreateSettlementException(gomock.Any(), nil, gomock.Any()).Return(int64(0), errors.New("db error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := SettlementUseCase{
+				SettlementRepo: f.SettlementRepo,
+				Parsers:        map[string]interfaces.SettlementParser{"csv": f.Parser},
+				Payments:       f.Payments,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.ProcessSettlementFile(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want.Lines, got.Lines)
+			if tt.want.File.Id != 0 {
+				assert.EqualValues(t, tt.want.File, got.File)
+			}
+		})
+	}
+}
+
+func TestSettlementUseCase_RepostSettlementException(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.SettlementRepostRequest
+	}
+	type fields struct {
+		SettlementRepo *mock_usecase.MockSettlementRepository
+		Payments       *mock_usecase.MockPaymentMaker
+	}
+	notFound := errs.Wrap(http.StatusNotFound, errors.New("not found"))
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.SettlementException
+		wantErr bool
+	}{
+		{
+			name: "success with loan reference id",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementRepo: mock_usecase.NewMockSettlementRepository(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.SettlementRepostRequest{ExceptionId: 21, LoanReferenceId: "loan1"},
+			},
+			mock: func(f fields, args input) {
+				f.SettlementRepo.EXPECT().SelectSettlementExceptionById(gomock.Any(), int64(21)).Return(&entities.SettlementException{
+					Id: 21, BankCode: "BCA", TransactionId: "trx3", VirtualAccountNumber: "8808002", Amount: 100,
+					Reason: "virtual account is not found", Status: entities.SettlementExceptionOpen,
+				}, nil)
+				f.SettlementRepo.EXPECT().SelectLoansByReferenceId(gomock.Any(), "loan1").Return(&[]entities.Loan{
+					{Id: 1, TenantId: 2, ReferenceId: "loan1"},
+				}, nil)
+				f.SettlementRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "VA-BCA-trx3").Return(nil, notFound)
+				f.Payments.EXPECT().MakePayment(gomock.Any(), entities.RepaymentRequest{
+					LoanReferenceId:      "loan1",
+					RepaymentReferenceId: "VA-BCA-trx3",
+					Amount:               100,
+				}).Return(int64(13), nil)
+				f.SettlementRepo.EXPECT().UpdateSettlementException(gomock.Any(), nil, gomock.Any()).Return(nil)
+			},
+			want: &entities.SettlementException{
+				Id: 21, BankCode: "BCA", TransactionId: "trx3", LoanReferenceId: "loan1", VirtualAccountNumber: "8808002",
+				Amount: 100, Reason: "virtual account is not found", Status: entities.SettlementExceptionPosted, RepaymentId: 13,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error payment still rejected",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementRepo: mock_usecase.NewMockSettlementRepository(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.SettlementRepostRequest{ExceptionId: 21},
+			},
+			mock: func(f fields, args input) {
+				f.SettlementRepo.EXPECT().SelectSettlementExceptionById(gomock.Any(), int64(21)).Return(&entities.SettlementException{
+					Id: 21, BankCode: "BCA", TransactionId: "trx1", LoanReferenceId: "loan1", Amount: 90,
+					Status: entities.SettlementExceptionOpen,
+				}, nil)
+				f.SettlementRepo.EXPECT().SelectLoansByReferenceId(gomock.Any(), "loan1").Return(&[]entities.Loan{
+					{Id: 1, TenantId: 2, ReferenceId: "loan1"},
+				}, nil)
+				f.SettlementRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "STL-BCA-trx1").Return(nil, notFound)
+				f.Payments.EXPECT().MakePayment(gomock.Any(), gomock.Any()).Return(int64(0),
+					errs.NewWithMessage(http.StatusBadRequest, "payment amount is invalid, expected: 100"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error exception not open",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementRepo: mock_usecase.NewMockSettlementRepository(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.SettlementRepostRequest{ExceptionId: 21},
+			},
+			mock: func(f fields, args input) {
+				f.SettlementRepo.EXPECT().SelectSettlementExceptionById(gomock.Any(), int64(21)).Return(&entities.SettlementException{
+					Id: 21, TransactionId: "trx1", Status: entities.SettlementExceptionDismissed,
+				}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error invalid exception id",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementRepo: mock_usecase.NewMockSettlementRepository(ctrl),
+					Payments:       mock_usecase.NewMockPaymentMaker(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.SettlementRepostRequest{},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := SettlementUseCase{
+				SettlementRepo: f.SettlementRepo,
+				Payments:       f.Payments,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.RepostSettlementException(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestSettlementUseCase_DismissSettlementException(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.SettlementDismissRequest
+	}
+	type fields struct {
+		SettlementRepo *mock_usecase.MockSettlementRepository
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.SettlementException
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementRepo: mock_usecase.NewMockSettlementRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.SettlementDismissRequest{ExceptionId: 21, Note: "refunded to the payer"},
+			},
+			mock: func(f fields, args input) {
+				f.SettlementRepo.EXPECT().SelectSettlementExceptionById(gomock.Any(), int64(21)).Return(&entities.SettlementException{
+					Id: 21, Status: entities.SettlementExceptionOpen,
+				}, nil)
+				f.SettlementRepo.EXPECT().UpdateSettlementException(gomock.Any(), nil, entities.SettlementException{
+					Id: 21, Status: entities.SettlementExceptionDismissed, Note: "refunded to the payer",
+				}).Return(nil)
+			},
+			want: &entities.SettlementException{
+				Id: 21, Status: entities.SettlementExceptionDismissed, Note: "refunded to the payer",
+			},
+			wantErr: false,
+		},
+		{
+			name: "error empty note",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementRepo: mock_usecase.NewMockSettlementRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.SettlementDismissRequest{ExceptionId: 21},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error exception not found",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SettlementRepo: mock_usecase.NewMockSettlementRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.SettlementDismissRequest{ExceptionId: 21, Note: "refunded to the payer"},
+			},
+			mock: func(f fields, args input) {
+				f.SettlementRepo.EXPECT().SelectSettlementExceptionById(gomock.Any(), int64(21)).Return(nil,
+					errs.Wrap(http.StatusNotFound, errors.New("not found")))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := SettlementUseCase{
+				SettlementRepo: f.SettlementRepo,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.DismissSettlementException(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const (
+	snapshotBatchSize = 500
+	maxBackfillDays   = 366
+)
+
+// CreateSnapshot writes the end of day state of every loan that existed on the
+// business date. Only repayments made before the end of that day are counted,
+// so a past date can be snapshotted again with the same result. A zero
+// business date means the last completed day.
+func (u *SnapshotUseCase) CreateSnapshot(ctx context.Context, businessDate time.Time) (*entities.SnapshotRunResult, error) {
+	now := u.Clock.Now()
+	if businessDate.IsZero() {
+		businessDate = helper.TruncateToDay(now).AddDate(0, 0, -1)
+	}
+	businessDate = helper.TruncateToDay(businessDate)
+	if !businessDate.Before(helper.TruncateToDay(now.In(businessDate.Location()))) {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "business date has not ended yet")
+	}
+
+	return u.createSnapshot(ctx, businessDate)
+}
+
+// BackfillSnapshots snapshots every business date from the first to the last
+// date of the request, both included.
+func (u *SnapshotUseCase) BackfillSnapshots(ctx context.Context, request entities.SnapshotBackfillRequest) (*[]entities.SnapshotRunResult, error) {
+	fromDate, err := helper.ParseDate(request.FromDate, time.Local)
+	if err != nil {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "from date must be formatted as "+helper.DateLayout)
+	}
+	toDate, err := helper.ParseDate(request.ToDate, time.Local)
+	if err != nil {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "to date must be formatted as "+helper.DateLayout)
+	}
+	if toDate.Before(fromDate) {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "to date can not be before from date")
+	}
+	if helper.DaysBetween(fromDate, toDate) >= maxBackfillDays {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "date range can not be longer than a year")
+	}
+	if !toDate.Before(helper.TruncateToDay(u.Clock.Now())) {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "to date has not ended yet")
+	}
+
+	results := []entities.SnapshotRunResult{}
+	for businessDate := fromDate; !businessDate.After(toDate); businessDate = businessDate.AddDate(0, 0, 1) {
+		result, err := u.createSnapshot(ctx, businessDate)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *result)
+	}
+
+	return &results, nil
+}
+
+func (u *SnapshotUseCase) GetSnapshotList(ctx context.Context, businessDate string) (*[]entities.LoanDailySnapshot, error) {
+	date, err := helper.ParseDate(businessDate, time.Local)
+	if err != nil {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "business date must be formatted as "+helper.DateLayout)
+	}
+
+	snapshots, err := u.SnapshotRepo.SelectLoanDailySnapshotByBusinessDate(ctx, date)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+		return &[]entities.LoanDailySnapshot{}, nil
+	}
+
+	return snapshots, nil
+}
+
+func (u *SnapshotUseCase) createSnapshot(ctx context.Context, businessDate time.Time) (*entities.SnapshotRunResult, error) {
+	endOfDay := businessDate.AddDate(0, 0, 1)
+	result := &entities.SnapshotRunResult{BusinessDate: businessDate}
+
+	var afterId int64
+	for {
+		loans, err := u.SnapshotRepo.SelectLoanCreatedBefore(ctx, endOfDay, afterId, snapshotBatchSize)
+		if err != nil {
+			if errs.GetHTTPCode(err) != http.StatusNotFound {
+				return nil, err
+			}
+			return result, nil
+		}
+		if len(*loans) == 0 {
+			return result, nil
+		}
+
+		loanIds := make([]int64, len(*loans))
+		for i, loan := range *loans {
+			loanIds[i] = loan.Id
+		}
+		repaymentCounts, err := u.SnapshotRepo.SelectRepaymentCountByLoanIds(ctx, loanIds, endOfDay)
+		if err != nil {
+			return nil, err
+		}
+
+		err = u.saveSnapshots(ctx, *loans, repaymentCounts, businessDate)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Loans += len(*loans)
+		afterId = loanIds[len(loanIds)-1]
+		if len(*loans) < snapshotBatchSize {
+			return result, nil
+		}
+	}
+}
+
+func (u *SnapshotUseCase) saveSnapshots(ctx context.Context, loans []entities.Loan, repaymentCounts map[int64]int, businessDate time.Time) error {
+	dbTx, err := u.SnapshotRepo.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback()
+
+	for _, loan := range loans {
+		err = u.SnapshotRepo.UpsertLoanDailySnapshot(ctx, dbTx, buildSnapshot(loan, repaymentCounts[loan.Id], businessDate))
+		if err != nil {
+			return err
+		}
+	}
+
+	return dbTx.Commit()
+}
+
+// buildSnapshot derives the state of the loan on the business date from the
+// installments paid until then, not from the current loan status.
+func buildSnapshot(loan entities.Loan, installmentsPaid int, businessDate time.Time) entities.LoanDailySnapshot {
+	status := loan.Status
+	// the date of a write-off is not kept on the loan, so a written off loan
+	// stays written off on every business date
+	if status != entities.LoanStatusRejected && status != entities.LoanStatusWrittenOff {
+		status = entities.LoanStatusActive
+		if installmentsPaid >= loan.Tenor {
+			status = entities.LoanStatusCompleted
+		}
+	}
+
+	// a written off loan is out of collection and no longer ages
+	var daysPastDue int
+	if status.IsActive() {
+		daysPastDue = daysPastDueOf(loan, installmentsPaid, businessDate)
+	}
+
+	// the engine does not charge penalties yet
+	var penaltyOutstanding int64
+
+	return entities.LoanDailySnapshot{
+		BusinessDate:         businessDate,
+		LoanId:               loan.Id,
+		LoanReferenceId:      loan.ReferenceId,
+		UserId:               loan.UserId,
+		Status:               status,
+		InstallmentsPaid:     installmentsPaid,
+		PrincipalOutstanding: loan.Amount - loan.PrincipalPaid(installmentsPaid),
+		InterestOutstanding:  loan.TotalInterest() - loan.InterestPaid(installmentsPaid),
+		PenaltyOutstanding:   penaltyOutstanding,
+		DaysPastDue:          daysPastDue,
+		AgingBucket:          entities.AgingBucketOf(daysPastDue),
+	}
+}
@@ -0,0 +1,295 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+)
+
+func TestSnapshotUseCase_CreateSnapshot(t *testing.T) {
+	type input struct {
+		ctx          context.Context
+		businessDate time.Time
+	}
+	type fields struct {
+		SnapshotRepo *mock_usecase.MockSnapshotRepository
+		Clock        *mock_domain.MockClock
+		Tx           *mock_domain.MockAtomicTransaction
+	}
+	now := time.Date(2000, 3, 15, 8, 0, 0, 0, time.Local)
+	businessDate := time.Date(2000, 3, 14, 0, 0, 0, 0, time.Local)
+	loans := []entities.Loan{
+		{
+			Id:                1,
+			ReferenceId:       "loan1",
+			UserId:            1,
+			Amount:            1200,
+			RatePercentage:    10,
+			Status:            entities.LoanStatusActive,
+			RepaymentSchedule: entities.RepaymentMonthly,
+			Tenor:             12,
+			RepaymentAmount:   110,
+			CreatedAt:         time.Date(2000, 1, 1, 10, 0, 0, 0, time.Local),
+		},
+		{
+			Id:                2,
+			ReferenceId:       "loan2",
+			UserId:            2,
+			Amount:            1000,
+			RatePercentage:    10,
+			Status:            entities.LoanStatusCompleted,
+			RepaymentSchedule: entities.RepaymentWeekly,
+			Tenor:             2,
+			RepaymentAmount:   550,
+			CreatedAt:         time.Date(2000, 3, 1, 10, 0, 0, 0, time.Local),
+		},
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.SnapshotRunResult
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SnapshotRepo: mock_usecase.NewMockSnapshotRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+				endOfDay := time.Date(2000, 3, 15, 0, 0, 0, 0, time.Local)
+				f.Clock.EXPECT().Now().Return(now)
+				f.SnapshotRepo.EXPECT().SelectLoanCreatedBefore(gomock.Any(), endOfDay, int64(0), 500).Return(&loans, nil)
+				// loan 2 was completed later, on the business date it had one installment paid
+				f.SnapshotRepo.EXPECT().SelectRepaymentCountByLoanIds(gomock.Any(), []int64{1, 2}, endOfDay).Return(map[int64]int{1: 1, 2: 1}, nil)
+				f.SnapshotRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.SnapshotRepo.EXPECT().UpsertLoanDailySnapshot(gomock.Any(), f.Tx, entities.LoanDailySnapshot{
+					BusinessDate:         businessDate,
+					LoanId:               1,
+					LoanReferenceId:      "loan1",
+					UserId:               1,
+					Status:               entities.LoanStatusActive,
+					InstallmentsPaid:     1,
+					PrincipalOutstanding: 1100,
+					InterestOutstanding:  110,
+					DaysPastDue:          13,
+					AgingBucket:          entities.Aging1To30,
+				}).Return(nil)
+				f.SnapshotRepo.EXPECT().UpsertLoanDailySnapshot(gomock.Any(), f.Tx, entities.LoanDailySnapshot{
+					BusinessDate:         businessDate,
+					LoanId:               2,
+					LoanReferenceId:      "loan2",
+					UserId:               2,
+					Status:               entities.LoanStatusActive,
+					InstallmentsPaid:     1,
+					PrincipalOutstanding: 500,
+					InterestOutstanding:  50,
+					DaysPastDue:          0,
+					AgingBucket:          entities.AgingCurrent,
+				}).Return(nil)
+				f.Tx.EXPECT().Commit().Return(nil)
+				f.Tx.EXPECT().Rollback().Return(nil)
+			},
+			want: &entities.SnapshotRunResult{
+				BusinessDate: businessDate,
+				Loans:        2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error business date not ended",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SnapshotRepo: mock_usecase.NewMockSnapshotRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:          context.Background(),
+				businessDate: now,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error upsert snapshot",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SnapshotRepo: mock_usecase.NewMockSnapshotRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:          context.Background(),
+				businessDate: businessDate,
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.SnapshotRepo.EXPECT().SelectLoanCreatedBefore(gomock.Any(), gomock.Any(), int64(0), 500).Return(&loans, nil)
+				f.SnapshotRepo.EXPECT().SelectRepaymentCountByLoanIds(gomock.Any(), []int64{1, 2}, gomock.Any()).Return(map[int64]int{}, nil)
+				f.SnapshotRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.SnapshotRepo.EXPECT().UpsertLoanDailySnapshot(gomock.Any(), f.Tx, gomock.Any()).Return(errors.New("some error"))
+				f.Tx.EXPECT().Rollback().Return(nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := SnapshotUseCase{
+				SnapshotRepo: f.SnapshotRepo,
+				Clock:        f.Clock,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.CreateSnapshot(tt.input.ctx, tt.input.businessDate)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestSnapshotUseCase_BackfillSnapshots(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.SnapshotBackfillRequest
+	}
+	type fields struct {
+		SnapshotRepo *mock_usecase.MockSnapshotRepository
+		Clock        *mock_domain.MockClock
+	}
+	now := time.Date(2000, 3, 15, 8, 0, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *[]entities.SnapshotRunResult
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SnapshotRepo: mock_usecase.NewMockSnapshotRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.SnapshotBackfillRequest{FromDate: "2000-03-01", ToDate: "2000-03-02"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.SnapshotRepo.EXPECT().SelectLoanCreatedBefore(gomock.Any(), time.Date(2000, 3, 2, 0, 0, 0, 0, time.Local), int64(0), 500).Return(&[]entities.Loan{}, nil)
+				f.SnapshotRepo.EXPECT().SelectLoanCreatedBefore(gomock.Any(), time.Date(2000, 3, 3, 0, 0, 0, 0, time.Local), int64(0), 500).Return(&[]entities.Loan{}, nil)
+			},
+			want: &[]entities.SnapshotRunResult{
+				{BusinessDate: time.Date(2000, 3, 1, 0, 0, 0, 0, time.Local)},
+				{BusinessDate: time.Date(2000, 3, 2, 0, 0, 0, 0, time.Local)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error to date before from date",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SnapshotRepo: mock_usecase.NewMockSnapshotRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.SnapshotBackfillRequest{FromDate: "2000-03-02", ToDate: "2000-03-01"},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error to date not ended",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SnapshotRepo: mock_usecase.NewMockSnapshotRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.SnapshotBackfillRequest{FromDate: "2000-03-01", ToDate: "2000-03-15"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error date format",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					SnapshotRepo: mock_usecase.NewMockSnapshotRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.SnapshotBackfillRequest{FromDate: "01-03-2000", ToDate: "2000-03-02"},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := SnapshotUseCase{
+				SnapshotRepo: f.SnapshotRepo,
+				Clock:        f.Clock,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.BackfillSnapshots(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
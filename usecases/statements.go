@@ -0,0 +1,160 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+// GetStatement returns the statement of a user for a calendar month that has
+// ended. The first request generates and stores it, every later request
+// returns the stored statement.
+func (u *StatementUseCase) GetStatement(ctx context.Context, request entities.StatementRequest) (*entities.Statement, error) {
+	if !IsUserValid(request.UserId) {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "user id is invalid")
+	}
+	periodStart, err := time.ParseInLocation(helper.MonthLayout, request.Period, time.Local)
+	if err != nil {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "period must be formatted as "+helper.MonthLayout)
+	}
+	endOfPeriod := periodStart.AddDate(0, 1, 0)
+	if endOfPeriod.After(helper.TruncateToDay(u.Clock.Now())) {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "period has not ended yet")
+	}
+
+	statement, err := u.StatementRepo.SelectStatementByPeriod(ctx, request.UserId, periodStart)
+	if err == nil {
+		return statement, nil
+	}
+	if errs.GetHTTPCode(err) != http.StatusNotFound {
+		return nil, err
+	}
+
+	statement, err = u.buildStatement(ctx, request.UserId, periodStart, endOfPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.StatementRepo.CreateStatement(ctx, nil, *statement)
+	if err != nil {
+		return nil, err
+	}
+
+	// read it back, a statement generated at the same time may have been stored first
+	return u.StatementRepo.SelectStatementByPeriod(ctx, request.UserId, periodStart)
+}
+
+func (u *StatementUseCase) buildStatement(ctx context.Context, userId int64, periodStart, endOfPeriod time.Time) (*entities.Statement, error) {
+	statement := &entities.Statement{
+		UserId:      userId,
+		PeriodStart: periodStart,
+		PeriodEnd:   endOfPeriod.AddDate(0, 0, -1),
+		Totals:      []entities.StatementTotal{},
+		Loans:       []entities.StatementLoan{},
+	}
+
+	loans, err := u.StatementRepo.SelectLoanByUserId(ctx, userId)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+		return statement, nil
+	}
+
+	totalIndex := map[string]int{}
+	for _, loan := range *loans {
+		if loan.Status == entities.LoanStatusRejected || !loan.CreatedAt.Before(endOfPeriod) {
+			continue
+		}
+
+		repayments, err := u.StatementRepo.SelectRepaymentByLoanId(ctx, loan.Id)
+		if err != nil {
+			if errs.GetHTTPCode(err) != http.StatusNotFound {
+				return nil, err
+			}
+			repayments = &[]entities.Repayment{}
+		}
+
+		statementLoan := buildStatementLoan(loan, *repayments, periodStart, endOfPeriod)
+		// loans settled before the period have nothing to show
+		if statementLoan.OpeningBalance == 0 && statementLoan.AmountBooked == 0 && len(statementLoan.Payments) == 0 {
+			continue
+		}
+
+		i, ok := totalIndex[statementLoan.Currency]
+		if !ok {
+			i = len(statement.Totals)
+			totalIndex[statementLoan.Currency] = i
+			statement.Totals = append(statement.Totals, entities.StatementTotal{Currency: statementLoan.Currency})
+		}
+		total := &statement.Totals[i]
+		total.OpeningBalance += statementLoan.OpeningBalance
+		total.AmountBooked += statementLoan.AmountBooked
+		total.AmountDue += statementLoan.AmountDue
+		total.PaymentsTotal += statementLoan.PaymentsTotal
+		total.Fees += statementLoan.Fees
+		total.ClosingBalance += statementLoan.ClosingBalance
+		statement.Loans = append(statement.Loans, statementLoan)
+	}
+
+	return statement, nil
+}
+
+func buildStatementLoan(loan entities.Loan, repayments []entities.Repayment, periodStart, endOfPeriod time.Time) entities.StatementLoan {
+	totalPayable := loan.TotalPayable()
+	statementLoan := entities.StatementLoan{
+		LoanReferenceId:   loan.ReferenceId,
+		Currency:          loan.Currency,
+		RepaymentSchedule: loan.RepaymentSchedule,
+		Payments:          []entities.StatementPayment{},
+	}
+
+	if loan.CreatedAt.Before(periodStart) {
+		statementLoan.OpeningBalance = totalPayable
+	} else {
+		statementLoan.AmountBooked = totalPayable
+	}
+
+	sort.Slice(repayments, func(i, j int) bool {
+		return repayments[i].CreatedAt.Before(repayments[j].CreatedAt)
+	})
+	for _, repayment := range repayments {
+		if repayment.Status == entities.RepaymentReversed {
+			continue
+		}
+		switch {
+		case repayment.CreatedAt.Before(periodStart):
+			statementLoan.OpeningBalance -= repayment.Amount
+		case repayment.CreatedAt.Before(endOfPeriod):
+			statementLoan.PaymentsTotal += repayment.Amount
+			statementLoan.Payments = append(statementLoan.Payments, entities.StatementPayment{
+				ReferenceId: repayment.ReferenceId,
+				Amount:      repayment.Amount,
+				PaidAt:      repayment.CreatedAt,
+			})
+		}
+	}
+
+	for installment := 1; installment <= loan.Tenor; installment++ {
+		dueDate := loan.DueDate(installment)
+		if dueDate.Before(periodStart) {
+			continue
+		}
+		if !dueDate.Before(endOfPeriod) {
+			break
+		}
+		statementLoan.InstallmentsDue++
+		statementLoan.AmountDue += loan.RepaymentAmount
+	}
+
+	// Fees stays zero, the engine does not charge fees yet
+	statementLoan.ClosingBalance = statementLoan.OpeningBalance + statementLoan.AmountBooked +
+		statementLoan.Fees - statementLoan.PaymentsTotal
+
+	return statementLoan
+}
@@ -0,0 +1,235 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+func TestStatementUseCase_GetStatement(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.StatementRequest
+	}
+	type fields struct {
+		StatementRepo *mock_usecase.MockStatementRepository
+		Clock         *mock_domain.MockClock
+	}
+	now := time.Date(2000, 4, 15, 8, 0, 0, 0, time.Local)
+	periodStart := time.Date(2000, 3, 1, 0, 0, 0, 0, time.Local)
+	periodEnd := time.Date(2000, 3, 31, 0, 0, 0, 0, time.Local)
+	loans := []entities.Loan{
+		{
+			Id:                1,
+			ReferenceId:       "loan1",
+			UserId:            1,
+			Currency:          "IDR",
+			Amount:            1200,
+			RatePercentage:    10,
+			Status:            entities.LoanStatusActive,
+			RepaymentSchedule: entities.RepaymentMonthly,
+			Tenor:             12,
+			RepaymentAmount:   110,
+			CreatedAt:         time.Date(2000, 1, 1, 10, 0, 0, 0, time.Local),
+		},
+		{
+			Id:                2,
+			ReferenceId:       "loan2",
+			UserId:            1,
+			Currency:          "USD",
+			Amount:            1000,
+			RatePercentage:    10,
+			Status:            entities.LoanStatusActive,
+			RepaymentSchedule: entities.RepaymentWeekly,
+			Tenor:             2,
+			RepaymentAmount:   550,
+			CreatedAt:         time.Date(2000, 3, 20, 10, 0, 0, 0, time.Local),
+		},
+		{
+			Id:                3,
+			ReferenceId:       "loan3",
+			UserId:            1,
+			Amount:            1000,
+			RatePercentage:    10,
+			Status:            entities.LoanStatusActive,
+			RepaymentSchedule: entities.RepaymentWeekly,
+			Tenor:             2,
+			RepaymentAmount:   550,
+			CreatedAt:         time.Date(2000, 4, 2, 10, 0, 0, 0, time.Local),
+		},
+	}
+	storedStatement := &entities.Statement{Id: 1, UserId: 1, PeriodStart: periodStart, PeriodEnd: periodEnd}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.Statement
+		wantErr bool
+	}{
+		{
+			name: "success generate",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					StatementRepo: mock_usecase.NewMockStatementRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.StatementRequest{UserId: 1, Period: "2000-03"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.StatementRepo.EXPECT().SelectStatementByPeriod(gomock.Any(), int64(1), periodStart).Return(nil, errs.NewWithMessage(404, "not found"))
+				f.StatementRepo.EXPECT().SelectLoanByUserId(gomock.Any(), int64(1)).Return(&loans, nil)
+				f.StatementRepo.EXPECT().SelectRepaymentByLoanId(gomock.Any(), int64(1)).Return(&[]entities.Repayment{
+					{ReferenceId: "repay2", Amount: 110, CreatedAt: time.Date(2000, 3, 2, 9, 0, 0, 0, time.Local)},
+					{ReferenceId: "repay1", Amount: 110, CreatedAt: time.Date(2000, 2, 1, 9, 0, 0, 0, time.Local)},
+				}, nil)
+				f.StatementRepo.EXPECT().SelectRepaymentByLoanId(gomock.Any(), int64(2)).Return(nil, errs.NewWithMessage(404, "not found"))
+				f.StatementRepo.EXPECT().CreateStatement(gomock.Any(), nil, entities.Statement{
+					UserId:      1,
+					PeriodStart: periodStart,
+					PeriodEnd:   periodEnd,
+					Totals: []entities.StatementTotal{
+						{Currency: "IDR", OpeningBalance: 1210, AmountDue: 110, PaymentsTotal: 110, ClosingBalance: 1100},
+						{Currency: "USD", AmountBooked: 1100, AmountDue: 550, ClosingBalance: 1100},
+					},
+					Loans: []entities.StatementLoan{
+						{
+							LoanReferenceId:   "loan1",
+							Currency:          "IDR",
+							RepaymentSchedule: entities.RepaymentMonthly,
+							OpeningBalance:    1210,
+							InstallmentsDue:   1,
+							AmountDue:         110,
+							PaymentsTotal:     110,
+							ClosingBalance:    1100,
+							Payments: []entities.StatementPayment{
+								{ReferenceId: "repay2", Amount: 110, PaidAt: time.Date(2000, 3, 2, 9, 0, 0, 0, time.Local)},
+							},
+						},
+						{
+							LoanReferenceId:   "loan2",
+							Currency:          "USD",
+							RepaymentSchedule: entities.RepaymentWeekly,
+							AmountBooked:      1100,
+							InstallmentsDue:   1,
+							AmountDue:         550,
+							ClosingBalance:    1100,
+							Payments:          []entities.StatementPayment{},
+						},
+					},
+				}).Return(nil)
+				f.StatementRepo.EXPECT().SelectStatementByPeriod(gomock.Any(), int64(1), periodStart).Return(storedStatement, nil)
+			},
+			want:    storedStatement,
+			wantErr: false,
+		},
+		{
+			name: "success stored",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					StatementRepo: mock_usecase.NewMockStatementRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.StatementRequest{UserId: 1, Period: "2000-03"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.StatementRepo.EXPECT().SelectStatementByPeriod(gomock.Any(), int64(1), periodStart).Return(storedStatement, nil)
+			},
+			want:    storedStatement,
+			wantErr: false,
+		},
+		{
+			name: "error period not ended",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					StatementRepo: mock_usecase.NewMockStatementRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.StatementRequest{UserId: 1, Period: "2000-04"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error period format",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					StatementRepo: mock_usecase.NewMockStatementRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.StatementRequest{UserId: 1, Period: "03-2000"},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error create statement",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					StatementRepo: mock_usecase.NewMockStatementRepository(ctrl),
+					Clock:         mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.StatementRequest{UserId: 1, Period: "2000-03"},
+			},
+			mock: func(f fields, args input) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.StatementRepo.EXPECT().SelectStatementByPeriod(gomock.Any(), int64(1), periodStart).Return(nil, errs.NewWithMessage(404, "not found"))
+				f.StatementRepo.EXPECT().SelectLoanByUserId(gomock.Any(), int64(1)).Return(nil, errs.NewWithMessage(404, "not found"))
+				f.StatementRepo.EXPECT().CreateStatement(gomock.Any(), nil, gomock.Any()).Return(errors.New("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := StatementUseCase{
+				StatementRepo: f.StatementRepo,
+				Clock:         f.Clock,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.GetStatement(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+// CreateTenant registers a lending partner, its clients are created with the
+// apiclient command
+func (u *TenantUseCase) CreateTenant(ctx context.Context, code string, settings entities.TenantSettingsRequest) (*entities.Tenant, error) {
+	code = strings.TrimSpace(code)
+	errMessage := validateTenantSettings(&settings)
+	if code == "" {
+		errMessage = append([]string{"code can not be empty"}, errMessage...)
+	}
+	if len(errMessage) != 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	tenant := entities.Tenant{
+		Code:                  code,
+		Name:                  settings.Name,
+		Timezone:              settings.Timezone,
+		Currency:              settings.Currency,
+		DelinquentAfterMissed: settings.DelinquentAfterMissed,
+	}
+	var err error
+	tenant.Id, err = u.TenantRepo.CreateTenant(ctx, nil, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenant, nil
+}
+
+// GetCurrentTenant returns the tenant of the request, or the default tenant
+// outside of a request
+func (u *TenantUseCase) GetCurrentTenant(ctx context.Context) (*entities.Tenant, error) {
+	tenantId := helper.GetTenantId(ctx)
+	if tenantId == 0 {
+		tenant := entities.DefaultTenant
+		return &tenant, nil
+	}
+
+	return u.TenantRepo.SelectTenantById(ctx, tenantId)
+}
+
+// GetTenantList returns every tenant, the default tenant first
+func (u *TenantUseCase) GetTenantList(ctx context.Context) (*[]entities.Tenant, error) {
+	return u.TenantRepo.SelectTenants(ctx)
+}
+
+// UpdateSettings replaces the settings of the tenant of the request
+func (u *TenantUseCase) UpdateSettings(ctx context.Context, settings entities.TenantSettingsRequest) (*entities.Tenant, error) {
+	if errMessage := validateTenantSettings(&settings); len(errMessage) != 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	tenantId := helper.GetTenantId(ctx)
+	if tenantId == 0 {
+		tenantId = entities.DefaultTenantId
+	}
+	tenant, err := u.TenantRepo.SelectTenantById(ctx, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant.Name = settings.Name
+	tenant.Timezone = settings.Timezone
+	tenant.Currency = settings.Currency
+	tenant.DelinquentAfterMissed = settings.DelinquentAfterMissed
+
+	err = u.TenantRepo.UpdateTenantSettings(ctx, nil, *tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	return tenant, nil
+}
+
+func validateTenantSettings(settings *entities.TenantSettingsRequest) []string {
+	var errMessage []string
+
+	settings.Name = strings.TrimSpace(settings.Name)
+	if settings.Name == "" {
+		errMessage = append(errMessage, "name can not be empty")
+	}
+	if _, err := time.LoadLocation(settings.Timezone); settings.Timezone == "" || err != nil {
+		errMessage = append(errMessage, "timezone is invalid")
+	}
+	settings.Currency = entities.NormalizeCurrency(settings.Currency)
+	if !entities.IsValidCurrency(settings.Currency) {
+		errMessage = append(errMessage, "currency is not supported")
+	}
+	if settings.DelinquentAfterMissed < 1 {
+		errMessage = append(errMessage, "delinquent_after_missed must be at least 1")
+	}
+
+	return errMessage
+}
+
+// tenantOf returns the tenant of the request from the provider, or the
+// default tenant when there is no provider
+func tenantOf(ctx context.Context, tenants TenantProvider) (*entities.Tenant, error) {
+	if tenants == nil {
+		tenant := entities.DefaultTenant
+		return &tenant, nil
+	}
+	return tenants.GetCurrentTenant(ctx)
+}
+
+// todayOf returns the business date it is at now in the timezone of the
+// tenant of the request
+func todayOf(ctx context.Context, tenants TenantProvider, now time.Time) (time.Time, error) {
+	tenant, err := tenantOf(ctx, tenants)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return helper.TruncateToDay(now.In(tenant.Location())), nil
+}
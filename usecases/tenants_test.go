@@ -0,0 +1,288 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func TestTenantUseCase_GetCurrentTenant(t *testing.T) {
+	type input struct {
+		ctx context.Context
+	}
+	type fields struct {
+		TenantRepo *mock_usecase.MockTenantRepository
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "success tenant of the request",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					TenantRepo: mock_usecase.NewMockTenantRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: helper.WithTenantId(context.Background(), 7),
+			},
+			mock: func(f fields, args input) {
+				f.TenantRepo.EXPECT().SelectTenantById(gomock.Any(), int64(7)).Return(&entities.Tenant{Id: 7}, nil)
+			},
+			want:    7,
+			wantErr: false,
+		},
+		{
+			name: "success default tenant outside of a request",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					TenantRepo: mock_usecase.NewMockTenantRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    entities.DefaultTenantId,
+			wantErr: false,
+		},
+		{
+			name: "error select tenant",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					TenantRepo: mock_usecase.NewMockTenantRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: helper.WithTenantId(context.Background(), 7),
+			},
+			mock: func(f fields, args input) {
+				f.TenantRepo.EXPECT().SelectTenantById(gomock.Any(), int64(7)).Return(nil, errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := TenantUseCase{
+				TenantRepo: f.TenantRepo,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.GetCurrentTenant(tt.input.ctx)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got.Id)
+		})
+	}
+}
+
+func TestTenantUseCase_UpdateSettings(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.TenantSettingsRequest
+	}
+	type fields struct {
+		TenantRepo *mock_usecase.MockTenantRepository
+	}
+	tests := []struct {
+		name     string
+		fields   func(ctrl *gomock.Controller) fields
+		input    input
+		mock     func(f fields, input input)
+		wantCode int
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					TenantRepo: mock_usecase.NewMockTenantRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: helper.WithTenantId(context.Background(), 7),
+				param: entities.TenantSettingsRequest{
+					Name:                  " Partner ",
+					Timezone:              "Asia/Makassar",
+					Currency:              "usd",
+					DelinquentAfterMissed: 3,
+				},
+			},
+			mock: func(f fields, args input) {
+				f.TenantRepo.EXPECT().SelectTenantById(gomock.Any(), int64(7)).Return(&entities.Tenant{Id: 7, Code: "partner"}, nil)
+				f.TenantRepo.EXPECT().UpdateTenantSettings(gomock.Any(), nil, entities.Tenant{
+					Id:                    7,
+					Code:                  "partner",
+					Name:                  "Partner",
+					Timezone:              "Asia/Makassar",
+					Currency:              "USD",
+					DelinquentAfterMissed: 3,
+				}).Return(nil)
+			},
+			wantCode: 0,
+		},
+		{
+			name: "error invalid settings",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					TenantRepo: mock_usecase.NewMockTenantRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: helper.WithTenantId(context.Background(), 7),
+				param: entities.TenantSettingsRequest{
+					Name:     "Partner",
+					Timezone: "Mars/Olympus",
+					Currency: "rupiah",
+				},
+			},
+			mock: func(f fields, args input) {
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error tenant not found",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					TenantRepo: mock_usecase.NewMockTenantRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx: helper.WithTenantId(context.Background(), 7),
+				param: entities.TenantSettingsRequest{
+					Name:                  "Partner",
+					Timezone:              "Asia/Jakarta",
+					Currency:              "IDR",
+					DelinquentAfterMissed: 2,
+				},
+			},
+			mock: func(f fields, args input) {
+				f.TenantRepo.EXPECT().SelectTenantById(gomock.Any(), int64(7)).
+					Return(nil, errs.NewWithMessage(http.StatusNotFound, "not found"))
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := TenantUseCase{
+				TenantRepo: f.TenantRepo,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.UpdateSettings(tt.input.ctx, tt.input.param)
+			if tt.wantCode != 0 {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantCode, errs.GetHTTPCode(err))
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, "USD", got.Currency)
+		})
+	}
+}
+
+func TestTenantUseCase_CreateTenant(t *testing.T) {
+	type input struct {
+		ctx      context.Context
+		code     string
+		settings entities.TenantSettingsRequest
+	}
+	type fields struct {
+		TenantRepo *mock_usecase.MockTenantRepository
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					TenantRepo: mock_usecase.NewMockTenantRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:  context.Background(),
+				code: "partner",
+				settings: entities.TenantSettingsRequest{
+					Name:                  "Partner",
+					Timezone:              "Asia/Jakarta",
+					Currency:              "IDR",
+					DelinquentAfterMissed: 2,
+				},
+			},
+			mock: func(f fields, args input) {
+				f.TenantRepo.EXPECT().CreateTenant(gomock.Any(), nil, gomock.Any()).Return(int64(2), nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error empty code",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					TenantRepo: mock_usecase.NewMockTenantRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:  context.Background(),
+				code: " ",
+				settings: entities.TenantSettingsRequest{
+					Name:                  "Partner",
+					Timezone:              "Asia/Jakarta",
+					Currency:              "IDR",
+					DelinquentAfterMissed: 2,
+				},
+			},
+			mock: func(f fields, args input) {
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := TenantUseCase{
+				TenantRepo: f.TenantRepo,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.CreateTenant(tt.input.ctx, tt.input.code, tt.input.settings)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, int64(2), got.Id)
+		})
+	}
+}
@@ -3,36 +3,16 @@ package usecases
 import (
 	"context"
 	"net/http"
-	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/sirait-kevin/BillingEngine/domain/entities"
 	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
 )
 
 func (u *BillingUseCase) CreateLoan(ctx context.Context, loanRequest entities.LoanRequest) (int64, error) {
-	var errMessage []string
-
-	if loanRequest.ReferenceId == "" {
-		errMessage = append(errMessage, "Reference Id is required")
-	}
-	if !IsUserValid(loanRequest.UserId) {
-		errMessage = append(errMessage, "UserId is invalid")
-	}
-	if loanRequest.Amount < 1 {
-		errMessage = append(errMessage, "Amount is required")
-	}
-	if loanRequest.RatePercentage < 0 {
-		errMessage = append(errMessage, "Rate percentage is required")
-	}
-	if !loanRequest.RepaymentSchedule.IsValid() {
-		errMessage = append(errMessage, "Repayment schedule is invalid")
-	}
-	if loanRequest.Tenor < 1 {
-		errMessage = append(errMessage, "Tenor is required")
-	}
-
+	errMessage := validateLoanRequest(loanRequest)
 	if errMessage != nil || len(errMessage) != 0 {
 		return 0, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, ","))
 	}
@@ -53,25 +33,95 @@ func (u *BillingUseCase) CreateLoan(ctx context.Context, loanRequest entities.Lo
 		return 0, errs.NewWithMessage(http.StatusForbidden, "User is delinquent")
 	}
 
-	repaymentAmount := (loanRequest.Amount + (loanRequest.Amount * int64(loanRequest.RatePercentage) / 100)) / int64(loanRequest.Tenor)
+	currency, err := loanCurrencyOf(ctx, u.Tenants, loanRequest.Currency)
+	if err != nil {
+		return 0, err
+	}
 
 	loanId, err := u.DBRepo.CreateLoan(ctx, nil, entities.Loan{
 		ReferenceId:       loanRequest.ReferenceId,
 		UserId:            loanRequest.UserId,
 		Amount:            loanRequest.Amount,
+		Currency:          currency,
 		RatePercentage:    loanRequest.RatePercentage,
 		Status:            entities.LoanStatusActive,
 		RepaymentSchedule: loanRequest.RepaymentSchedule,
 		Tenor:             loanRequest.Tenor,
-		RepaymentAmount:   repaymentAmount,
+		RepaymentAmount:   repaymentAmountOf(loanRequest),
+		Borrower: entities.Borrower{
+			Name:        strings.TrimSpace(loanRequest.Borrower.Name),
+			NationalId:  strings.TrimSpace(loanRequest.Borrower.NationalId),
+			BankAccount: strings.TrimSpace(loanRequest.Borrower.BankAccount),
+		},
 	})
 	if err != nil {
 		return 0, err
 	}
+
+	u.publishEvent(ctx, helper.GetTenantId(ctx), entities.EventLoanCreated, entities.LoanEventData{
+		LoanId:          loanId,
+		LoanReferenceId: loanRequest.ReferenceId,
+		UserId:          loanRequest.UserId,
+		Amount:          entities.NewMoney(loanRequest.Amount, currency),
+		Status:          entities.LoanStatusActive.String(),
+	})
+
 	return loanId, nil
 
 }
 
+// validateLoanRequest holds the rules every new loan must follow, however it is created
+func validateLoanRequest(loanRequest entities.LoanRequest) []string {
+	var errMessage []string
+
+	if loanRequest.ReferenceId == "" {
+		errMessage = append(errMessage, "Reference Id is required")
+	}
+	if !IsUserValid(loanRequest.UserId) {
+		errMessage = append(errMessage, "UserId is invalid")
+	}
+	if loanRequest.Amount < 1 {
+		errMessage = append(errMessage, "Amount is required")
+	}
+	if loanRequest.RatePercentage < 0 {
+		errMessage = append(errMessage, "Rate percentage is required")
+	}
+	if !loanRequest.RepaymentSchedule.IsValid() {
+		errMessage = append(errMessage, "Repayment schedule is invalid")
+	}
+	if loanRequest.Tenor < 1 {
+		errMessage = append(errMessage, "Tenor is required")
+	}
+	if loanRequest.Currency != "" && !entities.IsValidCurrency(entities.NormalizeCurrency(loanRequest.Currency)) {
+		errMessage = append(errMessage, "Currency is not supported")
+	}
+
+	return errMessage
+}
+
+// loanCurrencyOf is the currency a new loan is booked in: the requested one,
+// or else the currency of the tenant
+func loanCurrencyOf(ctx context.Context, tenants TenantProvider, requested string) (string, error) {
+	if currency := entities.NormalizeCurrency(requested); currency != "" {
+		return currency, nil
+	}
+	tenant, err := tenantOf(ctx, tenants)
+	if err != nil {
+		return "", err
+	}
+
+	return tenant.Currency, nil
+}
+
+func repaymentAmountOf(loanRequest entities.LoanRequest) int64 {
+	return installmentAmountOf(loanRequest.Amount, loanRequest.RatePercentage, loanRequest.Tenor)
+}
+
+// installmentAmountOf spreads the principal and its flat interest evenly over the tenor
+func installmentAmountOf(principal int64, ratePercentage, tenor int) int64 {
+	return (principal + (principal * int64(ratePercentage) / 100)) / int64(tenor)
+}
+
 func (u *BillingUseCase) GetPaymentHistoryByReferenceID(ctx context.Context, referenceId string) (*entities.LoanHistory, error) {
 
 	if referenceId == "" {
@@ -88,12 +138,18 @@ func (u *BillingUseCase) GetPaymentHistoryByReferenceID(ctx context.Context, ref
 		if errs.GetHTTPCode(err) != http.StatusNotFound {
 			return nil, err
 		}
+		repayments = &[]entities.Repayment{}
 	}
 
-	return &entities.LoanHistory{
-		Loan:       *loan,
-		Repayments: *repayments,
-	}, nil
+	history := &entities.LoanHistory{
+		Loan:       loan.Response(),
+		Repayments: make([]entities.RepaymentResponse, len(*repayments)),
+	}
+	for i, repayment := range *repayments {
+		history.Repayments[i] = repayment.Response()
+	}
+
+	return history, nil
 }
 
 func (u *BillingUseCase) GetOutStandingAmountByReferenceID(ctx context.Context, referenceId string) (*entities.OutStanding, error) {
@@ -111,12 +167,10 @@ func (u *BillingUseCase) GetOutStandingAmountByReferenceID(ctx context.Context,
 		}
 	}
 
-	totalLoan := loan.RepaymentAmount * int64(loan.Tenor)
-
 	return &entities.OutStanding{
 		LoanId:            loan.Id,
 		LoanReferenceId:   loan.ReferenceId,
-		OutstandingAmount: totalLoan - totalRepayments,
+		OutstandingAmount: loan.Money(loan.TotalPayable() - totalRepayments),
 	}, nil
 }
 
@@ -133,6 +187,16 @@ func (u *BillingUseCase) GetUserStatusIsDelinquent(ctx context.Context, userId i
 			return false, nil
 		}
 	}
+
+	// a written off loan no longer makes its user delinquent
+	activeLoans := []entities.Loan{}
+	for _, loan := range *loans {
+		if loan.Status != entities.LoanStatusWrittenOff {
+			activeLoans = append(activeLoans, loan)
+		}
+	}
+	loans = &activeLoans
+
 	repaymentCounts := map[int64]int{}
 	errWg := make([]error, len(*loans))
 
@@ -157,9 +221,13 @@ func (u *BillingUseCase) GetUserStatusIsDelinquent(ctx context.Context, userId i
 		}
 	}
 
+	tenant, err := tenantOf(ctx, u.Tenants)
+	if err != nil {
+		return false, err
+	}
 	for _, loan := range *loans {
 		if loan.Tenor > repaymentCounts[loan.Id] {
-			if entities.MissRepayment(loan.CreatedAt, u.Clock.Now(), repaymentCounts[loan.Id], loan.RepaymentSchedule) > 1 {
+			if loan.MissedInstallments(u.Clock.Now(), repaymentCounts[loan.Id]) >= tenant.DelinquentAfterMissed {
 				return true, nil
 			}
 		}
@@ -168,6 +236,14 @@ func (u *BillingUseCase) GetUserStatusIsDelinquent(ctx context.Context, userId i
 	return false, nil
 }
 
+func (u *BillingUseCase) GetOpenPromiseToPayListByUserId(ctx context.Context, userId int64) (*[]entities.PromiseToPay, error) {
+	if !IsUserValid(userId) {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "user id is invalid")
+	}
+
+	return u.DBRepo.SelectOpenPromiseToPayByUserId(ctx, userId)
+}
+
 func (u *BillingUseCase) GetRepaymentInquiryByLoanReferenceId(ctx context.Context, referenceId string) (*entities.RepaymentInquiry, error) {
 	if referenceId == "" {
 		return nil, errs.NewWithMessage(http.StatusBadRequest, "reference id can not be empty")
@@ -185,27 +261,29 @@ func (u *BillingUseCase) GetRepaymentInquiryByLoanReferenceId(ctx context.Contex
 		}
 	}
 
-	missRepaymentCount := entities.MissRepayment(loan.CreatedAt, u.Clock.Now(), repaymentCount, loan.RepaymentSchedule)
+	// nothing is due on a written off loan, money collected on it is a recovery
+	var missRepaymentCount int
+	if loan.Status != entities.LoanStatusWrittenOff {
+		missRepaymentCount = loan.MissedInstallments(u.Clock.Now(), repaymentCount)
+	}
 
 	needRepayments := []entities.RepaymentNeeded{}
 	for i := 0; i < missRepaymentCount; i++ {
-		addTime := i + repaymentCount + 1
-		dueDate := entities.AddTime(loan.CreatedAt, addTime, loan.RepaymentSchedule)
+		dueDate := loan.DueDate(i + repaymentCount + 1)
 		isLate := u.Clock.Now().After(dueDate)
 
 		needRepayments = append(needRepayments, entities.RepaymentNeeded{
-			Amount:  loan.RepaymentAmount,
+			Amount:  loan.Money(loan.RepaymentAmount),
 			DueDate: dueDate,
 			IsLate:  isLate,
 		})
 	}
 
 	if len(needRepayments) == 0 && loan.Status.IsActive() {
-		addTime := repaymentCount + 1
-		dueDate := entities.AddTime(loan.CreatedAt, addTime, loan.RepaymentSchedule)
+		dueDate := loan.DueDate(repaymentCount + 1)
 		isLate := u.Clock.Now().After(dueDate)
 		needRepayments = append(needRepayments, entities.RepaymentNeeded{
-			Amount:  loan.RepaymentAmount,
+			Amount:  loan.Money(loan.RepaymentAmount),
 			DueDate: dueDate,
 			IsLate:  isLate,
 		})
@@ -265,8 +343,14 @@ func (u *BillingUseCase) MakePayment(ctx context.Context, repaymentRequest entit
 		}
 	}
 
+	// a payment without a currency is in the currency of the loan
+	if currency := entities.NormalizeCurrency(repaymentRequest.Currency); currency != "" && currency != loan.Currency {
+		return 0, errs.NewWithMessage(http.StatusBadRequest,
+			"payment currency "+currency+" does not match the loan currency "+loan.Currency)
+	}
 	if loan.RepaymentAmount != repaymentRequest.Amount {
-		return 0, errs.NewWithMessage(http.StatusBadRequest, "payment amount is invalid, expected: "+strconv.FormatInt(loan.RepaymentAmount, 10))
+		return 0, errs.NewWithMessage(http.StatusBadRequest,
+			"payment amount is invalid, expected: "+loan.Money(loan.RepaymentAmount).String())
 	}
 
 	dbTx, err := u.DBRepo.BeginTx(ctx)
@@ -276,15 +360,24 @@ func (u *BillingUseCase) MakePayment(ctx context.Context, repaymentRequest entit
 	defer dbTx.Rollback()
 
 	repaymentId, err = u.DBRepo.CreateRepayment(ctx, dbTx, entities.Repayment{
+		TenantId:    loan.TenantId,
 		LoanId:      loan.Id,
 		ReferenceId: repaymentRequest.RepaymentReferenceId,
 		Amount:      repaymentRequest.Amount,
+		Currency:    loan.Currency,
+		CreatedAt:   repaymentRequest.PaidAt,
 	})
 	if err != nil {
 		return 0, err
 	}
 
-	if repaymentTotalAmount+repaymentRequest.Amount >= loan.RepaymentAmount*int64(loan.Tenor) {
+	err = u.issueReceipt(ctx, dbTx, *loan, repaymentId, repaymentRequest.RepaymentReferenceId, repaymentTotalAmount)
+	if err != nil {
+		return 0, err
+	}
+
+	isCompleted := repaymentTotalAmount+repaymentRequest.Amount >= loan.TotalPayable()
+	if isCompleted {
 		err = u.DBRepo.UpdateLoanStatusByReferenceId(ctx, dbTx, loan.ReferenceId, entities.LoanStatusCompleted)
 		if err != nil {
 			return 0, err
@@ -296,6 +389,23 @@ func (u *BillingUseCase) MakePayment(ctx context.Context, repaymentRequest entit
 		return 0, err
 	}
 
+	u.publishEvent(ctx, loan.TenantId, entities.EventPaymentReceived, entities.PaymentEventData{
+		LoanId:               loan.Id,
+		LoanReferenceId:      loan.ReferenceId,
+		RepaymentId:          repaymentId,
+		RepaymentReferenceId: repaymentRequest.RepaymentReferenceId,
+		Amount:               loan.Money(repaymentRequest.Amount),
+	})
+	if isCompleted {
+		u.publishEvent(ctx, loan.TenantId, entities.EventLoanCompleted, entities.LoanEventData{
+			LoanId:          loan.Id,
+			LoanReferenceId: loan.ReferenceId,
+			UserId:          loan.UserId,
+			Amount:          loan.Money(loan.Amount),
+			Status:          entities.LoanStatusCompleted.String(),
+		})
+	}
+
 	return repaymentId, nil
 }
 
@@ -314,6 +424,10 @@ func (u *BillingUseCase) GetLoanListByUserId(ctx context.Context, userId int64)
 	return loans, err
 }
 
+func (u *BillingUseCase) publishEvent(ctx context.Context, tenantId int64, eventType string, data interface{}) {
+	publishEvent(ctx, u.Events, u.Clock, tenantId, eventType, data)
+}
+
 func IsUserValid(userId int64) bool {
 	if userId < 1 {
 		return false
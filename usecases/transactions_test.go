@@ -61,6 +61,7 @@ func TestBillingUseCase_CreateLoan(t *testing.T) {
 					ReferenceId:       args.param.ReferenceId,
 					UserId:            args.param.UserId,
 					Amount:            args.param.Amount,
+					Currency:          entities.DefaultCurrency,
 					RatePercentage:    args.param.RatePercentage,
 					Status:            entities.LoanStatusActive,
 					RepaymentSchedule: args.param.RepaymentSchedule,
@@ -72,6 +73,116 @@ func TestBillingUseCase_CreateLoan(t *testing.T) {
 			want:    1,
 			wantErr: false,
 		},
+		{
+			name: "success requested currency",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DBRepo: mock_usecase.NewMockDBRepository(ctrl),
+					Clock:  mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.LoanRequest{
+					ReferenceId:       "1",
+					UserId:            1,
+					Amount:            100000,
+					RatePercentage:    10,
+					RepaymentSchedule: "weekly",
+					Tenor:             10,
+					Currency:          "usd",
+				},
+			},
+			mock: func(f fields, args input) {
+				f.DBRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), args.param.ReferenceId).Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+				f.DBRepo.EXPECT().SelectLoanByUserId(gomock.Any(), args.param.UserId).Return(&[]entities.Loan{}, nil)
+				f.DBRepo.EXPECT().CreateLoan(gomock.Any(), nil, entities.Loan{
+					ReferenceId:       args.param.ReferenceId,
+					UserId:            args.param.UserId,
+					Amount:            args.param.Amount,
+					Currency:          "USD",
+					RatePercentage:    args.param.RatePercentage,
+					Status:            entities.LoanStatusActive,
+					RepaymentSchedule: args.param.RepaymentSchedule,
+					Tenor:             args.param.Tenor,
+					RepaymentAmount:   11000,
+				}).Return(int64(1), nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "success with borrower",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DBRepo: mock_usecase.NewMockDBRepository(ctrl),
+					Clock:  mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.LoanRequest{
+					ReferenceId:       "1",
+					UserId:            1,
+					Amount:            100000,
+					RatePercentage:    10,
+					RepaymentSchedule: "weekly",
+					Tenor:             10,
+					Borrower: entities.Borrower{
+						Name:        " Budi Santoso ",
+						NationalId:  "3171234567890001",
+						BankAccount: "1234567890",
+					},
+				},
+			},
+			mock: func(f fields, args input) {
+				f.DBRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), args.param.ReferenceId).Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+				f.DBRepo.EXPECT().SelectLoanByUserId(gomock.Any(), args.param.UserId).Return(&[]entities.Loan{}, nil)
+				f.DBRepo.EXPECT().CreateLoan(gomock.Any(), nil, entities.Loan{
+					ReferenceId:       args.param.ReferenceId,
+					UserId:            args.param.UserId,
+					Amount:            args.param.Amount,
+					Currency:          entities.DefaultCurrency,
+					RatePercentage:    args.param.RatePercentage,
+					Status:            entities.LoanStatusActive,
+					RepaymentSchedule: args.param.RepaymentSchedule,
+					Tenor:             args.param.Tenor,
+					RepaymentAmount:   11000,
+					Borrower: entities.Borrower{
+						Name:        "Budi Santoso",
+						NationalId:  "3171234567890001",
+						BankAccount: "1234567890",
+					},
+				}).Return(int64(1), nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "error currency not supported",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DBRepo: mock_usecase.NewMockDBRepository(ctrl),
+					Clock:  mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.LoanRequest{
+					ReferenceId:       "1",
+					UserId:            1,
+					Amount:            1,
+					RatePercentage:    1,
+					RepaymentSchedule: "weekly",
+					Tenor:             1,
+					Currency:          "XYZ",
+				},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    0,
+			wantErr: true,
+		},
 		{
 			name: "error parameter",
 			fields: func(ctrl *gomock.Controller) fields {
@@ -237,6 +348,7 @@ func TestBillingUseCase_CreateLoan(t *testing.T) {
 					ReferenceId:       args.param.ReferenceId,
 					UserId:            args.param.UserId,
 					Amount:            args.param.Amount,
+					Currency:          entities.DefaultCurrency,
 					RatePercentage:    args.param.RatePercentage,
 					Status:            entities.LoanStatusActive,
 					RepaymentSchedule: args.param.RepaymentSchedule,
@@ -305,9 +417,10 @@ func TestBillingUseCase_GetPaymentHistoryByReferenceID(t *testing.T) {
 					Id:                1,
 					ReferenceId:       "",
 					UserId:            0,
-					Amount:            0,
+					Amount:            1000,
+					Currency:          "IDR",
 					RatePercentage:    0,
-					Status:            0,
+					Status:            entities.LoanStatusActive,
 					RepaymentSchedule: "",
 					Tenor:             0,
 					RepaymentAmount:   0,
@@ -319,7 +432,8 @@ func TestBillingUseCase_GetPaymentHistoryByReferenceID(t *testing.T) {
 						Id:          0,
 						LoanId:      0,
 						ReferenceId: "",
-						Amount:      0,
+						Amount:      100,
+						Currency:    "IDR",
 						CreatedAt:   time.Time{},
 						UpdatedAt:   time.Time{},
 					},
@@ -327,11 +441,14 @@ func TestBillingUseCase_GetPaymentHistoryByReferenceID(t *testing.T) {
 
 			},
 			want: &entities.LoanHistory{
-				Loan: entities.Loan{
-					Id: 1,
+				Loan: entities.LoanResponse{
+					Id:              1,
+					Amount:          entities.NewMoney(1000, "IDR"),
+					Status:          "active",
+					RepaymentAmount: entities.NewMoney(0, "IDR"),
 				},
-				Repayments: []entities.Repayment{
-					{},
+				Repayments: []entities.RepaymentResponse{
+					{Amount: entities.NewMoney(100, "IDR")},
 				},
 			},
 			wantErr: false,
@@ -484,6 +601,7 @@ func TestBillingUseCase_GetOutStandingAmountByReferenceID(t *testing.T) {
 					ReferenceId:       "",
 					UserId:            0,
 					Amount:            1000,
+					Currency:          "IDR",
 					RatePercentage:    0,
 					Status:            0,
 					RepaymentSchedule: "",
@@ -498,7 +616,7 @@ func TestBillingUseCase_GetOutStandingAmountByReferenceID(t *testing.T) {
 			want: &entities.OutStanding{
 				LoanId:            1,
 				LoanReferenceId:   "",
-				OutstandingAmount: 1000,
+				OutstandingAmount: entities.NewMoney(1000, "IDR"),
 			},
 			wantErr: false,
 		},
@@ -610,8 +728,9 @@ func TestBillingUseCase_GetUserStatusIsDelinquent(t *testing.T) {
 		param int64
 	}
 	type fields struct {
-		DBRepo *mock_usecase.MockDBRepository
-		Clock  *mock_domain.MockClock
+		DBRepo  *mock_usecase.MockDBRepository
+		Clock   *mock_domain.MockClock
+		Tenants *mock_usecase.MockTenantProvider
 	}
 	tests := []struct {
 		name    string
@@ -655,6 +774,67 @@ func TestBillingUseCase_GetUserStatusIsDelinquent(t *testing.T) {
 			want:    false,
 			wantErr: false,
 		},
+		{
+			name: "success deferred loan is not delinquent",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DBRepo: mock_usecase.NewMockDBRepository(ctrl),
+					Clock:  mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: 1,
+			},
+			mock: func(f fields, args input) {
+				f.DBRepo.EXPECT().SelectLoanByUserId(gomock.Any(), args.param).Return(&[]entities.Loan{
+					{
+						Id:                 1,
+						Amount:             1200,
+						Status:             entities.LoanStatusActive,
+						RepaymentSchedule:  entities.RepaymentMonthly,
+						Tenor:              12,
+						RepaymentAmount:    100,
+						ScheduleVersion:    2,
+						ScheduleStartAt:    time.Date(2000, 8, 1, 0, 0, 0, 0, time.UTC),
+						InstallmentsOffset: 2,
+						PaidOffset:         200,
+						CreatedAt:          time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+				}, nil)
+				f.DBRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(int(2), nil)
+				f.Clock.EXPECT().Now().Return(time.Date(2000, 6, 15, 0, 0, 0, 0, time.UTC))
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "success written off loan is not delinquent",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DBRepo: mock_usecase.NewMockDBRepository(ctrl),
+					Clock:  mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: 1,
+			},
+			mock: func(f fields, args input) {
+				f.DBRepo.EXPECT().SelectLoanByUserId(gomock.Any(), args.param).Return(&[]entities.Loan{
+					{
+						Id:                1,
+						Amount:            1000,
+						Status:            entities.LoanStatusWrittenOff,
+						RepaymentSchedule: entities.RepaymentMonthly,
+						Tenor:             2,
+						CreatedAt:         time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+				}, nil)
+			},
+			want:    false,
+			wantErr: false,
+		},
 		{
 			name: "error parameter",
 			fields: func(ctrl *gomock.Controller) fields {
@@ -789,6 +969,36 @@ func TestBillingUseCase_GetUserStatusIsDelinquent(t *testing.T) {
 			want:    true,
 			wantErr: false,
 		},
+		{
+			name: "success tenant allows more missed installments",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DBRepo:  mock_usecase.NewMockDBRepository(ctrl),
+					Clock:   mock_domain.NewMockClock(ctrl),
+					Tenants: mock_usecase.NewMockTenantProvider(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: 1,
+			},
+			mock: func(f fields, args input) {
+				f.DBRepo.EXPECT().SelectLoanByUserId(gomock.Any(), args.param).Return(&[]entities.Loan{
+					{
+						Id:                1,
+						Amount:            1000,
+						RepaymentSchedule: entities.RepaymentMonthly,
+						Tenor:             2,
+						CreatedAt:         time.Date(2000, 11, 1, 0, 0, 0, 0, time.UTC),
+					},
+				}, nil)
+				f.DBRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(int(0), nil)
+				f.Tenants.EXPECT().GetCurrentTenant(gomock.Any()).Return(&entities.Tenant{Id: 2, DelinquentAfterMissed: 3}, nil)
+				f.Clock.EXPECT().Now().Return(time.Date(2000, 12, 1, 0, 0, 0, 0, time.UTC))
+			},
+			want:    false,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -799,6 +1009,9 @@ func TestBillingUseCase_GetUserStatusIsDelinquent(t *testing.T) {
 				DBRepo: f.DBRepo,
 				Clock:  f.Clock,
 			}
+			if f.Tenants != nil {
+				u.Tenants = f.Tenants
+			}
 			tt.mock(f, tt.input)
 
 			got, err := u.GetUserStatusIsDelinquent(tt.input.ctx, tt.input.param)
@@ -847,6 +1060,7 @@ func TestBillingUseCase_GetRepaymentInquiryByLoanReferenceId(t *testing.T) {
 					ReferenceId:       "",
 					UserId:            0,
 					Amount:            1000,
+					Currency:          "IDR",
 					RatePercentage:    0,
 					Status:            1,
 					RepaymentSchedule: "weekly",
@@ -866,7 +1080,7 @@ func TestBillingUseCase_GetRepaymentInquiryByLoanReferenceId(t *testing.T) {
 				LoanStatus:      "active",
 				RepaymentNeeded: []entities.RepaymentNeeded{
 					{
-						Amount:  1000,
+						Amount:  entities.NewMoney(1000, "IDR"),
 						DueDate: time.Date(2000, time.December, 8, 0, 0, 0, 0, time.UTC),
 						IsLate:  false,
 					},
@@ -874,6 +1088,51 @@ func TestBillingUseCase_GetRepaymentInquiryByLoanReferenceId(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "success deferred installment is not late",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DBRepo: mock_usecase.NewMockDBRepository(ctrl),
+					Clock:  mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: "reference",
+			},
+			mock: func(f fields, args input) {
+				f.DBRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), args.param).Return(&entities.Loan{
+					Id:                 1,
+					ReferenceId:        "reference",
+					Amount:             1200,
+					Currency:           "IDR",
+					Status:             entities.LoanStatusActive,
+					RepaymentSchedule:  entities.RepaymentMonthly,
+					Tenor:              12,
+					RepaymentAmount:    100,
+					ScheduleVersion:    2,
+					ScheduleStartAt:    time.Date(2000, 8, 1, 0, 0, 0, 0, time.UTC),
+					InstallmentsOffset: 2,
+					PaidOffset:         200,
+					CreatedAt:          time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+				}, nil)
+				f.DBRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(int(2), nil)
+				f.Clock.EXPECT().Now().Return(time.Date(2000, 6, 15, 0, 0, 0, 0, time.UTC)).Times(2)
+			},
+			want: &entities.RepaymentInquiry{
+				LoanId:          1,
+				LoanReferenceId: "reference",
+				LoanStatus:      "active",
+				RepaymentNeeded: []entities.RepaymentNeeded{
+					{
+						Amount:  entities.NewMoney(100, "IDR"),
+						DueDate: time.Date(2000, time.September, 1, 0, 0, 0, 0, time.UTC),
+						IsLate:  false,
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "status still active",
 			fields: func(ctrl *gomock.Controller) fields {
@@ -892,6 +1151,7 @@ func TestBillingUseCase_GetRepaymentInquiryByLoanReferenceId(t *testing.T) {
 					ReferenceId:       "",
 					UserId:            0,
 					Amount:            1000,
+					Currency:          "IDR",
 					RatePercentage:    0,
 					Status:            1,
 					RepaymentSchedule: "weekly",
@@ -911,7 +1171,7 @@ func TestBillingUseCase_GetRepaymentInquiryByLoanReferenceId(t *testing.T) {
 				LoanStatus:      "active",
 				RepaymentNeeded: []entities.RepaymentNeeded{
 					{
-						Amount:  1000,
+						Amount:  entities.NewMoney(1000, "IDR"),
 						DueDate: time.Date(2000, time.December, 15, 0, 0, 0, 0, time.UTC),
 						IsLate:  false,
 					},
@@ -1046,6 +1306,7 @@ func TestBillingUseCase_MakePayment(t *testing.T) {
 				f.DBRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), args.param.RepaymentReferenceId).Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
 				f.DBRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), args.param.LoanReferenceId).Return(&entities.Loan{
 					Id:                1,
+					TenantId:          2,
 					ReferenceId:       "",
 					UserId:            0,
 					Amount:            2000,
@@ -1064,14 +1325,62 @@ func TestBillingUseCase_MakePayment(t *testing.T) {
 				f.DBRepo.EXPECT().BeginTx(gomock.Any()).Return(tx, nil)
 				f.DBRepo.EXPECT().CreateRepayment(gomock.Any(), gomock.Any(), entities.Repayment{
 					LoanId:      1,
+					TenantId:    2,
 					ReferenceId: "repaymentReference",
 					Amount:      1000,
 				}).Return(int64(1), nil)
+				f.Clock.EXPECT().Now().Return(time.Date(2000, 3, 15, 8, 0, 0, 0, time.Local))
+				f.DBRepo.EXPECT().NextReceiptSequence(gomock.Any(), tx, int64(2), "200003").Return(int64(7), nil)
+				f.DBRepo.EXPECT().CreateReceipt(gomock.Any(), tx, entities.Receipt{
+					TenantId:             2,
+					ReceiptNumber:        "RCP-200003-000007",
+					RepaymentId:          1,
+					RepaymentReferenceId: "repaymentReference",
+					LoanId:               1,
+					InstallmentNumber:    1,
+					Amount:               1000,
+					Principal:            1000,
+					Status:               entities.ReceiptIssued,
+					IssuedAt:             time.Date(2000, 3, 15, 8, 0, 0, 0, time.Local),
+				}).Return(int64(1), nil)
 
 			},
 			want:    1,
 			wantErr: false,
 		},
+		{
+			name: "error currency does not match the loan",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DBRepo: mock_usecase.NewMockDBRepository(ctrl),
+					Clock:  mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.RepaymentRequest{
+					LoanReferenceId:      "reference",
+					RepaymentReferenceId: "repaymentReference",
+					Amount:               1000,
+					Currency:             "usd",
+				},
+			},
+			mock: func(ctrl *gomock.Controller, f fields, args input) {
+				f.DBRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), args.param.RepaymentReferenceId).Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+				f.DBRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), args.param.LoanReferenceId).Return(&entities.Loan{
+					Id:                1,
+					Amount:            2000,
+					Currency:          "IDR",
+					Status:            entities.LoanStatusActive,
+					RepaymentSchedule: "weekly",
+					Tenor:             2,
+					RepaymentAmount:   1000,
+				}, nil)
+				f.DBRepo.EXPECT().SelectTotalRepaymentAmountByLoanId(gomock.Any(), int64(1)).Return(int64(0), nil)
+			},
+			want:    0,
+			wantErr: true,
+		},
 		{
 			name: "error parameter",
 			fields: func(ctrl *gomock.Controller) fields {
@@ -1359,6 +1668,44 @@ func TestBillingUseCase_MakePayment(t *testing.T) {
 			want:    0,
 			wantErr: true,
 		},
+		{
+			name: "error receipt sequence",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					DBRepo: mock_usecase.NewMockDBRepository(ctrl),
+					Clock:  mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.RepaymentRequest{
+					LoanReferenceId:      "reference",
+					RepaymentReferenceId: "repaymentReference",
+					Amount:               1000,
+				},
+			},
+			mock: func(ctrl *gomock.Controller, f fields, args input) {
+				f.DBRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), args.param.RepaymentReferenceId).Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+				f.DBRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), args.param.LoanReferenceId).Return(&entities.Loan{
+					Id:                1,
+					Amount:            2000,
+					Status:            entities.LoanStatusActive,
+					RepaymentSchedule: "weekly",
+					Tenor:             2,
+					RepaymentAmount:   1000,
+				}, nil)
+				f.DBRepo.EXPECT().SelectTotalRepaymentAmountByLoanId(gomock.Any(), int64(1)).Return(int64(1000), nil)
+				tx := mock_domain.NewMockAtomicTransaction(ctrl)
+				tx.EXPECT().Rollback().Return(nil)
+
+				f.DBRepo.EXPECT().BeginTx(gomock.Any()).Return(tx, nil)
+				f.DBRepo.EXPECT().CreateRepayment(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(2), nil)
+				f.Clock.EXPECT().Now().Return(time.Date(2000, 3, 15, 8, 0, 0, 0, time.Local))
+				f.DBRepo.EXPECT().NextReceiptSequence(gomock.Any(), tx, int64(0), "200003").Return(int64(0), errs.NewWithMessage(http.StatusInternalServerError, ""))
+			},
+			want:    0,
+			wantErr: true,
+		},
 		{
 			name: "error commit",
 			fields: func(ctrl *gomock.Controller) fields {
@@ -1401,6 +1748,19 @@ func TestBillingUseCase_MakePayment(t *testing.T) {
 					ReferenceId: "repaymentReference",
 					Amount:      1000,
 				}).Return(int64(1), nil)
+				f.Clock.EXPECT().Now().Return(time.Date(2000, 3, 15, 8, 0, 0, 0, time.Local))
+				f.DBRepo.EXPECT().NextReceiptSequence(gomock.Any(), tx, int64(0), "200003").Return(int64(7), nil)
+				f.DBRepo.EXPECT().CreateReceipt(gomock.Any(), tx, entities.Receipt{
+					ReceiptNumber:        "RCP-200003-000007",
+					RepaymentId:          1,
+					RepaymentReferenceId: "repaymentReference",
+					LoanId:               1,
+					InstallmentNumber:    1,
+					Amount:               1000,
+					Principal:            1000,
+					Status:               entities.ReceiptIssued,
+					IssuedAt:             time.Date(2000, 3, 15, 8, 0, 0, 0, time.Local),
+				}).Return(int64(1), nil)
 
 			},
 			want:    0,
@@ -0,0 +1,147 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func (u *VirtualAccountUseCase) CreateVirtualAccount(ctx context.Context, request entities.VirtualAccountRequest) (*entities.VirtualAccount, error) {
+	var errMessage []string
+
+	if request.LoanReferenceId == "" {
+		errMessage = append(errMessage, "loan reference id can not be empty")
+	}
+	generator, ok := u.Generators[strings.ToUpper(request.BankCode)]
+	if !ok {
+		errMessage = append(errMessage, "bank code is not supported")
+	}
+	if errMessage != nil || len(errMessage) != 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	loan, err := u.VirtualAccountRepo.SelectLoanByReferenceId(ctx, request.LoanReferenceId)
+	if err != nil {
+		return nil, err
+	}
+	if !loan.Status.IsActive() {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loan status has been "+loan.Status.String())
+	}
+
+	virtualAccounts, err := u.VirtualAccountRepo.SelectVirtualAccountByLoanId(ctx, loan.Id)
+	if err != nil && errs.GetHTTPCode(err) != http.StatusNotFound {
+		return nil, err
+	}
+	if virtualAccounts != nil {
+		for _, va := range *virtualAccounts {
+			if va.BankCode == generator.BankCode() && va.Status == entities.VirtualAccountActive {
+				return &va, nil
+			}
+		}
+	}
+
+	number, err := generator.Generate(ctx, *loan)
+	if err != nil {
+		return nil, err
+	}
+
+	virtualAccount := entities.VirtualAccount{
+		LoanId:   loan.Id,
+		BankCode: generator.BankCode(),
+		Number:   number,
+		Status:   entities.VirtualAccountActive,
+	}
+	virtualAccount.Id, err = u.VirtualAccountRepo.CreateVirtualAccount(ctx, nil, virtualAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &virtualAccount, nil
+}
+
+func (u *VirtualAccountUseCase) GetVirtualAccountListByLoanReferenceId(ctx context.Context, referenceId string) (*[]entities.VirtualAccount, error) {
+	if referenceId == "" {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loan reference id can not be empty")
+	}
+
+	loan, err := u.VirtualAccountRepo.SelectLoanByReferenceId(ctx, referenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	virtualAccounts, err := u.VirtualAccountRepo.SelectVirtualAccountByLoanId(ctx, loan.Id)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+		return &[]entities.VirtualAccount{}, nil
+	}
+
+	return virtualAccounts, nil
+}
+
+// HandlePaymentCallback matches a gateway notification to its loan and records
+// it as a repayment made when the gateway received the money, so a callback
+// delivered late does not make the loan look later than it is. Gateways retry
+// notifications, so a callback that was already recorded returns the existing
+// repayment instead of failing.
+func (u *VirtualAccountUseCase) HandlePaymentCallback(ctx context.Context, callback entities.PaymentCallback) (int64, error) {
+	var errMessage []string
+
+	if callback.TransactionId == "" {
+		errMessage = append(errMessage, "transaction id can not be empty")
+	}
+	if callback.VirtualAccountNumber == "" {
+		errMessage = append(errMessage, "virtual account number can not be empty")
+	}
+	if callback.Amount < 1 {
+		errMessage = append(errMessage, "amount is invalid")
+	}
+	if errMessage != nil || len(errMessage) != 0 {
+		return 0, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	virtualAccount, err := u.VirtualAccountRepo.SelectVirtualAccountByNumber(ctx, callback.VirtualAccountNumber)
+	if err != nil {
+		return 0, err
+	}
+	if virtualAccount.Status != entities.VirtualAccountActive {
+		return 0, errs.NewWithMessage(http.StatusBadRequest, "virtual account is "+string(virtualAccount.Status))
+	}
+	if callback.BankCode != "" && !strings.EqualFold(callback.BankCode, virtualAccount.BankCode) {
+		return 0, errs.NewWithMessage(http.StatusBadRequest, "bank code does not match the virtual account")
+	}
+
+	repaymentReferenceId := callback.RepaymentReferenceId(virtualAccount.BankCode)
+	repayment, err := u.VirtualAccountRepo.SelectRepaymentByReferenceId(ctx, repaymentReferenceId)
+	if err == nil {
+		return repayment.Id, nil
+	}
+	if errs.GetHTTPCode(err) != http.StatusNotFound {
+		return 0, err
+	}
+
+	loan, err := u.VirtualAccountRepo.SelectLoanById(ctx, virtualAccount.LoanId)
+	if err != nil {
+		return 0, err
+	}
+
+	// a paid time ahead of our clock is not trusted
+	paidAt := callback.PaidAt
+	if now := u.Clock.Now(); paidAt.IsZero() || paidAt.After(now) {
+		paidAt = now
+	}
+
+	// the loan reference id is only unique within the tenant of the loan
+	return u.Payments.MakePayment(helper.WithTenantId(ctx, loan.TenantId), entities.RepaymentRequest{
+		LoanReferenceId:      loan.ReferenceId,
+		RepaymentReferenceId: repaymentReferenceId,
+		Amount:               callback.Amount,
+		Currency:             callback.Currency,
+		PaidAt:               paidAt,
+	})
+}
@@ -0,0 +1,389 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+func TestVirtualAccountUseCase_CreateVirtualAccount(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.VirtualAccountRequest
+	}
+	type fields struct {
+		VirtualAccountRepo *mock_usecase.MockVirtualAccountRepository
+		Generator          *mock_domain.MockVirtualAccountGenerator
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.VirtualAccount
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					VirtualAccountRepo: mock_usecase.NewMockVirtualAccountRepository(ctrl),
+					Generator:          mock_domain.NewMockVirtualAccountGenerator(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.VirtualAccountRequest{
+					LoanReferenceId: "loan1",
+					BankCode:        "bca",
+				},
+			},
+			mock: func(f fields, args input) {
+				loan := entities.Loan{Id: 1, ReferenceId: "loan1", Status: entities.LoanStatusActive}
+				f.Generator.EXPECT().BankCode().Return("BCA").AnyTimes()
+				f.VirtualAccountRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&loan, nil)
+				f.VirtualAccountRepo.EXPECT().SelectVirtualAccountByLoanId(gomock.Any(), int64(1)).Return(&[]entities.VirtualAccount{}, nil)
+				f.Generator.EXPECT().Generate(gomock.Any(), loan).Return("880800000000013", nil)
+				f.VirtualAccountRepo.EXPECT().CreateVirtualAccount(gomock.Any(), nil, entities.VirtualAccount{
+					LoanId:   1,
+					BankCode: "BCA",
+					Number:   "880800000000013",
+					Status:   entities.VirtualAccountActive,
+				}).Return(int64(5), nil)
+			},
+			want: &entities.VirtualAccount{
+				Id:       5,
+				LoanId:   1,
+				BankCode: "BCA",
+				Number:   "880800000000013",
+				Status:   entities.VirtualAccountActive,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success already exists",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					VirtualAccountRepo: mock_usecase.NewMockVirtualAccountRepository(ctrl),
+					Generator:          mock_domain.NewMockVirtualAccountGenerator(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.VirtualAccountRequest{
+					LoanReferenceId: "loan1",
+					BankCode:        "BCA",
+				},
+			},
+			mock: func(f fields, args input) {
+				f.Generator.EXPECT().BankCode().Return("BCA").AnyTimes()
+				f.VirtualAccountRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&entities.Loan{Id: 1, Status: entities.LoanStatusActive}, nil)
+				f.VirtualAccountRepo.EXPECT().SelectVirtualAccountByLoanId(gomock.Any(), int64(1)).Return(&[]entities.VirtualAccount{
+					{Id: 5, LoanId: 1, BankCode: "BCA", Number: "880800000000013", Status: entities.VirtualAccountActive},
+				}, nil)
+			},
+			want: &entities.VirtualAccount{
+				Id:       5,
+				LoanId:   1,
+				BankCode: "BCA",
+				Number:   "880800000000013",
+				Status:   entities.VirtualAccountActive,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error bank not supported",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					VirtualAccountRepo: mock_usecase.NewMockVirtualAccountRepository(ctrl),
+					Generator:          mock_domain.NewMockVirtualAccountGenerator(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.VirtualAccountRequest{
+					LoanReferenceId: "loan1",
+					BankCode:        "XYZ",
+				},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error loan not active",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					VirtualAccountRepo: mock_usecase.NewMockVirtualAccountRepository(ctrl),
+					Generator:          mock_domain.NewMockVirtualAccountGenerator(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.VirtualAccountRequest{
+					LoanReferenceId: "loan1",
+					BankCode:        "BCA",
+				},
+			},
+			mock: func(f fields, args input) {
+				f.VirtualAccountRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&entities.Loan{Id: 1, Status: entities.LoanStatusCompleted}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := VirtualAccountUseCase{
+				VirtualAccountRepo: f.VirtualAccountRepo,
+				Generators:         map[string]interfaces.VirtualAccountGenerator{"BCA": f.Generator},
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.CreateVirtualAccount(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestVirtualAccountUseCase_HandlePaymentCallback(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.PaymentCallback
+	}
+	type fields struct {
+		VirtualAccountRepo *mock_usecase.MockVirtualAccountRepository
+		Payments           *mock_usecase.MockPaymentMaker
+		Clock              *mock_domain.MockClock
+	}
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	sampleCallback := entities.PaymentCallback{
+		TransactionId:        "trx1",
+		BankCode:             "BCA",
+		VirtualAccountNumber: "880800000000013",
+		Amount:               1000,
+		Currency:             "IDR",
+		PaidAt:               now.Add(-6 * time.Hour),
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					VirtualAccountRepo: mock_usecase.NewMockVirtualAccountRepository(ctrl),
+					Payments:           mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:              mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: sampleCallback,
+			},
+			mock: func(f fields, args input) {
+				f.VirtualAccountRepo.EXPECT().SelectVirtualAccountByNumber(gomock.Any(), "880800000000013").Return(&entities.VirtualAccount{
+					Id: 5, LoanId: 1, BankCode: "BCA", Number: "880800000000013", Status: entities.VirtualAccountActive,
+				}, nil)
+				f.VirtualAccountRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "VA-BCA-trx1").Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+				f.VirtualAccountRepo.EXPECT().SelectLoanById(gomock.Any(), int64(1)).Return(&entities.Loan{Id: 1, ReferenceId: "loan1"}, nil)
+				f.Clock.EXPECT().Now().Return(now)
+				f.Payments.EXPECT().MakePayment(gomock.Any(), entities.RepaymentRequest{
+					LoanReferenceId:      "loan1",
+					RepaymentReferenceId: "VA-BCA-trx1",
+					Amount:               1000,
+					Currency:             "IDR",
+					PaidAt:               now.Add(-6 * time.Hour),
+				}).Return(int64(9), nil)
+			},
+			want:    9,
+			wantErr: false,
+		},
+		{
+			name: "success paid time ahead of the clock is now",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					VirtualAccountRepo: mock_usecase.NewMockVirtualAccountRepository(ctrl),
+					Payments:           mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:              mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx: context.Background(),
+				param: entities.PaymentCallback{
+					TransactionId:        "trx1",
+					BankCode:             "BCA",
+					VirtualAccountNumber: "880800000000013",
+					Amount:               1000,
+					PaidAt:               now.Add(time.Hour),
+				},
+			},
+			mock: func(f fields, args input) {
+				f.VirtualAccountRepo.EXPECT().SelectVirtualAccountByNumber(gomock.Any(), "880800000000013").Return(&entities.VirtualAccount{
+					Id: 5, LoanId: 1, BankCode: "BCA", Number: "880800000000013", Status: entities.VirtualAccountActive,
+				}, nil)
+				f.VirtualAccountRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "VA-BCA-trx1").Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+				f.VirtualAccountRepo.EXPECT().SelectLoanById(gomock.Any(), int64(1)).Return(&entities.Loan{Id: 1, ReferenceId: "loan1"}, nil)
+				f.Clock.EXPECT().Now().Return(now)
+				f.Payments.EXPECT().MakePayment(gomock.Any(), entities.RepaymentRequest{
+					LoanReferenceId:      "loan1",
+					RepaymentReferenceId: "VA-BCA-trx1",
+					Amount:               1000,
+					PaidAt:               now,
+				}).Return(int64(9), nil)
+			},
+			want:    9,
+			wantErr: false,
+		},
+		{
+			name: "success callback already recorded",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					VirtualAccountRepo: mock_usecase.NewMockVirtualAccountRepository(ctrl),
+					Payments:           mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:              mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: sampleCallback,
+			},
+			mock: func(f fields, args input) {
+				f.VirtualAccountRepo.EXPECT().SelectVirtualAccountByNumber(gomock.Any(), "880800000000013").Return(&entities.VirtualAccount{
+					Id: 5, LoanId: 1, BankCode: "BCA", Status: entities.VirtualAccountActive,
+				}, nil)
+				f.VirtualAccountRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "VA-BCA-trx1").Return(&entities.Repayment{Id: 9}, nil)
+			},
+			want:    9,
+			wantErr: false,
+		},
+		{
+			name: "error parameter",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					VirtualAccountRepo: mock_usecase.NewMockVirtualAccountRepository(ctrl),
+					Payments:           mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:              mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.PaymentCallback{},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "error virtual account not found",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					VirtualAccountRepo: mock_usecase.NewMockVirtualAccountRepository(ctrl),
+					Payments:           mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:              mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: sampleCallback,
+			},
+			mock: func(f fields, args input) {
+				f.VirtualAccountRepo.EXPECT().SelectVirtualAccountByNumber(gomock.Any(), "880800000000013").Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "error bank code mismatch",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					VirtualAccountRepo: mock_usecase.NewMockVirtualAccountRepository(ctrl),
+					Payments:           mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:              mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: sampleCallback,
+			},
+			mock: func(f fields, args input) {
+				f.VirtualAccountRepo.EXPECT().SelectVirtualAccountByNumber(gomock.Any(), "880800000000013").Return(&entities.VirtualAccount{
+					Id: 5, LoanId: 1, BankCode: "BNI", Status: entities.VirtualAccountActive,
+				}, nil)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "error make payment",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					VirtualAccountRepo: mock_usecase.NewMockVirtualAccountRepository(ctrl),
+					Payments:           mock_usecase.NewMockPaymentMaker(ctrl),
+					Clock:              mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: sampleCallback,
+			},
+			mock: func(f fields, args input) {
+				f.VirtualAccountRepo.EXPECT().SelectVirtualAccountByNumber(gomock.Any(), "880800000000013").Return(&entities.VirtualAccount{
+					Id: 5, LoanId: 1, BankCode: "BCA", Status: entities.VirtualAccountActive,
+				}, nil)
+				f.VirtualAccountRepo.EXPECT().SelectRepaymentByReferenceId(gomock.Any(), "VA-BCA-trx1").Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+				f.VirtualAccountRepo.EXPECT().SelectLoanById(gomock.Any(), int64(1)).Return(&entities.Loan{Id: 1, ReferenceId: "loan1"}, nil)
+				f.Clock.EXPECT().Now().Return(now)
+				f.Payments.EXPECT().MakePayment(gomock.Any(), gomock.Any()).Return(int64(0), errs.NewWithMessage(http.StatusBadRequest, ""))
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := VirtualAccountUseCase{
+				VirtualAccountRepo: f.VirtualAccountRepo,
+				Payments:           f.Payments,
+				Clock:              f.Clock,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.HandlePaymentCallback(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const (
+	defaultWebhookMaxAttempts = 6
+	defaultWebhookBackoff     = 30 * time.Second
+	webhookDispatchBatchSize  = 100
+)
+
+func (u *WebhookUseCase) RegisterSubscription(ctx context.Context, clientKey string, request entities.WebhookSubscriptionRequest) (*entities.WebhookSubscription, error) {
+	var errMessage []string
+
+	if clientKey == "" {
+		errMessage = append(errMessage, "client key can not be empty")
+	}
+	// events are only sent over https to public hosts, never into the network of the engine
+	if !helper.IsPublicHttpsUrl(request.Url) {
+		errMessage = append(errMessage, "url must be an https url of a public host")
+	}
+	if len(request.EventTypes) == 0 {
+		errMessage = append(errMessage, "event types can not be empty")
+	}
+	for _, eventType := range request.EventTypes {
+		if !entities.IsValidWebhookEventType(eventType) {
+			errMessage = append(errMessage, "event type "+eventType+" is not supported")
+		}
+	}
+	if errMessage != nil || len(errMessage) != 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	secret, err := helper.GenerateRandomString(32)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := entities.WebhookSubscription{
+		ClientKey:  clientKey,
+		Url:        request.Url,
+		EventTypes: request.EventTypes,
+		Secret:     secret,
+		IsActive:   true,
+	}
+	subscription.Id, err = u.WebhookRepo.CreateWebhookSubscription(ctx, nil, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (u *WebhookUseCase) GetSubscriptionList(ctx context.Context, clientKey string) (*[]entities.WebhookSubscription, error) {
+	if clientKey == "" {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "client key can not be empty")
+	}
+
+	subscriptions, err := u.WebhookRepo.SelectWebhookSubscriptionByClientKey(ctx, clientKey)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+		return &[]entities.WebhookSubscription{}, nil
+	}
+
+	// the signing secret is only shown once, at registration
+	for i := range *subscriptions {
+		(*subscriptions)[i].Secret = ""
+	}
+
+	return subscriptions, nil
+}
+
+func (u *WebhookUseCase) DisableSubscription(ctx context.Context, clientKey string, subscriptionId int64) error {
+	subscription, err := u.getClientSubscription(ctx, clientKey, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	return u.WebhookRepo.UpdateWebhookSubscriptionStatus(ctx, nil, subscription.Id, false)
+}
+
+func (u *WebhookUseCase) GetDeliveryList(ctx context.Context, clientKey string, subscriptionId int64) (*[]entities.WebhookDelivery, error) {
+	subscription, err := u.getClientSubscription(ctx, clientKey, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := u.WebhookRepo.SelectWebhookDeliveryBySubscriptionId(ctx, subscription.Id)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+		return &[]entities.WebhookDelivery{}, nil
+	}
+
+	return deliveries, nil
+}
+
+// Publish records a pending delivery for every active subscription of the
+// clients of the tenant owning the loan that listens to the event, so events
+// of the gateway callback, auto-debit, settlement files and scheduled jobs
+// reach them too. Sending happens in DispatchPending.
+func (u *WebhookUseCase) Publish(ctx context.Context, event entities.Event) error {
+	tenantId := event.TenantId
+	if tenantId == 0 {
+		tenantId = entities.DefaultTenantId
+	}
+
+	subscriptions, err := u.WebhookRepo.SelectWebhookSubscriptionByTenantId(ctx, tenantId)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range *subscriptions {
+		if !subscription.IsActive || !subscription.IsSubscribedTo(event.Type) {
+			continue
+		}
+		_, err = u.WebhookRepo.CreateWebhookDelivery(ctx, nil, entities.WebhookDelivery{
+			SubscriptionId: subscription.Id,
+			EventId:        event.Id,
+			EventType:      event.Type,
+			Payload:        string(payload),
+			Status:         entities.WebhookDeliveryPending,
+			NextAttemptAt:  u.Clock.Now(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DispatchPending sends every pending delivery whose next attempt is due. A
+// delivery that can not be sent does not hold back the others, the errors of
+// all of them are returned together. A delivery whose subscription is gone
+// fails right away.
+func (u *WebhookUseCase) DispatchPending(ctx context.Context) error {
+	deliveries, err := u.WebhookRepo.SelectDueWebhookDelivery(ctx, u.Clock.Now(), webhookDispatchBatchSize)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return err
+		}
+		return nil
+	}
+
+	var errList []error
+	for _, delivery := range *deliveries {
+		subscription, err := u.WebhookRepo.SelectWebhookSubscriptionById(ctx, delivery.SubscriptionId)
+		if err != nil {
+			if errs.GetHTTPCode(err) == http.StatusNotFound {
+				delivery.Status = entities.WebhookDeliveryFailed
+				delivery.LastError = "subscription not found"
+				err = u.WebhookRepo.UpdateWebhookDelivery(ctx, nil, delivery)
+			}
+			if err != nil {
+				errList = append(errList, fmt.Errorf("delivery %d: %w", delivery.Id, err))
+			}
+			continue
+		}
+		if _, err = u.deliver(ctx, *subscription, delivery); err != nil {
+			errList = append(errList, fmt.Errorf("delivery %d: %w", delivery.Id, err))
+		}
+	}
+
+	return errors.Join(errList...)
+}
+
+// Redeliver sends a delivery again right away, whatever its current status is.
+func (u *WebhookUseCase) Redeliver(ctx context.Context, clientKey string, deliveryId int64) (*entities.WebhookDelivery, error) {
+	if deliveryId < 1 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "delivery id is invalid")
+	}
+
+	delivery, err := u.WebhookRepo.SelectWebhookDeliveryById(ctx, deliveryId)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := u.getClientSubscription(ctx, clientKey, delivery.SubscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.deliver(ctx, *subscription, *delivery)
+}
+
+func (u *WebhookUseCase) deliver(ctx context.Context, subscription entities.WebhookSubscription, delivery entities.WebhookDelivery) (*entities.WebhookDelivery, error) {
+	responseCode, sendErr := u.send(ctx, subscription, delivery)
+	now := u.Clock.Now()
+
+	delivery.Attempts++
+	delivery.LastResponseCode = responseCode
+	delivery.LastError = ""
+	if sendErr != nil {
+		delivery.LastError = sendErr.Error()
+	}
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = entities.WebhookDeliveryDelivered
+		delivery.DeliveredAt = now
+	case delivery.Attempts >= u.maxAttempts():
+		delivery.Status = entities.WebhookDeliveryFailed
+	default:
+		delivery.Status = entities.WebhookDeliveryPending
+		delivery.NextAttemptAt = now.Add(u.backoff(delivery.Attempts))
+	}
+
+	dbTx, err := u.WebhookRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer dbTx.Rollback()
+
+	_, err = u.WebhookRepo.CreateWebhookDeliveryAttempt(ctx, dbTx, entities.WebhookDeliveryAttempt{
+		DeliveryId:   delivery.Id,
+		Attempt:      delivery.Attempts,
+		ResponseCode: responseCode,
+		Error:        delivery.LastError,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.WebhookRepo.UpdateWebhookDelivery(ctx, dbTx, delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	err = dbTx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (u *WebhookUseCase) send(ctx context.Context, subscription entities.WebhookSubscription, delivery entities.WebhookDelivery) (int, error) {
+	if !subscription.IsActive {
+		return 0, errs.NewWithMessage(http.StatusGone, "subscription is disabled")
+	}
+	// older subscriptions may have been stored with a url that is no longer allowed
+	if !helper.IsPublicHttpsUrl(subscription.Url) {
+		return 0, errs.NewWithMessage(http.StatusBadRequest, "subscription url is not allowed")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(delivery.Id, 10))
+	req.Header.Set("X-Signature", helper.GenerateSignature(subscription.Secret, delivery.Payload))
+
+	resp, err := u.HTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, errs.NewWithMessage(resp.StatusCode, "unexpected response status "+strconv.Itoa(resp.StatusCode))
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (u *WebhookUseCase) getClientSubscription(ctx context.Context, clientKey string, subscriptionId int64) (*entities.WebhookSubscription, error) {
+	if subscriptionId < 1 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "subscription id is invalid")
+	}
+
+	subscription, err := u.WebhookRepo.SelectWebhookSubscriptionById(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.ClientKey != clientKey {
+		return nil, errs.NewWithMessage(http.StatusNotFound, "subscription not found")
+	}
+
+	return subscription, nil
+}
+
+func (u *WebhookUseCase) maxAttempts() int {
+	if u.MaxAttempts < 1 {
+		return defaultWebhookMaxAttempts
+	}
+	return u.MaxAttempts
+}
+
+// backoff doubles the wait after every failed attempt.
+func (u *WebhookUseCase) backoff(attempts int) time.Duration {
+	base := u.BaseBackoff
+	if base <= 0 {
+		base = defaultWebhookBackoff
+	}
+	return base * time.Duration(1<<uint(attempts-1))
+}
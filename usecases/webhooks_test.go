@@ -0,0 +1,604 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+)
+
+func TestWebhookUseCase_RegisterSubscription(t *testing.T) {
+	type input struct {
+		ctx       context.Context
+		clientKey string
+		param     entities.WebhookSubscriptionRequest
+	}
+	type fields struct {
+		WebhookRepo *mock_usecase.MockWebhookRepository
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookRepo: mock_usecase.NewMockWebhookRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:       context.Background(),
+				clientKey: "client1",
+				param: entities.WebhookSubscriptionRequest{
+					Url:        "https://partner.example.com/hooks",
+					EventTypes: []string{entities.EventPaymentReceived},
+				},
+			},
+			mock: func(f fields, args input) {
+				f.WebhookRepo.EXPECT().CreateWebhookSubscription(gomock.Any(), nil, gomock.Any()).Return(int64(1), nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error parameter",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookRepo: mock_usecase.NewMockWebhookRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:       context.Background(),
+				clientKey: "client1",
+				param: entities.WebhookSubscriptionRequest{
+					Url:        "ftp://partner.example.com",
+					EventTypes: []string{"unknown.event"},
+				},
+			},
+			mock: func(f fields, args input) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "error url of a private host",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookRepo: mock_usecase.NewMockWebhookRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:       context.Background(),
+				clientKey: "client1",
+				param: entities.WebhookSubscriptionRequest{
+					Url:        "https://10.0.0.5/hooks",
+					EventTypes: []string{entities.EventPaymentReceived},
+				},
+			},
+			mock: func(f fields, args input) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "error db",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookRepo: mock_usecase.NewMockWebhookRepository(ctrl),
+				}
+			},
+			input: input{
+				ctx:       context.Background(),
+				clientKey: "client1",
+				param: entities.WebhookSubscriptionRequest{
+					Url:        "https://partner.example.com/hooks",
+					EventTypes: []string{entities.EventLoanCreated},
+				},
+			},
+			mock: func(f fields, args input) {
+				f.WebhookRepo.EXPECT().CreateWebhookSubscription(gomock.Any(), nil, gomock.Any()).Return(int64(0), errs.NewWithMessage(http.StatusInternalServerError, ""))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := WebhookUseCase{
+				WebhookRepo: f.WebhookRepo,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.RegisterSubscription(tt.input.ctx, tt.input.clientKey, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, 1, got.Id)
+			assert.EqualValues(t, tt.input.clientKey, got.ClientKey)
+			assert.NotEmpty(t, got.Secret)
+		})
+	}
+}
+
+func TestWebhookUseCase_Publish(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.Event
+	}
+	type fields struct {
+		WebhookRepo *mock_usecase.MockWebhookRepository
+		Clock       *mock_domain.MockClock
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		wantErr bool
+	}{
+		{
+			name: "success only matching subscriptions",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookRepo: mock_usecase.NewMockWebhookRepository(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.Event{Id: "event1", Type: entities.EventPaymentReceived, TenantId: 2},
+			},
+			mock: func(f fields, args input) {
+				f.WebhookRepo.EXPECT().SelectWebhookSubscriptionByTenantId(gomock.Any(), int64(2)).Return(&[]entities.WebhookSubscription{
+					{Id: 1, EventTypes: []string{entities.EventPaymentReceived}, IsActive: true},
+					{Id: 2, EventTypes: []string{entities.EventLoanCreated}, IsActive: true},
+					{Id: 3, EventTypes: []string{entities.EventPaymentReceived}, IsActive: false},
+				}, nil)
+				f.Clock.EXPECT().Now().Return(time.Date(2000, 12, 1, 0, 0, 0, 0, time.UTC))
+				f.WebhookRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), nil, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, tx interface{}, delivery entities.WebhookDelivery) (int64, error) {
+						assert.EqualValues(t, 1, delivery.SubscriptionId)
+						assert.EqualValues(t, entities.WebhookDeliveryPending, delivery.Status)
+						return 1, nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name: "success event without tenant goes to the default tenant",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookRepo: mock_usecase.NewMockWebhookRepository(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.Event{Id: "event1", Type: entities.EventPaymentReceived},
+			},
+			mock: func(f fields, args input) {
+				f.WebhookRepo.EXPECT().SelectWebhookSubscriptionByTenantId(gomock.Any(), entities.DefaultTenantId).Return(&[]entities.WebhookSubscription{}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error select subscription",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookRepo: mock_usecase.NewMockWebhookRepository(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.Event{Id: "event1", Type: entities.EventPaymentReceived, TenantId: 2},
+			},
+			mock: func(f fields, args input) {
+				f.WebhookRepo.EXPECT().SelectWebhookSubscriptionByTenantId(gomock.Any(), int64(2)).Return(nil, errs.NewWithMessage(http.StatusInternalServerError, ""))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := WebhookUseCase{
+				WebhookRepo: f.WebhookRepo,
+				Clock:       f.Clock,
+			}
+			tt.mock(f, tt.input)
+
+			err := u.Publish(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestWebhookUseCase_DispatchPending(t *testing.T) {
+	now := time.Date(2000, 12, 1, 0, 0, 0, 0, time.UTC)
+	subscription := entities.WebhookSubscription{
+		Id:         1,
+		ClientKey:  "client1",
+		Url:        "https://partner.example.com/hooks",
+		EventTypes: []string{entities.EventPaymentReceived},
+		Secret:     "secret",
+		IsActive:   true,
+	}
+	delivery := entities.WebhookDelivery{
+		Id:             10,
+		SubscriptionId: 1,
+		EventType:      entities.EventPaymentReceived,
+		Payload:        `{"id":"event1"}`,
+		Status:         entities.WebhookDeliveryPending,
+		Attempts:       1,
+	}
+
+	type fields struct {
+		WebhookRepo *mock_usecase.MockWebhookRepository
+		HTTPClient  *mock_domain.MockHTTPClient
+		Clock       *mock_domain.MockClock
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		mock    func(ctrl *gomock.Controller, f fields)
+		wantErr bool
+	}{
+		{
+			name: "success delivered",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookRepo: mock_usecase.NewMockWebhookRepository(ctrl),
+					HTTPClient:  mock_domain.NewMockHTTPClient(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+				}
+			},
+			mock: func(ctrl *gomock.Controller, f fields) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.WebhookRepo.EXPECT().SelectDueWebhookDelivery(gomock.Any(), now, webhookDispatchBatchSize).Return(&[]entities.WebhookDelivery{delivery}, nil)
+				f.WebhookRepo.EXPECT().SelectWebhookSubscriptionById(gomock.Any(), int64(1)).Return(&subscription, nil)
+				f.HTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					assert.EqualValues(t, "1d929d47a3f35c174eb4563391cc5ba70c2f889509a0eb4f27652efc214fde4b", req.Header.Get("X-Signature"))
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+				})
+
+				tx := mock_domain.NewMockAtomicTransaction(ctrl)
+				tx.EXPECT().Commit().Return(nil)
+				tx.EXPECT().Rollback().Return(nil)
+				f.WebhookRepo.EXPECT().BeginTx(gomock.Any()).Return(tx, nil)
+				f.WebhookRepo.EXPECT().CreateWebhookDeliveryAttempt(gomock.Any(), tx, entities.WebhookDeliveryAttempt{
+					DeliveryId:   10,
+					Attempt:      2,
+					ResponseCode: http.StatusOK,
+				}).Return(int64(1), nil)
+				f.WebhookRepo.EXPECT().UpdateWebhookDelivery(gomock.Any(), tx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, tx interface{}, d entities.WebhookDelivery) error {
+						assert.EqualValues(t, entities.WebhookDeliveryDelivered, d.Status)
+						assert.EqualValues(t, now, d.DeliveredAt)
+						return nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name: "success retry with backoff",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookRepo: mock_usecase.NewMockWebhookRepository(ctrl),
+					HTTPClient:  mock_domain.NewMockHTTPClient(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+				}
+			},
+			mock: func(ctrl *gomock.Controller, f fields) {
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.WebhookRepo.EXPECT().SelectDueWebhookDelivery(gomock.Any(), now, webhookDispatchBatchSize).Return(&[]entities.WebhookDelivery{delivery}, nil)
+				f.WebhookRepo.EXPECT().SelectWebhookSubscriptionById(gomock.Any(), int64(1)).Return(&subscription, nil)
+				f.HTTPClient.EXPECT().Do(gomock.Any()).Return(nil, errors.New("connection refused"))
+
+				tx := mock_domain.NewMockAtomicTransaction(ctrl)
+				tx.EXPECT().Commit().Return(nil)
+				tx.EXPECT().Rollback().Return(nil)
+				f.WebhookRepo.EXPECT().BeginTx(gomock.Any()).Return(tx, nil)
+				f.WebhookRepo.EXPECT().CreateWebhookDeliveryAttempt(gomock.Any(), tx, gomock.Any()).Return(int64(1), nil)
+				f.WebhookRepo.EXPECT().UpdateWebhookDelivery(gomock.Any(), tx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, tx interface{}, d entities.WebhookDelivery) error {
+						assert.EqualValues(t, entities.WebhookDeliveryPending, d.Status)
+						assert.EqualValues(t, 2, d.Attempts)
+						assert.EqualValues(t, now.Add(2*time.Minute), d.NextAttemptAt)
+						return nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name: "success nothing due",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookRepo: mock_usecase.NewMockWebhookRepository(ctrl),
+					HTTPClient:  mock_domain.NewMockHTTPClient(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+				}
+			},
+			mock: func(ctrl *gomock.Controller, f fields) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.WebhookRepo.EXPECT().SelectDueWebhookDelivery(gomock.Any(), now, webhookDispatchBatchSize).Return(&[]entities.WebhookDelivery{}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success missing subscription fails the delivery and the others are sent",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookRepo: mock_usecase.NewMockWebhookRepository(ctrl),
+					HTTPClient:  mock_domain.NewMockHTTPClient(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+				}
+			},
+			mock: func(ctrl *gomock.Controller, f fields) {
+				orphan := delivery
+				orphan.Id, orphan.SubscriptionId = 11, 2
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.WebhookRepo.EXPECT().SelectDueWebhookDelivery(gomock.Any(), now, webhookDispatchBatchSize).Return(&[]entities.WebhookDelivery{orphan, delivery}, nil)
+				f.WebhookRepo.EXPECT().SelectWebhookSubscriptionById(gomock.Any(), int64(2)).Return(nil, errs.NewWithMessage(http.StatusNotFound, ""))
+				f.WebhookRepo.EXPECT().UpdateWebhookDelivery(gomock.Any(), nil, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, tx interface{}, d entities.WebhookDelivery) error {
+						assert.EqualValues(t, 11, d.Id)
+						assert.EqualValues(t, entities.WebhookDeliveryFailed, d.Status)
+						assert.EqualValues(t, "subscription not found", d.LastError)
+						return nil
+					})
+				f.WebhookRepo.EXPECT().SelectWebhookSubscriptionById(gomock.Any(), int64(1)).Return(&subscription, nil)
+				f.HTTPClient.EXPECT().Do(gomock.Any()).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil)
+
+				tx := mock_domain.NewMockAtomicTransaction(ctrl)
+				tx.EXPECT().Commit().Return(nil)
+				tx.EXPECT().Rollback().Return(nil)
+				f.WebhookRepo.EXPECT().BeginTx(gomock.Any()).Return(tx, nil)
+				f.WebhookRepo.EXPECT().CreateWebhookDeliveryAttempt(gomock.Any(), tx, gomock.Any()).Return(int64(1), nil)
+				f.WebhookRepo.EXPECT().UpdateWebhookDelivery(gomock.Any(), tx, gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success url of a private host is not called",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookRepo: mock_usecase.NewMockWebhookRepository(ctrl),
+					HTTPClient:  mock_domain.NewMockHTTPClient(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+				}
+			},
+			mock: func(ctrl *gomock.Controller, f fields) {
+				private := subscription
+				private.Url = "http://169.254.169.254/latest/meta-data"
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.WebhookRepo.EXPECT().SelectDueWebhookDelivery(gomock.Any(), now, webhookDispatchBatchSize).Return(&[]entities.WebhookDelivery{delivery}, nil)
+				f.WebhookRepo.EXPECT().SelectWebhookSubscriptionById(gomock.Any(), int64(1)).Return(&private, nil)
+
+				tx := mock_domain.NewMockAtomicTransaction(ctrl)
+				tx.EXPECT().Commit().Return(nil)
+				tx.EXPECT().Rollback().Return(nil)
+				f.WebhookRepo.EXPECT().BeginTx(gomock.Any()).Return(tx, nil)
+				f.WebhookRepo.EXPECT().CreateWebhookDeliveryAttempt(gomock.Any(), tx, gomock.Any()).Return(int64(1), nil)
+				f.WebhookRepo.EXPECT().UpdateWebhookDelivery(gomock.Any(), tx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, tx interface{}, d entities.WebhookDelivery) error {
+						assert.EqualValues(t, entities.WebhookDeliveryPending, d.Status)
+						assert.EqualValues(t, "subscription url is not allowed", d.LastError)
+						return nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name: "error one delivery does not stop the others",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookRepo: mock_usecase.NewMockWebhookRepository(ctrl),
+					HTTPClient:  mock_domain.NewMockHTTPClient(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+				}
+			},
+			mock: func(ctrl *gomock.Controller, f fields) {
+				other := delivery
+				other.Id, other.SubscriptionId = 11, 2
+				f.Clock.EXPECT().Now().Return(now).AnyTimes()
+				f.WebhookRepo.EXPECT().SelectDueWebhookDelivery(gomock.Any(), now, webhookDispatchBatchSize).Return(&[]entities.WebhookDelivery{other, delivery}, nil)
+				f.WebhookRepo.EXPECT().SelectWebhookSubscriptionById(gomock.Any(), int64(2)).Return(nil, errs.NewWithMessage(http.StatusInternalServerError, ""))
+				f.WebhookRepo.EXPECT().SelectWebhookSubscriptionById(gomock.Any(), int64(1)).Return(&subscription, nil)
+				f.HTTPClient.EXPECT().Do(gomock.Any()).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil)
+
+				tx := mock_domain.NewMockAtomicTransaction(ctrl)
+				tx.EXPECT().Commit().Return(nil)
+				tx.EXPECT().Rollback().Return(nil)
+				f.WebhookRepo.EXPECT().BeginTx(gomock.Any()).Return(tx, nil)
+				f.WebhookRepo.EXPECT().CreateWebhookDeliveryAttempt(gomock.Any(), tx, gomock.Any()).Return(int64(1), nil)
+				f.WebhookRepo.EXPECT().UpdateWebhookDelivery(gomock.Any(), tx, gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error select due delivery",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookRepo: mock_usecase.NewMockWebhookRepository(ctrl),
+					HTTPClient:  mock_domain.NewMockHTTPClient(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+				}
+			},
+			mock: func(ctrl *gomock.Controller, f fields) {
+				f.Clock.EXPECT().Now().Return(now)
+				f.WebhookRepo.EXPECT().SelectDueWebhookDelivery(gomock.Any(), now, webhookDispatchBatchSize).Return(nil, errs.NewWithMessage(http.StatusInternalServerError, ""))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := WebhookUseCase{
+				WebhookRepo: f.WebhookRepo,
+				HTTPClient:  f.HTTPClient,
+				Clock:       f.Clock,
+				BaseBackoff: time.Minute,
+			}
+			tt.mock(ctrl, f)
+
+			err := u.DispatchPending(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestWebhookUseCase_Redeliver(t *testing.T) {
+	type input struct {
+		ctx        context.Context
+		clientKey  string
+		deliveryId int64
+	}
+	type fields struct {
+		WebhookRepo *mock_usecase.MockWebhookRepository
+		HTTPClient  *mock_domain.MockHTTPClient
+		Clock       *mock_domain.MockClock
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(ctrl *gomock.Controller, f fields, input input)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookRepo: mock_usecase.NewMockWebhookRepository(ctrl),
+					HTTPClient:  mock_domain.NewMockHTTPClient(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:        context.Background(),
+				clientKey:  "client1",
+				deliveryId: 10,
+			},
+			mock: func(ctrl *gomock.Controller, f fields, args input) {
+				f.WebhookRepo.EXPECT().SelectWebhookDeliveryById(gomock.Any(), args.deliveryId).Return(&entities.WebhookDelivery{
+					Id:             10,
+					SubscriptionId: 1,
+					Status:         entities.WebhookDeliveryFailed,
+					Attempts:       6,
+				}, nil)
+				f.WebhookRepo.EXPECT().SelectWebhookSubscriptionById(gomock.Any(), int64(1)).Return(&entities.WebhookSubscription{
+					Id:        1,
+					ClientKey: "client1",
+					Url:       "https://partner.example.com/hooks",
+					IsActive:  true,
+				}, nil)
+				f.HTTPClient.EXPECT().Do(gomock.Any()).Return(&http.Response{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader(""))}, nil)
+				f.Clock.EXPECT().Now().Return(time.Date(2000, 12, 1, 0, 0, 0, 0, time.UTC))
+
+				tx := mock_domain.NewMockAtomicTransaction(ctrl)
+				tx.EXPECT().Commit().Return(nil)
+				tx.EXPECT().Rollback().Return(nil)
+				f.WebhookRepo.EXPECT().BeginTx(gomock.Any()).Return(tx, nil)
+				f.WebhookRepo.EXPECT().CreateWebhookDeliveryAttempt(gomock.Any(), tx, gomock.Any()).Return(int64(1), nil)
+				f.WebhookRepo.EXPECT().UpdateWebhookDelivery(gomock.Any(), tx, gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error other client",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookRepo: mock_usecase.NewMockWebhookRepository(ctrl),
+					HTTPClient:  mock_domain.NewMockHTTPClient(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:        context.Background(),
+				clientKey:  "client2",
+				deliveryId: 10,
+			},
+			mock: func(ctrl *gomock.Controller, f fields, args input) {
+				f.WebhookRepo.EXPECT().SelectWebhookDeliveryById(gomock.Any(), args.deliveryId).Return(&entities.WebhookDelivery{
+					Id:             10,
+					SubscriptionId: 1,
+				}, nil)
+				f.WebhookRepo.EXPECT().SelectWebhookSubscriptionById(gomock.Any(), int64(1)).Return(&entities.WebhookSubscription{
+					Id:        1,
+					ClientKey: "client1",
+				}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error parameter",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WebhookRepo: mock_usecase.NewMockWebhookRepository(ctrl),
+					HTTPClient:  mock_domain.NewMockHTTPClient(ctrl),
+					Clock:       mock_domain.NewMockClock(ctrl),
+				}
+			},
+			input: input{
+				ctx:        context.Background(),
+				clientKey:  "client1",
+				deliveryId: 0,
+			},
+			mock: func(ctrl *gomock.Controller, f fields, args input) {
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := WebhookUseCase{
+				WebhookRepo: f.WebhookRepo,
+				HTTPClient:  f.HTTPClient,
+				Clock:       f.Clock,
+			}
+			tt.mock(ctrl, f, tt.input)
+
+			got, err := u.Redeliver(tt.input.ctx, tt.input.clientKey, tt.input.deliveryId)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, entities.WebhookDeliveryDelivered, got.Status)
+			assert.EqualValues(t, 7, got.Attempts)
+		})
+	}
+}
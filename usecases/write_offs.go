@@ -0,0 +1,305 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+const writeOffBatchSize = 500
+
+func (u *WriteOffUseCase) WriteOffLoan(ctx context.Context, request entities.WriteOffRequest) (*entities.WriteOff, error) {
+	var errMessage []string
+
+	if request.LoanReferenceId == "" {
+		errMessage = append(errMessage, "loan reference id can not be empty")
+	}
+	if strings.TrimSpace(request.Reason) == "" {
+		errMessage = append(errMessage, "reason can not be empty")
+	}
+	if errMessage != nil || len(errMessage) != 0 {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	loan, err := u.WriteOffRepo.SelectLoanByReferenceId(ctx, request.LoanReferenceId)
+	if err != nil {
+		return nil, err
+	}
+	if !loan.Status.IsActive() {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loan status has been "+loan.Status.String())
+	}
+
+	installmentsPaid, err := u.WriteOffRepo.SelectRepaymentCountByLoanId(ctx, loan.Id)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+	}
+
+	today, err := todayOf(ctx, u.Tenants, u.Clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	return u.writeOff(ctx, *loan, daysPastDueOf(*loan, installmentsPaid, today), entities.WriteOffManual, request.Reason)
+}
+
+// WriteOffDelinquentLoans writes off every loan that is at least as many days
+// past due on the business date as the policy allows, unless the borrower has
+// an open promise to pay. Loans written off by an earlier run are no longer
+// active, so a business date can be run again.
+func (u *WriteOffUseCase) WriteOffDelinquentLoans(ctx context.Context, businessDate time.Time) (*entities.WriteOffRunResult, error) {
+	businessDate = helper.TruncateToDay(businessDate)
+	result := &entities.WriteOffRunResult{BusinessDate: businessDate}
+	if u.Policy.DaysPastDue < 1 {
+		return result, nil
+	}
+
+	endOfDay := businessDate.AddDate(0, 0, 1)
+	reason := "more than " + strconv.Itoa(u.Policy.DaysPastDue) + " days past due"
+
+	var afterId int64
+	for {
+		loans, err := u.WriteOffRepo.SelectLoanCreatedBefore(ctx, endOfDay, afterId, writeOffBatchSize)
+		if err != nil {
+			if errs.GetHTTPCode(err) != http.StatusNotFound {
+				return nil, err
+			}
+			return result, nil
+		}
+		if len(*loans) == 0 {
+			return result, nil
+		}
+
+		var activeLoanIds []int64
+		for _, loan := range *loans {
+			if loan.Status.IsActive() {
+				activeLoanIds = append(activeLoanIds, loan.Id)
+			}
+		}
+		repaymentCounts := map[int64]int{}
+		if len(activeLoanIds) != 0 {
+			repaymentCounts, err = u.WriteOffRepo.SelectRepaymentCountByLoanIds(ctx, activeLoanIds, endOfDay)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, loan := range *loans {
+			if !loan.Status.IsActive() {
+				continue
+			}
+			result.Loans++
+
+			daysPastDue := daysPastDueOf(loan, repaymentCounts[loan.Id], businessDate)
+			if daysPastDue < u.Policy.DaysPastDue {
+				continue
+			}
+
+			// an open promise to pay holds the escalation until it is kept or broken
+			_, err = u.WriteOffRepo.SelectOpenPromiseToPayByLoanId(ctx, loan.Id)
+			if err == nil {
+				result.OnPromise++
+				continue
+			}
+			if errs.GetHTTPCode(err) != http.StatusNotFound {
+				return nil, err
+			}
+
+			_, err = u.writeOff(ctx, loan, daysPastDue, entities.WriteOffAutomatic, reason)
+			if err != nil {
+				return nil, err
+			}
+			result.WrittenOff++
+		}
+
+		afterId = (*loans)[len(*loans)-1].Id
+		if len(*loans) < writeOffBatchSize {
+			return result, nil
+		}
+	}
+}
+
+// RecordRecovery books money collected on a written off loan. Recoveries never
+// add up to more than the amount written off.
+func (u *WriteOffUseCase) RecordRecovery(ctx context.Context, request entities.RecoveryRequest) (int64, error) {
+	var errMessage []string
+
+	if request.LoanReferenceId == "" {
+		errMessage = append(errMessage, "loan reference id can not be empty")
+	}
+	if request.RecoveryReferenceId == "" {
+		errMessage = append(errMessage, "recovery reference id can not be empty")
+	}
+	if request.Amount < 1 {
+		errMessage = append(errMessage, "amount is invalid")
+	}
+	if errMessage != nil || len(errMessage) != 0 {
+		return 0, errs.NewWithMessage(http.StatusBadRequest, strings.Join(errMessage, "; "))
+	}
+
+	_, err := u.WriteOffRepo.SelectRecoveryByReferenceId(ctx, request.RecoveryReferenceId)
+	if err == nil {
+		return 0, errs.NewWithMessage(http.StatusBadRequest, "recovery reference id already exists")
+	}
+	if errs.GetHTTPCode(err) != http.StatusNotFound {
+		return 0, err
+	}
+
+	loan, err := u.writtenOffLoan(ctx, request.LoanReferenceId)
+	if err != nil {
+		return 0, err
+	}
+
+	dbTx, err := u.WriteOffRepo.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer dbTx.Rollback()
+
+	// the write-off stays locked until the recovery is recorded, so two
+	// recoveries made at once can not both fit in what remains
+	writeOff, err := u.WriteOffRepo.SelectWriteOffByLoanIdForUpdate(ctx, dbTx, loan.Id)
+	if err != nil {
+		return 0, err
+	}
+	detail, err := u.writeOffDetail(ctx, *writeOff)
+	if err != nil {
+		return 0, err
+	}
+	if request.Amount > detail.RemainingAmount {
+		return 0, errs.NewWithMessage(http.StatusBadRequest,
+			"amount can not be more than the remaining written off amount: "+loan.Money(detail.RemainingAmount).String())
+	}
+
+	id, err := u.WriteOffRepo.CreateRecovery(ctx, dbTx, entities.Recovery{
+		LoanId:      loan.Id,
+		ReferenceId: request.RecoveryReferenceId,
+		Amount:      request.Amount,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	err = dbTx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (u *WriteOffUseCase) GetWriteOff(ctx context.Context, loanReferenceId string) (*entities.WriteOffDetail, error) {
+	loan, err := u.writtenOffLoan(ctx, loanReferenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	writeOff, err := u.WriteOffRepo.SelectWriteOffByLoanId(ctx, loan.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.writeOffDetail(ctx, *writeOff)
+}
+
+func (u *WriteOffUseCase) writtenOffLoan(ctx context.Context, loanReferenceId string) (*entities.Loan, error) {
+	if loanReferenceId == "" {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loan reference id can not be empty")
+	}
+
+	loan, err := u.WriteOffRepo.SelectLoanByReferenceId(ctx, loanReferenceId)
+	if err != nil {
+		return nil, err
+	}
+	if loan.Status != entities.LoanStatusWrittenOff {
+		return nil, errs.NewWithMessage(http.StatusBadRequest, "loan status has been "+loan.Status.String())
+	}
+
+	return loan, nil
+}
+
+// writeOffDetail adds up the recoveries made on the write-off
+func (u *WriteOffUseCase) writeOffDetail(ctx context.Context, writeOff entities.WriteOff) (*entities.WriteOffDetail, error) {
+	recoveries, err := u.WriteOffRepo.SelectRecoveryByLoanId(ctx, writeOff.LoanId)
+	if err != nil {
+		return nil, err
+	}
+
+	var recoveredAmount int64
+	for _, recovery := range *recoveries {
+		recoveredAmount += recovery.Amount
+	}
+
+	return &entities.WriteOffDetail{
+		WriteOff:        writeOff,
+		Recoveries:      *recoveries,
+		RecoveredAmount: recoveredAmount,
+		RemainingAmount: writeOff.Amount - recoveredAmount,
+	}, nil
+}
+
+// writeOff records what is still owed on the loan and takes it out of
+// collection in one transaction
+func (u *WriteOffUseCase) writeOff(ctx context.Context, loan entities.Loan, daysPastDue int, writeOffType entities.WriteOffType, reason string) (*entities.WriteOff, error) {
+	// the job sees every tenant, the loan is only written off within its own
+	ctx = helper.WithTenantId(ctx, loan.TenantId)
+
+	totalRepayments, err := u.WriteOffRepo.SelectTotalRepaymentAmountByLoanId(ctx, loan.Id)
+	if err != nil {
+		if errs.GetHTTPCode(err) != http.StatusNotFound {
+			return nil, err
+		}
+	}
+
+	writeOff := entities.WriteOff{
+		LoanId:          loan.Id,
+		LoanReferenceId: loan.ReferenceId,
+		Amount:          loan.TotalPayable() - totalRepayments,
+		DaysPastDue:     daysPastDue,
+		Type:            writeOffType,
+		Reason:          reason,
+	}
+
+	dbTx, err := u.WriteOffRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer dbTx.Rollback()
+
+	writeOff.Id, err = u.WriteOffRepo.CreateWriteOff(ctx, dbTx, writeOff)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.WriteOffRepo.UpdateLoanStatusByReferenceId(ctx, dbTx, loan.ReferenceId, entities.LoanStatusWrittenOff)
+	if err != nil {
+		return nil, err
+	}
+
+	err = dbTx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &writeOff, nil
+}
+
+// daysPastDueOf counts the days since the first unpaid installment was due,
+// in the timezone of the business date
+func daysPastDueOf(loan entities.Loan, installmentsPaid int, businessDate time.Time) int {
+	if installmentsPaid >= loan.Tenor {
+		return 0
+	}
+	dueDate := loan.DueDate(installmentsPaid + 1).In(businessDate.Location())
+	daysPastDue := helper.DaysBetween(dueDate, businessDate)
+	if daysPastDue < 0 {
+		return 0
+	}
+	return daysPastDue
+}
@@ -0,0 +1,498 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sirait-kevin/BillingEngine/domain/entities"
+	"github.com/sirait-kevin/BillingEngine/domain/interfaces"
+	mock_domain "github.com/sirait-kevin/BillingEngine/mocks/domain"
+	mock_usecase "github.com/sirait-kevin/BillingEngine/mocks/usecases"
+	"github.com/sirait-kevin/BillingEngine/pkg/errs"
+	"github.com/sirait-kevin/BillingEngine/pkg/helper"
+)
+
+func TestWriteOffUseCase_WriteOffLoan(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.WriteOffRequest
+	}
+	type fields struct {
+		WriteOffRepo *mock_usecase.MockWriteOffRepository
+		Clock        *mock_domain.MockClock
+		Tx           *mock_domain.MockAtomicTransaction
+	}
+	loan := &entities.Loan{
+		Id:                1,
+		ReferenceId:       "loan1",
+		Amount:            1200,
+		Status:            entities.LoanStatusActive,
+		RepaymentSchedule: entities.RepaymentMonthly,
+		Tenor:             12,
+		RepaymentAmount:   110,
+		CreatedAt:         time.Date(2024, 1, 1, 9, 0, 0, 0, time.Local),
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    *entities.WriteOff
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffRepo: mock_usecase.NewMockWriteOffRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.WriteOffRequest{LoanReferenceId: "loan1", Reason: "borrower deceased"},
+			},
+			mock: func(f fields, args input) {
+				f.WriteOffRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(loan, nil)
+				f.WriteOffRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(2, nil)
+				f.Clock.EXPECT().Now().Return(time.Date(2024, 5, 1, 10, 0, 0, 0, time.Local))
+				f.WriteOffRepo.EXPECT().SelectTotalRepaymentAmountByLoanId(gomock.Any(), int64(1)).Return(int64(220), nil)
+				f.WriteOffRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.WriteOffRepo.EXPECT().CreateWriteOff(gomock.Any(), f.Tx, entities.WriteOff{
+					LoanId:          1,
+					LoanReferenceId: "loan1",
+					Amount:          1100,
+					DaysPastDue:     30,
+					Type:            entities.WriteOffManual,
+					Reason:          "borrower deceased",
+				}).Return(int64(5), nil)
+				f.WriteOffRepo.EXPECT().UpdateLoanStatusByReferenceId(gomock.Any(), f.Tx, "loan1", entities.LoanStatusWrittenOff).Return(nil)
+				f.Tx.EXPECT().Commit().Return(nil)
+				f.Tx.EXPECT().Rollback().Return(nil)
+			},
+			want: &entities.WriteOff{
+				Id:              5,
+				LoanId:          1,
+				LoanReferenceId: "loan1",
+				Amount:          1100,
+				DaysPastDue:     30,
+				Type:            entities.WriteOffManual,
+				Reason:          "borrower deceased",
+			},
+			wantErr: false,
+		},
+		{
+			name: "error empty reason",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffRepo: mock_usecase.NewMockWriteOffRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.WriteOffRequest{LoanReferenceId: "loan1", Reason: " "},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error loan not active",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffRepo: mock_usecase.NewMockWriteOffRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.WriteOffRequest{LoanReferenceId: "loan1", Reason: "borrower deceased"},
+			},
+			mock: func(f fields, args input) {
+				f.WriteOffRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&entities.Loan{
+					Id:     1,
+					Status: entities.LoanStatusWrittenOff,
+				}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error create write-off",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffRepo: mock_usecase.NewMockWriteOffRepository(ctrl),
+					Clock:        mock_domain.NewMockClock(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.WriteOffRequest{LoanReferenceId: "loan1", Reason: "borrower deceased"},
+			},
+			mock: func(f fields, args input) {
+				f.WriteOffRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(loan, nil)
+				f.WriteOffRepo.EXPECT().SelectRepaymentCountByLoanId(gomock.Any(), int64(1)).Return(0, errs.Wrap(http.StatusNotFound, errors.New("not found")))
+				f.Clock.EXPECT().Now().Return(time.Date(2024, 5, 1, 10, 0, 0, 0, time.Local))
+				f.WriteOffRepo.EXPECT().SelectTotalRepaymentAmountByLoanId(gomock.Any(), int64(1)).Return(int64(0), nil)
+				f.WriteOffRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.WriteOffRepo.EXPECT().CreateWriteOff(gomock.Any(), f.Tx, gomock.Any()).Return(int64(0), errors.New("some error"))
+				f.Tx.EXPECT().Rollback().Return(nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := WriteOffUseCase{
+				WriteOffRepo: f.WriteOffRepo,
+				Clock:        f.Clock,
+				Policy:       entities.DefaultWriteOffPolicy,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.WriteOffLoan(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestWriteOffUseCase_WriteOffDelinquentLoans(t *testing.T) {
+	type input struct {
+		ctx          context.Context
+		businessDate time.Time
+	}
+	type fields struct {
+		WriteOffRepo *mock_usecase.MockWriteOffRepository
+		Tx           *mock_domain.MockAtomicTransaction
+	}
+	businessDate := time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		policy  entities.WriteOffPolicy
+		mock    func(f fields, input input)
+		want    *entities.WriteOffRunResult
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffRepo: mock_usecase.NewMockWriteOffRepository(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:          helper.WithOperator(context.Background()),
+				businessDate: businessDate.Add(6 * time.Hour),
+			},
+			policy: entities.WriteOffPolicy{DaysPastDue: 90},
+			mock: func(f fields, args input) {
+				f.WriteOffRepo.EXPECT().SelectLoanCreatedBefore(gomock.Any(), businessDate.AddDate(0, 0, 1), int64(0), writeOffBatchSize).Return(&[]entities.Loan{
+					{Id: 1, TenantId: 2, ReferenceId: "loan1", Status: entities.LoanStatusActive, RepaymentSchedule: entities.RepaymentMonthly,
+						Tenor: 6, RepaymentAmount: 200, CreatedAt: time.Date(2024, 1, 1, 9, 0, 0, 0, time.Local)},
+					{Id: 2, ReferenceId: "loan2", Status: entities.LoanStatusActive, RepaymentSchedule: entities.RepaymentMonthly,
+						Tenor: 6, RepaymentAmount: 200, CreatedAt: time.Date(2024, 5, 1, 9, 0, 0, 0, time.Local)},
+					{Id: 3, ReferenceId: "loan3", Status: entities.LoanStatusCompleted, RepaymentSchedule: entities.RepaymentMonthly,
+						Tenor: 1, RepaymentAmount: 200, CreatedAt: time.Date(2023, 1, 1, 9, 0, 0, 0, time.Local)},
+					{Id: 4, ReferenceId: "loan4", Status: entities.LoanStatusWrittenOff, RepaymentSchedule: entities.RepaymentMonthly,
+						Tenor: 6, RepaymentAmount: 200, CreatedAt: time.Date(2023, 1, 1, 9, 0, 0, 0, time.Local)},
+				}, nil)
+				f.WriteOffRepo.EXPECT().SelectRepaymentCountByLoanIds(gomock.Any(), []int64{1, 2}, businessDate.AddDate(0, 0, 1)).Return(map[int64]int{}, nil)
+				f.WriteOffRepo.EXPECT().SelectOpenPromiseToPayByLoanId(gomock.Any(), int64(1)).Return(nil, errs.Wrap(http.StatusNotFound, errors.New("not found")))
+				f.WriteOffRepo.EXPECT().SelectTotalRepaymentAmountByLoanId(gomock.Any(), int64(1)).Return(int64(0), errs.Wrap(http.StatusNotFound, errors.New("not found")))
+				f.WriteOffRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.WriteOffRepo.EXPECT().CreateWriteOff(gomock.Any(), f.Tx, entities.WriteOff{
+					LoanId:          1,
+					LoanReferenceId: "loan1",
+					Amount:          1200,
+					DaysPastDue:     121,
+					Type:            entities.WriteOffAutomatic,
+					Reason:          "more than 90 days past due",
+				}).Return(int64(5), nil)
+				// the loan is written off within its own tenant, not in every tenant
+				f.WriteOffRepo.EXPECT().UpdateLoanStatusByReferenceId(gomock.Any(), f.Tx, "loan1", entities.LoanStatusWrittenOff).
+					DoAndReturn(func(ctx context.Context, tx interfaces.AtomicTransaction, referenceId string, status entities.LoanStatus) error {
+						assert.Equal(t, int64(2), helper.GetTenantId(ctx))
+						assert.False(t, helper.IsOperator(ctx))
+						return nil
+					})
+				f.Tx.EXPECT().Commit().Return(nil)
+				f.Tx.EXPECT().Rollback().Return(nil)
+			},
+			want: &entities.WriteOffRunResult{
+				BusinessDate: businessDate,
+				Loans:        2,
+				WrittenOff:   1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success open promise to pay holds the write-off",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffRepo: mock_usecase.NewMockWriteOffRepository(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:          context.Background(),
+				businessDate: businessDate,
+			},
+			policy: entities.WriteOffPolicy{DaysPastDue: 90},
+			mock: func(f fields, args input) {
+				f.WriteOffRepo.EXPECT().SelectLoanCreatedBefore(gomock.Any(), businessDate.AddDate(0, 0, 1), int64(0), writeOffBatchSize).Return(&[]entities.Loan{
+					{Id: 1, ReferenceId: "loan1", Status: entities.LoanStatusActive, RepaymentSchedule: entities.RepaymentMonthly,
+						Tenor: 6, RepaymentAmount: 200, CreatedAt: time.Date(2024, 1, 1, 9, 0, 0, 0, time.Local)},
+				}, nil)
+				f.WriteOffRepo.EXPECT().SelectRepaymentCountByLoanIds(gomock.Any(), []int64{1}, businessDate.AddDate(0, 0, 1)).Return(map[int64]int{}, nil)
+				f.WriteOffRepo.EXPECT().SelectOpenPromiseToPayByLoanId(gomock.Any(), int64(1)).Return(&entities.PromiseToPay{
+					Id:     3,
+					LoanId: 1,
+					Status: entities.PromiseOpen,
+				}, nil)
+			},
+			want: &entities.WriteOffRunResult{
+				BusinessDate: businessDate,
+				Loans:        1,
+				OnPromise:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success automatic write-off turned off",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffRepo: mock_usecase.NewMockWriteOffRepository(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:          context.Background(),
+				businessDate: businessDate,
+			},
+			policy: entities.WriteOffPolicy{},
+			mock: func(f fields, args input) {
+			},
+			want: &entities.WriteOffRunResult{
+				BusinessDate: businessDate,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error select loans",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffRepo: mock_usecase.NewMockWriteOffRepository(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:          context.Background(),
+				businessDate: businessDate,
+			},
+			policy: entities.WriteOffPolicy{DaysPastDue: 90},
+			mock: func(f fields, args input) {
+				f.WriteOffRepo.EXPECT().SelectLoanCreatedBefore(gomock.Any(), gomock.Any(), int64(0), writeOffBatchSize).Return(nil, errors.New("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := WriteOffUseCase{
+				WriteOffRepo: f.WriteOffRepo,
+				Policy:       tt.policy,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.WriteOffDelinquentLoans(tt.input.ctx, tt.input.businessDate)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestWriteOffUseCase_RecordRecovery(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		param entities.RecoveryRequest
+	}
+	type fields struct {
+		WriteOffRepo *mock_usecase.MockWriteOffRepository
+		Tx           *mock_domain.MockAtomicTransaction
+	}
+	notFound := errs.Wrap(http.StatusNotFound, errors.New("not found"))
+	writtenOffLoan := &entities.Loan{Id: 1, ReferenceId: "loan1", Status: entities.LoanStatusWrittenOff}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		input   input
+		mock    func(f fields, input input)
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffRepo: mock_usecase.NewMockWriteOffRepository(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.RecoveryRequest{LoanReferenceId: "loan1", RecoveryReferenceId: "rec2", Amount: 500},
+			},
+			mock: func(f fields, args input) {
+				f.WriteOffRepo.EXPECT().SelectRecoveryByReferenceId(gomock.Any(), "rec2").Return(nil, notFound)
+				f.WriteOffRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(writtenOffLoan, nil)
+				f.Tx.EXPECT().Commit().Return(nil)
+				f.Tx.EXPECT().Rollback().Return(nil)
+				f.WriteOffRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.WriteOffRepo.EXPECT().SelectWriteOffByLoanIdForUpdate(gomock.Any(), f.Tx, int64(1)).Return(&entities.WriteOff{Id: 5, LoanId: 1, Amount: 1100}, nil)
+				f.WriteOffRepo.EXPECT().SelectRecoveryByLoanId(gomock.Any(), int64(1)).Return(&[]entities.Recovery{
+					{Id: 6, LoanId: 1, ReferenceId: "rec1", Amount: 300},
+				}, nil)
+				f.WriteOffRepo.EXPECT().CreateRecovery(gomock.Any(), f.Tx, entities.Recovery{
+					LoanId:      1,
+					ReferenceId: "rec2",
+					Amount:      500,
+				}).Return(int64(7), nil)
+			},
+			want:    7,
+			wantErr: false,
+		},
+		{
+			name: "error amount more than remaining",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffRepo: mock_usecase.NewMockWriteOffRepository(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.RecoveryRequest{LoanReferenceId: "loan1", RecoveryReferenceId: "rec2", Amount: 900},
+			},
+			mock: func(f fields, args input) {
+				f.WriteOffRepo.EXPECT().SelectRecoveryByReferenceId(gomock.Any(), "rec2").Return(nil, notFound)
+				f.WriteOffRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(writtenOffLoan, nil)
+				f.Tx.EXPECT().Rollback().Return(nil)
+				f.WriteOffRepo.EXPECT().BeginTx(gomock.Any()).Return(f.Tx, nil)
+				f.WriteOffRepo.EXPECT().SelectWriteOffByLoanIdForUpdate(gomock.Any(), f.Tx, int64(1)).Return(&entities.WriteOff{Id: 5, LoanId: 1, Amount: 1100}, nil)
+				f.WriteOffRepo.EXPECT().SelectRecoveryByLoanId(gomock.Any(), int64(1)).Return(&[]entities.Recovery{
+					{Id: 6, LoanId: 1, ReferenceId: "rec1", Amount: 300},
+				}, nil)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "error loan not written off",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffRepo: mock_usecase.NewMockWriteOffRepository(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.RecoveryRequest{LoanReferenceId: "loan1", RecoveryReferenceId: "rec2", Amount: 500},
+			},
+			mock: func(f fields, args input) {
+				f.WriteOffRepo.EXPECT().SelectRecoveryByReferenceId(gomock.Any(), "rec2").Return(nil, notFound)
+				f.WriteOffRepo.EXPECT().SelectLoanByReferenceId(gomock.Any(), "loan1").Return(&entities.Loan{
+					Id:     1,
+					Status: entities.LoanStatusActive,
+				}, nil)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "error reference id already exists",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffRepo: mock_usecase.NewMockWriteOffRepository(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.RecoveryRequest{LoanReferenceId: "loan1", RecoveryReferenceId: "rec1", Amount: 500},
+			},
+			mock: func(f fields, args input) {
+				f.WriteOffRepo.EXPECT().SelectRecoveryByReferenceId(gomock.Any(), "rec1").Return(&entities.Recovery{Id: 6}, nil)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "error invalid request",
+			fields: func(ctrl *gomock.Controller) fields {
+				return fields{
+					WriteOffRepo: mock_usecase.NewMockWriteOffRepository(ctrl),
+					Tx:           mock_domain.NewMockAtomicTransaction(ctrl),
+				}
+			},
+			input: input{
+				ctx:   context.Background(),
+				param: entities.RecoveryRequest{LoanReferenceId: "loan1"},
+			},
+			mock: func(f fields, args input) {
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			f := tt.fields(ctrl)
+			u := WriteOffUseCase{
+				WriteOffRepo: f.WriteOffRepo,
+			}
+			tt.mock(f, tt.input)
+
+			got, err := u.RecordRecovery(tt.input.ctx, tt.input.param)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}